@@ -0,0 +1,136 @@
+package beacon
+
+import (
+	"context"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+const (
+	getBlindedBlockPath        = "/eth/v1/beacon/blinded_blocks"
+	getBlockHeadersPath        = "/eth/v1/beacon/headers"
+	getBlockAttestationsV2Path = "/eth/v2/beacon/blocks/{{.Id}}/attestations"
+	publishBlockV2Path         = "/eth/v2/beacon/blocks"
+	publishBlindedBlockV2Path  = "/eth/v2/beacon/blinded_blocks"
+)
+
+var getBlockAttestationsV2Tpl = idTemplate(getBlockAttestationsV2Path)
+
+// BroadcastValidation is the level of validation the beacon node applies to a published block before broadcasting it.
+type BroadcastValidation string
+
+const (
+	BroadcastValidationGossip                   BroadcastValidation = "gossip"
+	BroadcastValidationConsensus                BroadcastValidation = "consensus"
+	BroadcastValidationConsensusAndEquivocation BroadcastValidation = "consensus_and_equivocation"
+)
+
+// GetSignedBlock retrieves the signed block for the given block id, decoded according to its fork version. The block
+// is requested in ssz encoding, and decoded from json if the beacon node does not serve ssz.
+func (c *Client) GetSignedBlock(ctx context.Context, blockId StateOrBlockId) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	resp, err := c.getVersioned(ctx, renderGetBlockPath(blockId))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting block by id = %s", blockId)
+	}
+	return signedBlockFromResponse(resp, false)
+}
+
+// GetBlindedBlock retrieves the signed block for the given block id with the header of its execution payload instead
+// of the payload, decoded according to its fork version.
+func (c *Client) GetBlindedBlock(ctx context.Context, blockId StateOrBlockId) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	resp, err := c.getVersioned(ctx, path.Join(getBlindedBlockPath, string(blockId)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting blinded block by id = %s", blockId)
+	}
+	return signedBlockFromResponse(resp, true)
+}
+
+// GetBlockHeaders retrieves the headers of the canonical blocks at slot, or of the head when slot is nil, and of
+// their children when parentRoot is given.
+func (c *Client) GetBlockHeaders(ctx context.Context, slot *primitives.Slot, parentRoot *[32]byte) ([]*structs.SignedBeaconBlockHeaderContainer, error) {
+	query := map[string][]string{}
+	if slot != nil {
+		query["slot"] = []string{uintString(*slot)}
+	}
+	if parentRoot != nil {
+		query["parent_root"] = []string{string(IdFromRoot(*parentRoot))}
+	}
+	resp := &structs.GetBlockHeadersResponse{}
+	if err := c.getJSON(ctx, getBlockHeadersPath, resp, queryOf(query)); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetBlockAttestations retrieves the attestations included in the block for the given block id, decoded according
+// to the fork version of the block.
+func (c *Client) GetBlockAttestations(ctx context.Context, blockId StateOrBlockId) ([]ethpb.Att, error) {
+	resp, err := c.getVersioned(ctx, getBlockAttestationsV2Tpl(blockId))
+	if err != nil {
+		return nil, err
+	}
+	data, err := resp.data()
+	if err != nil {
+		return nil, err
+	}
+	return attestationsFromJSON(resp.version, data)
+}
+
+// PublishBlock submits a signed block to the beacon node in ssz encoding, which validates it with the given
+// validation level, broadcasts it to the network and imports it. From Deneb, full blocks are published with their
+// blobs and KZG proofs.
+func (c *Client) PublishBlock(ctx context.Context, blk *ethpb.GenericSignedBeaconBlock, validation BroadcastValidation) error {
+	var body []byte
+	var v int
+	var err error
+	blinded := false
+	switch b := blk.Block.(type) {
+	case *ethpb.GenericSignedBeaconBlock_Phase0:
+		body, err = b.Phase0.MarshalSSZ()
+		v = version.Phase0
+	case *ethpb.GenericSignedBeaconBlock_Altair:
+		body, err = b.Altair.MarshalSSZ()
+		v = version.Altair
+	case *ethpb.GenericSignedBeaconBlock_Bellatrix:
+		body, err = b.Bellatrix.MarshalSSZ()
+		v = version.Bellatrix
+	case *ethpb.GenericSignedBeaconBlock_BlindedBellatrix:
+		body, err = b.BlindedBellatrix.MarshalSSZ()
+		v, blinded = version.Bellatrix, true
+	case *ethpb.GenericSignedBeaconBlock_Capella:
+		body, err = b.Capella.MarshalSSZ()
+		v = version.Capella
+	case *ethpb.GenericSignedBeaconBlock_BlindedCapella:
+		body, err = b.BlindedCapella.MarshalSSZ()
+		v, blinded = version.Capella, true
+	case *ethpb.GenericSignedBeaconBlock_Deneb:
+		body, err = b.Deneb.MarshalSSZ()
+		v = version.Deneb
+	case *ethpb.GenericSignedBeaconBlock_BlindedDeneb:
+		body, err = b.BlindedDeneb.MarshalSSZ()
+		v, blinded = version.Deneb, true
+	case *ethpb.GenericSignedBeaconBlock_Electra:
+		body, err = b.Electra.MarshalSSZ()
+		v = version.Electra
+	case *ethpb.GenericSignedBeaconBlock_BlindedElectra:
+		body, err = b.BlindedElectra.MarshalSSZ()
+		v, blinded = version.Electra, true
+	default:
+		return errors.Errorf("unsupported block type %T", blk.Block)
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not marshal block")
+	}
+	p := publishBlockV2Path
+	if blinded {
+		p = publishBlindedBlockV2Path
+	}
+	query := map[string][]string{"broadcast_validation": {string(validation)}}
+	return c.postSSZ(ctx, p, body, v, queryOf(query))
+}
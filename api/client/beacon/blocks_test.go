@@ -0,0 +1,113 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestGetSignedBlock(t *testing.T) {
+	pb := util.NewBeaconBlockCapella()
+	pb.Block.Slot = 42
+	sszBody, err := pb.MarshalSSZ()
+	require.NoError(t, err)
+	jsonBlock, err := structs.SignedBeaconBlockCapellaFromConsensus(pb)
+	require.NoError(t, err)
+	jsonBody, err := json.Marshal(map[string]interface{}{"version": "capella", "data": jsonBlock})
+	require.NoError(t, err)
+
+	serveSSZ := true
+	rt := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "/eth/v2/beacon/blocks/head", req.URL.Path)
+		require.StringContains(t, api.OctetStreamMediaType, req.Header.Get("Accept"))
+		res := &http.Response{Request: req, StatusCode: http.StatusOK, Header: http.Header{}}
+		if serveSSZ {
+			res.Header.Set("Content-Type", api.OctetStreamMediaType)
+			res.Header.Set(api.VersionHeader, "capella")
+			res.Body = io.NopCloser(bytes.NewBuffer(sszBody))
+		} else {
+			res.Header.Set("Content-Type", api.JsonMediaType)
+			res.Body = io.NopCloser(bytes.NewBuffer(jsonBody))
+		}
+		return res, nil
+	}}
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(rt))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for _, ssz := range []bool{true, false} {
+		serveSSZ = ssz
+		blk, err := c.GetSignedBlock(ctx, IdHead)
+		require.NoError(t, err)
+		require.Equal(t, version.Capella, blk.Version())
+		require.Equal(t, pb.Block.Slot, blk.Block().Slot())
+		got, err := blk.Proto()
+		require.NoError(t, err)
+		require.DeepEqual(t, pb, got)
+	}
+}
+
+func TestPublishBlock(t *testing.T) {
+	var gotPath, gotVersion, gotValidation string
+	var gotBody []byte
+	rt := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		gotPath = req.URL.Path
+		gotVersion = req.Header.Get(api.VersionHeader)
+		gotValidation = req.URL.Query().Get("broadcast_validation")
+		require.Equal(t, api.OctetStreamMediaType, req.Header.Get("Content-Type"))
+		var err error
+		gotBody, err = io.ReadAll(req.Body)
+		require.NoError(t, err)
+		return &http.Response{Request: req, StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer(nil))}, nil
+	}}
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(rt))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	full := util.NewBeaconBlockCapella()
+	require.NoError(t, c.PublishBlock(ctx, &ethpb.GenericSignedBeaconBlock{Block: &ethpb.GenericSignedBeaconBlock_Capella{Capella: full}}, BroadcastValidationGossip))
+	require.Equal(t, publishBlockV2Path, gotPath)
+	require.Equal(t, "capella", gotVersion)
+	require.Equal(t, "gossip", gotValidation)
+	want, err := full.MarshalSSZ()
+	require.NoError(t, err)
+	require.DeepEqual(t, want, gotBody)
+
+	blinded := util.NewBlindedBeaconBlockCapella()
+	require.NoError(t, c.PublishBlock(ctx, &ethpb.GenericSignedBeaconBlock{Block: &ethpb.GenericSignedBeaconBlock_BlindedCapella{BlindedCapella: blinded}}, BroadcastValidationConsensus))
+	require.Equal(t, publishBlindedBlockV2Path, gotPath)
+	require.Equal(t, "consensus", gotValidation)
+}
+
+func TestGetPoolAttestations(t *testing.T) {
+	att := util.HydrateAttestationElectra(&ethpb.AttestationElectra{})
+	data, err := json.Marshal([]*structs.AttestationElectra{structs.AttElectraFromConsensus(att)})
+	require.NoError(t, err)
+	body, err := json.Marshal(&structs.ListAttestationsResponse{Version: "electra", Data: data})
+	require.NoError(t, err)
+	rt := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, attestationsPoolV2Path, req.URL.Path)
+		require.Equal(t, "3", req.URL.Query().Get("slot"))
+		require.Equal(t, false, req.URL.Query().Has("committee_index"))
+		return &http.Response{Request: req, StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer(body))}, nil
+	}}
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(rt))
+	require.NoError(t, err)
+
+	slot := att.Data.Slot + 3
+	atts, err := c.GetPoolAttestations(context.Background(), &slot, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(atts))
+	require.DeepEqual(t, att, atts[0])
+}
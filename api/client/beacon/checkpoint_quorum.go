@@ -0,0 +1,290 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrCheckpointQuorumNotReached is returned when not enough checkpoint providers agree on the finalized
+	// block root and state root to safely use the downloaded data for checkpoint sync.
+	ErrCheckpointQuorumNotReached = errors.New("checkpoint sync providers did not reach quorum")
+	errInvalidCheckpointQuorum    = errors.New("invalid checkpoint sync quorum")
+)
+
+// ProviderResult describes the finalized checkpoint reported by a single checkpoint sync provider.
+type ProviderResult struct {
+	Host      string
+	Epoch     primitives.Epoch
+	BlockRoot [32]byte
+	StateRoot [32]byte
+	// Agrees is true when the provider reported the selected checkpoint, or has finalized past it on the same chain.
+	Agrees bool
+	Err    error
+}
+
+func (r ProviderResult) String() string {
+	if r.Err != nil {
+		return fmt.Sprintf("%s: error=%s", r.Host, r.Err)
+	}
+	return fmt.Sprintf("%s: epoch=%d, block_root=%#x, state_root=%#x, agrees=%t", r.Host, r.Epoch, r.BlockRoot, r.StateRoot, r.Agrees)
+}
+
+// QuorumReport summarizes the responses of all checkpoint sync providers that were queried,
+// and how many of them agreed on the selected checkpoint.
+type QuorumReport struct {
+	Quorum    int
+	Agreeing  int
+	Providers []ProviderResult
+}
+
+// String renders the report in a form suitable for logging or returning to the operator.
+func (r *QuorumReport) String() string {
+	lines := make([]string, 0, len(r.Providers)+1)
+	lines = append(lines, fmt.Sprintf("%d of %d providers agree, quorum=%d", r.Agreeing, len(r.Providers), r.Quorum))
+	for _, p := range r.Providers {
+		lines = append(lines, "  "+p.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+type checkpointKey struct {
+	epoch     primitives.Epoch
+	blockRoot [32]byte
+	stateRoot [32]byte
+}
+
+// checkpointAttempts bounds the retries when a provider finalizes a new epoch while it is being queried.
+const checkpointAttempts = 3
+
+// finalizedCheckpoint queries the finalized checkpoint of a provider, and the root of its finalized state.
+// These are fetched by separate requests, so they are retried if the finalized checkpoint moved in between.
+func finalizedCheckpoint(ctx context.Context, c *Client) (checkpointKey, error) {
+	for i := 0; i < checkpointAttempts; i++ {
+		before, err := finalizedEpochRoot(ctx, c)
+		if err != nil {
+			return checkpointKey{}, err
+		}
+		sr, err := c.GetStateRoot(ctx, IdFinalized)
+		if err != nil {
+			return checkpointKey{}, err
+		}
+		after, err := finalizedEpochRoot(ctx, c)
+		if err != nil {
+			return checkpointKey{}, err
+		}
+		if before == after {
+			return checkpointKey{epoch: before.epoch, blockRoot: before.blockRoot, stateRoot: sr}, nil
+		}
+	}
+	return checkpointKey{}, errors.Errorf("finalized checkpoint changed during each of %d attempts to query it", checkpointAttempts)
+}
+
+func finalizedEpochRoot(ctx context.Context, c *Client) (checkpointKey, error) {
+	cps, err := c.GetFinalityCheckpoints(ctx, IdHead)
+	if err != nil {
+		return checkpointKey{}, err
+	}
+	if cps.Finalized == nil {
+		return checkpointKey{}, errors.New("empty finalized checkpoint in finality checkpoints response")
+	}
+	epoch, err := strconv.ParseUint(cps.Finalized.Epoch, 10, 64)
+	if err != nil {
+		return checkpointKey{}, errors.Wrapf(err, "invalid finalized checkpoint epoch %s", cps.Finalized.Epoch)
+	}
+	root, err := hexutil.Decode(cps.Finalized.Root)
+	if err != nil {
+		return checkpointKey{}, errors.Wrapf(err, "invalid finalized checkpoint root %s", cps.Finalized.Root)
+	}
+	return checkpointKey{epoch: primitives.Epoch(epoch), blockRoot: bytesutil.ToBytes32(root)}, nil
+}
+
+// isCanonical determines if the given block root is part of the canonical chain of the provider.
+func isCanonical(ctx context.Context, c *Client, root [32]byte) bool {
+	h, err := c.GetBlockHeader(ctx, IdFromRoot(root))
+	if err != nil {
+		return false
+	}
+	return h.Canonical && h.Root == fmt.Sprintf("%#x", root)
+}
+
+// DownloadFinalizedDataWithQuorum asks every given client for its finalized checkpoint and the root of its finalized
+// state, and selects the most recent checkpoint that at least quorum providers agree on. A quorum value of 0 requires
+// all providers to agree. A provider that has already finalized a later epoch agrees with a checkpoint if the
+// checkpoint block is canonical in its view, so that providers crossing an epoch boundary are not counted against
+// the quorum. The state and block of the selected checkpoint are then downloaded once, from one of the agreeing
+// providers, and verified against the agreed roots.
+// The returned QuorumReport describes every provider's response, and is populated even when an error is returned,
+// so that a disagreement can be reported to the operator.
+func DownloadFinalizedDataWithQuorum(ctx context.Context, clients []*Client, quorum int) (*OriginData, *QuorumReport, error) {
+	if len(clients) == 0 {
+		return nil, nil, errors.Wrap(errInvalidCheckpointQuorum, "no checkpoint sync providers specified")
+	}
+	if quorum == 0 {
+		quorum = len(clients)
+	}
+	if quorum < 0 || quorum > len(clients) {
+		return nil, nil, errors.Wrapf(errInvalidCheckpointQuorum, "quorum=%d must be between 1 and the number of providers (%d)", quorum, len(clients))
+	}
+
+	results := make([]ProviderResult, len(clients))
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		wg.Add(1)
+		go func(i int, c *Client) {
+			defer wg.Done()
+			results[i].Host = c.NodeURL()
+			k, err := finalizedCheckpoint(ctx, c)
+			if err != nil {
+				results[i].Err = err
+				return
+			}
+			results[i].Epoch = k.epoch
+			results[i].BlockRoot = k.blockRoot
+			results[i].StateRoot = k.stateRoot
+		}(i, c)
+	}
+	wg.Wait()
+
+	counts := make(map[checkpointKey]int)
+	first := make(map[checkpointKey]int)
+	for i, r := range results {
+		if r.Err != nil {
+			continue
+		}
+		k := checkpointKey{epoch: r.Epoch, blockRoot: r.BlockRoot, stateRoot: r.StateRoot}
+		if _, ok := first[k]; !ok {
+			first[k] = i
+		}
+		counts[k]++
+	}
+	keys := make([]checkpointKey, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	// prefer the most recent checkpoint, then the one with the most agreement, breaking ties by provider order
+	// so the outcome is deterministic
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].epoch != keys[j].epoch {
+			return keys[i].epoch > keys[j].epoch
+		}
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return first[keys[i]] < first[keys[j]]
+	})
+
+	report := &QuorumReport{Quorum: quorum, Providers: results}
+	if len(keys) == 0 {
+		return nil, report, errors.Wrapf(ErrCheckpointQuorumNotReached, "all providers failed:\n%s", report)
+	}
+	var best checkpointKey
+	var agrees []bool
+	for _, k := range keys {
+		a := agreement(ctx, clients, results, k)
+		n := 0
+		for i := range a {
+			if a[i] {
+				n++
+			}
+		}
+		if agrees == nil || n > report.Agreeing {
+			best, agrees, report.Agreeing = k, a, n
+		}
+		if n >= quorum {
+			break
+		}
+	}
+	for i := range results {
+		results[i].Agrees = agrees[i]
+	}
+	if report.Agreeing < quorum {
+		return nil, report, errors.Wrapf(ErrCheckpointQuorumNotReached, "providers disagree on the finalized checkpoint:\n%s", report)
+	}
+	if len(keys) > 1 {
+		log.WithFields(logrus.Fields{
+			"agreeing":  report.Agreeing,
+			"providers": len(results),
+			"quorum":    quorum,
+		}).Warn("Checkpoint sync providers disagree, using checkpoint reported by quorum")
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			log.WithError(r.Err).WithField("provider", r.Host).Warn("Checkpoint sync provider failed")
+			continue
+		}
+		log.WithFields(logrus.Fields{
+			"provider":  r.Host,
+			"epoch":     r.Epoch,
+			"blockRoot": fmt.Sprintf("%#x", r.BlockRoot),
+			"stateRoot": fmt.Sprintf("%#x", r.StateRoot),
+			"agrees":    r.Agrees,
+		}).Info("Checkpoint sync provider response")
+	}
+
+	od, err := downloadAgreedData(ctx, clients, results, best)
+	if err != nil {
+		return nil, report, err
+	}
+	return od, report, nil
+}
+
+// agreement determines which providers agree with the given checkpoint: those that reported it, and those that
+// finalized a later epoch and consider the checkpoint block canonical.
+func agreement(ctx context.Context, clients []*Client, results []ProviderResult, k checkpointKey) []bool {
+	a := make([]bool, len(results))
+	var wg sync.WaitGroup
+	for i, r := range results {
+		if r.Err != nil {
+			continue
+		}
+		if r.Epoch == k.epoch {
+			a[i] = r.BlockRoot == k.blockRoot && r.StateRoot == k.stateRoot
+			continue
+		}
+		if r.Epoch < k.epoch {
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			a[i] = isCanonical(ctx, clients[i], k.blockRoot)
+		}(i)
+	}
+	wg.Wait()
+	return a
+}
+
+// downloadAgreedData downloads the state and block of the agreed checkpoint from the first agreeing provider that
+// serves data matching the agreed roots. The state is requested by its root, so that it can't change if the provider
+// finalizes another epoch in the meantime.
+func downloadAgreedData(ctx context.Context, clients []*Client, results []ProviderResult, k checkpointKey) (*OriginData, error) {
+	var errs []string
+	for i, r := range results {
+		if !r.Agrees {
+			continue
+		}
+		od, err := downloadOriginData(ctx, clients[i], IdFromRoot(k.stateRoot))
+		if err == nil && (od.sr != k.stateRoot || od.br != k.blockRoot) {
+			err = errors.Wrapf(errCheckpointBlockMismatch, "downloaded state_root=%#x, block_root=%#x", od.sr, od.br)
+		}
+		if err != nil {
+			log.WithError(err).WithField("provider", r.Host).Warn("Failed to download agreed checkpoint sync data")
+			errs = append(errs, fmt.Sprintf("%s: %s", r.Host, err))
+			continue
+		}
+		return od, nil
+	}
+	return nil, errors.Errorf("could not download the agreed checkpoint epoch=%d, block_root=%#x, state_root=%#x from any provider:\n  %s",
+		k.epoch, k.blockRoot, k.stateRoot, strings.Join(errs, "\n  "))
+}
@@ -0,0 +1,201 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	blocktest "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks/testing"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+type finalizedFixture struct {
+	epoch primitives.Epoch
+	br    [32]byte
+	sr    [32]byte
+	slot  primitives.Slot
+	ms    []byte
+	mb    []byte
+	// downloads counts the requests for the full state, across all providers serving this fixture.
+	downloads atomic.Int32
+}
+
+// finalizedDataFixture builds a finalized state and block; different proposer indices produce different
+// (but internally consistent) checkpoints.
+func finalizedDataFixture(t *testing.T, proposer primitives.ValidatorIndex) *finalizedFixture {
+	ctx := context.Background()
+	cfg := params.MainnetConfig().Copy()
+	epoch := cfg.AltairForkEpoch - 1
+	slot, err := slots.EpochStart(epoch)
+	require.NoError(t, err)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	fork, err := forkForEpoch(cfg, epoch)
+	require.NoError(t, err)
+	require.NoError(t, st.SetFork(fork))
+	require.NoError(t, st.SetSlot(slot))
+
+	b, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	b, err = blocktest.SetBlockParentRoot(b, cfg.ZeroHash)
+	require.NoError(t, err)
+	b, err = blocktest.SetBlockSlot(b, slot)
+	require.NoError(t, err)
+	b, err = blocktest.SetProposerIndex(b, proposer)
+	require.NoError(t, err)
+	header, err := b.Header()
+	require.NoError(t, err)
+	require.NoError(t, st.SetLatestBlockHeader(header.Header))
+	sr, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	b, err = blocktest.SetBlockStateRoot(b, sr)
+	require.NoError(t, err)
+	mb, err := b.MarshalSSZ()
+	require.NoError(t, err)
+	br, err := b.Block().HashTreeRoot()
+	require.NoError(t, err)
+	ms, err := st.MarshalSSZ()
+	require.NoError(t, err)
+	return &finalizedFixture{epoch: epoch, br: br, sr: sr, slot: slot, ms: ms, mb: mb}
+}
+
+// rt serves the fixture as the finalized checkpoint of a provider.
+func (f *finalizedFixture) rt(t *testing.T) *testRT {
+	return f.providerRT(t, f.epoch, f.br, f.sr)
+}
+
+// aheadRT serves a provider that has finalized the epoch after the fixture, on the same chain.
+func (f *finalizedFixture) aheadRT(t *testing.T) *testRT {
+	return f.providerRT(t, f.epoch+1, [32]byte{'n', 'e', 'x', 't'}, [32]byte{'n', 'e', 'x', 't'})
+}
+
+func (f *finalizedFixture) providerRT(t *testing.T, epoch primitives.Epoch, br, sr [32]byte) *testRT {
+	jsonResponse := func(v interface{}) io.ReadCloser {
+		b, err := json.Marshal(v)
+		require.NoError(t, err)
+		return io.NopCloser(bytes.NewBuffer(b))
+	}
+	return &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		res := &http.Response{Request: req, StatusCode: http.StatusOK}
+		switch req.URL.Path {
+		case getFinalityCheckpointsTpl(IdHead):
+			res.Body = jsonResponse(&structs.GetFinalityCheckpointsResponse{Data: &structs.FinalityCheckpoints{
+				Finalized: &structs.Checkpoint{Epoch: fmt.Sprintf("%d", epoch), Root: fmt.Sprintf("%#x", br)},
+			}})
+		case getStateRootTpl(IdFinalized):
+			res.Body = jsonResponse(&structs.GetStateRootResponse{Data: &structs.StateRoot{Root: fmt.Sprintf("%#x", sr)}})
+		case getBlockHeaderTpl(IdFromRoot(f.br)):
+			res.Body = jsonResponse(&structs.GetBlockHeaderResponse{Data: &structs.SignedBeaconBlockHeaderContainer{
+				Root: fmt.Sprintf("%#x", f.br), Canonical: true,
+				Header: &structs.SignedBeaconBlockHeader{Message: &structs.BeaconBlockHeader{Slot: fmt.Sprintf("%d", f.slot)}},
+			}})
+		case renderGetStatePath(IdFromRoot(f.sr)):
+			f.downloads.Add(1)
+			res.Body = io.NopCloser(bytes.NewBuffer(f.ms))
+		case renderGetBlockPath(IdFromSlot(f.slot)):
+			res.Body = io.NopCloser(bytes.NewBuffer(f.mb))
+		default:
+			res.StatusCode = http.StatusNotFound
+			res.Body = io.NopCloser(bytes.NewBufferString(""))
+		}
+		return res, nil
+	}}
+}
+
+func failingRT() *testRT {
+	return &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}}
+}
+
+func quorumClients(t *testing.T, rts ...*testRT) []*Client {
+	clients := make([]*Client, len(rts))
+	for i, rt := range rts {
+		c, err := NewClient(fmt.Sprintf("http://provider-%d:3500", i), client.WithRoundTripper(rt))
+		require.NoError(t, err)
+		clients[i] = c
+	}
+	return clients
+}
+
+func TestDownloadFinalizedDataWithQuorum(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all agree", func(t *testing.T) {
+		honest := finalizedDataFixture(t, 0)
+		clients := quorumClients(t, honest.rt(t), honest.rt(t), honest.rt(t))
+		od, report, err := DownloadFinalizedDataWithQuorum(ctx, clients, 0)
+		require.NoError(t, err)
+		require.Equal(t, honest.br, od.br)
+		require.Equal(t, honest.sr, od.sr)
+		require.Equal(t, 3, report.Agreeing)
+		require.Equal(t, 3, report.Quorum)
+		// the state is only downloaded once, after the providers agreed on its root
+		require.Equal(t, int32(1), honest.downloads.Load())
+	})
+	t.Run("quorum reached despite dissent", func(t *testing.T) {
+		honest, evil := finalizedDataFixture(t, 0), finalizedDataFixture(t, 1)
+		require.NotEqual(t, honest.br, evil.br)
+		clients := quorumClients(t, evil.rt(t), honest.rt(t), honest.rt(t))
+		od, report, err := DownloadFinalizedDataWithQuorum(ctx, clients, 2)
+		require.NoError(t, err)
+		require.Equal(t, honest.br, od.br)
+		require.Equal(t, 2, report.Agreeing)
+		require.Equal(t, false, report.Providers[0].Agrees)
+		require.Equal(t, int32(0), evil.downloads.Load())
+	})
+	t.Run("quorum reached despite failure", func(t *testing.T) {
+		honest := finalizedDataFixture(t, 0)
+		clients := quorumClients(t, honest.rt(t), failingRT(), honest.rt(t))
+		od, report, err := DownloadFinalizedDataWithQuorum(ctx, clients, 2)
+		require.NoError(t, err)
+		require.Equal(t, honest.br, od.br)
+		require.NotNil(t, report.Providers[1].Err)
+	})
+	t.Run("provider crossed epoch boundary", func(t *testing.T) {
+		honest := finalizedDataFixture(t, 0)
+		clients := quorumClients(t, honest.aheadRT(t), honest.rt(t), honest.rt(t))
+		od, report, err := DownloadFinalizedDataWithQuorum(ctx, clients, 0)
+		require.NoError(t, err)
+		require.Equal(t, honest.br, od.br)
+		require.Equal(t, 3, report.Agreeing)
+		require.Equal(t, honest.epoch+1, report.Providers[0].Epoch)
+		require.Equal(t, true, report.Providers[0].Agrees)
+	})
+	t.Run("disagreement", func(t *testing.T) {
+		honest, evil := finalizedDataFixture(t, 0), finalizedDataFixture(t, 1)
+		clients := quorumClients(t, evil.rt(t), honest.rt(t))
+		_, report, err := DownloadFinalizedDataWithQuorum(ctx, clients, 0)
+		require.ErrorIs(t, err, ErrCheckpointQuorumNotReached)
+		require.Equal(t, 1, report.Agreeing)
+		require.StringContains(t, fmt.Sprintf("%#x", evil.br), err.Error())
+		require.StringContains(t, fmt.Sprintf("%#x", honest.br), err.Error())
+		require.Equal(t, int32(0), honest.downloads.Load()+evil.downloads.Load())
+	})
+	t.Run("all failed", func(t *testing.T) {
+		clients := quorumClients(t, failingRT(), failingRT())
+		_, _, err := DownloadFinalizedDataWithQuorum(ctx, clients, 1)
+		require.ErrorIs(t, err, ErrCheckpointQuorumNotReached)
+	})
+	t.Run("invalid quorum", func(t *testing.T) {
+		honest := finalizedDataFixture(t, 0)
+		clients := quorumClients(t, honest.rt(t))
+		_, _, err := DownloadFinalizedDataWithQuorum(ctx, clients, 2)
+		require.ErrorIs(t, err, errInvalidCheckpointQuorum)
+		_, _, err = DownloadFinalizedDataWithQuorum(ctx, nil, 0)
+		require.ErrorIs(t, err, errInvalidCheckpointQuorum)
+	})
+}
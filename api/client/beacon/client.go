@@ -15,13 +15,16 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/client"
 	"github.com/prysmaticlabs/prysm/v5/api/server"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/network/forks"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/sirupsen/logrus"
 )
 
@@ -35,6 +38,14 @@ const (
 	getStatePath             = "/eth/v2/debug/beacon/states"
 	getNodeVersionPath       = "/eth/v1/node/version"
 	changeBLStoExecutionPath = "/eth/v1/beacon/pool/bls_to_execution_changes"
+	getBlobSidecarsPath      = "/eth/v1/beacon/blob_sidecars"
+	getGenesisPath           = "/eth/v1/beacon/genesis"
+	getSyncStatusPath        = "/eth/v1/node/syncing"
+	getBlockHeaderPath       = "/eth/v1/beacon/headers/{{.Id}}"
+	getCommitteesPath        = "/eth/v1/beacon/states/{{.Id}}/committees"
+	attesterSlashingsPath    = "/eth/v1/beacon/pool/attester_slashings"
+	attesterSlashingsV2Path  = "/eth/v2/beacon/pool/attester_slashings"
+	proposerSlashingsPath    = "/eth/v1/beacon/pool/proposer_slashings"
 )
 
 // StateOrBlockId represents the block_id / state_id parameters that several of the Eth Beacon API methods accept.
@@ -112,6 +123,32 @@ func (c *Client) GetBlock(ctx context.Context, blockId StateOrBlockId) ([]byte,
 	return b, nil
 }
 
+var errMalformedBlobSidecars = errors.New("malformed blob sidecars response")
+
+// GetBlobSidecars retrieves the BlobSidecars for the given block id.
+// Block identifier can be one of: "head" (canonical head in node's view), "genesis", "finalized",
+// <slot>, <hex encoded blockRoot with 0x prefix>. Variables of type StateOrBlockId are exported by this package
+// for the named identifiers.
+// The sidecars are requested in ssz encoding and returned in the order served by the beacon node.
+func (c *Client) GetBlobSidecars(ctx context.Context, blockId StateOrBlockId) ([]*ethpb.BlobSidecar, error) {
+	b, err := c.Get(ctx, path.Join(getBlobSidecarsPath, string(blockId)), client.WithSSZEncoding())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting blob sidecars by id = %s", blockId)
+	}
+	if len(b)%fieldparams.BlobSidecarSize != 0 {
+		return nil, errors.Wrapf(errMalformedBlobSidecars, "response size %d is not a multiple of sidecar size %d", len(b), fieldparams.BlobSidecarSize)
+	}
+	sidecars := make([]*ethpb.BlobSidecar, len(b)/fieldparams.BlobSidecarSize)
+	for i := range sidecars {
+		sc := &ethpb.BlobSidecar{}
+		if err := sc.UnmarshalSSZ(b[i*fieldparams.BlobSidecarSize : (i+1)*fieldparams.BlobSidecarSize]); err != nil {
+			return nil, errors.Wrapf(err, "error unmarshaling blob sidecar %d for id = %s", i, blockId)
+		}
+		sidecars[i] = sc
+	}
+	return sidecars, nil
+}
+
 var getBlockRootTpl = idTemplate(getBlockRootPath)
 
 // GetBlockRoot retrieves the hash_tree_root of the BeaconBlock for the given block id.
@@ -334,6 +371,96 @@ func (c *Client) GetBLStoExecutionChanges(ctx context.Context) (*structs.BLSToEx
 	return poolResponse, nil
 }
 
+// GetGenesis retrieves the genesis time, genesis validators root and genesis fork version of the chain.
+func (c *Client) GetGenesis(ctx context.Context) (*structs.Genesis, error) {
+	body, err := c.Get(ctx, getGenesisPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting genesis")
+	}
+	resp := &structs.GetGenesisResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetGenesis")
+	}
+	if resp.Data == nil {
+		return nil, errors.New("empty genesis response")
+	}
+	return resp.Data, nil
+}
+
+// GetSyncStatus retrieves the head slot and the sync status of the beacon node.
+func (c *Client) GetSyncStatus(ctx context.Context) (*structs.SyncStatusResponseData, error) {
+	body, err := c.Get(ctx, getSyncStatusPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting sync status")
+	}
+	resp := &structs.SyncStatusResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetSyncStatus")
+	}
+	if resp.Data == nil {
+		return nil, errors.New("empty sync status response")
+	}
+	return resp.Data, nil
+}
+
+var getBlockHeaderTpl = idTemplate(getBlockHeaderPath)
+
+// GetBlockHeader retrieves the signed header of the block for the given block id.
+// Block identifier can be one of: "head" (canonical head in node's view), "genesis", "finalized",
+// <slot>, <hex encoded blockRoot with 0x prefix>. Variables of type StateOrBlockId are exported by this package
+// for the named identifiers.
+func (c *Client) GetBlockHeader(ctx context.Context, blockId StateOrBlockId) (*structs.SignedBeaconBlockHeaderContainer, error) {
+	body, err := c.Get(ctx, getBlockHeaderTpl(blockId))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting block header by id = %s", blockId)
+	}
+	resp := &structs.GetBlockHeaderResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetBlockHeader")
+	}
+	if resp.Data == nil || resp.Data.Header == nil || resp.Data.Header.Message == nil {
+		return nil, errors.Errorf("empty block header response for id = %s", blockId)
+	}
+	return resp.Data, nil
+}
+
+var getCommitteesTpl = idTemplate(getCommitteesPath)
+
+// GetCommittees retrieves all the beacon committees of the given epoch, as computed from the state identified
+// by stateId.
+func (c *Client) GetCommittees(ctx context.Context, stateId StateOrBlockId, epoch primitives.Epoch) ([]*structs.Committee, error) {
+	query := url.Values{"epoch": []string{strconv.FormatUint(uint64(epoch), 10)}}
+	body, err := c.Get(ctx, getCommitteesTpl(stateId), client.WithQuery(query))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting committees of epoch %d by state id = %s", epoch, stateId)
+	}
+	resp := &structs.GetCommitteesResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetCommittees")
+	}
+	return resp.Data, nil
+}
+
+// SubmitAttesterSlashing submits an attester slashing to the operations pool of the beacon node, which
+// verifies it and broadcasts it to the network.
+func (c *Client) SubmitAttesterSlashing(ctx context.Context, slashing ethpb.AttSlashing) error {
+	switch s := slashing.(type) {
+	case *ethpb.AttesterSlashing:
+		return c.postJSON(ctx, attesterSlashingsPath, structs.AttesterSlashingFromConsensus(s), nil)
+	case *ethpb.AttesterSlashingElectra:
+		return c.postJSON(ctx, attesterSlashingsV2Path, structs.AttesterSlashingElectraFromConsensus(s), nil,
+			client.WithHeader(api.VersionHeader, version.String(s.Version())))
+	default:
+		return errors.Errorf("unsupported attester slashing type %T", slashing)
+	}
+}
+
+// SubmitProposerSlashing submits a proposer slashing to the operations pool of the beacon node, which
+// verifies it and broadcasts it to the network.
+func (c *Client) SubmitProposerSlashing(ctx context.Context, slashing *ethpb.ProposerSlashing) error {
+	return c.postJSON(ctx, proposerSlashingsPath, structs.ProposerSlashingFromConsensus(slashing), nil)
+}
+
 type forkScheduleResponse struct {
 	Data []structs.Fork
 }
@@ -1,11 +1,19 @@
 package beacon
 
 import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
 	"net/url"
+	"path"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/client"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
 )
 
 func TestParseNodeVersion(t *testing.T) {
@@ -137,3 +145,91 @@ func TestValidHostname(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBlobSidecars(t *testing.T) {
+	ctx := context.Background()
+	_, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 2)
+	var body []byte
+	for i := range sidecars {
+		b, err := sidecars[i].MarshalSSZ()
+		require.NoError(t, err)
+		body = append(body, b...)
+	}
+	root := sidecars[0].BlockRoot()
+	rt := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		res := &http.Response{Request: req, StatusCode: http.StatusOK}
+		switch req.URL.Path {
+		case path.Join(getBlobSidecarsPath, string(IdFromRoot(root))):
+			res.Body = io.NopCloser(bytes.NewBuffer(body))
+		default:
+			res.Body = io.NopCloser(bytes.NewBuffer(body[1:]))
+		}
+		return res, nil
+	}}
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(rt))
+	require.NoError(t, err)
+
+	got, err := c.GetBlobSidecars(ctx, IdFromRoot(root))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(got))
+	for i := range got {
+		require.DeepEqual(t, sidecars[i].BlobSidecar, got[i])
+	}
+
+	_, err = c.GetBlobSidecars(ctx, IdHead)
+	require.ErrorIs(t, err, errMalformedBlobSidecars)
+}
+
+func TestGetCommittees(t *testing.T) {
+	rt := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "/eth/v1/beacon/states/head/committees", req.URL.Path)
+		require.Equal(t, "3", req.URL.Query().Get("epoch"))
+		body := `{"data":[{"index":"1","slot":"96","validators":["4","2"]}]}`
+		return &http.Response{Request: req, StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+	}}
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(rt))
+	require.NoError(t, err)
+
+	committees, err := c.GetCommittees(context.Background(), IdHead, 3)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(committees))
+	require.Equal(t, "96", committees[0].Slot)
+	require.DeepEqual(t, []string{"4", "2"}, committees[0].Validators)
+}
+
+func TestSubmitAttesterSlashing(t *testing.T) {
+	var gotPath, gotVersion string
+	status := http.StatusOK
+	rt := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		gotPath = req.URL.Path
+		gotVersion = req.Header.Get(api.VersionHeader)
+		return &http.Response{Request: req, StatusCode: status, Body: io.NopCloser(bytes.NewBuffer(nil))}, nil
+	}}
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(rt))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, c.SubmitAttesterSlashing(ctx, &ethpb.AttesterSlashing{
+		Attestation_1: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{}),
+		Attestation_2: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{}),
+	}))
+	require.Equal(t, attesterSlashingsPath, gotPath)
+	require.Equal(t, "", gotVersion)
+
+	electra := &ethpb.AttesterSlashingElectra{
+		Attestation_1: &ethpb.IndexedAttestationElectra{
+			Data:      util.HydrateAttestationData(&ethpb.AttestationData{}),
+			Signature: make([]byte, 96),
+		},
+		Attestation_2: &ethpb.IndexedAttestationElectra{
+			Data:      util.HydrateAttestationData(&ethpb.AttestationData{}),
+			Signature: make([]byte, 96),
+		},
+	}
+	require.NoError(t, c.SubmitAttesterSlashing(ctx, electra))
+	require.Equal(t, attesterSlashingsV2Path, gotPath)
+	require.Equal(t, "electra", gotVersion)
+
+	status = http.StatusBadRequest
+	require.ErrorIs(t, c.SubmitAttesterSlashing(ctx, electra), client.ErrNotOK)
+}
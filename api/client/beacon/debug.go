@@ -0,0 +1,92 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+)
+
+const (
+	getDepositContractPath = "/eth/v1/config/deposit_contract"
+	getDepositSnapshotPath = "/eth/v1/beacon/deposit_snapshot"
+	getForkChoiceHeadsPath = "/eth/v2/debug/beacon/heads"
+	getForkChoiceDumpPath  = "/eth/v1/debug/fork_choice"
+	getStateDiffPath       = "/prysm/v1/debug/states/diff"
+)
+
+// GetDepositContract retrieves the chain id and the address of the deposit contract the beacon node is configured
+// with.
+func (c *Client) GetDepositContract(ctx context.Context) (*structs.DepositContractData, error) {
+	resp := &structs.GetDepositContractResponse{}
+	if err := c.getJSON(ctx, getDepositContractPath, resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("empty deposit contract response")
+	}
+	return resp.Data, nil
+}
+
+// GetDepositSnapshot retrieves the EIP-4881 snapshot of the deposit tree at the latest finalized deposit.
+func (c *Client) GetDepositSnapshot(ctx context.Context) (*structs.DepositSnapshot, error) {
+	resp := &structs.GetDepositSnapshotResponse{}
+	if err := c.getJSON(ctx, getDepositSnapshotPath, resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("empty deposit snapshot response")
+	}
+	return resp.Data, nil
+}
+
+// GetBeaconState retrieves the BeaconState for the given state id, decoded according to its fork version.
+func (c *Client) GetBeaconState(ctx context.Context, stateId StateOrBlockId) (state.BeaconState, error) {
+	b, err := c.GetState(ctx, stateId)
+	if err != nil {
+		return nil, err
+	}
+	vu, err := detect.FromState(b)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error detecting chain config for state by id = %s", stateId)
+	}
+	st, err := vu.UnmarshalBeaconState(b)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling state by id = %s", stateId)
+	}
+	return st, nil
+}
+
+// GetForkChoiceHeads retrieves the leaves of the fork choice tree of the beacon node.
+func (c *Client) GetForkChoiceHeads(ctx context.Context) ([]*structs.ForkChoiceHead, error) {
+	resp := &structs.GetForkChoiceHeadsV2Response{}
+	if err := c.getJSON(ctx, getForkChoiceHeadsPath, resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetForkChoiceDump retrieves all the nodes of the fork choice store of the beacon node, along with its checkpoints.
+func (c *Client) GetForkChoiceDump(ctx context.Context) (*structs.GetForkChoiceDumpResponse, error) {
+	resp := &structs.GetForkChoiceDumpResponse{}
+	if err := c.getJSON(ctx, getForkChoiceDumpPath, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetStateDiff retrieves the field level difference between the states for the given state ids. This is a prysm
+// specific endpoint.
+func (c *Client) GetStateDiff(ctx context.Context, from, to StateOrBlockId) (*structs.StateDiff, error) {
+	query := queryOf(map[string][]string{"from": {string(from)}, "to": {string(to)}})
+	resp := &structs.GetStateDiffResponse{}
+	if err := c.getJSON(ctx, getStateDiffPath, resp, query); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("empty state diff response")
+	}
+	return resp.Data, nil
+}
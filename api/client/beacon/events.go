@@ -0,0 +1,64 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/event"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+)
+
+// SubscribeEvents subscribes to the given topics of the event stream of the beacon node and returns the channel on
+// which the events are delivered. The subscription lasts until ctx is canceled, in which case the channel is closed,
+// or until the connection fails, which is signaled by an event of type event.EventConnectionError.
+func (c *Client) SubscribeEvents(ctx context.Context, topics []string) (<-chan *event.Event, error) {
+	stream, err := event.NewEventStream(ctx, c.HTTPClient(), c.NodeURL(), topics)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create event stream")
+	}
+	ch := make(chan *event.Event, 1)
+	go stream.Subscribe(ch)
+	return ch, nil
+}
+
+// DecodeEvent decodes the data of an event received from the event stream into the structs type of its topic. The
+// data of topics whose type depends on the fork version, such as attestations and attester slashings, is returned
+// as a json.RawMessage.
+func DecodeEvent(e *event.Event) (interface{}, error) {
+	var v interface{}
+	switch e.EventType {
+	case event.EventHead:
+		v = &structs.HeadEvent{}
+	case event.EventBlock:
+		v = &structs.BlockEvent{}
+	case event.EventFinalizedCheckpoint:
+		v = &structs.FinalizedCheckpointEvent{}
+	case event.EventChainReorg:
+		v = &structs.ChainReorgEvent{}
+	case event.EventVoluntaryExit:
+		v = &structs.SignedVoluntaryExit{}
+	case event.EventBlsToExecutionChange:
+		v = &structs.SignedBLSToExecutionChange{}
+	case event.EventProposerSlashing:
+		v = &structs.ProposerSlashing{}
+	case event.EventContributionAndProof:
+		v = &structs.SignedContributionAndProof{}
+	case event.EventPayloadAttributes:
+		v = &structs.PayloadAttributesEvent{}
+	case event.EventBlobSidecar:
+		v = &structs.BlobSidecarEvent{}
+	case event.EventLightClientFinalityUpdate:
+		v = &structs.LightClientFinalityUpdateEvent{}
+	case event.EventLightClientOptimisticUpdate:
+		v = &structs.LightClientOptimisticUpdateEvent{}
+	case event.EventError, event.EventConnectionError:
+		return nil, errors.Errorf("%s: %s", e.EventType, string(e.Data))
+	default:
+		return json.RawMessage(e.Data), nil
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return nil, errors.Wrapf(err, "could not decode %s event", e.EventType)
+	}
+	return v, nil
+}
@@ -0,0 +1,29 @@
+package beacon
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/client/event"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	v, err := DecodeEvent(&event.Event{EventType: event.EventHead, Data: []byte(`{"slot":"4","block":"0x01"}`)})
+	require.NoError(t, err)
+	head, ok := v.(*structs.HeadEvent)
+	require.Equal(t, true, ok)
+	require.Equal(t, "4", head.Slot)
+	require.Equal(t, "0x01", head.Block)
+
+	raw := []byte(`{"aggregation_bits":"0x01"}`)
+	v, err = DecodeEvent(&event.Event{EventType: event.EventAttestation, Data: raw})
+	require.NoError(t, err)
+	require.DeepEqual(t, json.RawMessage(raw), v)
+
+	_, err = DecodeEvent(&event.Event{EventType: event.EventConnectionError, Data: []byte("could not connect")})
+	require.ErrorContains(t, "could not connect", err)
+	_, err = DecodeEvent(&event.Event{EventType: event.EventBlock, Data: []byte(`{`)})
+	require.ErrorContains(t, "could not decode block event", err)
+}
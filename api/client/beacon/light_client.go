@@ -0,0 +1,58 @@
+package beacon
+
+import (
+	"context"
+	"path"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+)
+
+const (
+	getLightClientBootstrapPath        = "/eth/v1/beacon/light_client/bootstrap"
+	getLightClientUpdatesPath          = "/eth/v1/beacon/light_client/updates"
+	getLightClientFinalityUpdatePath   = "/eth/v1/beacon/light_client/finality_update"
+	getLightClientOptimisticUpdatePath = "/eth/v1/beacon/light_client/optimistic_update"
+)
+
+// GetLightClientBootstrap retrieves the light client bootstrap for the block with the given root, which must be a
+// finalized epoch boundary block.
+func (c *Client) GetLightClientBootstrap(ctx context.Context, blockRoot [32]byte) (*structs.LightClientBootstrapResponse, error) {
+	resp := &structs.LightClientBootstrapResponse{}
+	if err := c.getJSON(ctx, path.Join(getLightClientBootstrapPath, hexutil.Encode(blockRoot[:])), resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetLightClientUpdates retrieves the best light client updates of count sync committee periods from startPeriod.
+func (c *Client) GetLightClientUpdates(ctx context.Context, startPeriod, count uint64) ([]*structs.LightClientUpdateResponse, error) {
+	query := map[string][]string{
+		"start_period": {strconv.FormatUint(startPeriod, 10)},
+		"count":        {strconv.FormatUint(count, 10)},
+	}
+	var resp []*structs.LightClientUpdateResponse
+	if err := c.getJSON(ctx, getLightClientUpdatesPath, &resp, queryOf(query)); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetLightClientFinalityUpdate retrieves the latest light client finality update known to the beacon node.
+func (c *Client) GetLightClientFinalityUpdate(ctx context.Context) (*structs.LightClientFinalityUpdateResponse, error) {
+	resp := &structs.LightClientFinalityUpdateResponse{}
+	if err := c.getJSON(ctx, getLightClientFinalityUpdatePath, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetLightClientOptimisticUpdate retrieves the latest light client optimistic update known to the beacon node.
+func (c *Client) GetLightClientOptimisticUpdate(ctx context.Context) (*structs.LightClientOptimisticUpdateResponse, error) {
+	resp := &structs.LightClientOptimisticUpdateResponse{}
+	if err := c.getJSON(ctx, getLightClientOptimisticUpdatePath, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
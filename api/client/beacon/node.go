@@ -0,0 +1,73 @@
+package beacon
+
+import (
+	"context"
+	"net/http"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon/iface"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+)
+
+const (
+	getIdentityPath   = "/eth/v1/node/identity"
+	getPeersPath      = "/eth/v1/node/peers"
+	getPeerCountPath  = "/eth/v1/node/peer_count"
+	getNodeHealthPath = "/eth/v1/node/health"
+)
+
+var _ iface.HealthNode = &Client{}
+
+// GetIdentity retrieves the network identity of the beacon node.
+func (c *Client) GetIdentity(ctx context.Context) (*structs.Identity, error) {
+	resp := &structs.GetIdentityResponse{}
+	if err := c.getJSON(ctx, getIdentityPath, resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("empty identity response")
+	}
+	return resp.Data, nil
+}
+
+// GetPeers retrieves the peers known to the beacon node, optionally filtered by connection states and directions.
+func (c *Client) GetPeers(ctx context.Context, states []string, directions []string) ([]*structs.Peer, error) {
+	resp := &structs.GetPeersResponse{}
+	query := queryOf(map[string][]string{"state": states, "direction": directions})
+	if err := c.getJSON(ctx, getPeersPath, resp, query); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetPeer retrieves the peer of the beacon node with the given peer id.
+func (c *Client) GetPeer(ctx context.Context, peerId string) (*structs.Peer, error) {
+	resp := &structs.GetPeerResponse{}
+	if err := c.getJSON(ctx, path.Join(getPeersPath, peerId), resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.Errorf("empty peer response for id = %s", peerId)
+	}
+	return resp.Data, nil
+}
+
+// GetPeerCount retrieves the number of peers of the beacon node by connection state.
+func (c *Client) GetPeerCount(ctx context.Context) (*structs.PeerCount, error) {
+	resp := &structs.GetPeerCountResponse{}
+	if err := c.getJSON(ctx, getPeerCountPath, resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("empty peer count response")
+	}
+	return resp.Data, nil
+}
+
+// IsHealthy returns true when the beacon node reports itself as ready or syncing, so that a Client can be monitored
+// by a NodeHealthTracker.
+func (c *Client) IsHealthy(ctx context.Context) bool {
+	_, _, err := c.Request(ctx, http.MethodGet, getNodeHealthPath, nil)
+	return err == nil
+}
@@ -0,0 +1,97 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+const (
+	attestationsPoolV2Path = "/eth/v2/beacon/pool/attestations"
+	voluntaryExitsPoolPath = "/eth/v1/beacon/pool/voluntary_exits"
+	syncCommitteesPoolPath = "/eth/v1/beacon/pool/sync_committees"
+)
+
+// GetPoolAttestations retrieves the attestations known to the operations pool of the beacon node, optionally
+// filtered by slot and committee index, decoded according to the fork version of the response.
+func (c *Client) GetPoolAttestations(ctx context.Context, slot *primitives.Slot, committeeIndex *primitives.CommitteeIndex) ([]ethpb.Att, error) {
+	query := map[string][]string{}
+	if slot != nil {
+		query["slot"] = []string{uintString(*slot)}
+	}
+	if committeeIndex != nil {
+		query["committee_index"] = []string{uintString(*committeeIndex)}
+	}
+	resp, err := c.getVersioned(ctx, attestationsPoolV2Path, queryOf(query))
+	if err != nil {
+		return nil, err
+	}
+	data, err := resp.data()
+	if err != nil {
+		return nil, err
+	}
+	return attestationsFromJSON(resp.version, data)
+}
+
+// SubmitAttestations submits attestations to the beacon node, which verifies them, adds them to its operations pool
+// and broadcasts them to the network. All the attestations must be of the same fork version.
+func (c *Client) SubmitAttestations(ctx context.Context, atts []ethpb.Att) error {
+	body, v, err := attestationsToJSON(atts)
+	if err != nil {
+		return err
+	}
+	return c.postJSON(ctx, attestationsPoolV2Path, body, nil, client.WithHeader(api.VersionHeader, version.String(v)))
+}
+
+// GetPoolAttesterSlashings retrieves the attester slashings known to the operations pool of the beacon node,
+// decoded according to the fork version of the response.
+func (c *Client) GetPoolAttesterSlashings(ctx context.Context) ([]ethpb.AttSlashing, error) {
+	resp, err := c.getVersioned(ctx, attesterSlashingsV2Path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := resp.data()
+	if err != nil {
+		return nil, err
+	}
+	return attesterSlashingsFromJSON(resp.version, data)
+}
+
+// GetPoolProposerSlashings retrieves the proposer slashings known to the operations pool of the beacon node.
+func (c *Client) GetPoolProposerSlashings(ctx context.Context) ([]*structs.ProposerSlashing, error) {
+	resp := &structs.GetProposerSlashingsResponse{}
+	if err := c.getJSON(ctx, proposerSlashingsPath, resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetPoolVoluntaryExits retrieves the voluntary exits known to the operations pool of the beacon node.
+func (c *Client) GetPoolVoluntaryExits(ctx context.Context) ([]*structs.SignedVoluntaryExit, error) {
+	resp := &structs.ListVoluntaryExitsResponse{}
+	if err := c.getJSON(ctx, voluntaryExitsPoolPath, resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// SubmitVoluntaryExit submits a signed voluntary exit to the beacon node, which verifies it, adds it to its
+// operations pool and broadcasts it to the network.
+func (c *Client) SubmitVoluntaryExit(ctx context.Context, exit *ethpb.SignedVoluntaryExit) error {
+	if exit == nil || exit.Exit == nil {
+		return errors.New("empty voluntary exit")
+	}
+	return c.postJSON(ctx, voluntaryExitsPoolPath, structs.SignedExitFromConsensus(exit), nil)
+}
+
+// SubmitSyncCommitteeMessages submits sync committee messages to the beacon node, which verifies them, adds them to
+// its operations pool and broadcasts them to the network.
+func (c *Client) SubmitSyncCommitteeMessages(ctx context.Context, msgs []*structs.SyncCommitteeMessage) error {
+	return c.postJSON(ctx, syncCommitteesPoolPath, msgs, nil)
+}
@@ -0,0 +1,204 @@
+package beacon
+
+import (
+	"context"
+	"net/http"
+	"path"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+const (
+	getChainHeadPath            = "/prysm/v1/beacon/chain_head"
+	getIndividualVotesPath      = "/prysm/v1/beacon/individual_votes"
+	getReorgsPath               = "/prysm/v1/beacon/reorgs"
+	getAttestationInclusionPath = "/prysm/v1/beacon/attestations/inclusion"
+	publishBlobsPath            = "/prysm/v1/beacon/blobs"
+	getStateProofPath           = "/prysm/v1/beacon/states/{{.Id}}/proof"
+	getBlockProofPath           = "/prysm/v1/beacon/blocks/{{.Id}}/proof"
+	getPendingDepositEpochsPath = "/prysm/v1/beacon/states/{{.Id}}/pending_deposit_epochs"
+	trustedPeersPath            = "/prysm/v1/node/trusted_peers"
+	getExecutionClientsPath     = "/prysm/v1/node/execution_clients"
+	getValidatorPerformancePath = "/prysm/v1/validators/performance"
+	getValidatorLifecyclesPath  = "/prysm/v1/validators/lifecycle"
+	getSlashingEvidencePath     = "/prysm/v1/slasher/slashings"
+)
+
+var (
+	getStateProofTpl           = idTemplate(getStateProofPath)
+	getBlockProofTpl           = idTemplate(getBlockProofPath)
+	getPendingDepositEpochsTpl = idTemplate(getPendingDepositEpochsPath)
+)
+
+// GetChainHead retrieves the head of the chain along with its justified, previous justified and finalized
+// checkpoints. This is a prysm specific endpoint.
+func (c *Client) GetChainHead(ctx context.Context) (*structs.ChainHead, error) {
+	resp := &structs.ChainHead{}
+	if err := c.getJSON(ctx, getChainHeadPath, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetIndividualVotes retrieves how the given validators voted in the given epoch. This is a prysm specific endpoint.
+func (c *Client) GetIndividualVotes(ctx context.Context, req *structs.GetIndividualVotesRequest) ([]*structs.IndividualVote, error) {
+	resp := &structs.GetIndividualVotesResponse{}
+	if err := c.postJSON(ctx, getIndividualVotesPath, req, resp); err != nil {
+		return nil, err
+	}
+	return resp.IndividualVotes, nil
+}
+
+// GetReorgs retrieves the reorgs observed by the beacon node whose slots are in the given range, bounds being
+// optional. This is a prysm specific endpoint.
+func (c *Client) GetReorgs(ctx context.Context, fromSlot, toSlot *primitives.Slot) ([]*structs.ReorgEvent, error) {
+	query := map[string][]string{}
+	if fromSlot != nil {
+		query["from_slot"] = []string{uintString(*fromSlot)}
+	}
+	if toSlot != nil {
+		query["to_slot"] = []string{uintString(*toSlot)}
+	}
+	resp := &structs.GetReorgsResponse{}
+	if err := c.getJSON(ctx, getReorgsPath, resp, queryOf(query)); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetValidatorAttestationInclusion retrieves the imported blocks, canonical or not, which include the attestation of
+// the given validator at the given slot. This is a prysm specific endpoint.
+func (c *Client) GetValidatorAttestationInclusion(ctx context.Context, index primitives.ValidatorIndex, slot primitives.Slot) ([]*structs.AttestationInclusion, error) {
+	query := map[string][]string{"validator_index": {uintString(index)}, "slot": {uintString(slot)}}
+	resp := &structs.GetAttestationInclusionResponse{}
+	if err := c.getJSON(ctx, getAttestationInclusionPath, resp, queryOf(query)); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetAttestationInclusion retrieves the imported blocks, canonical or not, which include the attestation with the
+// given data root and aggregation bits. Committee bits are required for Electra attestations spanning several
+// committees and may be nil otherwise. This is a prysm specific endpoint.
+func (c *Client) GetAttestationInclusion(ctx context.Context, dataRoot [32]byte, aggregationBits, committeeBits []byte) ([]*structs.AttestationInclusion, error) {
+	query := map[string][]string{"data_root": {hexutil.Encode(dataRoot[:])}, "aggregation_bits": {hexutil.Encode(aggregationBits)}}
+	if committeeBits != nil {
+		query["committee_bits"] = []string{hexutil.Encode(committeeBits)}
+	}
+	resp := &structs.GetAttestationInclusionResponse{}
+	if err := c.getJSON(ctx, getAttestationInclusionPath, resp, queryOf(query)); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// PublishBlobs submits blob sidecars of an already known block to the beacon node, which verifies them, imports
+// them and broadcasts them to the network. This is a prysm specific endpoint.
+func (c *Client) PublishBlobs(ctx context.Context, req *structs.PublishBlobsRequest) error {
+	return c.postJSON(ctx, publishBlobsPath, req, nil)
+}
+
+// GetStateProof retrieves the Merkle proof of the field at the given SSZ path of the state for the given state id.
+// This is a prysm specific endpoint.
+func (c *Client) GetStateProof(ctx context.Context, stateId StateOrBlockId, fieldPath string) (*structs.GetSszProofResponse, error) {
+	resp := &structs.GetSszProofResponse{}
+	query := queryOf(map[string][]string{"path": {fieldPath}})
+	if err := c.getJSON(ctx, getStateProofTpl(stateId), resp, query); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetBlockProof retrieves the Merkle proof of the field at the given SSZ path of the block for the given block id.
+// This is a prysm specific endpoint.
+func (c *Client) GetBlockProof(ctx context.Context, blockId StateOrBlockId, fieldPath string) (*structs.GetSszProofResponse, error) {
+	resp := &structs.GetSszProofResponse{}
+	query := queryOf(map[string][]string{"path": {fieldPath}})
+	if err := c.getJSON(ctx, getBlockProofTpl(blockId), resp, query); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetPendingDepositEpochs retrieves the estimated processing epochs of the pending deposits of the Electra state
+// for the given state id, optionally filtered by validator ids and deposit queue indices. This is a prysm specific
+// endpoint.
+func (c *Client) GetPendingDepositEpochs(ctx context.Context, stateId StateOrBlockId, validatorIds []string, depositIndices []uint64) ([]*structs.PendingDepositEpoch, error) {
+	query := map[string][]string{"validator_id": validatorIds, "deposit_index": uintStrings(depositIndices)}
+	resp := &structs.GetPendingDepositEpochsResponse{}
+	if err := c.getJSON(ctx, getPendingDepositEpochsTpl(stateId), resp, queryOf(query)); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetTrustedPeers retrieves the trusted peers of the beacon node. This is a prysm specific endpoint.
+func (c *Client) GetTrustedPeers(ctx context.Context) ([]*structs.Peer, error) {
+	resp := &structs.PeersResponse{}
+	if err := c.getJSON(ctx, trustedPeersPath, resp); err != nil {
+		return nil, err
+	}
+	return resp.Peers, nil
+}
+
+// AddTrustedPeer adds the peer with the given multiaddress, which must include the peer id, to the trusted peers of
+// the beacon node. This is a prysm specific endpoint.
+func (c *Client) AddTrustedPeer(ctx context.Context, addr string) error {
+	return c.postJSON(ctx, trustedPeersPath, &structs.AddrRequest{Addr: addr}, nil)
+}
+
+// RemoveTrustedPeer removes the peer with the given peer id from the trusted peers of the beacon node. This is a
+// prysm specific endpoint.
+func (c *Client) RemoveTrustedPeer(ctx context.Context, peerId string) error {
+	if _, _, err := c.Request(ctx, http.MethodDelete, path.Join(trustedPeersPath, peerId), nil); err != nil {
+		return errors.Wrapf(err, "error removing trusted peer %s", peerId)
+	}
+	return nil
+}
+
+// GetExecutionClients retrieves the execution clients the beacon node is connected to. This is a prysm specific
+// endpoint.
+func (c *Client) GetExecutionClients(ctx context.Context) ([]*structs.ExecutionClient, error) {
+	resp := &structs.ExecutionClientsResponse{}
+	if err := c.getJSON(ctx, getExecutionClientsPath, resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetValidatorPerformance retrieves the performance of the given validators in the previous epoch. This is a prysm
+// specific endpoint.
+func (c *Client) GetValidatorPerformance(ctx context.Context, req *structs.GetValidatorPerformanceRequest) (*structs.GetValidatorPerformanceResponse, error) {
+	resp := &structs.GetValidatorPerformanceResponse{}
+	if err := c.postJSON(ctx, getValidatorPerformancePath, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetValidatorLifecycles retrieves the lifecycles of the given validators projected from the head state. Validator
+// ids are either indices or hex encoded public keys. This is a prysm specific endpoint.
+func (c *Client) GetValidatorLifecycles(ctx context.Context, ids []string) (*structs.GetValidatorLifecyclesResponse, error) {
+	resp := &structs.GetValidatorLifecyclesResponse{}
+	if err := c.getJSON(ctx, getValidatorLifecyclesPath, resp, queryOf(map[string][]string{"id": ids})); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSlashingEvidence retrieves the slashing evidence archived by the beacon node, optionally filtered by validator
+// indices and starting epoch. This is a prysm specific endpoint.
+func (c *Client) GetSlashingEvidence(ctx context.Context, indices []primitives.ValidatorIndex, fromEpoch *primitives.Epoch) ([]*structs.SlashingEvidence, error) {
+	query := map[string][]string{"validator_index": uintStrings(indices)}
+	if fromEpoch != nil {
+		query["from_epoch"] = []string{uintString(*fromEpoch)}
+	}
+	resp := &structs.GetSlashingEvidenceResponse{}
+	if err := c.getJSON(ctx, getSlashingEvidencePath, resp, queryOf(query)); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
@@ -0,0 +1,132 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// getJSON requests the path and decodes the JSON response into v.
+func (c *Client) getJSON(ctx context.Context, p string, v interface{}, opts ...client.ReqOption) error {
+	b, err := c.Get(ctx, p, opts...)
+	if err != nil {
+		return errors.Wrapf(err, "error requesting %s", p)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return errors.Wrapf(err, "error decoding json response of %s", p)
+	}
+	return nil
+}
+
+// postJSON sends the JSON encoding of body to the path and decodes the JSON response into v, unless v is nil.
+func (c *Client) postJSON(ctx context.Context, p string, body, v interface{}, opts ...client.ReqOption) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal JSON")
+	}
+	_, resp, err := c.Request(ctx, http.MethodPost, p, b, opts...)
+	if err != nil {
+		return errors.Wrapf(err, "error posting to %s", p)
+	}
+	if v == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp, v); err != nil {
+		return errors.Wrapf(err, "error decoding json response of %s", p)
+	}
+	return nil
+}
+
+// postSSZ sends the SSZ encoded body of the given fork version to the path.
+func (c *Client) postSSZ(ctx context.Context, p string, body []byte, v int, opts ...client.ReqOption) error {
+	opts = append(opts,
+		client.WithHeader("Content-Type", api.OctetStreamMediaType),
+		client.WithHeader(api.VersionHeader, version.String(v)),
+	)
+	if _, _, err := c.Request(ctx, http.MethodPost, p, body, opts...); err != nil {
+		return errors.Wrapf(err, "error posting to %s", p)
+	}
+	return nil
+}
+
+// versionedResponse is the body of a response served either in SSZ or in JSON, along with the fork version of its
+// content.
+type versionedResponse struct {
+	body    []byte
+	ssz     bool
+	version int
+	header  http.Header
+}
+
+// getVersioned requests the path, preferring an SSZ response. The fork version of the response is read from the
+// Eth-Consensus-Version header, or from the version field of JSON responses when the header is missing.
+func (c *Client) getVersioned(ctx context.Context, p string, opts ...client.ReqOption) (*versionedResponse, error) {
+	header, b, err := c.Request(ctx, http.MethodGet, p, nil, append(opts, client.WithJSONOrSSZEncoding())...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting %s", p)
+	}
+	resp := &versionedResponse{
+		body:   b,
+		ssz:    strings.HasPrefix(header.Get("Content-Type"), api.OctetStreamMediaType),
+		header: header,
+	}
+	v := header.Get(api.VersionHeader)
+	if v == "" && !resp.ssz {
+		versioned := &struct {
+			Version string `json:"version"`
+		}{}
+		if err := json.Unmarshal(b, versioned); err != nil {
+			return nil, errors.Wrapf(err, "error decoding json response of %s", p)
+		}
+		v = versioned.Version
+	}
+	resp.version, err = version.FromString(v)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unknown version of %s response", p)
+	}
+	return resp, nil
+}
+
+// data returns the data field of a JSON response.
+func (r *versionedResponse) data() (json.RawMessage, error) {
+	d := &struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(r.body, d); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response")
+	}
+	return d.Data, nil
+}
+
+func uintString[T ~uint64](v T) string {
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+func uintStrings[T ~uint64](vs []T) []string {
+	s := make([]string, len(vs))
+	for i, v := range vs {
+		s[i] = uintString(v)
+	}
+	return s
+}
+
+// queryOf returns the query option setting the non-empty values.
+func queryOf(values map[string][]string) client.ReqOption {
+	query := url.Values{}
+	for k, vs := range values {
+		for _, v := range vs {
+			if v != "" {
+				query.Add(k, v)
+			}
+		}
+	}
+	return client.WithQuery(query)
+}
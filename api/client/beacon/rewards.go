@@ -0,0 +1,60 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+const (
+	getBlockRewardsPath         = "/eth/v1/beacon/rewards/blocks/{{.Id}}"
+	getAttestationRewardsPath   = "/eth/v1/beacon/rewards/attestations/%d"
+	getSyncCommitteeRewardsPath = "/eth/v1/beacon/rewards/sync_committee/{{.Id}}"
+)
+
+var (
+	getBlockRewardsTpl         = idTemplate(getBlockRewardsPath)
+	getSyncCommitteeRewardsTpl = idTemplate(getSyncCommitteeRewardsPath)
+)
+
+// GetBlockRewards retrieves the rewards of the proposer of the block for the given block id.
+func (c *Client) GetBlockRewards(ctx context.Context, blockId StateOrBlockId) (*structs.BlockRewards, error) {
+	resp := &structs.BlockRewardsResponse{}
+	if err := c.getJSON(ctx, getBlockRewardsTpl(blockId), resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.Errorf("empty block rewards response for id = %s", blockId)
+	}
+	return resp.Data, nil
+}
+
+// GetAttestationRewards retrieves the attestation rewards of the given validators in the given epoch, along with the
+// ideal rewards by effective balance. Validator ids are either indices or hex encoded public keys, and the rewards of
+// all the validators are returned when no ids are given.
+func (c *Client) GetAttestationRewards(ctx context.Context, epoch primitives.Epoch, ids []string) (*structs.AttestationRewards, error) {
+	if ids == nil {
+		ids = []string{}
+	}
+	resp := &structs.AttestationRewardsResponse{}
+	if err := c.postJSON(ctx, fmt.Sprintf(getAttestationRewardsPath, epoch), ids, resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// GetSyncCommitteeRewards retrieves the sync committee rewards of the given validators in the block for the given
+// block id. The rewards of all the sync committee members are returned when no ids are given.
+func (c *Client) GetSyncCommitteeRewards(ctx context.Context, blockId StateOrBlockId, ids []string) ([]structs.SyncCommitteeReward, error) {
+	if ids == nil {
+		ids = []string{}
+	}
+	resp := &structs.SyncCommitteeRewardsResponse{}
+	if err := c.postJSON(ctx, getSyncCommitteeRewardsTpl(blockId), ids, resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
@@ -0,0 +1,195 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+)
+
+const (
+	getStateRootPath                 = "/eth/v1/beacon/states/{{.Id}}/root"
+	getFinalityCheckpointsPath       = "/eth/v1/beacon/states/{{.Id}}/finality_checkpoints"
+	getValidatorsPath                = "/eth/v1/beacon/states/{{.Id}}/validators"
+	getValidatorBalancesPath         = "/eth/v1/beacon/states/{{.Id}}/validator_balances"
+	getValidatorCountPath            = "/eth/v1/beacon/states/{{.Id}}/validator_count"
+	getSyncCommitteesPath            = "/eth/v1/beacon/states/{{.Id}}/sync_committees"
+	getRandaoPath                    = "/eth/v1/beacon/states/{{.Id}}/randao"
+	getPendingDepositsPath           = "/eth/v1/beacon/states/{{.Id}}/pending_deposits"
+	getPendingPartialWithdrawalsPath = "/eth/v1/beacon/states/{{.Id}}/pending_partial_withdrawals"
+	getPendingConsolidationsPath     = "/eth/v1/beacon/states/{{.Id}}/pending_consolidations"
+	getExpectedWithdrawalsPath       = "/eth/v1/builder/states/{{.Id}}/expected_withdrawals"
+)
+
+var (
+	getStateRootTpl                 = idTemplate(getStateRootPath)
+	getFinalityCheckpointsTpl       = idTemplate(getFinalityCheckpointsPath)
+	getValidatorsTpl                = idTemplate(getValidatorsPath)
+	getValidatorBalancesTpl         = idTemplate(getValidatorBalancesPath)
+	getValidatorCountTpl            = idTemplate(getValidatorCountPath)
+	getSyncCommitteesTpl            = idTemplate(getSyncCommitteesPath)
+	getRandaoTpl                    = idTemplate(getRandaoPath)
+	getPendingDepositsTpl           = idTemplate(getPendingDepositsPath)
+	getPendingPartialWithdrawalsTpl = idTemplate(getPendingPartialWithdrawalsPath)
+	getPendingConsolidationsTpl     = idTemplate(getPendingConsolidationsPath)
+	getExpectedWithdrawalsTpl       = idTemplate(getExpectedWithdrawalsPath)
+)
+
+// GetStateRoot retrieves the hash_tree_root of the BeaconState for the given state id.
+func (c *Client) GetStateRoot(ctx context.Context, stateId StateOrBlockId) ([32]byte, error) {
+	resp := &structs.GetStateRootResponse{}
+	if err := c.getJSON(ctx, getStateRootTpl(stateId), resp); err != nil {
+		return [32]byte{}, err
+	}
+	if resp.Data == nil {
+		return [32]byte{}, errors.Errorf("empty state root response for id = %s", stateId)
+	}
+	root, err := hexutil.Decode(resp.Data.Root)
+	if err != nil {
+		return [32]byte{}, errors.Wrapf(err, "error decoding hex-encoded value %s", resp.Data.Root)
+	}
+	return bytesutil.ToBytes32(root), nil
+}
+
+// GetFinalityCheckpoints retrieves the finality checkpoints of the state for the given state id.
+func (c *Client) GetFinalityCheckpoints(ctx context.Context, stateId StateOrBlockId) (*structs.FinalityCheckpoints, error) {
+	resp := &structs.GetFinalityCheckpointsResponse{}
+	if err := c.getJSON(ctx, getFinalityCheckpointsTpl(stateId), resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.Errorf("empty finality checkpoints response for id = %s", stateId)
+	}
+	return resp.Data, nil
+}
+
+// GetValidators retrieves the validators of the state for the given state id. Validator ids are either indices or
+// hex encoded public keys. All the validators are returned when no ids are given, and only the validators with one of
+// the statuses are returned when statuses are given. The ids are sent in the body of the request, so that they can be
+// numerous.
+func (c *Client) GetValidators(ctx context.Context, stateId StateOrBlockId, ids []string, statuses []string) ([]*structs.ValidatorContainer, error) {
+	req := &structs.GetValidatorsRequest{Ids: ids, Statuses: statuses}
+	resp := &structs.GetValidatorsResponse{}
+	if err := c.postJSON(ctx, getValidatorsTpl(stateId), req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetValidator retrieves the validator of the state for the given state id and validator id, which is either an
+// index or a hex encoded public key.
+func (c *Client) GetValidator(ctx context.Context, stateId StateOrBlockId, validatorId string) (*structs.ValidatorContainer, error) {
+	resp := &structs.GetValidatorResponse{}
+	if err := c.getJSON(ctx, getValidatorsTpl(stateId)+"/"+validatorId, resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.Errorf("empty validator response for id = %s", validatorId)
+	}
+	return resp.Data, nil
+}
+
+// GetValidatorBalances retrieves the balances of the validators of the state for the given state id. All the
+// balances are returned when no ids are given.
+func (c *Client) GetValidatorBalances(ctx context.Context, stateId StateOrBlockId, ids []string) ([]*structs.ValidatorBalance, error) {
+	if ids == nil {
+		ids = []string{}
+	}
+	resp := &structs.GetValidatorBalancesResponse{}
+	if err := c.postJSON(ctx, getValidatorBalancesTpl(stateId), ids, resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetValidatorCount retrieves the number of validators of the state for the given state id by status. The counts of
+// all the statuses are returned when no statuses are given.
+func (c *Client) GetValidatorCount(ctx context.Context, stateId StateOrBlockId, statuses []string) ([]*structs.ValidatorCount, error) {
+	resp := &structs.GetValidatorCountResponse{}
+	if err := c.getJSON(ctx, getValidatorCountTpl(stateId), resp, queryOf(map[string][]string{"status": statuses})); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetSyncCommittees retrieves the sync committee of the given epoch, or of the epoch of the state when epoch is
+// nil, as computed from the state for the given state id.
+func (c *Client) GetSyncCommittees(ctx context.Context, stateId StateOrBlockId, epoch *primitives.Epoch) (*structs.SyncCommitteeValidators, error) {
+	query := map[string][]string{}
+	if epoch != nil {
+		query["epoch"] = []string{uintString(*epoch)}
+	}
+	resp := &structs.GetSyncCommitteeResponse{}
+	if err := c.getJSON(ctx, getSyncCommitteesTpl(stateId), resp, queryOf(query)); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.Errorf("empty sync committees response for id = %s", stateId)
+	}
+	return resp.Data, nil
+}
+
+// GetRandao retrieves the RANDAO mix of the given epoch, or of the epoch of the state when epoch is nil, from the
+// state for the given state id.
+func (c *Client) GetRandao(ctx context.Context, stateId StateOrBlockId, epoch *primitives.Epoch) ([32]byte, error) {
+	query := map[string][]string{}
+	if epoch != nil {
+		query["epoch"] = []string{uintString(*epoch)}
+	}
+	resp := &structs.GetRandaoResponse{}
+	if err := c.getJSON(ctx, getRandaoTpl(stateId), resp, queryOf(query)); err != nil {
+		return [32]byte{}, err
+	}
+	if resp.Data == nil {
+		return [32]byte{}, errors.Errorf("empty randao response for id = %s", stateId)
+	}
+	randao, err := hexutil.Decode(resp.Data.Randao)
+	if err != nil {
+		return [32]byte{}, errors.Wrapf(err, "error decoding hex-encoded value %s", resp.Data.Randao)
+	}
+	return bytesutil.ToBytes32(randao), nil
+}
+
+// GetPendingDeposits retrieves the pending deposits of the Electra state for the given state id.
+func (c *Client) GetPendingDeposits(ctx context.Context, stateId StateOrBlockId) ([]*structs.PendingDeposit, error) {
+	resp := &structs.GetPendingDepositsResponse{}
+	if err := c.getJSON(ctx, getPendingDepositsTpl(stateId), resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetPendingPartialWithdrawals retrieves the pending partial withdrawals of the Electra state for the given state id.
+func (c *Client) GetPendingPartialWithdrawals(ctx context.Context, stateId StateOrBlockId) ([]*structs.PendingPartialWithdrawal, error) {
+	resp := &structs.GetPendingPartialWithdrawalsResponse{}
+	if err := c.getJSON(ctx, getPendingPartialWithdrawalsTpl(stateId), resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetPendingConsolidations retrieves the pending consolidations of the Electra state for the given state id.
+func (c *Client) GetPendingConsolidations(ctx context.Context, stateId StateOrBlockId) ([]*structs.PendingConsolidation, error) {
+	resp := &structs.GetPendingConsolidationsResponse{}
+	if err := c.getJSON(ctx, getPendingConsolidationsTpl(stateId), resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetExpectedWithdrawals retrieves the withdrawals expected in the block proposed at proposalSlot, or at the slot
+// following the state when proposalSlot is nil, on top of the state for the given state id.
+func (c *Client) GetExpectedWithdrawals(ctx context.Context, stateId StateOrBlockId, proposalSlot *primitives.Slot) ([]*structs.ExpectedWithdrawal, error) {
+	query := map[string][]string{}
+	if proposalSlot != nil {
+		query["proposal_slot"] = []string{uintString(*proposalSlot)}
+	}
+	resp := &structs.ExpectedWithdrawalsResponse{}
+	if err := c.getJSON(ctx, getExpectedWithdrawalsTpl(stateId), resp, queryOf(query)); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
@@ -0,0 +1,246 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+const (
+	getAttesterDutiesPath            = "/eth/v1/validator/duties/attester/%d"
+	getProposerDutiesPath            = "/eth/v1/validator/duties/proposer/%d"
+	getSyncCommitteeDutiesPath       = "/eth/v1/validator/duties/sync/%d"
+	getLivenessPath                  = "/eth/v1/validator/liveness/%d"
+	getAttestationDataPath           = "/eth/v1/validator/attestation_data"
+	getAggregateAttestationV2Path    = "/eth/v2/validator/aggregate_attestation"
+	submitAggregateAndProofsV2Path   = "/eth/v2/validator/aggregate_and_proofs"
+	getSyncCommitteeContributionPath = "/eth/v1/validator/sync_committee_contribution"
+	submitContributionAndProofsPath  = "/eth/v1/validator/contribution_and_proofs"
+	beaconCommitteeSubscriptionsPath = "/eth/v1/validator/beacon_committee_subscriptions"
+	syncCommitteeSubscriptionsPath   = "/eth/v1/validator/sync_committee_subscriptions"
+	registerValidatorPath            = "/eth/v1/validator/register_validator"
+	prepareBeaconProposerPath        = "/eth/v1/validator/prepare_beacon_proposer"
+	produceBlockV3Path               = "/eth/v3/validator/blocks/%d"
+)
+
+// GetAttesterDuties retrieves the attester duties of the given validators in the given epoch.
+func (c *Client) GetAttesterDuties(ctx context.Context, epoch primitives.Epoch, indices []primitives.ValidatorIndex) (*structs.GetAttesterDutiesResponse, error) {
+	resp := &structs.GetAttesterDutiesResponse{}
+	if err := c.postJSON(ctx, fmt.Sprintf(getAttesterDutiesPath, epoch), uintStrings(indices), resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetProposerDuties retrieves the block proposers of all the slots of the given epoch.
+func (c *Client) GetProposerDuties(ctx context.Context, epoch primitives.Epoch) (*structs.GetProposerDutiesResponse, error) {
+	resp := &structs.GetProposerDutiesResponse{}
+	if err := c.getJSON(ctx, fmt.Sprintf(getProposerDutiesPath, epoch), resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSyncCommitteeDuties retrieves the sync committee duties of the given validators in the sync committee period
+// of the given epoch.
+func (c *Client) GetSyncCommitteeDuties(ctx context.Context, epoch primitives.Epoch, indices []primitives.ValidatorIndex) (*structs.GetSyncCommitteeDutiesResponse, error) {
+	resp := &structs.GetSyncCommitteeDutiesResponse{}
+	if err := c.postJSON(ctx, fmt.Sprintf(getSyncCommitteeDutiesPath, epoch), uintStrings(indices), resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetLiveness retrieves whether the given validators were seen active on the network in the given epoch.
+func (c *Client) GetLiveness(ctx context.Context, epoch primitives.Epoch, indices []primitives.ValidatorIndex) ([]*structs.Liveness, error) {
+	resp := &structs.GetLivenessResponse{}
+	if err := c.postJSON(ctx, fmt.Sprintf(getLivenessPath, epoch), uintStrings(indices), resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetAttestationData retrieves the attestation data to sign for the given slot and committee index.
+func (c *Client) GetAttestationData(ctx context.Context, slot primitives.Slot, committeeIndex primitives.CommitteeIndex) (*ethpb.AttestationData, error) {
+	query := map[string][]string{
+		"slot":            {uintString(slot)},
+		"committee_index": {uintString(committeeIndex)},
+	}
+	resp := &structs.GetAttestationDataResponse{}
+	if err := c.getJSON(ctx, getAttestationDataPath, resp, queryOf(query)); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.Errorf("empty attestation data response for slot %d", slot)
+	}
+	return resp.Data.ToConsensus()
+}
+
+// GetAggregateAttestation retrieves the aggregate of the attestations known to the beacon node with the given
+// attestation data root, slot and, from Electra, committee index. The committee index is ignored before Electra.
+func (c *Client) GetAggregateAttestation(ctx context.Context, dataRoot [32]byte, slot primitives.Slot, committeeIndex primitives.CommitteeIndex) (ethpb.Att, error) {
+	query := map[string][]string{
+		"attestation_data_root": {hexutil.Encode(dataRoot[:])},
+		"slot":                  {uintString(slot)},
+		"committee_index":       {uintString(committeeIndex)},
+	}
+	resp, err := c.getVersioned(ctx, getAggregateAttestationV2Path, queryOf(query))
+	if err != nil {
+		return nil, err
+	}
+	data, err := resp.data()
+	if err != nil {
+		return nil, err
+	}
+	atts, err := attestationsFromJSON(resp.version, json.RawMessage("["+string(data)+"]"))
+	if err != nil {
+		return nil, err
+	}
+	return atts[0], nil
+}
+
+// SubmitAggregateAndProofs submits signed aggregates to the beacon node, which verifies them and broadcasts them to
+// the network. All the aggregates must be of the same fork version.
+func (c *Client) SubmitAggregateAndProofs(ctx context.Context, aggregates []ethpb.SignedAggregateAttAndProof) error {
+	if len(aggregates) == 0 {
+		return nil
+	}
+	v := aggregates[0].Version()
+	body := make([]interface{}, len(aggregates))
+	for i, a := range aggregates {
+		switch agg := a.(type) {
+		case *ethpb.SignedAggregateAttestationAndProof:
+			if v >= version.Electra {
+				return errors.Errorf("aggregate %d is a %T, not an electra aggregate", i, a)
+			}
+			body[i] = structs.SignedAggregateAttestationAndProofFromConsensus(agg)
+		case *ethpb.SignedAggregateAttestationAndProofElectra:
+			if v < version.Electra {
+				return errors.Errorf("aggregate %d is a %T, not a phase0 aggregate", i, a)
+			}
+			body[i] = structs.SignedAggregateAttestationAndProofElectraFromConsensus(agg)
+		default:
+			return errors.Errorf("unsupported aggregate type %T", a)
+		}
+	}
+	return c.postJSON(ctx, submitAggregateAndProofsV2Path, body, nil, client.WithHeader(api.VersionHeader, version.String(v)))
+}
+
+// GetSyncCommitteeContribution retrieves the aggregate of the sync committee messages known to the beacon node for
+// the given slot, subcommittee and block root.
+func (c *Client) GetSyncCommitteeContribution(ctx context.Context, slot primitives.Slot, subcommitteeIndex uint64, blockRoot [32]byte) (*ethpb.SyncCommitteeContribution, error) {
+	query := map[string][]string{
+		"slot":               {uintString(slot)},
+		"subcommittee_index": {strconv.FormatUint(subcommitteeIndex, 10)},
+		"beacon_block_root":  {hexutil.Encode(blockRoot[:])},
+	}
+	resp := &structs.ProduceSyncCommitteeContributionResponse{}
+	if err := c.getJSON(ctx, getSyncCommitteeContributionPath, resp, queryOf(query)); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.Errorf("empty sync committee contribution response for slot %d", slot)
+	}
+	return resp.Data.ToConsensus()
+}
+
+// SubmitContributionAndProofs submits signed sync committee contributions to the beacon node, which verifies them
+// and broadcasts them to the network.
+func (c *Client) SubmitContributionAndProofs(ctx context.Context, contributions []*ethpb.SignedContributionAndProof) error {
+	body := make([]*structs.SignedContributionAndProof, len(contributions))
+	for i, contribution := range contributions {
+		body[i] = structs.SignedContributionAndProofFromConsensus(contribution)
+	}
+	return c.postJSON(ctx, submitContributionAndProofsPath, body, nil)
+}
+
+// SubmitBeaconCommitteeSubscriptions asks the beacon node to subscribe to the attestation subnets of the given
+// committees, and to aggregate their attestations when the validator is an aggregator.
+func (c *Client) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subs []*structs.BeaconCommitteeSubscription) error {
+	return c.postJSON(ctx, beaconCommitteeSubscriptionsPath, subs, nil)
+}
+
+// SubmitSyncCommitteeSubscriptions asks the beacon node to subscribe to the sync committee subnets of the given
+// validators until the given epochs.
+func (c *Client) SubmitSyncCommitteeSubscriptions(ctx context.Context, subs []*structs.SyncCommitteeSubscription) error {
+	return c.postJSON(ctx, syncCommitteeSubscriptionsPath, subs, nil)
+}
+
+// RegisterValidators submits signed validator registrations to the beacon node, which forwards them to the builder
+// network.
+func (c *Client) RegisterValidators(ctx context.Context, registrations []*ethpb.SignedValidatorRegistrationV1) error {
+	body := make([]*structs.SignedValidatorRegistration, len(registrations))
+	for i, registration := range registrations {
+		body[i] = structs.SignedValidatorRegistrationFromConsensus(registration)
+	}
+	return c.postJSON(ctx, registerValidatorPath, body, nil)
+}
+
+// PrepareBeaconProposers submits the fee recipients of validators, which the beacon node uses when preparing the
+// execution payloads of their blocks.
+func (c *Client) PrepareBeaconProposers(ctx context.Context, recipients []*structs.FeeRecipient) error {
+	return c.postJSON(ctx, prepareBeaconProposerPath, recipients, nil)
+}
+
+// ProducedBlock is a block produced by the beacon node, along with its values to the proposer in Wei.
+type ProducedBlock struct {
+	Block                 *ethpb.GenericBeaconBlock
+	ExecutionPayloadValue string
+	ConsensusBlockValue   string
+}
+
+// ProduceBlockOpts are the optional parameters of ProduceBlock. A nil BuilderBoostFactor leaves the choice between
+// the local and the builder payloads to the beacon node.
+type ProduceBlockOpts struct {
+	Graffiti               []byte
+	SkipRandaoVerification bool
+	BuilderBoostFactor     *uint64
+}
+
+// ProduceBlock asks the beacon node to produce an unsigned block for the given slot, which is blinded when the
+// beacon node selects the payload of a builder.
+func (c *Client) ProduceBlock(ctx context.Context, slot primitives.Slot, randaoReveal []byte, opts *ProduceBlockOpts) (*ProducedBlock, error) {
+	query := map[string][]string{"randao_reveal": {hexutil.Encode(randaoReveal)}}
+	if opts != nil {
+		if len(opts.Graffiti) > 0 {
+			query["graffiti"] = []string{hexutil.Encode(opts.Graffiti)}
+		}
+		if opts.SkipRandaoVerification {
+			query["skip_randao_verification"] = []string{"true"}
+		}
+		if opts.BuilderBoostFactor != nil {
+			query["builder_boost_factor"] = []string{strconv.FormatUint(*opts.BuilderBoostFactor, 10)}
+		}
+	}
+	resp, err := c.getVersioned(ctx, fmt.Sprintf(produceBlockV3Path, slot), queryOf(query))
+	if err != nil {
+		return nil, err
+	}
+	blinded := resp.header.Get(api.ExecutionPayloadBlindedHeader) == "true"
+	if !resp.ssz && resp.header.Get(api.ExecutionPayloadBlindedHeader) == "" {
+		v3 := &structs.ProduceBlockV3Response{}
+		if err := json.Unmarshal(resp.body, v3); err != nil {
+			return nil, errors.Wrap(err, "error decoding json response of block production")
+		}
+		blinded = v3.ExecutionPayloadBlinded
+	}
+	blk, err := producedBlockFromResponse(resp, blinded)
+	if err != nil {
+		return nil, err
+	}
+	return &ProducedBlock{
+		Block:                 blk,
+		ExecutionPayloadValue: resp.header.Get(api.ExecutionPayloadValueHeader),
+		ConsensusBlockValue:   resp.header.Get(api.ConsensusBlockValueHeader),
+	}, nil
+}
@@ -0,0 +1,91 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestProduceBlock(t *testing.T) {
+	blinded := util.NewBlindedBeaconBlockCapella().Block
+	jsonBlock, err := structs.BlindedBeaconBlockCapellaFromConsensus(blinded)
+	require.NoError(t, err)
+	data, err := json.Marshal(jsonBlock)
+	require.NoError(t, err)
+	body, err := json.Marshal(&structs.ProduceBlockV3Response{
+		Version:                 "capella",
+		ExecutionPayloadBlinded: true,
+		ExecutionPayloadValue:   "10",
+		ConsensusBlockValue:     "20",
+		Data:                    data,
+	})
+	require.NoError(t, err)
+	rt := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "/eth/v3/validator/blocks/7", req.URL.Path)
+		require.Equal(t, "0x"+string(bytes.Repeat([]byte("00"), 96)), req.URL.Query().Get("randao_reveal"))
+		require.Equal(t, "100", req.URL.Query().Get("builder_boost_factor"))
+		require.Equal(t, false, req.URL.Query().Has("graffiti"))
+		header := http.Header{}
+		header.Set("Content-Type", api.JsonMediaType)
+		header.Set(api.ExecutionPayloadValueHeader, "10")
+		header.Set(api.ConsensusBlockValueHeader, "20")
+		return &http.Response{Request: req, StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(bytes.NewBuffer(body))}, nil
+	}}
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(rt))
+	require.NoError(t, err)
+
+	boost := uint64(100)
+	produced, err := c.ProduceBlock(context.Background(), 7, make([]byte, 96), &ProduceBlockOpts{BuilderBoostFactor: &boost})
+	require.NoError(t, err)
+	require.Equal(t, true, produced.Block.IsBlinded)
+	require.DeepEqual(t, blinded, produced.Block.GetBlindedCapella())
+	require.Equal(t, "10", produced.ExecutionPayloadValue)
+	require.Equal(t, "20", produced.ConsensusBlockValue)
+}
+
+func TestSubmitAggregateAndProofs(t *testing.T) {
+	var gotVersion string
+	var gotBody []map[string]interface{}
+	rt := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, submitAggregateAndProofsV2Path, req.URL.Path)
+		gotVersion = req.Header.Get(api.VersionHeader)
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&gotBody))
+		return &http.Response{Request: req, StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer(nil))}, nil
+	}}
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(rt))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	agg := &ethpb.SignedAggregateAttestationAndProofElectra{
+		Message: &ethpb.AggregateAttestationAndProofElectra{
+			AggregatorIndex: 3,
+			Aggregate:       util.HydrateAttestationElectra(&ethpb.AttestationElectra{}),
+			SelectionProof:  make([]byte, 96),
+		},
+		Signature: make([]byte, 96),
+	}
+	require.NoError(t, c.SubmitAggregateAndProofs(ctx, []ethpb.SignedAggregateAttAndProof{agg}))
+	require.Equal(t, "electra", gotVersion)
+	require.Equal(t, 1, len(gotBody))
+	require.Equal(t, "3", gotBody[0]["message"].(map[string]interface{})["aggregator_index"])
+
+	phase0 := &ethpb.SignedAggregateAttestationAndProof{
+		Message: &ethpb.AggregateAttestationAndProof{
+			Aggregate:      util.HydrateAttestation(&ethpb.Attestation{}),
+			SelectionProof: make([]byte, 96),
+		},
+		Signature: make([]byte, 96),
+	}
+	err = c.SubmitAggregateAndProofs(ctx, []ethpb.SignedAggregateAttAndProof{agg, phase0})
+	require.ErrorContains(t, "not an electra aggregate", err)
+}
@@ -0,0 +1,290 @@
+package beacon
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+type sszUnmarshaler interface {
+	UnmarshalSSZ([]byte) error
+}
+
+type genericSignedBlock interface {
+	ToGeneric() (*ethpb.GenericSignedBeaconBlock, error)
+}
+
+type genericBlock interface {
+	ToGeneric() (*ethpb.GenericBeaconBlock, error)
+}
+
+// signedBlockFromResponse decodes the signed block of the given fork version served by the block endpoints.
+func signedBlockFromResponse(resp *versionedResponse, blinded bool) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	blinded = blinded && resp.version >= version.Bellatrix
+	if resp.ssz {
+		var pb sszUnmarshaler
+		switch {
+		case resp.version == version.Phase0:
+			pb = &ethpb.SignedBeaconBlock{}
+		case resp.version == version.Altair:
+			pb = &ethpb.SignedBeaconBlockAltair{}
+		case resp.version == version.Bellatrix && blinded:
+			pb = &ethpb.SignedBlindedBeaconBlockBellatrix{}
+		case resp.version == version.Bellatrix:
+			pb = &ethpb.SignedBeaconBlockBellatrix{}
+		case resp.version == version.Capella && blinded:
+			pb = &ethpb.SignedBlindedBeaconBlockCapella{}
+		case resp.version == version.Capella:
+			pb = &ethpb.SignedBeaconBlockCapella{}
+		case resp.version == version.Deneb && blinded:
+			pb = &ethpb.SignedBlindedBeaconBlockDeneb{}
+		case resp.version == version.Deneb:
+			pb = &ethpb.SignedBeaconBlockDeneb{}
+		case resp.version == version.Electra && blinded:
+			pb = &ethpb.SignedBlindedBeaconBlockElectra{}
+		case resp.version == version.Electra:
+			pb = &ethpb.SignedBeaconBlockElectra{}
+		default:
+			return nil, errors.Errorf("unsupported block version %s", version.String(resp.version))
+		}
+		if err := pb.UnmarshalSSZ(resp.body); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal ssz block")
+		}
+		return blocks.NewSignedBeaconBlock(pb)
+	}
+
+	var jsonBlock interface{}
+	switch {
+	case resp.version == version.Phase0:
+		jsonBlock = &structs.SignedBeaconBlock{}
+	case resp.version == version.Altair:
+		jsonBlock = &structs.SignedBeaconBlockAltair{}
+	case resp.version == version.Bellatrix && blinded:
+		jsonBlock = &structs.SignedBlindedBeaconBlockBellatrix{}
+	case resp.version == version.Bellatrix:
+		jsonBlock = &structs.SignedBeaconBlockBellatrix{}
+	case resp.version == version.Capella && blinded:
+		jsonBlock = &structs.SignedBlindedBeaconBlockCapella{}
+	case resp.version == version.Capella:
+		jsonBlock = &structs.SignedBeaconBlockCapella{}
+	case resp.version == version.Deneb && blinded:
+		jsonBlock = &structs.SignedBlindedBeaconBlockDeneb{}
+	case resp.version == version.Deneb:
+		jsonBlock = &structs.SignedBeaconBlockDeneb{}
+	case resp.version == version.Electra && blinded:
+		jsonBlock = &structs.SignedBlindedBeaconBlockElectra{}
+	case resp.version == version.Electra:
+		jsonBlock = &structs.SignedBeaconBlockElectra{}
+	default:
+		return nil, errors.Errorf("unsupported block version %s", version.String(resp.version))
+	}
+	data, err := resp.data()
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, jsonBlock); err != nil {
+		return nil, errors.Wrap(err, "could not decode json block")
+	}
+	switch b := jsonBlock.(type) {
+	case genericSignedBlock:
+		g, err := b.ToGeneric()
+		if err != nil {
+			return nil, err
+		}
+		return blocks.NewSignedBeaconBlock(g.Block)
+	case *structs.SignedBeaconBlockDeneb:
+		pb, err := b.ToConsensus()
+		if err != nil {
+			return nil, err
+		}
+		return blocks.NewSignedBeaconBlock(pb)
+	case *structs.SignedBeaconBlockElectra:
+		pb, err := b.ToConsensus()
+		if err != nil {
+			return nil, err
+		}
+		return blocks.NewSignedBeaconBlock(pb)
+	default:
+		return nil, errors.Errorf("unsupported json block type %T", jsonBlock)
+	}
+}
+
+// producedBlockFromResponse decodes the block served by the block production endpoint. From Deneb, full blocks are
+// served with their blobs and KZG proofs.
+func producedBlockFromResponse(resp *versionedResponse, blinded bool) (*ethpb.GenericBeaconBlock, error) {
+	blinded = blinded && resp.version >= version.Bellatrix
+	if resp.ssz {
+		var pb sszUnmarshaler
+		var g *ethpb.GenericBeaconBlock
+		switch {
+		case resp.version == version.Phase0:
+			b := &ethpb.BeaconBlock{}
+			pb, g = b, &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Phase0{Phase0: b}}
+		case resp.version == version.Altair:
+			b := &ethpb.BeaconBlockAltair{}
+			pb, g = b, &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Altair{Altair: b}}
+		case resp.version == version.Bellatrix && blinded:
+			b := &ethpb.BlindedBeaconBlockBellatrix{}
+			pb, g = b, &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_BlindedBellatrix{BlindedBellatrix: b}, IsBlinded: true}
+		case resp.version == version.Bellatrix:
+			b := &ethpb.BeaconBlockBellatrix{}
+			pb, g = b, &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Bellatrix{Bellatrix: b}}
+		case resp.version == version.Capella && blinded:
+			b := &ethpb.BlindedBeaconBlockCapella{}
+			pb, g = b, &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_BlindedCapella{BlindedCapella: b}, IsBlinded: true}
+		case resp.version == version.Capella:
+			b := &ethpb.BeaconBlockCapella{}
+			pb, g = b, &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Capella{Capella: b}}
+		case resp.version == version.Deneb && blinded:
+			b := &ethpb.BlindedBeaconBlockDeneb{}
+			pb, g = b, &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_BlindedDeneb{BlindedDeneb: b}, IsBlinded: true}
+		case resp.version == version.Deneb:
+			b := &ethpb.BeaconBlockContentsDeneb{}
+			pb, g = b, &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Deneb{Deneb: b}}
+		case resp.version == version.Electra && blinded:
+			b := &ethpb.BlindedBeaconBlockElectra{}
+			pb, g = b, &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_BlindedElectra{BlindedElectra: b}, IsBlinded: true}
+		case resp.version == version.Electra:
+			b := &ethpb.BeaconBlockContentsElectra{}
+			pb, g = b, &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Electra{Electra: b}}
+		default:
+			return nil, errors.Errorf("unsupported block version %s", version.String(resp.version))
+		}
+		if err := pb.UnmarshalSSZ(resp.body); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal ssz block")
+		}
+		return g, nil
+	}
+
+	var jsonBlock genericBlock
+	switch {
+	case resp.version == version.Phase0:
+		jsonBlock = &structs.BeaconBlock{}
+	case resp.version == version.Altair:
+		jsonBlock = &structs.BeaconBlockAltair{}
+	case resp.version == version.Bellatrix && blinded:
+		jsonBlock = &structs.BlindedBeaconBlockBellatrix{}
+	case resp.version == version.Bellatrix:
+		jsonBlock = &structs.BeaconBlockBellatrix{}
+	case resp.version == version.Capella && blinded:
+		jsonBlock = &structs.BlindedBeaconBlockCapella{}
+	case resp.version == version.Capella:
+		jsonBlock = &structs.BeaconBlockCapella{}
+	case resp.version == version.Deneb && blinded:
+		jsonBlock = &structs.BlindedBeaconBlockDeneb{}
+	case resp.version == version.Deneb:
+		jsonBlock = &structs.BeaconBlockContentsDeneb{}
+	case resp.version == version.Electra && blinded:
+		jsonBlock = &structs.BlindedBeaconBlockElectra{}
+	case resp.version == version.Electra:
+		jsonBlock = &structs.BeaconBlockContentsElectra{}
+	default:
+		return nil, errors.Errorf("unsupported block version %s", version.String(resp.version))
+	}
+	data, err := resp.data()
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, jsonBlock); err != nil {
+		return nil, errors.Wrap(err, "could not decode json block")
+	}
+	return jsonBlock.ToGeneric()
+}
+
+// attestationsFromJSON decodes the attestations of the given fork version.
+func attestationsFromJSON(v int, data json.RawMessage) ([]ethpb.Att, error) {
+	if v >= version.Electra {
+		var jsonAtts []*structs.AttestationElectra
+		if err := json.Unmarshal(data, &jsonAtts); err != nil {
+			return nil, errors.Wrap(err, "could not decode json attestations")
+		}
+		atts := make([]ethpb.Att, len(jsonAtts))
+		for i, a := range jsonAtts {
+			att, err := a.ToConsensus()
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not convert attestation %d", i)
+			}
+			atts[i] = att
+		}
+		return atts, nil
+	}
+	var jsonAtts []*structs.Attestation
+	if err := json.Unmarshal(data, &jsonAtts); err != nil {
+		return nil, errors.Wrap(err, "could not decode json attestations")
+	}
+	atts := make([]ethpb.Att, len(jsonAtts))
+	for i, a := range jsonAtts {
+		att, err := a.ToConsensus()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not convert attestation %d", i)
+		}
+		atts[i] = att
+	}
+	return atts, nil
+}
+
+// attestationsToJSON returns the JSON representation of attestations, which must all be of the same fork version,
+// along with that version.
+func attestationsToJSON(atts []ethpb.Att) (interface{}, int, error) {
+	if len(atts) == 0 {
+		return []*structs.Attestation{}, version.Phase0, nil
+	}
+	v := atts[0].Version()
+	if v >= version.Electra {
+		jsonAtts := make([]*structs.AttestationElectra, len(atts))
+		for i, a := range atts {
+			att, ok := a.(*ethpb.AttestationElectra)
+			if !ok {
+				return nil, 0, errors.Errorf("attestation %d is a %T, not an electra attestation", i, a)
+			}
+			jsonAtts[i] = structs.AttElectraFromConsensus(att)
+		}
+		return jsonAtts, v, nil
+	}
+	jsonAtts := make([]*structs.Attestation, len(atts))
+	for i, a := range atts {
+		att, ok := a.(*ethpb.Attestation)
+		if !ok {
+			return nil, 0, errors.Errorf("attestation %d is a %T, not a phase0 attestation", i, a)
+		}
+		jsonAtts[i] = structs.AttFromConsensus(att)
+	}
+	return jsonAtts, v, nil
+}
+
+// attesterSlashingsFromJSON decodes the attester slashings of the given fork version.
+func attesterSlashingsFromJSON(v int, data json.RawMessage) ([]ethpb.AttSlashing, error) {
+	if v >= version.Electra {
+		var jsonSlashings []*structs.AttesterSlashingElectra
+		if err := json.Unmarshal(data, &jsonSlashings); err != nil {
+			return nil, errors.Wrap(err, "could not decode json attester slashings")
+		}
+		slashings := make([]ethpb.AttSlashing, len(jsonSlashings))
+		for i, s := range jsonSlashings {
+			slashing, err := s.ToConsensus()
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not convert attester slashing %d", i)
+			}
+			slashings[i] = slashing
+		}
+		return slashings, nil
+	}
+	var jsonSlashings []*structs.AttesterSlashing
+	if err := json.Unmarshal(data, &jsonSlashings); err != nil {
+		return nil, errors.Wrap(err, "could not decode json attester slashings")
+	}
+	slashings := make([]ethpb.AttSlashing, len(jsonSlashings))
+	for i, s := range jsonSlashings {
+		slashing, err := s.ToConsensus()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not convert attester slashing %d", i)
+		}
+		slashings[i] = slashing
+	}
+	return slashings, nil
+}
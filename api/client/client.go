@@ -1,11 +1,13 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"net"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -22,6 +24,8 @@ type Client struct {
 	baseURL     *url.URL
 	token       string
 	maxBodySize int64
+	retries     int
+	backoff     time.Duration
 }
 
 // NewClient constructs a new client with the provided options (ex WithTimeout).
@@ -53,9 +57,55 @@ func (c *Client) BaseURL() *url.URL {
 	return c.baseURL
 }
 
-// Do execute the request against the http client
+// HTTPClient returns the http client used to send requests.
+func (c *Client) HTTPClient() *http.Client {
+	return c.hc
+}
+
+// Do execute the request against the http client. When the client is configured with WithRetries, requests failing
+// to connect or answered with 429, 502, 503 or 504 are retried with an exponential backoff, provided their body can
+// be sent again.
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
-	return c.hc.Do(req)
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := c.hc.Do(req)
+		if attempt >= c.retries || !retryable(resp, err) || req.Context().Err() != nil {
+			return resp, err
+		}
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req.Body = body
+		}
+		if resp != nil {
+			if closeErr := resp.Body.Close(); closeErr != nil {
+				return nil, errors.Wrap(closeErr, "could not close response body")
+			}
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
 }
 
 func urlForHost(h string) (*url.URL, error) {
@@ -79,27 +129,42 @@ func (c *Client) NodeURL() string {
 
 // Get is a generic, opinionated GET function to reduce boilerplate amongst the getters in this package.
 func (c *Client) Get(ctx context.Context, path string, opts ...ReqOption) ([]byte, error) {
+	_, b, err := c.Request(ctx, http.MethodGet, path, nil, opts...)
+	return b, err
+}
+
+// Request sends a request with the given method to the path, with body as its JSON encoded content when it is not
+// nil. The content type can be overridden by the request options. It returns the headers and the body of the
+// response, or an error wrapping ErrNotOK when the API does not respond with 2xx.
+func (c *Client) Request(ctx context.Context, method, path string, body []byte, opts ...ReqOption) (http.Header, []byte, error) {
 	u := c.baseURL.ResolveReference(&url.URL{Path: path})
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	var bodyReader io.Reader = http.NoBody
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bodyReader)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
 	}
 	for _, o := range opts {
 		o(req)
 	}
-	r, err := c.hc.Do(req)
+	r, err := c.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer func() {
 		err = r.Body.Close()
 	}()
-	if r.StatusCode != http.StatusOK {
-		return nil, Non200Err(r)
+	if r.StatusCode < 200 || r.StatusCode >= 300 {
+		return nil, nil, Non200Err(r)
 	}
 	b, err := io.ReadAll(io.LimitReader(r.Body, c.maxBodySize))
 	if err != nil {
-		return nil, errors.Wrap(err, "error reading http response body")
+		return nil, nil, errors.Wrap(err, "error reading http response body")
 	}
-	return b, nil
+	return r.Header, b, nil
 }
@@ -1,8 +1,13 @@
 package client
 
 import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 )
@@ -46,3 +51,48 @@ func TestBaseURL(t *testing.T) {
 	require.Equal(t, "www.offchainlabs.com", cl.BaseURL().Hostname())
 	require.Equal(t, "3500", cl.BaseURL().Port())
 }
+
+type testRT struct {
+	rt func(*http.Request) (*http.Response, error)
+}
+
+func (rt *testRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	return rt.rt(req)
+}
+
+func TestRequest_Retries(t *testing.T) {
+	var attempts int
+	var bodies []string
+	statuses := []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}
+	rt := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		b, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(b))
+		status := statuses[attempts]
+		attempts++
+		return &http.Response{Request: req, StatusCode: status, Body: io.NopCloser(bytes.NewBufferString("ok"))}, nil
+	}}
+	ctx := context.Background()
+
+	cl, err := NewClient("http://localhost:3500", WithRoundTripper(rt), WithRetries(2, time.Millisecond))
+	require.NoError(t, err)
+	_, b, err := cl.Request(ctx, http.MethodPost, "/foo", []byte(`{"a":1}`))
+	require.NoError(t, err)
+	require.Equal(t, "ok", string(b))
+	require.Equal(t, 3, attempts)
+	require.DeepEqual(t, []string{`{"a":1}`, `{"a":1}`, `{"a":1}`}, bodies)
+
+	attempts = 0
+	cl, err = NewClient("http://localhost:3500", WithRoundTripper(rt), WithRetries(1, time.Millisecond))
+	require.NoError(t, err)
+	_, _, err = cl.Request(ctx, http.MethodGet, "/foo", nil)
+	require.ErrorIs(t, err, ErrNotOK)
+	require.Equal(t, 2, attempts)
+
+	attempts = 1
+	cl, err = NewClient("http://localhost:3500", WithRoundTripper(rt))
+	require.NoError(t, err)
+	_, err = cl.Get(ctx, "/foo")
+	require.ErrorIs(t, err, ErrNotOK)
+	require.Equal(t, 2, attempts)
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -23,6 +24,27 @@ func WithAuthorizationToken(token string) ReqOption {
 	}
 }
 
+// WithJSONOrSSZEncoding is a request functional option that accepts both SSZ and JSON responses, preferring SSZ.
+func WithJSONOrSSZEncoding() ReqOption {
+	return func(req *http.Request) {
+		req.Header.Set("Accept", "application/octet-stream;q=1.0,application/json;q=0.9")
+	}
+}
+
+// WithHeader is a request functional option that sets a header of the request.
+func WithHeader(key, value string) ReqOption {
+	return func(req *http.Request) {
+		req.Header.Set(key, value)
+	}
+}
+
+// WithQuery is a request functional option that sets the query string of the request URL.
+func WithQuery(query url.Values) ReqOption {
+	return func(req *http.Request) {
+		req.URL.RawQuery = query.Encode()
+	}
+}
+
 // ClientOpt is a functional option for the Client type (http.Client wrapper)
 type ClientOpt func(*Client)
 
@@ -47,6 +69,15 @@ func WithAuthenticationToken(token string) ClientOpt {
 	}
 }
 
+// WithRetries retries requests that fail to connect or are answered with 429, 502, 503 or 504 up to the given
+// number of times, waiting for backoff before the first retry and doubling it after each attempt.
+func WithRetries(retries int, backoff time.Duration) ClientOpt {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
 // WithMaxBodySize overrides the default max body size of 8MB.
 func WithMaxBodySize(size int64) ClientOpt {
 	return func(c *Client) {
@@ -11,6 +11,8 @@ const (
 	OctetStreamMediaType          = "application/octet-stream"
 	EventStreamMediaType          = "text/event-stream"
 	KeepAlive                     = "keep-alive"
+	JsonLinesMediaType            = "application/x-ndjson"
+	LastEventIDHeader             = "Last-Event-ID"
 )
 
 // SetSSEHeaders sets the headers needed for a server-sent event response.
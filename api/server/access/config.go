@@ -0,0 +1,127 @@
+package access
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Route groups a policy can allow.
+const (
+	// GroupRead covers the queries of chain, node and pool data.
+	GroupRead = "read"
+	// GroupValidator covers validator duties, block production and the submission of blocks and pool objects.
+	GroupValidator = "validator"
+	// GroupDebug covers the debug endpoints, such as full states and the fork choice store.
+	GroupDebug = "debug"
+	// GroupAdmin covers the endpoints changing the configuration of the node, such as trusted peers.
+	GroupAdmin = "admin"
+)
+
+var groups = map[string]bool{GroupRead: true, GroupValidator: true, GroupDebug: true, GroupAdmin: true}
+
+// Config is the access configuration of the HTTP API, read from a YAML file such as:
+//
+//	anonymous:
+//	  groups: [read]
+//	  rate_limit: 5
+//	tokens:
+//	  - name: staking-team
+//	    token: 4b1a0a6a3c...
+//	    groups: [read, validator]
+//	    rate_limit: 50
+//	    burst: 100
+//	    max_concurrent: 20
+type Config struct {
+	// Anonymous is the policy of requests without a bearer token. Such requests are rejected when it is not set.
+	Anonymous *Policy `yaml:"anonymous"`
+	// Tokens are the bearer tokens accepted by the API.
+	Tokens []*Token `yaml:"tokens"`
+}
+
+// Policy limits the requests of a client.
+type Policy struct {
+	// Groups are the route groups the client may call.
+	Groups []string `yaml:"groups"`
+	// RateLimit is the number of requests per second the client may make on average, unlimited when 0.
+	RateLimit float64 `yaml:"rate_limit"`
+	// Burst is the number of requests the client may make at once, defaulting to one second of requests.
+	Burst int64 `yaml:"burst"`
+	// MaxConcurrent is the number of requests of the client served at the same time, unlimited when 0.
+	MaxConcurrent int `yaml:"max_concurrent"`
+}
+
+// Token is a bearer token and the policy of the client using it.
+type Token struct {
+	// Name identifies the client in the audit log.
+	Name   string `yaml:"name"`
+	Token  string `yaml:"token"`
+	Policy `yaml:",inline"`
+}
+
+// LoadConfig reads and validates the access configuration at path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read access config")
+	}
+	return parseConfig(data)
+}
+
+func parseConfig(data []byte) (*Config, error) {
+	cfg := &Config{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, errors.Wrap(err, "could not parse access config")
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid access config")
+	}
+	return cfg, nil
+}
+
+func (c *Config) validate() error {
+	if c.Anonymous != nil {
+		if err := c.Anonymous.validate(); err != nil {
+			return errors.Wrap(err, "anonymous")
+		}
+	}
+	names := make(map[string]bool, len(c.Tokens))
+	tokens := make(map[string]bool, len(c.Tokens))
+	for i, t := range c.Tokens {
+		if t == nil || t.Name == "" {
+			return fmt.Errorf("token %d has no name", i)
+		}
+		if t.Name == anonymousName {
+			return fmt.Errorf("token name %s is reserved", anonymousName)
+		}
+		if names[t.Name] {
+			return fmt.Errorf("token name %s is used twice", t.Name)
+		}
+		names[t.Name] = true
+		if t.Token == "" {
+			return fmt.Errorf("token %s is empty", t.Name)
+		}
+		if tokens[t.Token] {
+			return fmt.Errorf("token of %s is used twice", t.Name)
+		}
+		tokens[t.Token] = true
+		if err := t.Policy.validate(); err != nil {
+			return errors.Wrap(err, t.Name)
+		}
+	}
+	return nil
+}
+
+func (p *Policy) validate() error {
+	for _, g := range p.Groups {
+		if !groups[g] {
+			return fmt.Errorf("unknown route group %s", g)
+		}
+	}
+	if p.RateLimit < 0 || p.Burst < 0 || p.MaxConcurrent < 0 {
+		return errors.New("limits must not be negative")
+	}
+	return nil
+}
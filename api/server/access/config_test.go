@@ -0,0 +1,59 @@
+package access
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestParseConfig(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		cfg, err := parseConfig([]byte(`
+anonymous:
+  groups: [read]
+  rate_limit: 5
+tokens:
+  - name: foo
+    token: secret
+    groups: [read, validator]
+    rate_limit: 50
+    burst: 100
+    max_concurrent: 20
+`))
+		require.NoError(t, err)
+		require.NotNil(t, cfg.Anonymous)
+		assert.DeepEqual(t, []string{GroupRead}, cfg.Anonymous.Groups)
+		assert.Equal(t, float64(5), cfg.Anonymous.RateLimit)
+		require.Equal(t, 1, len(cfg.Tokens))
+		assert.Equal(t, "foo", cfg.Tokens[0].Name)
+		assert.Equal(t, "secret", cfg.Tokens[0].Token)
+		assert.DeepEqual(t, []string{GroupRead, GroupValidator}, cfg.Tokens[0].Groups)
+		assert.Equal(t, int64(100), cfg.Tokens[0].Burst)
+		assert.Equal(t, 20, cfg.Tokens[0].MaxConcurrent)
+	})
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := parseConfig([]byte("tokens:\n  - name: foo\n    token: secret\n    rate: 5\n"))
+		assert.ErrorContains(t, "could not parse access config", err)
+	})
+	t.Run("unknown group", func(t *testing.T) {
+		_, err := parseConfig([]byte("tokens:\n  - name: foo\n    token: secret\n    groups: [write]\n"))
+		assert.ErrorContains(t, "unknown route group write", err)
+	})
+	t.Run("duplicate name", func(t *testing.T) {
+		_, err := parseConfig([]byte("tokens:\n  - name: foo\n    token: a\n  - name: foo\n    token: b\n"))
+		assert.ErrorContains(t, "token name foo is used twice", err)
+	})
+	t.Run("duplicate token", func(t *testing.T) {
+		_, err := parseConfig([]byte("tokens:\n  - name: foo\n    token: a\n  - name: bar\n    token: a\n"))
+		assert.ErrorContains(t, "token of bar is used twice", err)
+	})
+	t.Run("empty token", func(t *testing.T) {
+		_, err := parseConfig([]byte("tokens:\n  - name: foo\n"))
+		assert.ErrorContains(t, "token foo is empty", err)
+	})
+	t.Run("negative limit", func(t *testing.T) {
+		_, err := parseConfig([]byte("anonymous:\n  max_concurrent: -1\n"))
+		assert.ErrorContains(t, "limits must not be negative", err)
+	})
+}
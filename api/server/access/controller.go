@@ -0,0 +1,350 @@
+// Package access authenticates the requests of the HTTP API with bearer tokens, limits them per client with route
+// groups, rate limits and concurrency caps, and records them in an audit log.
+package access
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/async"
+	leakybucket "github.com/prysmaticlabs/prysm/v5/container/leaky-bucket"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	anonymousName = "anonymous"
+	// reloadDebounceInterval groups the file events of one change of the configuration into one reload.
+	reloadDebounceInterval = time.Second
+)
+
+// Controller authenticates, limits and audits the requests of the HTTP API.
+type Controller struct {
+	configPath string
+	configHash [32]byte
+	clients    atomic.Pointer[clients]
+	audit      *logrus.Logger
+}
+
+// clients are the clients of the current configuration.
+type clients struct {
+	// enabled is false when there is no access configuration, in which case all requests are served.
+	enabled   bool
+	anonymous *client
+	byToken   map[[32]byte]*client
+}
+
+// client tracks the requests of a client against its policy.
+type client struct {
+	name     string
+	policy   Policy
+	groups   map[string]bool
+	mu       sync.Mutex
+	bucket   *leakybucket.LeakyBucket
+	inFlight chan struct{}
+}
+
+// NewController returns a controller enforcing the access configuration at configPath, reloaded when the file
+// changes, and appending an audit record of each request as a JSON line to the file at auditPath.
+// Either path may be empty to disable access control or auditing.
+func NewController(ctx context.Context, configPath, auditPath string) (*Controller, error) {
+	c := &Controller{configPath: configPath}
+	c.clients.Store(&clients{})
+	if configPath != "" {
+		data, err := os.ReadFile(configPath) // #nosec G304
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read access config")
+		}
+		cfg, err := parseConfig(data)
+		if err != nil {
+			return nil, err
+		}
+		c.apply(cfg, sha256.Sum256(data))
+		go c.watch(ctx)
+	}
+	if auditPath != "" {
+		f, err := os.OpenFile(auditPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) // #nosec G304
+		if err != nil {
+			return nil, errors.Wrap(err, "could not open audit log")
+		}
+		c.audit = logrus.New()
+		c.audit.SetFormatter(&logrus.JSONFormatter{})
+		c.audit.SetOutput(f)
+		go func() {
+			<-ctx.Done()
+			if err := f.Close(); err != nil {
+				log.WithError(err).Error("Could not close audit log")
+			}
+		}()
+	}
+	return c, nil
+}
+
+// Middleware admits the requests allowed by the policy of their client and audits all of them. Requests whose
+// handler panics, like streams aborted with http.ErrAbortHandler, still release their concurrency slot and are
+// audited as aborted.
+func (c *Controller) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		group := RouteGroup(r)
+		aw := &auditWriter{ResponseWriter: w, status: http.StatusOK}
+		name, release, ok := c.admit(aw, r, group)
+		defer func() {
+			p := recover()
+			c.record(r, name, group, aw, start, p != nil)
+			if p != nil {
+				panic(p)
+			}
+		}()
+		if !ok {
+			return
+		}
+		defer release()
+		next.ServeHTTP(aw, r)
+	})
+}
+
+// record writes the audit record of a request, if auditing is enabled.
+func (c *Controller) record(r *http.Request, name, group string, aw *auditWriter, start time.Time, aborted bool) {
+	if c.audit == nil {
+		return
+	}
+	fields := logrus.Fields{
+		"client":      name,
+		"remote_addr": r.RemoteAddr,
+		"method":      r.Method,
+		"path":        r.URL.Path,
+		"group":       group,
+		"status":      aw.status,
+		"bytes":       aw.bytes,
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	if aborted {
+		fields["aborted"] = true
+	}
+	c.audit.WithFields(fields).Info("API request")
+}
+
+// admit checks the request against the policy of its client, writing an error response when it is not allowed.
+// The returned function must be called once an admitted request is served.
+func (c *Controller) admit(w http.ResponseWriter, r *http.Request, group string) (string, func(), bool) {
+	cs := c.clients.Load()
+	if !cs.enabled {
+		return anonymousName, func() {}, true
+	}
+	cl, code, msg := cs.authenticate(r.Header.Get("Authorization"))
+	if cl == nil {
+		httputil.HandleError(w, msg, code)
+		return "", nil, false
+	}
+	if !cl.groups[group] {
+		httputil.HandleError(w, fmt.Sprintf("Client %s may not call %s routes", cl.name, group), http.StatusForbidden)
+		return cl.name, nil, false
+	}
+	if !cl.allow() {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(1/cl.policy.RateLimit))))
+		httputil.HandleError(w, fmt.Sprintf("Client %s exceeded its rate limit", cl.name), http.StatusTooManyRequests)
+		return cl.name, nil, false
+	}
+	if !cl.acquire() {
+		httputil.HandleError(w, fmt.Sprintf("Client %s exceeded its limit of concurrent requests", cl.name), http.StatusTooManyRequests)
+		return cl.name, nil, false
+	}
+	return cl.name, cl.release, true
+}
+
+// authenticate returns the client of an Authorization header, or the status code and message of the error.
+func (cs *clients) authenticate(header string) (*client, int, string) {
+	if header == "" {
+		if cs.anonymous == nil {
+			return nil, http.StatusUnauthorized, "Unauthorized: no Authorization header passed"
+		}
+		return cs.anonymous, 0, ""
+	}
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return nil, http.StatusUnauthorized, "Unauthorized: Authorization header must be of the form Bearer {token}"
+	}
+	cl, ok := cs.byToken[sha256.Sum256([]byte(strings.TrimSpace(token)))]
+	if !ok {
+		return nil, http.StatusUnauthorized, "Unauthorized: token value is invalid"
+	}
+	return cl, 0, ""
+}
+
+func newClient(name string, policy Policy) *client {
+	cl := &client{name: name, policy: policy, groups: make(map[string]bool, len(policy.Groups))}
+	for _, g := range policy.Groups {
+		cl.groups[g] = true
+	}
+	if policy.RateLimit > 0 {
+		burst := policy.Burst
+		if burst == 0 {
+			burst = int64(math.Max(1, math.Ceil(policy.RateLimit)))
+		}
+		cl.bucket = leakybucket.NewLeakyBucket(policy.RateLimit, burst, time.Second)
+	}
+	if policy.MaxConcurrent > 0 {
+		cl.inFlight = make(chan struct{}, policy.MaxConcurrent)
+	}
+	return cl
+}
+
+// allow reports whether a request is within the rate limit of the client, counting it if it is.
+func (cl *client) allow() bool {
+	if cl.bucket == nil {
+		return true
+	}
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	return cl.bucket.Add(1) == 1
+}
+
+// acquire reserves one of the concurrent requests of the client.
+func (cl *client) acquire() bool {
+	if cl.inFlight == nil {
+		return true
+	}
+	select {
+	case cl.inFlight <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (cl *client) release() {
+	if cl.inFlight != nil {
+		<-cl.inFlight
+	}
+}
+
+// apply switches to a configuration. Clients whose name and policy did not change keep their rate and concurrency
+// counts.
+func (c *Controller) apply(cfg *Config, hash [32]byte) {
+	previous := make(map[string]*client)
+	old := c.clients.Load()
+	if old.anonymous != nil {
+		previous[old.anonymous.name] = old.anonymous
+	}
+	for _, cl := range old.byToken {
+		previous[cl.name] = cl
+	}
+	clientFor := func(name string, policy Policy) *client {
+		if cl, ok := previous[name]; ok && reflect.DeepEqual(cl.policy, policy) {
+			return cl
+		}
+		return newClient(name, policy)
+	}
+
+	cs := &clients{enabled: true, byToken: make(map[[32]byte]*client, len(cfg.Tokens))}
+	if cfg.Anonymous != nil {
+		cs.anonymous = clientFor(anonymousName, *cfg.Anonymous)
+	}
+	for _, t := range cfg.Tokens {
+		cs.byToken[sha256.Sum256([]byte(t.Token))] = clientFor(t.Name, t.Policy)
+	}
+	c.configHash = hash
+	c.clients.Store(cs)
+}
+
+// reload applies the configuration file when its content changed, keeping the current configuration when the file
+// is invalid.
+func (c *Controller) reload() {
+	data, err := os.ReadFile(c.configPath)
+	if err != nil {
+		log.WithError(err).Error("Could not read access config, keeping the previous one")
+		return
+	}
+	hash := sha256.Sum256(data)
+	if hash == c.configHash {
+		return
+	}
+	cfg, err := parseConfig(data)
+	if err != nil {
+		log.WithError(err).Error("Could not reload access config, keeping the previous one")
+		return
+	}
+	c.apply(cfg, hash)
+	log.WithField("tokens", len(cfg.Tokens)).Info("Reloaded access config")
+}
+
+// watch reloads the configuration on changes of its directory, which unlike watching the file itself also catches
+// files replaced by editors or swapped symlinks.
+func (c *Controller) watch(ctx context.Context) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.WithError(err).Error("Could not initialize access config watcher")
+		return
+	}
+	defer func() {
+		if err := watcher.Close(); err != nil {
+			log.WithError(err).Error("Could not close access config watcher")
+		}
+	}()
+	dir := filepath.Dir(c.configPath)
+	if err := watcher.Add(dir); err != nil {
+		log.WithError(err).Errorf("Could not watch directory %s", dir)
+		return
+	}
+	changes := make(chan interface{}, 100)
+	go async.Debounce(ctx, reloadDebounceInterval, changes, func(interface{}) {
+		c.reload()
+	})
+	for {
+		select {
+		case event := <-watcher.Events:
+			select {
+			case changes <- event:
+			default:
+			}
+		case err := <-watcher.Errors:
+			log.WithError(err).Error("Could not watch access config")
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// auditWriter records the status and size of a response.
+type auditWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *auditWriter) WriteHeader(statusCode int) {
+	w.status = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *auditWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+// Flush lets streaming handlers, such as the event stream, flush through the writer.
+func (w *auditWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying writer to http.ResponseController.
+func (w *auditWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
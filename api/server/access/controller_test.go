@@ -0,0 +1,219 @@
+package access
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+const testConfig = `
+anonymous:
+  groups: [read]
+tokens:
+  - name: staking
+    token: secret
+    groups: [read, validator]
+  - name: limited
+    token: limited-secret
+    groups: [read]
+    rate_limit: 1
+    burst: 2
+  - name: single
+    token: single-secret
+    groups: [read]
+    max_concurrent: 1
+`
+
+func newTestController(t *testing.T, config string, audit bool) (*Controller, string, string) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "access.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(config), 0600))
+	var auditPath string
+	if audit {
+		auditPath = filepath.Join(dir, "audit.log")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	c, err := NewController(ctx, configPath, auditPath)
+	require.NoError(t, err)
+	return c, configPath, auditPath
+}
+
+func serve(h http.Handler, method, path, token string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, "http://example.com"+path, nil)
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	writer := httptest.NewRecorder()
+	h.ServeHTTP(writer, request)
+	return writer
+}
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func TestController_Middleware(t *testing.T) {
+	c, _, _ := newTestController(t, testConfig, false)
+	h := c.Middleware(okHandler)
+
+	t.Run("anonymous read", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(h, http.MethodGet, "/eth/v1/beacon/genesis", "").Code)
+	})
+	t.Run("anonymous validator route", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, serve(h, http.MethodGet, "/eth/v1/validator/duties/proposer/1", "").Code)
+	})
+	t.Run("token validator route", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(h, http.MethodPost, "/eth/v2/beacon/blocks", "secret").Code)
+	})
+	t.Run("token debug route", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, serve(h, http.MethodGet, "/eth/v2/debug/beacon/states/head", "secret").Code)
+	})
+	t.Run("invalid token", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve(h, http.MethodGet, "/eth/v1/beacon/genesis", "foo").Code)
+	})
+	t.Run("invalid header", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/genesis", nil)
+		request.Header.Set("Authorization", "secret")
+		writer := httptest.NewRecorder()
+		h.ServeHTTP(writer, request)
+		assert.Equal(t, http.StatusUnauthorized, writer.Code)
+	})
+	t.Run("rate limit", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(h, http.MethodGet, "/eth/v1/beacon/genesis", "limited-secret").Code)
+		assert.Equal(t, http.StatusOK, serve(h, http.MethodGet, "/eth/v1/beacon/genesis", "limited-secret").Code)
+		writer := serve(h, http.MethodGet, "/eth/v1/beacon/genesis", "limited-secret")
+		assert.Equal(t, http.StatusTooManyRequests, writer.Code)
+		assert.Equal(t, "1", writer.Header().Get("Retry-After"))
+	})
+	t.Run("concurrency limit", func(t *testing.T) {
+		started, done := make(chan struct{}), make(chan struct{})
+		blocking := c.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			close(started)
+			<-done
+			w.WriteHeader(http.StatusOK)
+		}))
+		finished := make(chan int)
+		go func() {
+			finished <- serve(blocking, http.MethodGet, "/eth/v1/beacon/genesis", "single-secret").Code
+		}()
+		<-started
+		assert.Equal(t, http.StatusTooManyRequests, serve(h, http.MethodGet, "/eth/v1/beacon/genesis", "single-secret").Code)
+		close(done)
+		assert.Equal(t, http.StatusOK, <-finished)
+		assert.Equal(t, http.StatusOK, serve(h, http.MethodGet, "/eth/v1/beacon/genesis", "single-secret").Code)
+	})
+}
+
+func TestController_NoAnonymous(t *testing.T) {
+	c, _, _ := newTestController(t, "tokens:\n  - name: foo\n    token: secret\n    groups: [read]\n", false)
+	h := c.Middleware(okHandler)
+	assert.Equal(t, http.StatusUnauthorized, serve(h, http.MethodGet, "/eth/v1/beacon/genesis", "").Code)
+	assert.Equal(t, http.StatusOK, serve(h, http.MethodGet, "/eth/v1/beacon/genesis", "secret").Code)
+}
+
+func TestController_Reload(t *testing.T) {
+	c, configPath, _ := newTestController(t, testConfig, false)
+	h := c.Middleware(okHandler)
+	staking := c.clients.Load().byToken[sha256Token("secret")]
+
+	require.NoError(t, os.WriteFile(configPath, []byte(testConfig+`  - name: debugger
+    token: debug-secret
+    groups: [debug]
+`), 0600))
+	c.reload()
+	assert.Equal(t, http.StatusOK, serve(h, http.MethodGet, "/eth/v2/debug/beacon/states/head", "debug-secret").Code)
+	// Unchanged clients keep their state.
+	assert.Equal(t, staking, c.clients.Load().byToken[sha256Token("secret")])
+
+	require.NoError(t, os.WriteFile(configPath, []byte("tokens: [\n"), 0600))
+	c.reload()
+	assert.Equal(t, http.StatusOK, serve(h, http.MethodGet, "/eth/v2/debug/beacon/states/head", "debug-secret").Code)
+}
+
+func TestController_Audit(t *testing.T) {
+	c, _, auditPath := newTestController(t, testConfig, true)
+	h := c.Middleware(okHandler)
+	serve(h, http.MethodPost, "/eth/v1/beacon/pool/attestations", "secret")
+	serve(h, http.MethodPost, "/eth/v1/beacon/pool/attestations", "")
+
+	data, err := os.ReadFile(auditPath)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Equal(t, 2, len(lines))
+	records := make([]map[string]interface{}, len(lines))
+	for i, line := range lines {
+		require.NoError(t, json.Unmarshal([]byte(line), &records[i]))
+	}
+	assert.Equal(t, "staking", records[0]["client"])
+	assert.Equal(t, GroupValidator, records[0]["group"])
+	assert.Equal(t, "/eth/v1/beacon/pool/attestations", records[0]["path"])
+	assert.Equal(t, float64(http.StatusOK), records[0]["status"])
+	assert.Equal(t, anonymousName, records[1]["client"])
+	assert.Equal(t, float64(http.StatusForbidden), records[1]["status"])
+}
+
+func TestController_Abort(t *testing.T) {
+	c, _, auditPath := newTestController(t, testConfig, true)
+	aborting := c.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		panic(http.ErrAbortHandler)
+	}))
+	for i := 0; i < 2; i++ {
+		func() {
+			defer func() {
+				assert.Equal(t, http.ErrAbortHandler, recover())
+			}()
+			serve(aborting, http.MethodGet, "/eth/v1/beacon/genesis", "single-secret")
+		}()
+	}
+	// The aborted requests released their concurrency slot.
+	assert.Equal(t, http.StatusOK, serve(c.Middleware(okHandler), http.MethodGet, "/eth/v1/beacon/genesis", "single-secret").Code)
+
+	data, err := os.ReadFile(auditPath)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Equal(t, 3, len(lines))
+	for i, line := range lines {
+		record := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		assert.Equal(t, "single", record["client"])
+		assert.Equal(t, i < 2, record["aborted"] != nil)
+	}
+}
+
+func TestRouteGroup(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		group  string
+	}{
+		{http.MethodGet, "/eth/v1/beacon/blocks/head/root", GroupRead},
+		{http.MethodPost, "/eth/v1/beacon/states/head/validators", GroupRead},
+		{http.MethodPost, "/eth/v1/beacon/blocks", GroupValidator},
+		{http.MethodPost, "/eth/v2/beacon/blinded_blocks", GroupValidator},
+		{http.MethodGet, "/eth/v1/beacon/pool/attestations", GroupRead},
+		{http.MethodPost, "/eth/v1/beacon/pool/attestations", GroupValidator},
+		{http.MethodGet, "/eth/v3/validator/blocks/1", GroupValidator},
+		{http.MethodGet, "/eth/v2/debug/beacon/states/head", GroupDebug},
+		{http.MethodGet, "/prysm/v1/node/trusted_peers", GroupRead},
+		{http.MethodDelete, "/prysm/v1/node/trusted_peers/foo", GroupAdmin},
+	}
+	for _, tt := range tests {
+		request := httptest.NewRequest(tt.method, "http://example.com"+tt.path, nil)
+		assert.Equal(t, tt.group, RouteGroup(request), tt.method+" "+tt.path)
+	}
+}
+
+func sha256Token(token string) [32]byte {
+	return sha256.Sum256([]byte(token))
+}
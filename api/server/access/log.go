@@ -0,0 +1,5 @@
+package access
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "access")
@@ -0,0 +1,62 @@
+package access
+
+import (
+	"net/http"
+	"strings"
+)
+
+var (
+	debugPrefixes = []string{"/eth/v1/debug/", "/eth/v2/debug/", "/prysm/v1/debug/"}
+	// validatorPrefixes are the routes of validator clients, whatever the method.
+	validatorPrefixes = []string{"/eth/v1/validator/", "/eth/v2/validator/", "/eth/v3/validator/"}
+	// submissionPrefixes are the routes of validator clients when they are posted to.
+	submissionPrefixes = []string{
+		"/eth/v1/beacon/blocks", "/eth/v2/beacon/blocks",
+		"/eth/v1/beacon/blinded_blocks", "/eth/v2/beacon/blinded_blocks",
+		"/eth/v1/beacon/pool/", "/eth/v2/beacon/pool/",
+	}
+	// adminPrefixes are the routes changing the node when they are not read.
+	adminPrefixes = []string{"/prysm/node/", "/prysm/v1/node/"}
+)
+
+// RouteGroup returns the route group of a request.
+func RouteGroup(r *http.Request) string {
+	path := r.URL.Path
+	read := r.Method == http.MethodGet || r.Method == http.MethodHead
+	switch {
+	case hasPrefix(path, debugPrefixes):
+		return GroupDebug
+	case hasPrefix(path, validatorPrefixes):
+		return GroupValidator
+	case !read && hasSubmissionPrefix(path):
+		return GroupValidator
+	case !read && hasPrefix(path, adminPrefixes):
+		return GroupAdmin
+	default:
+		return GroupRead
+	}
+}
+
+func hasPrefix(path string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSubmissionPrefix matches the block publishing routes exactly, so that posted block queries such as
+// /eth/v1/beacon/blocks/{block_id}/... are not mistaken for submissions, and pool routes by prefix.
+func hasSubmissionPrefix(path string) bool {
+	for _, p := range submissionPrefixes {
+		if strings.HasSuffix(p, "/") {
+			if strings.HasPrefix(path, p) {
+				return true
+			}
+		} else if path == p {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package structs
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 
@@ -9,7 +10,9 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api/server"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/slashing"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/validator"
 	"github.com/prysmaticlabs/prysm/v5/container/slice"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
@@ -17,6 +20,7 @@ import (
 	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
 	ethv1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 )
 
 var errNilValue = errors.New("nil value")
@@ -377,6 +381,28 @@ func (a *AggregateAttestationAndProofElectra) ToConsensus() (*eth.AggregateAttes
 	}, nil
 }
 
+func SignedAggregateAttestationAndProofFromConsensus(s *eth.SignedAggregateAttestationAndProof) *SignedAggregateAttestationAndProof {
+	return &SignedAggregateAttestationAndProof{
+		Message: &AggregateAttestationAndProof{
+			AggregatorIndex: fmt.Sprintf("%d", s.Message.AggregatorIndex),
+			Aggregate:       AttFromConsensus(s.Message.Aggregate),
+			SelectionProof:  hexutil.Encode(s.Message.SelectionProof),
+		},
+		Signature: hexutil.Encode(s.Signature),
+	}
+}
+
+func SignedAggregateAttestationAndProofElectraFromConsensus(s *eth.SignedAggregateAttestationAndProofElectra) *SignedAggregateAttestationAndProofElectra {
+	return &SignedAggregateAttestationAndProofElectra{
+		Message: &AggregateAttestationAndProofElectra{
+			AggregatorIndex: fmt.Sprintf("%d", s.Message.AggregatorIndex),
+			Aggregate:       AttElectraFromConsensus(s.Message.Aggregate),
+			SelectionProof:  hexutil.Encode(s.Message.SelectionProof),
+		},
+		Signature: hexutil.Encode(s.Signature),
+	}
+}
+
 func (a *Attestation) ToConsensus() (*eth.Attestation, error) {
 	aggBits, err := hexutil.Decode(a.AggregationBits)
 	if err != nil {
@@ -1553,3 +1579,88 @@ func SyncAggregateFromConsensus(sa *eth.SyncAggregate) *SyncAggregate {
 		SyncCommitteeSignature: hexutil.Encode(sa.SyncCommitteeSignature),
 	}
 }
+
+func ReorgEventFromConsensus(e *forkchoice.ReorgEvent) *ReorgEvent {
+	branch := func(blocks []*forkchoice.ReorgBlock) []*ReorgBlock {
+		result := make([]*ReorgBlock, len(blocks))
+		for i, b := range blocks {
+			result[i] = &ReorgBlock{
+				Root:          hexutil.Encode(b.Root[:]),
+				Slot:          fmt.Sprintf("%d", b.Slot),
+				ProposerIndex: fmt.Sprintf("%d", b.ProposerIndex),
+				Weight:        fmt.Sprintf("%d", b.Weight),
+				ArrivalMillis: fmt.Sprintf("%d", b.ArrivalMillis),
+				Timely:        b.Timely,
+				ProposerBoost: b.ProposerBoost,
+			}
+		}
+		return result
+	}
+	atts := make([]*OrphanedAttestation, len(e.OrphanedAttestations))
+	for i, a := range e.OrphanedAttestations {
+		atts[i] = &OrphanedAttestation{
+			BlockRoot:       hexutil.Encode(a.BlockRoot[:]),
+			Slot:            fmt.Sprintf("%d", a.Slot),
+			CommitteeIndex:  fmt.Sprintf("%d", a.CommitteeIndex),
+			BeaconBlockRoot: hexutil.Encode(a.BeaconBlockRoot[:]),
+			Attesters:       fmt.Sprintf("%d", a.Attesters),
+		}
+		atts[i].AttestingIndices = make([]string, len(a.AttestingIndices))
+		for j, idx := range a.AttestingIndices {
+			atts[i].AttestingIndices[j] = fmt.Sprintf("%d", idx)
+		}
+	}
+	return &ReorgEvent{
+		Kind:                 e.Kind.String(),
+		Slot:                 fmt.Sprintf("%d", e.Slot),
+		Timestamp:            fmt.Sprintf("%d", e.Timestamp),
+		Reason:               e.Reason,
+		OldHeadRoot:          hexutil.Encode(e.OldHeadRoot[:]),
+		NewHeadRoot:          hexutil.Encode(e.NewHeadRoot[:]),
+		CommonAncestorRoot:   hexutil.Encode(e.CommonAncestorRoot[:]),
+		CommonAncestorSlot:   fmt.Sprintf("%d", e.CommonAncestorSlot),
+		Depth:                fmt.Sprintf("%d", e.Depth),
+		OldBranch:            branch(e.OldBranch),
+		NewBranch:            branch(e.NewBranch),
+		OrphanedAttestations: atts,
+		Truncated:            e.Truncated,
+	}
+}
+
+func SlashingEvidenceFromConsensus(e *slashing.Evidence) (*SlashingEvidence, error) {
+	indices := make([]string, len(e.ValidatorIndices))
+	for i, index := range e.ValidatorIndices {
+		indices[i] = fmt.Sprintf("%d", index)
+	}
+	result := &SlashingEvidence{
+		Root:             hexutil.Encode(e.Root[:]),
+		Epoch:            fmt.Sprintf("%d", e.Epoch),
+		ValidatorIndices: indices,
+		Source:           e.Source.String(),
+		Timestamp:        fmt.Sprintf("%d", e.Timestamp),
+		Included:         e.Included,
+	}
+	if e.Included {
+		result.InclusionSlot = fmt.Sprintf("%d", e.InclusionSlot)
+	}
+	if e.ProposerSlashing != nil {
+		result.ProposerSlashing = ProposerSlashingFromConsensus(e.ProposerSlashing)
+		return result, nil
+	}
+	var attesterSlashing any
+	switch s := e.AttesterSlashing.(type) {
+	case *eth.AttesterSlashing:
+		attesterSlashing = AttesterSlashingFromConsensus(s)
+	case *eth.AttesterSlashingElectra:
+		attesterSlashing = AttesterSlashingElectraFromConsensus(s)
+	default:
+		return nil, fmt.Errorf("unsupported attester slashing type %T", e.AttesterSlashing)
+	}
+	var err error
+	result.AttesterSlashing, err = json.Marshal(attesterSlashing)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal attester slashing")
+	}
+	result.Version = version.String(e.AttesterSlashing.Version())
+	return result, nil
+}
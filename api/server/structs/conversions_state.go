@@ -6,7 +6,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	beaconState "github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/diff"
 	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 )
 
 var errPayloadHeaderNotFound = errors.New("expected payload header not found")
@@ -775,3 +777,72 @@ func BeaconStateElectraFromConsensus(st beaconState.BeaconState) (*BeaconStateEl
 		PendingConsolidations:         PendingConsolidationsFromConsensus(pc),
 	}, nil
 }
+
+func StateDiffFromConsensus(d *diff.StateDiff) *StateDiff {
+	values := func(changes []*diff.Uint64Change) []*ValueDiff {
+		result := make([]*ValueDiff, len(changes))
+		for i, c := range changes {
+			result[i] = &ValueDiff{
+				Index: fmt.Sprintf("%d", c.Index),
+				Old:   fmt.Sprintf("%d", c.Old),
+				New:   fmt.Sprintf("%d", c.New),
+				Delta: fmt.Sprintf("%d", int64(c.New-c.Old)),
+			}
+		}
+		return result
+	}
+	result := &StateDiff{
+		FromSlot:         fmt.Sprintf("%d", d.FromSlot),
+		ToSlot:           fmt.Sprintf("%d", d.ToSlot),
+		FromVersion:      version.String(d.FromVersion),
+		ToVersion:        version.String(d.ToVersion),
+		ChangedFields:    d.ChangedFields,
+		Checkpoints:      make([]*CheckpointDiff, len(d.Checkpoints)),
+		Validators:       make([]*ValidatorDiff, len(d.Validators)),
+		Balances:         values(d.Balances),
+		InactivityScores: values(d.InactivityScores),
+		Participation:    make([]*ParticipationDiff, len(d.Participation)),
+		Queues:           make([]*QueueDiff, len(d.Queues)),
+		Truncated:        d.Truncated,
+	}
+	if d.Fork != nil {
+		result.Fork = &ForkDiff{Old: ForkFromConsensus(d.Fork.Old), New: ForkFromConsensus(d.Fork.New)}
+	}
+	if d.JustificationBits != nil {
+		result.JustificationBits = &JustificationBitsDiff{
+			Old: hexutil.Encode(d.JustificationBits.Old),
+			New: hexutil.Encode(d.JustificationBits.New),
+		}
+	}
+	for i, c := range d.Checkpoints {
+		result.Checkpoints[i] = &CheckpointDiff{
+			Name: c.Name,
+			Old:  CheckpointFromConsensus(c.Old),
+			New:  CheckpointFromConsensus(c.New),
+		}
+	}
+	for i, c := range d.Validators {
+		result.Validators[i] = &ValidatorDiff{Index: fmt.Sprintf("%d", c.Index), New: ValidatorFromConsensus(c.New)}
+		if c.Old != nil {
+			result.Validators[i].Old = ValidatorFromConsensus(c.Old)
+		}
+	}
+	for i, c := range d.Participation {
+		result.Participation[i] = &ParticipationDiff{
+			Index: fmt.Sprintf("%d", c.Index),
+			Epoch: c.Epoch,
+			Old:   fmt.Sprintf("%d", c.Old),
+			New:   fmt.Sprintf("%d", c.New),
+		}
+	}
+	for i, c := range d.Queues {
+		result.Queues[i] = &QueueDiff{
+			Name:      c.Name,
+			OldLength: fmt.Sprintf("%d", c.OldLength),
+			NewLength: fmt.Sprintf("%d", c.NewLength),
+			Removed:   fmt.Sprintf("%d", c.Removed),
+			Added:     fmt.Sprintf("%d", c.Added),
+		}
+	}
+	return result
+}
@@ -161,6 +161,27 @@ type Randao struct {
 	Randao string `json:"randao"`
 }
 
+type GetPendingDepositsResponse struct {
+	Version             string            `json:"version"`
+	ExecutionOptimistic bool              `json:"execution_optimistic"`
+	Finalized           bool              `json:"finalized"`
+	Data                []*PendingDeposit `json:"data"`
+}
+
+type GetPendingPartialWithdrawalsResponse struct {
+	Version             string                      `json:"version"`
+	ExecutionOptimistic bool                        `json:"execution_optimistic"`
+	Finalized           bool                        `json:"finalized"`
+	Data                []*PendingPartialWithdrawal `json:"data"`
+}
+
+type GetPendingConsolidationsResponse struct {
+	Version             string                  `json:"version"`
+	ExecutionOptimistic bool                    `json:"execution_optimistic"`
+	Finalized           bool                    `json:"finalized"`
+	Data                []*PendingConsolidation `json:"data"`
+}
+
 type GetSyncCommitteeResponse struct {
 	ExecutionOptimistic bool                     `json:"execution_optimistic"`
 	Finalized           bool                     `json:"finalized"`
@@ -250,3 +271,82 @@ type ChainHead struct {
 	PreviousJustifiedBlockRoot string `json:"previous_justified_block_root"`
 	OptimisticStatus           bool   `json:"optimistic_status"`
 }
+
+type GetReorgsResponse struct {
+	Data []*ReorgEvent `json:"data"`
+}
+
+type ReorgEvent struct {
+	Kind                 string                 `json:"kind"`
+	Slot                 string                 `json:"slot"`
+	Timestamp            string                 `json:"timestamp"`
+	Reason               string                 `json:"reason,omitempty"`
+	OldHeadRoot          string                 `json:"old_head_root"`
+	NewHeadRoot          string                 `json:"new_head_root"`
+	CommonAncestorRoot   string                 `json:"common_ancestor_root"`
+	CommonAncestorSlot   string                 `json:"common_ancestor_slot"`
+	Depth                string                 `json:"depth"`
+	OldBranch            []*ReorgBlock          `json:"old_branch"`
+	NewBranch            []*ReorgBlock          `json:"new_branch"`
+	OrphanedAttestations []*OrphanedAttestation `json:"orphaned_attestations"`
+	Truncated            bool                   `json:"truncated"`
+}
+
+type ReorgBlock struct {
+	Root          string `json:"root"`
+	Slot          string `json:"slot"`
+	ProposerIndex string `json:"proposer_index"`
+	Weight        string `json:"weight"`
+	ArrivalMillis string `json:"arrival_millis"`
+	Timely        bool   `json:"timely"`
+	ProposerBoost bool   `json:"proposer_boost"`
+}
+
+type OrphanedAttestation struct {
+	BlockRoot        string   `json:"block_root"`
+	Slot             string   `json:"slot"`
+	CommitteeIndex   string   `json:"committee_index"`
+	BeaconBlockRoot  string   `json:"beacon_block_root"`
+	Attesters        string   `json:"attesters"`
+	AttestingIndices []string `json:"attesting_indices"`
+}
+
+type GetAttestationInclusionResponse struct {
+	Data []*AttestationInclusion `json:"data"`
+}
+
+type AttestationInclusion struct {
+	Slot           string `json:"slot"`
+	InclusionSlot  string `json:"inclusion_slot"`
+	InclusionDelay string `json:"inclusion_delay"`
+	BlockRoot      string `json:"block_root"`
+	Canonical      bool   `json:"canonical"`
+}
+
+type GetPendingDepositEpochsResponse struct {
+	ExecutionOptimistic bool                   `json:"execution_optimistic"`
+	Finalized           bool                   `json:"finalized"`
+	Data                []*PendingDepositEpoch `json:"data"`
+}
+
+type PendingDepositEpoch struct {
+	QueueIndex      string          `json:"queue_index"`
+	ValidatorIndex  string          `json:"validator_index,omitempty"`
+	Deposit         *PendingDeposit `json:"deposit"`
+	ProcessingEpoch string          `json:"processing_epoch"`
+}
+
+type GetSszProofResponse struct {
+	Version             string    `json:"version"`
+	ExecutionOptimistic bool      `json:"execution_optimistic"`
+	Finalized           bool      `json:"finalized"`
+	Data                *SszProof `json:"data"`
+}
+
+type SszProof struct {
+	Path             string   `json:"path"`
+	Root             string   `json:"root"`
+	GeneralizedIndex string   `json:"gindex"`
+	Leaf             string   `json:"leaf"`
+	Branch           []string `json:"branch"`
+}
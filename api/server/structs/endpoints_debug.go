@@ -55,3 +55,68 @@ type ForkChoiceNodeExtraData struct {
 	ExecutionOptimistic      bool   `json:"execution_optimistic"`
 	TimeStamp                string `json:"timestamp"`
 }
+
+type GetStateDiffResponse struct {
+	Data *StateDiff `json:"data"`
+}
+
+type StateDiff struct {
+	FromSlot          string                 `json:"from_slot"`
+	ToSlot            string                 `json:"to_slot"`
+	FromVersion       string                 `json:"from_version"`
+	ToVersion         string                 `json:"to_version"`
+	ChangedFields     []string               `json:"changed_fields"`
+	Fork              *ForkDiff              `json:"fork,omitempty"`
+	JustificationBits *JustificationBitsDiff `json:"justification_bits,omitempty"`
+	Checkpoints       []*CheckpointDiff      `json:"checkpoints"`
+	Validators        []*ValidatorDiff       `json:"validators"`
+	Balances          []*ValueDiff           `json:"balances"`
+	InactivityScores  []*ValueDiff           `json:"inactivity_scores"`
+	Participation     []*ParticipationDiff   `json:"participation"`
+	Queues            []*QueueDiff           `json:"queues"`
+	Truncated         bool                   `json:"truncated"`
+}
+
+type ForkDiff struct {
+	Old *Fork `json:"old"`
+	New *Fork `json:"new"`
+}
+
+type JustificationBitsDiff struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+type CheckpointDiff struct {
+	Name string      `json:"name"`
+	Old  *Checkpoint `json:"old"`
+	New  *Checkpoint `json:"new"`
+}
+
+type ValidatorDiff struct {
+	Index string     `json:"index"`
+	Old   *Validator `json:"old"`
+	New   *Validator `json:"new"`
+}
+
+type ValueDiff struct {
+	Index string `json:"index"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+	Delta string `json:"delta"`
+}
+
+type ParticipationDiff struct {
+	Index string `json:"index"`
+	Epoch string `json:"epoch"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+type QueueDiff struct {
+	Name      string `json:"name"`
+	OldLength string `json:"old_length"`
+	NewLength string `json:"new_length"`
+	Removed   string `json:"removed"`
+	Added     string `json:"added"`
+}
@@ -100,3 +100,12 @@ type LightClientOptimisticUpdateEvent struct {
 	Version string                       `json:"version"`
 	Data    *LightClientOptimisticUpdate `json:"data"`
 }
+
+type PayloadVerificationDivergenceEvent struct {
+	BlockHash               string `json:"block_hash"`
+	BlockNumber             string `json:"block_number"`
+	PrimaryStatus           string `json:"primary_status"`
+	PrimaryLatestValidHash  string `json:"primary_latest_valid_hash"`
+	VerifierStatus          string `json:"verifier_status"`
+	VerifierLatestValidHash string `json:"verifier_latest_valid_hash"`
+}
@@ -71,3 +71,17 @@ type AddrRequest struct {
 type PeersResponse struct {
 	Peers []*Peer `json:"peers"`
 }
+
+type ExecutionClientsResponse struct {
+	Data []*ExecutionClient `json:"data"`
+}
+
+type ExecutionClient struct {
+	Endpoint       string `json:"endpoint"`
+	Primary        bool   `json:"primary"`
+	Healthy        bool   `json:"healthy"`
+	Syncing        bool   `json:"syncing"`
+	LastResponse   string `json:"last_response"`
+	UnhealthySince string `json:"unhealthy_since"`
+	LastError      string `json:"last_error"`
+}
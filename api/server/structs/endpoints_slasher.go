@@ -0,0 +1,20 @@
+package structs
+
+import "encoding/json"
+
+type GetSlashingEvidenceResponse struct {
+	Data []*SlashingEvidence `json:"data"`
+}
+
+type SlashingEvidence struct {
+	Root             string            `json:"root"`
+	Epoch            string            `json:"epoch"`
+	ValidatorIndices []string          `json:"validator_indices"`
+	Source           string            `json:"source"`
+	Timestamp        string            `json:"timestamp"`
+	Included         bool              `json:"included"`
+	InclusionSlot    string            `json:"inclusion_slot,omitempty"`
+	Version          string            `json:"version,omitempty"`
+	AttesterSlashing json.RawMessage   `json:"attester_slashing,omitempty"` // Accepts both `*AttesterSlashing` and `*AttesterSlashingElectra` types
+	ProposerSlashing *ProposerSlashing `json:"proposer_slashing,omitempty"`
+}
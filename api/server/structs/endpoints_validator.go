@@ -150,3 +150,74 @@ type ActiveSetChanges struct {
 	EjectedPublicKeys   []string `json:"ejected_public_keys"`
 	EjectedIndices      []string `json:"ejected_indices"`
 }
+
+type GetValidatorLifecyclesResponse struct {
+	Slot string                `json:"slot"`
+	Data []*ValidatorLifecycle `json:"data"`
+}
+
+type ValidatorLifecycle struct {
+	Index                       string `json:"index,omitempty"`
+	Pubkey                      string `json:"pubkey"`
+	Status                      string `json:"status"`
+	ActivationEligibilityEpoch  string `json:"activation_eligibility_epoch"`
+	ActivationEpoch             string `json:"activation_epoch"`
+	ExitEpoch                   string `json:"exit_epoch"`
+	WithdrawableEpoch           string `json:"withdrawable_epoch"`
+	ExitInitiated               bool   `json:"exit_initiated"`
+	PendingDepositQueuePosition string `json:"pending_deposit_queue_position,omitempty"`
+	ActivationQueuePosition     string `json:"activation_queue_position,omitempty"`
+	ExitQueuePosition           string `json:"exit_queue_position,omitempty"`
+	NextWithdrawalSlot          string `json:"next_withdrawal_slot,omitempty"`
+	WithdrawalSweepPosition     string `json:"withdrawal_sweep_position,omitempty"`
+}
+
+type GetValidatorHistoryRequest struct {
+	Ids        []string `json:"ids"`
+	StartEpoch string   `json:"start_epoch"`
+	EndEpoch   string   `json:"end_epoch"`
+}
+
+type ValidatorEpochHistory struct {
+	Epoch      string                  `json:"epoch"`
+	Validators []*ValidatorEpochRecord `json:"validators"`
+}
+
+type ValidatorEpochRecord struct {
+	Index            string `json:"index"`
+	Status           string `json:"status"`
+	Balance          string `json:"balance"`
+	EffectiveBalance string `json:"effective_balance"`
+}
+
+type GetValidatorRewardsRequest struct {
+	Ids        []string `json:"ids"`
+	StartEpoch string   `json:"start_epoch"`
+	EndEpoch   string   `json:"end_epoch"`
+}
+
+type GetValidatorRewardsResponse struct {
+	ExecutionOptimistic bool                     `json:"execution_optimistic"`
+	Finalized           bool                     `json:"finalized"`
+	Data                []*ValidatorEpochRewards `json:"data"`
+}
+
+type ValidatorEpochRewards struct {
+	Epoch      string             `json:"epoch"`
+	Validators []*ValidatorReward `json:"validators"`
+}
+
+type ValidatorReward struct {
+	ValidatorIndex         string  `json:"validator_index"`
+	Total                  string  `json:"total"`
+	Source                 string  `json:"source"`
+	Target                 string  `json:"target"`
+	Head                   string  `json:"head"`
+	Inactivity             string  `json:"inactivity"`
+	ProposerAttestations   string  `json:"proposer_attestations"`
+	ProposerSyncAggregate  string  `json:"proposer_sync_aggregate"`
+	ProposerSlashings      string  `json:"proposer_slashings"`
+	SyncCommitteeRewards   string  `json:"sync_committee_rewards"`
+	SyncCommitteePenalties string  `json:"sync_committee_penalties"`
+	ExecutionPayments      *string `json:"execution_payments"`
+}
@@ -0,0 +1,82 @@
+package blockchain
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+// blockAttestationInclusions returns the attestation inclusions of the given block to be recorded in the
+// attestation inclusion cache, or nil when the cache is disabled. The state must be the post state of the block.
+// Failures are only logged since inclusion tracking never prevents a block from being imported.
+func (s *Service) blockAttestationInclusions(ctx context.Context, root [32]byte, blk interfaces.ReadOnlyBeaconBlock, st state.ReadOnlyBeaconState) []*cache.AttestationInclusion {
+	if !s.cfg.AttestationInclusionCache.Enabled() {
+		return nil
+	}
+	incs, err := attestationInclusions(ctx, root, blk, st)
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"slot":      blk.Slot(),
+			"blockRoot": root,
+		}).Warn("Could not compute attestation inclusions")
+		return nil
+	}
+	return incs
+}
+
+// attestationInclusions splits the attestations of the block into one inclusion per committee. Aggregation bits of
+// Electra attestations span all the committees of their committee bits and are cut using the committee sizes.
+func attestationInclusions(ctx context.Context, root [32]byte, blk interfaces.ReadOnlyBeaconBlock, st state.ReadOnlyBeaconState) ([]*cache.AttestationInclusion, error) {
+	var incs []*cache.AttestationInclusion
+	for _, att := range blk.Body().Attestations() {
+		data := att.GetData()
+		dataRoot, err := data.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute attestation data root")
+		}
+		if att.Version() < version.Electra {
+			incs = append(incs, &cache.AttestationInclusion{
+				BlockRoot:       root,
+				BlockSlot:       blk.Slot(),
+				DataRoot:        dataRoot,
+				Slot:            data.Slot,
+				CommitteeIndex:  data.CommitteeIndex,
+				AggregationBits: att.GetAggregationBits(),
+			})
+			continue
+		}
+		committees, err := helpers.AttestationCommittees(ctx, st, att)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get attestation committees")
+		}
+		committeeIndices := att.CommitteeBitsVal().BitIndices()
+		bits := att.GetAggregationBits()
+		offset := uint64(0)
+		for i, committee := range committees {
+			committeeBits := bitfield.NewBitlist(uint64(len(committee)))
+			for j := range committee {
+				if bits.BitAt(offset + uint64(j)) {
+					committeeBits.SetBitAt(uint64(j), true)
+				}
+			}
+			offset += uint64(len(committee))
+			incs = append(incs, &cache.AttestationInclusion{
+				BlockRoot:       root,
+				BlockSlot:       blk.Slot(),
+				DataRoot:        dataRoot,
+				Slot:            data.Slot,
+				CommitteeIndex:  primitives.CommitteeIndex(committeeIndices[i]),
+				AggregationBits: committeeBits,
+			})
+		}
+	}
+	return incs, nil
+}
@@ -0,0 +1,84 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestAttestationInclusions_Phase0(t *testing.T) {
+	att := util.HydrateAttestation(&ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist{0b1101},
+		Data:            &ethpb.AttestationData{Slot: 3, CommitteeIndex: 2},
+	})
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 4
+	b.Block.Body.Attestations = []*ethpb.Attestation{att}
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+
+	incs, err := attestationInclusions(context.Background(), [32]byte{'a'}, blk.Block(), nil)
+	require.NoError(t, err)
+	dataRoot, err := att.Data.HashTreeRoot()
+	require.NoError(t, err)
+	assert.DeepEqual(t, []*cache.AttestationInclusion{{
+		BlockRoot:       [32]byte{'a'},
+		BlockSlot:       4,
+		DataRoot:        dataRoot,
+		Slot:            3,
+		CommitteeIndex:  2,
+		AggregationBits: bitfield.Bitlist{0b1101},
+	}}, incs)
+}
+
+func TestAttestationInclusions_Electra(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MinimalSpecConfig())
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateElectra(t, 256)
+	committees, err := helpers.BeaconCommittees(ctx, st, 1)
+	require.NoError(t, err)
+	require.Equal(t, true, len(committees) > 2)
+	size0, size2 := uint64(len(committees[0])), uint64(len(committees[2]))
+
+	committeeBits := bitfield.NewBitvector64()
+	committeeBits.SetBitAt(0, true)
+	committeeBits.SetBitAt(2, true)
+	aggregationBits := bitfield.NewBitlist(size0 + size2)
+	aggregationBits.SetBitAt(1, true)
+	aggregationBits.SetBitAt(size0+3, true)
+	att := util.HydrateAttestationElectra(&ethpb.AttestationElectra{
+		AggregationBits: aggregationBits,
+		CommitteeBits:   committeeBits,
+		Data:            &ethpb.AttestationData{Slot: 1},
+	})
+	b := util.NewBeaconBlockElectra()
+	b.Block.Slot = 2
+	b.Block.Body.Attestations = []*ethpb.AttestationElectra{att}
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+
+	incs, err := attestationInclusions(ctx, [32]byte{'a'}, blk.Block(), st)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(incs))
+	dataRoot, err := att.Data.HashTreeRoot()
+	require.NoError(t, err)
+
+	bits0 := bitfield.NewBitlist(size0)
+	bits0.SetBitAt(1, true)
+	bits2 := bitfield.NewBitlist(size2)
+	bits2.SetBitAt(3, true)
+	assert.DeepEqual(t, []*cache.AttestationInclusion{
+		{BlockRoot: [32]byte{'a'}, BlockSlot: 2, DataRoot: dataRoot, Slot: 1, CommitteeIndex: 0, AggregationBits: bits0},
+		{BlockRoot: [32]byte{'a'}, BlockSlot: 2, DataRoot: dataRoot, Slot: 1, CommitteeIndex: 2, AggregationBits: bits2},
+	}, incs)
+}
@@ -119,6 +119,12 @@ type OptimisticModeFetcher interface {
 	IsOptimisticForRoot(ctx context.Context, root [32]byte) (bool, error)
 }
 
+// PayloadDisputeFetcher retrieves whether blocks descend from an execution payload disputed by the
+// verification execution client.
+type PayloadDisputeFetcher interface {
+	IsDisputedBranch(ctx context.Context, root [32]byte) (bool, error)
+}
+
 // FinalizedCheckpt returns the latest finalized checkpoint from chain store.
 func (s *Service) FinalizedCheckpt() *ethpb.Checkpoint {
 	s.cfg.ForkChoiceStore.RLock()
@@ -146,7 +146,7 @@ func (s *Service) notifyForkchoiceUpdate(ctx context.Context, arg *fcuConfig) (*
 		}
 	}
 	forkchoiceUpdatedValidNodeCount.Inc()
-	if err := s.cfg.ForkChoiceStore.SetOptimisticToValid(ctx, arg.headRoot); err != nil {
+	if err := s.setOptimisticToValid(ctx, arg.headRoot); err != nil {
 		log.WithError(err).Error("Could not set head root to valid")
 		return nil, nil
 	}
@@ -275,6 +275,20 @@ func (s *Service) notifyNewPayload(ctx context.Context, preStateVersion int,
 	case err == nil:
 		newPayloadValidNodeCount.Inc()
 		return true, nil
+	case errors.Is(err, execution.ErrPayloadVerificationDivergence):
+		// The block is imported optimistically and stays so while its payload is disputed.
+		newPayloadOptimisticNodeCount.Inc()
+		root, err := blk.Block().HashTreeRoot()
+		if err != nil {
+			return false, errors.Wrap(err, "could not compute block root")
+		}
+		s.markDisputedBlock(root, blk.Block().Slot())
+		log.WithFields(logrus.Fields{
+			"slot":             blk.Block().Slot(),
+			"blockRoot":        fmt.Sprintf("%#x", root),
+			"payloadBlockHash": fmt.Sprintf("%#x", bytesutil.Trunc(payload.BlockHash())),
+		}).Error("Verification execution client disputes the payload, not attesting to its branch")
+		return false, nil
 	case errors.Is(err, execution.ErrAcceptedSyncingPayloadStatus):
 		newPayloadOptimisticNodeCount.Inc()
 		log.WithFields(logrus.Fields{
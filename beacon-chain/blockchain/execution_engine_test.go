@@ -562,6 +562,13 @@ func Test_NotifyNewPayload(t *testing.T) {
 			isValidPayload: false,
 			invalidBlock:   true,
 		},
+		{
+			name:           "new payload disputed by verification execution client",
+			postState:      bellatrixState,
+			blk:            bellatrixBlk,
+			newPayloadErr:  execution.ErrPayloadVerificationDivergence,
+			isValidPayload: false,
+		},
 		{
 			name:           "altair pre state, altair block",
 			postState:      bellatrixState,
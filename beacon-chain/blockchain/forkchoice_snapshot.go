@@ -0,0 +1,163 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+// saveForkchoiceSnapshot serializes fork choice and saves it to the DB.
+func (s *Service) saveForkchoiceSnapshot(ctx context.Context) error {
+	s.cfg.ForkChoiceStore.RLock()
+	snapshot, err := s.cfg.ForkChoiceStore.Snapshot()
+	s.cfg.ForkChoiceStore.RUnlock()
+	if err != nil {
+		return errors.Wrap(err, "could not snapshot fork choice")
+	}
+	return s.cfg.BeaconDB.SaveForkChoiceSnapshot(ctx, snapshot)
+}
+
+// runForkchoiceSnapshots periodically saves fork choice to the DB until the service is stopped.
+func (s *Service) runForkchoiceSnapshots() {
+	ticker := time.NewTicker(s.cfg.ForkchoiceSnapshotInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.saveForkchoiceSnapshot(s.ctx); err != nil {
+				log.WithError(err).Error("Could not save fork choice snapshot")
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting routine")
+			return
+		}
+	}
+}
+
+// restoreForkchoiceSnapshot restores fork choice from the snapshot saved in the DB. It returns false, and leaves
+// fork choice unchanged, if there is no snapshot or if the snapshot is stale: its finalized checkpoint differs from
+// the one in the DB, one of its blocks is missing from the DB, or the DB has blocks descending from the snapshot
+// which were saved after it was taken.
+// The caller of this function must have a lock on forkchoice.
+func (s *Service) restoreForkchoiceSnapshot(ctx context.Context, finalized *ethpb.Checkpoint) (bool, error) {
+	if s.cfg.ForkchoiceSnapshotInterval <= 0 {
+		return false, nil
+	}
+	snapshot, err := s.cfg.BeaconDB.ForkChoiceSnapshot(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "could not get fork choice snapshot")
+	}
+	if len(snapshot) == 0 {
+		return false, nil
+	}
+
+	// Decode the snapshot on the side, so that it can be checked against the DB before replacing fork choice.
+	scratch := doublylinkedtree.New()
+	if err := scratch.Restore(snapshot); err != nil {
+		return false, err
+	}
+	dump, err := scratch.ForkChoiceDump(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "could not dump fork choice snapshot")
+	}
+	if dump.FinalizedCheckpoint.Epoch != finalized.Epoch || !bytes.Equal(dump.FinalizedCheckpoint.Root, finalized.Root) {
+		log.WithFields(logrus.Fields{
+			"snapshotFinalizedEpoch": dump.FinalizedCheckpoint.Epoch,
+			"finalizedEpoch":         finalized.Epoch,
+		}).Info("Fork choice snapshot is stale, rebuilding fork choice from the finalized state")
+		return false, nil
+	}
+	for _, n := range dump.ForkChoiceNodes {
+		if !s.cfg.BeaconDB.HasBlock(ctx, bytesutil.ToBytes32(n.BlockRoot)) {
+			log.WithField("root", bytesutil.Trunc(n.BlockRoot)).Info("Fork choice snapshot references a block missing from the DB, rebuilding fork choice from the finalized state")
+			return false, nil
+		}
+	}
+	missing, err := s.blockMissingFromSnapshot(ctx, dump.ForkChoiceNodes)
+	if err != nil {
+		return false, err
+	}
+	if missing != nil {
+		log.WithField("root", bytesutil.Trunc(missing)).Info("Fork choice snapshot is missing a block saved after it was taken, rebuilding fork choice from the finalized state")
+		return false, nil
+	}
+
+	if err := s.cfg.ForkChoiceStore.Restore(snapshot); err != nil {
+		return false, err
+	}
+	s.cfg.ForkChoiceStore.SetGenesisTime(uint64(s.genesisTime.Unix()))
+	log.WithFields(logrus.Fields{
+		"nodes":          len(dump.ForkChoiceNodes),
+		"finalizedEpoch": finalized.Epoch,
+		"headRoot":       bytesutil.Trunc(dump.HeadRoot),
+	}).Info("Restored fork choice from snapshot")
+	return true, nil
+}
+
+// blockMissingFromSnapshot returns the root of the DB head block, or of a block descending from a snapshot node,
+// if it is not part of the snapshot. Blocks on branches which were pruned from fork choice are ignored. It returns
+// nil if the snapshot is up to date with the DB.
+func (s *Service) blockMissingFromSnapshot(ctx context.Context, nodes []*forkchoice.Node) ([]byte, error) {
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+	known := make(map[[32]byte]bool, len(nodes))
+	lowest := nodes[0].Slot
+	for _, n := range nodes {
+		known[bytesutil.ToBytes32(n.BlockRoot)] = true
+		if n.Slot < lowest {
+			lowest = n.Slot
+		}
+	}
+
+	headBlock, err := s.cfg.BeaconDB.HeadBlock(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head block")
+	}
+	if headBlock != nil && !headBlock.IsNil() {
+		headRoot, err := headBlock.Block().HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get head block root")
+		}
+		if headBlock.Block().Slot() > lowest && !known[headRoot] {
+			return headRoot[:], nil
+		}
+	}
+
+	highest, _, err := s.cfg.BeaconDB.HighestRootsBelowSlot(ctx, math.MaxUint64)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get highest block slot")
+	}
+	// Blocks are walked in slot order, so that the first block missing from the snapshot is found before its
+	// descendants, whose parents are then unknown as well.
+	for slot := lowest + 1; slot <= highest; slot++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		blks, err := s.cfg.BeaconDB.BlocksBySlot(ctx, slot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get blocks at slot %d", slot)
+		}
+		for _, b := range blks {
+			if !known[b.Block().ParentRoot()] {
+				continue
+			}
+			root, err := b.Block().HashTreeRoot()
+			if err != nil {
+				return nil, errors.Wrap(err, "could not get block root")
+			}
+			if !known[root] {
+				return root[:], nil
+			}
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,120 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestService_RestoreForkchoiceSnapshot(t *testing.T) {
+	genesis := util.NewBeaconBlock()
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	finalizedSlot := params.BeaconConfig().SlotsPerEpoch*2 + 1
+	finalizedBlock := util.NewBeaconBlock()
+	finalizedBlock.Block.Slot = finalizedSlot
+	finalizedBlock.Block.ParentRoot = bytesutil.PadTo(genesisRoot[:], 32)
+	finalizedRoot, err := finalizedBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+	finalizedState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, finalizedState.SetSlot(finalizedSlot))
+	require.NoError(t, finalizedState.SetGenesisValidatorsRoot(params.BeaconConfig().ZeroHash[:]))
+	finalized := &ethpb.Checkpoint{Epoch: slots.ToEpoch(finalizedSlot), Root: finalizedRoot[:]}
+
+	c, tr := minimalTestService(t, WithFinalizedStateAtStartUp(finalizedState), WithForkchoiceSnapshotInterval(time.Minute))
+	ctx, beaconDB, stateGen := tr.ctx, tr.db, tr.sg
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+	require.NoError(t, beaconDB.SaveState(ctx, finalizedState, genesisRoot))
+	require.NoError(t, beaconDB.SaveState(ctx, finalizedState, finalizedRoot))
+	util.SaveBlock(t, ctx, beaconDB, finalizedBlock)
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, finalized))
+	require.NoError(t, stateGen.SaveState(ctx, finalizedRoot, finalizedState))
+
+	// Without a snapshot, fork choice is rebuilt from the finalized state.
+	require.NoError(t, c.StartFromSavedState(finalizedState))
+	require.Equal(t, 1, c.cfg.ForkChoiceStore.NodeCount())
+	require.NoError(t, c.saveForkchoiceSnapshot(ctx))
+
+	restore := func() (bool, *doublylinkedtree.ForkChoice) {
+		fcs := doublylinkedtree.New()
+		fcs.SetBalancesByRooter(stateGen.ActiveNonSlashedBalancesByRoot)
+		c.cfg.ForkChoiceStore = fcs
+		fcs.Lock()
+		defer fcs.Unlock()
+		restored, err := c.restoreForkchoiceSnapshot(ctx, finalized)
+		require.NoError(t, err)
+		return restored, fcs
+	}
+	restored, fcs := restore()
+	require.Equal(t, true, restored)
+	require.Equal(t, true, fcs.HasNode(finalizedRoot))
+	require.Equal(t, finalized.Epoch, fcs.FinalizedCheckpoint().Epoch)
+
+	t.Run("stale finalized checkpoint", func(t *testing.T) {
+		fcs := doublylinkedtree.New()
+		c.cfg.ForkChoiceStore = fcs
+		fcs.Lock()
+		defer fcs.Unlock()
+		restored, err := c.restoreForkchoiceSnapshot(ctx, &ethpb.Checkpoint{Epoch: finalized.Epoch + 1, Root: finalizedRoot[:]})
+		require.NoError(t, err)
+		require.Equal(t, false, restored)
+		require.Equal(t, false, fcs.HasNode(finalizedRoot))
+	})
+	t.Run("block on a pruned branch", func(t *testing.T) {
+		orphan := util.NewBeaconBlock()
+		orphan.Block.Slot = finalizedSlot + 1
+		orphan.Block.ParentRoot = bytesutil.PadTo(genesisRoot[:], 32)
+		util.SaveBlock(t, ctx, beaconDB, orphan)
+		restored, fcs := restore()
+		require.Equal(t, true, restored)
+		require.Equal(t, true, fcs.HasNode(finalizedRoot))
+	})
+	t.Run("head saved after the snapshot", func(t *testing.T) {
+		head := util.NewBeaconBlock()
+		head.Block.Slot = finalizedSlot + 3
+		head.Block.ParentRoot = bytesutil.PadTo([]byte{'b'}, 32)
+		util.SaveBlock(t, ctx, beaconDB, head)
+		headRoot, err := head.Block.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: head.Block.Slot, Root: headRoot[:]}))
+		require.NoError(t, beaconDB.SaveHeadBlockRoot(ctx, headRoot))
+		restored, fcs := restore()
+		require.Equal(t, false, restored)
+		require.Equal(t, false, fcs.HasNode(finalizedRoot))
+		require.NoError(t, beaconDB.SaveHeadBlockRoot(ctx, finalizedRoot))
+	})
+	t.Run("block saved after the snapshot", func(t *testing.T) {
+		child := util.NewBeaconBlock()
+		child.Block.Slot = finalizedSlot + 2
+		child.Block.ParentRoot = bytesutil.PadTo(finalizedRoot[:], 32)
+		util.SaveBlock(t, ctx, beaconDB, child)
+		restored, fcs := restore()
+		require.Equal(t, false, restored)
+		require.Equal(t, false, fcs.HasNode(finalizedRoot))
+	})
+	t.Run("block missing from the DB", func(t *testing.T) {
+		missing := [32]byte{'a'}
+		st, roblock, err := prepareForkchoiceState(ctx, finalizedSlot+1, missing, finalizedRoot, [32]byte{'A'}, finalized, finalized)
+		require.NoError(t, err)
+		require.NoError(t, fcs.InsertNode(ctx, st, roblock))
+		c.cfg.ForkChoiceStore = fcs
+		require.NoError(t, c.saveForkchoiceSnapshot(ctx))
+		restored, fcs := restore()
+		require.Equal(t, false, restored)
+		require.Equal(t, false, fcs.HasNode(finalizedRoot))
+	})
+	t.Run("snapshots disabled", func(t *testing.T) {
+		c.cfg.ForkchoiceSnapshotInterval = 0
+		restored, _ := restore()
+		require.Equal(t, false, restored)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	payloadattribute "github.com/prysmaticlabs/prysm/v5/consensus-types/payload-attribute"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
@@ -120,6 +121,7 @@ func (s *Service) shouldOverrideFCU(newHeadRoot [32]byte, proposingSlot primitiv
 	if proposingSlot == currentSlot {
 		proposerHead := s.cfg.ForkChoiceStore.GetProposerHead()
 		if proposerHead != newHeadRoot {
+			s.recordLateBlockDecision(forkchoice.LateBlockReorgAttempt, newHeadRoot, "")
 			return true
 		}
 		log.WithFields(logrus.Fields{
@@ -128,8 +130,11 @@ func (s *Service) shouldOverrideFCU(newHeadRoot [32]byte, proposingSlot primitiv
 		}).Infof("Attempted late block reorg aborted due to attestations at %d seconds",
 			params.BeaconConfig().SecondsPerSlot)
 		lateBlockFailedAttemptSecondThreshold.Inc()
+		s.recordLateBlockDecision(forkchoice.LateBlockReorgRefused, newHeadRoot,
+			fmt.Sprintf("attestations at %d seconds", params.BeaconConfig().SecondsPerSlot))
 	} else {
 		if s.cfg.ForkChoiceStore.ShouldOverrideFCU() {
+			s.recordLateBlockDecision(forkchoice.LateBlockReorgAttempt, newHeadRoot, "")
 			return true
 		}
 		secs, err := slots.SecondsSinceSlotStart(currentSlot,
@@ -144,6 +149,8 @@ func (s *Service) shouldOverrideFCU(newHeadRoot [32]byte, proposingSlot primitiv
 			}).Infof("Attempted late block reorg aborted due to attestations at %d seconds",
 				doublylinkedtree.ProcessAttestationsThreshold)
 			lateBlockFailedAttemptFirstThreshold.Inc()
+			s.recordLateBlockDecision(forkchoice.LateBlockReorgRefused, newHeadRoot,
+				fmt.Sprintf("attestations at %d seconds", doublylinkedtree.ProcessAttestationsThreshold))
 		}
 	}
 	return false
@@ -130,6 +130,7 @@ func (s *Service) saveHead(ctx context.Context, newHeadRoot [32]byte, headBlock
 		}).Info("Chain reorg occurred")
 		reorgDistance.Observe(float64(dis))
 		reorgDepth.Observe(float64(dep))
+		s.recordReorg(oldHeadRoot, newHeadRoot, commonRoot, forkSlot, dep)
 
 		s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
 			Type: statefeed.Reorg,
@@ -1,6 +1,8 @@
 package blockchain
 
 import (
+	"time"
+
 	"github.com/prysmaticlabs/prysm/v5/async/event"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
@@ -16,6 +18,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 )
 
@@ -85,6 +88,14 @@ func WithTrackedValidatorsCache(c *cache.TrackedValidatorsCache) Option {
 	}
 }
 
+// WithAttestationInclusionCache for the attestation inclusions of imported blocks.
+func WithAttestationInclusionCache(c *cache.AttestationInclusionCache) Option {
+	return func(s *Service) error {
+		s.cfg.AttestationInclusionCache = c
+		return nil
+	}
+}
+
 // WithAttestationPool for attestation lifecycle after chain inclusion.
 func WithAttestationPool(p attestations.Pool) Option {
 	return func(s *Service) error {
@@ -109,6 +120,14 @@ func WithSlashingPool(p slashings.PoolManager) Option {
 	}
 }
 
+// WithSlashingArchive records the slashings included in canonical blocks.
+func WithSlashingArchive(a *slashings.Archive) Option {
+	return func(s *Service) error {
+		s.cfg.SlashingArchive = a
+		return nil
+	}
+}
+
 // WithBLSToExecPool to keep track of BLS to Execution address changes.
 func WithBLSToExecPool(p blstoexec.PoolManager) Option {
 	return func(s *Service) error {
@@ -205,3 +224,19 @@ func WithSyncChecker(checker Checker) Option {
 		return nil
 	}
 }
+
+// WithForkchoiceSnapshotInterval sets how often fork choice is saved to the DB. A zero interval disables snapshots.
+func WithForkchoiceSnapshotInterval(interval time.Duration) Option {
+	return func(s *Service) error {
+		s.cfg.ForkchoiceSnapshotInterval = interval
+		return nil
+	}
+}
+
+// WithReorgHistoryWindow sets for how many epochs reorg analytics are kept in the DB. A zero window disables them.
+func WithReorgHistoryWindow(window primitives.Epoch) Option {
+	return func(s *Service) error {
+		s.cfg.ReorgHistoryWindow = window
+		return nil
+	}
+}
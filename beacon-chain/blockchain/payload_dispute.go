@@ -0,0 +1,102 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// markDisputedBlock records a block whose execution payload was validated by the primary execution client
+// but found invalid by the verification execution client.
+func (s *Service) markDisputedBlock(root [32]byte, slot primitives.Slot) {
+	s.disputedBlocksLock.Lock()
+	defer s.disputedBlocksLock.Unlock()
+	s.disputedBlocks[root] = slot
+}
+
+// disputedBlocksCopy returns a copy of the disputed blocks so that fork choice can be
+// walked without holding the dispute lock.
+func (s *Service) disputedBlocksCopy() map[[32]byte]primitives.Slot {
+	s.disputedBlocksLock.RLock()
+	defer s.disputedBlocksLock.RUnlock()
+	disputed := make(map[[32]byte]primitives.Slot, len(s.disputedBlocks))
+	for r, slot := range s.disputedBlocks {
+		disputed[r] = slot
+	}
+	return disputed
+}
+
+// forgetDisputedBlocks removes the given blocks from the disputed blocks.
+func (s *Service) forgetDisputedBlocks(roots [][32]byte) {
+	if len(roots) == 0 {
+		return
+	}
+	s.disputedBlocksLock.Lock()
+	defer s.disputedBlocksLock.Unlock()
+	for _, r := range roots {
+		delete(s.disputedBlocks, r)
+	}
+}
+
+// IsDisputedBranch returns true if the block is, or descends from, a block whose execution payload is disputed
+// by the verification execution client. Disputed blocks older than the finalized checkpoint are forgotten.
+func (s *Service) IsDisputedBranch(ctx context.Context, root [32]byte) (bool, error) {
+	disputed := s.disputedBlocksCopy()
+	if len(disputed) == 0 {
+		return false, nil
+	}
+	s.cfg.ForkChoiceStore.RLock()
+	isDisputed, stale, err := s.isDisputedBranch(ctx, root, disputed)
+	s.cfg.ForkChoiceStore.RUnlock()
+	s.forgetDisputedBlocks(stale)
+	return isDisputed, err
+}
+
+// isDisputedBranch checks the block against the given disputed blocks and returns the ones
+// older than the finalized checkpoint. The caller must hold the fork choice lock.
+func (s *Service) isDisputedBranch(ctx context.Context, root [32]byte, disputed map[[32]byte]primitives.Slot) (bool, [][32]byte, error) {
+	finalizedSlot, err := slots.EpochStart(s.cfg.ForkChoiceStore.FinalizedCheckpoint().Epoch)
+	if err != nil {
+		return false, nil, err
+	}
+	var stale [][32]byte
+	for r, slot := range disputed {
+		if slot < finalizedSlot {
+			stale = append(stale, r)
+			continue
+		}
+		if r == root {
+			return true, stale, nil
+		}
+		ancestor, err := s.cfg.ForkChoiceStore.AncestorRoot(ctx, root, slot)
+		if err != nil {
+			return false, stale, errors.Wrap(err, "could not get ancestor root")
+		}
+		if ancestor == r {
+			return true, stale, nil
+		}
+	}
+	return false, stale, nil
+}
+
+// setOptimisticToValid marks the block and its ancestors as fully validated in fork choice, unless the
+// block is in a disputed branch, in which case the branch stays optimistic. The caller must hold the
+// fork choice lock.
+func (s *Service) setOptimisticToValid(ctx context.Context, root [32]byte) error {
+	disputed := s.disputedBlocksCopy()
+	if len(disputed) > 0 {
+		isDisputed, stale, err := s.isDisputedBranch(ctx, root, disputed)
+		s.forgetDisputedBlocks(stale)
+		if err != nil {
+			return errors.Wrap(err, "could not check if block is in a disputed branch")
+		}
+		if isDisputed {
+			log.WithField("blockRoot", fmt.Sprintf("%#x", root)).Debug("Keeping disputed branch optimistic")
+			return nil
+		}
+	}
+	return s.cfg.ForkChoiceStore.SetOptimisticToValid(ctx, root)
+}
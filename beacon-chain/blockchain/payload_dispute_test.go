@@ -0,0 +1,98 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestService_IsDisputedBranch(t *testing.T) {
+	ctx := context.Background()
+	c := &Service{
+		cfg:            &config{ForkChoiceStore: doublylinkedtree.New()},
+		disputedBlocks: make(map[[32]byte]primitives.Slot),
+	}
+	ojc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	ofc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	// a <- b <- c
+	//  \
+	//   <- d
+	for _, n := range []struct {
+		slot         primitives.Slot
+		root, parent [32]byte
+	}{
+		{100, [32]byte{'a'}, [32]byte{}},
+		{101, [32]byte{'b'}, [32]byte{'a'}},
+		{102, [32]byte{'c'}, [32]byte{'b'}},
+		{101, [32]byte{'d'}, [32]byte{'a'}},
+	} {
+		st, roblock, err := prepareForkchoiceState(ctx, n.slot, n.root, n.parent, params.BeaconConfig().ZeroHash, ojc, ofc)
+		require.NoError(t, err)
+		require.NoError(t, c.cfg.ForkChoiceStore.InsertNode(ctx, st, roblock))
+	}
+
+	disputed, err := c.IsDisputedBranch(ctx, [32]byte{'c'})
+	require.NoError(t, err)
+	require.Equal(t, false, disputed)
+
+	c.markDisputedBlock([32]byte{'b'}, 101)
+	for root, want := range map[[32]byte]bool{
+		{'a'}: false,
+		{'b'}: true,
+		{'c'}: true,
+		{'d'}: false,
+	} {
+		disputed, err := c.IsDisputedBranch(ctx, root)
+		require.NoError(t, err)
+		require.Equal(t, want, disputed)
+	}
+}
+
+func TestService_SetOptimisticToValidDisputedBranch(t *testing.T) {
+	ctx := context.Background()
+	c := &Service{
+		cfg:            &config{ForkChoiceStore: doublylinkedtree.New()},
+		disputedBlocks: make(map[[32]byte]primitives.Slot),
+	}
+	ojc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	ofc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	// a <- b <- c
+	//  \
+	//   <- d
+	for _, n := range []struct {
+		slot         primitives.Slot
+		root, parent [32]byte
+	}{
+		{100, [32]byte{'a'}, [32]byte{}},
+		{101, [32]byte{'b'}, [32]byte{'a'}},
+		{102, [32]byte{'c'}, [32]byte{'b'}},
+		{101, [32]byte{'d'}, [32]byte{'a'}},
+	} {
+		st, roblock, err := prepareForkchoiceState(ctx, n.slot, n.root, n.parent, params.BeaconConfig().ZeroHash, ojc, ofc)
+		require.NoError(t, err)
+		require.NoError(t, c.cfg.ForkChoiceStore.InsertNode(ctx, st, roblock))
+	}
+	c.markDisputedBlock([32]byte{'b'}, 101)
+
+	require.NoError(t, c.setOptimisticToValid(ctx, [32]byte{'c'}))
+	for _, root := range [][32]byte{{'b'}, {'c'}} {
+		optimistic, err := c.cfg.ForkChoiceStore.IsOptimistic(root)
+		require.NoError(t, err)
+		require.Equal(t, true, optimistic)
+	}
+
+	require.NoError(t, c.setOptimisticToValid(ctx, [32]byte{'d'}))
+	for _, root := range [][32]byte{{'a'}, {'d'}} {
+		optimistic, err := c.cfg.ForkChoiceStore.IsOptimistic(root)
+		require.NoError(t, err)
+		require.Equal(t, false, optimistic)
+	}
+	optimistic, err := c.cfg.ForkChoiceStore.IsOptimistic([32]byte{'b'})
+	require.NoError(t, err)
+	require.Equal(t, true, optimistic)
+}
@@ -43,6 +43,7 @@ type BlockReceiver interface {
 	HasBlock(ctx context.Context, root [32]byte) bool
 	RecentBlockSlot(root [32]byte) (primitives.Slot, error)
 	BlockBeingSynced([32]byte) bool
+	RecordBlockArrival(root [32]byte, t time.Time)
 }
 
 // BlobReceiver interface defines the methods of chain service for receiving new
@@ -394,6 +395,9 @@ func (s *Service) prunePostBlockOperationPools(ctx context.Context, blk interfac
 	for _, ps := range blk.Block().Body().ProposerSlashings() {
 		s.cfg.SlashingPool.MarkIncludedProposerSlashing(ps)
 	}
+	if s.cfg.SlashingArchive != nil {
+		s.cfg.SlashingArchive.RecordIncludedSlashings(ctx, blk)
+	}
 
 	return nil
 }
@@ -0,0 +1,268 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// lateBlockDecision identifies the last late block reorg decision that was recorded, so that the
+// repeated calls to shouldOverrideFCU for the same head do not record it more than once.
+type lateBlockDecision struct {
+	root [32]byte
+	kind forkchoice.ReorgKind
+}
+
+// reorgEventsBufferSize is the number of reorg events that can be waiting to be completed and saved.
+const reorgEventsBufferSize = 64
+
+// blockArrivalsSize is the number of gossip arrival times of blocks kept for reorg analytics.
+const blockArrivalsSize = 256
+
+// RecordBlockArrival records the time a block was received over gossip, so that reorg analytics
+// report its arrival with millisecond precision.
+func (s *Service) RecordBlockArrival(root [32]byte, t time.Time) {
+	if s.cfg.ReorgHistoryWindow == 0 {
+		return
+	}
+	s.blockArrivals.Add(root, t)
+}
+
+// recordReorg records the attribution data of a chain reorg from oldHeadRoot to newHeadRoot.
+// Caller of the method MUST acquire a lock on forkchoice.
+func (s *Service) recordReorg(oldHeadRoot, newHeadRoot, commonRoot [32]byte, forkSlot primitives.Slot, depth uint64) {
+	if s.cfg.ReorgHistoryWindow == 0 {
+		return
+	}
+	event := &forkchoice.ReorgEvent{
+		Kind:               forkchoice.ChainReorg,
+		OldHeadRoot:        oldHeadRoot,
+		NewHeadRoot:        newHeadRoot,
+		CommonAncestorRoot: commonRoot,
+		CommonAncestorSlot: forkSlot,
+		Depth:              depth,
+	}
+	s.queueReorgEvent(event)
+}
+
+// recordLateBlockDecision records the attribution data of the decision to reorg, or to keep, the late
+// head block headRoot.
+// Caller of the method MUST acquire a lock on forkchoice.
+func (s *Service) recordLateBlockDecision(kind forkchoice.ReorgKind, headRoot [32]byte, reason string) {
+	if s.cfg.ReorgHistoryWindow == 0 {
+		return
+	}
+	decision := lateBlockDecision{root: headRoot, kind: kind}
+	if s.lastLateBlockDecision == decision {
+		return
+	}
+	s.lastLateBlockDecision = decision
+	parentRoot, err := s.cfg.ForkChoiceStore.ParentRoot(headRoot)
+	if err != nil {
+		log.WithError(err).Error("Could not record late block reorg decision")
+		return
+	}
+	parentSlot, err := s.cfg.ForkChoiceStore.Slot(parentRoot)
+	if err != nil {
+		log.WithError(err).Error("Could not record late block reorg decision")
+		return
+	}
+	event := &forkchoice.ReorgEvent{
+		Kind:               kind,
+		Reason:             reason,
+		OldHeadRoot:        headRoot,
+		NewHeadRoot:        parentRoot,
+		CommonAncestorRoot: parentRoot,
+		CommonAncestorSlot: parentSlot,
+		Depth:              1,
+	}
+	s.queueReorgEvent(event)
+}
+
+// queueReorgEvent sets the fields of the event that are read from fork choice, and queues it to be
+// completed from the DB and saved in the background. The event is dropped if the queue is full.
+// Caller of the method MUST acquire a lock on forkchoice.
+func (s *Service) queueReorgEvent(event *forkchoice.ReorgEvent) {
+	event.Slot = s.CurrentSlot()
+	event.Timestamp = uint64(time.Now().Unix())
+	boosted := map[[32]byte]bool{
+		s.cfg.ForkChoiceStore.ProposerBoost():         true,
+		s.cfg.ForkChoiceStore.PreviousProposerBoost(): true,
+	}
+	delete(boosted, [32]byte{})
+	var oldTruncated, newTruncated bool
+	event.OldBranch, oldTruncated = s.reorgBranch(event.OldHeadRoot, event.CommonAncestorRoot, boosted)
+	event.NewBranch, newTruncated = s.reorgBranch(event.NewHeadRoot, event.CommonAncestorRoot, boosted)
+	event.Truncated = oldTruncated || newTruncated
+	select {
+	case s.reorgEvents <- event:
+	default:
+		log.WithField("oldHeadRoot", fmt.Sprintf("%#x", event.OldHeadRoot)).Warn("Dropping reorg event, too many events are waiting to be saved")
+	}
+}
+
+// reorgBranch returns the blocks from root down to the ancestor root, excluded, as known to fork
+// choice. The walk is bounded to one epoch worth of blocks, the branch is reported as truncated
+// when the ancestor is not reached.
+// Caller of the method MUST acquire a lock on forkchoice.
+func (s *Service) reorgBranch(root, ancestor [32]byte, boosted map[[32]byte]bool) ([]*forkchoice.ReorgBlock, bool) {
+	var branch []*forkchoice.ReorgBlock
+	boostThreshold := params.BeaconConfig().SecondsPerSlot * 1000 / params.BeaconConfig().IntervalsPerSlot
+	genesis := uint64(s.genesisTime.Unix())
+	for root != ancestor {
+		if uint64(len(branch)) >= uint64(params.BeaconConfig().SlotsPerEpoch) {
+			return branch, true
+		}
+		n, err := s.cfg.ForkChoiceStore.NodeDump(root)
+		if err != nil {
+			return branch, true
+		}
+		b := &forkchoice.ReorgBlock{
+			Root:          root,
+			Slot:          n.Slot,
+			Weight:        n.Weight,
+			ProposerBoost: boosted[root],
+		}
+		if millis, ok := s.arrivalMillis(root, n.Slot, n.Timestamp, genesis); ok {
+			b.ArrivalMillis = millis
+			b.Timely = millis < boostThreshold
+		}
+		branch = append(branch, b)
+		root = bytesutil.ToBytes32(n.ParentRoot)
+	}
+	return branch, false
+}
+
+// arrivalMillis returns the number of milliseconds between the start of the slot and the arrival
+// of the block, received over gossip, or inserted in fork choice at the given timestamp otherwise.
+func (s *Service) arrivalMillis(root [32]byte, slot primitives.Slot, timestamp, genesis uint64) (uint64, bool) {
+	slotStart, err := slots.ToTime(genesis, slot)
+	if err != nil {
+		return 0, false
+	}
+	if v, ok := s.blockArrivals.Get(root); ok {
+		if t, ok := v.(time.Time); ok && !t.Before(slotStart) {
+			return uint64(t.Sub(slotStart).Milliseconds()), true
+		}
+	}
+	secs, err := slots.SecondsSinceSlotStart(slot, genesis, timestamp)
+	if err != nil {
+		return 0, false
+	}
+	return secs * 1000, true
+}
+
+// runReorgAnalytics completes and saves the queued reorg events until the service is stopped.
+func (s *Service) runReorgAnalytics() {
+	for {
+		select {
+		case event := <-s.reorgEvents:
+			s.processReorgEvent(s.ctx, event)
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting routine")
+			return
+		}
+	}
+}
+
+// processReorgEvent completes the event with the data read from the DB, and saves it.
+func (s *Service) processReorgEvent(ctx context.Context, event *forkchoice.ReorgEvent) {
+	if err := s.completeReorgEvent(ctx, event); err != nil {
+		log.WithError(err).WithField("kind", event.Kind.String()).Error("Could not record reorg event")
+		return
+	}
+	s.saveReorgEvent(ctx, event)
+}
+
+// completeReorgEvent sets the proposers of the branch blocks and the attestations orphaned by the
+// event, with their attesting indices read from the post-state of the orphaned block.
+func (s *Service) completeReorgEvent(ctx context.Context, event *forkchoice.ReorgEvent) error {
+	for _, b := range event.NewBranch {
+		blk, err := s.getBlock(ctx, b.Root)
+		if err != nil {
+			return err
+		}
+		b.ProposerIndex = blk.Block().ProposerIndex()
+	}
+	for _, b := range event.OldBranch {
+		blk, err := s.getBlock(ctx, b.Root)
+		if err != nil {
+			return err
+		}
+		b.ProposerIndex = blk.Block().ProposerIndex()
+		if event.Kind == forkchoice.LateBlockReorgRefused {
+			// The late block stays canonical, so its attestations are not orphaned.
+			continue
+		}
+		atts := blk.Block().Body().Attestations()
+		if len(atts) == 0 {
+			continue
+		}
+		st, err := s.cfg.StateGen.StateByRoot(ctx, b.Root)
+		if err != nil {
+			log.WithError(err).WithField("root", fmt.Sprintf("%#x", b.Root)).Debug("Could not get state of orphaned block, attesting indices are not recorded")
+			st = nil
+		}
+		for _, a := range atts {
+			committee, err := a.GetCommitteeIndex()
+			if err != nil {
+				committee = a.GetData().CommitteeIndex
+			}
+			orphaned := &forkchoice.OrphanedAttestation{
+				BlockRoot:       b.Root,
+				Slot:            a.GetData().Slot,
+				CommitteeIndex:  committee,
+				BeaconBlockRoot: bytesutil.ToBytes32(a.GetData().BeaconBlockRoot),
+				Attesters:       a.GetAggregationBits().Count(),
+			}
+			if st != nil {
+				indices, err := attestingIndices(ctx, st, a)
+				if err != nil {
+					log.WithError(err).WithField("root", fmt.Sprintf("%#x", b.Root)).Debug("Could not get attesting indices of orphaned attestation")
+				}
+				orphaned.AttestingIndices = indices
+			}
+			event.OrphanedAttestations = append(event.OrphanedAttestations, orphaned)
+		}
+	}
+	return nil
+}
+
+// attestingIndices returns the indices of the validators attesting in a, according to the committees of st.
+func attestingIndices(ctx context.Context, st state.ReadOnlyBeaconState, a ethpb.Att) ([]uint64, error) {
+	committees, err := helpers.AttestationCommittees(ctx, st, a)
+	if err != nil {
+		return nil, err
+	}
+	return attestation.AttestingIndices(a, committees...)
+}
+
+// saveReorgEvent saves the event and prunes the events that fell out of the configured history window.
+func (s *Service) saveReorgEvent(ctx context.Context, event *forkchoice.ReorgEvent) {
+	if err := s.cfg.BeaconDB.SaveReorgEvent(ctx, event); err != nil {
+		log.WithError(err).Error("Could not save reorg event")
+		return
+	}
+	epoch := slots.ToEpoch(event.Slot)
+	if epoch <= s.cfg.ReorgHistoryWindow {
+		return
+	}
+	start, err := slots.EpochStart(epoch - s.cfg.ReorgHistoryWindow)
+	if err != nil {
+		log.WithError(err).Error("Could not compute reorg history window")
+		return
+	}
+	if err := s.cfg.BeaconDB.DeleteReorgEventsBefore(ctx, start); err != nil {
+		log.WithError(err).WithField("slot", fmt.Sprintf("%d", start)).Error("Could not prune reorg events")
+	}
+}
@@ -0,0 +1,144 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestService_RecordReorgEvents(t *testing.T) {
+	service, tr := minimalTestService(t, WithReorgHistoryWindow(1))
+	ctx, beaconDB, fcs := tr.ctx, tr.db, tr.fcs
+	service.genesisTime = time.Now().Truncate(time.Second).Add(-2 * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	fcs.SetGenesisTime(uint64(service.genesisTime.Unix()))
+	jc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+
+	//     G
+	//    / \
+	//   B   C
+	insert := func(b *ethpb.SignedBeaconBlock) [32]byte {
+		util.SaveBlock(t, ctx, beaconDB, b)
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		st, roblock, err := prepareForkchoiceState(ctx, b.Block.Slot, root, [32]byte(b.Block.ParentRoot), [32]byte{}, jc, jc)
+		require.NoError(t, err)
+		require.NoError(t, fcs.InsertNode(ctx, st, roblock))
+		return root
+	}
+	genesis := util.NewBeaconBlock()
+	genesisRoot := insert(genesis)
+	orphaned := util.NewBeaconBlock()
+	orphaned.Block.Slot = 1
+	orphaned.Block.ProposerIndex = 3
+	orphaned.Block.ParentRoot = genesisRoot[:]
+	aggregationBits := bitfield.NewBitlist(8)
+	aggregationBits.SetBitAt(1, true)
+	aggregationBits.SetBitAt(3, true)
+	att := util.HydrateAttestation(&ethpb.Attestation{AggregationBits: aggregationBits})
+	orphaned.Block.Body.Attestations = []*ethpb.Attestation{att}
+	orphanedRoot := insert(orphaned)
+	newHead := util.NewBeaconBlock()
+	newHead.Block.Slot = 2
+	newHead.Block.ProposerIndex = 5
+	newHead.Block.ParentRoot = genesisRoot[:]
+	newHeadRoot := insert(newHead)
+	// The attesting indices of orphaned attestations are read from the post-state of the orphaned block.
+	orphanedState, _ := util.DeterministicGenesisState(t, 256)
+	require.NoError(t, orphanedState.SetSlot(1))
+	require.NoError(t, beaconDB.SaveState(ctx, orphanedState, orphanedRoot))
+	committee, err := helpers.BeaconCommitteeFromState(ctx, orphanedState, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 8, len(committee))
+
+	// Events are queued under the fork choice lock, and completed and saved in the background.
+	processQueued := func() {
+		for len(service.reorgEvents) > 0 {
+			service.processReorgEvent(ctx, <-service.reorgEvents)
+		}
+	}
+	// The orphaned block was received over gossip late in its slot.
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	service.RecordBlockArrival(orphanedRoot, service.genesisTime.Add(slotDuration+4500*time.Millisecond))
+	service.recordReorg(orphanedRoot, newHeadRoot, genesisRoot, 0, 2)
+	service.recordLateBlockDecision(forkchoice.LateBlockReorgAttempt, newHeadRoot, "")
+	service.recordLateBlockDecision(forkchoice.LateBlockReorgAttempt, newHeadRoot, "")
+	service.recordLateBlockDecision(forkchoice.LateBlockReorgRefused, orphanedRoot, "attestations at 10 seconds")
+	require.Equal(t, 3, len(service.reorgEvents))
+	processQueued()
+
+	events, err := beaconDB.ReorgEvents(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(events))
+
+	reorg := events[0]
+	require.Equal(t, forkchoice.ChainReorg, reorg.Kind)
+	require.Equal(t, primitives.Slot(2), reorg.Slot)
+	require.Equal(t, genesisRoot, reorg.CommonAncestorRoot)
+	require.Equal(t, uint64(2), reorg.Depth)
+	require.Equal(t, false, reorg.Truncated)
+	require.Equal(t, 1, len(reorg.OldBranch))
+	old := reorg.OldBranch[0]
+	require.Equal(t, orphanedRoot, old.Root)
+	require.Equal(t, primitives.ValidatorIndex(3), old.ProposerIndex)
+	require.Equal(t, uint64(4500), old.ArrivalMillis)
+	require.Equal(t, false, old.Timely)
+	require.Equal(t, false, old.ProposerBoost)
+	require.Equal(t, 1, len(reorg.NewBranch))
+	require.Equal(t, newHeadRoot, reorg.NewBranch[0].Root)
+	require.Equal(t, primitives.ValidatorIndex(5), reorg.NewBranch[0].ProposerIndex)
+	require.Equal(t, true, reorg.NewBranch[0].Timely)
+	require.Equal(t, true, reorg.NewBranch[0].ProposerBoost)
+	require.DeepEqual(t, []*forkchoice.OrphanedAttestation{{
+		BlockRoot:        orphanedRoot,
+		Attesters:        2,
+		AttestingIndices: []uint64{uint64(committee[1]), uint64(committee[3])},
+	}}, reorg.OrphanedAttestations)
+
+	// The attempt is recorded once even though the decision was taken twice.
+	attempt := events[1]
+	require.Equal(t, forkchoice.LateBlockReorgAttempt, attempt.Kind)
+	require.Equal(t, newHeadRoot, attempt.OldHeadRoot)
+	require.Equal(t, genesisRoot, attempt.NewHeadRoot)
+	require.Equal(t, 1, len(attempt.OldBranch))
+	require.Equal(t, 0, len(attempt.NewBranch))
+
+	refused := events[2]
+	require.Equal(t, forkchoice.LateBlockReorgRefused, refused.Kind)
+	require.Equal(t, "attestations at 10 seconds", refused.Reason)
+	require.Equal(t, 0, len(refused.OrphanedAttestations))
+
+	t.Run("truncated branch", func(t *testing.T) {
+		branch, truncated := service.reorgBranch(newHeadRoot, [32]byte{'x'}, nil)
+		require.Equal(t, true, truncated)
+		require.Equal(t, 2, len(branch))
+		_, truncated = service.reorgBranch(newHeadRoot, genesisRoot, nil)
+		require.Equal(t, false, truncated)
+	})
+
+	t.Run("events out of the window are pruned", func(t *testing.T) {
+		service.genesisTime = service.genesisTime.Add(-3 * time.Duration(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot)) * time.Second)
+		service.recordReorg(newHeadRoot, orphanedRoot, genesisRoot, 0, 1)
+		processQueued()
+		events, err := beaconDB.ReorgEvents(ctx, 0, params.BeaconConfig().SlotsPerEpoch*4)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(events))
+		require.Equal(t, newHeadRoot, events[0].OldHeadRoot)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		service.cfg.ReorgHistoryWindow = 0
+		service.recordReorg(orphanedRoot, newHeadRoot, genesisRoot, 0, 2)
+		require.Equal(t, 0, len(service.reorgEvents))
+		events, err := beaconDB.ReorgEvents(ctx, 0, params.BeaconConfig().SlotsPerEpoch*4)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(events))
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/async/event"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
@@ -32,9 +33,11 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	lruwrpr "github.com/prysmaticlabs/prysm/v5/cache/lru"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
@@ -65,32 +68,42 @@ type Service struct {
 	blobNotifiers        *blobNotifierMap
 	blockBeingSynced     *currentlySyncingBlock
 	blobStorage          *filesystem.BlobStorage
+	disputedBlocks       map[[32]byte]primitives.Slot
+	disputedBlocksLock   sync.RWMutex
+	// lastLateBlockDecision is protected by the fork choice lock.
+	lastLateBlockDecision lateBlockDecision
+	reorgEvents           chan *forkchoice.ReorgEvent
+	blockArrivals         *lru.Cache
 }
 
 // config options for the service.
 type config struct {
-	BeaconBlockBuf          int
-	ChainStartFetcher       execution.ChainStartFetcher
-	BeaconDB                db.HeadAccessDatabase
-	DepositCache            cache.DepositCache
-	PayloadIDCache          *cache.PayloadIDCache
-	TrackedValidatorsCache  *cache.TrackedValidatorsCache
-	AttPool                 attestations.Pool
-	ExitPool                voluntaryexits.PoolManager
-	SlashingPool            slashings.PoolManager
-	BLSToExecPool           blstoexec.PoolManager
-	P2p                     p2p.Broadcaster
-	MaxRoutines             int
-	StateNotifier           statefeed.Notifier
-	ForkChoiceStore         f.ForkChoicer
-	AttService              *attestations.Service
-	StateGen                *stategen.State
-	SlasherAttestationsFeed *event.Feed
-	WeakSubjectivityCheckpt *ethpb.Checkpoint
-	BlockFetcher            execution.POWBlockFetcher
-	FinalizedStateAtStartUp state.BeaconState
-	ExecutionEngineCaller   execution.EngineCaller
-	SyncChecker             Checker
+	BeaconBlockBuf             int
+	ChainStartFetcher          execution.ChainStartFetcher
+	BeaconDB                   db.HeadAccessDatabase
+	DepositCache               cache.DepositCache
+	PayloadIDCache             *cache.PayloadIDCache
+	TrackedValidatorsCache     *cache.TrackedValidatorsCache
+	AttestationInclusionCache  *cache.AttestationInclusionCache
+	AttPool                    attestations.Pool
+	ExitPool                   voluntaryexits.PoolManager
+	SlashingPool               slashings.PoolManager
+	SlashingArchive            *slashings.Archive
+	BLSToExecPool              blstoexec.PoolManager
+	P2p                        p2p.Broadcaster
+	MaxRoutines                int
+	StateNotifier              statefeed.Notifier
+	ForkChoiceStore            f.ForkChoicer
+	AttService                 *attestations.Service
+	StateGen                   *stategen.State
+	SlasherAttestationsFeed    *event.Feed
+	WeakSubjectivityCheckpt    *ethpb.Checkpoint
+	BlockFetcher               execution.POWBlockFetcher
+	FinalizedStateAtStartUp    state.BeaconState
+	ExecutionEngineCaller      execution.EngineCaller
+	SyncChecker                Checker
+	ForkchoiceSnapshotInterval time.Duration
+	ReorgHistoryWindow         primitives.Epoch
 }
 
 // Checker is an interface used to determine if a node is in initial sync
@@ -179,6 +192,9 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 		boundaryRoots:        [][32]byte{},
 		checkpointStateCache: cache.NewCheckpointStateCache(),
 		initSyncBlocks:       make(map[[32]byte]interfaces.ReadOnlySignedBeaconBlock),
+		disputedBlocks:       make(map[[32]byte]primitives.Slot),
+		reorgEvents:          make(chan *forkchoice.ReorgEvent, reorgEventsBufferSize),
+		blockArrivals:        lruwrpr.New(blockArrivalsSize),
 		blobNotifiers:        bn,
 		cfg:                  &config{},
 		blockBeingSynced:     &currentlySyncingBlock{roots: make(map[[32]byte]struct{})},
@@ -214,6 +230,12 @@ func (s *Service) Start() {
 	}
 	s.spawnProcessAttestationsRoutine()
 	go s.runLateBlockTasks()
+	if s.cfg.ForkchoiceSnapshotInterval > 0 {
+		go s.runForkchoiceSnapshots()
+	}
+	if s.cfg.ReorgHistoryWindow > 0 {
+		go s.runReorgAnalytics()
+	}
 }
 
 // Stop the blockchain service's main event loop and associated goroutines.
@@ -232,6 +254,12 @@ func (s *Service) Stop() error {
 	} else {
 		s.headLock.RUnlock()
 	}
+	if s.cfg.ForkchoiceSnapshotInterval > 0 {
+		// Save the latest fork choice so that the next run doesn't have to rebuild it.
+		if err := s.saveForkchoiceSnapshot(s.ctx); err != nil {
+			log.WithError(err).Error("Could not save fork choice snapshot")
+		}
+	}
 	// Save initial sync cached blocks to the DB before stop.
 	return s.cfg.BeaconDB.SaveBlocks(s.ctx, s.getInitSyncBlocks())
 }
@@ -289,9 +317,36 @@ func (s *Service) StartFromSavedState(saved state.BeaconState) error {
 		return errNilFinalizedCheckpoint
 	}
 
-	fRoot := s.ensureRootNotZeros(bytesutil.ToBytes32(finalized.Root))
 	s.cfg.ForkChoiceStore.Lock()
 	defer s.cfg.ForkChoiceStore.Unlock()
+	restored, err := s.restoreForkchoiceSnapshot(s.ctx, finalized)
+	if err != nil {
+		log.WithError(err).Warn("Could not restore fork choice snapshot, rebuilding fork choice from the finalized state")
+	}
+	if !restored {
+		if err := s.initializeForkchoice(justified, finalized); err != nil {
+			return err
+		}
+	}
+	// not attempting to save initial sync blocks here, because there shouldn't be any until
+	// after the statefeed.Initialized event is fired (below)
+	if err := s.wsVerifier.VerifyWeakSubjectivity(s.ctx, finalized.Epoch); err != nil {
+		// Exit run time if the node failed to verify weak subjectivity checkpoint.
+		return errors.Wrap(err, "could not verify initial checkpoint provided for chain sync")
+	}
+
+	vr := bytesutil.ToBytes32(saved.GenesisValidatorsRoot())
+	if err := s.clockSetter.SetClock(startup.NewClock(s.genesisTime, vr)); err != nil {
+		return errors.Wrap(err, "failed to initialize blockchain service")
+	}
+
+	return nil
+}
+
+// initializeForkchoice builds fork choice from the finalized state, with the finalized block as the tree root.
+// The caller of this function must have a lock on forkchoice.
+func (s *Service) initializeForkchoice(justified, finalized *ethpb.Checkpoint) error {
+	fRoot := s.ensureRootNotZeros(bytesutil.ToBytes32(finalized.Root))
 	if err := s.cfg.ForkChoiceStore.UpdateJustifiedCheckpoint(s.ctx, &forkchoicetypes.Checkpoint{Epoch: justified.Epoch,
 		Root: bytesutil.ToBytes32(justified.Root)}); err != nil {
 		return errors.Wrap(err, "could not update forkchoice's justified checkpoint")
@@ -328,18 +383,6 @@ func (s *Service) StartFromSavedState(saved state.BeaconState) error {
 			}
 		}
 	}
-	// not attempting to save initial sync blocks here, because there shouldn't be any until
-	// after the statefeed.Initialized event is fired (below)
-	if err := s.wsVerifier.VerifyWeakSubjectivity(s.ctx, finalized.Epoch); err != nil {
-		// Exit run time if the node failed to verify weak subjectivity checkpoint.
-		return errors.Wrap(err, "could not verify initial checkpoint provided for chain sync")
-	}
-
-	vr := bytesutil.ToBytes32(saved.GenesisValidatorsRoot())
-	if err := s.clockSetter.SetClock(startup.NewClock(s.genesisTime, vr)); err != nil {
-		return errors.Wrap(err, "failed to initialize blockchain service")
-	}
-
 	return nil
 }
 
@@ -39,6 +39,7 @@ var ErrNilState = errors.New("nil state")
 type ChainService struct {
 	NotFinalized                bool
 	Optimistic                  bool
+	DisputedBranch              bool
 	ValidAttestation            bool
 	ValidatorsRoot              [32]byte
 	PublicKey                   [fieldparams.BLSPubkeyLength]byte
@@ -525,6 +526,11 @@ func (s *ChainService) IsOptimistic(_ context.Context) (bool, error) {
 	return s.Optimistic, nil
 }
 
+// IsDisputedBranch mocks the same method in the chain service.
+func (s *ChainService) IsDisputedBranch(_ context.Context, _ [32]byte) (bool, error) {
+	return s.DisputedBranch, nil
+}
+
 // InForkchoice mocks the same method in the chain service
 func (s *ChainService) InForkchoice(_ [32]byte) bool {
 	return !s.NotFinalized
@@ -696,6 +702,9 @@ func (c *ChainService) BlockBeingSynced(root [32]byte) bool {
 	return root == c.SyncingRoot
 }
 
+// RecordBlockArrival mocks the same method in the chain service.
+func (*ChainService) RecordBlockArrival([32]byte, time.Time) {}
+
 // ReceiveBlob implements the same method in the chain service
 func (c *ChainService) ReceiveBlob(_ context.Context, b blocks.VerifiedROBlob) error {
 	c.Blobs = append(c.Blobs, b)
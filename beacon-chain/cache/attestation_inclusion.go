@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// AttestationInclusion records that the votes of a single committee, given by their aggregation bits, were
+// included in a block. Electra attestations spanning several committees are recorded once per committee.
+type AttestationInclusion struct {
+	BlockRoot       [32]byte
+	BlockSlot       primitives.Slot
+	DataRoot        [32]byte
+	Slot            primitives.Slot
+	CommitteeIndex  primitives.CommitteeIndex
+	AggregationBits bitfield.Bitlist
+}
+
+// AttestationInclusionCache indexes the attestations included in the imported blocks, whether canonical or not,
+// by attestation slot and committee index. Attestations whose slot is older than the configured number of epochs
+// before the latest imported block are pruned.
+type AttestationInclusionCache struct {
+	sync.RWMutex
+	epochs     primitives.Epoch
+	lowestSlot primitives.Slot
+	blocks     map[[32]byte]primitives.Slot
+	slots      map[primitives.Slot]map[primitives.CommitteeIndex][]*AttestationInclusion
+	dataRoots  map[[32]byte]primitives.Slot
+}
+
+// NewAttestationInclusionCache returns a cache keeping attestation inclusions for the given number of epochs.
+// A cache created with zero epochs is disabled and ignores every inclusion.
+func NewAttestationInclusionCache(epochs primitives.Epoch) *AttestationInclusionCache {
+	return &AttestationInclusionCache{
+		epochs:    epochs,
+		blocks:    make(map[[32]byte]primitives.Slot),
+		slots:     make(map[primitives.Slot]map[primitives.CommitteeIndex][]*AttestationInclusion),
+		dataRoots: make(map[[32]byte]primitives.Slot),
+	}
+}
+
+// Enabled returns whether the cache records inclusions.
+func (c *AttestationInclusionCache) Enabled() bool {
+	return c != nil && c.epochs > 0
+}
+
+// LowestSlot returns the lowest attestation slot for which inclusions are kept.
+func (c *AttestationInclusionCache) LowestSlot() primitives.Slot {
+	c.RLock()
+	defer c.RUnlock()
+	return c.lowestSlot
+}
+
+// Add records the attestation inclusions of the block with the given root and slot. Inclusions of a block that
+// was already recorded are ignored, and inclusions older than the window of the cache are pruned.
+func (c *AttestationInclusionCache) Add(blockRoot [32]byte, blockSlot primitives.Slot, inclusions []*AttestationInclusion) {
+	if !c.Enabled() {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	if _, ok := c.blocks[blockRoot]; ok {
+		return
+	}
+	c.prune(blockSlot)
+	c.blocks[blockRoot] = blockSlot
+	for _, inc := range inclusions {
+		if inc.Slot < c.lowestSlot {
+			continue
+		}
+		committees, ok := c.slots[inc.Slot]
+		if !ok {
+			committees = make(map[primitives.CommitteeIndex][]*AttestationInclusion)
+			c.slots[inc.Slot] = committees
+		}
+		committees[inc.CommitteeIndex] = append(committees[inc.CommitteeIndex], inc)
+		c.dataRoots[inc.DataRoot] = inc.Slot
+	}
+}
+
+// Committee returns the inclusions of the votes of the given committee at the given slot.
+func (c *AttestationInclusionCache) Committee(slot primitives.Slot, index primitives.CommitteeIndex) []*AttestationInclusion {
+	c.RLock()
+	defer c.RUnlock()
+	incs := c.slots[slot][index]
+	return append(make([]*AttestationInclusion, 0, len(incs)), incs...)
+}
+
+// DataRoot returns the inclusions of attestations with the given data root, across all committees.
+func (c *AttestationInclusionCache) DataRoot(root [32]byte) []*AttestationInclusion {
+	c.RLock()
+	defer c.RUnlock()
+	slot, ok := c.dataRoots[root]
+	if !ok {
+		return nil
+	}
+	var incs []*AttestationInclusion
+	for _, committee := range c.slots[slot] {
+		for _, inc := range committee {
+			if inc.DataRoot == root {
+				incs = append(incs, inc)
+			}
+		}
+	}
+	return incs
+}
+
+// prune removes the inclusions whose attestation slot falls out of the window ending at the given block slot.
+// The caller must hold the write lock.
+func (c *AttestationInclusionCache) prune(blockSlot primitives.Slot) {
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	epoch := primitives.Epoch(blockSlot / slotsPerEpoch)
+	if epoch <= c.epochs {
+		return
+	}
+	lowest := primitives.Slot(epoch-c.epochs) * slotsPerEpoch
+	if lowest <= c.lowestSlot {
+		return
+	}
+	c.lowestSlot = lowest
+	for slot := range c.slots {
+		if slot < lowest {
+			delete(c.slots, slot)
+		}
+	}
+	for root, slot := range c.dataRoots {
+		if slot < lowest {
+			delete(c.dataRoots, root)
+		}
+	}
+	for root, slot := range c.blocks {
+		if slot < lowest {
+			delete(c.blocks, root)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestAttestationInclusionCache_Add(t *testing.T) {
+	c := NewAttestationInclusionCache(2)
+	require.Equal(t, true, c.Enabled())
+
+	inc1 := &AttestationInclusion{BlockRoot: [32]byte{'a'}, BlockSlot: 11, DataRoot: [32]byte{1}, Slot: 10, CommitteeIndex: 0, AggregationBits: bitfield.Bitlist{0b1101}}
+	inc2 := &AttestationInclusion{BlockRoot: [32]byte{'a'}, BlockSlot: 11, DataRoot: [32]byte{2}, Slot: 10, CommitteeIndex: 1, AggregationBits: bitfield.Bitlist{0b1011}}
+	c.Add([32]byte{'a'}, 11, []*AttestationInclusion{inc1, inc2})
+	inc3 := &AttestationInclusion{BlockRoot: [32]byte{'b'}, BlockSlot: 12, DataRoot: [32]byte{1}, Slot: 10, CommitteeIndex: 0, AggregationBits: bitfield.Bitlist{0b1010}}
+	c.Add([32]byte{'b'}, 12, []*AttestationInclusion{inc3})
+	// Blocks are only recorded once.
+	c.Add([32]byte{'b'}, 12, []*AttestationInclusion{inc3})
+
+	assert.DeepEqual(t, []*AttestationInclusion{inc1, inc3}, c.Committee(10, 0))
+	assert.DeepEqual(t, []*AttestationInclusion{inc2}, c.Committee(10, 1))
+	assert.Equal(t, 0, len(c.Committee(10, 2)))
+	assert.Equal(t, 0, len(c.Committee(9, 0)))
+	assert.DeepEqual(t, []*AttestationInclusion{inc1, inc3}, c.DataRoot([32]byte{1}))
+	assert.DeepEqual(t, []*AttestationInclusion{inc2}, c.DataRoot([32]byte{2}))
+	assert.Equal(t, 0, len(c.DataRoot([32]byte{3})))
+}
+
+func TestAttestationInclusionCache_Prune(t *testing.T) {
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	c := NewAttestationInclusionCache(2)
+
+	old := &AttestationInclusion{BlockRoot: [32]byte{'a'}, BlockSlot: 1, DataRoot: [32]byte{1}, Slot: 0}
+	c.Add([32]byte{'a'}, 1, []*AttestationInclusion{old})
+	recent := &AttestationInclusion{BlockRoot: [32]byte{'b'}, BlockSlot: slotsPerEpoch + 1, DataRoot: [32]byte{2}, Slot: slotsPerEpoch}
+	c.Add([32]byte{'b'}, slotsPerEpoch+1, []*AttestationInclusion{recent})
+	assert.Equal(t, primitives.Slot(0), c.LowestSlot())
+
+	// A block in epoch 3 only keeps attestations from epoch 1 onwards.
+	late := &AttestationInclusion{BlockRoot: [32]byte{'c'}, BlockSlot: 3 * slotsPerEpoch, DataRoot: [32]byte{3}, Slot: slotsPerEpoch - 1}
+	c.Add([32]byte{'c'}, 3*slotsPerEpoch, []*AttestationInclusion{late})
+	assert.Equal(t, slotsPerEpoch, c.LowestSlot())
+	assert.Equal(t, 0, len(c.Committee(0, 0)))
+	assert.Equal(t, 0, len(c.Committee(slotsPerEpoch-1, 0)))
+	assert.Equal(t, 0, len(c.DataRoot([32]byte{1})))
+	assert.DeepEqual(t, []*AttestationInclusion{recent}, c.Committee(slotsPerEpoch, 0))
+	assert.DeepEqual(t, []*AttestationInclusion{recent}, c.DataRoot([32]byte{2}))
+}
+
+func TestAttestationInclusionCache_Disabled(t *testing.T) {
+	c := NewAttestationInclusionCache(0)
+	require.Equal(t, false, c.Enabled())
+	c.Add([32]byte{'a'}, 1, []*AttestationInclusion{{Slot: 0}})
+	assert.Equal(t, 0, len(c.Committee(0, 0)))
+
+	var nilCache *AttestationInclusionCache
+	require.Equal(t, false, nilCache.Enabled())
+	nilCache.Add([32]byte{'a'}, 1, []*AttestationInclusion{{Slot: 0}})
+}
@@ -204,21 +204,27 @@ func IsSyncCommitteeAggregator(sig []byte) (bool, error) {
 // ValidateSyncMessageTime validates sync message to ensure that the provided slot is valid.
 // Spec: [IGNORE] The message's slot is for the current slot (with a MAXIMUM_GOSSIP_CLOCK_DISPARITY allowance), i.e. sync_committee_message.slot == current_slot
 func ValidateSyncMessageTime(slot primitives.Slot, genesisTime time.Time, clockDisparity time.Duration) error {
-	if err := slots.ValidateClock(slot, uint64(genesisTime.Unix())); err != nil {
-		return err
+	return ValidateSyncMessageTimeAt(slot, genesisTime, time.Now(), clockDisparity)
+}
+
+// ValidateSyncMessageTimeAt validates the sync message time like ValidateSyncMessageTime, relative to the
+// given current time instead of the local clock.
+func ValidateSyncMessageTimeAt(slot primitives.Slot, genesisTime, now time.Time, clockDisparity time.Duration) error {
+	currentSlot := slots.Duration(genesisTime, now)
+	if maxSlot := currentSlot.Add(slots.MaxSlotBuffer); slot > maxSlot {
+		return fmt.Errorf("slot %d > %d which exceeds max allowed value relative to the local clock", slot, maxSlot)
 	}
 	messageTime, err := slots.ToTime(uint64(genesisTime.Unix()), slot)
 	if err != nil {
 		return err
 	}
-	currentSlot := slots.Since(genesisTime)
 	slotStartTime, err := slots.ToTime(uint64(genesisTime.Unix()), currentSlot)
 	if err != nil {
 		return err
 	}
 
 	lowestSlotBound := slotStartTime.Add(-clockDisparity)
-	currentLowerBound := time.Now().Add(-clockDisparity)
+	currentLowerBound := now.Add(-clockDisparity)
 	// In the event the Slot's start time, is before the
 	// current allowable bound, we set the slot's start
 	// time as the bound.
@@ -227,7 +233,7 @@ func ValidateSyncMessageTime(slot primitives.Slot, genesisTime time.Time, clockD
 	}
 
 	lowerBound := lowestSlotBound
-	upperBound := time.Now().Add(clockDisparity)
+	upperBound := now.Add(clockDisparity)
 	// Verify sync message slot is within the time range.
 	if messageTime.Before(lowerBound) || messageTime.After(upperBound) {
 		syncErr := fmt.Errorf(
@@ -0,0 +1,126 @@
+package blocks
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// WithdrawalSweep projects when the withdrawal sweep reaches each validator. It replays the sweep of
+// ProcessWithdrawals block after block from the state, with the balances of the state and a block at
+// every slot. Pending partial withdrawals and balance changes after the state are not accounted for.
+type WithdrawalSweep struct {
+	st    state.ReadOnlyBeaconState
+	epoch primitives.Epoch
+	next  primitives.ValidatorIndex
+	// offsets holds, by validator index, the number of slots after the next one at which the sweep first reaches the validator.
+	offsets []uint64
+	// cycle is the number of slots the sweep takes to go through every validator.
+	cycle uint64
+}
+
+// NewWithdrawalSweep replays the withdrawal sweep from the state until it reached every validator.
+func NewWithdrawalSweep(st state.ReadOnlyBeaconState) (*WithdrawalSweep, error) {
+	if st == nil || st.IsNil() {
+		return nil, errors.New("nil state")
+	}
+	if st.Version() < version.Capella {
+		return nil, errors.Errorf("withdrawals are not supported in %s", version.String(st.Version()))
+	}
+	next, err := st.NextWithdrawalValidatorIndex()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get next withdrawal validator index")
+	}
+	s := &WithdrawalSweep{
+		st:    st,
+		epoch: slots.ToEpoch(st.Slot() + 1),
+		next:  next,
+	}
+	numVals := uint64(st.NumValidators())
+	if numVals == 0 {
+		return s, nil
+	}
+
+	balances := st.Balances()
+	withdrawable := make([]bool, numVals)
+	if err := st.ReadFromEveryValidator(func(idx int, val state.ReadOnlyValidator) error {
+		if idx >= len(balances) {
+			return errors.Errorf("no balance for validator %d", idx)
+		}
+		withdrawable[idx] = helpers.IsFullyWithdrawableValidator(val, balances[idx], s.epoch, st.Version()) ||
+			helpers.IsPartiallyWithdrawableValidator(val, balances[idx], s.epoch, st.Version())
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not read validators")
+	}
+
+	s.offsets = make([]uint64, numVals)
+	reached := make([]bool, numVals)
+	remaining := numVals
+	bound := min(numVals, params.BeaconConfig().MaxValidatorsPerWithdrawalsSweep)
+	index := uint64(next) % numVals
+	// Every block reaches at least one validator that was not reached before, so the loop ends within numVals blocks.
+	for slot := uint64(0); remaining > 0 && slot <= numVals; slot++ {
+		start, count := index, uint64(0)
+		for i := uint64(0); i < bound; i++ {
+			if !reached[index] {
+				reached[index] = true
+				s.offsets[index] = slot
+				remaining--
+			}
+			if withdrawable[index] {
+				count++
+			}
+			index = (index + 1) % numVals
+			if count == params.BeaconConfig().MaxWithdrawalsPerPayload {
+				break
+			}
+		}
+		if count < params.BeaconConfig().MaxWithdrawalsPerPayload {
+			index = (start + params.BeaconConfig().MaxValidatorsPerWithdrawalsSweep) % numVals
+		}
+		s.cycle = slot + 1
+	}
+	return s, nil
+}
+
+// NextWithdrawalSlot returns the slot of the next withdrawal of the validator by the sweep, and the position of the
+// validator in the sweep counted from the next validator it processes. A validator that is not withdrawable yet but
+// has initiated its exit is withdrawn from by the first pass of the sweep after its withdrawable epoch. It returns
+// false when the sweep is not expected to withdraw from the validator.
+func (s *WithdrawalSweep) NextWithdrawalSlot(idx primitives.ValidatorIndex) (primitives.Slot, uint64, bool, error) {
+	numVals := uint64(len(s.offsets))
+	if uint64(idx) >= numVals {
+		return 0, 0, false, errors.Errorf("validator index %d out of range", idx)
+	}
+	val, err := s.st.ValidatorAtIndexReadOnly(idx)
+	if err != nil {
+		return 0, 0, false, errors.Wrap(err, "could not get validator")
+	}
+	balance, err := s.st.BalanceAtIndex(idx)
+	if err != nil {
+		return 0, 0, false, errors.Wrap(err, "could not get balance")
+	}
+	from := s.st.Slot() + 1
+	if !helpers.IsFullyWithdrawableValidator(val, balance, s.epoch, s.st.Version()) &&
+		!helpers.IsPartiallyWithdrawableValidator(val, balance, s.epoch, s.st.Version()) {
+		if !helpers.HasExecutionWithdrawalCredentials(val) || val.WithdrawableEpoch() == params.BeaconConfig().FarFutureEpoch || balance == 0 {
+			return 0, 0, false, nil
+		}
+		from, err = slots.EpochStart(val.WithdrawableEpoch())
+		if err != nil {
+			return 0, 0, false, err
+		}
+	}
+	slot := s.st.Slot() + 1 + primitives.Slot(s.offsets[idx])
+	if slot < from {
+		passes := (uint64(from-slot) + s.cycle - 1) / s.cycle
+		slot += primitives.Slot(passes * s.cycle)
+	}
+	position := (uint64(idx) + numVals - uint64(s.next)%numVals) % numVals
+	return slot, position, true, nil
+}
@@ -0,0 +1,82 @@
+package blocks_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	state_native "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestWithdrawalSweep(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.MaxWithdrawalsPerPayload = 4
+	cfg.MaxValidatorsPerWithdrawalsSweep = 10
+	params.OverrideBeaconConfig(cfg)
+
+	maxBalance := params.BeaconConfig().MaxEffectiveBalance
+	vals := make([]*ethpb.Validator, 40)
+	balances := make([]uint64, len(vals))
+	for i := range vals {
+		vals[i] = &ethpb.Validator{
+			PublicKey:             make([]byte, 48),
+			WithdrawalCredentials: make([]byte, 32),
+			EffectiveBalance:      maxBalance,
+			ExitEpoch:             params.BeaconConfig().FarFutureEpoch,
+			WithdrawableEpoch:     params.BeaconConfig().FarFutureEpoch,
+		}
+		balances[i] = maxBalance + 1
+		// Validators 20 to 29 have BLS withdrawal credentials.
+		if i < 20 || i >= 30 {
+			vals[i].WithdrawalCredentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
+		}
+	}
+	// Validator 21 exited and becomes withdrawable in two epochs.
+	vals[21].WithdrawalCredentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
+	vals[21].ExitEpoch = 5
+	vals[21].WithdrawableEpoch = 12
+	balances[21] = maxBalance
+
+	st, err := util.NewBeaconStateCapella()
+	require.NoError(t, err)
+	require.NoError(t, st.SetValidators(vals))
+	require.NoError(t, st.SetBalances(balances))
+	require.NoError(t, st.SetSlot(10*params.BeaconConfig().SlotsPerEpoch))
+	require.NoError(t, st.SetNextWithdrawalValidatorIndex(5))
+
+	s, err := blocks.NewWithdrawalSweep(st)
+	require.NoError(t, err)
+	// Blocks withdraw from 5-8, 9-12, 13-16, 17-19 then skip to 27, 30-33, 34-37, 38-1 and 2-4.
+	for idx, offset := range map[primitives.ValidatorIndex]primitives.Slot{5: 0, 8: 0, 13: 2, 19: 3, 30: 4, 39: 6, 3: 7} {
+		slot, _, ok, err := s.NextWithdrawalSlot(idx)
+		require.NoError(t, err)
+		require.Equal(t, true, ok)
+		require.Equal(t, st.Slot()+1+offset, slot, "validator %d", idx)
+	}
+	_, position, _, err := s.NextWithdrawalSlot(3)
+	require.NoError(t, err)
+	require.Equal(t, uint64(38), position)
+
+	_, _, ok, err := s.NextWithdrawalSlot(25)
+	require.NoError(t, err)
+	require.Equal(t, false, ok)
+
+	// Reached 3 slots after the next one, then once every 8 slots until withdrawable at slot 384.
+	slot, _, ok, err := s.NextWithdrawalSlot(21)
+	require.NoError(t, err)
+	require.Equal(t, true, ok)
+	require.Equal(t, primitives.Slot(388), slot)
+
+	_, _, _, err = s.NextWithdrawalSlot(40)
+	require.ErrorContains(t, "out of range", err)
+
+	bellatrix, err := state_native.InitializeFromProtoUnsafeBellatrix(&ethpb.BeaconStateBellatrix{})
+	require.NoError(t, err)
+	_, err = blocks.NewWithdrawalSweep(bellatrix)
+	require.ErrorContains(t, "not supported", err)
+}
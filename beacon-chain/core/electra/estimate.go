@@ -0,0 +1,137 @@
+package electra
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// EstimatePendingDepositEpochs estimates, for each deposit in the pending deposits queue of the
+// state, the epoch at the start of which the deposit is applied to its validator's balance. It
+// replays ProcessPendingDeposits epoch after epoch with the churn of the current total active
+// balance, assuming that the chain finalizes every epoch from now on and that no other deposit
+// enters the queue. The estimate of a deposit for a validator that is exiting but not yet
+// withdrawable follows the postponement rules, so it is at least the epoch after the validator
+// becomes withdrawable.
+func EstimatePendingDepositEpochs(st state.ReadOnlyBeaconState) ([]primitives.Epoch, error) {
+	if st == nil || st.IsNil() {
+		return nil, errors.New("nil state")
+	}
+	pendingDeposits, err := st.PendingDeposits()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get pending deposits")
+	}
+	balanceToConsume, err := st.DepositBalanceToConsume()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get deposit balance to consume")
+	}
+	activeBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get total active balance")
+	}
+	churn := helpers.ActivationExitChurnLimit(primitives.Gwei(activeBalance))
+
+	// Exit and withdrawable epochs of the validators already in the registry, by pending deposit.
+	exitEpochs := make([]primitives.Epoch, len(pendingDeposits))
+	withdrawableEpochs := make([]primitives.Epoch, len(pendingDeposits))
+	for i, d := range pendingDeposits {
+		exitEpochs[i] = params.BeaconConfig().FarFutureEpoch
+		withdrawableEpochs[i] = params.BeaconConfig().FarFutureEpoch
+		index, found := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(d.PublicKey))
+		if !found {
+			continue
+		}
+		val, err := st.ValidatorAtIndexReadOnly(index)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get validator")
+		}
+		exitEpochs[i] = val.ExitEpoch()
+		withdrawableEpochs[i] = val.WithdrawableEpoch()
+	}
+
+	finalizedEpoch := st.FinalizedCheckpoint().Epoch
+	// earliestEpoch is the first epoch at which the deposit is finalized during epoch processing. When
+	// the chain finalizes every epoch, the transition into epoch e finalizes epoch e-2.
+	earliestEpoch := func(slot primitives.Slot) primitives.Epoch {
+		finalizedSlot, err := slots.EpochStart(finalizedEpoch)
+		if err == nil && slot <= finalizedSlot {
+			return 0
+		}
+		e := slots.ToEpoch(slot)
+		if slot%params.BeaconConfig().SlotsPerEpoch != 0 {
+			e++
+		}
+		return e + 2
+	}
+
+	estimates := make([]primitives.Epoch, len(pendingDeposits))
+	queue := make([]int, len(pendingDeposits))
+	for i := range queue {
+		queue[i] = i
+	}
+	epoch := slots.ToEpoch(st.Slot()) + 1
+	for len(queue) > 0 {
+		available := balanceToConsume + churn
+		processed := primitives.Gwei(0)
+		next := 0
+		isChurnLimitReached := false
+		var postponed []int
+		for _, i := range queue {
+			d := pendingDeposits[i]
+			if earliestEpoch(d.Slot) > epoch {
+				break
+			}
+			if uint64(next) >= params.BeaconConfig().MaxPendingDepositsPerEpoch {
+				break
+			}
+			if withdrawableEpochs[i] < epoch {
+				estimates[i] = epoch
+			} else if exitEpochs[i] < params.BeaconConfig().FarFutureEpoch {
+				postponed = append(postponed, i)
+			} else {
+				isChurnLimitReached = processed+primitives.Gwei(d.Amount) > available
+				if isChurnLimitReached {
+					break
+				}
+				processed += primitives.Gwei(d.Amount)
+				estimates[i] = epoch
+			}
+			next++
+		}
+		queue = append(queue[next:], postponed...)
+		if isChurnLimitReached {
+			balanceToConsume = available - processed
+		} else {
+			balanceToConsume = 0
+		}
+		if len(queue) == 0 {
+			break
+		}
+		// Skip the epochs in which nothing can be processed.
+		if head := queue[0]; next == 0 && earliestEpoch(pendingDeposits[head].Slot) > epoch {
+			epoch = earliestEpoch(pendingDeposits[head].Slot)
+			continue
+		}
+		if len(postponed) == len(queue) {
+			// Only deposits of exiting validators are left, they wait for the first validator to become withdrawable.
+			withdrawable := params.BeaconConfig().FarFutureEpoch
+			for _, i := range queue {
+				withdrawable = min(withdrawable, withdrawableEpochs[i])
+			}
+			if withdrawable == params.BeaconConfig().FarFutureEpoch {
+				for _, i := range queue {
+					estimates[i] = params.BeaconConfig().FarFutureEpoch
+				}
+				break
+			}
+			epoch = max(epoch+1, withdrawable+1)
+			continue
+		}
+		epoch++
+	}
+	return estimates, nil
+}
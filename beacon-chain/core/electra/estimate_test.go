@@ -0,0 +1,61 @@
+package electra_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/electra"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestEstimatePendingDepositEpochs(t *testing.T) {
+	churn := uint64(helpers.ActivationExitChurnLimit(1_000 * 1e9))
+
+	t.Run("nil state", func(t *testing.T) {
+		_, err := electra.EstimatePendingDepositEpochs(nil)
+		require.ErrorContains(t, "nil state", err)
+	})
+	t.Run("churn limit", func(t *testing.T) {
+		st := stateWithPendingDeposits(t, 1_000, 25, churn/10)
+		require.NoError(t, st.SetDepositBalanceToConsume(100))
+		epochs, err := electra.EstimatePendingDepositEpochs(st)
+		require.NoError(t, err)
+		require.Equal(t, 25, len(epochs))
+		for i, e := range epochs {
+			require.Equal(t, primitives.Epoch(11+i/10), e)
+		}
+	})
+	t.Run("deposits per epoch limit", func(t *testing.T) {
+		st := stateWithPendingDeposits(t, 1_000, 20, 1)
+		epochs, err := electra.EstimatePendingDepositEpochs(st)
+		require.NoError(t, err)
+		for i, e := range epochs {
+			require.Equal(t, primitives.Epoch(11+uint64(i)/params.BeaconConfig().MaxPendingDepositsPerEpoch), e)
+		}
+	})
+	t.Run("unfinalized deposit", func(t *testing.T) {
+		st := stateWithPendingDeposits(t, 1_000, 2, 1)
+		deposits, err := st.PendingDeposits()
+		require.NoError(t, err)
+		deposits[1].Slot = 15*params.BeaconConfig().SlotsPerEpoch + 1
+		require.NoError(t, st.SetPendingDeposits(deposits))
+		epochs, err := electra.EstimatePendingDepositEpochs(st)
+		require.NoError(t, err)
+		require.DeepEqual(t, []primitives.Epoch{11, 18}, epochs)
+	})
+	t.Run("exiting validator", func(t *testing.T) {
+		st := stateWithPendingDeposits(t, 1_000, 3, churn)
+		vals := st.Validators()
+		vals[0].ExitEpoch = 20
+		vals[0].WithdrawableEpoch = 30
+		vals[2].ExitEpoch = 5
+		vals[2].WithdrawableEpoch = 8
+		require.NoError(t, st.SetValidators(vals))
+		epochs, err := electra.EstimatePendingDepositEpochs(st)
+		require.NoError(t, err)
+		// The withdrawn validator does not consume churn, the exiting one waits until it is withdrawable.
+		require.DeepEqual(t, []primitives.Epoch{31, 11, 11}, epochs)
+	})
+}
@@ -3,6 +3,7 @@ package operation
 
 import (
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/slashing"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 )
 
@@ -32,6 +33,10 @@ const (
 
 	// AttesterSlashingReceived is sent after an attester slashing is received from gossip or rpc
 	AttesterSlashingReceived = 8
+
+	// SlashingEvidenceRecorded is sent after the evidence of a slashing was saved, when the slashing is
+	// first seen and when it is included in a canonical block.
+	SlashingEvidenceRecorded = 9
 )
 
 // UnAggregatedAttReceivedData is the data sent with UnaggregatedAttReceived events.
@@ -77,3 +82,8 @@ type ProposerSlashingReceivedData struct {
 type AttesterSlashingReceivedData struct {
 	AttesterSlashing ethpb.AttSlashing
 }
+
+// SlashingEvidenceRecordedData is the data sent with SlashingEvidenceRecorded events.
+type SlashingEvidenceRecordedData struct {
+	Evidence *slashing.Evidence
+}
@@ -33,6 +33,9 @@ const (
 	LightClientOptimisticUpdate
 	// PayloadAttributes events are fired upon a missed slot or new head.
 	PayloadAttributes
+	// PayloadVerificationDivergence is sent when the primary and the verification execution clients disagree on
+	// the validity of an execution payload.
+	PayloadVerificationDivergence
 )
 
 // BlockProcessedData is the data sent with BlockProcessed events.
@@ -68,3 +71,19 @@ type InitializedData struct {
 	// GenesisValidatorsRoot represents state.validators.HashTreeRoot().
 	GenesisValidatorsRoot []byte
 }
+
+// PayloadVerificationDivergenceData is the data sent with PayloadVerificationDivergence events.
+type PayloadVerificationDivergenceData struct {
+	// BlockHash is the hash of the disputed execution payload.
+	BlockHash [32]byte
+	// BlockNumber is the number of the disputed execution payload.
+	BlockNumber uint64
+	// PrimaryStatus is the payload status returned by the primary execution client.
+	PrimaryStatus string
+	// PrimaryLatestValidHash is the latest valid hash returned by the primary execution client.
+	PrimaryLatestValidHash []byte
+	// VerifierStatus is the payload status returned by the verification execution client.
+	VerifierStatus string
+	// VerifierLatestValidHash is the latest valid hash returned by the verification execution client.
+	VerifierLatestValidHash []byte
+}
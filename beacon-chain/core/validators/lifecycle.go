@@ -0,0 +1,248 @@
+package validators
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// Lifecycle is the projected lifecycle of a validator. Epochs already set in the state are kept, the
+// others are projected, FAR_FUTURE_EPOCH when they can't be.
+type Lifecycle struct {
+	ActivationEligibilityEpoch primitives.Epoch
+	ActivationEpoch            primitives.Epoch
+	ExitEpoch                  primitives.Epoch
+	WithdrawableEpoch          primitives.Epoch
+	// ExitInitiated is false when the exit and withdrawable epochs are projected for an exit initiated in the current epoch.
+	ExitInitiated bool
+	// ActivationQueuePosition is the 1-based position of the validator among the validators waiting for activation, 0 when
+	// it is not waiting for activation.
+	ActivationQueuePosition uint64
+	// ExitQueuePosition is the 1-based position of the validator among the validators that initiated their exit and did
+	// not exit yet, 0 when it is not exiting.
+	ExitQueuePosition uint64
+}
+
+type queuedValidator struct {
+	index           primitives.ValidatorIndex
+	epoch           primitives.Epoch
+	position        uint64
+	activationEpoch primitives.Epoch
+}
+
+// LifecycleProjector projects validator lifecycles from a state, assuming that the chain finalizes every
+// epoch and that no other validator joins the activation or exit queues.
+type LifecycleProjector struct {
+	st             state.BeaconState
+	currentEpoch   primitives.Epoch
+	finalizedEpoch primitives.Epoch
+	activations    map[primitives.ValidatorIndex]*queuedValidator
+	exits          map[primitives.ValidatorIndex]*queuedValidator
+	// The exit queue before Electra: the largest exit epoch, the number of validators exiting in it and the
+	// number of validators allowed to exit per epoch.
+	maxExitEpoch   primitives.Epoch
+	exitChurn      uint64
+	exitChurnLimit uint64
+	// The exit queue since Electra: the earliest exit epoch, the balance left to exit in it and the balance
+	// allowed to exit per epoch.
+	earliestExitEpoch    primitives.Epoch
+	exitBalanceToConsume primitives.Gwei
+	exitBalanceChurn     primitives.Gwei
+}
+
+// NewLifecycleProjector replays the activation queue of the state with the activation churn limit of
+// the current epoch and orders its exit queue.
+func NewLifecycleProjector(ctx context.Context, st state.BeaconState) (*LifecycleProjector, error) {
+	if st == nil || st.IsNil() {
+		return nil, errors.New("nil state")
+	}
+	p := &LifecycleProjector{
+		st:             st,
+		currentEpoch:   time.CurrentEpoch(st),
+		finalizedEpoch: st.FinalizedCheckpointEpoch(),
+		activations:    make(map[primitives.ValidatorIndex]*queuedValidator),
+		exits:          make(map[primitives.ValidatorIndex]*queuedValidator),
+	}
+	farFutureEpoch := params.BeaconConfig().FarFutureEpoch
+
+	var activationQueue, exitQueue []*queuedValidator
+	if err := st.ReadFromEveryValidator(func(idx int, val state.ReadOnlyValidator) error {
+		index := primitives.ValidatorIndex(idx)
+		if val.ActivationEpoch() == farFutureEpoch {
+			eligibility := val.ActivationEligibilityEpoch()
+			if helpers.IsEligibleForActivationQueue(val, p.currentEpoch) {
+				// Set during the processing of the current epoch.
+				eligibility = p.currentEpoch + 1
+			}
+			if eligibility != farFutureEpoch {
+				activationQueue = append(activationQueue, &queuedValidator{index: index, epoch: eligibility})
+			}
+		}
+		if val.ExitEpoch() != farFutureEpoch && val.ExitEpoch() > p.currentEpoch {
+			exitQueue = append(exitQueue, &queuedValidator{index: index, epoch: val.ExitEpoch()})
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not read validators")
+	}
+
+	// Validators are activated by eligibility epoch then index, within the activation churn limit before Electra.
+	sortQueue(activationQueue)
+	churn := uint64(len(activationQueue))
+	if st.Version() < version.Electra {
+		activeCount, err := helpers.ActiveValidatorCount(ctx, st, p.currentEpoch)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get active validator count")
+		}
+		churn = helpers.ValidatorActivationChurnLimit(activeCount)
+		if st.Version() >= version.Deneb {
+			churn = helpers.ValidatorActivationChurnLimitDeneb(activeCount)
+		}
+		p.exitChurnLimit = helpers.ValidatorExitChurnLimit(activeCount)
+	}
+	epoch, count := p.currentEpoch, uint64(0)
+	for i, v := range activationQueue {
+		if ready := p.activationReadyEpoch(v.epoch); ready > epoch {
+			epoch, count = ready, 0
+		}
+		if count >= churn {
+			epoch, count = epoch+1, 0
+		}
+		count++
+		v.position = uint64(i) + 1
+		v.activationEpoch = helpers.ActivationExitEpoch(epoch)
+		p.activations[v.index] = v
+	}
+
+	sortQueue(exitQueue)
+	for i, v := range exitQueue {
+		v.position = uint64(i) + 1
+		p.exits[v.index] = v
+	}
+	if st.Version() < version.Electra {
+		p.maxExitEpoch, p.exitChurn = MaxExitEpochAndChurn(st)
+		return p, nil
+	}
+	var err error
+	if p.earliestExitEpoch, err = st.EarliestExitEpoch(); err != nil {
+		return nil, errors.Wrap(err, "could not get earliest exit epoch")
+	}
+	if p.exitBalanceToConsume, err = st.ExitBalanceToConsume(); err != nil {
+		return nil, errors.Wrap(err, "could not get exit balance to consume")
+	}
+	activeBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get total active balance")
+	}
+	p.exitBalanceChurn = helpers.ActivationExitChurnLimit(primitives.Gwei(activeBalance))
+	return p, nil
+}
+
+// Project returns the projected lifecycle of the validator with the given index.
+func (p *LifecycleProjector) Project(ctx context.Context, idx primitives.ValidatorIndex) (*Lifecycle, error) {
+	val, err := p.st.ValidatorAtIndexReadOnly(idx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get validator")
+	}
+	l := &Lifecycle{
+		ActivationEligibilityEpoch: val.ActivationEligibilityEpoch(),
+		ActivationEpoch:            val.ActivationEpoch(),
+		ExitEpoch:                  val.ExitEpoch(),
+		WithdrawableEpoch:          val.WithdrawableEpoch(),
+		ExitInitiated:              val.ExitEpoch() != params.BeaconConfig().FarFutureEpoch,
+	}
+	if v, ok := p.activations[idx]; ok {
+		l.ActivationEligibilityEpoch = v.epoch
+		l.ActivationEpoch = v.activationEpoch
+		l.ActivationQueuePosition = v.position
+	}
+	if v, ok := p.exits[idx]; ok {
+		l.ExitQueuePosition = v.position
+	}
+	if !l.ExitInitiated && helpers.IsActiveValidatorUsingTrie(val, p.currentEpoch) {
+		exitEpoch, err := p.exitEpoch(primitives.Gwei(val.EffectiveBalance()))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not project exit")
+		}
+		l.ExitEpoch = exitEpoch
+		l.WithdrawableEpoch, err = exitEpoch.SafeAddEpoch(params.BeaconConfig().MinValidatorWithdrawabilityDelay)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// exitEpoch returns the exit epoch of a validator with the given effective balance initiating its exit in the
+// current epoch. It is computed as InitiateValidatorExit does, from the exit queue of the state read once by the
+// projector, so that the state is neither copied nor modified.
+func (p *LifecycleProjector) exitEpoch(effectiveBalance primitives.Gwei) (primitives.Epoch, error) {
+	exitableEpoch := helpers.ActivationExitEpoch(p.currentEpoch)
+	if p.st.Version() < version.Electra {
+		epoch, churn := p.maxExitEpoch, p.exitChurn
+		if exitableEpoch > epoch {
+			epoch, churn = exitableEpoch, 0
+		}
+		if churn >= p.exitChurnLimit {
+			return epoch.SafeAdd(1)
+		}
+		return epoch, nil
+	}
+	// Same as compute_exit_epoch_and_update_churn, without updating the churn.
+	epoch := max(p.earliestExitEpoch, exitableEpoch)
+	toConsume := p.exitBalanceToConsume
+	if p.earliestExitEpoch < epoch {
+		toConsume = p.exitBalanceChurn
+	}
+	if effectiveBalance > toConsume {
+		return epoch.SafeAdd(uint64((effectiveBalance-toConsume-1)/p.exitBalanceChurn + 1))
+	}
+	return epoch, nil
+}
+
+// ProjectDeposit returns the projected lifecycle of a validator added to the registry by a pending deposit
+// applied at the start of the given epoch. Activations are not churn limited since Electra, the only fork with
+// pending deposits, so the validator is activated as soon as its eligibility is finalized.
+func (p *LifecycleProjector) ProjectDeposit(depositEpoch primitives.Epoch) *Lifecycle {
+	farFutureEpoch := params.BeaconConfig().FarFutureEpoch
+	if depositEpoch == farFutureEpoch {
+		return &Lifecycle{
+			ActivationEligibilityEpoch: farFutureEpoch,
+			ActivationEpoch:            farFutureEpoch,
+			ExitEpoch:                  farFutureEpoch,
+			WithdrawableEpoch:          farFutureEpoch,
+		}
+	}
+	eligibility := depositEpoch + 1
+	return &Lifecycle{
+		ActivationEligibilityEpoch: eligibility,
+		ActivationEpoch:            helpers.ActivationExitEpoch(p.activationReadyEpoch(eligibility)),
+		ExitEpoch:                  farFutureEpoch,
+		WithdrawableEpoch:          farFutureEpoch,
+	}
+}
+
+// activationReadyEpoch returns the first epoch whose processing can activate a validator with the given
+// eligibility epoch. When the chain finalizes every epoch, the processing of epoch e finalizes epoch e-1.
+func (p *LifecycleProjector) activationReadyEpoch(eligibility primitives.Epoch) primitives.Epoch {
+	if eligibility <= p.finalizedEpoch {
+		return p.currentEpoch
+	}
+	return max(p.currentEpoch, eligibility+1)
+}
+
+func sortQueue(queue []*queuedValidator) {
+	sort.Slice(queue, func(i, j int) bool {
+		if queue[i].epoch == queue[j].epoch {
+			return queue[i].index < queue[j].index
+		}
+		return queue[i].epoch < queue[j].epoch
+	})
+}
@@ -0,0 +1,144 @@
+package validators_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/validators"
+	state_native "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestLifecycleProjector(t *testing.T) {
+	ctx := context.Background()
+	far := params.BeaconConfig().FarFutureEpoch
+	maxBalance := params.BeaconConfig().MaxEffectiveBalance
+	vals := make([]*ethpb.Validator, 19)
+	balances := make([]uint64, len(vals))
+	for i := range vals {
+		vals[i] = &ethpb.Validator{
+			PublicKey:                  make([]byte, 48),
+			WithdrawalCredentials:      make([]byte, 32),
+			EffectiveBalance:           maxBalance,
+			ActivationEligibilityEpoch: 0,
+			ExitEpoch:                  far,
+			WithdrawableEpoch:          far,
+		}
+		balances[i] = maxBalance
+	}
+	// Waiting for activation: 10 is not finalized yet, 11 to 15 are, 16 enters the queue in this epoch.
+	vals[10].ActivationEligibilityEpoch = 9
+	for i := 10; i <= 16; i++ {
+		vals[i].ActivationEpoch = far
+		if i > 10 {
+			vals[i].ActivationEligibilityEpoch = 5
+		}
+	}
+	vals[16].ActivationEligibilityEpoch = far
+	// Exiting.
+	vals[17].ExitEpoch = 20
+	vals[17].WithdrawableEpoch = 276
+	vals[18].ExitEpoch = 15
+	vals[18].WithdrawableEpoch = 271
+
+	st, err := util.NewBeaconStateDeneb()
+	require.NoError(t, err)
+	require.NoError(t, st.SetValidators(vals))
+	require.NoError(t, st.SetBalances(balances))
+	require.NoError(t, st.SetSlot(10*params.BeaconConfig().SlotsPerEpoch))
+	require.NoError(t, st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: 8, Root: make([]byte, 32)}))
+
+	p, err := validators.NewLifecycleProjector(ctx, st)
+	require.NoError(t, err)
+	project := func(idx primitives.ValidatorIndex) *validators.Lifecycle {
+		l, err := p.Project(ctx, idx)
+		require.NoError(t, err)
+		return l
+	}
+
+	t.Run("active", func(t *testing.T) {
+		// The exit queue is at epoch 20 with room left in its churn.
+		require.DeepEqual(t, &validators.Lifecycle{
+			ActivationEligibilityEpoch: 0,
+			ActivationEpoch:            0,
+			ExitEpoch:                  20,
+			WithdrawableEpoch:          276,
+		}, project(0))
+	})
+	t.Run("activation queue", func(t *testing.T) {
+		// The churn limit of 4 validators per epoch pushes the fifth validator of the queue to the next epoch.
+		for i := primitives.ValidatorIndex(11); i <= 14; i++ {
+			l := project(i)
+			require.Equal(t, primitives.Epoch(15), l.ActivationEpoch)
+			require.Equal(t, uint64(i-10), l.ActivationQueuePosition)
+			require.Equal(t, far, l.ExitEpoch)
+		}
+		require.Equal(t, primitives.Epoch(16), project(15).ActivationEpoch)
+		l := project(10)
+		require.Equal(t, primitives.Epoch(16), l.ActivationEpoch)
+		require.Equal(t, uint64(6), l.ActivationQueuePosition)
+		l = project(16)
+		require.Equal(t, primitives.Epoch(11), l.ActivationEligibilityEpoch)
+		require.Equal(t, primitives.Epoch(17), l.ActivationEpoch)
+		require.Equal(t, uint64(7), l.ActivationQueuePosition)
+	})
+	t.Run("exit queue", func(t *testing.T) {
+		l := project(17)
+		require.Equal(t, true, l.ExitInitiated)
+		require.Equal(t, primitives.Epoch(20), l.ExitEpoch)
+		require.Equal(t, primitives.Epoch(276), l.WithdrawableEpoch)
+		require.Equal(t, uint64(2), l.ExitQueuePosition)
+		require.Equal(t, uint64(1), project(18).ExitQueuePosition)
+	})
+	t.Run("deposit", func(t *testing.T) {
+		l := p.ProjectDeposit(12)
+		require.Equal(t, primitives.Epoch(13), l.ActivationEligibilityEpoch)
+		require.Equal(t, primitives.Epoch(19), l.ActivationEpoch)
+		require.Equal(t, far, p.ProjectDeposit(far).ActivationEpoch)
+	})
+	// The projection does not modify the state.
+	v, err := st.ValidatorAtIndexReadOnly(0)
+	require.NoError(t, err)
+	require.Equal(t, far, v.ExitEpoch())
+}
+
+func TestLifecycleProjector_ElectraExit(t *testing.T) {
+	ctx := context.Background()
+	cfg := params.BeaconConfig()
+	genesis, _ := util.DeterministicGenesisStateElectra(t, 64)
+	require.NoError(t, genesis.SetSlot(10*cfg.SlotsPerEpoch))
+	val, err := genesis.ValidatorAtIndex(1)
+	require.NoError(t, err)
+	val.EffectiveBalance = cfg.MaxEffectiveBalanceElectra
+	require.NoError(t, genesis.UpdateValidatorAtIndex(1, val))
+
+	for _, queue := range []struct {
+		earliestExitEpoch primitives.Epoch
+		toConsume         primitives.Gwei
+	}{
+		{earliestExitEpoch: 0, toConsume: 0},
+		{earliestExitEpoch: 20, toConsume: primitives.Gwei(cfg.EffectiveBalanceIncrement)},
+		{earliestExitEpoch: 20, toConsume: primitives.Gwei(cfg.MaxEffectiveBalance)},
+	} {
+		pb, ok := genesis.ToProto().(*ethpb.BeaconStateElectra)
+		require.Equal(t, true, ok)
+		pb.EarliestExitEpoch = queue.earliestExitEpoch
+		pb.ExitBalanceToConsume = queue.toConsume
+		st, err := state_native.InitializeFromProtoElectra(pb)
+		require.NoError(t, err)
+		p, err := validators.NewLifecycleProjector(ctx, st)
+		require.NoError(t, err)
+		for _, idx := range []primitives.ValidatorIndex{0, 1} {
+			l, err := p.Project(ctx, idx)
+			require.NoError(t, err)
+			// The projection matches the exit epoch given by the exit of the validator.
+			_, exitEpoch, err := validators.InitiateValidatorExit(ctx, st.Copy(), idx, 0, 0)
+			require.NoError(t, err)
+			require.Equal(t, exitEpoch, l.ExitEpoch)
+		}
+	}
+}
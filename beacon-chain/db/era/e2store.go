@@ -0,0 +1,92 @@
+package era
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// e2store record types used by era files.
+var (
+	TypeVersion                     = [2]byte{0x65, 0x32}
+	TypeCompressedSignedBeaconBlock = [2]byte{0x01, 0x00}
+	TypeCompressedBeaconState       = [2]byte{0x02, 0x00}
+	TypeEmpty                       = [2]byte{0x00, 0x00}
+	TypeSlotIndex                   = [2]byte{0x69, 0x32}
+)
+
+// headerSize is the size of an e2store record header: 2 bytes of type, 4 bytes of little-endian length and
+// 2 reserved bytes, which must be zero.
+const headerSize = 8
+
+var (
+	errInvalidRecordHeader = errors.New("invalid e2store record header")
+	errRecordTooLarge      = errors.New("e2store record exceeds maximum length")
+)
+
+// Record is a single typed entry of an e2store file.
+type Record struct {
+	Type [2]byte
+	Data []byte
+}
+
+// Size returns the number of bytes the record occupies in the file, including its header.
+func (r Record) Size() int64 {
+	return int64(headerSize + len(r.Data))
+}
+
+// e2storeWriter writes e2store records to an io.Writer, keeping track of the offset of each record,
+// which is needed to build the slot indices of an era file.
+type e2storeWriter struct {
+	w      io.Writer
+	offset int64
+}
+
+func newE2storeWriter(w io.Writer) *e2storeWriter {
+	return &e2storeWriter{w: w}
+}
+
+// write appends a record and returns the offset at which it was written.
+func (e *e2storeWriter) write(r Record) (int64, error) {
+	if uint64(len(r.Data)) > uint64(^uint32(0)) {
+		return 0, errors.Wrapf(errRecordTooLarge, "length=%d", len(r.Data))
+	}
+	var header [headerSize]byte
+	copy(header[:2], r.Type[:])
+	binary.LittleEndian.PutUint32(header[2:6], uint32(len(r.Data)))
+	at := e.offset
+	if _, err := e.w.Write(header[:]); err != nil {
+		return 0, err
+	}
+	if _, err := e.w.Write(r.Data); err != nil {
+		return 0, err
+	}
+	e.offset += r.Size()
+	return at, nil
+}
+
+// readRecord reads the record at the given offset. The record must end before the given end offset, usually the
+// size of the file, so that the length read from the header is checked before its data is allocated.
+func readRecord(r io.ReaderAt, offset, end int64) (Record, error) {
+	if offset < 0 || offset > end-headerSize {
+		return Record{}, errors.Wrapf(errInvalidRecordHeader, "no room for record header at offset %d", offset)
+	}
+	var header [headerSize]byte
+	if _, err := r.ReadAt(header[:], offset); err != nil {
+		return Record{}, errors.Wrapf(err, "could not read record header at offset %d", offset)
+	}
+	if header[6] != 0 || header[7] != 0 {
+		return Record{}, errors.Wrapf(errInvalidRecordHeader, "non-zero reserved bytes at offset %d", offset)
+	}
+	length := int64(binary.LittleEndian.Uint32(header[2:6]))
+	if length > end-offset-headerSize {
+		return Record{}, errors.Wrapf(errRecordTooLarge, "length=%d at offset %d exceeds the end of the file at %d", length, offset, end)
+	}
+	rec := Record{Data: make([]byte, length)}
+	copy(rec.Type[:], header[:2])
+	if _, err := r.ReadAt(rec.Data, offset+headerSize); err != nil {
+		return Record{}, errors.Wrapf(err, "could not read record data at offset %d", offset)
+	}
+	return rec, nil
+}
@@ -0,0 +1,347 @@
+package era
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+)
+
+var (
+	errBlindedBlock      = errors.New("era files require full blocks, but the block is blinded")
+	errSlotOutOfRange    = errors.New("slot is outside of the era")
+	errBlocksOutOfOrder  = errors.New("blocks must be written in increasing slot order, before the state")
+	errMissingState      = errors.New("the era state was not written")
+	errInvalidEraFile    = errors.New("invalid era file")
+	errUnexpectedRecord  = errors.New("unexpected e2store record type")
+	errUnexpectedVersion = errors.New("block or state fork version does not match the era file")
+)
+
+// Era is the number of an era file. Era n contains the blocks from the SLOTS_PER_HISTORICAL_ROOT slots preceding
+// the slot n * SLOTS_PER_HISTORICAL_ROOT, and the BeaconState at that slot. Era 0 contains only the genesis state.
+type Era uint64
+
+func slotsPerEra() primitives.Slot {
+	return primitives.Slot(params.BeaconConfig().SlotsPerHistoricalRoot)
+}
+
+// StateSlot returns the slot of the BeaconState stored in the era.
+func (e Era) StateSlot() primitives.Slot {
+	return primitives.Slot(e) * slotsPerEra()
+}
+
+// StartSlot returns the lowest slot for which the era can contain a block.
+func (e Era) StartSlot() primitives.Slot {
+	if e == 0 {
+		return 0
+	}
+	return primitives.Slot(e-1) * slotsPerEra()
+}
+
+// ForSlot returns the era containing the block at the given slot.
+func ForSlot(slot primitives.Slot) Era {
+	return Era(slot/slotsPerEra()) + 1
+}
+
+// Filename returns the standard name of an era file: <config-name>-<era-number>-<short-historical-root>.era,
+// where the short historical root is the hex encoding of the first 4 bytes of the era's historical root.
+func Filename(configName string, e Era, root [32]byte) string {
+	return fmt.Sprintf("%s-%05d-%x.era", configName, e, root[:4])
+}
+
+// Writer writes a single era file. Blocks must be written in increasing slot order, followed by the state.
+// Finish must be called to write the slot indices which complete the file.
+type Writer struct {
+	e2s       *e2storeWriter
+	era       Era
+	blocks    []int64
+	lastBlock primitives.Slot
+	hasBlock  bool
+	state     int64
+}
+
+// NewWriter creates a Writer for the given era, writing the version record to w.
+func NewWriter(w io.Writer, e Era) (*Writer, error) {
+	ew := &Writer{e2s: newE2storeWriter(w), era: e, state: -1}
+	if e > 0 {
+		ew.blocks = make([]int64, slotsPerEra())
+	}
+	if _, err := ew.e2s.write(Record{Type: TypeVersion}); err != nil {
+		return nil, err
+	}
+	return ew, nil
+}
+
+// WriteBlock writes a snappy compressed SignedBeaconBlock record.
+func (w *Writer) WriteBlock(b interfaces.ReadOnlySignedBeaconBlock) error {
+	if b.IsBlinded() {
+		return errors.Wrapf(errBlindedBlock, "slot=%d", b.Block().Slot())
+	}
+	slot := b.Block().Slot()
+	if w.era == 0 || slot < w.era.StartSlot() || slot >= w.era.StateSlot() {
+		return errors.Wrapf(errSlotOutOfRange, "slot=%d, era=%d", slot, w.era)
+	}
+	if w.state >= 0 || (w.hasBlock && slot <= w.lastBlock) {
+		return errors.Wrapf(errBlocksOutOfOrder, "slot=%d", slot)
+	}
+	enc, err := b.MarshalSSZ()
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal block at slot %d", slot)
+	}
+	offset, err := w.writeCompressed(TypeCompressedSignedBeaconBlock, enc)
+	if err != nil {
+		return err
+	}
+	w.blocks[slot-w.era.StartSlot()] = offset
+	w.lastBlock, w.hasBlock = slot, true
+	return nil
+}
+
+// WriteState writes the snappy compressed BeaconState record. The state must be at the era's state slot.
+func (w *Writer) WriteState(st state.ReadOnlyBeaconState) error {
+	if st.Slot() != w.era.StateSlot() {
+		return errors.Wrapf(errSlotOutOfRange, "state slot=%d, era=%d", st.Slot(), w.era)
+	}
+	enc, err := st.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal state")
+	}
+	offset, err := w.writeCompressed(TypeCompressedBeaconState, enc)
+	if err != nil {
+		return err
+	}
+	w.state = offset
+	return nil
+}
+
+// Finish writes the block and state slot indices.
+func (w *Writer) Finish() error {
+	if w.state < 0 {
+		return errMissingState
+	}
+	if w.era > 0 {
+		if _, err := w.writeIndex(w.era.StartSlot(), w.blocks); err != nil {
+			return err
+		}
+	}
+	_, err := w.writeIndex(w.era.StateSlot(), []int64{w.state})
+	return err
+}
+
+func (w *Writer) writeCompressed(t [2]byte, data []byte) (int64, error) {
+	buf := bytes.NewBuffer(nil)
+	sw := snappy.NewBufferedWriter(buf)
+	if _, err := sw.Write(data); err != nil {
+		return 0, err
+	}
+	if err := sw.Close(); err != nil {
+		return 0, err
+	}
+	return w.e2s.write(Record{Type: t, Data: buf.Bytes()})
+}
+
+// writeIndex writes a SlotIndex record: starting-slot | index * count | count, with offsets relative to the
+// beginning of the SlotIndex record, and 0 for slots without a record.
+func (w *Writer) writeIndex(start primitives.Slot, offsets []int64) (int64, error) {
+	at := w.e2s.offset
+	data := make([]byte, 8*(len(offsets)+2))
+	binary.LittleEndian.PutUint64(data, uint64(start))
+	for i, o := range offsets {
+		rel := int64(0)
+		if o != 0 {
+			rel = o - at
+		}
+		binary.LittleEndian.PutUint64(data[8*(i+1):], uint64(rel))
+	}
+	binary.LittleEndian.PutUint64(data[8*(len(offsets)+1):], uint64(len(offsets)))
+	return w.e2s.write(Record{Type: TypeSlotIndex, Data: data})
+}
+
+// Reader provides access to the blocks and state of an era file.
+type Reader struct {
+	r      io.ReaderAt
+	size   int64
+	era    Era
+	state  int64
+	blocks []int64
+}
+
+// File is a Reader backed by an open file, which must be closed after use.
+type File struct {
+	*Reader
+	f *os.File
+}
+
+// Close closes the underlying file.
+func (f *File) Close() error {
+	return f.f.Close()
+}
+
+// Open opens the era file at the given path.
+func Open(path string) (*File, error) {
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not stat era file %s", path)
+	}
+	r, err := NewReader(f, fi.Size())
+	if err != nil {
+		if cerr := f.Close(); cerr != nil {
+			log.WithError(cerr).Error("Could not close era file")
+		}
+		return nil, errors.Wrapf(err, "could not read era file %s", path)
+	}
+	return &File{Reader: r, f: f}, nil
+}
+
+// NewReader reads the slot indices at the end of an era file of the given size.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	stateIdx, stateStart, err := readIndex(r, size)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read state index")
+	}
+	if len(stateIdx.offsets) != 1 || stateIdx.start%slotsPerEra() != 0 {
+		return nil, errors.Wrapf(errInvalidEraFile, "unexpected state index, start=%d, count=%d", stateIdx.start, len(stateIdx.offsets))
+	}
+	er := &Reader{r: r, size: size, era: Era(stateIdx.start / slotsPerEra()), state: stateIdx.absolute(0)}
+	if er.era == 0 {
+		return er, nil
+	}
+	blockIdx, _, err := readIndex(r, stateStart)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read block index")
+	}
+	if blockIdx.start != er.era.StartSlot() || primitives.Slot(len(blockIdx.offsets)) != slotsPerEra() {
+		return nil, errors.Wrapf(errInvalidEraFile, "unexpected block index, start=%d, count=%d", blockIdx.start, len(blockIdx.offsets))
+	}
+	er.blocks = make([]int64, len(blockIdx.offsets))
+	for i := range blockIdx.offsets {
+		er.blocks[i] = blockIdx.absolute(i)
+	}
+	return er, nil
+}
+
+type slotIndex struct {
+	at      int64
+	start   primitives.Slot
+	offsets []int64
+}
+
+func (s slotIndex) absolute(i int) int64 {
+	if s.offsets[i] == 0 {
+		return 0
+	}
+	return s.at + s.offsets[i]
+}
+
+// readIndex reads the SlotIndex record which ends at the given offset, returning it and the offset where it begins.
+func readIndex(r io.ReaderAt, end int64) (slotIndex, int64, error) {
+	if end < headerSize+16 {
+		return slotIndex{}, 0, errors.Wrapf(errInvalidEraFile, "no room for slot index before offset %d", end)
+	}
+	var b [8]byte
+	if _, err := r.ReadAt(b[:], end-8); err != nil {
+		return slotIndex{}, 0, err
+	}
+	count := binary.LittleEndian.Uint64(b[:])
+	if count > uint64(end-headerSize-16)/8 {
+		return slotIndex{}, 0, errors.Wrapf(errInvalidEraFile, "slot index count %d is too large", count)
+	}
+	start := end - headerSize - 16 - int64(8*count)
+	rec, err := readRecord(r, start, end)
+	if err != nil {
+		return slotIndex{}, 0, err
+	}
+	if rec.Type != TypeSlotIndex || rec.Size() != end-start {
+		return slotIndex{}, 0, errors.Wrapf(errUnexpectedRecord, "expected slot index at offset %d", start)
+	}
+	idx := slotIndex{at: start, start: primitives.Slot(binary.LittleEndian.Uint64(rec.Data)), offsets: make([]int64, count)}
+	for i := range idx.offsets {
+		idx.offsets[i] = int64(binary.LittleEndian.Uint64(rec.Data[8*(i+1):]))
+	}
+	return idx, start, nil
+}
+
+// Era returns the era number of the file.
+func (r *Reader) Era() Era {
+	return r.era
+}
+
+// State reads the BeaconState of the era.
+func (r *Reader) State() (state.BeaconState, error) {
+	enc, err := r.readCompressed(r.state, TypeCompressedBeaconState)
+	if err != nil {
+		return nil, err
+	}
+	u, err := detect.FromState(enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not detect era state version")
+	}
+	return u.UnmarshalBeaconState(enc)
+}
+
+// Block reads the SignedBeaconBlock at the given slot. A nil block is returned for empty slots.
+func (r *Reader) Block(slot primitives.Slot) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	if r.era == 0 || slot < r.era.StartSlot() || slot >= r.era.StateSlot() {
+		return nil, errors.Wrapf(errSlotOutOfRange, "slot=%d, era=%d", slot, r.era)
+	}
+	offset := r.blocks[slot-r.era.StartSlot()]
+	if offset == 0 {
+		return nil, nil
+	}
+	enc, err := r.readCompressed(offset, TypeCompressedSignedBeaconBlock)
+	if err != nil {
+		return nil, err
+	}
+	u, err := detect.FromBlock(enc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not detect version of block at slot %d", slot)
+	}
+	b, err := u.UnmarshalBeaconBlock(enc)
+	if err != nil {
+		return nil, err
+	}
+	if b.Block().Slot() != slot {
+		return nil, errors.Wrapf(errInvalidEraFile, "block indexed at slot %d has slot %d", slot, b.Block().Slot())
+	}
+	return b, nil
+}
+
+// Blocks reads all the blocks of the era, in increasing slot order.
+func (r *Reader) Blocks() ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, 0)
+	for i := range r.blocks {
+		if r.blocks[i] == 0 {
+			continue
+		}
+		b, err := r.Block(r.era.StartSlot() + primitives.Slot(i))
+		if err != nil {
+			return nil, err
+		}
+		blks = append(blks, b)
+	}
+	return blks, nil
+}
+
+func (r *Reader) readCompressed(offset int64, t [2]byte) ([]byte, error) {
+	rec, err := readRecord(r.r, offset, r.size)
+	if err != nil {
+		return nil, err
+	}
+	if rec.Type != t {
+		return nil, errors.Wrapf(errUnexpectedRecord, "type=%#x at offset %d, expected %#x", rec.Type, offset, t)
+	}
+	return io.ReadAll(snappy.NewReader(bytes.NewReader(rec.Data)))
+}
@@ -0,0 +1,201 @@
+package era
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+// testEra returns the first era which only contains capella blocks in the mainnet config.
+func testEra() Era {
+	return ForSlot(primitives.Slot(params.BeaconConfig().CapellaForkEpoch)*params.BeaconConfig().SlotsPerEpoch) + 1
+}
+
+// testBlocks generates a chain of blocks at the given slots, linked by parent root.
+func testBlocks(t *testing.T, parent [32]byte, slots ...primitives.Slot) []blocks.ROBlock {
+	blks := make([]blocks.ROBlock, len(slots))
+	for i, s := range slots {
+		b := util.NewBeaconBlockCapella()
+		b.Block.Slot = s
+		b.Block.ParentRoot = parent[:]
+		sb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		blks[i], err = blocks.NewROBlock(sb)
+		require.NoError(t, err)
+		parent = blks[i].Root()
+	}
+	return blks
+}
+
+// testEraState generates the state of the era, with block roots matching the given blocks.
+func testEraState(t *testing.T, e Era, blks []blocks.ROBlock) state.BeaconState {
+	n := params.BeaconConfig().SlotsPerHistoricalRoot
+	roots := make([][]byte, n)
+	prev := make([]byte, 32)
+	next := 0
+	for s := e.StartSlot(); s < e.StateSlot(); s++ {
+		if next < len(blks) && blks[next].Block().Slot() == s {
+			r := blks[next].Root()
+			prev = r[:]
+			next++
+		}
+		roots[s%n] = prev
+	}
+	st, err := util.NewBeaconStateCapella(func(s *ethpb.BeaconStateCapella) error {
+		s.Slot = e.StateSlot()
+		s.BlockRoots = roots
+		s.Fork.CurrentVersion = params.BeaconConfig().CapellaForkVersion
+		return nil
+	})
+	require.NoError(t, err)
+	return st
+}
+
+func writeTestEra(t *testing.T, e Era, blks []blocks.ROBlock, st state.BeaconState) []byte {
+	buf := bytes.NewBuffer(nil)
+	w, err := NewWriter(buf, e)
+	require.NoError(t, err)
+	for i := range blks {
+		require.NoError(t, w.WriteBlock(blks[i]))
+	}
+	require.NoError(t, w.WriteState(st))
+	require.NoError(t, w.Finish())
+	return buf.Bytes()
+}
+
+func TestEra_Slots(t *testing.T) {
+	n := params.BeaconConfig().SlotsPerHistoricalRoot
+	require.Equal(t, primitives.Slot(0), Era(0).StartSlot())
+	require.Equal(t, primitives.Slot(0), Era(0).StateSlot())
+	require.Equal(t, primitives.Slot(0), Era(1).StartSlot())
+	require.Equal(t, n, Era(1).StateSlot())
+	require.Equal(t, 2*n, Era(3).StartSlot())
+	require.Equal(t, Era(1), ForSlot(0))
+	require.Equal(t, Era(1), ForSlot(n-1))
+	require.Equal(t, Era(2), ForSlot(n))
+	require.Equal(t, "mainnet-00042-01020304.era", Filename("mainnet", 42, [32]byte{1, 2, 3, 4, 5}))
+}
+
+func TestWriterReader(t *testing.T) {
+	e := testEra()
+	start := e.StartSlot()
+	blks := testBlocks(t, [32]byte{}, start, start+1, start+5, e.StateSlot()-1)
+	st := testEraState(t, e, blks)
+	enc := writeTestEra(t, e, blks, st)
+
+	r, err := NewReader(bytes.NewReader(enc), int64(len(enc)))
+	require.NoError(t, err)
+	require.Equal(t, e, r.Era())
+
+	rst, err := r.State()
+	require.NoError(t, err)
+	expectedRoot, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	actualRoot, err := rst.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, actualRoot)
+
+	rblks, err := r.Blocks()
+	require.NoError(t, err)
+	require.Equal(t, len(blks), len(rblks))
+	for i := range rblks {
+		root, err := rblks[i].Block().HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, blks[i].Root(), root)
+	}
+	b, err := r.Block(start + 2)
+	require.NoError(t, err)
+	require.Equal(t, true, b == nil)
+	_, err = r.Block(e.StateSlot())
+	require.ErrorIs(t, err, errSlotOutOfRange)
+}
+
+func TestWriter_Errors(t *testing.T) {
+	e := testEra()
+	blks := testBlocks(t, [32]byte{}, e.StartSlot()+1, e.StartSlot(), e.StateSlot())
+	st := testEraState(t, e, nil)
+
+	w, err := NewWriter(bytes.NewBuffer(nil), e)
+	require.NoError(t, err)
+	require.ErrorIs(t, w.Finish(), errMissingState)
+	require.NoError(t, w.WriteBlock(blks[0]))
+	require.ErrorIs(t, w.WriteBlock(blks[1]), errBlocksOutOfOrder)
+	require.ErrorIs(t, w.WriteBlock(blks[2]), errSlotOutOfRange)
+	require.NoError(t, w.WriteState(st))
+	require.ErrorIs(t, w.WriteBlock(blks[2]), errSlotOutOfRange)
+
+	w, err = NewWriter(bytes.NewBuffer(nil), e+1)
+	require.NoError(t, err)
+	require.ErrorIs(t, w.WriteState(st), errSlotOutOfRange)
+
+	blinded := util.NewBlindedBeaconBlockCapella()
+	blinded.Block.Slot = e.StartSlot()
+	bb, err := blocks.NewSignedBeaconBlock(blinded)
+	require.NoError(t, err)
+	w, err = NewWriter(bytes.NewBuffer(nil), e)
+	require.NoError(t, err)
+	require.ErrorIs(t, w.WriteBlock(bb), errBlindedBlock)
+}
+
+func TestReader_Invalid(t *testing.T) {
+	e := testEra()
+	enc := writeTestEra(t, e, testBlocks(t, [32]byte{}, e.StartSlot()), testEraState(t, e, nil))
+
+	_, err := NewReader(bytes.NewReader(enc[:4]), 4)
+	require.ErrorIs(t, err, errInvalidEraFile)
+	// Truncating the file cuts into the state index.
+	_, err = NewReader(bytes.NewReader(enc), int64(len(enc)-8))
+	require.NotNil(t, err)
+
+	corrupt := bytes.Clone(enc)
+	// Point the state index at the version record, at the start of the file.
+	at := int64(len(corrupt)) - headerSize - 24
+	binary.LittleEndian.PutUint64(corrupt[len(corrupt)-16:], uint64(-at))
+	r, err := NewReader(bytes.NewReader(corrupt), int64(len(corrupt)))
+	require.NoError(t, err)
+	_, err = r.State()
+	require.ErrorIs(t, err, errUnexpectedRecord)
+
+	// A record length past the end of the file is rejected before the record is allocated.
+	r, err = NewReader(bytes.NewReader(enc), int64(len(enc)))
+	require.NoError(t, err)
+	corrupt = bytes.Clone(enc)
+	binary.LittleEndian.PutUint32(corrupt[r.state+2:], ^uint32(0))
+	r, err = NewReader(bytes.NewReader(corrupt), int64(len(corrupt)))
+	require.NoError(t, err)
+	_, err = r.State()
+	require.ErrorIs(t, err, errRecordTooLarge)
+}
+
+func TestOpen(t *testing.T) {
+	e := testEra()
+	blks := testBlocks(t, [32]byte{}, e.StartSlot()+3)
+	enc := writeTestEra(t, e, blks, testEraState(t, e, blks))
+	p := filepath.Join(t.TempDir(), "test.era")
+	require.NoError(t, os.WriteFile(p, enc, 0600))
+
+	f, err := Open(p)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	b, err := f.Block(e.StartSlot() + 3)
+	require.NoError(t, err)
+	root, err := b.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, blks[0].Root(), root)
+
+	_, err = Open(filepath.Join(t.TempDir(), "missing.era"))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,217 @@
+package era
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	errEraNotFinalized = errors.New("era is not finalized")
+	errBlindedHistory  = errors.New("the database stores blinded blocks, which can not be exported to era files")
+)
+
+// ExportDatabase describes the database methods needed to export era files.
+type ExportDatabase interface {
+	stategen.HistoryAccessor
+	Blocks(ctx context.Context, f *filters.QueryFilter) ([]interfaces.ReadOnlySignedBeaconBlock, [][32]byte, error)
+	IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
+	GenesisState(ctx context.Context) (state.BeaconState, error)
+	FinalizedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
+	SavesBlindedBlocks(ctx context.Context) (bool, error)
+}
+
+type finalizedChecker struct {
+	db ExportDatabase
+}
+
+// IsCanonical implements stategen.CanonicalChecker; only finalized blocks are exported.
+func (c *finalizedChecker) IsCanonical(ctx context.Context, root [32]byte) (bool, error) {
+	return c.db.IsFinalizedBlock(ctx, root), nil
+}
+
+type finalizedSlotter struct {
+	slot primitives.Slot
+}
+
+// CurrentSlot implements stategen.CurrentSlotter.
+func (s finalizedSlotter) CurrentSlot() primitives.Slot {
+	return s.slot
+}
+
+// LastFinalizedEra returns the most recent era for which both the blocks and the state are finalized.
+func LastFinalizedEra(ctx context.Context, db ExportDatabase) (Era, error) {
+	cp, err := db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not read finalized checkpoint")
+	}
+	fs, err := slots.EpochStart(cp.Epoch)
+	if err != nil {
+		return 0, err
+	}
+	return Era(fs / slotsPerEra()), nil
+}
+
+// Export writes the era files for the eras from start to end, inclusive, to the given directory, and returns
+// the paths of the written files. Only finalized eras can be exported, and eras with blocks after Bellatrix
+// only from a database storing full execution payloads.
+func Export(ctx context.Context, db ExportDatabase, dir string, start, end Era) ([]string, error) {
+	last, err := LastFinalizedEra(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	if end > last {
+		return nil, errors.Wrapf(errEraNotFinalized, "era=%d, last finalized era=%d", end, last)
+	}
+	if err := checkFullBlocks(ctx, db, end); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "could not create era directory %s", dir)
+	}
+	history := stategen.NewCanonicalHistory(db, &finalizedChecker{db: db}, finalizedSlotter{slot: last.StateSlot()})
+	paths := make([]string, 0, end-start+1)
+	for e := start; e <= end; e++ {
+		if ctx.Err() != nil {
+			return paths, ctx.Err()
+		}
+		p, err := exportEra(ctx, db, history, dir, e)
+		if err != nil {
+			return paths, errors.Wrapf(err, "could not export era %d", e)
+		}
+		paths = append(paths, p)
+		log.WithFields(logrus.Fields{"era": e, "path": p}).Info("Exported era file")
+	}
+	return paths, nil
+}
+
+// checkFullBlocks fails if the eras up to end can contain Bellatrix blocks while the database stores them blinded.
+// Era files hold full blocks, whose execution payloads are only kept by databases created with
+// --save-full-execution-payloads.
+func checkFullBlocks(ctx context.Context, db ExportDatabase, end Era) error {
+	fork := params.BeaconConfig().BellatrixForkEpoch
+	if fork == params.BeaconConfig().FarFutureEpoch {
+		return nil
+	}
+	forkSlot, err := slots.EpochStart(fork)
+	if err != nil {
+		return err
+	}
+	if end.StateSlot() <= forkSlot {
+		return nil
+	}
+	blinded, err := db.SavesBlindedBlocks(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not read the block storage type of the database")
+	}
+	if blinded {
+		return errors.Wrapf(errBlindedHistory, "era %d is the last era without Bellatrix blocks, later eras can only be "+
+			"exported from a database created with --%s", ForSlot(forkSlot)-1, features.SaveFullExecutionPayloads.Name)
+	}
+	return nil
+}
+
+func exportEra(ctx context.Context, db ExportDatabase, history *stategen.CanonicalHistory, dir string, e Era) (string, error) {
+	var st state.BeaconState
+	var err error
+	if e == 0 {
+		st, err = db.GenesisState(ctx)
+	} else {
+		st, err = history.ReplayerForSlot(e.StateSlot()).ReplayToSlot(ctx, e.StateSlot())
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "could not compute era state")
+	}
+	var blks []interfaces.ReadOnlySignedBeaconBlock
+	if e > 0 {
+		blks, err = finalizedBlocks(ctx, db, e)
+		if err != nil {
+			return "", err
+		}
+	}
+	root, err := Root(st, e)
+	if err != nil {
+		return "", err
+	}
+	p := filepath.Join(dir, Filename(params.BeaconConfig().ConfigName, e, root))
+	tmp := p + ".tmp"
+	if err := writeEra(tmp, e, blks, st); err != nil {
+		if rerr := os.Remove(tmp); rerr != nil && !os.IsNotExist(rerr) {
+			log.WithError(rerr).Error("Could not remove partially written era file")
+		}
+		return "", err
+	}
+	return p, os.Rename(tmp, p)
+}
+
+// finalizedBlocks returns the finalized blocks of the era, in increasing slot order. The genesis block is implied
+// by the genesis state and is not part of any era.
+func finalizedBlocks(ctx context.Context, db ExportDatabase, e Era) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	start := e.StartSlot()
+	if start == 0 {
+		start = 1
+	}
+	f := filters.NewFilter().SetStartSlot(start).SetEndSlot(e.StateSlot() - 1)
+	blks, roots, err := db.Blocks(ctx, f)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read era blocks")
+	}
+	finalized := make([]interfaces.ReadOnlySignedBeaconBlock, 0, len(blks))
+	for i := range blks {
+		if db.IsFinalizedBlock(ctx, roots[i]) {
+			finalized = append(finalized, blks[i])
+		}
+	}
+	sort.Slice(finalized, func(i, j int) bool {
+		return finalized[i].Block().Slot() < finalized[j].Block().Slot()
+	})
+	return finalized, nil
+}
+
+func writeEra(path string, e Era, blks []interfaces.ReadOnlySignedBeaconBlock, st state.ReadOnlyBeaconState) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600) // #nosec G304
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(f)
+	w, err := NewWriter(bw, e)
+	if err != nil {
+		return closeWith(f, err)
+	}
+	for _, b := range blks {
+		if err := w.WriteBlock(b); err != nil {
+			return closeWith(f, err)
+		}
+	}
+	if err := w.WriteState(st); err != nil {
+		return closeWith(f, err)
+	}
+	if err := w.Finish(); err != nil {
+		return closeWith(f, err)
+	}
+	if err := bw.Flush(); err != nil {
+		return closeWith(f, err)
+	}
+	return f.Close()
+}
+
+func closeWith(f *os.File, err error) error {
+	if cerr := f.Close(); cerr != nil {
+		log.WithError(cerr).Error("Could not close era file")
+	}
+	return err
+}
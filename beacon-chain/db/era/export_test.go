@@ -0,0 +1,214 @@
+package era
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+type mockExportDB struct {
+	genesis   state.BeaconState
+	finalized primitives.Epoch
+	blocks    []blocks.ROBlock
+	canonical map[[32]byte]bool
+	states    map[[32]byte]state.BeaconState
+	blinded   bool
+}
+
+var _ ExportDatabase = &mockExportDB{}
+
+func (m *mockExportDB) HighestRootsBelowSlot(_ context.Context, slot primitives.Slot) (primitives.Slot, [][32]byte, error) {
+	var highest primitives.Slot
+	var roots [][32]byte
+	for i := range m.blocks {
+		s := m.blocks[i].Block().Slot()
+		if s >= slot || s < highest {
+			continue
+		}
+		if s > highest {
+			highest, roots = s, nil
+		}
+		roots = append(roots, m.blocks[i].Root())
+	}
+	return highest, roots, nil
+}
+
+func (m *mockExportDB) GenesisBlockRoot(context.Context) ([32]byte, error) {
+	return [32]byte{}, nil
+}
+
+func (m *mockExportDB) Block(_ context.Context, root [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	for i := range m.blocks {
+		if m.blocks[i].Root() == root {
+			return m.blocks[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockExportDB) StateOrError(_ context.Context, root [32]byte) (state.BeaconState, error) {
+	st, ok := m.states[root]
+	if !ok {
+		return nil, db.ErrNotFoundState
+	}
+	return st, nil
+}
+
+func (m *mockExportDB) Blocks(_ context.Context, f *filters.QueryFilter) ([]interfaces.ReadOnlySignedBeaconBlock, [][32]byte, error) {
+	start, end := f.Filters()[filters.StartSlot].(primitives.Slot), f.Filters()[filters.EndSlot].(primitives.Slot)
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, 0)
+	roots := make([][32]byte, 0)
+	for i := range m.blocks {
+		if s := m.blocks[i].Block().Slot(); s >= start && s <= end {
+			blks = append(blks, m.blocks[i])
+			roots = append(roots, m.blocks[i].Root())
+		}
+	}
+	return blks, roots, nil
+}
+
+func (m *mockExportDB) IsFinalizedBlock(_ context.Context, root [32]byte) bool {
+	return m.canonical[root]
+}
+
+func (m *mockExportDB) GenesisState(context.Context) (state.BeaconState, error) {
+	return m.genesis, nil
+}
+
+func (m *mockExportDB) FinalizedCheckpoint(context.Context) (*ethpb.Checkpoint, error) {
+	return &ethpb.Checkpoint{Epoch: m.finalized, Root: make([]byte, 32)}, nil
+}
+
+func (m *mockExportDB) SavesBlindedBlocks(context.Context) (bool, error) {
+	return m.blinded, nil
+}
+
+func TestExport_Genesis(t *testing.T) {
+	ctx := context.Background()
+	genesis, err := util.NewBeaconStateCapella(func(s *ethpb.BeaconStateCapella) error {
+		s.GenesisValidatorsRoot = []byte{0xab, 0xcd, 0xef, 0x01, 0x02}
+		s.Fork.CurrentVersion = params.BeaconConfig().CapellaForkVersion
+		return nil
+	})
+	require.NoError(t, err)
+	m := &mockExportDB{genesis: genesis}
+	dir := filepath.Join(t.TempDir(), "era")
+
+	_, err = Export(ctx, m, dir, 0, 1)
+	require.ErrorIs(t, err, errEraNotFinalized)
+
+	paths, err := Export(ctx, m, dir, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(paths))
+	require.Equal(t, filepath.Join(dir, params.BeaconConfig().ConfigName+"-00000-abcdef01.era"), paths[0])
+
+	f, err := Open(paths[0])
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	require.Equal(t, Era(0), f.Era())
+	st, err := f.State()
+	require.NoError(t, err)
+	require.DeepEqual(t, genesis.GenesisValidatorsRoot(), st.GenesisValidatorsRoot())
+}
+
+func TestExport_FullBlocks(t *testing.T) {
+	ctx := context.Background()
+	e := testEra()
+	blks := testBlocks(t, [32]byte{}, e.StartSlot(), e.StateSlot()-1)
+	// The era state is replayed from the state of the last block of the era.
+	st, _ := util.DeterministicGenesisStateCapella(t, 64)
+	require.NoError(t, st.SetSlot(e.StateSlot()-1))
+	require.NoError(t, st.SetFork(&ethpb.Fork{
+		PreviousVersion: params.BeaconConfig().BellatrixForkVersion,
+		CurrentVersion:  params.BeaconConfig().CapellaForkVersion,
+		Epoch:           params.BeaconConfig().CapellaForkEpoch,
+	}))
+	// The historical summary of the era is appended after the historical roots of the previous eras.
+	require.NoError(t, st.SetHistoricalRoots(make([][]byte, e-1)))
+	m := &mockExportDB{
+		finalized: slots.ToEpoch(e.StateSlot()),
+		blocks:    blks,
+		canonical: map[[32]byte]bool{blks[0].Root(): true, blks[1].Root(): true},
+		states:    map[[32]byte]state.BeaconState{blks[1].Root(): st},
+		blinded:   true,
+	}
+	dir := filepath.Join(t.TempDir(), "era")
+
+	// Blocks saved blinded can not be exported, which is checked before writing anything.
+	_, err := Export(ctx, m, dir, e, e)
+	require.ErrorIs(t, err, errBlindedHistory)
+	_, err = os.Stat(dir)
+	require.Equal(t, true, os.IsNotExist(err))
+
+	m.blinded = false
+	paths, err := Export(ctx, m, dir, e, e)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(paths))
+	f, err := Open(paths[0])
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	require.Equal(t, e, f.Era())
+	exported, err := f.Blocks()
+	require.NoError(t, err)
+	require.Equal(t, len(blks), len(exported))
+	for i := range exported {
+		require.Equal(t, version.Capella, exported[i].Version())
+		require.Equal(t, false, exported[i].IsBlinded())
+		root, err := exported[i].Block().HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, blks[i].Root(), root)
+	}
+	eraState, err := f.State()
+	require.NoError(t, err)
+	require.Equal(t, e.StateSlot(), eraState.Slot())
+}
+
+func TestExport_BlindedBeforeBellatrix(t *testing.T) {
+	cfg := params.BeaconConfig().Copy()
+	cfg.BellatrixForkEpoch = 2 * primitives.Epoch(cfg.SlotsPerHistoricalRoot) / primitives.Epoch(cfg.SlotsPerEpoch)
+	params.OverrideBeaconConfig(cfg)
+	params.SetupTestConfigCleanup(t)
+
+	// Only eras ending after the Bellatrix fork can contain blinded blocks.
+	m := &mockExportDB{blinded: true}
+	require.NoError(t, checkFullBlocks(context.Background(), m, 2))
+	require.ErrorIs(t, checkFullBlocks(context.Background(), m, 3), errBlindedHistory)
+}
+
+func TestFinalizedBlocks(t *testing.T) {
+	e := testEra()
+	canonical := testBlocks(t, [32]byte{}, e.StartSlot(), e.StartSlot()+3)
+	fork := testBlocks(t, [32]byte{1}, e.StartSlot()+2)
+	outside := testBlocks(t, canonical[1].Root(), e.StateSlot())
+	m := &mockExportDB{
+		blocks:    []blocks.ROBlock{outside[0], canonical[1], fork[0], canonical[0]},
+		canonical: map[[32]byte]bool{canonical[0].Root(): true, canonical[1].Root(): true, outside[0].Root(): true},
+	}
+	blks, err := finalizedBlocks(context.Background(), m, e)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(blks))
+	require.Equal(t, e.StartSlot(), blks[0].Block().Slot())
+	require.Equal(t, e.StartSlot()+3, blks[1].Block().Slot())
+
+	last, err := LastFinalizedEra(context.Background(), &mockExportDB{finalized: primitives.Epoch(2 * params.BeaconConfig().SlotsPerHistoricalRoot / params.BeaconConfig().SlotsPerEpoch)})
+	require.NoError(t, err)
+	require.Equal(t, Era(2), last)
+}
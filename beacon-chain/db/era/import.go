@@ -0,0 +1,178 @@
+package era
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	"github.com/sirupsen/logrus"
+)
+
+var errEraDisconnected = errors.New("highest block of era does not connect to the lowest backfilled block")
+
+// ImportDatabase describes the database methods needed to import era files.
+type ImportDatabase interface {
+	BackfillStatus(context.Context) (*dbval.BackfillStatus, error)
+	SaveBackfillStatus(context.Context, *dbval.BackfillStatus) error
+	StateOrError(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
+	SaveROBlocks(ctx context.Context, blks []blocks.ROBlock, cache bool) error
+	BackfillFinalizedIndex(ctx context.Context, blocks []blocks.ROBlock, finalizedChildRoot [32]byte) error
+}
+
+// Files returns the paths of the era files in the given directory.
+func Files(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0)
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".era") {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, e.Name()))
+	}
+	return paths, nil
+}
+
+// Import fills the gap in block history left by checkpoint sync, from the era files in the given directory.
+// Eras are imported from the most recent backwards, starting from the lowest block already in the database.
+// Each era state is verified against the historical roots of the checkpoint sync origin state, and the era blocks
+// against the block roots of the era state, so the era files do not need to come from a trusted source.
+// The number of imported blocks is returned.
+func Import(ctx context.Context, db ImportDatabase, dir string) (int, error) {
+	status, err := db.BackfillStatus(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "era import requires a checkpoint synced database with a backfill status")
+	}
+	trusted, err := db.StateOrError(ctx, bytesutil.ToBytes32(status.OriginRoot))
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not load checkpoint sync origin state %#x", status.OriginRoot)
+	}
+	paths, err := Files(dir)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not list era files in %s", dir)
+	}
+	eras, err := eraFiles(paths)
+	if err != nil {
+		return 0, err
+	}
+
+	imported := 0
+	for _, ef := range eras {
+		if ctx.Err() != nil {
+			return imported, ctx.Err()
+		}
+		if ef.era == 0 || ef.era.StartSlot() >= primitives.Slot(status.LowSlot) {
+			continue
+		}
+		n, err := importFile(ctx, db, trusted, ef.path, status)
+		if err != nil {
+			return imported, errors.Wrapf(err, "could not import era %d", ef.era)
+		}
+		imported += n
+		log.WithFields(logrus.Fields{
+			"era":     ef.era,
+			"blocks":  n,
+			"lowSlot": status.LowSlot,
+		}).Info("Imported era file")
+	}
+	return imported, nil
+}
+
+type eraFile struct {
+	path string
+	era  Era
+}
+
+// eraFiles reads the era number of each file, closing it right away, and sorts the files from the most recent era.
+func eraFiles(paths []string) ([]eraFile, error) {
+	eras := make([]eraFile, 0, len(paths))
+	for _, p := range paths {
+		f, err := Open(p)
+		if err != nil {
+			return nil, err
+		}
+		eras = append(eras, eraFile{path: p, era: f.Era()})
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close era file")
+		}
+	}
+	sort.Slice(eras, func(i, j int) bool {
+		return eras[i].era > eras[j].era
+	})
+	return eras, nil
+}
+
+// importFile opens the era file at the given path for the duration of its import.
+func importFile(ctx context.Context, db ImportDatabase, trusted state.ReadOnlyBeaconState, path string, status *dbval.BackfillStatus) (int, error) {
+	f, err := Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close era file")
+		}
+	}()
+	return importEra(ctx, db, trusted, f.Reader, status)
+}
+
+// importEra imports the blocks of the era below the lowest backfilled block, and updates the given status.
+func importEra(ctx context.Context, db ImportDatabase, trusted state.ReadOnlyBeaconState, r *Reader, status *dbval.BackfillStatus) (int, error) {
+	eraState, err := r.State()
+	if err != nil {
+		return 0, errors.Wrap(err, "could not read era state")
+	}
+	if err := VerifyState(trusted, eraState, r.Era()); err != nil {
+		return 0, err
+	}
+	signed, err := r.Blocks()
+	if err != nil {
+		return 0, err
+	}
+	blks := make([]blocks.ROBlock, 0, len(signed))
+	for i := range signed {
+		if signed[i].Block().Slot() >= primitives.Slot(status.LowSlot) {
+			break
+		}
+		rb, err := blocks.NewROBlock(signed[i])
+		if err != nil {
+			return 0, err
+		}
+		blks = append(blks, rb)
+	}
+	if len(blks) == 0 {
+		return 0, nil
+	}
+	if err := VerifyBlocks(eraState, blks); err != nil {
+		return 0, err
+	}
+	highest := blks[len(blks)-1]
+	if highest.Root() != bytesutil.ToBytes32(status.LowParentRoot) {
+		return 0, errors.Wrapf(errEraDisconnected, "parent_root=%#x, root=%#x, slot=%d", status.LowParentRoot, highest.Root(), highest.Block().Slot())
+	}
+	if err := db.SaveROBlocks(ctx, blks, false); err != nil {
+		return 0, errors.Wrap(err, "could not save era blocks")
+	}
+	if err := db.BackfillFinalizedIndex(ctx, blks, bytesutil.ToBytes32(status.LowRoot)); err != nil {
+		return 0, errors.Wrap(err, "could not update finalized index")
+	}
+	lowest := blks[0]
+	pr := lowest.Block().ParentRoot()
+	status.LowSlot = uint64(lowest.Block().Slot())
+	status.LowRoot = lowest.RootSlice()
+	status.LowParentRoot = pr[:]
+	if err := db.SaveBackfillStatus(ctx, status); err != nil {
+		return 0, errors.Wrap(err, "could not save backfill status")
+	}
+	return len(blks), nil
+}
@@ -0,0 +1,129 @@
+package era
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+type mockImportDB struct {
+	status *dbval.BackfillStatus
+	states map[[32]byte]state.BeaconState
+	blocks map[[32]byte]blocks.ROBlock
+	child  map[[32]byte][32]byte
+}
+
+var _ ImportDatabase = &mockImportDB{}
+
+func (m *mockImportDB) BackfillStatus(context.Context) (*dbval.BackfillStatus, error) {
+	if m.status == nil {
+		return nil, db.ErrNotFound
+	}
+	return m.status, nil
+}
+
+func (m *mockImportDB) SaveBackfillStatus(_ context.Context, status *dbval.BackfillStatus) error {
+	m.status = status
+	return nil
+}
+
+func (m *mockImportDB) StateOrError(_ context.Context, root [32]byte) (state.BeaconState, error) {
+	st, ok := m.states[root]
+	if !ok {
+		return nil, db.ErrNotFoundState
+	}
+	return st, nil
+}
+
+func (m *mockImportDB) SaveROBlocks(_ context.Context, blks []blocks.ROBlock, _ bool) error {
+	for i := range blks {
+		m.blocks[blks[i].Root()] = blks[i]
+	}
+	return nil
+}
+
+func (m *mockImportDB) BackfillFinalizedIndex(_ context.Context, blks []blocks.ROBlock, finalizedChildRoot [32]byte) error {
+	for i := range blks {
+		m.child[blks[i].Root()] = finalizedChildRoot
+		if i+1 < len(blks) {
+			m.child[blks[i].Root()] = blks[i+1].Root()
+		}
+	}
+	return nil
+}
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	e := testEra()
+	genesisParent := [32]byte{0xaa}
+	chain := testBlocks(t, genesisParent, e.StartSlot(), e.StartSlot()+2, e.StateSlot()-1, e.StateSlot()+1)
+	eraBlocks, origin := chain[:3], chain[3]
+	eraState := testEraState(t, e, eraBlocks)
+
+	dir := t.TempDir()
+	enc := writeTestEra(t, e, eraBlocks, eraState)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, Filename(params.BeaconConfig().ConfigName, e, [32]byte{})), enc, 0600))
+	// Files without the era extension are ignored.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("not an era"), 0600))
+
+	newDB := func(trusted state.BeaconState) *mockImportDB {
+		pr := origin.Block().ParentRoot()
+		return &mockImportDB{
+			status: &dbval.BackfillStatus{
+				LowSlot:       uint64(origin.Block().Slot()),
+				LowRoot:       origin.RootSlice(),
+				LowParentRoot: pr[:],
+				OriginSlot:    uint64(origin.Block().Slot()),
+				OriginRoot:    origin.RootSlice(),
+			},
+			states: map[[32]byte]state.BeaconState{origin.Root(): trusted},
+			blocks: make(map[[32]byte]blocks.ROBlock),
+			child:  make(map[[32]byte][32]byte),
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		m := newDB(testTrustedState(t, e, testSummary(t, eraState)))
+		n, err := Import(ctx, m, dir)
+		require.NoError(t, err)
+		require.Equal(t, len(eraBlocks), n)
+		for i := range eraBlocks {
+			_, ok := m.blocks[eraBlocks[i].Root()]
+			require.Equal(t, true, ok)
+		}
+		require.Equal(t, origin.Root(), m.child[eraBlocks[2].Root()])
+		require.Equal(t, uint64(e.StartSlot()), m.status.LowSlot)
+		require.DeepEqual(t, genesisParent[:], m.status.LowParentRoot)
+
+		// The era is already imported.
+		n, err = Import(ctx, m, dir)
+		require.NoError(t, err)
+		require.Equal(t, 0, n)
+	})
+	t.Run("root mismatch", func(t *testing.T) {
+		summary := testSummary(t, eraState)
+		summary.StateSummaryRoot[0] ^= 1
+		m := newDB(testTrustedState(t, e, summary))
+		_, err := Import(ctx, m, dir)
+		require.ErrorIs(t, err, errEraRootMismatch)
+		require.Equal(t, 0, len(m.blocks))
+	})
+	t.Run("disconnected", func(t *testing.T) {
+		m := newDB(testTrustedState(t, e, testSummary(t, eraState)))
+		m.status.LowParentRoot = make([]byte, 32)
+		_, err := Import(ctx, m, dir)
+		require.ErrorIs(t, err, errEraDisconnected)
+	})
+	t.Run("no backfill status", func(t *testing.T) {
+		_, err := Import(ctx, &mockImportDB{}, dir)
+		require.ErrorIs(t, err, db.ErrNotFound)
+	})
+}
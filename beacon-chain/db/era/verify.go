@@ -0,0 +1,119 @@
+package era
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+var (
+	errEraNotInHistory  = errors.New("era is not covered by the historical roots of the trusted state")
+	errEraRootMismatch  = errors.New("era state does not match the historical root of the trusted state")
+	errBlockRootInvalid = errors.New("block root does not match the block roots of the era state")
+	errBlocksNotLinked  = errors.New("block parent_root does not match the root of the preceding block")
+)
+
+// Root returns the historical root identifying the given era, as seen by the given state. For eras accumulated
+// before Capella this is the corresponding entry of historical_roots, and for later eras it is the hash tree root
+// of the corresponding entry of historical_summaries. Era 0 is identified by the genesis validators root.
+func Root(st state.ReadOnlyBeaconState, e Era) ([32]byte, error) {
+	if e == 0 {
+		return bytesutil.ToBytes32(st.GenesisValidatorsRoot()), nil
+	}
+	hr, err := st.HistoricalRoots()
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not read historical roots")
+	}
+	i := uint64(e - 1)
+	if i < uint64(len(hr)) {
+		return bytesutil.ToBytes32(hr[i]), nil
+	}
+	summary, err := historicalSummary(st, i-uint64(len(hr)))
+	if err != nil {
+		return [32]byte{}, errors.Wrapf(err, "era=%d", e)
+	}
+	return summary.HashTreeRoot()
+}
+
+func historicalSummary(st state.ReadOnlyBeaconState, i uint64) (*ethpb.HistoricalSummary, error) {
+	if st.Version() < version.Capella {
+		return nil, errEraNotInHistory
+	}
+	hs, err := st.HistoricalSummaries()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read historical summaries")
+	}
+	if i >= uint64(len(hs)) {
+		return nil, errEraNotInHistory
+	}
+	return hs[i], nil
+}
+
+// VerifyState checks that the block_roots and state_roots of the state stored in the given era match the
+// historical root that the trusted state holds for the era.
+func VerifyState(trusted, eraState state.ReadOnlyBeaconState, e Era) error {
+	if e == 0 {
+		return nil
+	}
+	if eraState.Slot() != e.StateSlot() {
+		return errors.Wrapf(errSlotOutOfRange, "era state slot=%d, era=%d", eraState.Slot(), e)
+	}
+	hr, err := trusted.HistoricalRoots()
+	if err != nil {
+		return errors.Wrap(err, "could not read historical roots")
+	}
+	i := uint64(e - 1)
+	if i < uint64(len(hr)) {
+		batch := &ethpb.HistoricalBatch{BlockRoots: eraState.BlockRoots(), StateRoots: eraState.StateRoots()}
+		root, err := batch.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not compute historical batch root")
+		}
+		if root != bytesutil.ToBytes32(hr[i]) {
+			return errors.Wrapf(errEraRootMismatch, "era=%d, historical root=%#x, era state batch root=%#x", e, hr[i], root)
+		}
+		return nil
+	}
+	summary, err := historicalSummary(trusted, i-uint64(len(hr)))
+	if err != nil {
+		return errors.Wrapf(err, "era=%d", e)
+	}
+	n := params.BeaconConfig().SlotsPerHistoricalRoot
+	br, err := stateutil.ArraysRoot(eraState.BlockRoots(), uint64(n))
+	if err != nil {
+		return errors.Wrap(err, "could not compute block roots root")
+	}
+	sr, err := stateutil.ArraysRoot(eraState.StateRoots(), uint64(n))
+	if err != nil {
+		return errors.Wrap(err, "could not compute state roots root")
+	}
+	if br != bytesutil.ToBytes32(summary.BlockSummaryRoot) || sr != bytesutil.ToBytes32(summary.StateSummaryRoot) {
+		return errors.Wrapf(errEraRootMismatch, "era=%d, block summary root=%#x, state summary root=%#x", e, summary.BlockSummaryRoot, summary.StateSummaryRoot)
+	}
+	return nil
+}
+
+// VerifyBlocks checks that the given blocks, sorted by slot, are the ones committed to by the block_roots of the
+// era state, and that each block is the parent of the next one.
+func VerifyBlocks(eraState state.ReadOnlyBeaconState, blks []blocks.ROBlock) error {
+	roots := eraState.BlockRoots()
+	for i := range blks {
+		slot := blks[i].Block().Slot()
+		if slot >= eraState.Slot() || eraState.Slot()-slot > slotsPerEra() {
+			return errors.Wrapf(errSlotOutOfRange, "block slot=%d, era state slot=%d", slot, eraState.Slot())
+		}
+		expected := bytesutil.ToBytes32(roots[slot%slotsPerEra()])
+		if blks[i].Root() != expected {
+			return errors.Wrapf(errBlockRootInvalid, "slot=%d, root=%#x, expected=%#x", slot, blks[i].Root(), expected)
+		}
+		if i > 0 && blks[i].Block().ParentRoot() != blks[i-1].Root() {
+			return errors.Wrapf(errBlocksNotLinked, "slot=%d, parent_root=%#x, previous root=%#x", slot, blks[i].Block().ParentRoot(), blks[i-1].Root())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package era
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func testSummary(t *testing.T, eraState state.ReadOnlyBeaconState) *ethpb.HistoricalSummary {
+	n := uint64(params.BeaconConfig().SlotsPerHistoricalRoot)
+	br, err := stateutil.ArraysRoot(eraState.BlockRoots(), n)
+	require.NoError(t, err)
+	sr, err := stateutil.ArraysRoot(eraState.StateRoots(), n)
+	require.NoError(t, err)
+	return &ethpb.HistoricalSummary{BlockSummaryRoot: br[:], StateSummaryRoot: sr[:]}
+}
+
+// testTrustedState generates a state which holds the given summary for the given era,
+// with the preceding eras accumulated in historical_roots.
+func testTrustedState(t *testing.T, e Era, summary *ethpb.HistoricalSummary) state.BeaconState {
+	st, err := util.NewBeaconStateCapella(func(s *ethpb.BeaconStateCapella) error {
+		s.HistoricalRoots = make([][]byte, e-1)
+		for i := range s.HistoricalRoots {
+			s.HistoricalRoots[i] = make([]byte, 32)
+		}
+		s.HistoricalSummaries = []*ethpb.HistoricalSummary{summary}
+		return nil
+	})
+	require.NoError(t, err)
+	return st
+}
+
+func TestVerifyState(t *testing.T) {
+	e := testEra()
+	eraState := testEraState(t, e, testBlocks(t, [32]byte{}, e.StartSlot()))
+
+	t.Run("historical summary", func(t *testing.T) {
+		trusted := testTrustedState(t, e, testSummary(t, eraState))
+		require.NoError(t, VerifyState(trusted, eraState, e))
+		require.ErrorIs(t, VerifyState(trusted, eraState, e+1), errSlotOutOfRange)
+
+		summary := testSummary(t, eraState)
+		summary.BlockSummaryRoot[0] ^= 1
+		require.ErrorIs(t, VerifyState(testTrustedState(t, e, summary), eraState, e), errEraRootMismatch)
+	})
+	t.Run("historical root", func(t *testing.T) {
+		batch := &ethpb.HistoricalBatch{BlockRoots: eraState.BlockRoots(), StateRoots: eraState.StateRoots()}
+		root, err := batch.HashTreeRoot()
+		require.NoError(t, err)
+		trusted, err := util.NewBeaconStateCapella(func(s *ethpb.BeaconStateCapella) error {
+			s.HistoricalRoots = make([][]byte, e)
+			for i := range s.HistoricalRoots {
+				s.HistoricalRoots[i] = make([]byte, 32)
+			}
+			s.HistoricalRoots[e-1] = root[:]
+			return nil
+		})
+		require.NoError(t, err)
+		require.NoError(t, VerifyState(trusted, eraState, e))
+
+		hr, err := Root(trusted, e)
+		require.NoError(t, err)
+		require.Equal(t, root, hr)
+	})
+	t.Run("era not in history", func(t *testing.T) {
+		trusted := testTrustedState(t, e-1, testSummary(t, eraState))
+		require.ErrorIs(t, VerifyState(trusted, eraState, e), errEraNotInHistory)
+		_, err := Root(trusted, e)
+		require.ErrorIs(t, err, errEraNotInHistory)
+	})
+}
+
+func TestVerifyBlocks(t *testing.T) {
+	e := testEra()
+	blks := testBlocks(t, [32]byte{}, e.StartSlot(), e.StartSlot()+4, e.StateSlot()-1)
+	eraState := testEraState(t, e, blks)
+	require.NoError(t, VerifyBlocks(eraState, blks))
+
+	other := testBlocks(t, [32]byte{1}, e.StartSlot()+4)
+	require.ErrorIs(t, VerifyBlocks(eraState, []blocks.ROBlock{blks[0], other[0]}), errBlockRootInvalid)
+	require.ErrorIs(t, VerifyBlocks(eraState, []blocks.ROBlock{blks[0], blks[0]}), errBlocksNotLinked)
+}
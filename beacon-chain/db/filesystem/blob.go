@@ -66,6 +66,15 @@ func WithSaveFsync(fsync bool) BlobStorageOption {
 	}
 }
 
+// WithArchiveMode is an option that exempts all blobs from the retention policy, so that they are never pruned.
+// This enables archive nodes to keep blobs outside of the retention period, for instance those downloaded by backfill.
+func WithArchiveMode(archive bool) BlobStorageOption {
+	return func(b *BlobStorage) error {
+		b.archive = archive
+		return nil
+	}
+}
+
 // NewBlobStorage creates a new instance of the BlobStorage object. Note that the implementation of BlobStorage may
 // attempt to hold a file lock to guarantee exclusive control of the blob storage directory, so this should only be
 // initialized once per beacon node.
@@ -84,7 +93,11 @@ func NewBlobStorage(opts ...BlobStorageOption) (*BlobStorage, error) {
 		return nil, errors.Wrapf(err, "failed to create blob storage at %s", b.base)
 	}
 	b.fs = afero.NewBasePathFs(afero.NewOsFs(), b.base)
-	pruner, err := newBlobPruner(b.fs, b.retentionEpochs)
+	var popts []prunerOpt
+	if b.archive {
+		popts = append(popts, withArchive())
+	}
+	pruner, err := newBlobPruner(b.fs, b.retentionEpochs, popts...)
 	if err != nil {
 		return nil, err
 	}
@@ -97,6 +110,7 @@ type BlobStorage struct {
 	base            string
 	retentionEpochs primitives.Epoch
 	fsync           bool
+	archive         bool
 	fs              afero.Fs
 	pruner          *blobPruner
 }
@@ -117,6 +131,11 @@ func (bs *BlobStorage) WarmCache() {
 	}()
 }
 
+// ArchiveMode returns true if the BlobStorage retains blobs indefinitely, see WithArchiveMode.
+func (bs *BlobStorage) ArchiveMode() bool {
+	return bs != nil && bs.archive
+}
+
 // ErrBlobStorageSummarizerUnavailable is a sentinel error returned when there is no pruner/cache available.
 // This should be used by code that optionally uses the summarizer to optimize rpc requests. Being able to
 // fallback when there is no summarizer allows client code to avoid test complexity where the summarizer doesn't matter.
@@ -34,11 +34,21 @@ type blobPruner struct {
 	cache        *blobStorageCache
 	cacheReady   chan struct{}
 	warmed       bool
+	archive      bool
 	fs           afero.Fs
 }
 
 type prunerOpt func(*blobPruner) error
 
+// withArchive disables pruning, so that blobs are retained indefinitely. The pruner is still responsible
+// for maintaining the cache of blobs on disk.
+func withArchive() prunerOpt {
+	return func(p *blobPruner) error {
+		p.archive = true
+		return nil
+	}
+}
+
 func withWarmedCache() prunerOpt {
 	return func(p *blobPruner) error {
 		return p.warmCache()
@@ -66,6 +76,9 @@ func (p *blobPruner) notify(root [32]byte, latest primitives.Slot, idx uint64) e
 	if err := p.cache.ensure(root, latest, idx); err != nil {
 		return err
 	}
+	if p.archive {
+		return nil
+	}
 	pruned := uint64(windowMin(latest, p.windowSize))
 	if p.prunedBefore.Swap(pruned) == pruned {
 		return nil
@@ -112,6 +112,28 @@ func TestTryPruneDir_CachedExpired(t *testing.T) {
 	})
 }
 
+func TestNotify_Archive(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	pr, err := newBlobPruner(fs, 0, withArchive())
+	require.NoError(t, err)
+	bs := &BlobStorage{fs: fs, pruner: pr, archive: true}
+	require.Equal(t, true, bs.ArchiveMode())
+
+	_, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 0, 1)
+	scs, err := verification.BlobSidecarSliceNoop(sidecars)
+	require.NoError(t, err)
+	require.NoError(t, bs.Save(scs[0]))
+
+	// A blob far beyond the retention window would normally trigger pruning of the first blob.
+	latest := pr.windowSize + 10*params.BeaconConfig().SlotsPerEpoch
+	require.NoError(t, pr.notify([32]byte{1}, latest, 0))
+	require.Equal(t, uint64(0), pr.prunedBefore.Load())
+	files, err := listDir(fs, rootString(scs[0].BlockRoot()))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(files))
+	require.Equal(t, true, pr.cache.Summary([32]byte{1}).HasIndex(0))
+}
+
 func TestTryPruneDir_SlotFromFile(t *testing.T) {
 	t.Run("expired blobs deleted", func(t *testing.T) {
 		fs, bs := NewEphemeralBlobStorageWithFs(t)
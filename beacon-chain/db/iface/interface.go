@@ -12,8 +12,10 @@ import (
 	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/slashing"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/backup"
 	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
@@ -64,6 +66,13 @@ type ReadOnlyDatabase interface {
 	// origin checkpoint sync support
 	OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
 	BackfillStatus(context.Context) (*dbval.BackfillStatus, error)
+	// Fork choice snapshot operations.
+	ForkChoiceSnapshot(ctx context.Context) ([]byte, error)
+	// Reorg analytics operations.
+	ReorgEvents(ctx context.Context, from, to primitives.Slot) ([]*forkchoice.ReorgEvent, error)
+	// Slashing evidence operations.
+	SlashingEvidence(ctx context.Context, epoch primitives.Epoch, root [32]byte) (*slashing.Evidence, error)
+	SlashingEvidenceSince(ctx context.Context, epoch primitives.Epoch) ([]*slashing.Evidence, error)
 }
 
 // NoHeadAccessDatabase defines a struct without access to chain head data.
@@ -99,6 +108,14 @@ type NoHeadAccessDatabase interface {
 	// light client operations
 	SaveLightClientUpdate(ctx context.Context, period uint64, update interfaces.LightClientUpdate) error
 	SaveLightClientBootstrap(ctx context.Context, blockRoot []byte, bootstrap interfaces.LightClientBootstrap) error
+	// Fork choice snapshot operations.
+	SaveForkChoiceSnapshot(ctx context.Context, snapshot []byte) error
+	// Reorg analytics operations.
+	SaveReorgEvent(ctx context.Context, event *forkchoice.ReorgEvent) error
+	DeleteReorgEventsBefore(ctx context.Context, slot primitives.Slot) error
+	// Slashing evidence operations.
+	SaveSlashingEvidence(ctx context.Context, e *slashing.Evidence) error
+	DeleteSlashingEvidenceBefore(ctx context.Context, epoch primitives.Epoch) error
 
 	CleanUpDirtyStates(ctx context.Context, slotsPerArchivedPoint primitives.Slot) error
 }
@@ -256,6 +256,12 @@ func (s *Store) SaveBlock(ctx context.Context, signed interfaces.ReadOnlySignedB
 // if a `saveBlindedBeaconBlocks` key exists in the database. Otherwise, we check if the last
 // blocked stored to check if it is blinded, and then write that `saveBlindedBeaconBlocks` key
 // to the DB for future checks.
+// SavesBlindedBlocks reports whether the database stores post-Bellatrix blocks with execution payload headers
+// instead of full payloads, which is the case unless it was created with --save-full-execution-payloads.
+func (s *Store) SavesBlindedBlocks(ctx context.Context) (bool, error) {
+	return s.shouldSaveBlinded(ctx)
+}
+
 func (s *Store) shouldSaveBlinded(ctx context.Context) (bool, error) {
 	var saveBlinded bool
 	if err := s.db.View(func(tx *bolt.Tx) error {
@@ -0,0 +1,36 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ForkChoiceSnapshot returns the last saved fork choice snapshot, or nil if none was saved.
+func (s *Store) ForkChoiceSnapshot(ctx context.Context) ([]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ForkChoiceSnapshot")
+	defer span.End()
+	var snapshot []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(chainMetadataBucket).Get(forkchoiceSnapshotKey)
+		if enc == nil {
+			return nil
+		}
+		var err error
+		snapshot, err = snappy.Decode(nil, enc)
+		return err
+	})
+	return snapshot, err
+}
+
+// SaveForkChoiceSnapshot saves a serialized fork choice store, replacing the previous snapshot.
+func (s *Store) SaveForkChoiceSnapshot(ctx context.Context, snapshot []byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveForkChoiceSnapshot")
+	defer span.End()
+	enc := snappy.Encode(nil, snapshot)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(chainMetadataBucket).Put(forkchoiceSnapshotKey, enc)
+	})
+}
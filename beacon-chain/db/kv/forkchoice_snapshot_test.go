@@ -0,0 +1,22 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_ForkChoiceSnapshot(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	snapshot, err := db.ForkChoiceSnapshot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(snapshot))
+
+	require.NoError(t, db.SaveForkChoiceSnapshot(ctx, []byte("first")))
+	require.NoError(t, db.SaveForkChoiceSnapshot(ctx, []byte("second")))
+	snapshot, err = db.ForkChoiceSnapshot(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte("second"), snapshot)
+}
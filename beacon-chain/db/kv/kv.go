@@ -120,6 +120,8 @@ var Buckets = [][]byte{
 
 	feeRecipientBucket,
 	registrationBucket,
+	reorgEventsBucket,
+	slashingEvidenceBucket,
 }
 
 // KVStoreOption is a functional option that modifies a kv.Store.
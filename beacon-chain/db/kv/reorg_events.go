@@ -0,0 +1,170 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SaveReorgEvent saves a reorg or late block reorg decision. Events are keyed by slot followed by
+// a sequence number, so several events in the same slot are kept in insertion order.
+func (s *Store) SaveReorgEvent(ctx context.Context, event *forkchoicetypes.ReorgEvent) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveReorgEvent")
+	defer span.End()
+	if event == nil {
+		return errors.New("nil reorg event")
+	}
+	enc, err := encode(ctx, reorgEventToProto(event))
+	if err != nil {
+		return errors.Wrap(err, "could not encode reorg event")
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(reorgEventsBucket)
+		seq, err := bkt.NextSequence()
+		if err != nil {
+			return err
+		}
+		key := append(bytesutil.SlotToBytesBigEndian(event.Slot), bytesutil.Uint64ToBytesBigEndian(seq)...)
+		return bkt.Put(key, enc)
+	})
+}
+
+// ReorgEvents returns the saved reorg events whose slot lies in the inclusive range [from, to].
+func (s *Store) ReorgEvents(ctx context.Context, from, to primitives.Slot) ([]*forkchoicetypes.ReorgEvent, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.ReorgEvents")
+	defer span.End()
+	if to < from {
+		return nil, errors.Errorf("invalid slot range: from %d is higher than to %d", from, to)
+	}
+	events := make([]*forkchoicetypes.ReorgEvent, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(reorgEventsBucket).Cursor()
+		max := bytesutil.SlotToBytesBigEndian(to)
+		for k, v := c.Seek(bytesutil.SlotToBytesBigEndian(from)); k != nil && bytes.Compare(k[:8], max) <= 0; k, v = c.Next() {
+			event := &dbval.ReorgEvent{}
+			if err := decode(ctx, v, event); err != nil {
+				return errors.Wrap(err, "could not decode reorg event")
+			}
+			events = append(events, reorgEventFromProto(event))
+		}
+		return nil
+	})
+	return events, err
+}
+
+// DeleteReorgEventsBefore deletes the saved reorg events whose slot is lower than the given slot.
+func (s *Store) DeleteReorgEventsBefore(ctx context.Context, slot primitives.Slot) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DeleteReorgEventsBefore")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		c := tx.Bucket(reorgEventsBucket).Cursor()
+		min := bytesutil.SlotToBytesBigEndian(slot)
+		for k, _ := c.First(); k != nil && bytes.Compare(k[:8], min) < 0; k, _ = c.First() {
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func reorgEventToProto(e *forkchoicetypes.ReorgEvent) *dbval.ReorgEvent {
+	branch := func(blocks []*forkchoicetypes.ReorgBlock) []*dbval.ReorgBlock {
+		if len(blocks) == 0 {
+			return nil
+		}
+		result := make([]*dbval.ReorgBlock, len(blocks))
+		for i, b := range blocks {
+			result[i] = &dbval.ReorgBlock{
+				Root:          b.Root[:],
+				Slot:          uint64(b.Slot),
+				ProposerIndex: uint64(b.ProposerIndex),
+				Weight:        b.Weight,
+				ArrivalMillis: b.ArrivalMillis,
+				Timely:        b.Timely,
+				ProposerBoost: b.ProposerBoost,
+			}
+		}
+		return result
+	}
+	var atts []*dbval.OrphanedAttestation
+	for _, a := range e.OrphanedAttestations {
+		atts = append(atts, &dbval.OrphanedAttestation{
+			BlockRoot:        a.BlockRoot[:],
+			Slot:             uint64(a.Slot),
+			CommitteeIndex:   uint64(a.CommitteeIndex),
+			BeaconBlockRoot:  a.BeaconBlockRoot[:],
+			Attesters:        a.Attesters,
+			AttestingIndices: a.AttestingIndices,
+		})
+	}
+	return &dbval.ReorgEvent{
+		Kind:                 uint64(e.Kind),
+		Slot:                 uint64(e.Slot),
+		Timestamp:            e.Timestamp,
+		Reason:               e.Reason,
+		OldHeadRoot:          e.OldHeadRoot[:],
+		NewHeadRoot:          e.NewHeadRoot[:],
+		CommonAncestorRoot:   e.CommonAncestorRoot[:],
+		CommonAncestorSlot:   uint64(e.CommonAncestorSlot),
+		Depth:                e.Depth,
+		OldBranch:            branch(e.OldBranch),
+		NewBranch:            branch(e.NewBranch),
+		OrphanedAttestations: atts,
+		Truncated:            e.Truncated,
+	}
+}
+
+func reorgEventFromProto(e *dbval.ReorgEvent) *forkchoicetypes.ReorgEvent {
+	branch := func(blocks []*dbval.ReorgBlock) []*forkchoicetypes.ReorgBlock {
+		if len(blocks) == 0 {
+			return nil
+		}
+		result := make([]*forkchoicetypes.ReorgBlock, len(blocks))
+		for i, b := range blocks {
+			result[i] = &forkchoicetypes.ReorgBlock{
+				Root:          bytesutil.ToBytes32(b.Root),
+				Slot:          primitives.Slot(b.Slot),
+				ProposerIndex: primitives.ValidatorIndex(b.ProposerIndex),
+				Weight:        b.Weight,
+				ArrivalMillis: b.ArrivalMillis,
+				Timely:        b.Timely,
+				ProposerBoost: b.ProposerBoost,
+			}
+		}
+		return result
+	}
+	var atts []*forkchoicetypes.OrphanedAttestation
+	for _, a := range e.OrphanedAttestations {
+		atts = append(atts, &forkchoicetypes.OrphanedAttestation{
+			BlockRoot:        bytesutil.ToBytes32(a.BlockRoot),
+			Slot:             primitives.Slot(a.Slot),
+			CommitteeIndex:   primitives.CommitteeIndex(a.CommitteeIndex),
+			BeaconBlockRoot:  bytesutil.ToBytes32(a.BeaconBlockRoot),
+			Attesters:        a.Attesters,
+			AttestingIndices: a.AttestingIndices,
+		})
+	}
+	return &forkchoicetypes.ReorgEvent{
+		Kind:                 forkchoicetypes.ReorgKind(e.Kind),
+		Slot:                 primitives.Slot(e.Slot),
+		Timestamp:            e.Timestamp,
+		Reason:               e.Reason,
+		OldHeadRoot:          bytesutil.ToBytes32(e.OldHeadRoot),
+		NewHeadRoot:          bytesutil.ToBytes32(e.NewHeadRoot),
+		CommonAncestorRoot:   bytesutil.ToBytes32(e.CommonAncestorRoot),
+		CommonAncestorSlot:   primitives.Slot(e.CommonAncestorSlot),
+		Depth:                e.Depth,
+		OldBranch:            branch(e.OldBranch),
+		NewBranch:            branch(e.NewBranch),
+		OrphanedAttestations: atts,
+		Truncated:            e.Truncated,
+	}
+}
@@ -0,0 +1,50 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_ReorgEvents(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	events := []*forkchoicetypes.ReorgEvent{
+		{Kind: forkchoicetypes.ChainReorg, Slot: 10, NewHeadRoot: [32]byte{'a'}},
+		{Kind: forkchoicetypes.LateBlockReorgAttempt, Slot: 12, OldHeadRoot: [32]byte{'b'}},
+		{Kind: forkchoicetypes.LateBlockReorgRefused, Slot: 12, OldHeadRoot: [32]byte{'c'}},
+		{
+			Kind:      forkchoicetypes.ChainReorg,
+			Slot:      300,
+			Depth:     2,
+			OldBranch: []*forkchoicetypes.ReorgBlock{{Root: [32]byte{'d'}, Slot: 299, ProposerIndex: 7, Weight: 32, ArrivalMillis: 5250}},
+			NewBranch: []*forkchoicetypes.ReorgBlock{{Root: [32]byte{'e'}, Slot: 300, Timely: true, ProposerBoost: true}},
+			OrphanedAttestations: []*forkchoicetypes.OrphanedAttestation{
+				{BlockRoot: [32]byte{'d'}, Slot: 298, CommitteeIndex: 1, Attesters: 3, AttestingIndices: []uint64{4, 8, 15}},
+			},
+			Truncated: true,
+		},
+	}
+	for _, e := range events {
+		require.NoError(t, db.SaveReorgEvent(ctx, e))
+	}
+
+	got, err := db.ReorgEvents(ctx, 0, 1000)
+	require.NoError(t, err)
+	require.DeepEqual(t, events, got)
+
+	got, err = db.ReorgEvents(ctx, 11, 299)
+	require.NoError(t, err)
+	require.DeepEqual(t, events[1:3], got)
+
+	_, err = db.ReorgEvents(ctx, 5, 4)
+	require.ErrorContains(t, "invalid slot range", err)
+
+	require.NoError(t, db.DeleteReorgEventsBefore(ctx, primitives.Slot(12)))
+	got, err = db.ReorgEvents(ctx, 0, 1000)
+	require.NoError(t, err)
+	require.DeepEqual(t, events[1:], got)
+}
@@ -7,15 +7,17 @@ package kv
 // it easy to scan for keys that have a certain shard number as a prefix and return those
 // corresponding attestations.
 var (
-	blocksBucket          = []byte("blocks")
-	stateBucket           = []byte("state")
-	stateSummaryBucket    = []byte("state-summary")
-	chainMetadataBucket   = []byte("chain-metadata")
-	checkpointBucket      = []byte("check-point")
-	powchainBucket        = []byte("powchain")
-	stateValidatorsBucket = []byte("state-validators")
-	feeRecipientBucket    = []byte("fee-recipient")
-	registrationBucket    = []byte("registration")
+	blocksBucket           = []byte("blocks")
+	stateBucket            = []byte("state")
+	stateSummaryBucket     = []byte("state-summary")
+	chainMetadataBucket    = []byte("chain-metadata")
+	checkpointBucket       = []byte("check-point")
+	powchainBucket         = []byte("powchain")
+	stateValidatorsBucket  = []byte("state-validators")
+	feeRecipientBucket     = []byte("fee-recipient")
+	registrationBucket     = []byte("registration")
+	reorgEventsBucket      = []byte("reorg-events")
+	slashingEvidenceBucket = []byte("slashing-evidence")
 
 	// Light Client Updates Bucket
 	lightClientUpdatesBucket   = []byte("light-client-updates")
@@ -59,6 +61,8 @@ var (
 	originCheckpointBlockRootKey = []byte("origin-checkpoint-block-root")
 	// tracking data about an ongoing backfill
 	backfillStatusKey = []byte("backfill-status")
+	// serialized fork choice store used to restart without rebuilding fork choice
+	forkchoiceSnapshotKey = []byte("forkchoice-snapshot")
 
 	// Deprecated: This index key was migrated in PR 6461. Do not use, except for migrations.
 	lastArchivedIndexKey = []byte("last-archived")
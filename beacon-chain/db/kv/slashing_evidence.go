@@ -0,0 +1,155 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/slashing"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SaveSlashingEvidence saves the evidence of a slashing, replacing any evidence saved for the same
+// slashing. Evidence is keyed by offense epoch followed by slashing root.
+func (s *Store) SaveSlashingEvidence(ctx context.Context, e *slashing.Evidence) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveSlashingEvidence")
+	defer span.End()
+	if e == nil {
+		return errors.New("nil slashing evidence")
+	}
+	indices := make([]uint64, len(e.ValidatorIndices))
+	for i, idx := range e.ValidatorIndices {
+		indices[i] = uint64(idx)
+	}
+	c := &dbval.SlashingEvidence{
+		ValidatorIndices: indices,
+		Source:           uint32(e.Source),
+		Timestamp:        e.Timestamp,
+		Included:         e.Included,
+		InclusionSlot:    uint64(e.InclusionSlot),
+	}
+	var err error
+	switch {
+	case e.AttesterSlashing != nil:
+		c.Version = uint32(e.AttesterSlashing.Version())
+		c.AttesterSlashing, err = e.AttesterSlashing.MarshalSSZ()
+	case e.ProposerSlashing != nil:
+		c.ProposerSlashing, err = e.ProposerSlashing.MarshalSSZ()
+	default:
+		return errors.New("slashing evidence without slashing")
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not marshal slashing")
+	}
+	enc, err := encode(ctx, c)
+	if err != nil {
+		return errors.Wrap(err, "could not encode slashing evidence")
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(slashingEvidenceBucket).Put(slashingEvidenceKey(e.Epoch, e.Root), enc)
+	})
+}
+
+// SlashingEvidence returns the saved evidence of the slashing with the given offense epoch and root,
+// or nil if there is none.
+func (s *Store) SlashingEvidence(ctx context.Context, epoch primitives.Epoch, root [32]byte) (*slashing.Evidence, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SlashingEvidence")
+	defer span.End()
+	var e *slashing.Evidence
+	err := s.db.View(func(tx *bolt.Tx) error {
+		key := slashingEvidenceKey(epoch, root)
+		v := tx.Bucket(slashingEvidenceBucket).Get(key)
+		if v == nil {
+			return nil
+		}
+		var err error
+		e, err = decodeSlashingEvidence(ctx, key, v)
+		return err
+	})
+	return e, err
+}
+
+// SlashingEvidenceSince returns the saved slashing evidence whose offense epoch is greater than or
+// equal to the given epoch, ordered by epoch.
+func (s *Store) SlashingEvidenceSince(ctx context.Context, epoch primitives.Epoch) ([]*slashing.Evidence, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SlashingEvidenceSince")
+	defer span.End()
+	evidence := make([]*slashing.Evidence, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(slashingEvidenceBucket).Cursor()
+		for k, v := c.Seek(bytesutil.EpochToBytesBigEndian(epoch)); k != nil; k, v = c.Next() {
+			e, err := decodeSlashingEvidence(ctx, k, v)
+			if err != nil {
+				return err
+			}
+			evidence = append(evidence, e)
+		}
+		return nil
+	})
+	return evidence, err
+}
+
+// DeleteSlashingEvidenceBefore deletes the saved slashing evidence whose offense epoch is lower than the given epoch.
+func (s *Store) DeleteSlashingEvidenceBefore(ctx context.Context, epoch primitives.Epoch) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DeleteSlashingEvidenceBefore")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		c := tx.Bucket(slashingEvidenceBucket).Cursor()
+		min := bytesutil.EpochToBytesBigEndian(epoch)
+		for k, _ := c.First(); k != nil && bytes.Compare(k[:8], min) < 0; k, _ = c.First() {
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func slashingEvidenceKey(epoch primitives.Epoch, root [32]byte) []byte {
+	return append(bytesutil.EpochToBytesBigEndian(epoch), root[:]...)
+}
+
+func decodeSlashingEvidence(ctx context.Context, key, value []byte) (*slashing.Evidence, error) {
+	if len(key) != 40 {
+		return nil, errors.Errorf("invalid slashing evidence key length %d", len(key))
+	}
+	c := &dbval.SlashingEvidence{}
+	if err := decode(ctx, value, c); err != nil {
+		return nil, errors.Wrap(err, "could not decode slashing evidence")
+	}
+	e := &slashing.Evidence{
+		Root:             bytesutil.ToBytes32(key[8:]),
+		Epoch:            bytesutil.BytesToEpochBigEndian(key[:8]),
+		ValidatorIndices: make([]primitives.ValidatorIndex, len(c.ValidatorIndices)),
+		Source:           slashing.Source(c.Source),
+		Timestamp:        c.Timestamp,
+		Included:         c.Included,
+		InclusionSlot:    primitives.Slot(c.InclusionSlot),
+	}
+	for i, idx := range c.ValidatorIndices {
+		e.ValidatorIndices[i] = primitives.ValidatorIndex(idx)
+	}
+	switch {
+	case len(c.AttesterSlashing) > 0:
+		var as ethpb.AttSlashing = &ethpb.AttesterSlashing{}
+		if int(c.Version) >= version.Electra {
+			as = &ethpb.AttesterSlashingElectra{}
+		}
+		if err := as.UnmarshalSSZ(c.AttesterSlashing); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal attester slashing")
+		}
+		e.AttesterSlashing = as
+	case len(c.ProposerSlashing) > 0:
+		e.ProposerSlashing = &ethpb.ProposerSlashing{}
+		if err := e.ProposerSlashing.UnmarshalSSZ(c.ProposerSlashing); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal proposer slashing")
+		}
+	}
+	return e, nil
+}
@@ -0,0 +1,65 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/slashing"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestStore_SlashingEvidence(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	att := &ethpb.IndexedAttestationElectra{
+		AttestingIndices: []uint64{1, 2},
+		Data:             util.HydrateAttestationData(&ethpb.AttestationData{Target: &ethpb.Checkpoint{Epoch: 5}}),
+		Signature:        make([]byte, 96),
+	}
+	attesterSlashing, err := slashing.NewAttesterSlashingEvidence(
+		&ethpb.AttesterSlashingElectra{Attestation_1: att, Attestation_2: att}, slashing.SourceGossip, 10,
+	)
+	require.NoError(t, err)
+	header := util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{Slot: 64, ProposerIndex: 3},
+	})
+	proposerSlashing, err := slashing.NewProposerSlashingEvidence(
+		&ethpb.ProposerSlashing{Header_1: header, Header_2: header}, slashing.SourceSlasher, 20,
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, db.SaveSlashingEvidence(ctx, attesterSlashing))
+	require.NoError(t, db.SaveSlashingEvidence(ctx, proposerSlashing))
+	require.ErrorContains(t, "without slashing", db.SaveSlashingEvidence(ctx, &slashing.Evidence{}))
+
+	got, err := db.SlashingEvidenceSince(ctx, 0)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*slashing.Evidence{proposerSlashing, attesterSlashing}, got)
+	got, err = db.SlashingEvidenceSince(ctx, 3)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*slashing.Evidence{attesterSlashing}, got)
+
+	attesterSlashing.Included = true
+	attesterSlashing.InclusionSlot = 170
+	require.NoError(t, db.SaveSlashingEvidence(ctx, attesterSlashing))
+	e, err := db.SlashingEvidence(ctx, 5, attesterSlashing.Root)
+	require.NoError(t, err)
+	require.DeepEqual(t, attesterSlashing, e)
+
+	e, err = db.SlashingEvidence(ctx, primitives.Epoch(4), attesterSlashing.Root)
+	require.NoError(t, err)
+	require.Equal(t, true, e == nil)
+
+	require.NoError(t, db.DeleteSlashingEvidenceBefore(ctx, 5))
+	got, err = db.SlashingEvidenceSince(ctx, 0)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*slashing.Evidence{attesterSlashing}, got)
+	require.NoError(t, db.DeleteSlashingEvidenceBefore(ctx, 6))
+	got, err = db.SlashingEvidenceSince(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(got))
+}
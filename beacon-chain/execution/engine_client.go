@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	gethRPC "github.com/ethereum/go-ethereum/rpc"
 	"github.com/holiman/uint256"
 	"github.com/pkg/errors"
@@ -75,6 +76,8 @@ const (
 	BlockByHashMethod = "eth_getBlockByHash"
 	// BlockByNumberMethod request string for JSON-RPC.
 	BlockByNumberMethod = "eth_getBlockByNumber"
+	// BlockReceiptsMethod request string for JSON-RPC.
+	BlockReceiptsMethod = "eth_getBlockReceipts"
 	// GetPayloadBodiesByHashV1 is the engine_getPayloadBodiesByHashX JSON-RPC method for pre-Electra payloads.
 	GetPayloadBodiesByHashV1 = "engine_getPayloadBodiesByHashV1"
 	// GetPayloadBodiesByRangeV1 is the engine_getPayloadBodiesByRangeX JSON-RPC method for pre-Electra payloads.
@@ -108,6 +111,11 @@ type Reconstructor interface {
 	ReconstructBlobSidecars(ctx context.Context, block interfaces.ReadOnlySignedBeaconBlock, blockRoot [32]byte, indices []bool) ([]blocks.VerifiedROBlob, error)
 }
 
+// ReceiptsFetcher defines a client that can fetch the receipts of the transactions of execution blocks.
+type ReceiptsFetcher interface {
+	BlockReceipts(ctx context.Context, hash common.Hash) ([]*gethtypes.Receipt, error)
+}
+
 // EngineCaller defines a client that can interact with an Ethereum
 // execution node's engine service via JSON-RPC.
 type EngineCaller interface {
@@ -131,53 +139,70 @@ func (s *Service) NewPayload(ctx context.Context, payload interfaces.ExecutionDa
 		newPayloadLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
 
-	d := time.Now().Add(time.Duration(params.BeaconConfig().ExecutionEngineTimeoutValue) * time.Second)
-	ctx, cancel := context.WithDeadline(ctx, d)
-	defer cancel()
-	result := &pb.PayloadStatus{}
-
-	switch payload.Proto().(type) {
-	case *pb.ExecutionPayload:
-		payloadPb, ok := payload.Proto().(*pb.ExecutionPayload)
-		if !ok {
-			return nil, errors.New("execution data must be a Bellatrix or Capella execution payload")
-		}
-		err := s.rpcClient.CallContext(ctx, result, NewPayloadMethod, payloadPb)
+	s.sendToSecondaries(func(ctx context.Context, client RPCClient) error {
+		_, err := newPayload(ctx, client, payload, versionedHashes, parentBlockRoot, executionRequests)
+		return err
+	})
+	if verifier, ok := s.verifierConn(); ok {
+		method, args, err := newPayloadRequest(payload, versionedHashes, parentBlockRoot, executionRequests)
 		if err != nil {
-			return nil, handleRPCError(err)
+			return nil, err
 		}
+		return s.verifiedNewPayload(ctx, verifier, payload, method, args)
+	}
+	lvh, err := newPayload(ctx, s.conn().client, payload, versionedHashes, parentBlockRoot, executionRequests)
+	s.observePrimary(err)
+	return lvh, err
+}
+
+func newPayload(ctx context.Context, client RPCClient, payload interfaces.ExecutionData, versionedHashes []common.Hash, parentBlockRoot *common.Hash, executionRequests *pb.ExecutionRequests) ([]byte, error) {
+	method, args, err := newPayloadRequest(payload, versionedHashes, parentBlockRoot, executionRequests)
+	if err != nil {
+		return nil, err
+	}
+	result, err := newPayloadStatus(ctx, client, method, args)
+	if err != nil {
+		return nil, err
+	}
+	return payloadStatusResult(result)
+}
+
+// newPayloadRequest returns the engine_newPayloadVX method and its parameters for the execution payload.
+func newPayloadRequest(payload interfaces.ExecutionData, versionedHashes []common.Hash, parentBlockRoot *common.Hash, executionRequests *pb.ExecutionRequests) (string, []interface{}, error) {
+	switch payloadPb := payload.Proto().(type) {
+	case *pb.ExecutionPayload:
+		return NewPayloadMethod, []interface{}{payloadPb}, nil
 	case *pb.ExecutionPayloadCapella:
-		payloadPb, ok := payload.Proto().(*pb.ExecutionPayloadCapella)
-		if !ok {
-			return nil, errors.New("execution data must be a Capella execution payload")
-		}
-		err := s.rpcClient.CallContext(ctx, result, NewPayloadMethodV2, payloadPb)
-		if err != nil {
-			return nil, handleRPCError(err)
-		}
+		return NewPayloadMethodV2, []interface{}{payloadPb}, nil
 	case *pb.ExecutionPayloadDeneb:
-		payloadPb, ok := payload.Proto().(*pb.ExecutionPayloadDeneb)
-		if !ok {
-			return nil, errors.New("execution data must be a Deneb execution payload")
-		}
 		if executionRequests == nil {
-			err := s.rpcClient.CallContext(ctx, result, NewPayloadMethodV3, payloadPb, versionedHashes, parentBlockRoot)
-			if err != nil {
-				return nil, handleRPCError(err)
-			}
-		} else {
-			flattenedRequests, err := pb.EncodeExecutionRequests(executionRequests)
-			if err != nil {
-				return nil, errors.Wrap(err, "failed to encode execution requests")
-			}
-			err = s.rpcClient.CallContext(ctx, result, NewPayloadMethodV4, payloadPb, versionedHashes, parentBlockRoot, flattenedRequests)
-			if err != nil {
-				return nil, handleRPCError(err)
-			}
+			return NewPayloadMethodV3, []interface{}{payloadPb, versionedHashes, parentBlockRoot}, nil
 		}
+		flattenedRequests, err := pb.EncodeExecutionRequests(executionRequests)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "failed to encode execution requests")
+		}
+		return NewPayloadMethodV4, []interface{}{payloadPb, versionedHashes, parentBlockRoot, flattenedRequests}, nil
 	default:
-		return nil, errors.New("unknown execution data type")
+		return "", nil, errors.New("unknown execution data type")
+	}
+}
+
+// newPayloadStatus sends the engine_newPayloadVX request to the execution client and returns the raw payload status.
+func newPayloadStatus(ctx context.Context, client RPCClient, method string, args []interface{}) (*pb.PayloadStatus, error) {
+	d := time.Now().Add(time.Duration(params.BeaconConfig().ExecutionEngineTimeoutValue) * time.Second)
+	ctx, cancel := context.WithDeadline(ctx, d)
+	defer cancel()
+	result := &pb.PayloadStatus{}
+	if err := client.CallContext(ctx, result, method, args...); err != nil {
+		return nil, handleRPCError(err)
 	}
+	return result, nil
+}
+
+// payloadStatusResult maps the payload status returned by the execution client to the latest valid hash and error
+// returned by NewPayload.
+func payloadStatusResult(result *pb.PayloadStatus) ([]byte, error) {
 	if result.ValidationError != "" {
 		log.WithError(errors.New(result.ValidationError)).Error("Got a validation error in newPayload")
 	}
@@ -206,6 +231,18 @@ func (s *Service) ForkchoiceUpdated(
 		forkchoiceUpdatedLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
 
+	s.sendToSecondaries(func(ctx context.Context, client RPCClient) error {
+		_, _, err := forkchoiceUpdated(ctx, client, state, attrs)
+		return err
+	})
+	id, lvh, err := forkchoiceUpdated(ctx, s.conn().client, state, attrs)
+	s.observePrimary(err)
+	return id, lvh, err
+}
+
+func forkchoiceUpdated(
+	ctx context.Context, client RPCClient, state *pb.ForkchoiceState, attrs payloadattribute.Attributer,
+) (*pb.PayloadIDBytes, []byte, error) {
 	d := time.Now().Add(time.Duration(params.BeaconConfig().ExecutionEngineTimeoutValue) * time.Second)
 	ctx, cancel := context.WithDeadline(ctx, d)
 	defer cancel()
@@ -220,7 +257,7 @@ func (s *Service) ForkchoiceUpdated(
 		if err != nil {
 			return nil, nil, err
 		}
-		err = s.rpcClient.CallContext(ctx, result, ForkchoiceUpdatedMethod, state, a)
+		err = client.CallContext(ctx, result, ForkchoiceUpdatedMethod, state, a)
 		if err != nil {
 			return nil, nil, handleRPCError(err)
 		}
@@ -229,7 +266,7 @@ func (s *Service) ForkchoiceUpdated(
 		if err != nil {
 			return nil, nil, err
 		}
-		err = s.rpcClient.CallContext(ctx, result, ForkchoiceUpdatedMethodV2, state, a)
+		err = client.CallContext(ctx, result, ForkchoiceUpdatedMethodV2, state, a)
 		if err != nil {
 			return nil, nil, handleRPCError(err)
 		}
@@ -238,7 +275,7 @@ func (s *Service) ForkchoiceUpdated(
 		if err != nil {
 			return nil, nil, err
 		}
-		err = s.rpcClient.CallContext(ctx, result, ForkchoiceUpdatedMethodV3, state, a)
+		err = client.CallContext(ctx, result, ForkchoiceUpdatedMethodV3, state, a)
 		if err != nil {
 			return nil, nil, handleRPCError(err)
 		}
@@ -293,7 +330,7 @@ func (s *Service) GetPayload(ctx context.Context, payloadId [8]byte, slot primit
 	defer cancel()
 
 	method, result := getPayloadMethodAndMessage(slot)
-	err := s.rpcClient.CallContext(ctx, result, method, pb.PayloadIDBytes(payloadId))
+	err := s.conn().client.CallContext(ctx, result, method, pb.PayloadIDBytes(payloadId))
 	if err != nil {
 		return nil, handleRPCError(err)
 	}
@@ -309,7 +346,7 @@ func (s *Service) ExchangeCapabilities(ctx context.Context) ([]string, error) {
 	defer span.End()
 
 	var result []string
-	err := s.rpcClient.CallContext(ctx, &result, ExchangeCapabilities, supportedEngineEndpoints)
+	err := s.conn().client.CallContext(ctx, &result, ExchangeCapabilities, supportedEngineEndpoints)
 	if err != nil {
 		return nil, handleRPCError(err)
 	}
@@ -423,7 +460,7 @@ func (s *Service) LatestExecutionBlock(ctx context.Context) (*pb.ExecutionBlock,
 	defer span.End()
 
 	result := &pb.ExecutionBlock{}
-	err := s.rpcClient.CallContext(
+	err := s.conn().client.CallContext(
 		ctx,
 		result,
 		BlockByNumberMethod,
@@ -439,7 +476,7 @@ func (s *Service) ExecutionBlockByHash(ctx context.Context, hash common.Hash, wi
 	ctx, span := trace.StartSpan(ctx, "powchain.engine-api-client.ExecutionBlockByHash")
 	defer span.End()
 	result := &pb.ExecutionBlock{}
-	err := s.rpcClient.CallContext(ctx, result, BlockByHashMethod, hash, withTxs)
+	err := s.conn().client.CallContext(ctx, result, BlockByHashMethod, hash, withTxs)
 	return result, handleRPCError(err)
 }
 
@@ -465,7 +502,7 @@ func (s *Service) ExecutionBlocksByHashes(ctx context.Context, hashes []common.H
 		})
 		execBlks = append(execBlks, blk)
 	}
-	ioErr := s.rpcClient.BatchCall(elems)
+	ioErr := s.conn().client.BatchCall(elems)
 	if ioErr != nil {
 		return nil, ioErr
 	}
@@ -477,10 +514,23 @@ func (s *Service) ExecutionBlocksByHashes(ctx context.Context, hashes []common.H
 	return execBlks, nil
 }
 
+// BlockReceipts fetches the receipts of the transactions of an execution block by hash by calling
+// eth_getBlockReceipts via JSON-RPC.
+func (s *Service) BlockReceipts(ctx context.Context, hash common.Hash) ([]*gethtypes.Receipt, error) {
+	ctx, span := trace.StartSpan(ctx, "powchain.engine-api-client.BlockReceipts")
+	defer span.End()
+	var receipts []*gethtypes.Receipt
+	err := s.conn().client.CallContext(ctx, &receipts, BlockReceiptsMethod, hash)
+	if err == nil && receipts == nil {
+		err = ethereum.NotFound
+	}
+	return receipts, handleRPCError(err)
+}
+
 // HeaderByHash returns the relevant header details for the provided block hash.
 func (s *Service) HeaderByHash(ctx context.Context, hash common.Hash) (*types.HeaderInfo, error) {
 	var hdr *types.HeaderInfo
-	err := s.rpcClient.CallContext(ctx, &hdr, BlockByHashMethod, hash, false /* no transactions */)
+	err := s.conn().client.CallContext(ctx, &hdr, BlockByHashMethod, hash, false /* no transactions */)
 	if err == nil && hdr == nil {
 		err = ethereum.NotFound
 	}
@@ -490,7 +540,7 @@ func (s *Service) HeaderByHash(ctx context.Context, hash common.Hash) (*types.He
 // HeaderByNumber returns the relevant header details for the provided block number.
 func (s *Service) HeaderByNumber(ctx context.Context, number *big.Int) (*types.HeaderInfo, error) {
 	var hdr *types.HeaderInfo
-	err := s.rpcClient.CallContext(ctx, &hdr, BlockByNumberMethod, toBlockNumArg(number), false /* no transactions */)
+	err := s.conn().client.CallContext(ctx, &hdr, BlockByNumberMethod, toBlockNumArg(number), false /* no transactions */)
 	if err == nil && hdr == nil {
 		err = ethereum.NotFound
 	}
@@ -507,7 +557,7 @@ func (s *Service) GetBlobs(ctx context.Context, versionedHashes []common.Hash) (
 	}
 
 	result := make([]*pb.BlobAndProof, len(versionedHashes))
-	err := s.rpcClient.CallContext(ctx, &result, GetBlobsV1, versionedHashes)
+	err := s.conn().client.CallContext(ctx, &result, GetBlobsV1, versionedHashes)
 	return result, handleRPCError(err)
 }
 
@@ -531,7 +581,7 @@ func (s *Service) ReconstructFullBlock(
 func (s *Service) ReconstructFullBellatrixBlockBatch(
 	ctx context.Context, blindedBlocks []interfaces.ReadOnlySignedBeaconBlock,
 ) ([]interfaces.SignedBeaconBlock, error) {
-	unb, err := reconstructBlindedBlockBatch(ctx, s.rpcClient, blindedBlocks)
+	unb, err := reconstructBlindedBlockBatch(ctx, s.conn().client, blindedBlocks)
 	if err != nil {
 		return nil, err
 	}
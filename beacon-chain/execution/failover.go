@@ -0,0 +1,270 @@
+package execution
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	contracts "github.com/prysmaticlabs/prysm/v5/contracts/deposit"
+	"github.com/prysmaticlabs/prysm/v5/io/logs"
+	"github.com/prysmaticlabs/prysm/v5/network"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultFailoverTimeout is the time the primary execution client may be unresponsive or syncing
+// before a healthy secondary execution client is promoted.
+const defaultFailoverTimeout = time.Minute
+
+// ExecutionClientStatus describes one of the execution clients the node sends engine API calls to.
+type ExecutionClientStatus struct {
+	Endpoint string
+	Primary  bool
+	// Healthy is true when the last engine API call was answered with a status other than SYNCING.
+	Healthy bool
+	Syncing bool
+	// LastResponse is the time of the last engine API call answered by the execution client.
+	LastResponse time.Time
+	// UnhealthySince is the time since which the execution client is failing or syncing.
+	UnhealthySince time.Time
+	LastError      error
+}
+
+// ExecutionClientsFetcher retrieves the status of the execution clients of the node.
+type ExecutionClientsFetcher interface {
+	ExecutionClientStatuses() []*ExecutionClientStatus
+}
+
+// engineEndpoint tracks the health of an execution client from the outcome of the engine API calls sent to it.
+type engineEndpoint struct {
+	sync.Mutex
+	endpoint network.Endpoint
+	// client is the connection to a secondary execution client. The primary uses the rpcClient of the Service.
+	client       RPCClient
+	lastResponse time.Time
+	failingSince time.Time
+	syncingSince time.Time
+	lastErr      error
+}
+
+// observe records the outcome of an engine API call. Payload statuses returned as errors, like INVALID,
+// are responses of a healthy execution client.
+func (e *engineEndpoint) observe(err error, now time.Time) {
+	e.Lock()
+	defer e.Unlock()
+	e.lastErr = err
+	switch {
+	case err == nil, errors.Is(err, ErrInvalidPayloadStatus), errors.Is(err, ErrInvalidBlockHashPayloadStatus):
+		e.lastResponse = now
+		e.failingSince = time.Time{}
+		e.syncingSince = time.Time{}
+		e.lastErr = nil
+	case errors.Is(err, ErrAcceptedSyncingPayloadStatus):
+		e.lastResponse = now
+		e.failingSince = time.Time{}
+		if e.syncingSince.IsZero() {
+			e.syncingSince = now
+		}
+	default:
+		if e.failingSince.IsZero() {
+			e.failingSince = now
+		}
+	}
+}
+
+func (e *engineEndpoint) healthy() bool {
+	e.Lock()
+	defer e.Unlock()
+	return !e.lastResponse.IsZero() && e.failingSince.IsZero() && e.syncingSince.IsZero()
+}
+
+// unhealthySince returns the time since which the execution client is failing or syncing, or the zero time.
+func (e *engineEndpoint) unhealthySince() time.Time {
+	e.Lock()
+	defer e.Unlock()
+	if !e.failingSince.IsZero() {
+		return e.failingSince
+	}
+	return e.syncingSince
+}
+
+func (e *engineEndpoint) status(url string, primary bool) *ExecutionClientStatus {
+	e.Lock()
+	defer e.Unlock()
+	since := e.failingSince
+	if since.IsZero() {
+		since = e.syncingSince
+	}
+	return &ExecutionClientStatus{
+		Endpoint:       logs.MaskCredentialsLogging(url),
+		Primary:        primary,
+		Healthy:        !e.lastResponse.IsZero() && since.IsZero(),
+		Syncing:        !e.syncingSince.IsZero(),
+		LastResponse:   e.lastResponse,
+		UnhealthySince: since,
+		LastError:      e.lastErr,
+	}
+}
+
+// setupSecondaryConnections dials the secondary execution clients. A secondary that can't be reached or is on
+// the wrong chain is never promoted.
+func (s *Service) setupSecondaryConnections(ctx context.Context) {
+	defer s.updateExecutionClientMetrics()
+	s.engineLock.Lock()
+	defer s.engineLock.Unlock()
+	for _, e := range s.secondaries {
+		fields := logrus.Fields{"endpoint": logs.MaskCredentialsLogging(e.endpoint.Url)}
+		if err := s.dialEngineEndpoint(ctx, e); err != nil {
+			e.observe(err, time.Now())
+			log.WithError(err).WithFields(fields).Error("Could not connect to secondary execution client")
+			continue
+		}
+		log.WithFields(fields).Info("Connected to secondary execution client")
+	}
+}
+
+// dialEngineEndpoint connects to the execution client of the endpoint and checks it follows the expected chain.
+func (s *Service) dialEngineEndpoint(ctx context.Context, e *engineEndpoint) error {
+	client, err := s.newRPCClientWithAuth(ctx, e.endpoint)
+	if err != nil {
+		return errors.Wrap(err, "could not dial execution client")
+	}
+	if err := ensureCorrectExecutionChain(ctx, ethclient.NewClient(client)); err != nil {
+		client.Close()
+		return errors.Wrap(err, "could not verify execution chain")
+	}
+	e.client = client
+	return nil
+}
+
+// sendToSecondaries sends an engine API call to the secondary execution clients in the background.
+// Their responses are only used to track their health.
+func (s *Service) sendToSecondaries(call func(ctx context.Context, client RPCClient) error) {
+	s.engineLock.RLock()
+	defer s.engineLock.RUnlock()
+	for _, e := range s.secondaries {
+		if e.client == nil {
+			continue
+		}
+		s.secondaryCalls.Add(1)
+		go func(e *engineEndpoint, client RPCClient) {
+			defer s.secondaryCalls.Done()
+			e.observe(call(s.ctx, client), time.Now())
+		}(e, e.client)
+	}
+}
+
+// observePrimary records the outcome of an engine API call sent to the primary execution client, and promotes
+// a healthy secondary if the primary has been failing or syncing for longer than the failover timeout.
+func (s *Service) observePrimary(err error) {
+	s.engineLock.RLock()
+	primary := s.primary
+	s.engineLock.RUnlock()
+	if primary == nil {
+		return
+	}
+	now := time.Now()
+	primary.observe(err, now)
+	defer s.updateExecutionClientMetrics()
+	since := primary.unhealthySince()
+	if since.IsZero() || now.Sub(since) < s.cfg.failoverTimeout {
+		return
+	}
+	s.failover(primary)
+}
+
+// failover promotes the first healthy secondary execution client. The previous primary becomes a secondary.
+func (s *Service) failover(primary *engineEndpoint) {
+	s.engineLock.Lock()
+	defer s.engineLock.Unlock()
+	if s.primary != primary {
+		// Another call already promoted a secondary.
+		return
+	}
+	for i, e := range s.secondaries {
+		if e.client == nil || !e.healthy() {
+			continue
+		}
+		prev := s.cfg.currHttpEndpoint
+		conn := engineConn{
+			endpoint:      e.endpoint,
+			client:        e.client,
+			logFilterer:   s.httpLogger,
+			depositCaller: s.depositContractCaller,
+		}
+		if client, ok := e.client.(*gethRPC.Client); ok {
+			fetcher := ethclient.NewClient(client)
+			depositContractCaller, err := contracts.NewDepositContractCaller(s.cfg.depositContractAddr, fetcher)
+			if err != nil {
+				log.WithError(err).Error("Could not initialize deposit contract caller for secondary execution client")
+				continue
+			}
+			conn.logFilterer = fetcher
+			conn.depositCaller = depositContractCaller
+		}
+		primary.client = s.setConnLocked(conn)
+		primary.endpoint = prev
+		s.secondaries[i] = primary
+		s.primary = e
+		e.client = nil
+		failoverCount.Inc()
+		log.WithFields(logrus.Fields{
+			"previous": logs.MaskCredentialsLogging(prev.Url),
+			"primary":  logs.MaskCredentialsLogging(e.endpoint.Url),
+			"since":    primary.unhealthySince(),
+		}).Warn("Primary execution client is unhealthy, promoted a secondary execution client")
+		go func() {
+			c, err := s.ExchangeCapabilities(s.ctx)
+			if err != nil {
+				log.WithError(err).Error("Could not exchange capabilities with execution client")
+			}
+			s.capabilityCache.save(c)
+		}()
+		return
+	}
+}
+
+// ExecutionClientStatuses returns the status of the primary execution client, followed by the secondaries.
+func (s *Service) ExecutionClientStatuses() []*ExecutionClientStatus {
+	s.engineLock.RLock()
+	defer s.engineLock.RUnlock()
+	statuses := make([]*ExecutionClientStatus, 0, len(s.secondaries)+1)
+	if s.primary != nil {
+		statuses = append(statuses, s.primary.status(s.cfg.currHttpEndpoint.Url, true))
+	}
+	for _, e := range s.secondaries {
+		statuses = append(statuses, e.status(e.endpoint.Url, false))
+	}
+	return statuses
+}
+
+func (s *Service) updateExecutionClientMetrics() {
+	for _, st := range s.ExecutionClientStatuses() {
+		executionClientPrimary.WithLabelValues(st.Endpoint).Set(boolToFloat(st.Primary))
+		executionClientHealthy.WithLabelValues(st.Endpoint).Set(boolToFloat(st.Healthy))
+		executionClientSyncing.WithLabelValues(st.Endpoint).Set(boolToFloat(st.Syncing))
+	}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// closeSecondaryConnections waits for the calls in flight to the secondary execution clients, and closes their
+// connections.
+func (s *Service) closeSecondaryConnections() {
+	s.secondaryCalls.Wait()
+	s.engineLock.Lock()
+	defer s.engineLock.Unlock()
+	for _, e := range s.secondaries {
+		if e.client != nil {
+			e.client.Close()
+			e.client = nil
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	payloadattribute "github.com/prysmaticlabs/prysm/v5/consensus-types/payload-attribute"
+	"github.com/prysmaticlabs/prysm/v5/network"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+// statusClient answers engine API calls with a fixed payload status.
+type statusClient struct {
+	status pb.PayloadStatus_Status
+}
+
+func (*statusClient) Close() {}
+
+func (*statusClient) BatchCall([]gethRPC.BatchElem) error {
+	return nil
+}
+
+func (c *statusClient) CallContext(_ context.Context, result interface{}, _ string, _ ...interface{}) error {
+	switch r := result.(type) {
+	case *ForkchoiceUpdatedResponse:
+		r.Status = &pb.PayloadStatus{Status: c.status}
+	case *pb.PayloadStatus:
+		r.Status = c.status
+	}
+	return nil
+}
+
+func TestEngineEndpoint_Observe(t *testing.T) {
+	e := &engineEndpoint{}
+	require.Equal(t, false, e.healthy())
+	now := time.Now()
+	e.observe(ErrInvalidPayloadStatus, now)
+	require.Equal(t, true, e.healthy())
+	require.Equal(t, true, e.unhealthySince().IsZero())
+
+	e.observe(ErrAcceptedSyncingPayloadStatus, now.Add(time.Second))
+	e.observe(ErrAcceptedSyncingPayloadStatus, now.Add(2*time.Second))
+	require.Equal(t, false, e.healthy())
+	require.Equal(t, now.Add(time.Second), e.unhealthySince())
+
+	err := errors.New("connection refused")
+	e.observe(err, now.Add(3*time.Second))
+	require.Equal(t, now.Add(3*time.Second), e.unhealthySince())
+	st := e.status("http://localhost:8551", true)
+	require.Equal(t, false, st.Healthy)
+	require.Equal(t, true, st.Syncing)
+	require.Equal(t, now.Add(2*time.Second), st.LastResponse)
+	require.ErrorIs(t, st.LastError, err)
+
+	e.observe(nil, now.Add(4*time.Second))
+	require.Equal(t, true, e.healthy())
+	require.Equal(t, true, e.unhealthySince().IsZero())
+}
+
+func TestService_Failover(t *testing.T) {
+	primary := &statusClient{status: pb.PayloadStatus_SYNCING}
+	secondary := &statusClient{status: pb.PayloadStatus_VALID}
+	s := &Service{
+		ctx: context.Background(),
+		cfg: &config{
+			currHttpEndpoint: network.HttpEndpoint("http://primary:8551"),
+			failoverTimeout:  time.Minute,
+		},
+		rpcClient:       primary,
+		capabilityCache: &capabilityCache{},
+		primary:         &engineEndpoint{},
+		secondaries: []*engineEndpoint{
+			{endpoint: network.HttpEndpoint("http://secondary:8551"), client: secondary},
+		},
+	}
+	s.secondaries[0].observe(nil, time.Now())
+	attr, err := payloadattribute.New(&pb.PayloadAttributes{})
+	require.NoError(t, err)
+
+	// The primary is syncing, but not for long enough to be replaced.
+	_, _, err = s.ForkchoiceUpdated(context.Background(), &pb.ForkchoiceState{}, attr)
+	require.ErrorIs(t, err, ErrAcceptedSyncingPayloadStatus)
+	statuses := s.ExecutionClientStatuses()
+	require.Equal(t, 2, len(statuses))
+	require.Equal(t, "http://primary:8551", statuses[0].Endpoint)
+	require.Equal(t, true, statuses[0].Primary)
+	require.Equal(t, true, statuses[0].Syncing)
+	require.Equal(t, "http://secondary:8551", statuses[1].Endpoint)
+	require.Equal(t, true, statuses[1].Healthy)
+
+	// The response of the primary is authoritative, the secondary is promoted for the next calls.
+	s.primary.syncingSince = time.Now().Add(-2 * time.Minute)
+	_, _, err = s.ForkchoiceUpdated(context.Background(), &pb.ForkchoiceState{}, attr)
+	require.ErrorIs(t, err, ErrAcceptedSyncingPayloadStatus)
+	require.Equal(t, "http://secondary:8551", s.ExecutionClientEndpoint())
+	statuses = s.ExecutionClientStatuses()
+	require.Equal(t, "http://secondary:8551", statuses[0].Endpoint)
+	require.Equal(t, true, statuses[0].Primary)
+	require.Equal(t, "http://primary:8551", statuses[1].Endpoint)
+	require.Equal(t, false, statuses[1].Primary)
+
+	_, _, err = s.ForkchoiceUpdated(context.Background(), &pb.ForkchoiceState{}, attr)
+	require.NoError(t, err)
+	s.closeSecondaryConnections()
+}
+
+func TestService_NoFailoverWithoutHealthySecondary(t *testing.T) {
+	s := &Service{
+		ctx: context.Background(),
+		cfg: &config{
+			currHttpEndpoint: network.HttpEndpoint("http://primary:8551"),
+			failoverTimeout:  0,
+		},
+		rpcClient:       &statusClient{status: pb.PayloadStatus_SYNCING},
+		capabilityCache: &capabilityCache{},
+		primary:         &engineEndpoint{},
+		secondaries:     []*engineEndpoint{{endpoint: network.HttpEndpoint("http://secondary:8551")}},
+	}
+	attr, err := payloadattribute.New(&pb.PayloadAttributes{})
+	require.NoError(t, err)
+	_, _, err = s.ForkchoiceUpdated(context.Background(), &pb.ForkchoiceState{}, attr)
+	require.ErrorIs(t, err, ErrAcceptedSyncingPayloadStatus)
+	require.Equal(t, "http://primary:8551", s.ExecutionClientEndpoint())
+}
+
+func TestService_FailoverConcurrentCalls(t *testing.T) {
+	s := &Service{
+		ctx: context.Background(),
+		cfg: &config{
+			currHttpEndpoint: network.HttpEndpoint("http://primary:8551"),
+			failoverTimeout:  0,
+		},
+		rpcClient:       &statusClient{status: pb.PayloadStatus_SYNCING},
+		capabilityCache: &capabilityCache{},
+		primary:         &engineEndpoint{},
+		secondaries: []*engineEndpoint{
+			{endpoint: network.HttpEndpoint("http://secondary:8551"), client: &statusClient{status: pb.PayloadStatus_VALID}},
+		},
+	}
+	s.secondaries[0].observe(nil, time.Now())
+
+	// Run with -race: reads of the primary execution client connection must not race with its replacement.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				s.observePrimary(ErrAcceptedSyncingPayloadStatus)
+				_ = s.conn().client
+				_ = s.ExecutionClientEndpoint()
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, "http://secondary:8551", s.ExecutionClientEndpoint())
+}
@@ -67,7 +67,7 @@ func (s *Service) ProcessETH1Block(ctx context.Context, blkNum *big.Int) error {
 		FromBlock: blkNum,
 		ToBlock:   blkNum,
 	}
-	logs, err := s.httpLogger.FilterLogs(ctx, query)
+	logs, err := s.conn().logFilterer.FilterLogs(ctx, query)
 	if err != nil {
 		return err
 	}
@@ -297,7 +297,7 @@ func (s *Service) processPastLogs(ctx context.Context) error {
 	currentBlockNum = max(currentBlockNum, deploymentBlock)
 	// To store all blocks.
 	headersMap := make(map[uint64]*types.HeaderInfo)
-	rawLogCount, err := s.depositContractCaller.GetDepositCount(&bind.CallOpts{})
+	rawLogCount, err := s.conn().depositCaller.GetDepositCount(&bind.CallOpts{})
 	if err != nil {
 		return err
 	}
@@ -395,7 +395,7 @@ func (s *Service) processBlockInBatch(ctx context.Context, currentBlockNum uint6
 		query.ToBlock = new(big.Int).SetUint64(latestFollowHeight)
 		end = latestFollowHeight
 	}
-	logs, err := s.httpLogger.FilterLogs(ctx, query)
+	logs, err := s.conn().logFilterer.FilterLogs(ctx, query)
 	if err != nil {
 		if tooMuchDataRequestedError(err) {
 			if batchSize == 0 {
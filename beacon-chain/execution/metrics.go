@@ -71,4 +71,24 @@ var (
 		Name: "execution_payload_bodies_count",
 		Help: "The number of requested payload bodies is too large",
 	})
+	failoverCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "execution_client_failover_total",
+		Help: "The number of times a secondary execution client was promoted to primary",
+	})
+	payloadDivergenceCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "execution_payload_verification_divergence_total",
+		Help: "The number of payloads the primary and the verification execution clients disagree on",
+	})
+	executionClientPrimary = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "execution_client_primary",
+		Help: "1 if the execution client is the primary one, whose engine API responses are authoritative",
+	}, []string{"endpoint"})
+	executionClientHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "execution_client_healthy",
+		Help: "1 if the execution client answered the last engine API call with a status other than SYNCING",
+	}, []string{"endpoint"})
+	executionClientSyncing = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "execution_client_syncing",
+		Help: "1 if the execution client answered the last engine API call with a SYNCING status",
+	}, []string{"endpoint"})
 )
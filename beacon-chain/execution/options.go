@@ -1,6 +1,8 @@
 package execution
 
 import (
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
@@ -38,6 +40,52 @@ func WithHttpEndpointAndJWTSecret(endpointString string, secret []byte) Option {
 	}
 }
 
+// WithSecondaryHttpEndpoint adds a secondary execution client, authenticated with its own JWT secret.
+// Engine API calls are also sent to secondaries, which can be promoted if the primary becomes unhealthy.
+func WithSecondaryHttpEndpoint(endpointString string, secret []byte) Option {
+	return func(s *Service) error {
+		hEndpoint := network.HttpEndpoint(endpointString)
+		if len(secret) > 0 {
+			hEndpoint.Auth.Method = authorization.Bearer
+			hEndpoint.Auth.Value = string(secret)
+		}
+		s.secondaries = append(s.secondaries, &engineEndpoint{endpoint: hEndpoint})
+		return nil
+	}
+}
+
+// WithFailoverTimeout sets the time the primary execution client may be unresponsive or syncing
+// before a healthy secondary execution client is promoted.
+func WithFailoverTimeout(timeout time.Duration) Option {
+	return func(s *Service) error {
+		s.cfg.failoverTimeout = timeout
+		return nil
+	}
+}
+
+// WithVerificationHttpEndpoint adds an execution client that verifies the execution payloads validated by the
+// primary. Unlike secondaries, the verification execution client is never promoted.
+func WithVerificationHttpEndpoint(endpointString string, secret []byte) Option {
+	return func(s *Service) error {
+		hEndpoint := network.HttpEndpoint(endpointString)
+		if len(secret) > 0 {
+			hEndpoint.Auth.Method = authorization.Bearer
+			hEndpoint.Auth.Value = string(secret)
+		}
+		s.verifier = &engineEndpoint{endpoint: hEndpoint}
+		return nil
+	}
+}
+
+// WithPayloadDivergenceDir sets the directory where execution payloads disputed by the
+// verification execution client are persisted.
+func WithPayloadDivergenceDir(dir string) Option {
+	return func(s *Service) error {
+		s.cfg.divergenceDir = dir
+		return nil
+	}
+}
+
 // WithHeaders adds headers to the execution node JSON-RPC requests.
 func WithHeaders(headers []string) Option {
 	return func(s *Service) error {
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/ethclient"
 	gethRPC "github.com/ethereum/go-ethereum/rpc"
 	"github.com/pkg/errors"
@@ -17,22 +18,56 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/network/authorization"
 )
 
+// engineConn is the connection to an execution client. The connection to the primary execution client is replaced
+// when reconnecting and on failover to a secondary execution client.
+type engineConn struct {
+	endpoint      network.Endpoint
+	client        RPCClient
+	logFilterer   bind.ContractFilterer
+	depositCaller *contracts.DepositContractCaller
+}
+
+// conn returns the connection to the primary execution client. The fields of the service making up the connection
+// are only read through conn, and written through setConnLocked.
+func (s *Service) conn() engineConn {
+	s.engineLock.RLock()
+	defer s.engineLock.RUnlock()
+	c := engineConn{
+		client:        s.rpcClient,
+		logFilterer:   s.httpLogger,
+		depositCaller: s.depositContractCaller,
+	}
+	if s.cfg != nil {
+		c.endpoint = s.cfg.currHttpEndpoint
+	}
+	return c
+}
+
+// setConnLocked replaces the connection to the primary execution client and returns the previous client.
+// The caller must hold engineLock.
+func (s *Service) setConnLocked(c engineConn) RPCClient {
+	prev := s.rpcClient
+	s.cfg.currHttpEndpoint = c.endpoint
+	s.rpcClient = c.client
+	s.httpLogger = c.logFilterer
+	s.depositContractCaller = c.depositCaller
+	return prev
+}
+
+// setupExecutionClientConnections connects to the primary execution client at the given endpoint, and replaces the
+// previous connection, which is closed. The connection is dropped if a secondary execution client was promoted
+// in the meantime.
 func (s *Service) setupExecutionClientConnections(ctx context.Context, currEndpoint network.Endpoint) error {
 	client, err := s.newRPCClientWithAuth(ctx, currEndpoint)
 	if err != nil {
 		return errors.Wrap(err, "could not dial execution node")
 	}
-	// Attach the clients to the service struct.
 	fetcher := ethclient.NewClient(client)
-	s.rpcClient = client
-	s.httpLogger = fetcher
-
 	depositContractCaller, err := contracts.NewDepositContractCaller(s.cfg.depositContractAddr, fetcher)
 	if err != nil {
 		client.Close()
 		return errors.Wrap(err, "could not initialize deposit contract caller")
 	}
-	s.depositContractCaller = depositContractCaller
 
 	// Ensure we have the correct chain and deposit IDs.
 	if err := ensureCorrectExecutionChain(ctx, fetcher); err != nil {
@@ -45,6 +80,24 @@ func (s *Service) setupExecutionClientConnections(ctx context.Context, currEndpo
 		}
 		return errors.Wrap(err, errStr)
 	}
+
+	// Attach the clients to the service struct.
+	s.engineLock.Lock()
+	if s.cfg.currHttpEndpoint.Url != currEndpoint.Url {
+		s.engineLock.Unlock()
+		client.Close()
+		return errors.New("primary execution client changed while connecting")
+	}
+	prev := s.setConnLocked(engineConn{
+		endpoint:      currEndpoint,
+		client:        client,
+		logFilterer:   fetcher,
+		depositCaller: depositContractCaller,
+	})
+	s.engineLock.Unlock()
+	if prev != nil {
+		prev.Close()
+	}
 	s.updateConnectedETH1(true)
 	s.runError = nil
 	return nil
@@ -67,17 +120,13 @@ func (s *Service) pollConnectionStatus(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
-			log.Debugf("Trying to dial endpoint: %s", logs.MaskCredentialsLogging(s.cfg.currHttpEndpoint.Url))
-			currClient := s.rpcClient
-			if err := s.setupExecutionClientConnections(ctx, s.cfg.currHttpEndpoint); err != nil {
+			endpoint := s.conn().endpoint
+			log.Debugf("Trying to dial endpoint: %s", logs.MaskCredentialsLogging(endpoint.Url))
+			if err := s.setupExecutionClientConnections(ctx, endpoint); err != nil {
 				errorLogger(err, "Could not connect to execution client endpoint")
 				continue
 			}
-			// Close previous client, if connection was successful.
-			if currClient != nil {
-				currClient.Close()
-			}
-			log.WithField("endpoint", logs.MaskCredentialsLogging(s.cfg.currHttpEndpoint.Url)).Info("Connected to new endpoint")
+			log.WithField("endpoint", logs.MaskCredentialsLogging(endpoint.Url)).Info("Connected to new endpoint")
 
 			c, err := s.ExchangeCapabilities(ctx)
 			if err != nil {
@@ -99,15 +148,10 @@ func (s *Service) retryExecutionClientConnection(ctx context.Context, err error)
 	s.updateConnectedETH1(false)
 	// Back off for a while before redialing.
 	time.Sleep(backOffPeriod)
-	currClient := s.rpcClient
-	if err := s.setupExecutionClientConnections(ctx, s.cfg.currHttpEndpoint); err != nil {
+	if err := s.setupExecutionClientConnections(ctx, s.conn().endpoint); err != nil {
 		s.runError = errors.Wrap(err, "setupExecutionClientConnections")
 		return
 	}
-	// Close previous client, if connection was successful.
-	if currClient != nil {
-		currClient.Close()
-	}
 	// Reset run error in the event of a successful connection.
 	s.runError = nil
 }
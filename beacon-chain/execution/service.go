@@ -130,6 +130,8 @@ type config struct {
 	headers                 []string
 	finalizedStateAtStartup state.BeaconState
 	jwtId                   string
+	failoverTimeout         time.Duration
+	divergenceDir           string
 }
 
 // Service fetches important information about the canonical
@@ -160,6 +162,11 @@ type Service struct {
 	verifierWaiter          *verification.InitializerWaiter
 	blobVerifier            verification.NewBlobVerifier
 	capabilityCache         *capabilityCache
+	engineLock              sync.RWMutex // guards the connections to the execution clients, read through conn.
+	primary                 *engineEndpoint
+	secondaries             []*engineEndpoint
+	secondaryCalls          sync.WaitGroup
+	verifier                *engineEndpoint
 }
 
 // NewService sets up a new instance with an ethclient when given a web3 endpoint as a string in the config.
@@ -181,6 +188,7 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 		cfg: &config{
 			beaconNodeStatsUpdater: &NopBeaconNodeStatsUpdater{},
 			eth1HeaderReqLimit:     defaultEth1HeaderReqLimit,
+			failoverTimeout:        defaultFailoverTimeout,
 		},
 		latestEth1Data: &ethpb.LatestETH1Data{
 			BlockHeight:        0,
@@ -198,6 +206,7 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 		preGenesisState:         genState,
 		eth1HeadTicker:          time.NewTicker(time.Duration(params.BeaconConfig().SecondsPerETH1Block) * time.Second),
 		capabilityCache:         &capabilityCache{},
+		primary:                 &engineEndpoint{},
 	}
 
 	for _, opt := range opts {
@@ -218,12 +227,14 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 
 // Start the powchain service's main event loop.
 func (s *Service) Start() {
-	if err := s.setupExecutionClientConnections(s.ctx, s.cfg.currHttpEndpoint); err != nil {
+	if err := s.setupExecutionClientConnections(s.ctx, s.conn().endpoint); err != nil {
 		log.WithError(err).Error("Could not connect to execution endpoint")
 	}
+	s.setupSecondaryConnections(s.ctx)
+	s.setupVerifierConnection(s.ctx)
 	// If the chain has not started already and we don't have access to eth1 nodes, we will not be
 	// able to generate the genesis state.
-	if !s.chainStartData.Chainstarted && s.cfg.currHttpEndpoint.Url == "" {
+	if !s.chainStartData.Chainstarted && s.conn().endpoint.Url == "" {
 		// check for genesis state before shutting down the node,
 		// if a genesis state exists, we can continue on.
 		genState, err := s.cfg.beaconDB.GenesisState(s.ctx)
@@ -255,9 +266,11 @@ func (s *Service) Stop() error {
 	if s.cancel != nil {
 		defer s.cancel()
 	}
-	if s.rpcClient != nil {
-		s.rpcClient.Close()
+	if client := s.conn().client; client != nil {
+		client.Close()
 	}
+	s.closeSecondaryConnections()
+	s.closeVerifierConnection()
 	return nil
 }
 
@@ -295,7 +308,7 @@ func (s *Service) ExecutionClientConnected() bool {
 
 // ExecutionClientEndpoint returns the URL of the current, connected execution client.
 func (s *Service) ExecutionClientEndpoint() string {
-	return s.cfg.currHttpEndpoint.Url
+	return s.conn().endpoint.Url
 }
 
 // ExecutionClientConnectionErr returns the error (if any) of the current connection.
@@ -430,7 +443,7 @@ func (s *Service) batchRequestHeaders(startBlock, endBlock uint64) ([]*types.Hea
 		})
 		headers = append(headers, header)
 	}
-	ioErr := s.rpcClient.BatchCall(elems)
+	ioErr := s.conn().client.BatchCall(elems)
 	if ioErr != nil {
 		return nil, ioErr
 	}
@@ -597,7 +610,7 @@ func (s *Service) run(done <-chan struct{}) {
 		case <-done:
 			s.isRunning = false
 			s.runError = nil
-			s.rpcClient.Close()
+			s.conn().client.Close()
 			s.updateConnectedETH1(false)
 			log.Debug("Context closed, exiting goroutine")
 			return
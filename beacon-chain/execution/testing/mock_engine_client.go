@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/holiman/uint256"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -38,6 +39,7 @@ type EngineClient struct {
 	ErrGetPayload               error
 	BlobSidecars                []blocks.VerifiedROBlob
 	ErrorBlobSidecars           error
+	ReceiptsByBlockHash         map[[32]byte][]*gethtypes.Receipt
 }
 
 // NewPayload --
@@ -93,6 +95,15 @@ func (e *EngineClient) ReconstructFullBlock(
 	return blocks.BuildSignedBeaconBlockFromExecutionPayload(blindedBlock, payload)
 }
 
+// BlockReceipts --
+func (e *EngineClient) BlockReceipts(_ context.Context, h common.Hash) ([]*gethtypes.Receipt, error) {
+	receipts, ok := e.ReceiptsByBlockHash[h]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+	return receipts, nil
+}
+
 // ReconstructFullBellatrixBlockBatch --
 func (e *EngineClient) ReconstructFullBellatrixBlockBatch(
 	ctx context.Context, blindedBlocks []interfaces.ReadOnlySignedBeaconBlock,
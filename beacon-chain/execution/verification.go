@@ -0,0 +1,201 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/io/logs"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrPayloadVerificationDivergence is returned by NewPayload along with the latest valid hash of the primary
+// execution client, when the primary validated a payload that the verification execution client found invalid.
+var ErrPayloadVerificationDivergence = errors.New("verification execution client disagrees on the validity of the payload")
+
+// payloadVerdict is the response of an execution client to an engine_newPayloadVX request.
+type payloadVerdict struct {
+	Endpoint string            `json:"endpoint"`
+	Status   *pb.PayloadStatus `json:"status"`
+	err      error
+}
+
+// payloadDivergence is the record persisted for a payload on which the execution clients disagree.
+// Params are encoded as in the engine API, so the request can be sent again to an execution client.
+type payloadDivergence struct {
+	Time     time.Time       `json:"time"`
+	Method   string          `json:"method"`
+	Params   []interface{}   `json:"params"`
+	Primary  *payloadVerdict `json:"primary"`
+	Verifier *payloadVerdict `json:"verifier"`
+}
+
+// setupVerifierConnection dials the verification execution client. Payloads are not cross-checked if it can't be
+// reached or is on the wrong chain.
+func (s *Service) setupVerifierConnection(ctx context.Context) {
+	s.engineLock.Lock()
+	defer s.engineLock.Unlock()
+	if s.verifier == nil {
+		return
+	}
+	fields := logrus.Fields{"endpoint": logs.MaskCredentialsLogging(s.verifier.endpoint.Url)}
+	if err := s.dialEngineEndpoint(ctx, s.verifier); err != nil {
+		log.WithError(err).WithFields(fields).Error("Could not connect to verification execution client, payloads will not be cross-checked")
+		return
+	}
+	log.WithFields(fields).Info("Connected to verification execution client")
+}
+
+// verifierConn returns the connection to the verification execution client. It returns false if payloads are not
+// cross-checked.
+func (s *Service) verifierConn() (engineConn, bool) {
+	s.engineLock.RLock()
+	defer s.engineLock.RUnlock()
+	if s.verifier == nil || s.verifier.client == nil {
+		return engineConn{}, false
+	}
+	return engineConn{endpoint: s.verifier.endpoint, client: s.verifier.client}, true
+}
+
+// verifiedNewPayload sends the payload to both the primary and the verification execution clients, and returns
+// the response of the primary. If the primary found the payload VALID and the verifier found it INVALID,
+// ErrPayloadVerificationDivergence is returned along with the latest valid hash of the primary.
+func (s *Service) verifiedNewPayload(ctx context.Context, verifier engineConn, payload interfaces.ExecutionData, method string, args []interface{}) ([]byte, error) {
+	verified := make(chan *payloadVerdict, 1)
+	go func() {
+		status, err := newPayloadStatus(ctx, verifier.client, method, args)
+		verified <- &payloadVerdict{Endpoint: verifier.endpoint.Url, Status: status, err: err}
+	}()
+	conn := s.conn()
+	status, err := newPayloadStatus(ctx, conn.client, method, args)
+	primary := &payloadVerdict{Endpoint: conn.endpoint.Url, Status: status, err: err}
+	var lvh []byte
+	if err == nil {
+		lvh, err = payloadStatusResult(status)
+	}
+	s.observePrimary(err)
+
+	var v *payloadVerdict
+	select {
+	case v = <-verified:
+	case <-ctx.Done():
+		return lvh, err
+	}
+	if v.err != nil {
+		log.WithError(v.err).Warn("Could not verify payload with verification execution client")
+		return lvh, err
+	}
+	if !conflictingPayloadStatuses(primary.Status, v.Status) {
+		return lvh, err
+	}
+	s.reportPayloadDivergence(payload, method, args, primary, v)
+	if err == nil {
+		return lvh, errors.Wrapf(ErrPayloadVerificationDivergence, "verification execution client returned %s", v.Status.Status)
+	}
+	return lvh, err
+}
+
+// conflictingPayloadStatuses returns true if one execution client found the payload VALID and the other INVALID.
+// SYNCING and ACCEPTED are not a verdict on the payload.
+func conflictingPayloadStatuses(a, b *pb.PayloadStatus) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	return (a.Status == pb.PayloadStatus_VALID && invalidPayloadStatus(b.Status)) ||
+		(invalidPayloadStatus(a.Status) && b.Status == pb.PayloadStatus_VALID)
+}
+
+func invalidPayloadStatus(status pb.PayloadStatus_Status) bool {
+	return status == pb.PayloadStatus_INVALID || status == pb.PayloadStatus_INVALID_BLOCK_HASH
+}
+
+// reportPayloadDivergence logs the responses of both execution clients, persists the payload for analysis and
+// notifies the divergence on the state feed.
+func (s *Service) reportPayloadDivergence(payload interfaces.ExecutionData, method string, args []interface{}, primary, verifier *payloadVerdict) {
+	payloadDivergenceCount.Inc()
+	blockHash := bytesutil.ToBytes32(payload.BlockHash())
+	log.WithFields(logrus.Fields{
+		"blockHash":                   fmt.Sprintf("%#x", blockHash),
+		"blockNumber":                 payload.BlockNumber(),
+		"primaryEndpoint":             logs.MaskCredentialsLogging(primary.Endpoint),
+		"primaryStatus":               primary.Status.Status,
+		"primaryLatestValidHash":      fmt.Sprintf("%#x", primary.Status.LatestValidHash),
+		"primaryValidationError":      primary.Status.ValidationError,
+		"verificationEndpoint":        logs.MaskCredentialsLogging(verifier.Endpoint),
+		"verificationStatus":          verifier.Status.Status,
+		"verificationLatestValidHash": fmt.Sprintf("%#x", verifier.Status.LatestValidHash),
+		"verificationValidationError": verifier.Status.ValidationError,
+	}).Error("Execution clients disagree on the validity of the payload")
+
+	if s.cfg.divergenceDir != "" {
+		path, err := s.persistPayloadDivergence(payload, method, args, primary, verifier)
+		if err != nil {
+			log.WithError(err).Error("Could not persist disputed payload")
+		} else {
+			log.WithField("path", path).Info("Persisted disputed payload")
+		}
+	}
+
+	if s.cfg.stateNotifier != nil {
+		s.cfg.stateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.PayloadVerificationDivergence,
+			Data: &statefeed.PayloadVerificationDivergenceData{
+				BlockHash:               blockHash,
+				BlockNumber:             payload.BlockNumber(),
+				PrimaryStatus:           primary.Status.Status.String(),
+				PrimaryLatestValidHash:  primary.Status.LatestValidHash,
+				VerifierStatus:          verifier.Status.Status.String(),
+				VerifierLatestValidHash: verifier.Status.LatestValidHash,
+			},
+		})
+	}
+}
+
+// persistPayloadDivergence writes the engine API request and the responses of both execution clients to the
+// divergence directory, and returns the path of the written file.
+func (s *Service) persistPayloadDivergence(payload interfaces.ExecutionData, method string, args []interface{}, primary, verifier *payloadVerdict) (string, error) {
+	record := &payloadDivergence{
+		Time:   time.Now(),
+		Method: method,
+		Params: args,
+		Primary: &payloadVerdict{
+			Endpoint: logs.MaskCredentialsLogging(primary.Endpoint),
+			Status:   primary.Status,
+		},
+		Verifier: &payloadVerdict{
+			Endpoint: logs.MaskCredentialsLogging(verifier.Endpoint),
+			Status:   verifier.Status,
+		},
+	}
+	enc, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "could not encode disputed payload")
+	}
+	if err := file.MkdirAll(s.cfg.divergenceDir); err != nil {
+		return "", errors.Wrap(err, "could not create divergence directory")
+	}
+	path := filepath.Join(s.cfg.divergenceDir, fmt.Sprintf("%d-%#x.json", payload.BlockNumber(), payload.BlockHash()))
+	if err := file.WriteFile(path, enc); err != nil {
+		return "", errors.Wrap(err, "could not write disputed payload")
+	}
+	return path, nil
+}
+
+// closeVerifierConnection closes the connection to the verification execution client.
+func (s *Service) closeVerifierConnection() {
+	s.engineLock.Lock()
+	defer s.engineLock.Unlock()
+	if s.verifier != nil && s.verifier.client != nil {
+		s.verifier.client.Close()
+		s.verifier.client = nil
+	}
+}
@@ -0,0 +1,76 @@
+package execution
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/network"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestService_VerifiedNewPayload(t *testing.T) {
+	payload, err := blocks.WrappedExecutionPayload(fixturesStruct().ExecutionPayload)
+	require.NoError(t, err)
+	newService := func(primary, verifier pb.PayloadStatus_Status) *Service {
+		return &Service{
+			ctx: context.Background(),
+			cfg: &config{
+				currHttpEndpoint: network.HttpEndpoint("http://primary:8551"),
+				stateNotifier:    &goodNotifier{},
+				divergenceDir:    t.TempDir(),
+			},
+			rpcClient: &statusClient{status: primary},
+			primary:   &engineEndpoint{},
+			verifier: &engineEndpoint{
+				endpoint: network.HttpEndpoint("http://verifier:8551"),
+				client:   &statusClient{status: verifier},
+			},
+		}
+	}
+	persisted := func(s *Service) bool {
+		files, err := file.DirFiles(s.cfg.divergenceDir)
+		require.NoError(t, err)
+		return len(files) == 1 && filepath.Ext(files[0]) == ".json"
+	}
+
+	t.Run("agreement", func(t *testing.T) {
+		s := newService(pb.PayloadStatus_VALID, pb.PayloadStatus_VALID)
+		_, err := s.NewPayload(context.Background(), payload, nil, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, false, persisted(s))
+	})
+	t.Run("verifier syncing", func(t *testing.T) {
+		s := newService(pb.PayloadStatus_VALID, pb.PayloadStatus_SYNCING)
+		_, err := s.NewPayload(context.Background(), payload, nil, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, false, persisted(s))
+	})
+	t.Run("verifier disputes valid payload", func(t *testing.T) {
+		s := newService(pb.PayloadStatus_VALID, pb.PayloadStatus_INVALID)
+		events := make(chan *feed.Event, 1)
+		sub := s.cfg.stateNotifier.StateFeed().Subscribe(events)
+		defer sub.Unsubscribe()
+		_, err := s.NewPayload(context.Background(), payload, nil, nil, nil)
+		require.ErrorIs(t, err, ErrPayloadVerificationDivergence)
+		require.Equal(t, true, persisted(s))
+		ev := <-events
+		require.Equal(t, feed.EventType(statefeed.PayloadVerificationDivergence), ev.Type)
+		data, ok := ev.Data.(*statefeed.PayloadVerificationDivergenceData)
+		require.Equal(t, true, ok)
+		require.Equal(t, "VALID", data.PrimaryStatus)
+		require.Equal(t, "INVALID", data.VerifierStatus)
+		require.Equal(t, payload.BlockNumber(), data.BlockNumber)
+	})
+	t.Run("primary rejects payload", func(t *testing.T) {
+		s := newService(pb.PayloadStatus_INVALID_BLOCK_HASH, pb.PayloadStatus_VALID)
+		_, err := s.NewPayload(context.Background(), payload, nil, nil, nil)
+		require.ErrorIs(t, err, ErrInvalidBlockHashPayloadStatus)
+		require.Equal(t, true, persisted(s))
+	})
+}
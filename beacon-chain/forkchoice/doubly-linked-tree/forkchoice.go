@@ -3,7 +3,6 @@ package doublylinkedtree
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice"
@@ -70,11 +69,16 @@ func (f *ForkChoice) Head(
 
 	jc := f.JustifiedCheckpoint()
 	fc := f.FinalizedCheckpoint()
-	currentEpoch := slots.EpochsSinceGenesis(time.Unix(int64(f.store.genesisTime), 0))
+	currentEpoch := slots.ToEpoch(f.store.currentSlot())
 	if err := f.store.treeRootNode.updateBestDescendant(ctx, jc.Epoch, fc.Epoch, currentEpoch); err != nil {
 		return [32]byte{}, errors.Wrap(err, "could not update best descendant")
 	}
-	return f.store.head(ctx)
+	head, err := f.store.head(ctx)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	f.recordHead(head)
+	return head, nil
 }
 
 // ProcessAttestation processes attestation for vote accounting, it iterates around validator indices
@@ -101,6 +105,7 @@ func (f *ForkChoice) ProcessAttestation(ctx context.Context, validatorIndices []
 	}
 
 	processedAttestationCount.Inc()
+	f.recordAttestation(validatorIndices, blockRoot, targetEpoch)
 }
 
 // InsertNode processes a new block by inserting it to the fork choice store.
@@ -118,6 +123,7 @@ func (f *ForkChoice) InsertNode(ctx context.Context, state state.BeaconState, ro
 		return errInvalidNilCheckpoint
 	}
 	finalizedEpoch := fc.Epoch
+	boost := f.store.proposerBoostRoot
 	node, err := f.store.insert(ctx, roblock, justifiedEpoch, finalizedEpoch)
 	if err != nil {
 		return err
@@ -131,6 +137,7 @@ func (f *ForkChoice) InsertNode(ctx context.Context, state state.BeaconState, ro
 		}
 		return errors.Wrap(err, "could not update checkpoints")
 	}
+	f.recordInsertNode(node, roblock.Block().ParentRoot(), justifiedEpoch, finalizedEpoch, jc, fc, boost)
 	return nil
 }
 
@@ -340,6 +347,7 @@ func (f *ForkChoice) SetOptimisticToValid(ctx context.Context, root [fieldparams
 	if !ok || node == nil {
 		return errors.Wrap(ErrNilNode, "could not set node to valid")
 	}
+	defer f.recordOptimisticToValid(root)
 	return node.setNodeAndParentValidated(ctx)
 }
 
@@ -360,6 +368,7 @@ func (f *ForkChoice) FinalizedCheckpoint() *forkchoicetypes.Checkpoint {
 
 // SetOptimisticToInvalid removes a block with an invalid execution payload from fork choice store
 func (f *ForkChoice) SetOptimisticToInvalid(ctx context.Context, root, parentRoot, payloadHash [fieldparams.RootLength]byte) ([][32]byte, error) {
+	defer f.recordOptimisticToInvalid(root, parentRoot, payloadHash)
 	return f.store.setOptimisticToInvalid(ctx, root, parentRoot, payloadHash)
 }
 
@@ -372,6 +381,7 @@ func (f *ForkChoice) InsertSlashedIndex(_ context.Context, index primitives.Vali
 		return
 	}
 	f.store.slashedIndices[index] = true
+	defer f.recordSlashedIndex(index)
 
 	// Subtract last vote from this equivocating validator
 
@@ -400,6 +410,7 @@ func (f *ForkChoice) UpdateJustifiedCheckpoint(ctx context.Context, jc *forkchoi
 	if jc == nil {
 		return errInvalidNilCheckpoint
 	}
+	defer f.recordCheckpoint(recordJustifiedCheckpoint, jc)
 	f.store.prevJustifiedCheckpoint = f.store.justifiedCheckpoint
 	f.store.justifiedCheckpoint = jc
 	if err := f.updateJustifiedBalances(ctx, jc.Root); err != nil {
@@ -414,6 +425,7 @@ func (f *ForkChoice) UpdateFinalizedCheckpoint(fc *forkchoicetypes.Checkpoint) e
 		return errInvalidNilCheckpoint
 	}
 	f.store.finalizedCheckpoint = fc
+	f.recordCheckpoint(recordFinalizedCheckpoint, fc)
 	return nil
 }
 
@@ -473,14 +485,18 @@ func (f *ForkChoice) InsertChain(ctx context.Context, chain []*forkchoicetypes.B
 		return nil
 	}
 	for i := len(chain) - 1; i > 0; i-- {
-		if _, err := f.store.insert(ctx,
+		boost := f.store.proposerBoostRoot
+		node, err := f.store.insert(ctx,
 			chain[i].Block,
-			chain[i].JustifiedCheckpoint.Epoch, chain[i].FinalizedCheckpoint.Epoch); err != nil {
+			chain[i].JustifiedCheckpoint.Epoch, chain[i].FinalizedCheckpoint.Epoch)
+		if err != nil {
 			return err
 		}
 		if err := f.updateCheckpoints(ctx, chain[i].JustifiedCheckpoint, chain[i].FinalizedCheckpoint); err != nil {
 			return err
 		}
+		f.recordInsertNode(node, chain[i].Block.Block().ParentRoot(), chain[i].JustifiedCheckpoint.Epoch,
+			chain[i].FinalizedCheckpoint.Epoch, chain[i].JustifiedCheckpoint, chain[i].FinalizedCheckpoint, boost)
 	}
 	return nil
 }
@@ -488,11 +504,13 @@ func (f *ForkChoice) InsertChain(ctx context.Context, chain []*forkchoicetypes.B
 // SetGenesisTime sets the genesisTime tracked by forkchoice
 func (f *ForkChoice) SetGenesisTime(genesisTime uint64) {
 	f.store.genesisTime = genesisTime
+	f.recordGenesisTime(genesisTime)
 }
 
 // SetOriginRoot sets the genesis block root
 func (f *ForkChoice) SetOriginRoot(root [32]byte) {
 	f.store.originRoot = root
+	f.recordOriginRoot(root)
 }
 
 // CachedHeadRoot returns the last cached head root
@@ -580,6 +598,20 @@ func (f *ForkChoice) ForkChoiceDump(ctx context.Context) (*forkchoice2.Dump, err
 	return resp, nil
 }
 
+// NodeDump returns the dump of the node with the given root, as it appears in ForkChoiceDump.
+func (f *ForkChoice) NodeDump(root [32]byte) (*forkchoice2.Node, error) {
+	n, ok := f.store.nodeByRoot[root]
+	if !ok || n == nil {
+		return nil, ErrNilNode
+	}
+	return n.dump(), nil
+}
+
+// PreviousProposerBoost returns the root of the block that received the proposer boost in the previous slot.
+func (f *ForkChoice) PreviousProposerBoost() [fieldparams.RootLength]byte {
+	return f.store.previousProposerBoostRoot
+}
+
 // SetBalancesByRooter sets the balanceByRoot handler in forkchoice
 func (f *ForkChoice) SetBalancesByRooter(handler forkchoice.BalancesByRooter) {
 	f.balancesByRoot = handler
@@ -600,6 +632,7 @@ func (f *ForkChoice) updateJustifiedBalances(ctx context.Context, root [32]byte)
 	if err != nil {
 		return errors.Wrap(err, "could not get justified balances")
 	}
+	f.recordJustifiedBalances(root, balances)
 	f.justifiedBalances = balances
 	f.store.committeeWeight = 0
 	f.numActiveValidators = 0
@@ -51,4 +51,10 @@ var (
 			Help: "The number of times pruning happened.",
 		},
 	)
+	droppedRecordCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "doublylinkedtree_recorder_dropped_count",
+			Help: "The number of fork choice records dropped because the recorder could not keep up.",
+		},
+	)
 )
@@ -147,16 +147,13 @@ func (n *Node) arrivedAfterOrphanCheck(genesisTime uint64) (bool, error) {
 	return secs >= ProcessAttestationsThreshold, err
 }
 
-// nodeTreeDump appends to the given list all the nodes descending from this one
-func (n *Node) nodeTreeDump(ctx context.Context, nodes []*forkchoice2.Node) ([]*forkchoice2.Node, error) {
-	if ctx.Err() != nil {
-		return nil, ctx.Err()
-	}
+// dump returns the representation of the node in a fork choice dump.
+func (n *Node) dump() *forkchoice2.Node {
 	var parentRoot [32]byte
 	if n.parent != nil {
 		parentRoot = n.parent.root
 	}
-	thisNode := &forkchoice2.Node{
+	d := &forkchoice2.Node{
 		Slot:                     n.slot,
 		BlockRoot:                n.root[:],
 		ParentRoot:               parentRoot[:],
@@ -171,12 +168,19 @@ func (n *Node) nodeTreeDump(ctx context.Context, nodes []*forkchoice2.Node) ([]*
 		Timestamp:                n.timestamp,
 	}
 	if n.optimistic {
-		thisNode.Validity = forkchoice2.Optimistic
+		d.Validity = forkchoice2.Optimistic
 	} else {
-		thisNode.Validity = forkchoice2.Valid
+		d.Validity = forkchoice2.Valid
 	}
+	return d
+}
 
-	nodes = append(nodes, thisNode)
+// nodeTreeDump appends to the given list all the nodes descending from this one
+func (n *Node) nodeTreeDump(ctx context.Context, nodes []*forkchoice2.Node) ([]*forkchoice2.Node, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	nodes = append(nodes, n.dump())
 	var err error
 	for _, child := range n.children {
 		nodes, err = child.nodeTreeDump(ctx, nodes)
@@ -30,6 +30,8 @@ import (
 //	    if ancestor_at_finalized_slot == store.finalized_checkpoint.root:
 //	        store.justified_checkpoint = store.best_justified_checkpoint
 func (f *ForkChoice) NewSlot(ctx context.Context, slot primitives.Slot) error {
+	defer f.recordNewSlot(slot)
+
 	// Reset proposer boost root
 	f.store.proposerBoostRoot = [32]byte{}
 
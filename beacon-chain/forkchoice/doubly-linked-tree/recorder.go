@@ -0,0 +1,489 @@
+package doublylinkedtree
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+// recordingMagic starts every fork choice recording, followed by the version of the record encoding.
+var recordingMagic = []byte("PFCR")
+
+// recordingVersion is the version of the record encoding. Recordings of another version are rejected.
+const recordingVersion = 1
+
+var errInvalidRecording = errors.New("invalid fork choice recording")
+
+// recordType identifies the fork choice input of a record.
+type recordType uint8
+
+const (
+	// recordSession starts a new fork choice, at each start of the beacon node.
+	recordSession recordType = iota + 1
+	recordSnapshot
+	recordGenesisTime
+	recordOriginRoot
+	recordInsertNode
+	recordProposerBoost
+	recordAttestation
+	recordNewSlot
+	recordJustifiedCheckpoint
+	recordFinalizedCheckpoint
+	recordJustifiedBalances
+	recordSlashedIndex
+	recordOptimisticToValid
+	recordOptimisticToInvalid
+	recordHead
+	recordShouldOverrideFCU
+	recordProposerHead
+)
+
+// recorderBufferSize is the number of records that can be waiting to be written.
+const recorderBufferSize = 8192
+
+// pendingRecord is a record waiting to be compressed and written by the write loop of the recorder. Snapshots
+// taken at head computations are queued as a copy of fork choice, encoded by the write loop.
+type pendingRecord struct {
+	typ      recordType
+	payload  []byte
+	at       time.Time
+	snapshot *snapshotCopy
+}
+
+// Recorder appends every input of fork choice to a file, so that the decisions of fork choice can be replayed
+// offline with Replay. Records are compressed with snappy and written in the background, and flushed to the file
+// after every head computation. When the recorder can't keep up, records are dropped and a snapshot of fork choice
+// is recorded at a later head computation, at most once per epoch, so that the recording can still be replayed
+// from there.
+type Recorder struct {
+	file    *os.File
+	w       *bufio.Writer
+	err     error
+	records chan *pendingRecord
+	quit    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+	dropped atomic.Bool
+
+	// lock guards closing, so that no record is queued once the write loop is told to quit.
+	lock    sync.RWMutex
+	closing bool
+
+	// lastSnapshot is the slot of the last snapshot taken at a head computation, guarded by the fork choice lock.
+	lastSnapshot    primitives.Slot
+	hasLastSnapshot bool
+}
+
+// NewRecorder opens the recording at the given path, creating it if needed, appends a new session to it and starts
+// its write loop.
+func NewRecorder(path string) (*Recorder, error) {
+	if err := file.MkdirAll(filepath.Dir(path)); err != nil {
+		return nil, errors.Wrap(err, "could not create recording directory")
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open recording")
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not stat recording")
+	}
+	r := &Recorder{
+		file:    f,
+		w:       bufio.NewWriter(f),
+		records: make(chan *pendingRecord, recorderBufferSize),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if info.Size() == 0 {
+		header := binary.LittleEndian.AppendUint64(append([]byte{}, recordingMagic...), recordingVersion)
+		if _, err := r.w.Write(header); err != nil {
+			return nil, errors.Wrap(err, "could not write recording header")
+		}
+	}
+	r.writeRecord(&pendingRecord{typ: recordSession, payload: encodeRecord(time.Now(), nil)})
+	if r.err != nil {
+		return nil, r.err
+	}
+	if err := r.w.Flush(); err != nil {
+		return nil, errors.Wrap(err, "could not flush recording")
+	}
+	go r.run()
+	return r, nil
+}
+
+// Close stops accepting records, writes the queued ones and closes the recording. Records written concurrently
+// with or after Close are dropped.
+func (r *Recorder) Close() error {
+	var err error
+	r.once.Do(func() {
+		r.lock.Lock()
+		r.closing = true
+		r.lock.Unlock()
+		close(r.quit)
+		<-r.done
+		flushErr := r.w.Flush()
+		err = r.file.Close()
+		if flushErr != nil {
+			err = errors.Wrap(flushErr, "could not flush recording")
+		}
+	})
+	return err
+}
+
+// write queues a record of the given type, at the given time, with the payload written by encode. The record is
+// dropped if the write loop can't keep up. Once a record is dropped, all records but snapshots are dropped until
+// a snapshot is queued, as they could not be replayed without the missing record.
+func (r *Recorder) write(t recordType, at time.Time, encode func(w *snapshotWriter)) {
+	if t != recordSnapshot && r.dropped.Load() {
+		droppedRecordCount.Inc()
+		return
+	}
+	r.queue(&pendingRecord{typ: t, payload: encodeRecord(at, encode)})
+}
+
+// writeSnapshot queues a snapshot from a copy of fork choice, which is encoded by the write loop.
+func (r *Recorder) writeSnapshot(at time.Time, c *snapshotCopy) {
+	r.queue(&pendingRecord{typ: recordSnapshot, at: at, snapshot: c})
+}
+
+func (r *Recorder) queue(rec *pendingRecord) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if r.closing {
+		return
+	}
+	select {
+	case r.records <- rec:
+		if rec.typ == recordSnapshot {
+			r.dropped.Store(false)
+		}
+	default:
+		r.dropped.Store(true)
+		droppedRecordCount.Inc()
+	}
+}
+
+// needsSnapshot reports whether records were dropped since the last snapshot was queued, in which case fork choice
+// must be recorded again from a snapshot.
+func (r *Recorder) needsSnapshot() bool {
+	return r.dropped.Load()
+}
+
+// snapshotDue reports whether a snapshot can be taken at a head computation at the given slot. Snapshots copy the
+// whole of fork choice, so they are taken at most once per epoch. The caller must have a lock on forkchoice.
+func (r *Recorder) snapshotDue(slot primitives.Slot) bool {
+	return !r.hasLastSnapshot || slot >= r.lastSnapshot+params.BeaconConfig().SlotsPerEpoch
+}
+
+func (r *Recorder) run() {
+	defer close(r.done)
+	for {
+		select {
+		case rec := <-r.records:
+			r.writeRecord(rec)
+		case <-r.quit:
+			// No record is queued once closing is set, so the queue can be drained without blocking.
+			for {
+				select {
+				case rec := <-r.records:
+					r.writeRecord(rec)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// writeRecord compresses and appends a record to the recording. Recording stops at the first write error.
+func (r *Recorder) writeRecord(rec *pendingRecord) {
+	if r.err != nil {
+		return
+	}
+	if rec.snapshot != nil {
+		rec.payload = encodeRecord(rec.at, rec.snapshot.write)
+	}
+	payload := snappy.Encode(nil, rec.payload)
+	buf := binary.AppendUvarint([]byte{byte(rec.typ)}, uint64(len(payload)))
+	if _, err := r.w.Write(buf); err != nil {
+		r.fail(err)
+		return
+	}
+	if _, err := r.w.Write(payload); err != nil {
+		r.fail(err)
+		return
+	}
+	if rec.typ == recordHead {
+		if err := r.w.Flush(); err != nil {
+			r.fail(err)
+		}
+	}
+}
+
+func (r *Recorder) fail(err error) {
+	r.err = errors.Wrap(err, "could not write fork choice record")
+	log.WithError(r.err).Error("Fork choice recording stopped")
+}
+
+// encodeRecord returns the uncompressed payload of a record: its time followed by the fields written by encode.
+func encodeRecord(at time.Time, encode func(w *snapshotWriter)) []byte {
+	w := &snapshotWriter{}
+	w.uint64(uint64(at.UnixNano()))
+	if encode != nil {
+		encode(w)
+	}
+	return w.buf
+}
+
+// SetRecorder makes fork choice append all of its inputs to the given recorder. If fork choice already has nodes,
+// a snapshot is recorded first. The caller of this function must have a lock on forkchoice.
+func (f *ForkChoice) SetRecorder(r *Recorder) {
+	f.recorder = r
+	if r == nil || f.store.treeRootNode == nil {
+		return
+	}
+	c, err := f.copyForSnapshot()
+	if err != nil {
+		log.WithError(err).Error("Could not record fork choice snapshot")
+		return
+	}
+	r.writeSnapshot(f.store.now(), c)
+}
+
+// record appends a record to the recording, if fork choice is being recorded. Records are written once their
+// input has been processed, so that the justified balances fetched while processing it precede it.
+func (f *ForkChoice) record(t recordType, encode func(w *snapshotWriter)) {
+	if f.recorder == nil {
+		return
+	}
+	f.recorder.write(t, f.store.now(), encode)
+}
+
+func (f *ForkChoice) recordSnapshot(snapshot []byte) {
+	f.record(recordSnapshot, func(w *snapshotWriter) {
+		w.buf = append(w.buf, snapshot...)
+	})
+}
+
+func (f *ForkChoice) recordGenesisTime(genesisTime uint64) {
+	f.record(recordGenesisTime, func(w *snapshotWriter) {
+		w.uint64(genesisTime)
+	})
+}
+
+func (f *ForkChoice) recordOriginRoot(root [32]byte) {
+	f.record(recordOriginRoot, func(w *snapshotWriter) {
+		w.root(root)
+	})
+}
+
+// recordInsertNode records the insertion of a node, with the checkpoints of the state it was inserted with, and
+// the checkpoints computed by pulling up the tips, which require the state to be computed again.
+func (f *ForkChoice) recordInsertNode(n *Node, parentRoot [32]byte, justifiedEpoch, finalizedEpoch primitives.Epoch, jc, fc *ethpb.Checkpoint, boost [32]byte) {
+	if f.recorder == nil {
+		return
+	}
+	f.record(recordInsertNode, func(w *snapshotWriter) {
+		w.root(n.root)
+		w.root(parentRoot)
+		w.uint64(uint64(n.slot))
+		w.root(n.payloadHash)
+		w.uint64(n.timestamp)
+		w.uint64(uint64(justifiedEpoch))
+		w.uint64(uint64(finalizedEpoch))
+		w.uint64(uint64(n.justifiedEpoch))
+		w.uint64(uint64(n.unrealizedJustifiedEpoch))
+		w.uint64(uint64(n.finalizedEpoch))
+		w.uint64(uint64(n.unrealizedFinalizedEpoch))
+		writeCheckpoint(w, f.store.unrealizedJustifiedCheckpoint)
+		writeCheckpoint(w, f.store.unrealizedFinalizedCheckpoint)
+		writeCheckpoint(w, &forkchoicetypes.Checkpoint{Epoch: jc.Epoch, Root: bytesutil.ToBytes32(jc.Root)})
+		writeCheckpoint(w, &forkchoicetypes.Checkpoint{Epoch: fc.Epoch, Root: bytesutil.ToBytes32(fc.Root)})
+	})
+	if f.store.proposerBoostRoot != boost {
+		f.record(recordProposerBoost, func(w *snapshotWriter) {
+			w.root(f.store.proposerBoostRoot)
+		})
+	}
+}
+
+func (f *ForkChoice) recordAttestation(validatorIndices []uint64, blockRoot [32]byte, targetEpoch primitives.Epoch) {
+	if f.recorder == nil {
+		return
+	}
+	f.record(recordAttestation, func(w *snapshotWriter) {
+		w.uint64s(validatorIndices)
+		w.root(blockRoot)
+		w.uint64(uint64(targetEpoch))
+	})
+}
+
+func (f *ForkChoice) recordNewSlot(slot primitives.Slot) {
+	f.record(recordNewSlot, func(w *snapshotWriter) {
+		w.uint64(uint64(slot))
+	})
+}
+
+func (f *ForkChoice) recordCheckpoint(t recordType, cp *forkchoicetypes.Checkpoint) {
+	f.record(t, func(w *snapshotWriter) {
+		writeCheckpoint(w, cp)
+	})
+}
+
+func (f *ForkChoice) recordJustifiedBalances(root [32]byte, balances []uint64) {
+	if f.recorder == nil {
+		return
+	}
+	f.record(recordJustifiedBalances, func(w *snapshotWriter) {
+		w.root(root)
+		w.uint64s(balances)
+	})
+}
+
+func (f *ForkChoice) recordSlashedIndex(index primitives.ValidatorIndex) {
+	f.record(recordSlashedIndex, func(w *snapshotWriter) {
+		w.uint64(uint64(index))
+	})
+}
+
+func (f *ForkChoice) recordOptimisticToValid(root [32]byte) {
+	f.record(recordOptimisticToValid, func(w *snapshotWriter) {
+		w.root(root)
+	})
+}
+
+func (f *ForkChoice) recordOptimisticToInvalid(root, parentRoot, payloadHash [32]byte) {
+	f.record(recordOptimisticToInvalid, func(w *snapshotWriter) {
+		w.root(root)
+		w.root(parentRoot)
+		w.root(payloadHash)
+	})
+}
+
+// recordHead records the computed head. If records were dropped, a snapshot of fork choice, which is complete
+// once the head is computed, is recorded first, so that replay resumes from there. Only a copy of fork choice is
+// taken here, it is encoded by the write loop of the recorder.
+func (f *ForkChoice) recordHead(root [32]byte) {
+	if f.recorder == nil {
+		return
+	}
+	if slot := f.store.currentSlot(); f.recorder.needsSnapshot() && f.recorder.snapshotDue(slot) {
+		c, err := f.copyForSnapshot()
+		if err != nil {
+			log.WithError(err).Error("Could not record fork choice snapshot")
+		} else {
+			f.recorder.lastSnapshot, f.recorder.hasLastSnapshot = slot, true
+			f.recorder.writeSnapshot(f.store.now(), c)
+		}
+	}
+	f.record(recordHead, func(w *snapshotWriter) {
+		w.root(root)
+	})
+}
+
+// recordLateBlockDecision records the decision of ShouldOverrideFCU or GetProposerHead, along with the head it was
+// taken for.
+func (f *ForkChoice) recordLateBlockDecision(t recordType, override bool, root [32]byte) {
+	if f.recorder == nil {
+		return
+	}
+	var head [32]byte
+	if f.store.headNode != nil {
+		head = f.store.headNode.root
+	}
+	f.record(t, func(w *snapshotWriter) {
+		w.root(head)
+		w.bool(override)
+		w.root(root)
+	})
+}
+
+func writeCheckpoint(w *snapshotWriter, cp *forkchoicetypes.Checkpoint) {
+	w.uint64(uint64(cp.Epoch))
+	w.root(cp.Root)
+}
+
+func readCheckpoint(r *snapshotReader) *forkchoicetypes.Checkpoint {
+	return &forkchoicetypes.Checkpoint{Epoch: primitives.Epoch(r.uint64()), Root: r.root()}
+}
+
+// record is a fork choice input read from a recording.
+type record struct {
+	typ     recordType
+	time    time.Time
+	payload *snapshotReader
+}
+
+// recordingReader reads the records of a recording.
+type recordingReader struct {
+	r *bufio.Reader
+}
+
+func newRecordingReader(r *bufio.Reader) (*recordingReader, error) {
+	header := make([]byte, len(recordingMagic)+8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, errors.Wrap(errInvalidRecording, "missing header")
+	}
+	if string(header[:len(recordingMagic)]) != string(recordingMagic) {
+		return nil, errors.Wrap(errInvalidRecording, "not a fork choice recording")
+	}
+	if v := binary.LittleEndian.Uint64(header[len(recordingMagic):]); v != recordingVersion {
+		return nil, errors.Wrapf(errInvalidRecording, "unsupported version %d", v)
+	}
+	return &recordingReader{r: r}, nil
+}
+
+// next returns the next record, or io.EOF at the end of the recording. A record truncated by a crash of the beacon
+// node is reported as io.ErrUnexpectedEOF.
+func (rr *recordingReader) next() (*record, error) {
+	t, err := rr.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	size, err := binary.ReadUvarint(rr.r)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if size > maxRecordSize {
+		return nil, errors.Wrapf(errInvalidRecording, "record of %d bytes", size)
+	}
+	compressed := make([]byte, size)
+	if _, err := io.ReadFull(rr.r, compressed); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	payload, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, errors.Wrap(errInvalidRecording, err.Error())
+	}
+	p := &snapshotReader{buf: payload}
+	at := p.uint64()
+	if p.err != nil {
+		return nil, errors.Wrap(errInvalidRecording, "record without time")
+	}
+	return &record{typ: recordType(t), time: time.Unix(0, int64(at)), payload: p}, nil
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// maxRecordSize bounds the size of a compressed record, the largest being snapshots and justified balances.
+const maxRecordSize = 1 << 30
@@ -0,0 +1,341 @@
+package doublylinkedtree
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+// DecisionKind is the kind of a fork choice decision reproduced by Replay.
+type DecisionKind int
+
+const (
+	// DecisionHead is the computation of the head by Head.
+	DecisionHead DecisionKind = iota
+	// DecisionOverrideFCU is the late block reorg decision of ShouldOverrideFCU.
+	DecisionOverrideFCU
+	// DecisionProposerHead is the late block reorg decision of GetProposerHead.
+	DecisionProposerHead
+)
+
+func (k DecisionKind) String() string {
+	switch k {
+	case DecisionHead:
+		return "head"
+	case DecisionOverrideFCU:
+		return "override_fcu"
+	case DecisionProposerHead:
+		return "proposer_head"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(k))
+	}
+}
+
+// ReplayedDecision is a fork choice decision reproduced from a recording, along with the decision recorded by the
+// beacon node.
+type ReplayedDecision struct {
+	Kind DecisionKind
+	Time time.Time
+	Slot primitives.Slot
+	// Head is the head of fork choice before the decision.
+	Head [32]byte
+	// Root is the head computed by the replay, or the proposer head for DecisionProposerHead.
+	Root         [32]byte
+	RecordedRoot [32]byte
+	// Override is the result of ShouldOverrideFCU for DecisionOverrideFCU.
+	Override         bool
+	RecordedOverride bool
+	// WeightChanges are the weights of the nodes of the previous and new head branches, when the head changed.
+	WeightChanges []*WeightChange
+	Err           error
+}
+
+// Diverged returns true if the replay didn't reproduce the recorded decision.
+func (d *ReplayedDecision) Diverged() bool {
+	return d.Err != nil || d.Root != d.RecordedRoot || d.Override != d.RecordedOverride
+}
+
+// WeightChange is the weight of a node before and after a head computation.
+type WeightChange struct {
+	Root   [32]byte
+	Slot   primitives.Slot
+	Before uint64
+	After  uint64
+}
+
+// Replay feeds the inputs recorded by a Recorder into a new fork choice store and calls fn with every head
+// computation and late block reorg decision. Each input is replayed at the time it was recorded. Replay stops at
+// the end of the recording, at the first error returned by fn, or at a record truncated by a crash of the beacon node.
+func Replay(ctx context.Context, r io.Reader, fn func(*ReplayedDecision) error) error {
+	rr, err := newRecordingReader(bufio.NewReader(r))
+	if err != nil {
+		return err
+	}
+	rp := &replayer{balances: make(map[[32]byte][]uint64)}
+	rp.reset()
+	for i := 0; ; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rec, err := rr.next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			log.WithField("index", i).Warn("Fork choice recording ends with a truncated record")
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "could not read record %d", i)
+		}
+		rp.now = rec.time
+		decision, err := rp.apply(ctx, rec)
+		if err != nil {
+			log.WithError(err).WithFields(logrus.Fields{"index": i, "time": rec.time}).Warn("Could not replay fork choice input")
+			continue
+		}
+		if decision != nil {
+			if err := fn(decision); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// replayer holds the fork choice store the recorded inputs are replayed into.
+type replayer struct {
+	f        *ForkChoice
+	now      time.Time
+	balances map[[32]byte][]uint64
+}
+
+// reset replaces fork choice with a new store, at the start of a session of the beacon node.
+func (rp *replayer) reset() {
+	rp.f = New()
+	rp.f.store.clock = func() time.Time { return rp.now }
+	rp.f.SetBalancesByRooter(func(_ context.Context, root [32]byte) ([]uint64, error) {
+		balances, ok := rp.balances[root]
+		if !ok {
+			return nil, errors.Errorf("no recorded balances for justified root %#x", root)
+		}
+		return balances, nil
+	})
+}
+
+func (rp *replayer) apply(ctx context.Context, rec *record) (*ReplayedDecision, error) {
+	f, p := rp.f, rec.payload
+	var err error
+	switch rec.typ {
+	case recordSession:
+		rp.reset()
+	case recordSnapshot:
+		err = f.Restore(p.buf)
+		p.buf = nil
+	case recordGenesisTime:
+		genesisTime := p.uint64()
+		if p.err == nil {
+			f.SetGenesisTime(genesisTime)
+		}
+	case recordOriginRoot:
+		root := p.root()
+		if p.err == nil {
+			f.SetOriginRoot(root)
+		}
+	case recordInsertNode:
+		err = rp.insertNode(ctx, p)
+	case recordProposerBoost:
+		root := p.root()
+		if p.err == nil && f.store.proposerBoostRoot != root {
+			log.WithField("root", fmt.Sprintf("%#x", root)).Debug("Applying recorded proposer boost")
+			f.store.proposerBoostRoot = root
+		}
+	case recordAttestation:
+		indices := p.uint64s()
+		root := p.root()
+		targetEpoch := primitives.Epoch(p.uint64())
+		if p.err == nil {
+			f.ProcessAttestation(ctx, indices, root, targetEpoch)
+		}
+	case recordNewSlot:
+		slot := primitives.Slot(p.uint64())
+		if p.err == nil {
+			err = f.NewSlot(ctx, slot)
+		}
+	case recordJustifiedCheckpoint:
+		cp := readCheckpoint(p)
+		if p.err == nil {
+			err = f.UpdateJustifiedCheckpoint(ctx, cp)
+		}
+	case recordFinalizedCheckpoint:
+		cp := readCheckpoint(p)
+		if p.err == nil {
+			err = f.UpdateFinalizedCheckpoint(cp)
+		}
+	case recordJustifiedBalances:
+		root := p.root()
+		balances := p.uint64s()
+		if p.err == nil {
+			rp.balances[root] = balances
+		}
+	case recordSlashedIndex:
+		index := primitives.ValidatorIndex(p.uint64())
+		if p.err == nil {
+			f.InsertSlashedIndex(ctx, index)
+		}
+	case recordOptimisticToValid:
+		root := p.root()
+		if p.err == nil {
+			err = f.SetOptimisticToValid(ctx, root)
+		}
+	case recordOptimisticToInvalid:
+		root, parentRoot, payloadHash := p.root(), p.root(), p.root()
+		if p.err == nil {
+			_, err = f.SetOptimisticToInvalid(ctx, root, parentRoot, payloadHash)
+		}
+	case recordHead:
+		recorded := p.root()
+		if p.err == nil {
+			return rp.head(ctx, recorded), nil
+		}
+	case recordShouldOverrideFCU, recordProposerHead:
+		head, recordedOverride, recorded := p.root(), p.bool(), p.root()
+		if p.err == nil {
+			return rp.lateBlockDecision(rec.typ, head, recordedOverride, recorded), nil
+		}
+	default:
+		return nil, errors.Wrapf(errInvalidRecording, "unknown record type %d", rec.typ)
+	}
+	if p.err != nil {
+		return nil, errors.Wrapf(p.err, "could not decode record of type %d", rec.typ)
+	}
+	if len(p.buf) != 0 {
+		return nil, errors.Wrapf(errInvalidRecording, "%d trailing bytes in record of type %d", len(p.buf), rec.typ)
+	}
+	return nil, err
+}
+
+// insertNode inserts a recorded node. The node is inserted with the checkpoints of its state, then the checkpoints
+// computed from the state when pulling up the tips are applied.
+func (rp *replayer) insertNode(ctx context.Context, p *snapshotReader) error {
+	root, parentRoot := p.root(), p.root()
+	slot := primitives.Slot(p.uint64())
+	payloadHash := p.root()
+	timestamp := p.uint64()
+	justifiedEpoch, finalizedEpoch := primitives.Epoch(p.uint64()), primitives.Epoch(p.uint64())
+	nodeJustifiedEpoch, nodeUnrealizedJustifiedEpoch := primitives.Epoch(p.uint64()), primitives.Epoch(p.uint64())
+	nodeFinalizedEpoch, nodeUnrealizedFinalizedEpoch := primitives.Epoch(p.uint64()), primitives.Epoch(p.uint64())
+	unrealizedJustified, unrealizedFinalized := readCheckpoint(p), readCheckpoint(p)
+	jc, fc := readCheckpoint(p), readCheckpoint(p)
+	if p.err != nil {
+		return p.err
+	}
+
+	signed, err := blocks.NewSignedBeaconBlock(&ethpb.SignedBeaconBlockBellatrix{
+		Block: &ethpb.BeaconBlockBellatrix{
+			Slot:       slot,
+			ParentRoot: parentRoot[:],
+			Body: &ethpb.BeaconBlockBodyBellatrix{
+				ExecutionPayload: &enginev1.ExecutionPayload{BlockHash: payloadHash[:]},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	roblock, err := blocks.NewROBlockWithRoot(signed, root)
+	if err != nil {
+		return err
+	}
+	// The node is inserted at the time it was inserted by the beacon node, to apply the same proposer boost.
+	rp.now = time.Unix(int64(timestamp), 0)
+	s := rp.f.store
+	n, err := s.insert(ctx, roblock, justifiedEpoch, finalizedEpoch)
+	if err != nil {
+		return err
+	}
+	n.justifiedEpoch, n.unrealizedJustifiedEpoch = nodeJustifiedEpoch, nodeUnrealizedJustifiedEpoch
+	n.finalizedEpoch, n.unrealizedFinalizedEpoch = nodeFinalizedEpoch, nodeUnrealizedFinalizedEpoch
+	s.unrealizedJustifiedCheckpoint, s.unrealizedFinalizedCheckpoint = unrealizedJustified, unrealizedFinalized
+	return rp.f.updateCheckpoints(ctx,
+		&ethpb.Checkpoint{Epoch: jc.Epoch, Root: jc.Root[:]},
+		&ethpb.Checkpoint{Epoch: fc.Epoch, Root: fc.Root[:]})
+}
+
+// head computes the head, and the weights of the branches of the previous and new heads if the head changed.
+func (rp *replayer) head(ctx context.Context, recorded [32]byte) *ReplayedDecision {
+	s := rp.f.store
+	d := &ReplayedDecision{Kind: DecisionHead, Time: rp.now, Slot: s.currentSlot(), RecordedRoot: recorded}
+	previous := s.headNode
+	if previous != nil {
+		d.Head = previous.root
+	}
+	weights := make(map[*Node]uint64, len(s.nodeByRoot))
+	for _, n := range s.nodeByRoot {
+		weights[n] = n.weight
+	}
+	d.Root, d.Err = rp.f.Head(ctx)
+	if d.Err != nil || previous == nil || d.Root == d.Head {
+		return d
+	}
+	current, ok := s.nodeByRoot[d.Root]
+	if !ok {
+		return d
+	}
+	// Walk both branches down to their common ancestor, which is included once.
+	onCurrentBranch := make(map[*Node]bool)
+	for n := current; n != nil; n = n.parent {
+		onCurrentBranch[n] = true
+	}
+	change := func(n *Node) *WeightChange {
+		return &WeightChange{Root: n.root, Slot: n.slot, Before: weights[n], After: n.weight}
+	}
+	var ancestor *Node
+	var previousBranch []*WeightChange
+	for n := previous; n != nil; n = n.parent {
+		if onCurrentBranch[n] {
+			ancestor = n
+			break
+		}
+		previousBranch = append(previousBranch, change(n))
+	}
+	for n := current; n != nil && n != ancestor; n = n.parent {
+		d.WeightChanges = append(d.WeightChanges, change(n))
+	}
+	d.WeightChanges = append(d.WeightChanges, previousBranch...)
+	if ancestor != nil {
+		d.WeightChanges = append(d.WeightChanges, change(ancestor))
+	}
+	return d
+}
+
+func (rp *replayer) lateBlockDecision(t recordType, head [32]byte, recordedOverride bool, recorded [32]byte) *ReplayedDecision {
+	d := &ReplayedDecision{
+		Time:             rp.now,
+		Slot:             rp.f.store.currentSlot(),
+		RecordedRoot:     recorded,
+		RecordedOverride: recordedOverride,
+	}
+	if rp.f.store.headNode != nil {
+		d.Head = rp.f.store.headNode.root
+	}
+	if d.Head != head {
+		d.Err = errors.Errorf("replayed head %#x differs from recorded head %#x", d.Head, head)
+	}
+	if t == recordShouldOverrideFCU {
+		d.Kind = DecisionOverrideFCU
+		d.Override = rp.f.ShouldOverrideFCU()
+		return d
+	}
+	d.Kind = DecisionProposerHead
+	d.Root = rp.f.GetProposerHead()
+	return d
+}
@@ -0,0 +1,182 @@
+package doublylinkedtree
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestForkChoice_RecordReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "forkchoice.rec")
+	rec, err := NewRecorder(path)
+	require.NoError(t, err)
+
+	f := New()
+	f.SetRecorder(rec)
+	f.SetBalancesByRooter(func(context.Context, [32]byte) ([]uint64, error) { return []uint64{10, 20, 25}, nil })
+	f.SetGenesisTime(uint64(time.Now().Unix()) - 2*params.BeaconConfig().SecondsPerSlot)
+	require.NoError(t, f.UpdateJustifiedCheckpoint(ctx, &forkchoicetypes.Checkpoint{Root: params.BeaconConfig().ZeroHash}))
+	require.NoError(t, f.UpdateFinalizedCheckpoint(&forkchoicetypes.Checkpoint{Root: params.BeaconConfig().ZeroHash}))
+	//         0
+	//        / \
+	//       1   2
+	//       |
+	//       3
+	for _, n := range []struct {
+		slot         primitives.Slot
+		root, parent [32]byte
+	}{
+		{0, params.BeaconConfig().ZeroHash, [32]byte{}},
+		{1, indexToHash(1), params.BeaconConfig().ZeroHash},
+		{1, indexToHash(2), params.BeaconConfig().ZeroHash},
+		{2, indexToHash(3), indexToHash(1)},
+	} {
+		st, roblock, err := prepareForkchoiceState(ctx, n.slot, n.root, n.parent, n.root, 0, 0)
+		require.NoError(t, err)
+		require.NoError(t, f.InsertNode(ctx, st, roblock))
+	}
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(3), 0)
+	f.ProcessAttestation(ctx, []uint64{2}, indexToHash(2), 0)
+	head, err := f.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(3), head)
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(2), 1)
+	head, err = f.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(2), head)
+	f.ShouldOverrideFCU()
+	f.GetProposerHead()
+	require.NoError(t, rec.Close())
+
+	// A restart appends a new session to the recording, starting from a snapshot.
+	snapshot, err := f.Snapshot()
+	require.NoError(t, err)
+	rec, err = NewRecorder(path)
+	require.NoError(t, err)
+	restarted := New()
+	restarted.SetRecorder(rec)
+	restarted.SetBalancesByRooter(f.balancesByRoot)
+	require.NoError(t, restarted.Restore(snapshot))
+	restarted.InsertSlashedIndex(ctx, 2)
+	head, err = restarted.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(2), head)
+	require.NoError(t, rec.Close())
+
+	recording, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var decisions []*ReplayedDecision
+	require.NoError(t, Replay(ctx, bytes.NewReader(recording), func(d *ReplayedDecision) error {
+		decisions = append(decisions, d)
+		return nil
+	}))
+	require.Equal(t, 5, len(decisions))
+	for _, d := range decisions {
+		require.NoError(t, d.Err)
+		require.Equal(t, false, d.Diverged())
+	}
+	kinds := []DecisionKind{DecisionHead, DecisionHead, DecisionOverrideFCU, DecisionProposerHead, DecisionHead}
+	for i, k := range kinds {
+		require.Equal(t, k, decisions[i].Kind)
+	}
+	require.Equal(t, indexToHash(3), decisions[0].Root)
+	require.Equal(t, primitives.Slot(2), decisions[0].Slot)
+
+	// The head change reports the weights of both branches down to their common ancestor.
+	change := decisions[1]
+	require.Equal(t, indexToHash(3), change.Head)
+	require.Equal(t, indexToHash(2), change.Root)
+	require.Equal(t, 4, len(change.WeightChanges))
+	require.DeepEqual(t, &WeightChange{Root: indexToHash(2), Slot: 1, Before: 25, After: 55}, change.WeightChanges[0])
+	require.DeepEqual(t, &WeightChange{Root: indexToHash(3), Slot: 2, Before: 30, After: 0}, change.WeightChanges[1])
+	require.DeepEqual(t, &WeightChange{Root: indexToHash(1), Slot: 1, Before: 30, After: 0}, change.WeightChanges[2])
+	require.Equal(t, params.BeaconConfig().ZeroHash, change.WeightChanges[3].Root)
+
+	t.Run("truncated recording", func(t *testing.T) {
+		var replayed int
+		require.NoError(t, Replay(ctx, bytes.NewReader(recording[:len(recording)-1]), func(*ReplayedDecision) error {
+			replayed++
+			return nil
+		}))
+		require.Equal(t, 4, replayed)
+	})
+	t.Run("not a recording", func(t *testing.T) {
+		err := Replay(ctx, bytes.NewReader([]byte("not a fork choice recording")), func(*ReplayedDecision) error { return nil })
+		require.ErrorIs(t, err, errInvalidRecording)
+	})
+}
+
+func TestRecorder_DropsUntilSnapshot(t *testing.T) {
+	r := &Recorder{records: make(chan *pendingRecord, 1)}
+	r.write(recordNewSlot, time.Now(), nil)
+	require.Equal(t, false, r.needsSnapshot())
+
+	// The queue is full, the record is dropped and so are the following ones until a snapshot is queued.
+	r.write(recordNewSlot, time.Now(), nil)
+	require.Equal(t, true, r.needsSnapshot())
+	<-r.records
+	r.write(recordNewSlot, time.Now(), nil)
+	require.Equal(t, 0, len(r.records))
+	r.write(recordSnapshot, time.Now(), nil)
+	require.Equal(t, false, r.needsSnapshot())
+	rec := <-r.records
+	require.Equal(t, recordSnapshot, rec.typ)
+}
+
+func TestRecorder_SnapshotOncePerEpoch(t *testing.T) {
+	f := setup(0, 0)
+	now := time.Unix(int64(f.store.genesisTime), 0)
+	f.store.clock = func() time.Time { return now }
+	r := &Recorder{records: make(chan *pendingRecord, 2)}
+	f.recorder = r
+	r.dropped.Store(true)
+
+	// Records were dropped, the head computation is preceded by a snapshot copied from fork choice.
+	f.recordHead(params.BeaconConfig().ZeroHash)
+	require.Equal(t, 2, len(r.records))
+	rec := <-r.records
+	require.Equal(t, recordSnapshot, rec.typ)
+	require.NotNil(t, rec.snapshot)
+	require.Equal(t, recordHead, (<-r.records).typ)
+
+	// Records are dropped again, no snapshot is taken until an epoch has passed.
+	r.dropped.Store(true)
+	now = now.Add(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	f.recordHead(params.BeaconConfig().ZeroHash)
+	require.Equal(t, 0, len(r.records))
+	require.Equal(t, true, r.needsSnapshot())
+
+	now = now.Add(time.Duration(uint64(params.BeaconConfig().SlotsPerEpoch)*params.BeaconConfig().SecondsPerSlot) * time.Second)
+	f.recordHead(params.BeaconConfig().ZeroHash)
+	require.Equal(t, 2, len(r.records))
+	require.Equal(t, recordSnapshot, (<-r.records).typ)
+	require.Equal(t, false, r.needsSnapshot())
+}
+
+func TestRecorder_WriteDuringClose(t *testing.T) {
+	rec, err := NewRecorder(filepath.Join(t.TempDir(), "forkchoice.rec"))
+	require.NoError(t, err)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				rec.write(recordNewSlot, time.Now(), func(w *snapshotWriter) { w.uint64(uint64(j)) })
+			}
+		}()
+	}
+	require.NoError(t, rec.Close())
+	wg.Wait()
+	require.NoError(t, rec.Close())
+}
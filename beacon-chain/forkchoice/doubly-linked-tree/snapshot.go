@@ -0,0 +1,436 @@
+package doublylinkedtree
+
+import (
+	"encoding/binary"
+	"slices"
+	"sort"
+
+	"github.com/pkg/errors"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// snapshotVersion is the version of the fork choice snapshot encoding. Snapshots of another version are rejected.
+const snapshotVersion = 1
+
+var errInvalidSnapshot = errors.New("invalid fork choice snapshot")
+
+// Snapshot serializes the node tree, votes, balances, proposer boost and checkpoints of fork choice,
+// so that it can be restored with Restore. The caller of this function must have a read lock on forkchoice.
+func (f *ForkChoice) Snapshot() ([]byte, error) {
+	c, err := f.copyForSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	w := &snapshotWriter{}
+	c.write(w)
+	return w.buf, nil
+}
+
+// snapshotNode is a copy of the fields of a node that are written to a snapshot.
+type snapshotNode struct {
+	root                     [fieldparams.RootLength]byte
+	parent                   optionalRoot
+	slot                     primitives.Slot
+	payloadHash              [fieldparams.RootLength]byte
+	target                   optionalRoot
+	justifiedEpoch           primitives.Epoch
+	unrealizedJustifiedEpoch primitives.Epoch
+	finalizedEpoch           primitives.Epoch
+	unrealizedFinalizedEpoch primitives.Epoch
+	balance                  uint64
+	weight                   uint64
+	bestDescendant           optionalRoot
+	optimistic               bool
+	timestamp                uint64
+}
+
+// optionalRoot is the root of a node that may be nil.
+type optionalRoot struct {
+	ok   bool
+	root [fieldparams.RootLength]byte
+}
+
+func nodeRoot(n *Node) optionalRoot {
+	if n == nil {
+		return optionalRoot{}
+	}
+	return optionalRoot{ok: true, root: n.root}
+}
+
+// snapshotCopy is a copy of everything written to a snapshot. It shares no memory with fork choice, so that it
+// can be encoded without a lock on forkchoice.
+type snapshotCopy struct {
+	genesisTime                uint64
+	originRoot                 [fieldparams.RootLength]byte
+	checkpoints                []forkchoicetypes.Checkpoint
+	proposerBoostRoot          [fieldparams.RootLength]byte
+	previousProposerBoostRoot  [fieldparams.RootLength]byte
+	previousProposerBoostScore uint64
+	committeeWeight            uint64
+	head                       optionalRoot
+	highestReceived            optionalRoot
+	receivedBlocksLastEpoch    [fieldparams.SlotsPerEpoch]primitives.Slot
+	allTipsAreInvalid          bool
+	slashed                    []uint64
+	nodes                      []snapshotNode
+	votes                      []Vote
+	balances                   []uint64
+	justifiedBalances          []uint64
+	numActiveValidators        uint64
+}
+
+// copyForSnapshot copies the content of fork choice that is written to a snapshot. The caller of this function
+// must have a read lock on forkchoice.
+func (f *ForkChoice) copyForSnapshot() (*snapshotCopy, error) {
+	s := f.store
+	if s.treeRootNode == nil {
+		return nil, errors.Wrap(errInvalidSnapshot, "fork choice has no nodes")
+	}
+	c := &snapshotCopy{
+		genesisTime:                s.genesisTime,
+		originRoot:                 s.originRoot,
+		proposerBoostRoot:          s.proposerBoostRoot,
+		previousProposerBoostRoot:  s.previousProposerBoostRoot,
+		previousProposerBoostScore: s.previousProposerBoostScore,
+		committeeWeight:            s.committeeWeight,
+		head:                       nodeRoot(s.headNode),
+		highestReceived:            nodeRoot(s.highestReceivedNode),
+		receivedBlocksLastEpoch:    s.receivedBlocksLastEpoch,
+		allTipsAreInvalid:          s.allTipsAreInvalid,
+		votes:                      append([]Vote(nil), f.votes...),
+		balances:                   append([]uint64(nil), f.balances...),
+		justifiedBalances:          append([]uint64(nil), f.justifiedBalances...),
+		numActiveValidators:        f.numActiveValidators,
+	}
+	for _, cp := range []*forkchoicetypes.Checkpoint{
+		s.justifiedCheckpoint,
+		s.unrealizedJustifiedCheckpoint,
+		s.unrealizedFinalizedCheckpoint,
+		s.prevJustifiedCheckpoint,
+		s.finalizedCheckpoint,
+	} {
+		c.checkpoints = append(c.checkpoints, *cp)
+	}
+
+	c.slashed = make([]uint64, 0, len(s.slashedIndices))
+	for idx := range s.slashedIndices {
+		c.slashed = append(c.slashed, uint64(idx))
+	}
+	sort.Slice(c.slashed, func(i, j int) bool { return c.slashed[i] < c.slashed[j] })
+
+	// Nodes are copied parents first, so that every parent is known when a node is restored.
+	c.nodes = make([]snapshotNode, 0, len(s.nodeByRoot))
+	queue := []*Node{s.treeRootNode}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		c.nodes = append(c.nodes, snapshotNode{
+			root:                     n.root,
+			parent:                   nodeRoot(n.parent),
+			slot:                     n.slot,
+			payloadHash:              n.payloadHash,
+			target:                   nodeRoot(n.target),
+			justifiedEpoch:           n.justifiedEpoch,
+			unrealizedJustifiedEpoch: n.unrealizedJustifiedEpoch,
+			finalizedEpoch:           n.finalizedEpoch,
+			unrealizedFinalizedEpoch: n.unrealizedFinalizedEpoch,
+			balance:                  n.balance,
+			weight:                   n.weight,
+			bestDescendant:           nodeRoot(n.bestDescendant),
+			optimistic:               n.optimistic,
+			timestamp:                n.timestamp,
+		})
+		queue = append(queue, n.children...)
+	}
+	if len(c.nodes) != len(s.nodeByRoot) {
+		return nil, errors.Wrapf(errInvalidSnapshot, "%d nodes are reachable from the tree root, %d are indexed", len(c.nodes), len(s.nodeByRoot))
+	}
+	return c, nil
+}
+
+// write encodes the copy as a snapshot.
+func (c *snapshotCopy) write(w *snapshotWriter) {
+	w.buf = slices.Grow(w.buf, 1024+len(c.nodes)*256+len(c.votes)*72+(len(c.balances)+len(c.justifiedBalances))*8)
+	w.uint64(snapshotVersion)
+	w.uint64(c.genesisTime)
+	w.root(c.originRoot)
+	for _, cp := range c.checkpoints {
+		w.uint64(uint64(cp.Epoch))
+		w.root(cp.Root)
+	}
+	w.root(c.proposerBoostRoot)
+	w.root(c.previousProposerBoostRoot)
+	w.uint64(c.previousProposerBoostScore)
+	w.uint64(c.committeeWeight)
+	w.nodeRoot(c.head)
+	w.nodeRoot(c.highestReceived)
+	w.uint64(uint64(len(c.receivedBlocksLastEpoch)))
+	for _, slot := range c.receivedBlocksLastEpoch {
+		w.uint64(uint64(slot))
+	}
+	w.bool(c.allTipsAreInvalid)
+	w.uint64s(c.slashed)
+
+	w.uint64(uint64(len(c.nodes)))
+	for _, n := range c.nodes {
+		w.root(n.root)
+		w.nodeRoot(n.parent)
+		w.uint64(uint64(n.slot))
+		w.root(n.payloadHash)
+		w.nodeRoot(n.target)
+		w.uint64(uint64(n.justifiedEpoch))
+		w.uint64(uint64(n.unrealizedJustifiedEpoch))
+		w.uint64(uint64(n.finalizedEpoch))
+		w.uint64(uint64(n.unrealizedFinalizedEpoch))
+		w.uint64(n.balance)
+		w.uint64(n.weight)
+		w.nodeRoot(n.bestDescendant)
+		w.bool(n.optimistic)
+		w.uint64(n.timestamp)
+	}
+
+	w.uint64(uint64(len(c.votes)))
+	for _, v := range c.votes {
+		w.root(v.currentRoot)
+		w.root(v.nextRoot)
+		w.uint64(uint64(v.nextEpoch))
+	}
+	w.uint64s(c.balances)
+	w.uint64s(c.justifiedBalances)
+	w.uint64(c.numActiveValidators)
+}
+
+// Restore replaces the content of fork choice with a snapshot produced by Snapshot. Fork choice is left
+// unchanged if the snapshot can't be decoded. The caller of this function must have a lock on forkchoice.
+func (f *ForkChoice) Restore(snapshot []byte) error {
+	r := &snapshotReader{buf: snapshot}
+	if v := r.uint64(); r.err == nil && v != snapshotVersion {
+		return errors.Wrapf(errInvalidSnapshot, "unsupported version %d", v)
+	}
+	s := &Store{
+		nodeByRoot:     make(map[[fieldparams.RootLength]byte]*Node),
+		nodeByPayload:  make(map[[fieldparams.RootLength]byte]*Node),
+		slashedIndices: make(map[primitives.ValidatorIndex]bool),
+	}
+	s.genesisTime = r.uint64()
+	s.originRoot = r.root()
+	checkpoints := make([]*forkchoicetypes.Checkpoint, 5)
+	for i := range checkpoints {
+		checkpoints[i] = &forkchoicetypes.Checkpoint{Epoch: primitives.Epoch(r.uint64()), Root: r.root()}
+	}
+	s.justifiedCheckpoint = checkpoints[0]
+	s.unrealizedJustifiedCheckpoint = checkpoints[1]
+	s.unrealizedFinalizedCheckpoint = checkpoints[2]
+	s.prevJustifiedCheckpoint = checkpoints[3]
+	s.finalizedCheckpoint = checkpoints[4]
+	s.proposerBoostRoot = r.root()
+	s.previousProposerBoostRoot = r.root()
+	s.previousProposerBoostScore = r.uint64()
+	s.committeeWeight = r.uint64()
+	headRoot, hasHead := r.nodeRoot()
+	highestRoot, hasHighest := r.nodeRoot()
+	if n := r.uint64(); r.err == nil && n != uint64(len(s.receivedBlocksLastEpoch)) {
+		return errors.Wrapf(errInvalidSnapshot, "snapshot tracks %d slots per epoch, want %d", n, len(s.receivedBlocksLastEpoch))
+	}
+	for i := range s.receivedBlocksLastEpoch {
+		s.receivedBlocksLastEpoch[i] = primitives.Slot(r.uint64())
+	}
+	s.allTipsAreInvalid = r.bool()
+	for _, idx := range r.uint64s() {
+		s.slashedIndices[primitives.ValidatorIndex(idx)] = true
+	}
+
+	nodes := r.count(32)
+	bestDescendants := make(map[*Node][32]byte)
+	for i := uint64(0); i < nodes && r.err == nil; i++ {
+		n := &Node{root: r.root()}
+		parentRoot, hasParent := r.nodeRoot()
+		n.slot = primitives.Slot(r.uint64())
+		n.payloadHash = r.root()
+		targetRoot, hasTarget := r.nodeRoot()
+		n.justifiedEpoch = primitives.Epoch(r.uint64())
+		n.unrealizedJustifiedEpoch = primitives.Epoch(r.uint64())
+		n.finalizedEpoch = primitives.Epoch(r.uint64())
+		n.unrealizedFinalizedEpoch = primitives.Epoch(r.uint64())
+		n.balance = r.uint64()
+		n.weight = r.uint64()
+		bestDescendant, hasBestDescendant := r.nodeRoot()
+		n.optimistic = r.bool()
+		n.timestamp = r.uint64()
+		if r.err != nil {
+			break
+		}
+		if _, ok := s.nodeByRoot[n.root]; ok {
+			return errors.Wrapf(errInvalidSnapshot, "duplicate node %#x", n.root)
+		}
+		if hasParent {
+			parent, ok := s.nodeByRoot[parentRoot]
+			if !ok {
+				return errors.Wrapf(errInvalidSnapshot, "unknown parent %#x of node %#x", parentRoot, n.root)
+			}
+			n.parent = parent
+			parent.children = append(parent.children, n)
+		} else if s.treeRootNode != nil {
+			return errors.Wrapf(errInvalidSnapshot, "node %#x has no parent", n.root)
+		} else {
+			s.treeRootNode = n
+		}
+		if hasTarget {
+			if targetRoot == n.root {
+				n.target = n
+			} else {
+				// Targets pruned from the tree are not tracked.
+				n.target = s.nodeByRoot[targetRoot]
+			}
+		}
+		if hasBestDescendant {
+			bestDescendants[n] = bestDescendant
+		}
+		s.nodeByRoot[n.root] = n
+		s.nodeByPayload[n.payloadHash] = n
+	}
+	if r.err == nil && s.treeRootNode == nil {
+		return errors.Wrap(errInvalidSnapshot, "no tree root node")
+	}
+	for n, root := range bestDescendants {
+		d, ok := s.nodeByRoot[root]
+		if !ok {
+			return errors.Wrapf(errInvalidSnapshot, "unknown best descendant %#x of node %#x", root, n.root)
+		}
+		n.bestDescendant = d
+	}
+	var ok bool
+	if hasHead {
+		if s.headNode, ok = s.nodeByRoot[headRoot]; !ok {
+			return errors.Wrapf(errInvalidSnapshot, "unknown head %#x", headRoot)
+		}
+	}
+	if hasHighest {
+		if s.highestReceivedNode, ok = s.nodeByRoot[highestRoot]; !ok {
+			return errors.Wrapf(errInvalidSnapshot, "unknown highest received node %#x", highestRoot)
+		}
+	}
+
+	votes := make([]Vote, r.count(72))
+	for i := range votes {
+		votes[i] = Vote{currentRoot: r.root(), nextRoot: r.root(), nextEpoch: primitives.Epoch(r.uint64())}
+	}
+	balances := r.uint64s()
+	justifiedBalances := r.uint64s()
+	numActiveValidators := r.uint64()
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.buf) != 0 {
+		return errors.Wrapf(errInvalidSnapshot, "%d trailing bytes", len(r.buf))
+	}
+
+	s.clock = f.store.clock
+	f.store = s
+	f.votes = votes
+	f.balances = balances
+	f.justifiedBalances = justifiedBalances
+	f.numActiveValidators = numActiveValidators
+	nodeCount.Set(float64(len(s.nodeByRoot)))
+	f.recordSnapshot(snapshot)
+	return nil
+}
+
+type snapshotWriter struct {
+	buf []byte
+}
+
+func (w *snapshotWriter) uint64(v uint64) {
+	w.buf = binary.LittleEndian.AppendUint64(w.buf, v)
+}
+
+func (w *snapshotWriter) uint64s(vs []uint64) {
+	w.uint64(uint64(len(vs)))
+	for _, v := range vs {
+		w.uint64(v)
+	}
+}
+
+func (w *snapshotWriter) bool(v bool) {
+	if v {
+		w.buf = append(w.buf, 1)
+		return
+	}
+	w.buf = append(w.buf, 0)
+}
+
+func (w *snapshotWriter) root(r [fieldparams.RootLength]byte) {
+	w.buf = append(w.buf, r[:]...)
+}
+
+// nodeRoot writes the root of a node that may be nil.
+func (w *snapshotWriter) nodeRoot(r optionalRoot) {
+	w.bool(r.ok)
+	w.root(r.root)
+}
+
+// snapshotReader decodes a snapshot. After the first error, reads return zero values and the error is kept.
+type snapshotReader struct {
+	buf []byte
+	err error
+}
+
+func (r *snapshotReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.buf) < n {
+		r.err = errors.Wrap(errInvalidSnapshot, "unexpected end of snapshot")
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *snapshotReader) uint64() uint64 {
+	b := r.next(8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+// count reads the length of a list whose items are encoded in at least itemSize bytes, rejecting lengths that
+// can't fit in the rest of the snapshot.
+func (r *snapshotReader) count(itemSize int) uint64 {
+	n := r.uint64()
+	if r.err == nil && n > uint64(len(r.buf)/itemSize) {
+		r.err = errors.Wrapf(errInvalidSnapshot, "list of %d items exceeds snapshot size", n)
+	}
+	if r.err != nil {
+		return 0
+	}
+	return n
+}
+
+func (r *snapshotReader) uint64s() []uint64 {
+	vs := make([]uint64, r.count(8))
+	for i := range vs {
+		vs[i] = r.uint64()
+	}
+	return vs
+}
+
+func (r *snapshotReader) bool() bool {
+	b := r.next(1)
+	return b != nil && b[0] == 1
+}
+
+func (r *snapshotReader) root() [fieldparams.RootLength]byte {
+	var root [fieldparams.RootLength]byte
+	copy(root[:], r.next(fieldparams.RootLength))
+	return root
+}
+
+func (r *snapshotReader) nodeRoot() ([fieldparams.RootLength]byte, bool) {
+	ok := r.bool()
+	return r.root(), ok
+}
@@ -0,0 +1,100 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestForkChoice_SnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+	f := setup(1, 1)
+	f.justifiedBalances = []uint64{10, 20, 30}
+	//         0
+	//        / \
+	//       1   2
+	//       |
+	//       3
+	for _, n := range []struct {
+		slot         uint64
+		root, parent int
+	}{
+		{1, 1, 0},
+		{1, 2, 0},
+		{2, 3, 1},
+	} {
+		parent := indexToHash(uint64(n.parent))
+		if n.parent == 0 {
+			parent = params.BeaconConfig().ZeroHash
+		}
+		st, roblock, err := prepareForkchoiceState(ctx, 0, indexToHash(uint64(n.root)), parent, indexToHash(uint64(n.root)+100), 1, 1)
+		require.NoError(t, err)
+		require.NoError(t, f.InsertNode(ctx, st, roblock))
+	}
+	require.NoError(t, f.SetOptimisticToValid(ctx, indexToHash(1)))
+	f.InsertSlashedIndex(ctx, 2)
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(3), 2)
+	f.ProcessAttestation(ctx, []uint64{2}, indexToHash(2), 2)
+	head, err := f.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(3), head)
+
+	snapshot, err := f.Snapshot()
+	require.NoError(t, err)
+	restored := New()
+	restored.SetBalancesByRooter(f.balancesByRoot)
+	require.NoError(t, restored.Restore(snapshot))
+
+	require.Equal(t, f.NodeCount(), restored.NodeCount())
+	want, err := f.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	got, err := restored.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, want, got)
+	require.DeepEqual(t, f.votes, restored.votes)
+	require.DeepEqual(t, f.balances, restored.balances)
+	require.Equal(t, true, restored.store.slashedIndices[2])
+
+	// The restored fork choice keeps tracking votes.
+	restored.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(2), 3)
+	head, err = restored.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(2), head)
+	wantTarget, err := f.TargetRootForEpoch(indexToHash(3), 0)
+	require.NoError(t, err)
+	target, err := restored.TargetRootForEpoch(indexToHash(3), 0)
+	require.NoError(t, err)
+	require.Equal(t, wantTarget, target)
+
+	// Both fork choices evolve identically after the restore.
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(2), 3)
+	_, err = f.Head(ctx)
+	require.NoError(t, err)
+	want, err = f.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	got, err = restored.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, want, got)
+}
+
+func TestForkChoice_RestoreInvalidSnapshot(t *testing.T) {
+	ctx := context.Background()
+	f := setup(1, 1)
+	st, roblock, err := prepareForkchoiceState(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, params.BeaconConfig().ZeroHash, 1, 1)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, roblock))
+	snapshot, err := f.Snapshot()
+	require.NoError(t, err)
+
+	restored := setup(1, 1)
+	require.ErrorIs(t, restored.Restore(snapshot[:len(snapshot)-1]), errInvalidSnapshot)
+	require.ErrorIs(t, restored.Restore(append(snapshot, 0)), errInvalidSnapshot)
+	unsupported := append([]byte{}, snapshot...)
+	unsupported[0] = snapshotVersion + 1
+	require.ErrorContains(t, "unsupported version", restored.Restore(unsupported))
+	// Fork choice is unchanged after a failed restore.
+	require.Equal(t, 1, restored.NodeCount())
+	require.Equal(t, false, restored.HasNode(indexToHash(1)))
+}
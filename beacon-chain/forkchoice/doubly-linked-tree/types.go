@@ -2,6 +2,7 @@ package doublylinkedtree
 
 import (
 	"sync"
+	"time"
 
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
@@ -18,6 +19,7 @@ type ForkChoice struct {
 	justifiedBalances   []uint64                    // tracks individual validator's last justified balances.
 	numActiveValidators uint64                      // tracks the total number of active validators.
 	balancesByRoot      forkchoice.BalancesByRooter // handler to obtain balances for the state with a given root
+	recorder            *Recorder                   // records the inputs of fork choice, if set.
 }
 
 // Store defines the fork choice store which includes block nodes and the last view of checkpoint information.
@@ -41,6 +43,7 @@ type Store struct {
 	highestReceivedNode           *Node                                      // The highest slot node.
 	receivedBlocksLastEpoch       [fieldparams.SlotsPerEpoch]primitives.Slot // Using `highestReceivedSlot`. The slot of blocks received in the last epoch.
 	allTipsAreInvalid             bool                                       // tracks if all tips are not viable for head
+	clock                         func() time.Time                           // returns the current time, overridden when replaying recorded inputs.
 }
 
 // Node defines the individual block which includes its block parent, ancestor and how much weight accounted for it.
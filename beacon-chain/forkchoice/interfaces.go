@@ -25,6 +25,13 @@ type ForkChoicer interface {
 	AttestationProcessor // to track new attestation for fork choice.
 	Getter               // to retrieve fork choice information.
 	Setter               // to set fork choice information.
+	Snapshotter          // to persist and restore fork choice.
+}
+
+// Snapshotter serializes fork choice so that it can be restored after a restart.
+type Snapshotter interface {
+	Snapshot() ([]byte, error)
+	Restore([]byte) error
 }
 
 // RLocker represents forkchoice's internal RWMutex read-only lock/unlock methods.
@@ -57,6 +64,8 @@ type Getter interface {
 	AncestorRoot(ctx context.Context, root [32]byte, slot primitives.Slot) ([32]byte, error)
 	CommonAncestor(ctx context.Context, root1 [32]byte, root2 [32]byte) ([32]byte, primitives.Slot, error)
 	ForkChoiceDump(context.Context) (*forkchoice2.Dump, error)
+	NodeDump([32]byte) (*forkchoice2.Node, error)
+	PreviousProposerBoost() [fieldparams.RootLength]byte
 	Tips() ([][32]byte, []primitives.Slot)
 }
 
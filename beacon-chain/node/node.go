@@ -19,6 +19,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/access"
 	"github.com/prysmaticlabs/prysm/v5/api/server/httprest"
 	"github.com/prysmaticlabs/prysm/v5/api/server/middleware"
 	"github.com/prysmaticlabs/prysm/v5/async/event"
@@ -43,6 +44,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/events"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
@@ -50,6 +52,7 @@ import (
 	regularsync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/backfill"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/backfill/coverage"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/capture"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/checkpoint"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/genesis"
 	initialsync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/initial-sync"
@@ -96,10 +99,12 @@ type BeaconNode struct {
 	attestationPool         attestations.Pool
 	exitPool                voluntaryexits.PoolManager
 	slashingsPool           slashings.PoolManager
+	slashingArchive         *slashings.Archive
 	syncCommitteePool       synccommittee.Pool
 	blsToExecPool           blstoexec.PoolManager
 	depositCache            cache.DepositCache
 	trackedValidatorsCache  *cache.TrackedValidatorsCache
+	attInclusionCache       *cache.AttestationInclusionCache
 	payloadIDCache          *cache.PayloadIDCache
 	stateFeed               *event.Feed
 	blockFeed               *event.Feed
@@ -113,6 +118,7 @@ type BeaconNode struct {
 	GenesisInitializer      genesis.Initializer
 	CheckpointInitializer   checkpoint.Initializer
 	forkChoicer             forkchoice.ForkChoicer
+	forkchoiceRecorder      *doublylinkedtree.Recorder
 	clockWaiter             startup.ClockWaiter
 	BackfillOpts            []backfill.ServiceOption
 	initialSyncComplete     chan struct{}
@@ -150,6 +156,7 @@ func New(cliCtx *cli.Context, cancel context.CancelFunc, opts ...Option) (*Beaco
 		syncCommitteePool:       synccommittee.NewPool(),
 		blsToExecPool:           blstoexec.NewPool(),
 		trackedValidatorsCache:  cache.NewTrackedValidatorsCache(),
+		attInclusionCache:       cache.NewAttestationInclusionCache(primitives.Epoch(cliCtx.Uint64(flags.AttestationInclusionEpochs.Name))),
 		payloadIDCache:          cache.NewPayloadIDCache(),
 		slasherBlockHeadersFeed: new(event.Feed),
 		slasherAttestationsFeed: new(event.Feed),
@@ -166,7 +173,17 @@ func New(cliCtx *cli.Context, cancel context.CancelFunc, opts ...Option) (*Beaco
 
 	synchronizer := startup.NewClockSynchronizer()
 	beacon.clockWaiter = synchronizer
-	beacon.forkChoicer = doublylinkedtree.New()
+	fc := doublylinkedtree.New()
+	if recordFile := cliCtx.String(flags.ForkchoiceRecordFile.Name); recordFile != "" {
+		recorder, err := doublylinkedtree.NewRecorder(recordFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not open fork choice recording")
+		}
+		fc.SetRecorder(recorder)
+		beacon.forkchoiceRecorder = recorder
+		log.WithField("path", recordFile).Info("Recording fork choice inputs")
+	}
+	beacon.forkChoicer = fc
 
 	depositAddress, err := execution.DepositContractAddress()
 	if err != nil {
@@ -274,6 +291,7 @@ func startBaseServices(cliCtx *cli.Context, beacon *BeaconNode, depositAddress s
 		return nil, errors.Wrap(err, "could not start DB")
 	}
 	beacon.BlobStorage.WarmCache()
+	beacon.slashingArchive = slashings.NewArchive(beacon.db, beacon, primitives.Epoch(cliCtx.Uint64(flags.SlashingEvidenceRetention.Name)))
 
 	log.Debugln("Starting Slashing DB")
 	if err := beacon.startSlasherDB(cliCtx); err != nil {
@@ -440,6 +458,11 @@ func (b *BeaconNode) Close() {
 	if err := b.db.Close(); err != nil {
 		log.WithError(err).Error("Failed to close database")
 	}
+	if b.forkchoiceRecorder != nil {
+		if err := b.forkchoiceRecorder.Close(); err != nil {
+			log.WithError(err).Error("Failed to close fork choice recording")
+		}
+	}
 	b.collector.unregister()
 	b.cancel()
 	close(b.stop)
@@ -735,6 +758,7 @@ func (b *BeaconNode) registerBlockchainService(fc forkchoice.ForkChoicer, gs *st
 		blockchain.WithAttestationPool(b.attestationPool),
 		blockchain.WithExitPool(b.exitPool),
 		blockchain.WithSlashingPool(b.slashingsPool),
+		blockchain.WithSlashingArchive(b.slashingArchive),
 		blockchain.WithBLSToExecPool(b.blsToExecPool),
 		blockchain.WithP2PBroadcaster(b.fetchP2P()),
 		blockchain.WithStateNotifier(b),
@@ -746,8 +770,11 @@ func (b *BeaconNode) registerBlockchainService(fc forkchoice.ForkChoicer, gs *st
 		blockchain.WithSyncComplete(syncComplete),
 		blockchain.WithBlobStorage(b.BlobStorage),
 		blockchain.WithTrackedValidatorsCache(b.trackedValidatorsCache),
+		blockchain.WithAttestationInclusionCache(b.attInclusionCache),
 		blockchain.WithPayloadIDCache(b.payloadIDCache),
 		blockchain.WithSyncChecker(b.syncChecker),
+		blockchain.WithForkchoiceSnapshotInterval(b.cliCtx.Duration(flags.ForkchoiceSnapshotInterval.Name)),
+		blockchain.WithReorgHistoryWindow(primitives.Epoch(b.cliCtx.Uint64(flags.ReorgHistoryWindow.Name))),
 	)
 
 	blockchainService, err := blockchain.NewService(b.ctx, opts...)
@@ -807,8 +834,7 @@ func (b *BeaconNode) registerSyncService(initialSyncComplete chan struct{}, bFil
 		return err
 	}
 
-	rs := regularsync.NewService(
-		b.ctx,
+	opts := []regularsync.Option{
 		regularsync.WithDatabase(b.db),
 		regularsync.WithP2P(b.fetchP2P()),
 		regularsync.WithChainService(chainService),
@@ -819,6 +845,7 @@ func (b *BeaconNode) registerSyncService(initialSyncComplete chan struct{}, bFil
 		regularsync.WithAttestationPool(b.attestationPool),
 		regularsync.WithExitPool(b.exitPool),
 		regularsync.WithSlashingPool(b.slashingsPool),
+		regularsync.WithSlashingArchive(b.slashingArchive),
 		regularsync.WithSyncCommsPool(b.syncCommitteePool),
 		regularsync.WithBlsToExecPool(b.blsToExecPool),
 		regularsync.WithStateGen(b.stateGen),
@@ -831,7 +858,21 @@ func (b *BeaconNode) registerSyncService(initialSyncComplete chan struct{}, bFil
 		regularsync.WithBlobStorage(b.BlobStorage),
 		regularsync.WithVerifierWaiter(b.verifyInitWaiter),
 		regularsync.WithAvailableBlocker(bFillStore),
-	)
+	}
+	if dir := b.cliCtx.String(flags.GossipCaptureDir.Name); dir != "" {
+		w, err := capture.NewWriter(
+			dir,
+			capture.WithTopics(b.cliCtx.StringSlice(flags.GossipCaptureTopics.Name)),
+			capture.WithMaxFileSize(int64(b.cliCtx.Uint64(flags.GossipCaptureMaxFileSize.Name))<<20),
+			capture.WithMaxFiles(b.cliCtx.Int(flags.GossipCaptureMaxFiles.Name)),
+		)
+		if err != nil {
+			return errors.Wrap(err, "could not start gossip capture")
+		}
+		log.WithField("dir", dir).Info("Capturing gossip messages")
+		opts = append(opts, regularsync.WithGossipCapture(w))
+	}
+	rs := regularsync.NewService(b.ctx, opts...)
 	return b.services.RegisterService(rs)
 }
 
@@ -879,9 +920,12 @@ func (b *BeaconNode) registerSlasherService() error {
 		AttestationStateFetcher: chainService,
 		StateGen:                b.stateGen,
 		SlashingPoolInserter:    b.slashingsPool,
+		SlashingArchive:         b.slashingArchive,
 		SyncChecker:             syncService,
 		HeadStateFetcher:        chainService,
 		ClockWaiter:             b.clockWaiter,
+		BeaconDatabase:          b.db,
+		RescanEpochs:            primitives.Epoch(b.cliCtx.Uint64(flags.SlasherRescanEpochs.Name)),
 	})
 	if err != nil {
 		return err
@@ -925,10 +969,20 @@ func (b *BeaconNode) registerRPCService(router *http.ServeMux) error {
 	maxMsgSize := b.cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name)
 	enableDebugRPCEndpoints := !b.cliCtx.Bool(flags.DisableDebugRPCEndpoints.Name)
 
+	var eventLog *events.EventLog
+	if depth := b.cliCtx.Int(flags.EventReplayDepth.Name); depth > 0 {
+		var err error
+		eventLog, err = events.NewEventLog(depth, b.cliCtx.String(flags.EventReplayFile.Name))
+		if err != nil {
+			return errors.Wrap(err, "could not create event log")
+		}
+	}
+
 	p2pService := b.fetchP2P()
 	rpcService := rpc.NewService(b.ctx, &rpc.Config{
 		ExecutionEngineCaller:     web3Service,
 		ExecutionReconstructor:    web3Service,
+		ExecutionReceiptsFetcher:  web3Service,
 		Host:                      host,
 		Port:                      port,
 		BeaconMonitoringHost:      beaconMonitoringHost,
@@ -952,13 +1006,16 @@ func (b *BeaconNode) registerRPCService(router *http.ServeMux) error {
 		GenesisTimeFetcher:        chainService,
 		GenesisFetcher:            chainService,
 		OptimisticModeFetcher:     chainService,
+		PayloadDisputeFetcher:     chainService,
 		AttestationsPool:          b.attestationPool,
 		ExitPool:                  b.exitPool,
 		SlashingsPool:             b.slashingsPool,
+		SlashingArchive:           b.slashingArchive,
 		BLSChangesPool:            b.blsToExecPool,
 		SyncCommitteeObjectPool:   b.syncCommitteePool,
 		ExecutionChainService:     web3Service,
 		ExecutionChainInfoFetcher: web3Service,
+		ExecutionClientsFetcher:   web3Service,
 		ChainStartFetcher:         chainStartFetcher,
 		MockEth1Votes:             mockEth1DataVotes,
 		SyncService:               syncService,
@@ -975,7 +1032,13 @@ func (b *BeaconNode) registerRPCService(router *http.ServeMux) error {
 		ClockWaiter:               b.clockWaiter,
 		BlobStorage:               b.BlobStorage,
 		TrackedValidatorsCache:    b.trackedValidatorsCache,
+		AttestationInclusionCache: b.attInclusionCache,
 		PayloadIDCache:            b.payloadIDCache,
+		EventLog:                  eventLog,
+		MaxValidatorHistoryEpochs: primitives.Epoch(b.cliCtx.Uint64(flags.ValidatorHistoryMaxEpochs.Name)),
+		EnableGraphQL:             b.cliCtx.Bool(flags.GraphQL.Name),
+		GraphQLMaxDepth:           b.cliCtx.Int(flags.GraphQLMaxDepth.Name),
+		GraphQLMaxCost:            b.cliCtx.Uint64(flags.GraphQLMaxCost.Name),
 	})
 
 	return b.services.RegisterService(rpcService)
@@ -1019,6 +1082,15 @@ func (b *BeaconNode) registerHTTPService(router *http.ServeMux) error {
 		middleware.NormalizeQueryValuesHandler,
 		middleware.CorsHandler(allowedOrigins),
 	}
+	accessConfig := b.cliCtx.String(flags.HTTPAccessConfig.Name)
+	auditLogFile := b.cliCtx.String(flags.HTTPAuditLogFile.Name)
+	if accessConfig != "" || auditLogFile != "" {
+		controller, err := access.NewController(b.ctx, accessConfig, auditLogFile)
+		if err != nil {
+			return errors.Wrap(err, "could not set up HTTP API access control")
+		}
+		middlewares = append(middlewares, controller.Middleware)
+	}
 
 	opts := []httprest.Option{
 		httprest.WithRouter(router),
@@ -0,0 +1,149 @@
+package slashings
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/slashing"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// ArchiveDatabase is the storage used by the slashing archive.
+type ArchiveDatabase interface {
+	SlashingEvidence(ctx context.Context, epoch primitives.Epoch, root [32]byte) (*slashing.Evidence, error)
+	SaveSlashingEvidence(ctx context.Context, e *slashing.Evidence) error
+	DeleteSlashingEvidenceBefore(ctx context.Context, epoch primitives.Epoch) error
+}
+
+// Archive keeps a persistent record of every slashing detected by the slasher, received over
+// gossip or the API, or included in a canonical block. A slashing is recorded once with the source
+// it was first seen from, and updated when it gets included. Every saved record is sent to the
+// operation feed. Evidence is kept for the retention number of epochs after its offense epoch, and
+// forever when the retention is zero.
+type Archive struct {
+	db          ArchiveDatabase
+	notifier    operation.Notifier
+	retention   primitives.Epoch
+	lock        sync.Mutex
+	prunedEpoch primitives.Epoch
+}
+
+// NewArchive returns a slashing archive that saves evidence to db, notifies notifier and keeps
+// evidence for retention epochs.
+func NewArchive(db ArchiveDatabase, notifier operation.Notifier, retention primitives.Epoch) *Archive {
+	return &Archive{db: db, notifier: notifier, retention: retention}
+}
+
+// RecordAttesterSlashing records an attester slashing seen from the given source.
+func (a *Archive) RecordAttesterSlashing(ctx context.Context, s ethpb.AttSlashing, source slashing.Source) {
+	e, err := slashing.NewAttesterSlashingEvidence(s, source, uint64(prysmTime.Now().Unix()))
+	if err == nil {
+		err = a.record(ctx, e)
+	}
+	if err != nil {
+		log.WithError(err).WithField("source", source).Error("Could not record attester slashing evidence")
+	}
+}
+
+// RecordProposerSlashing records a proposer slashing seen from the given source.
+func (a *Archive) RecordProposerSlashing(ctx context.Context, s *ethpb.ProposerSlashing, source slashing.Source) {
+	e, err := slashing.NewProposerSlashingEvidence(s, source, uint64(prysmTime.Now().Unix()))
+	if err == nil {
+		err = a.record(ctx, e)
+	}
+	if err != nil {
+		log.WithError(err).WithField("source", source).Error("Could not record proposer slashing evidence")
+	}
+}
+
+// RecordIncludedSlashings records the slashings included in a canonical block, and prunes the
+// evidence that fell out of the retention period once per epoch of the blocks.
+func (a *Archive) RecordIncludedSlashings(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock) {
+	a.prune(ctx, slots.ToEpoch(blk.Block().Slot()))
+	now := uint64(prysmTime.Now().Unix())
+	evidence := make([]*slashing.Evidence, 0)
+	for _, s := range blk.Block().Body().AttesterSlashings() {
+		e, err := slashing.NewAttesterSlashingEvidence(s, slashing.SourceBlock, now)
+		if err != nil {
+			log.WithError(err).Error("Could not record included attester slashing evidence")
+			continue
+		}
+		evidence = append(evidence, e)
+	}
+	for _, s := range blk.Block().Body().ProposerSlashings() {
+		e, err := slashing.NewProposerSlashingEvidence(s, slashing.SourceBlock, now)
+		if err != nil {
+			log.WithError(err).Error("Could not record included proposer slashing evidence")
+			continue
+		}
+		evidence = append(evidence, e)
+	}
+	for _, e := range evidence {
+		e.Included = true
+		e.InclusionSlot = blk.Block().Slot()
+		if err := a.record(ctx, e); err != nil {
+			log.WithError(err).WithField("slot", e.InclusionSlot).Error("Could not record included slashing evidence")
+		}
+	}
+}
+
+// prune deletes the evidence whose offense epoch is more than the retention number of epochs before
+// the given epoch. It only runs once per epoch.
+func (a *Archive) prune(ctx context.Context, epoch primitives.Epoch) {
+	if a.retention == 0 || epoch <= a.retention {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if epoch <= a.prunedEpoch {
+		return
+	}
+	if err := a.db.DeleteSlashingEvidenceBefore(ctx, epoch-a.retention); err != nil {
+		log.WithError(err).WithField("epoch", epoch-a.retention).Error("Could not prune slashing evidence")
+		return
+	}
+	a.prunedEpoch = epoch
+}
+
+// record saves new evidence, or marks the saved evidence of the same slashing as included. Nothing
+// is saved when the slashing was already recorded with the same inclusion status.
+func (a *Archive) record(ctx context.Context, e *slashing.Evidence) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	existing, err := a.db.SlashingEvidence(ctx, e.Epoch, e.Root)
+	if err != nil {
+		return errors.Wrap(err, "could not get saved slashing evidence")
+	}
+	if existing != nil {
+		if existing.Included || !e.Included {
+			return nil
+		}
+		existing.Included = true
+		existing.InclusionSlot = e.InclusionSlot
+		e = existing
+	}
+	if err := a.db.SaveSlashingEvidence(ctx, e); err != nil {
+		return errors.Wrap(err, "could not save slashing evidence")
+	}
+	slashingEvidenceRecorded.WithLabelValues(e.Source.String()).Inc()
+	log.WithFields(logrus.Fields{
+		"root":             e.Root,
+		"epoch":            e.Epoch,
+		"validatorIndices": e.ValidatorIndices,
+		"source":           e.Source,
+		"included":         e.Included,
+	}).Debug("Recorded slashing evidence")
+	a.notifier.OperationFeed().Send(&feed.Event{
+		Type: operation.SlashingEvidenceRecorded,
+		Data: &operation.SlashingEvidenceRecordedData{Evidence: e},
+	})
+	return nil
+}
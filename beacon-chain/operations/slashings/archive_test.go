@@ -0,0 +1,125 @@
+package slashings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/slashing"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type feedNotifier struct {
+	feed *event.Feed
+}
+
+func (n *feedNotifier) OperationFeed() event.SubscriberSender {
+	return n.feed
+}
+
+func TestArchive(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.SetupDB(t)
+	notifier := &feedNotifier{feed: new(event.Feed)}
+	events := make(chan *feed.Event, 10)
+	sub := notifier.feed.Subscribe(events)
+	defer sub.Unsubscribe()
+	a := NewArchive(db, notifier, 0)
+
+	att1 := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{3, 4}})
+	att2 := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{4}})
+	att2.Data.BeaconBlockRoot = bytesutil.PadTo([]byte{'b'}, 32)
+	attesterSlashing := &ethpb.AttesterSlashing{Attestation_1: att1, Attestation_2: att2}
+	proposerSlashing := &ethpb.ProposerSlashing{
+		Header_1: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: 1, ProposerIndex: 2}}),
+		Header_2: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: 1, ProposerIndex: 2, BodyRoot: bytesutil.PadTo([]byte{'b'}, 32)}}),
+	}
+
+	received := func() *slashing.Evidence {
+		e := <-events
+		require.Equal(t, feed.EventType(operation.SlashingEvidenceRecorded), e.Type)
+		return e.Data.(*operation.SlashingEvidenceRecordedData).Evidence
+	}
+
+	a.RecordAttesterSlashing(ctx, attesterSlashing, slashing.SourceSlasher)
+	e := received()
+	require.Equal(t, slashing.SourceSlasher, e.Source)
+	require.DeepEqual(t, []primitives.ValidatorIndex{4}, e.ValidatorIndices)
+	// A slashing seen again from another source is not recorded twice.
+	a.RecordAttesterSlashing(ctx, attesterSlashing, slashing.SourceGossip)
+	a.RecordProposerSlashing(ctx, proposerSlashing, slashing.SourceGossip)
+	e = received()
+	require.Equal(t, slashing.SourceGossip, e.Source)
+	require.Equal(t, primitives.ValidatorIndex(2), e.ValidatorIndices[0])
+
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 40
+	b.Block.Body.AttesterSlashings = []*ethpb.AttesterSlashing{attesterSlashing}
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	a.RecordIncludedSlashings(ctx, blk)
+	e = received()
+	require.Equal(t, slashing.SourceSlasher, e.Source)
+	require.Equal(t, true, e.Included)
+	require.Equal(t, primitives.Slot(40), e.InclusionSlot)
+	// The inclusion is only recorded once.
+	a.RecordIncludedSlashings(ctx, blk)
+	require.Equal(t, 0, len(events))
+
+	saved, err := db.SlashingEvidenceSince(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(saved))
+}
+
+func TestArchive_Prune(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.SetupDB(t)
+	a := NewArchive(db, &feedNotifier{feed: new(event.Feed)}, 10)
+
+	header := func(slot primitives.Slot) *ethpb.SignedBeaconBlockHeader {
+		return util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: slot, ProposerIndex: 2}})
+	}
+	for _, epoch := range []primitives.Epoch{1, 5} {
+		slot := primitives.Slot(uint64(epoch) * uint64(params.BeaconConfig().SlotsPerEpoch))
+		second := header(slot)
+		second.Header.BodyRoot = bytesutil.PadTo([]byte{'b'}, 32)
+		a.RecordProposerSlashing(ctx, &ethpb.ProposerSlashing{Header_1: header(slot), Header_2: second}, slashing.SourceGossip)
+	}
+	saved := func() []*slashing.Evidence {
+		evidence, err := db.SlashingEvidenceSince(ctx, 0)
+		require.NoError(t, err)
+		return evidence
+	}
+	require.Equal(t, 2, len(saved()))
+
+	included := func(epoch primitives.Epoch) interfaces.ReadOnlySignedBeaconBlock {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = primitives.Slot(uint64(epoch) * uint64(params.BeaconConfig().SlotsPerEpoch))
+		blk, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		return blk
+	}
+	// Evidence is kept for 10 epochs after its offense epoch.
+	a.RecordIncludedSlashings(ctx, included(11))
+	require.Equal(t, 2, len(saved()))
+	a.RecordIncludedSlashings(ctx, included(12))
+	evidence := saved()
+	require.Equal(t, 1, len(evidence))
+	require.Equal(t, primitives.Epoch(5), evidence[0].Epoch)
+	// Evidence recorded again for the same epoch is not pruned twice.
+	a.RecordProposerSlashing(ctx, &ethpb.ProposerSlashing{Header_1: header(0), Header_2: util.HydrateSignedBeaconHeader(
+		&ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{ProposerIndex: 2, BodyRoot: bytesutil.PadTo([]byte{'c'}, 32)}},
+	)}, slashing.SourceGossip)
+	a.RecordIncludedSlashings(ctx, included(12))
+	require.Equal(t, 2, len(saved()))
+}
@@ -30,4 +30,11 @@ var (
 			Help: "Number of proposer slashings included in blocks",
 		},
 	)
+	slashingEvidenceRecorded = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "slashing_evidence_recorded_total",
+			Help: "Number of slashing evidence records saved, by the source the slashing was first seen from",
+		},
+		[]string{"source"},
+	)
 )
@@ -44,7 +44,7 @@ const (
 
 // TestP2P represents a p2p implementation that can be used for testing.
 type TestP2P struct {
-	t               *testing.T
+	t               testing.TB
 	BHost           host.Host
 	EnodeID         enode.ID
 	pubsub          *pubsub.PubSub
@@ -57,7 +57,7 @@ type TestP2P struct {
 }
 
 // NewTestP2P initializes a new p2p test service.
-func NewTestP2P(t *testing.T, userOptions ...config.Option) *TestP2P {
+func NewTestP2P(t testing.TB, userOptions ...config.Option) *TestP2P {
 	ctx := context.Background()
 	options := []config.Option{
 		libp2p.ResourceManager(&network.NullResourceManager{}),
@@ -26,4 +26,5 @@ type Service struct {
 	P2P                   p2p.Broadcaster
 	ReplayerBuilder       stategen.ReplayerBuilder
 	OptimisticModeFetcher blockchain.OptimisticModeFetcher
+	PayloadDisputeFetcher blockchain.PayloadDisputeFetcher
 }
@@ -36,6 +36,8 @@ import (
 
 var errOptimisticMode = errors.New("the node is currently optimistic and cannot serve validators")
 
+var errDisputedBranch = errors.New("the head block descends from an execution payload disputed by the verification execution client")
+
 // AggregateBroadcastFailedError represents an error scenario where
 // broadcasting an aggregate selection proof failed.
 type AggregateBroadcastFailedError struct {
@@ -494,6 +496,9 @@ func (s *Service) GetAttestationData(
 	res := s.AttestationCache.Get()
 	if res != nil && res.Slot == req.Slot {
 		s.AttestationCache.RUnlock()
+		if rpcErr := s.CheckDisputedBranch(ctx, res.HeadRoot); rpcErr != nil {
+			return nil, rpcErr
+		}
 		return &ethpb.AttestationData{
 			Slot:            res.Slot,
 			CommitteeIndex:  committeeIndex,
@@ -518,6 +523,9 @@ func (s *Service) GetAttestationData(
 	// to acquire the lock.
 	res = s.AttestationCache.Get()
 	if res != nil && res.Slot == req.Slot {
+		if rpcErr := s.CheckDisputedBranch(ctx, res.HeadRoot); rpcErr != nil {
+			return nil, rpcErr
+		}
 		return &ethpb.AttestationData{
 			Slot:            res.Slot,
 			CommitteeIndex:  committeeIndex,
@@ -545,6 +553,9 @@ func (s *Service) GetAttestationData(
 	if err != nil {
 		return nil, &RpcError{Reason: Internal, Err: errors.Wrap(err, "could not get head root")}
 	}
+	if rpcErr := s.CheckDisputedBranch(ctx, headRoot); rpcErr != nil {
+		return nil, rpcErr
+	}
 	targetEpoch := slots.ToEpoch(req.Slot)
 	targetRoot, err := s.HeadFetcher.TargetRootForEpoch(bytesutil.ToBytes32(headRoot), targetEpoch)
 	if err != nil {
@@ -593,6 +604,23 @@ func (s *Service) GetAttestationData(
 	}, nil
 }
 
+// CheckDisputedBranch returns an error if the block descends from an execution payload disputed
+// by the verification execution client, in which case validators must not attest to it. The duty is
+// also refused when the dispute status cannot be determined.
+func (s *Service) CheckDisputedBranch(ctx context.Context, root []byte) *RpcError {
+	if s.PayloadDisputeFetcher == nil {
+		return nil
+	}
+	disputed, err := s.PayloadDisputeFetcher.IsDisputedBranch(ctx, bytesutil.ToBytes32(root))
+	if err != nil {
+		return &RpcError{Reason: Unavailable, Err: errors.Wrap(err, "could not determine if block is in a disputed branch")}
+	}
+	if disputed {
+		return &RpcError{Reason: Unavailable, Err: errDisputedBranch}
+	}
+	return nil
+}
+
 // SubmitSyncMessage submits the sync committee message to the network.
 // It also saves the sync committee message into the pending pool for block inclusion.
 func (s *Service) SubmitSyncMessage(ctx context.Context, msg *ethpb.SyncCommitteeMessage) *RpcError {
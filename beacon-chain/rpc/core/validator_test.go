@@ -1,10 +1,13 @@
 package core
 
 import (
+	"context"
 	"encoding/binary"
+	"errors"
 	"testing"
 	"time"
 
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/validator"
@@ -63,3 +66,32 @@ func pubKey(i uint64) []byte {
 	binary.LittleEndian.PutUint64(pubKey, i)
 	return pubKey
 }
+
+type erroringDisputeFetcher struct{}
+
+func (erroringDisputeFetcher) IsDisputedBranch(context.Context, [32]byte) (bool, error) {
+	return false, errors.New("bad")
+}
+
+func TestCheckDisputedBranch(t *testing.T) {
+	ctx := context.Background()
+	root := make([]byte, 32)
+
+	s := &Service{}
+	require.Equal(t, (*RpcError)(nil), s.CheckDisputedBranch(ctx, root))
+
+	s.PayloadDisputeFetcher = &mock.ChainService{DisputedBranch: false}
+	require.Equal(t, (*RpcError)(nil), s.CheckDisputedBranch(ctx, root))
+
+	s.PayloadDisputeFetcher = &mock.ChainService{DisputedBranch: true}
+	rpcErr := s.CheckDisputedBranch(ctx, root)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, ErrorReason(Unavailable), rpcErr.Reason)
+	assert.ErrorContains(t, errDisputedBranch.Error(), rpcErr.Err)
+
+	s.PayloadDisputeFetcher = erroringDisputeFetcher{}
+	rpcErr = s.CheckDisputedBranch(ctx, root)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, ErrorReason(Unavailable), rpcErr.Reason)
+	assert.ErrorContains(t, "could not determine if block is in a disputed branch", rpcErr.Err)
+}
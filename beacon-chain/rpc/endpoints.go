@@ -18,9 +18,11 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/node"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/rewards"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/validator"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/graphql"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
 	beaconprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/beacon"
 	nodeprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/node"
+	slasherprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/slasher"
 	validatorv1alpha1 "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/v1alpha1/validator"
 	validatorprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/validator"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
@@ -97,12 +99,16 @@ func (s *Service) endpoints(
 	endpoints = append(endpoints, s.configEndpoints()...)
 	endpoints = append(endpoints, s.lightClientEndpoints(blocker, stater)...)
 	endpoints = append(endpoints, s.eventsEndpoints()...)
-	endpoints = append(endpoints, s.prysmBeaconEndpoints(ch, stater, coreService)...)
+	endpoints = append(endpoints, s.prysmBeaconEndpoints(ch, stater, blocker, coreService)...)
 	endpoints = append(endpoints, s.prysmNodeEndpoints()...)
-	endpoints = append(endpoints, s.prysmValidatorEndpoints(stater, coreService)...)
+	endpoints = append(endpoints, s.prysmValidatorEndpoints(stater, coreService, rewardFetcher)...)
+	endpoints = append(endpoints, s.prysmSlasherEndpoints()...)
 	if enableDebug {
 		endpoints = append(endpoints, s.debugEndpoints(stater)...)
 	}
+	if s.cfg.EnableGraphQL {
+		endpoints = append(endpoints, s.graphqlEndpoints(blocker, stater)...)
+	}
 	return endpoints
 }
 
@@ -510,6 +516,7 @@ func (s *Service) beaconEndpoints(
 		BeaconDB:                s.cfg.BeaconDB,
 		AttestationsPool:        s.cfg.AttestationsPool,
 		SlashingsPool:           s.cfg.SlashingsPool,
+		SlashingArchive:         s.cfg.SlashingArchive,
 		ChainInfoFetcher:        s.cfg.ChainInfoFetcher,
 		GenesisTimeFetcher:      s.cfg.GenesisTimeFetcher,
 		BlockNotifier:           s.cfg.BlockNotifier,
@@ -579,6 +586,33 @@ func (s *Service) beaconEndpoints(
 			handler: server.GetRandao,
 			methods: []string{http.MethodGet},
 		},
+		{
+			template: "/eth/v1/beacon/states/{state_id}/pending_deposits",
+			name:     namespace + ".GetPendingDeposits",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
+			},
+			handler: server.GetPendingDeposits,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/eth/v1/beacon/states/{state_id}/pending_partial_withdrawals",
+			name:     namespace + ".GetPendingPartialWithdrawals",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
+			},
+			handler: server.GetPendingPartialWithdrawals,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/eth/v1/beacon/states/{state_id}/pending_consolidations",
+			name:     namespace + ".GetPendingConsolidations",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
+			},
+			handler: server.GetPendingConsolidations,
+			methods: []string{http.MethodGet},
+		},
 		{
 			template: "/eth/v1/beacon/blocks",
 			name:     namespace + ".PublishBlock",
@@ -641,7 +675,7 @@ func (s *Service) beaconEndpoints(
 			template: "/eth/v2/beacon/blocks/{block_id}/attestations",
 			name:     namespace + ".GetBlockAttestationsV2",
 			middleware: []middleware.Middleware{
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.GetBlockAttestationsV2,
 			methods: []string{http.MethodGet},
@@ -677,7 +711,7 @@ func (s *Service) beaconEndpoints(
 			template: "/eth/v2/beacon/pool/attestations",
 			name:     namespace + ".ListAttestationsV2",
 			middleware: []middleware.Middleware{
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.ListAttestationsV2,
 			methods: []string{http.MethodGet},
@@ -706,7 +740,7 @@ func (s *Service) beaconEndpoints(
 			template: "/eth/v1/beacon/pool/voluntary_exits",
 			name:     namespace + ".ListVoluntaryExits",
 			middleware: []middleware.Middleware{
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.ListVoluntaryExits,
 			methods: []string{http.MethodGet},
@@ -735,7 +769,7 @@ func (s *Service) beaconEndpoints(
 			template: "/eth/v1/beacon/pool/bls_to_execution_changes",
 			name:     namespace + ".ListBLSToExecutionChanges",
 			middleware: []middleware.Middleware{
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.ListBLSToExecutionChanges,
 			methods: []string{http.MethodGet},
@@ -763,7 +797,7 @@ func (s *Service) beaconEndpoints(
 			template: "/eth/v2/beacon/pool/attester_slashings",
 			name:     namespace + ".GetAttesterSlashingsV2",
 			middleware: []middleware.Middleware{
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.GetAttesterSlashingsV2,
 			methods: []string{http.MethodGet},
@@ -792,7 +826,7 @@ func (s *Service) beaconEndpoints(
 			template: "/eth/v1/beacon/pool/proposer_slashings",
 			name:     namespace + ".GetProposerSlashings",
 			middleware: []middleware.Middleware{
-				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.OctetStreamMediaType}),
 			},
 			handler: server.GetProposerSlashings,
 			methods: []string{http.MethodGet},
@@ -1008,6 +1042,15 @@ func (s *Service) debugEndpoints(stater lookup.Stater) []endpoint {
 			handler: server.GetForkChoice,
 			methods: []string{http.MethodGet},
 		},
+		{
+			template: "/prysm/v1/debug/states/diff",
+			name:     namespace + ".GetStateDiff",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetStateDiff,
+			methods: []string{http.MethodGet},
+		},
 	}
 }
 
@@ -1018,6 +1061,10 @@ func (s *Service) eventsEndpoints() []endpoint {
 		HeadFetcher:            s.cfg.HeadFetcher,
 		ChainInfoFetcher:       s.cfg.ChainInfoFetcher,
 		TrackedValidatorsCache: s.cfg.TrackedValidatorsCache,
+		EventLog:               s.cfg.EventLog,
+	}
+	if server.EventLog != nil {
+		go server.RecordEvents(s.ctx)
 	}
 
 	const namespace = "events"
@@ -1038,6 +1085,7 @@ func (s *Service) eventsEndpoints() []endpoint {
 func (s *Service) prysmBeaconEndpoints(
 	ch *stategen.CanonicalHistory,
 	stater lookup.Stater,
+	blocker lookup.Blocker,
 	coreService *core.Service,
 ) []endpoint {
 	server := &beaconprysm.Server{
@@ -1047,12 +1095,14 @@ func (s *Service) prysmBeaconEndpoints(
 		OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
 		CanonicalHistory:      ch,
 		BeaconDB:              s.cfg.BeaconDB,
+		Blocker:               blocker,
 		Stater:                stater,
 		ChainInfoFetcher:      s.cfg.ChainInfoFetcher,
 		FinalizationFetcher:   s.cfg.FinalizationFetcher,
 		CoreService:           coreService,
 		Broadcaster:           s.cfg.Broadcaster,
 		BlobReceiver:          s.cfg.BlobReceiver,
+		AttInclusionCache:     s.cfg.AttestationInclusionCache,
 	}
 
 	const namespace = "prysm.beacon"
@@ -1084,6 +1134,33 @@ func (s *Service) prysmBeaconEndpoints(
 			handler: server.GetValidatorCount,
 			methods: []string{http.MethodGet},
 		},
+		{
+			template: "/prysm/v1/beacon/states/{state_id}/pending_deposit_epochs",
+			name:     namespace + ".GetPendingDepositEpochs",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetPendingDepositEpochs,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/beacon/states/{state_id}/proof",
+			name:     namespace + ".GetStateProof",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetStateProof,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/beacon/blocks/{block_id}/proof",
+			name:     namespace + ".GetBlockProof",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetBlockProof,
+			methods: []string{http.MethodGet},
+		},
 		{
 			template: "/prysm/v1/beacon/individual_votes",
 			name:     namespace + ".GetIndividualVotes",
@@ -1103,6 +1180,24 @@ func (s *Service) prysmBeaconEndpoints(
 			handler: server.GetChainHead,
 			methods: []string{http.MethodGet},
 		},
+		{
+			template: "/prysm/v1/beacon/reorgs",
+			name:     namespace + ".GetReorgs",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetReorgs,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/beacon/attestations/inclusion",
+			name:     namespace + ".GetAttestationInclusion",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetAttestationInclusion,
+			methods: []string{http.MethodGet},
+		},
 		{
 			template: "/prysm/v1/beacon/blobs",
 			name:     namespace + ".PublishBlobs",
@@ -1127,6 +1222,7 @@ func (s *Service) prysmNodeEndpoints() []endpoint {
 		MetadataProvider:          s.cfg.MetadataProvider,
 		HeadFetcher:               s.cfg.HeadFetcher,
 		ExecutionChainInfoFetcher: s.cfg.ExecutionChainInfoFetcher,
+		ExecutionClientsFetcher:   s.cfg.ExecutionClientsFetcher,
 	}
 
 	const namespace = "prysm.node"
@@ -1187,14 +1283,32 @@ func (s *Service) prysmNodeEndpoints() []endpoint {
 			handler: server.RemoveTrustedPeer,
 			methods: []string{http.MethodDelete},
 		},
+		{
+			template: "/prysm/v1/node/execution_clients",
+			name:     namespace + ".ListExecutionClients",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.ListExecutionClients,
+			methods: []string{http.MethodGet},
+		},
 	}
 }
 
-func (s *Service) prysmValidatorEndpoints(stater lookup.Stater, coreService *core.Service) []endpoint {
+func (s *Service) prysmValidatorEndpoints(stater lookup.Stater, coreService *core.Service, rewardFetcher rewards.BlockRewardsFetcher) []endpoint {
 	server := &validatorprysm.Server{
-		ChainInfoFetcher: s.cfg.ChainInfoFetcher,
-		Stater:           stater,
-		CoreService:      coreService,
+		BeaconDB:               s.cfg.BeaconDB,
+		CanonicalFetcher:       s.cfg.CanonicalFetcher,
+		FinalizationFetcher:    s.cfg.FinalizationFetcher,
+		ChainInfoFetcher:       s.cfg.ChainInfoFetcher,
+		Stater:                 stater,
+		CoreService:            coreService,
+		TimeFetcher:            s.cfg.GenesisTimeFetcher,
+		OptimisticModeFetcher:  s.cfg.OptimisticModeFetcher,
+		BlockRewardFetcher:     rewardFetcher,
+		ExecutionReconstructor: s.cfg.ExecutionReconstructor,
+		ReceiptsFetcher:        s.cfg.ExecutionReceiptsFetcher,
+		MaxHistoryEpochs:       s.cfg.MaxValidatorHistoryEpochs,
 	}
 
 	const namespace = "prysm.validator"
@@ -1237,5 +1351,77 @@ func (s *Service) prysmValidatorEndpoints(stater lookup.Stater, coreService *cor
 			handler: server.GetActiveSetChanges,
 			methods: []string{http.MethodGet},
 		},
+		{
+			template: "/prysm/v1/validators/lifecycle",
+			name:     namespace + ".GetLifecycles",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetLifecycles,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/validators/history",
+			name:     namespace + ".GetHistory",
+			middleware: []middleware.Middleware{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType, api.JsonLinesMediaType, api.OctetStreamMediaType}),
+			},
+			handler: server.GetHistory,
+			methods: []string{http.MethodPost},
+		},
+		{
+			template: "/prysm/v1/validators/rewards",
+			name:     namespace + ".GetRewards",
+			middleware: []middleware.Middleware{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetRewards,
+			methods: []string{http.MethodPost},
+		},
+	}
+}
+
+func (s *Service) prysmSlasherEndpoints() []endpoint {
+	server := &slasherprysm.Server{
+		BeaconDB: s.cfg.BeaconDB,
+	}
+
+	const namespace = "prysm.slasher"
+	return []endpoint{
+		{
+			template: "/prysm/v1/slasher/slashings",
+			name:     namespace + ".GetSlashings",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetSlashings,
+			methods: []string{http.MethodGet},
+		},
+	}
+}
+
+func (s *Service) graphqlEndpoints(blocker lookup.Blocker, stater lookup.Stater) []endpoint {
+	handler := graphql.NewHandler(&graphql.Config{
+		Blocker:                blocker,
+		Stater:                 stater,
+		ForkchoiceFetcher:      s.cfg.ForkchoiceFetcher,
+		ExecutionReconstructor: s.cfg.ExecutionReconstructor,
+		MaxDepth:               s.cfg.GraphQLMaxDepth,
+		MaxCost:                s.cfg.GraphQLMaxCost,
+	})
+
+	return []endpoint{
+		{
+			template: "/graphql",
+			name:     "graphql.Query",
+			middleware: []middleware.Middleware{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: handler.ServeHTTP,
+			methods: []string{http.MethodPost},
+		},
 	}
 }
@@ -17,38 +17,41 @@ func Test_endpoints(t *testing.T) {
 	}
 
 	beaconRoutes := map[string][]string{
-		"/eth/v1/beacon/genesis":                                     {http.MethodGet},
-		"/eth/v1/beacon/states/{state_id}/root":                      {http.MethodGet},
-		"/eth/v1/beacon/states/{state_id}/fork":                      {http.MethodGet},
-		"/eth/v1/beacon/states/{state_id}/finality_checkpoints":      {http.MethodGet},
-		"/eth/v1/beacon/states/{state_id}/validators":                {http.MethodGet, http.MethodPost},
-		"/eth/v1/beacon/states/{state_id}/validators/{validator_id}": {http.MethodGet},
-		"/eth/v1/beacon/states/{state_id}/validator_balances":        {http.MethodGet, http.MethodPost},
-		"/eth/v1/beacon/states/{state_id}/committees":                {http.MethodGet},
-		"/eth/v1/beacon/states/{state_id}/sync_committees":           {http.MethodGet},
-		"/eth/v1/beacon/states/{state_id}/randao":                    {http.MethodGet},
-		"/eth/v1/beacon/headers":                                     {http.MethodGet},
-		"/eth/v1/beacon/headers/{block_id}":                          {http.MethodGet},
-		"/eth/v1/beacon/blinded_blocks":                              {http.MethodPost},
-		"/eth/v2/beacon/blinded_blocks":                              {http.MethodPost},
-		"/eth/v1/beacon/blocks":                                      {http.MethodPost},
-		"/eth/v2/beacon/blocks":                                      {http.MethodPost},
-		"/eth/v2/beacon/blocks/{block_id}":                           {http.MethodGet},
-		"/eth/v1/beacon/blocks/{block_id}/root":                      {http.MethodGet},
-		"/eth/v1/beacon/blocks/{block_id}/attestations":              {http.MethodGet},
-		"/eth/v2/beacon/blocks/{block_id}/attestations":              {http.MethodGet},
-		"/eth/v1/beacon/blob_sidecars/{block_id}":                    {http.MethodGet},
-		"/eth/v1/beacon/deposit_snapshot":                            {http.MethodGet},
-		"/eth/v1/beacon/blinded_blocks/{block_id}":                   {http.MethodGet},
-		"/eth/v1/beacon/pool/attestations":                           {http.MethodGet, http.MethodPost},
-		"/eth/v2/beacon/pool/attestations":                           {http.MethodGet, http.MethodPost},
-		"/eth/v1/beacon/pool/attester_slashings":                     {http.MethodGet, http.MethodPost},
-		"/eth/v2/beacon/pool/attester_slashings":                     {http.MethodGet, http.MethodPost},
-		"/eth/v1/beacon/pool/proposer_slashings":                     {http.MethodGet, http.MethodPost},
-		"/eth/v1/beacon/pool/sync_committees":                        {http.MethodPost},
-		"/eth/v1/beacon/pool/voluntary_exits":                        {http.MethodGet, http.MethodPost},
-		"/eth/v1/beacon/pool/bls_to_execution_changes":               {http.MethodGet, http.MethodPost},
-		"/prysm/v1/beacon/individual_votes":                          {http.MethodPost},
+		"/eth/v1/beacon/genesis":                                       {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/root":                        {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/fork":                        {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/finality_checkpoints":        {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/validators":                  {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/states/{state_id}/validators/{validator_id}":   {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/validator_balances":          {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/states/{state_id}/committees":                  {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/sync_committees":             {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/randao":                      {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/pending_deposits":            {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/pending_partial_withdrawals": {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/pending_consolidations":      {http.MethodGet},
+		"/eth/v1/beacon/headers":                                       {http.MethodGet},
+		"/eth/v1/beacon/headers/{block_id}":                            {http.MethodGet},
+		"/eth/v1/beacon/blinded_blocks":                                {http.MethodPost},
+		"/eth/v2/beacon/blinded_blocks":                                {http.MethodPost},
+		"/eth/v1/beacon/blocks":                                        {http.MethodPost},
+		"/eth/v2/beacon/blocks":                                        {http.MethodPost},
+		"/eth/v2/beacon/blocks/{block_id}":                             {http.MethodGet},
+		"/eth/v1/beacon/blocks/{block_id}/root":                        {http.MethodGet},
+		"/eth/v1/beacon/blocks/{block_id}/attestations":                {http.MethodGet},
+		"/eth/v2/beacon/blocks/{block_id}/attestations":                {http.MethodGet},
+		"/eth/v1/beacon/blob_sidecars/{block_id}":                      {http.MethodGet},
+		"/eth/v1/beacon/deposit_snapshot":                              {http.MethodGet},
+		"/eth/v1/beacon/blinded_blocks/{block_id}":                     {http.MethodGet},
+		"/eth/v1/beacon/pool/attestations":                             {http.MethodGet, http.MethodPost},
+		"/eth/v2/beacon/pool/attestations":                             {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/pool/attester_slashings":                       {http.MethodGet, http.MethodPost},
+		"/eth/v2/beacon/pool/attester_slashings":                       {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/pool/proposer_slashings":                       {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/pool/sync_committees":                          {http.MethodPost},
+		"/eth/v1/beacon/pool/voluntary_exits":                          {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/pool/bls_to_execution_changes":                 {http.MethodGet, http.MethodPost},
+		"/prysm/v1/beacon/individual_votes":                            {http.MethodPost},
 	}
 
 	lightClientRoutes := map[string][]string{
@@ -76,6 +79,7 @@ func Test_endpoints(t *testing.T) {
 		"/eth/v2/debug/beacon/states/{state_id}": {http.MethodGet},
 		"/eth/v2/debug/beacon/heads":             {http.MethodGet},
 		"/eth/v1/debug/fork_choice":              {http.MethodGet},
+		"/prysm/v1/debug/states/diff":            {http.MethodGet},
 	}
 
 	eventsRoutes := map[string][]string{
@@ -116,11 +120,16 @@ func Test_endpoints(t *testing.T) {
 	}
 
 	prysmBeaconRoutes := map[string][]string{
-		"/prysm/v1/beacon/weak_subjectivity":                 {http.MethodGet},
-		"/eth/v1/beacon/states/{state_id}/validator_count":   {http.MethodGet},
-		"/prysm/v1/beacon/states/{state_id}/validator_count": {http.MethodGet},
-		"/prysm/v1/beacon/chain_head":                        {http.MethodGet},
-		"/prysm/v1/beacon/blobs":                             {http.MethodPost},
+		"/prysm/v1/beacon/weak_subjectivity":                        {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/validator_count":          {http.MethodGet},
+		"/prysm/v1/beacon/states/{state_id}/validator_count":        {http.MethodGet},
+		"/prysm/v1/beacon/states/{state_id}/pending_deposit_epochs": {http.MethodGet},
+		"/prysm/v1/beacon/states/{state_id}/proof":                  {http.MethodGet},
+		"/prysm/v1/beacon/blocks/{block_id}/proof":                  {http.MethodGet},
+		"/prysm/v1/beacon/chain_head":                               {http.MethodGet},
+		"/prysm/v1/beacon/reorgs":                                   {http.MethodGet},
+		"/prysm/v1/beacon/attestations/inclusion":                   {http.MethodGet},
+		"/prysm/v1/beacon/blobs":                                    {http.MethodPost},
 	}
 
 	prysmNodeRoutes := map[string][]string{
@@ -128,6 +137,7 @@ func Test_endpoints(t *testing.T) {
 		"/prysm/v1/node/trusted_peers":           {http.MethodGet, http.MethodPost},
 		"/prysm/node/trusted_peers/{peer_id}":    {http.MethodDelete},
 		"/prysm/v1/node/trusted_peers/{peer_id}": {http.MethodDelete},
+		"/prysm/v1/node/execution_clients":       {http.MethodGet},
 	}
 
 	prysmValidatorRoutes := map[string][]string{
@@ -135,9 +145,20 @@ func Test_endpoints(t *testing.T) {
 		"/prysm/v1/validators/performance":        {http.MethodPost},
 		"/prysm/v1/validators/participation":      {http.MethodGet},
 		"/prysm/v1/validators/active_set_changes": {http.MethodGet},
+		"/prysm/v1/validators/lifecycle":          {http.MethodGet},
+		"/prysm/v1/validators/history":            {http.MethodPost},
+		"/prysm/v1/validators/rewards":            {http.MethodPost},
 	}
 
-	s := &Service{cfg: &Config{}}
+	prysmSlasherRoutes := map[string][]string{
+		"/prysm/v1/slasher/slashings": {http.MethodGet},
+	}
+
+	graphqlRoutes := map[string][]string{
+		"/graphql": {http.MethodPost},
+	}
+
+	s := &Service{cfg: &Config{EnableGraphQL: true}}
 
 	endpoints := s.endpoints(true, nil, nil, nil, nil, nil, nil)
 	actualRoutes := make(map[string][]string, len(endpoints))
@@ -148,7 +169,7 @@ func Test_endpoints(t *testing.T) {
 			actualRoutes[e.template] = e.methods
 		}
 	}
-	expectedRoutes := combineMaps(beaconRoutes, builderRoutes, configRoutes, debugRoutes, eventsRoutes, nodeRoutes, validatorRoutes, rewardsRoutes, lightClientRoutes, blobRoutes, prysmValidatorRoutes, prysmNodeRoutes, prysmBeaconRoutes)
+	expectedRoutes := combineMaps(beaconRoutes, builderRoutes, configRoutes, debugRoutes, eventsRoutes, nodeRoutes, validatorRoutes, rewardsRoutes, lightClientRoutes, blobRoutes, prysmValidatorRoutes, prysmNodeRoutes, prysmBeaconRoutes, prysmSlasherRoutes, graphqlRoutes)
 
 	assert.Equal(t, true, maps.EqualFunc(expectedRoutes, actualRoutes, func(actualMethods []string, expectedMethods []string) bool {
 		return slices.Equal(expectedMethods, actualMethods)
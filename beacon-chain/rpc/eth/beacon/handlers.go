@@ -236,6 +236,16 @@ func (s *Server) GetBlockAttestationsV2(w http.ResponseWriter, r *http.Request)
 	consensusAtts := blk.Block().Body().Attestations()
 
 	v := blk.Block().Version()
+	w.Header().Set(api.VersionHeader, version.String(v))
+	if httputil.RespondWithSsz(r) {
+		sszData, err := marshalSszVariableList(consensusAtts)
+		if err != nil {
+			httputil.HandleError(w, "Could not marshal attestations: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteSsz(w, sszData, "attestations.ssz")
+		return
+	}
 	var attStructs []interface{}
 	if v >= version.Electra {
 		for _, att := range consensusAtts {
@@ -270,7 +280,6 @@ func (s *Server) GetBlockAttestationsV2(w http.ResponseWriter, r *http.Request)
 		Finalized:           s.FinalizationFetcher.IsFinalized(ctx, root),
 		Data:                attBytes,
 	}
-	w.Header().Set(api.VersionHeader, version.String(v))
 	httputil.WriteJson(w, resp)
 }
 
@@ -1330,8 +1339,24 @@ func (s *Server) GetCommittees(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, "Could not get epoch end slot: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	isOptimistic, err := helpers.IsOptimistic(ctx, []byte(stateId), s.OptimisticModeFetcher, s.Stater, s.ChainInfoFetcher, s.BeaconDB)
+	if err != nil {
+		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		httputil.HandleError(w, "Could not calculate root of latest block header: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, blockRoot)
+
+	stream, err := httputil.NewJsonListWriter(w, &listResponseFields{ExecutionOptimistic: isOptimistic, Finalized: isFinalized})
+	if err != nil {
+		httputil.HandleError(w, "Could not write response: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
 	committeesPerSlot := corehelpers.SlotCommitteeCount(activeCount)
-	committees := make([]*structs.Committee, 0)
 	for slot := startSlot; slot <= endSlot; slot++ {
 		if rawSlot != "" && slot != primitives.Slot(sl) {
 			continue
@@ -1342,7 +1367,7 @@ func (s *Server) GetCommittees(w http.ResponseWriter, r *http.Request) {
 			}
 			committee, err := corehelpers.BeaconCommitteeFromState(ctx, st, slot, index)
 			if err != nil {
-				httputil.HandleError(w, "Could not get committee: "+err.Error(), http.StatusInternalServerError)
+				stream.HandleError("Could not get committee: "+err.Error(), http.StatusInternalServerError)
 				return
 			}
 			var validators []string
@@ -1354,23 +1379,15 @@ func (s *Server) GetCommittees(w http.ResponseWriter, r *http.Request) {
 				Slot:       strconv.FormatUint(uint64(slot), 10),
 				Validators: validators,
 			}
-			committees = append(committees, committeeContainer)
+			if err := stream.Write(committeeContainer); err != nil {
+				stream.HandleError("Could not write committee: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
 		}
 	}
-
-	isOptimistic, err := helpers.IsOptimistic(ctx, []byte(stateId), s.OptimisticModeFetcher, s.Stater, s.ChainInfoFetcher, s.BeaconDB)
-	if err != nil {
-		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
-	if err != nil {
-		httputil.HandleError(w, "Could not calculate root of latest block header: "+err.Error(), http.StatusInternalServerError)
-		return
+	if err := stream.Close(); err != nil {
+		stream.HandleError("Could not write response: "+err.Error(), http.StatusInternalServerError)
 	}
-	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, blockRoot)
-	httputil.WriteJson(w, &structs.GetCommitteesResponse{Data: committees, ExecutionOptimistic: isOptimistic, Finalized: isFinalized})
 }
 
 // GetBlockHeaders retrieves block headers matching given query. By default it will fetch current head slot blocks.
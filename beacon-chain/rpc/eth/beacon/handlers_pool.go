@@ -24,6 +24,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/config/features"
 	consensus_types "github.com/prysmaticlabs/prysm/v5/consensus-types"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	slashingtypes "github.com/prysmaticlabs/prysm/v5/consensus-types/slashing"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
@@ -110,33 +111,42 @@ func (s *Server) ListAttestationsV2(w http.ResponseWriter, r *http.Request) {
 	}
 	attestations = append(attestations, unaggAtts...)
 
-	filteredAtts := make([]interface{}, 0, len(attestations))
+	included := make([]eth.Att, 0, len(attestations))
 	for _, att := range attestations {
-		var includeAttestation bool
-		if v >= version.Electra && att.Version() >= version.Electra {
+		if (v >= version.Electra) != (att.Version() >= version.Electra) {
+			continue
+		}
+		if shouldIncludeAttestation(att.GetData(), rawSlot, slot, rawCommitteeIndex, committeeIndex) {
+			included = append(included, att)
+		}
+	}
+	w.Header().Set(api.VersionHeader, version.String(v))
+	if httputil.RespondWithSsz(r) {
+		sszData, err := marshalSszVariableList(included)
+		if err != nil {
+			httputil.HandleError(w, "Could not marshal attestations: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteSsz(w, sszData, "attestations.ssz")
+		return
+	}
+
+	filteredAtts := make([]interface{}, 0, len(included))
+	for _, att := range included {
+		if v >= version.Electra {
 			attElectra, ok := att.(*eth.AttestationElectra)
 			if !ok {
 				httputil.HandleError(w, fmt.Sprintf("Unable to convert attestation of type %T", att), http.StatusInternalServerError)
 				return
 			}
-
-			includeAttestation = shouldIncludeAttestation(attElectra.GetData(), rawSlot, slot, rawCommitteeIndex, committeeIndex)
-			if includeAttestation {
-				attStruct := structs.AttElectraFromConsensus(attElectra)
-				filteredAtts = append(filteredAtts, attStruct)
-			}
-		} else if v < version.Electra && att.Version() < version.Electra {
+			filteredAtts = append(filteredAtts, structs.AttElectraFromConsensus(attElectra))
+		} else {
 			attOld, ok := att.(*eth.Attestation)
 			if !ok {
 				httputil.HandleError(w, fmt.Sprintf("Unable to convert attestation of type %T", att), http.StatusInternalServerError)
 				return
 			}
-
-			includeAttestation = shouldIncludeAttestation(attOld.GetData(), rawSlot, slot, rawCommitteeIndex, committeeIndex)
-			if includeAttestation {
-				attStruct := structs.AttFromConsensus(attOld)
-				filteredAtts = append(filteredAtts, attStruct)
-			}
+			filteredAtts = append(filteredAtts, structs.AttFromConsensus(attOld))
 		}
 	}
 
@@ -146,7 +156,6 @@ func (s *Server) ListAttestationsV2(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set(api.VersionHeader, version.String(v))
 	httputil.WriteJson(w, &structs.ListAttestationsResponse{
 		Version: version.String(v),
 		Data:    attsData,
@@ -432,6 +441,15 @@ func (s *Server) ListVoluntaryExits(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, "Could not get exits from the pool: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if httputil.RespondWithSsz(r) {
+		sszData, err := marshalSszList(sourceExits)
+		if err != nil {
+			httputil.HandleError(w, "Could not marshal exits: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteSsz(w, sszData, "voluntary_exits.ssz")
+		return
+	}
 	exits := make([]*structs.SignedVoluntaryExit, len(sourceExits))
 	for i, e := range sourceExits {
 		exits[i] = structs.SignedExitFromConsensus(e)
@@ -682,6 +700,15 @@ func (s *Server) ListBLSToExecutionChanges(w http.ResponseWriter, r *http.Reques
 		httputil.HandleError(w, fmt.Sprintf("Could not get BLS to execution changes: %v", err), http.StatusInternalServerError)
 		return
 	}
+	if httputil.RespondWithSsz(r) {
+		sszData, err := marshalSszList(sourceChanges)
+		if err != nil {
+			httputil.HandleError(w, "Could not marshal BLS to execution changes: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteSsz(w, sszData, "bls_to_execution_changes.ssz")
+		return
+	}
 
 	httputil.WriteJson(w, &structs.BLSToExecutionChangesPoolResponse{
 		Data: structs.SignedBLSChangesFromConsensus(sourceChanges),
@@ -732,6 +759,22 @@ func (s *Server) GetAttesterSlashingsV2(w http.ResponseWriter, r *http.Request)
 	var attStructs []interface{}
 	sourceSlashings := s.SlashingsPool.PendingAttesterSlashings(ctx, headState, true /* return unlimited slashings */)
 
+	w.Header().Set(api.VersionHeader, version.String(v))
+	if httputil.RespondWithSsz(r) {
+		forkSlashings := make([]eth.AttSlashing, 0, len(sourceSlashings))
+		for _, slashing := range sourceSlashings {
+			if (v >= version.Electra) == (slashing.Version() >= version.Electra) {
+				forkSlashings = append(forkSlashings, slashing)
+			}
+		}
+		sszData, err := marshalSszVariableList(forkSlashings)
+		if err != nil {
+			httputil.HandleError(w, "Could not marshal slashings: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteSsz(w, sszData, "attester_slashings.ssz")
+		return
+	}
 	for _, slashing := range sourceSlashings {
 		var attStruct interface{}
 		if v >= version.Electra && slashing.Version() >= version.Electra {
@@ -764,7 +807,6 @@ func (s *Server) GetAttesterSlashingsV2(w http.ResponseWriter, r *http.Request)
 		Version: version.String(v),
 		Data:    attBytes,
 	}
-	w.Header().Set(api.VersionHeader, version.String(v))
 	httputil.WriteJson(w, resp)
 }
 
@@ -874,6 +916,9 @@ func (s *Server) submitAttesterSlashing(
 		httputil.HandleError(w, "Could not insert attester slashing into pool: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if s.SlashingArchive != nil {
+		s.SlashingArchive.RecordAttesterSlashing(ctx, slashing, slashingtypes.SourceAPI)
+	}
 	// notify events
 	s.OperationNotifier.OperationFeed().Send(&feed.Event{
 		Type: operation.AttesterSlashingReceived,
@@ -901,6 +946,15 @@ func (s *Server) GetProposerSlashings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	sourceSlashings := s.SlashingsPool.PendingProposerSlashings(ctx, headState, true /* return unlimited slashings */)
+	if httputil.RespondWithSsz(r) {
+		sszData, err := marshalSszList(sourceSlashings)
+		if err != nil {
+			httputil.HandleError(w, "Could not marshal slashings: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteSsz(w, sszData, "proposer_slashings.ssz")
+		return
+	}
 	slashings := structs.ProposerSlashingsFromConsensus(sourceSlashings)
 
 	httputil.WriteJson(w, &structs.GetProposerSlashingsResponse{Data: slashings})
@@ -949,6 +1003,9 @@ func (s *Server) SubmitProposerSlashing(w http.ResponseWriter, r *http.Request)
 		httputil.HandleError(w, "Could not insert proposer slashing into pool: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if s.SlashingArchive != nil {
+		s.SlashingArchive.RecordProposerSlashing(ctx, slashing, slashingtypes.SourceAPI)
+	}
 
 	// notify events
 	s.OperationNotifier.OperationFeed().Send(&feed.Event{
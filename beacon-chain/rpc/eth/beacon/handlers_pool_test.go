@@ -850,6 +850,21 @@ func TestListVoluntaryExits(t *testing.T) {
 	assert.Equal(t, "0x7369676e6174757265320000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000", resp.Data[1].Signature)
 	assert.Equal(t, "2", resp.Data[1].Message.Epoch)
 	assert.Equal(t, "2", resp.Data[1].Message.ValidatorIndex)
+
+	t.Run("ssz", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.ListVoluntaryExits(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		exitSize := exit1.SizeSSZ()
+		require.Equal(t, 2*exitSize, writer.Body.Len())
+		exit := &ethpbv1alpha1.SignedVoluntaryExit{}
+		require.NoError(t, exit.UnmarshalSSZ(writer.Body.Bytes()[exitSize:]))
+		assert.DeepEqual(t, exit2, exit)
+	})
 }
 
 func TestSubmitVoluntaryExit(t *testing.T) {
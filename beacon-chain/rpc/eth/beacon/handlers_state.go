@@ -8,6 +8,8 @@ import (
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
@@ -20,6 +22,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	ethpbalpha "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
@@ -333,3 +336,182 @@ func (s *Server) stateForSyncCommittee(ctx context.Context, w http.ResponseWrite
 	}
 	return st, true
 }
+
+// GetPendingDeposits returns the pending deposits queue of the state identified by state_id.
+func (s *Server) GetPendingDeposits(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPendingDeposits")
+	defer span.End()
+
+	st, ok := s.electraState(ctx, w, r)
+	if !ok {
+		return
+	}
+	deposits, err := st.PendingDeposits()
+	if err != nil {
+		httputil.HandleError(w, "Could not get pending deposits: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(api.VersionHeader, version.String(st.Version()))
+	if httputil.RespondWithSsz(r) {
+		sszData, err := marshalSszList(deposits)
+		if err != nil {
+			httputil.HandleError(w, "Could not marshal pending deposits: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteSsz(w, sszData, "pending_deposits.ssz")
+		return
+	}
+	isOptimistic, isFinalized, ok := s.stateStatus(ctx, w, r.PathValue("state_id"), st)
+	if !ok {
+		return
+	}
+	httputil.WriteJson(w, &structs.GetPendingDepositsResponse{
+		Version:             version.String(st.Version()),
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Data:                structs.PendingDepositsFromConsensus(deposits),
+	})
+}
+
+// GetPendingPartialWithdrawals returns the pending partial withdrawals queue of the state identified by state_id.
+func (s *Server) GetPendingPartialWithdrawals(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPendingPartialWithdrawals")
+	defer span.End()
+
+	st, ok := s.electraState(ctx, w, r)
+	if !ok {
+		return
+	}
+	withdrawals, err := st.PendingPartialWithdrawals()
+	if err != nil {
+		httputil.HandleError(w, "Could not get pending partial withdrawals: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(api.VersionHeader, version.String(st.Version()))
+	if httputil.RespondWithSsz(r) {
+		sszData, err := marshalSszList(withdrawals)
+		if err != nil {
+			httputil.HandleError(w, "Could not marshal pending partial withdrawals: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteSsz(w, sszData, "pending_partial_withdrawals.ssz")
+		return
+	}
+	isOptimistic, isFinalized, ok := s.stateStatus(ctx, w, r.PathValue("state_id"), st)
+	if !ok {
+		return
+	}
+	httputil.WriteJson(w, &structs.GetPendingPartialWithdrawalsResponse{
+		Version:             version.String(st.Version()),
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Data:                structs.PendingPartialWithdrawalsFromConsensus(withdrawals),
+	})
+}
+
+// GetPendingConsolidations returns the pending consolidations queue of the state identified by state_id.
+func (s *Server) GetPendingConsolidations(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPendingConsolidations")
+	defer span.End()
+
+	st, ok := s.electraState(ctx, w, r)
+	if !ok {
+		return
+	}
+	consolidations, err := st.PendingConsolidations()
+	if err != nil {
+		httputil.HandleError(w, "Could not get pending consolidations: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(api.VersionHeader, version.String(st.Version()))
+	if httputil.RespondWithSsz(r) {
+		sszData, err := marshalSszList(consolidations)
+		if err != nil {
+			httputil.HandleError(w, "Could not marshal pending consolidations: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteSsz(w, sszData, "pending_consolidations.ssz")
+		return
+	}
+	isOptimistic, isFinalized, ok := s.stateStatus(ctx, w, r.PathValue("state_id"), st)
+	if !ok {
+		return
+	}
+	httputil.WriteJson(w, &structs.GetPendingConsolidationsResponse{
+		Version:             version.String(st.Version()),
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Data:                structs.PendingConsolidationsFromConsensus(consolidations),
+	})
+}
+
+// electraState returns the state identified by the state_id URL parameter, writing an error when the
+// state can't be fetched or is from before Electra.
+func (s *Server) electraState(ctx context.Context, w http.ResponseWriter, r *http.Request) (state.BeaconState, bool) {
+	stateId := r.PathValue("state_id")
+	if stateId == "" {
+		httputil.HandleError(w, "state_id is required in URL params", http.StatusBadRequest)
+		return nil, false
+	}
+	st, err := s.Stater.State(ctx, []byte(stateId))
+	if err != nil {
+		shared.WriteStateFetchError(w, err)
+		return nil, false
+	}
+	if st.Version() < version.Electra {
+		httputil.HandleError(w, "State is from before "+version.String(version.Electra), http.StatusBadRequest)
+		return nil, false
+	}
+	return st, true
+}
+
+// stateStatus returns whether the state is optimistic and whether it is finalized.
+func (s *Server) stateStatus(ctx context.Context, w http.ResponseWriter, stateId string, st state.BeaconState) (bool, bool, bool) {
+	isOptimistic, err := helpers.IsOptimistic(ctx, []byte(stateId), s.OptimisticModeFetcher, s.Stater, s.ChainInfoFetcher, s.BeaconDB)
+	if err != nil {
+		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
+		return false, false, false
+	}
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		httputil.HandleError(w, "Could not calculate root of latest block header: "+err.Error(), http.StatusInternalServerError)
+		return false, false, false
+	}
+	return isOptimistic, s.FinalizationFetcher.IsFinalized(ctx, blockRoot), true
+}
+
+// marshalSszList serializes a list of fixed size SSZ containers.
+func marshalSszList[T ssz.Marshaler](items []T) ([]byte, error) {
+	var err error
+	sszData := make([]byte, 0)
+	for _, item := range items {
+		sszData, err = item.MarshalSSZTo(sszData)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sszData, nil
+}
+
+// marshalSszVariableList encodes a list of variable-size items, which unlike a list of fixed-size items starts with
+// the offsets of the items.
+func marshalSszVariableList[T ssz.Marshaler](items []T) ([]byte, error) {
+	offset := 4 * len(items) // Offsets are 4 bytes long.
+	size := offset
+	for _, item := range items {
+		size += item.SizeSSZ()
+	}
+	sszData := make([]byte, 0, size)
+	for _, item := range items {
+		sszData = ssz.WriteOffset(sszData, offset)
+		offset += item.SizeSSZ()
+	}
+	var err error
+	for _, item := range items {
+		sszData, err = item.MarshalSSZTo(sszData)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sszData, nil
+}
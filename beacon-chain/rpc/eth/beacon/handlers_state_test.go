@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
@@ -647,3 +648,90 @@ func TestGetSyncCommittees_Future(t *testing.T) {
 		}
 	}
 }
+
+func TestGetPendingQueues(t *testing.T) {
+	st, err := util.NewBeaconStateElectra()
+	require.NoError(t, err)
+	deposits := []*ethpbalpha.PendingDeposit{
+		{
+			PublicKey:             bytesutil.PadTo([]byte("pubkey"), 48),
+			WithdrawalCredentials: make([]byte, 32),
+			Amount:                32_000_000_000,
+			Signature:             make([]byte, 96),
+			Slot:                  3,
+		},
+	}
+	withdrawals := []*ethpbalpha.PendingPartialWithdrawal{{Index: 1, Amount: 2, WithdrawableEpoch: 3}, {Index: 4, Amount: 5, WithdrawableEpoch: 6}}
+	consolidations := []*ethpbalpha.PendingConsolidation{{SourceIndex: 7, TargetIndex: 8}}
+	require.NoError(t, st.SetPendingDeposits(deposits))
+	for _, w := range withdrawals {
+		require.NoError(t, st.AppendPendingPartialWithdrawal(w))
+	}
+	require.NoError(t, st.AppendPendingConsolidation(consolidations[0]))
+
+	chainService := &chainMock.ChainService{}
+	s := &Server{
+		Stater:                &testutil.MockStater{BeaconState: st},
+		HeadFetcher:           chainService,
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+		BeaconDB:              dbTest.SetupDB(t),
+	}
+	get := func(handler http.HandlerFunc, path string, ssz bool) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/"+path, nil)
+		request.SetPathValue("state_id", "head")
+		if ssz {
+			request.Header.Set("Accept", api.OctetStreamMediaType)
+		}
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		handler(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		require.Equal(t, "electra", writer.Header().Get(api.VersionHeader))
+		return writer
+	}
+
+	t.Run("pending deposits", func(t *testing.T) {
+		writer := get(s.GetPendingDeposits, "pending_deposits", false)
+		resp := &structs.GetPendingDepositsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, "electra", resp.Version)
+		require.DeepEqual(t, structs.PendingDepositsFromConsensus(deposits), resp.Data)
+
+		writer = get(s.GetPendingDeposits, "pending_deposits", true)
+		want, err := deposits[0].MarshalSSZ()
+		require.NoError(t, err)
+		require.DeepEqual(t, want, writer.Body.Bytes())
+	})
+	t.Run("pending partial withdrawals", func(t *testing.T) {
+		writer := get(s.GetPendingPartialWithdrawals, "pending_partial_withdrawals", false)
+		resp := &structs.GetPendingPartialWithdrawalsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.DeepEqual(t, structs.PendingPartialWithdrawalsFromConsensus(withdrawals), resp.Data)
+
+		writer = get(s.GetPendingPartialWithdrawals, "pending_partial_withdrawals", true)
+		first, err := withdrawals[0].MarshalSSZ()
+		require.NoError(t, err)
+		second, err := withdrawals[1].MarshalSSZ()
+		require.NoError(t, err)
+		require.DeepEqual(t, append(first, second...), writer.Body.Bytes())
+	})
+	t.Run("pending consolidations", func(t *testing.T) {
+		writer := get(s.GetPendingConsolidations, "pending_consolidations", false)
+		resp := &structs.GetPendingConsolidationsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.DeepEqual(t, structs.PendingConsolidationsFromConsensus(consolidations), resp.Data)
+	})
+	t.Run("state before electra", func(t *testing.T) {
+		denebSt, err := util.NewBeaconStateDeneb()
+		require.NoError(t, err)
+		s := &Server{Stater: &testutil.MockStater{BeaconState: denebSt}}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/pending_deposits", nil)
+		request.SetPathValue("state_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetPendingDeposits(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "before electra", writer.Body.String())
+	})
+}
@@ -3,6 +3,7 @@ package beacon
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -712,6 +713,34 @@ func TestGetBlockAttestations(t *testing.T) {
 	})
 
 	t.Run("V2", func(t *testing.T) {
+		t.Run("ssz", func(t *testing.T) {
+			mockChainService := &chainMock.ChainService{
+				FinalizedRoots: map[[32]byte]bool{},
+			}
+			s := &Server{
+				OptimisticModeFetcher: mockChainService,
+				FinalizationFetcher:   mockChainService,
+				Blocker:               &testutil.MockBlocker{BlockToReturn: esb},
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v2/beacon/blocks/{block_id}/attestations", nil)
+			request.SetPathValue("block_id", "head")
+			request.Header.Set("Accept", api.OctetStreamMediaType)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+
+			s.GetBlockAttestationsV2(writer, request)
+			require.Equal(t, http.StatusOK, writer.Code)
+			assert.Equal(t, "electra", writer.Header().Get(api.VersionHeader))
+
+			items := splitSszVariableList(t, writer.Body.Bytes())
+			require.Equal(t, len(electraAtts), len(items))
+			for i, item := range items {
+				expected, err := electraAtts[i].MarshalSSZ()
+				require.NoError(t, err)
+				assert.DeepEqual(t, expected, item)
+			}
+		})
 		t.Run("ok-pre-electra", func(t *testing.T) {
 			mockChainService := &chainMock.ChainService{
 				FinalizedRoots: map[[32]byte]bool{},
@@ -4177,3 +4206,24 @@ func Test_validateBlobSidecars(t *testing.T) {
 	require.NoError(t, err)
 	require.ErrorContains(t, "could not verify blob proof: can't verify opening proof", s.validateBlobSidecars(b, [][]byte{blob[:]}, [][]byte{proof[:]}))
 }
+
+// splitSszVariableList splits the SSZ encoding of a list of variable-size items into the encodings of the items.
+func splitSszVariableList(t *testing.T, data []byte) [][]byte {
+	if len(data) == 0 {
+		return nil
+	}
+	require.Equal(t, true, len(data) >= 4)
+	first := binary.LittleEndian.Uint32(data[:4])
+	require.Equal(t, uint32(0), first%4)
+	n := int(first / 4)
+	items := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		start := binary.LittleEndian.Uint32(data[4*i:])
+		end := uint32(len(data))
+		if i < n-1 {
+			end = binary.LittleEndian.Uint32(data[4*(i+1):])
+		}
+		items[i] = data[start:end]
+	}
+	return items
+}
@@ -24,6 +24,12 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
+// listResponseFields are the fields of a list response other than its data, which is streamed.
+type listResponseFields struct {
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Finalized           bool `json:"finalized"`
+}
+
 // GetValidators returns filterable list of validators with their balance, status and index.
 func (s *Server) GetValidators(w http.ResponseWriter, r *http.Request) {
 	ctx, span := trace.StartSpan(r.Context(), "beacon.GetValidators")
@@ -93,41 +99,6 @@ func (s *Server) GetValidators(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	readOnlyVals, ok := valsFromIds(w, st, ids)
-	if !ok {
-		return
-	}
-	epoch := slots.ToEpoch(st.Slot())
-
-	// Exit early if no matching validators were found or we don't want to further filter validators by status.
-	if len(readOnlyVals) == 0 || len(statuses) == 0 {
-		containers := make([]*structs.ValidatorContainer, len(readOnlyVals))
-		for i, val := range readOnlyVals {
-			valStatus, err := helpers.ValidatorSubStatus(val, epoch)
-			if err != nil {
-				httputil.HandleError(w, "Could not get validator status: "+err.Error(), http.StatusInternalServerError)
-				return
-			}
-			id := primitives.ValidatorIndex(i)
-			if len(ids) > 0 {
-				id = ids[i]
-			}
-			balance, err := st.BalanceAtIndex(id)
-			if err != nil {
-				httputil.HandleError(w, "Could not get validator balance: "+err.Error(), http.StatusInternalServerError)
-				return
-			}
-			containers[i] = valContainerFromReadOnlyVal(val, id, balance, valStatus)
-		}
-		resp := &structs.GetValidatorsResponse{
-			Data:                containers,
-			ExecutionOptimistic: isOptimistic,
-			Finalized:           isFinalized,
-		}
-		httputil.WriteJson(w, resp)
-		return
-	}
-
 	filteredStatuses := make(map[validator.Status]bool, len(statuses))
 	for _, ss := range statuses {
 		ok, vs := validator.StatusFromString(ss)
@@ -137,40 +108,50 @@ func (s *Server) GetValidators(w http.ResponseWriter, r *http.Request) {
 		}
 		filteredStatuses[vs] = true
 	}
-	valContainers := make([]*structs.ValidatorContainer, 0, len(readOnlyVals))
+	readOnlyVals, ok := valsFromIds(w, st, ids)
+	if !ok {
+		return
+	}
+	epoch := slots.ToEpoch(st.Slot())
+
+	stream, err := httputil.NewJsonListWriter(w, &listResponseFields{ExecutionOptimistic: isOptimistic, Finalized: isFinalized})
+	if err != nil {
+		httputil.HandleError(w, "Could not write response: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
 	for i, val := range readOnlyVals {
-		valStatus, err := helpers.ValidatorStatus(val, epoch)
-		if err != nil {
-			httputil.HandleError(w, "Could not get validator status: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
 		valSubStatus, err := helpers.ValidatorSubStatus(val, epoch)
 		if err != nil {
-			httputil.HandleError(w, "Could not get validator status: "+err.Error(), http.StatusInternalServerError)
+			stream.HandleError("Could not get validator status: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
-		if filteredStatuses[valStatus] || filteredStatuses[valSubStatus] {
-			var container *structs.ValidatorContainer
-			id := primitives.ValidatorIndex(i)
-			if len(ids) > 0 {
-				id = ids[i]
-			}
-			balance, err := st.BalanceAtIndex(id)
+		if len(filteredStatuses) > 0 {
+			valStatus, err := helpers.ValidatorStatus(val, epoch)
 			if err != nil {
-				httputil.HandleError(w, "Could not get validator balance: "+err.Error(), http.StatusInternalServerError)
+				stream.HandleError("Could not get validator status: "+err.Error(), http.StatusInternalServerError)
 				return
 			}
-			container = valContainerFromReadOnlyVal(val, id, balance, valSubStatus)
-			valContainers = append(valContainers, container)
+			if !filteredStatuses[valStatus] && !filteredStatuses[valSubStatus] {
+				continue
+			}
+		}
+		id := primitives.ValidatorIndex(i)
+		if len(ids) > 0 {
+			id = ids[i]
+		}
+		balance, err := st.BalanceAtIndex(id)
+		if err != nil {
+			stream.HandleError("Could not get validator balance: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if err := stream.Write(valContainerFromReadOnlyVal(val, id, balance, valSubStatus)); err != nil {
+			stream.HandleError("Could not write validator: "+err.Error(), http.StatusInternalServerError)
+			return
 		}
 	}
-
-	resp := &structs.GetValidatorsResponse{
-		Data:                valContainers,
-		ExecutionOptimistic: isOptimistic,
-		Finalized:           isFinalized,
+	if err := stream.Close(); err != nil {
+		stream.HandleError("Could not write response: "+err.Error(), http.StatusInternalServerError)
 	}
-	httputil.WriteJson(w, resp)
 }
 
 // GetValidator returns a validator specified by state and id or public key along with status and balance.
@@ -296,32 +277,31 @@ func (s *Server) GetValidatorBalances(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	stream, err := httputil.NewJsonListWriter(w, &listResponseFields{ExecutionOptimistic: isOptimistic, Finalized: isFinalized})
+	if err != nil {
+		httputil.HandleError(w, "Could not write response: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
 	bals := st.Balances()
-	var valBalances []*structs.ValidatorBalance
 	if len(ids) == 0 {
-		valBalances = make([]*structs.ValidatorBalance, len(bals))
-		for i, b := range bals {
-			valBalances[i] = &structs.ValidatorBalance{
-				Index:   strconv.FormatUint(uint64(i), 10),
-				Balance: strconv.FormatUint(b, 10),
-			}
+		ids = make([]primitives.ValidatorIndex, len(bals))
+		for i := range ids {
+			ids[i] = primitives.ValidatorIndex(i)
 		}
-	} else {
-		valBalances = make([]*structs.ValidatorBalance, len(ids))
-		for i, id := range ids {
-			valBalances[i] = &structs.ValidatorBalance{
-				Index:   strconv.FormatUint(uint64(id), 10),
-				Balance: strconv.FormatUint(bals[id], 10),
-			}
+	}
+	for _, id := range ids {
+		valBalance := &structs.ValidatorBalance{
+			Index:   strconv.FormatUint(uint64(id), 10),
+			Balance: strconv.FormatUint(bals[id], 10),
+		}
+		if err := stream.Write(valBalance); err != nil {
+			stream.HandleError("Could not write validator balance: "+err.Error(), http.StatusInternalServerError)
+			return
 		}
 	}
-
-	resp := &structs.GetValidatorBalancesResponse{
-		Data:                valBalances,
-		ExecutionOptimistic: isOptimistic,
-		Finalized:           isFinalized,
+	if err := stream.Close(); err != nil {
+		stream.HandleError("Could not write response: "+err.Error(), http.StatusInternalServerError)
 	}
-	httputil.WriteJson(w, resp)
 }
 
 // decodeIds takes in a list of validator ID strings (as either a pubkey or a validator index)
@@ -33,6 +33,7 @@ type Server struct {
 	Broadcaster             p2p.Broadcaster
 	AttestationsPool        attestations.Pool
 	SlashingsPool           slashings.PoolManager
+	SlashingArchive         *slashings.Archive
 	VoluntaryExitsPool      voluntaryexits.PoolManager
 	StateGenService         stategen.StateManager
 	Stater                  lookup.Stater
@@ -0,0 +1,74 @@
+package debug
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/diff"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+)
+
+const (
+	// defaultStateDiffLimit is the number of changes listed for each per validator field when no limit is given.
+	defaultStateDiffLimit = 1024
+	// maxStateDiffLimit is the largest limit accepted, which bounds the size of the response.
+	maxStateDiffLimit = 65536
+)
+
+// GetStateDiff is a HTTP handler that serves the GET /prysm/v1/debug/states/diff endpoint.
+// It returns the field level difference between the states given by the from and to query parameters, which
+// accept the same state ids as the other state endpoints: changed validators with their old and new records,
+// balance, inactivity score and participation flag changes, checkpoint, justification bits and fork changes,
+// and the number of items processed from and appended to the queues of the state. The optional limit query
+// parameter bounds the number of changes listed for each per validator field, and truncated is set in the
+// response when changes were left out.
+//
+// Example usage:
+//
+//	GET /prysm/v1/debug/states/diff?from=100&to=head&limit=100
+func (s *Server) GetStateDiff(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "debug.GetStateDiff")
+	defer span.End()
+
+	fromId := r.URL.Query().Get("from")
+	if fromId == "" {
+		httputil.HandleError(w, "from is required in query params", http.StatusBadRequest)
+		return
+	}
+	toId := r.URL.Query().Get("to")
+	if toId == "" {
+		httputil.HandleError(w, "to is required in query params", http.StatusBadRequest)
+		return
+	}
+	rawLimit, limit, ok := shared.UintFromQuery(w, r, "limit", false)
+	if !ok {
+		return
+	}
+	if rawLimit == "" {
+		limit = defaultStateDiffLimit
+	}
+	if limit == 0 || limit > maxStateDiffLimit {
+		httputil.HandleError(w, fmt.Sprintf("limit must be between 1 and %d", maxStateDiffLimit), http.StatusBadRequest)
+		return
+	}
+	from, err := s.Stater.State(ctx, []byte(fromId))
+	if err != nil {
+		shared.WriteStateFetchError(w, err)
+		return
+	}
+	to, err := s.Stater.State(ctx, []byte(toId))
+	if err != nil {
+		shared.WriteStateFetchError(w, err)
+		return
+	}
+
+	d, err := diff.Compute(ctx, from, to, int(limit))
+	if err != nil {
+		httputil.HandleError(w, "Could not compute state diff: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &structs.GetStateDiffResponse{Data: structs.StateDiffFromConsensus(d)})
+}
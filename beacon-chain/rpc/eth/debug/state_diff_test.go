@@ -0,0 +1,85 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestGetStateDiff(t *testing.T) {
+	from, _ := util.DeterministicGenesisStateElectra(t, 64)
+	to := from.Copy()
+	require.NoError(t, to.SetSlot(from.Slot()+32))
+	balance, err := from.BalanceAtIndex(4)
+	require.NoError(t, err)
+	require.NoError(t, to.UpdateBalancesAtIndex(4, balance-10))
+
+	s := &Server{
+		Stater: &testutil.MockStater{
+			StateProviderFunc: func(_ context.Context, id []byte) (state.BeaconState, error) {
+				switch string(id) {
+				case "genesis":
+					return from, nil
+				case "head":
+					return to, nil
+				}
+				return nil, &lookup.StateNotFoundError{}
+			},
+		},
+	}
+	get := func(query string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/states/diff"+query, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetStateDiff(writer, request)
+		return writer
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		writer := get("?from=genesis&to=head")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetStateDiffResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "0", resp.Data.FromSlot)
+		assert.Equal(t, "32", resp.Data.ToSlot)
+		assert.Equal(t, "electra", resp.Data.ToVersion)
+		assert.DeepEqual(t, []string{"slot", "balances"}, resp.Data.ChangedFields)
+		require.Equal(t, 1, len(resp.Data.Balances))
+		assert.Equal(t, "4", resp.Data.Balances[0].Index)
+		assert.Equal(t, "-10", resp.Data.Balances[0].Delta)
+		assert.Equal(t, 0, len(resp.Data.Validators))
+		assert.Equal(t, true, resp.Data.Fork == nil)
+	})
+	t.Run("limit", func(t *testing.T) {
+		writer := get("?from=genesis&to=head&limit=1")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetStateDiffResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data.Balances))
+		assert.Equal(t, false, resp.Data.Truncated)
+
+		writer = get("?from=genesis&to=head&limit=0")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "limit must be between 1 and", writer.Body.String())
+	})
+	t.Run("missing to", func(t *testing.T) {
+		writer := get("?from=genesis")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "to is required", writer.Body.String())
+	})
+	t.Run("state not found", func(t *testing.T) {
+		writer := get("?from=genesis&to=finalized")
+		require.Equal(t, http.StatusNotFound, writer.Code)
+	})
+}
@@ -67,6 +67,12 @@ const (
 	LightClientFinalityUpdateTopic = "light_client_finality_update"
 	// LightClientOptimisticUpdateTopic represents a new light client optimistic update event topic.
 	LightClientOptimisticUpdateTopic = "light_client_optimistic_update"
+	// PayloadVerificationDivergenceTopic represents a disagreement between the primary and the verification
+	// execution clients on the validity of an execution payload.
+	PayloadVerificationDivergenceTopic = "execution_payload_divergence"
+	// SlashingEvidenceTopic represents a slashing recorded by the node, when it is first seen and when it
+	// is included in a canonical block.
+	SlashingEvidenceTopic = "slashing_evidence"
 )
 
 var (
@@ -98,16 +104,18 @@ var opsFeedEventTopics = map[feed.EventType]string{
 	operation.BlobSidecarReceived:               BlobSidecarTopic,
 	operation.AttesterSlashingReceived:          AttesterSlashingTopic,
 	operation.ProposerSlashingReceived:          ProposerSlashingTopic,
+	operation.SlashingEvidenceRecorded:          SlashingEvidenceTopic,
 }
 
 var stateFeedEventTopics = map[feed.EventType]string{
-	statefeed.NewHead:                     HeadTopic,
-	statefeed.FinalizedCheckpoint:         FinalizedCheckpointTopic,
-	statefeed.LightClientFinalityUpdate:   LightClientFinalityUpdateTopic,
-	statefeed.LightClientOptimisticUpdate: LightClientOptimisticUpdateTopic,
-	statefeed.Reorg:                       ChainReorgTopic,
-	statefeed.BlockProcessed:              BlockTopic,
-	statefeed.PayloadAttributes:           PayloadAttributesTopic,
+	statefeed.NewHead:                       HeadTopic,
+	statefeed.FinalizedCheckpoint:           FinalizedCheckpointTopic,
+	statefeed.LightClientFinalityUpdate:     LightClientFinalityUpdateTopic,
+	statefeed.LightClientOptimisticUpdate:   LightClientOptimisticUpdateTopic,
+	statefeed.Reorg:                         ChainReorgTopic,
+	statefeed.BlockProcessed:                BlockTopic,
+	statefeed.PayloadAttributes:             PayloadAttributesTopic,
+	statefeed.PayloadVerificationDivergence: PayloadVerificationDivergenceTopic,
 }
 
 var topicsForStateFeed = topicsForFeed(stateFeedEventTopics)
@@ -171,6 +179,14 @@ func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	var lastID *uint64
+	if s.EventLog != nil {
+		lastID, err = parseLastEventID(r.Header.Get(api.LastEventIDHeader))
+		if err != nil {
+			httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
 
 	timeout := s.EventWriteTimeout
 	if timeout == 0 {
@@ -192,7 +208,11 @@ func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
 	es := newEventStreamer(buffSize, ka)
 
 	go es.outboxWriteLoop(ctx, cancel, sw, r.URL.Path)
-	if err := es.recvEventLoop(ctx, cancel, topics, s); err != nil {
+	recv := func() error { return es.recvEventLoop(ctx, cancel, topics, s) }
+	if s.EventLog != nil {
+		recv = func() error { return es.recvLoggedEventLoop(ctx, cancel, topics, s, lastID) }
+	}
+	if err := recv(); err != nil {
 		log.WithError(err).Debug("Shutting down StreamEvents handler.")
 	}
 	cleanupStart := time.Now()
@@ -427,6 +447,8 @@ func topicForEvent(event *feed.Event) string {
 		return AttesterSlashingTopic
 	case *operation.ProposerSlashingReceivedData:
 		return ProposerSlashingTopic
+	case *operation.SlashingEvidenceRecordedData:
+		return SlashingEvidenceTopic
 	case *ethpb.EventHead:
 		return HeadTopic
 	case *ethpb.EventFinalizedCheckpoint:
@@ -441,6 +463,8 @@ func topicForEvent(event *feed.Event) string {
 		return BlockTopic
 	case payloadattribute.EventData:
 		return PayloadAttributesTopic
+	case *statefeed.PayloadVerificationDivergenceData:
+		return PayloadVerificationDivergenceTopic
 	default:
 		return InvalidTopic
 	}
@@ -523,6 +547,14 @@ func (s *Server) lazyReaderForEvent(ctx context.Context, event *feed.Event, topi
 		return func() io.Reader {
 			return jsonMarshalReader(eventName, structs.ProposerSlashingFromConsensus(v.ProposerSlashing))
 		}, nil
+	case *operation.SlashingEvidenceRecordedData:
+		evidence, err := structs.SlashingEvidenceFromConsensus(v.Evidence)
+		if err != nil {
+			return nil, errors.Wrap(err, "SlashingEvidence conversion failure")
+		}
+		return func() io.Reader {
+			return jsonMarshalReader(eventName, evidence)
+		}, nil
 	case *ethpb.EventFinalizedCheckpoint:
 		return func() io.Reader {
 			return jsonMarshalReader(eventName, structs.FinalizedCheckpointEventFromV1(v))
@@ -568,6 +600,17 @@ func (s *Server) lazyReaderForEvent(ctx context.Context, event *feed.Event, topi
 			}
 			return jsonMarshalReader(eventName, blk)
 		}, nil
+	case *statefeed.PayloadVerificationDivergenceData:
+		return func() io.Reader {
+			return jsonMarshalReader(eventName, &structs.PayloadVerificationDivergenceEvent{
+				BlockHash:               hexutil.Encode(v.BlockHash[:]),
+				BlockNumber:             fmt.Sprintf("%d", v.BlockNumber),
+				PrimaryStatus:           v.PrimaryStatus,
+				PrimaryLatestValidHash:  hexutil.Encode(v.PrimaryLatestValidHash),
+				VerifierStatus:          v.VerifierStatus,
+				VerifierLatestValidHash: hexutil.Encode(v.VerifierLatestValidHash),
+			})
+		}, nil
 	default:
 		return nil, errors.Wrapf(errUnhandledEventData, "event data type %T unsupported", v)
 	}
@@ -23,6 +23,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	payloadattribute "github.com/prysmaticlabs/prysm/v5/consensus-types/payload-attribute"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/slashing"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
@@ -115,11 +116,15 @@ func operationEventsFixtures(t *testing.T) (*topicRequest, []*feed.Event) {
 		BlobSidecarTopic,
 		AttesterSlashingTopic,
 		ProposerSlashingTopic,
+		SlashingEvidenceTopic,
 	})
 	require.NoError(t, err)
 	ro, err := blocks.NewROBlob(util.HydrateBlobSidecar(&eth.BlobSidecar{}))
 	require.NoError(t, err)
 	vblob := blocks.NewVerifiedROBlob(ro)
+	header := util.HydrateSignedBeaconHeader(&eth.SignedBeaconBlockHeader{})
+	evidence, err := slashing.NewProposerSlashingEvidence(&eth.ProposerSlashing{Header_1: header, Header_2: header}, slashing.SourceGossip, 1)
+	require.NoError(t, err)
 
 	return topics, []*feed.Event{
 		&feed.Event{
@@ -244,6 +249,12 @@ func operationEventsFixtures(t *testing.T) (*topicRequest, []*feed.Event) {
 				},
 			},
 		},
+		&feed.Event{
+			Type: operation.SlashingEvidenceRecorded,
+			Data: &operation.SlashingEvidenceRecordedData{
+				Evidence: evidence,
+			},
+		},
 	}
 }
 
@@ -325,6 +336,7 @@ func TestStreamEvents_OperationsEvents(t *testing.T) {
 			FinalizedCheckpointTopic,
 			ChainReorgTopic,
 			BlockTopic,
+			PayloadVerificationDivergenceTopic,
 		})
 		require.NoError(t, err)
 		request := topics.testHttpRequest(testSync.ctx, t)
@@ -377,6 +389,17 @@ func TestStreamEvents_OperationsEvents(t *testing.T) {
 					ExecutionOptimistic: false,
 				},
 			},
+			&feed.Event{
+				Type: statefeed.PayloadVerificationDivergence,
+				Data: &statefeed.PayloadVerificationDivergenceData{
+					BlockHash:               [32]byte{'a'},
+					BlockNumber:             1,
+					PrimaryStatus:           "VALID",
+					PrimaryLatestValidHash:  make([]byte, 32),
+					VerifierStatus:          "INVALID",
+					VerifierLatestValidHash: make([]byte, 32),
+				},
+			},
 		}
 
 		go func() {
@@ -543,7 +566,7 @@ func TestStuckReaderScenarios(t *testing.T) {
 
 func wedgedWriterTestCase(t *testing.T, queueDepth func([]*feed.Event) int) {
 	topics, events := operationEventsFixtures(t)
-	require.Equal(t, 8, len(events))
+	require.Equal(t, 9, len(events))
 
 	// set eventFeedDepth to a number lower than the events we intend to send to force the server to drop the reader.
 	stn := mockChain.NewEventFeedWrapper()
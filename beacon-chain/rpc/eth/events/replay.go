@@ -0,0 +1,364 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+)
+
+// DefaultEventReplayDepth is the default number of events kept by topic for replay.
+const DefaultEventReplayDepth = 256
+
+// ReplayGapEventName is the name of the event sent before the replayed events when some of the events
+// following the Last-Event-ID of the client are no longer kept.
+const ReplayGapEventName = "replay_gap"
+
+// replayTopics are the topics whose events are kept for replay.
+var replayTopics = map[string]bool{
+	HeadTopic:                true,
+	BlockTopic:               true,
+	FinalizedCheckpointTopic: true,
+	ChainReorgTopic:          true,
+}
+
+var errEventLogOverflow = errors.New("client fell behind the event log")
+
+// loggedEvent is an event of the state or operation feeds with the ID assigned by the event log.
+type loggedEvent struct {
+	ID    uint64 `json:"id"`
+	Topic string `json:"topic"`
+	// Data is the serialized SSE frame of the event, without its ID, for the replayed topics.
+	Data  []byte `json:"data"`
+	event *feed.Event
+}
+
+// eventRing keeps the most recent events of a topic.
+type eventRing struct {
+	events []*loggedEvent
+	next   int
+	full   bool
+	// evicted is the ID of the last event dropped from the ring.
+	evicted uint64
+}
+
+func (r *eventRing) add(e *loggedEvent) {
+	if r.full {
+		r.evicted = r.events[r.next].ID
+	}
+	r.events[r.next] = e
+	r.next = (r.next + 1) % len(r.events)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// since returns the events of the ring with an ID greater than id, oldest first.
+func (r *eventRing) since(id uint64) []*loggedEvent {
+	var events []*loggedEvent
+	start := 0
+	if r.full {
+		start = r.next
+	}
+	for i := 0; i < len(r.events); i++ {
+		e := r.events[(start+i)%len(r.events)]
+		if e != nil && e.ID > id {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// logSubscription receives the events recorded by the log after it subscribed. The overflow channel is
+// closed when the subscriber did not keep up and missed events.
+type logSubscription struct {
+	events   chan *loggedEvent
+	overflow chan struct{}
+}
+
+// EventLog assigns a monotonic ID to every event of the state and operation feeds and keeps the most
+// recent events of the head, block, finalized_checkpoint and chain_reorg topics, so that clients
+// reconnecting with the Last-Event-ID header receive the events they missed.
+type EventLog struct {
+	sync.Mutex
+	path   string
+	nextID uint64
+	// firstID is the ID of the first event recorded by the log, earlier events were never kept.
+	firstID uint64
+	rings   map[string]*eventRing
+	subs    map[*logSubscription]bool
+}
+
+type eventLogSnapshot struct {
+	FirstID uint64         `json:"first_id"`
+	NextID  uint64         `json:"next_id"`
+	Events  []*loggedEvent `json:"events"`
+}
+
+// NewEventLog returns a log keeping depth events by replayed topic. When path is not empty, the events are
+// loaded from and saved to that file so that they survive restarts. Without it, IDs start from the current
+// time in microseconds to keep increasing across restarts, and clients reconnecting with an ID of a previous
+// run are told that their events can't be replayed.
+func NewEventLog(depth int, path string) (*EventLog, error) {
+	if depth <= 0 {
+		return nil, errors.New("event replay depth must be positive")
+	}
+	start := uint64(time.Now().UnixMicro())
+	l := &EventLog{
+		path:    path,
+		nextID:  start,
+		firstID: start,
+		rings:   make(map[string]*eventRing),
+		subs:    make(map[*logSubscription]bool),
+	}
+	for topic := range replayTopics {
+		l.rings[topic] = &eventRing{events: make([]*loggedEvent, depth)}
+	}
+	if path == "" {
+		return l, nil
+	}
+	b, err := file.ReadFileAsBytes(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read event log")
+	}
+	snapshot := &eventLogSnapshot{}
+	if err := json.Unmarshal(b, snapshot); err != nil {
+		return nil, errors.Wrap(err, "could not decode event log")
+	}
+	l.nextID, l.firstID = snapshot.NextID, snapshot.FirstID
+	for _, e := range snapshot.Events {
+		if r, ok := l.rings[e.Topic]; ok {
+			r.add(e)
+		}
+	}
+	return l, nil
+}
+
+// save writes the replayed events to the file of the log, if any.
+func (l *EventLog) save() error {
+	if l.path == "" {
+		return nil
+	}
+	l.Lock()
+	snapshot := &eventLogSnapshot{FirstID: l.firstID, NextID: l.nextID}
+	for _, r := range l.rings {
+		snapshot.Events = append(snapshot.Events, r.since(0)...)
+	}
+	l.Unlock()
+	sort.Slice(snapshot.Events, func(i, j int) bool { return snapshot.Events[i].ID < snapshot.Events[j].ID })
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return file.WriteFile(l.path, b)
+}
+
+// record assigns an ID to the event, keeps it if its topic is replayed and sends it to the subscribers.
+// A subscriber whose buffer is full is dropped rather than blocking the feeds.
+func (l *EventLog) record(e *loggedEvent) {
+	l.Lock()
+	defer l.Unlock()
+	e.ID = l.nextID
+	l.nextID++
+	if r, ok := l.rings[e.Topic]; ok && e.Data != nil {
+		r.add(e)
+	}
+	for sub := range l.subs {
+		select {
+		case sub.events <- e:
+		default:
+			close(sub.overflow)
+			delete(l.subs, sub)
+		}
+	}
+}
+
+// subscribe returns a subscription to the events recorded from now on along with the kept events of the
+// topics recorded after lastID, oldest first, when lastID is not nil. It also returns the replayed topics
+// for which events after lastID were dropped, or all of them when lastID is not an ID of the log.
+func (l *EventLog) subscribe(size int, topics *topicRequest, lastID *uint64) (*logSubscription, []*loggedEvent, []string) {
+	l.Lock()
+	defer l.Unlock()
+	sub := &logSubscription{events: make(chan *loggedEvent, size), overflow: make(chan struct{})}
+	l.subs[sub] = true
+	if lastID == nil {
+		return sub, nil, nil
+	}
+	var replay []*loggedEvent
+	var gaps []string
+	for topic, r := range l.rings {
+		if !topics.requested(topic) {
+			continue
+		}
+		if r.evicted > *lastID || *lastID+1 < l.firstID || *lastID >= l.nextID {
+			gaps = append(gaps, topic)
+		}
+		replay = append(replay, r.since(*lastID)...)
+	}
+	sort.Slice(replay, func(i, j int) bool { return replay[i].ID < replay[j].ID })
+	sort.Strings(gaps)
+	return sub, replay, gaps
+}
+
+func (l *EventLog) unsubscribe(sub *logSubscription) {
+	l.Lock()
+	defer l.Unlock()
+	delete(l.subs, sub)
+}
+
+// RecordEvents feeds the event log of the server with the events of the state and operation feeds until the
+// context is canceled, then saves the log.
+func (s *Server) RecordEvents(ctx context.Context) {
+	eventsChan := make(chan *feed.Event, DefaultEventFeedDepth)
+	stateSub := s.StateNotifier.StateFeed().Subscribe(eventsChan)
+	defer stateSub.Unsubscribe()
+	opsSub := s.OperationNotifier.OperationFeed().Subscribe(eventsChan)
+	defer opsSub.Unsubscribe()
+	all := &topicRequest{topics: replayTopics}
+	for {
+		select {
+		case <-ctx.Done():
+			if err := s.EventLog.save(); err != nil {
+				log.WithError(err).Error("Could not save event log")
+			}
+			return
+		case event := <-eventsChan:
+			e := &loggedEvent{Topic: topicForEvent(event), event: event}
+			if e.Topic == InvalidTopic {
+				continue
+			}
+			if replayTopics[e.Topic] {
+				data, err := renderEvent(ctx, s, event, all)
+				if err != nil {
+					log.WithError(err).WithField("topic", e.Topic).Error("Could not serialize event for replay")
+				}
+				e.Data = data
+			}
+			s.EventLog.record(e)
+		}
+	}
+}
+
+func renderEvent(ctx context.Context, s *Server, event *feed.Event, topics *topicRequest) ([]byte, error) {
+	lr, err := s.lazyReaderForEvent(ctx, event, topics)
+	if err != nil {
+		return nil, err
+	}
+	r := lr()
+	if r == nil {
+		return nil, errors.New("could not serialize event")
+	}
+	return io.ReadAll(r)
+}
+
+// withEventID prefixes the SSE frame of the event with its ID.
+func withEventID(id uint64, lr lazyReader) lazyReader {
+	return func() io.Reader {
+		r := lr()
+		if r == nil {
+			return nil
+		}
+		return io.MultiReader(bytes.NewBufferString("id: "+strconv.FormatUint(id, 10)+"\n"), r)
+	}
+}
+
+// replayGapReader returns the event telling the client that the events of the topics following lastID can't all
+// be replayed.
+func replayGapReader(lastID uint64, topics []string) lazyReader {
+	return func() io.Reader {
+		return jsonMarshalReader(ReplayGapEventName, &struct {
+			LastEventID string   `json:"last_event_id"`
+			Topics      []string `json:"topics"`
+		}{LastEventID: strconv.FormatUint(lastID, 10), Topics: topics})
+	}
+}
+
+// parseLastEventID returns the ID of the Last-Event-ID header of the request, nil when it is absent.
+func parseLastEventID(value string) (*uint64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, errors.Errorf("invalid Last-Event-ID %q", value)
+	}
+	return &id, nil
+}
+
+// recvLoggedEventLoop is the counterpart of recvEventLoop when the server keeps an event log: it first replays the
+// events the client missed since its last event, then streams the events of the log with their IDs.
+func (es *eventStreamer) recvLoggedEventLoop(ctx context.Context, cancel context.CancelFunc, req *topicRequest, s *Server, lastID *uint64) error {
+	defer close(es.outbox)
+	defer cancel()
+	sub, replay, gaps := s.EventLog.subscribe(cap(es.outbox), req, lastID)
+	defer s.EventLog.unsubscribe(sub)
+
+	if len(gaps) > 0 {
+		if err := es.blockingWrite(ctx, replayGapReader(*lastID, gaps)); err != nil {
+			return err
+		}
+	}
+	for _, e := range replay {
+		if err := es.blockingWrite(ctx, withEventID(e.ID, bytesLazyReader(e.Data))); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-sub.overflow:
+			log.WithError(errEventLogOverflow).Warn("Client is unable to keep up with event stream, shutting down.")
+			return errEventLogOverflow
+		case e := <-sub.events:
+			if !req.requested(e.Topic) {
+				continue
+			}
+			lr := bytesLazyReader(e.Data)
+			if e.Data == nil {
+				var err error
+				lr, err = s.lazyReaderForEvent(ctx, e.event, req)
+				if err != nil {
+					if !errors.Is(err, errNotRequested) {
+						log.WithField("topic", e.Topic).WithError(err).Error("StreamEvents API endpoint received an event it was unable to handle.")
+					}
+					continue
+				}
+			}
+			if err := es.safeWrite(ctx, withEventID(e.ID, lr)); err != nil {
+				if errors.Is(err, errSlowReader) {
+					log.WithError(err).Warn("Client is unable to keep up with event stream, shutting down.")
+				}
+				return err
+			}
+		}
+	}
+}
+
+// blockingWrite waits for room in the outbox, as replayed events can outnumber its capacity.
+func (es *eventStreamer) blockingWrite(ctx context.Context, lr lazyReader) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case es.outbox <- lr:
+		return nil
+	}
+}
+
+func bytesLazyReader(b []byte) lazyReader {
+	return func() io.Reader {
+		return bytes.NewReader(b)
+	}
+}
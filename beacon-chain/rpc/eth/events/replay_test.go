@@ -0,0 +1,180 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	mockChain "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	sse "github.com/r3labs/sse/v2"
+)
+
+func headEvent(slot primitives.Slot) *feed.Event {
+	return &feed.Event{
+		Type: statefeed.NewHead,
+		Data: &ethpb.EventHead{
+			Slot:                      slot,
+			Block:                     make([]byte, 32),
+			State:                     make([]byte, 32),
+			PreviousDutyDependentRoot: make([]byte, 32),
+			CurrentDutyDependentRoot:  make([]byte, 32),
+		},
+	}
+}
+
+func TestEventLog(t *testing.T) {
+	l, err := NewEventLog(2, "")
+	require.NoError(t, err)
+	first := l.nextID
+	for i := 0; i < 3; i++ {
+		l.record(&loggedEvent{Topic: HeadTopic, Data: []byte{byte(i)}})
+	}
+	l.record(&loggedEvent{Topic: FinalizedCheckpointTopic, Data: []byte{3}})
+	l.record(&loggedEvent{Topic: AttestationTopic})
+	heads, err := newTopicRequest([]string{HeadTopic, FinalizedCheckpointTopic})
+	require.NoError(t, err)
+
+	t.Run("no last event", func(t *testing.T) {
+		sub, replay, gaps := l.subscribe(1, heads, nil)
+		defer l.unsubscribe(sub)
+		require.Equal(t, 0, len(replay))
+		require.Equal(t, 0, len(gaps))
+	})
+	t.Run("kept events", func(t *testing.T) {
+		lastID := first + 1
+		sub, replay, gaps := l.subscribe(1, heads, &lastID)
+		defer l.unsubscribe(sub)
+		require.Equal(t, 0, len(gaps))
+		require.Equal(t, 2, len(replay))
+		require.Equal(t, first+2, replay[0].ID)
+		require.Equal(t, first+3, replay[1].ID)
+	})
+	t.Run("evicted events", func(t *testing.T) {
+		lastID := first - 1
+		sub, replay, gaps := l.subscribe(1, heads, &lastID)
+		defer l.unsubscribe(sub)
+		require.DeepEqual(t, []string{HeadTopic}, gaps)
+		require.Equal(t, 3, len(replay))
+	})
+	t.Run("unknown event", func(t *testing.T) {
+		lastID := first + 100
+		sub, replay, gaps := l.subscribe(1, heads, &lastID)
+		defer l.unsubscribe(sub)
+		require.DeepEqual(t, []string{FinalizedCheckpointTopic, HeadTopic}, gaps)
+		require.Equal(t, 0, len(replay))
+	})
+	t.Run("slow subscriber", func(t *testing.T) {
+		sub, _, _ := l.subscribe(1, heads, nil)
+		l.record(&loggedEvent{Topic: HeadTopic, Data: []byte{5}})
+		l.record(&loggedEvent{Topic: HeadTopic, Data: []byte{6}})
+		select {
+		case <-sub.overflow:
+		default:
+			t.Fatal("expected subscriber to overflow")
+		}
+	})
+	t.Run("saved", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "events.json")
+		l.path = path
+		require.NoError(t, l.save())
+		loaded, err := NewEventLog(2, path)
+		require.NoError(t, err)
+		require.Equal(t, l.nextID, loaded.nextID)
+		require.Equal(t, first, loaded.firstID)
+		lastID := first + 3
+		sub, replay, gaps := loaded.subscribe(1, heads, &lastID)
+		defer loaded.unsubscribe(sub)
+		require.Equal(t, 0, len(gaps))
+		require.Equal(t, 2, len(replay))
+		require.DeepEqual(t, []byte{6}, replay[1].Data)
+	})
+}
+
+func TestStreamEvents_Replay(t *testing.T) {
+	testSync := newStreamTestSync(t)
+	defer testSync.cleanup()
+	stn := mockChain.NewEventFeedWrapper()
+	opn := mockChain.NewEventFeedWrapper()
+	l, err := NewEventLog(2, "")
+	require.NoError(t, err)
+	first := l.nextID
+	s := &Server{
+		StateNotifier:     &mockChain.SimpleNotifier{Feed: stn},
+		OperationNotifier: &mockChain.SimpleNotifier{Feed: opn},
+		EventWriteTimeout: testEventWriteTimeout,
+		EventLog:          l,
+	}
+	recordCtx, cancelRecord := context.WithCancel(context.Background())
+	defer cancelRecord()
+	go s.RecordEvents(recordCtx)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, stn.WaitForSubscription(ctx))
+	for slot := primitives.Slot(1); slot <= 3; slot++ {
+		s.StateNotifier.StateFeed().Send(headEvent(slot))
+	}
+	for {
+		l.Lock()
+		recorded := l.nextID - first
+		l.Unlock()
+		if recorded == 3 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for events to be recorded")
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	t.Run("invalid Last-Event-ID", func(t *testing.T) {
+		topics, err := newTopicRequest([]string{HeadTopic})
+		require.NoError(t, err)
+		request := topics.testHttpRequest(testSync.ctx, t)
+		request.Header.Set("Last-Event-ID", "foo")
+		w := httptest.NewRecorder()
+		s.StreamEvents(w, request)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.StringContains(t, "invalid Last-Event-ID", w.Body.String())
+	})
+
+	topics, err := newTopicRequest([]string{HeadTopic})
+	require.NoError(t, err)
+	request := topics.testHttpRequest(testSync.ctx, t)
+	request.Header.Set("Last-Event-ID", fmt.Sprintf("%d", first-1))
+	w := NewStreamingResponseWriterRecorder(testSync.ctx)
+	go func() {
+		s.StreamEvents(w, request)
+		testSync.markDone()
+	}()
+
+	sseR := sse.NewEventStreamReader(w.Body(), 1<<24)
+	read := func() string {
+		ev, err := sseR.ReadEvent()
+		require.NoError(t, err)
+		return string(ev)
+	}
+	gap := read()
+	require.StringContains(t, "event: "+ReplayGapEventName, gap)
+	require.StringContains(t, fmt.Sprintf(`"last_event_id":"%d"`, first-1), gap)
+	require.StringContains(t, `"topics":["head"]`, gap)
+	for _, id := range []uint64{first + 1, first + 2} {
+		ev := read()
+		require.Equal(t, true, strings.HasPrefix(ev, fmt.Sprintf("id: %d\nevent: head\n", id)), ev)
+	}
+
+	s.StateNotifier.StateFeed().Send(headEvent(4))
+	ev := read()
+	require.Equal(t, true, strings.HasPrefix(ev, fmt.Sprintf("id: %d\nevent: head\n", first+3)), ev)
+	require.StringContains(t, `"slot":"4"`, ev)
+}
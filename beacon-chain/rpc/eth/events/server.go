@@ -23,4 +23,7 @@ type Server struct {
 	KeepAliveInterval      time.Duration
 	EventFeedDepth         int
 	EventWriteTimeout      time.Duration
+	// EventLog, when set, assigns IDs to the streamed events and replays the missed ones to clients
+	// reconnecting with the Last-Event-ID header.
+	EventLog *EventLog
 }
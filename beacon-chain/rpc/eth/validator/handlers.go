@@ -44,7 +44,7 @@ import (
 
 // GetAggregateAttestation aggregates all attestations matching the given attestation data root and slot, returning the aggregated result.
 func (s *Server) GetAggregateAttestation(w http.ResponseWriter, r *http.Request) {
-	_, span := trace.StartSpan(r.Context(), "validator.GetAggregateAttestation")
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetAggregateAttestation")
 	defer span.End()
 
 	_, attDataRoot, ok := shared.HexFromQuery(w, r, "attestation_data_root", fieldparams.RootLength, true)
@@ -60,6 +60,9 @@ func (s *Server) GetAggregateAttestation(w http.ResponseWriter, r *http.Request)
 	if agg == nil {
 		return
 	}
+	if !s.checkDisputedBranch(ctx, w, agg.GetData().BeaconBlockRoot) {
+		return
+	}
 	typedAgg, ok := agg.(*ethpbalpha.Attestation)
 	if !ok {
 		httputil.HandleError(w, fmt.Sprintf("Attestation is not of type %T", &ethpbalpha.Attestation{}), http.StatusInternalServerError)
@@ -75,7 +78,7 @@ func (s *Server) GetAggregateAttestation(w http.ResponseWriter, r *http.Request)
 
 // GetAggregateAttestationV2 aggregates all attestations matching the given attestation data root and slot, returning the aggregated result.
 func (s *Server) GetAggregateAttestationV2(w http.ResponseWriter, r *http.Request) {
-	_, span := trace.StartSpan(r.Context(), "validator.GetAggregateAttestationV2")
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetAggregateAttestationV2")
 	defer span.End()
 
 	_, attDataRoot, ok := shared.HexFromQuery(w, r, "attestation_data_root", fieldparams.RootLength, true)
@@ -96,6 +99,9 @@ func (s *Server) GetAggregateAttestationV2(w http.ResponseWriter, r *http.Reques
 	if agg == nil {
 		return
 	}
+	if !s.checkDisputedBranch(ctx, w, agg.GetData().BeaconBlockRoot) {
+		return
+	}
 	resp := &structs.AggregateAttestationResponse{
 		Version: version.String(v),
 	}
@@ -170,6 +176,19 @@ func (s *Server) aggregatedAttestation(w http.ResponseWriter, slot primitives.Sl
 	return agg[0]
 }
 
+// checkDisputedBranch writes an error and returns false if the block descends from an execution payload
+// disputed by the verification execution client.
+func (s *Server) checkDisputedBranch(ctx context.Context, w http.ResponseWriter, root []byte) bool {
+	if s.CoreService == nil {
+		return true
+	}
+	if rpcErr := s.CoreService.CheckDisputedBranch(ctx, root); rpcErr != nil {
+		httputil.HandleError(w, rpcErr.Err.Error(), core.ErrorReasonToHTTP(rpcErr.Reason))
+		return false
+	}
+	return true
+}
+
 func matchingAtts(atts []ethpbalpha.Att, slot primitives.Slot, attDataRoot []byte, index primitives.CommitteeIndex) ([]ethpbalpha.Att, error) {
 	if len(atts) == 0 {
 		return []ethpbalpha.Att{}, nil
@@ -647,6 +666,9 @@ func (s *Server) ProduceSyncCommitteeContribution(w http.ResponseWriter, r *http
 		httputil.HandleError(w, "Invalid Beacon Block Root: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	if !s.checkDisputedBranch(ctx, w, blockRoot) {
+		return
+	}
 	contribution, ok := s.produceSyncCommitteeContribution(ctx, w, primitives.Slot(slot), index, blockRoot)
 	if !ok {
 		return
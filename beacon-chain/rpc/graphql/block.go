@@ -0,0 +1,291 @@
+package graphql
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+var errNotFound = errors.New("not found")
+
+// Block resolves a block. The block is loaded from its id the first time a field other than its root is resolved,
+// so that blocks referenced by root, such as parents, cost nothing unless they are read.
+type Block struct {
+	cfg      *Config
+	id       string
+	once     sync.Once
+	blk      interfaces.ReadOnlySignedBeaconBlock
+	err      error
+	postOnce sync.Once
+	post     *State
+}
+
+func newBlock(cfg *Config, id string) *Block {
+	return &Block{cfg: cfg, id: id}
+}
+
+func (b *Block) load(ctx context.Context) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	b.once.Do(func() {
+		if b.err = charge(ctx, blockCost); b.err != nil {
+			return
+		}
+		blk, err := b.cfg.Blocker.Block(ctx, []byte(b.id))
+		if err != nil {
+			b.err = errors.Wrapf(err, "could not get block %s", b.id)
+			return
+		}
+		if err = blocks.BeaconBlockIsNil(blk); err != nil {
+			b.err = errors.Wrapf(errNotFound, "block %s", b.id)
+			return
+		}
+		b.blk = blk
+	})
+	return b.blk, b.err
+}
+
+func (b *Block) Root(ctx context.Context) (string, error) {
+	if isRoot(b.id) {
+		return b.id, nil
+	}
+	blk, err := b.load(ctx)
+	if err != nil {
+		return "", err
+	}
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		return "", errors.Wrap(err, "could not get block root")
+	}
+	return hexutil.Encode(root[:]), nil
+}
+
+func (b *Block) Slot(ctx context.Context) (string, error) {
+	blk, err := b.load(ctx)
+	if err != nil {
+		return "", err
+	}
+	return uint64String(uint64(blk.Block().Slot())), nil
+}
+
+func (b *Block) ProposerIndex(ctx context.Context) (string, error) {
+	blk, err := b.load(ctx)
+	if err != nil {
+		return "", err
+	}
+	return uint64String(uint64(blk.Block().ProposerIndex())), nil
+}
+
+func (b *Block) ParentRoot(ctx context.Context) (string, error) {
+	blk, err := b.load(ctx)
+	if err != nil {
+		return "", err
+	}
+	root := blk.Block().ParentRoot()
+	return hexutil.Encode(root[:]), nil
+}
+
+func (b *Block) StateRoot(ctx context.Context) (string, error) {
+	blk, err := b.load(ctx)
+	if err != nil {
+		return "", err
+	}
+	root := blk.Block().StateRoot()
+	return hexutil.Encode(root[:]), nil
+}
+
+func (b *Block) Version(ctx context.Context) (string, error) {
+	blk, err := b.load(ctx)
+	if err != nil {
+		return "", err
+	}
+	return version.String(blk.Version()), nil
+}
+
+func (b *Block) Parent(ctx context.Context) (*Block, error) {
+	blk, err := b.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if blk.Block().Slot() == 0 {
+		return nil, nil
+	}
+	root := blk.Block().ParentRoot()
+	return newBlock(b.cfg, hexutil.Encode(root[:])), nil
+}
+
+func (b *Block) State(ctx context.Context) (*State, error) {
+	st, err := b.state(ctx)
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return st, nil
+}
+
+// state returns the post-state of the block, loaded once by state root.
+func (b *Block) state(ctx context.Context) (*State, error) {
+	blk, err := b.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b.postOnce.Do(func() {
+		root := blk.Block().StateRoot()
+		b.post = newState(b.cfg, hexutil.Encode(root[:]))
+	})
+	if _, err := b.post.load(ctx); err != nil {
+		return nil, err
+	}
+	return b.post, nil
+}
+
+func (b *Block) Proposer(ctx context.Context) (*Validator, error) {
+	blk, err := b.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	st, err := b.State(ctx)
+	if err != nil || st == nil {
+		return nil, err
+	}
+	return st.validatorAt(ctx, blk.Block().ProposerIndex())
+}
+
+func (b *Block) Attestations(ctx context.Context) ([]*Attestation, error) {
+	blk, err := b.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	atts := blk.Block().Body().Attestations()
+	resolvers := make([]*Attestation, len(atts))
+	for i, att := range atts {
+		resolvers[i] = &Attestation{block: b, att: att}
+	}
+	return resolvers, nil
+}
+
+func (b *Block) Blobs(ctx context.Context, args struct{ Indices *[]string }) ([]*Blob, error) {
+	if err := charge(ctx, blobsCost); err != nil {
+		return nil, err
+	}
+	root, err := b.Root(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var indices []uint64
+	if args.Indices != nil {
+		indices = make([]uint64, len(*args.Indices))
+		for i, raw := range *args.Indices {
+			indices[i], err = strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid blob index %s", raw)
+			}
+		}
+	}
+	sidecars, rpcErr := b.cfg.Blocker.Blobs(ctx, root, indices)
+	if rpcErr != nil {
+		return nil, errors.Wrap(rpcErr.Err, "could not get blobs")
+	}
+	resolvers := make([]*Blob, len(sidecars))
+	for i, sidecar := range sidecars {
+		resolvers[i] = &Blob{sidecar: sidecar.BlobSidecar}
+	}
+	return resolvers, nil
+}
+
+// Ssz encodes the block with its full execution payload, reconstructing it from the execution client if the block
+// is stored blinded, like the Beacon API does.
+func (b *Block) Ssz(ctx context.Context) (string, error) {
+	blk, err := b.load(ctx)
+	if err != nil {
+		return "", err
+	}
+	if blk.Version() >= version.Bellatrix && blk.IsBlinded() {
+		if b.cfg.ExecutionReconstructor == nil {
+			return "", errors.New("could not reconstruct blinded block without an execution client")
+		}
+		if err := charge(ctx, payloadCost); err != nil {
+			return "", err
+		}
+		blk, err = b.cfg.ExecutionReconstructor.ReconstructFullBlock(ctx, blk)
+		if err != nil {
+			return "", errors.Wrap(err, "could not reconstruct full execution payload of blinded block")
+		}
+	}
+	if err := chargeSSZ(ctx, blk); err != nil {
+		return "", err
+	}
+	ssz, err := blk.MarshalSSZ()
+	if err != nil {
+		return "", errors.Wrap(err, "could not marshal block")
+	}
+	return hexutil.Encode(ssz), nil
+}
+
+// Attestation resolves an attestation included in a block.
+type Attestation struct {
+	block *Block
+	att   ethpb.Att
+}
+
+func (a *Attestation) Slot() string { return uint64String(uint64(a.att.GetData().Slot)) }
+
+func (a *Attestation) CommitteeIndex() (string, error) {
+	index, err := a.att.GetCommitteeIndex()
+	if err != nil {
+		return "", errors.Wrap(err, "could not get committee index")
+	}
+	return uint64String(uint64(index)), nil
+}
+
+func (a *Attestation) BeaconBlockRoot() string {
+	return hexutil.Encode(a.att.GetData().BeaconBlockRoot)
+}
+
+func (a *Attestation) Source() *Checkpoint     { return &Checkpoint{cp: a.att.GetData().Source} }
+func (a *Attestation) Target() *Checkpoint     { return &Checkpoint{cp: a.att.GetData().Target} }
+func (a *Attestation) AggregationBits() string { return hexutil.Encode(a.att.GetAggregationBits()) }
+func (a *Attestation) Signature() string       { return hexutil.Encode(a.att.GetSignature()) }
+
+func (a *Attestation) Attesters(ctx context.Context) ([]*Validator, error) {
+	st, err := a.block.state(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := charge(ctx, committeesCost); err != nil {
+		return nil, err
+	}
+	committees, err := helpers.AttestationCommittees(ctx, st.st, a.att)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attestation committees")
+	}
+	indices, err := attestation.AttestingIndices(a.att, committees...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attesting indices")
+	}
+	return st.validators(ctx, indices)
+}
+
+// Blob resolves a blob sidecar.
+type Blob struct {
+	sidecar *ethpb.BlobSidecar
+}
+
+func (b *Blob) Index() string         { return uint64String(b.sidecar.Index) }
+func (b *Blob) KzgCommitment() string { return hexutil.Encode(b.sidecar.KzgCommitment) }
+func (b *Blob) KzgProof() string      { return hexutil.Encode(b.sidecar.KzgProof) }
+func (b *Blob) Blob() string          { return hexutil.Encode(b.sidecar.Blob) }
+
+// isRoot reports whether a block or state id is a root.
+func isRoot(id string) bool {
+	return len(id) == 66 && id[:2] == "0x"
+}
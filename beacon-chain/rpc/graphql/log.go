@@ -0,0 +1,5 @@
+package graphql
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "rpc/graphql")
@@ -0,0 +1,90 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+// Resolver resolves the root query.
+type Resolver struct {
+	cfg *Config
+}
+
+// Block resolves a block by id, or null if it is not found.
+func (r *Resolver) Block(ctx context.Context, args struct{ Id string }) (*Block, error) {
+	b := newBlock(r.cfg, args.Id)
+	if _, err := b.load(ctx); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+// State resolves a state by id, or null if it is not found.
+func (r *Resolver) State(ctx context.Context, args struct{ Id string }) (*State, error) {
+	s := newState(r.cfg, args.Id)
+	if _, err := s.load(ctx); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// ForkChoice resolves the nodes of the fork choice store.
+func (r *Resolver) ForkChoice(ctx context.Context) ([]*ForkChoiceNode, error) {
+	if err := charge(ctx, forkChoiceCost); err != nil {
+		return nil, err
+	}
+	dump, err := r.cfg.ForkchoiceFetcher.ForkChoiceDump(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get forkchoice dump")
+	}
+	nodes := make([]*ForkChoiceNode, len(dump.ForkChoiceNodes))
+	for i, n := range dump.ForkChoiceNodes {
+		nodes[i] = &ForkChoiceNode{cfg: r.cfg, node: n}
+	}
+	return nodes, nil
+}
+
+// ForkChoiceNode resolves a node of the fork choice store.
+type ForkChoiceNode struct {
+	cfg  *Config
+	node *forkchoice.Node
+}
+
+func (n *ForkChoiceNode) Slot() string           { return uint64String(uint64(n.node.Slot)) }
+func (n *ForkChoiceNode) BlockRoot() string      { return hexutil.Encode(n.node.BlockRoot) }
+func (n *ForkChoiceNode) ParentRoot() string     { return hexutil.Encode(n.node.ParentRoot) }
+func (n *ForkChoiceNode) JustifiedEpoch() string { return uint64String(uint64(n.node.JustifiedEpoch)) }
+func (n *ForkChoiceNode) FinalizedEpoch() string { return uint64String(uint64(n.node.FinalizedEpoch)) }
+func (n *ForkChoiceNode) Weight() string         { return uint64String(n.node.Weight) }
+func (n *ForkChoiceNode) Validity() string       { return n.node.Validity.String() }
+
+func (n *ForkChoiceNode) ExecutionOptimistic() bool { return n.node.ExecutionOptimistic }
+
+// Block resolves the block of the node, or null if it is not in the database.
+func (n *ForkChoiceNode) Block(ctx context.Context) (*Block, error) {
+	r := &Resolver{cfg: n.cfg}
+	return r.Block(ctx, struct{ Id string }{Id: hexutil.Encode(n.node.BlockRoot)})
+}
+
+// Checkpoint resolves a checkpoint.
+type Checkpoint struct {
+	cp *ethpb.Checkpoint
+}
+
+func (c *Checkpoint) Epoch() string { return uint64String(uint64(c.cp.Epoch)) }
+func (c *Checkpoint) Root() string  { return hexutil.Encode(c.cp.Root) }
+
+func uint64String(v uint64) string {
+	return fmt.Sprintf("%d", v)
+}
@@ -0,0 +1,113 @@
+package graphql
+
+// schema is the GraphQL schema of the beacon node. Like in the Beacon API, 64-bit integers are represented as decimal
+// strings and byte arrays as 0x-prefixed hex strings. Block and state ids are the ids of the Beacon API: "head",
+// "genesis", "finalized", "justified", a slot or a root.
+const schema string = `
+    schema {
+        query: Query
+    }
+
+    type Query {
+        # Block returns a block, or null if it is not found.
+        block(id: String = "head"): Block
+        # State returns a state, or null if it is not found.
+        state(id: String = "head"): State
+        # ForkChoice returns the nodes of the fork choice store.
+        forkChoice: [ForkChoiceNode!]!
+    }
+
+    type Block {
+        root: String!
+        slot: String!
+        proposerIndex: String!
+        parentRoot: String!
+        stateRoot: String!
+        version: String!
+        # Parent is the parent block, or null for the genesis block.
+        parent: Block
+        # State is the post-state of the block.
+        state: State
+        # Proposer is the proposer of the block, read from the post-state of the block.
+        proposer: Validator
+        attestations: [Attestation!]!
+        # Blobs returns the blob sidecars of the block, all of them when indices is not set.
+        blobs(indices: [String!]): [Blob!]!
+        # Ssz is the SSZ encoding of the signed block, with its full execution payload.
+        ssz: String!
+    }
+
+    type Checkpoint {
+        epoch: String!
+        root: String!
+    }
+
+    type Attestation {
+        slot: String!
+        committeeIndex: String!
+        beaconBlockRoot: String!
+        source: Checkpoint!
+        target: Checkpoint!
+        aggregationBits: String!
+        signature: String!
+        # Attesters are the validators attesting, read from the post-state of the including block.
+        attesters: [Validator!]!
+    }
+
+    type Blob {
+        index: String!
+        kzgCommitment: String!
+        kzgProof: String!
+        blob: String!
+    }
+
+    type State {
+        root: String!
+        slot: String!
+        version: String!
+        finalizedCheckpoint: Checkpoint!
+        currentJustifiedCheckpoint: Checkpoint!
+        previousJustifiedCheckpoint: Checkpoint!
+        # Validator returns a validator by index or public key, or null if it is not in the registry.
+        validator(id: String!): Validator
+        # Validators returns the validators with the given ids, or a page of the registry when ids is not set.
+        validators(ids: [String!], first: Int = 100, skip: Int = 0): [Validator!]!
+        # Committees returns the beacon committees of a slot of the previous, current or next epoch of the state,
+        # defaulting to the slot of the state.
+        committees(slot: String, index: String): [Committee!]!
+        # Ssz is the SSZ encoding of the state.
+        ssz: String!
+    }
+
+    type Validator {
+        index: String!
+        pubkey: String!
+        withdrawalCredentials: String!
+        balance: String!
+        effectiveBalance: String!
+        status: String!
+        slashed: Boolean!
+        activationEligibilityEpoch: String!
+        activationEpoch: String!
+        exitEpoch: String!
+        withdrawableEpoch: String!
+    }
+
+    type Committee {
+        slot: String!
+        index: String!
+        validators: [Validator!]!
+    }
+
+    type ForkChoiceNode {
+        slot: String!
+        blockRoot: String!
+        parentRoot: String!
+        justifiedEpoch: String!
+        finalizedEpoch: String!
+        weight: String!
+        validity: String!
+        executionOptimistic: Boolean!
+        block: Block
+    }
+`
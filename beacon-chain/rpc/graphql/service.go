@@ -0,0 +1,161 @@
+// Package graphql serves a GraphQL query endpoint over the blocks, states, validators, committees, attestations, blobs
+// and fork choice nodes of the beacon node, so that clients can fetch related data in one request rather than chaining
+// Beacon API calls.
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+)
+
+const (
+	// DefaultMaxDepth is the default limit of the depth of a query.
+	DefaultMaxDepth = 10
+	// DefaultMaxCost is the default limit of the cost of a query.
+	DefaultMaxCost = 10000
+
+	// Costs of the loads made while resolving a query. They are charged every time a field is resolved, so that
+	// aliases of a field multiply its cost.
+	validatorCost  = 1
+	blockCost      = 10
+	blobsCost      = 20
+	committeesCost = 50
+	forkChoiceCost = 50
+	payloadCost    = 50
+	stateCost      = 100
+	// replayedStateCost is the cost of a state by slot or root, which may be regenerated by replaying blocks,
+	// unlike the head, genesis, justified and finalized states.
+	replayedStateCost = 1000
+	// maxReplayedStates limits the states by slot or root loaded by a query, regardless of its cost limit.
+	maxReplayedStates = 4
+	// sszBytesPerCost is the size of the part of an SSZ encoding costing 1.
+	sszBytesPerCost = 16 << 10
+
+	maxListPageSize = 1000
+	// maxRequestSize limits the size of the body of a request.
+	maxRequestSize = 1 << 20
+)
+
+// Config holds the dependencies and limits of the GraphQL handler.
+type Config struct {
+	Blocker                lookup.Blocker
+	Stater                 lookup.Stater
+	ForkchoiceFetcher      blockchain.ForkchoiceFetcher
+	ExecutionReconstructor execution.Reconstructor
+	// MaxDepth limits the nesting of the fields of a query.
+	MaxDepth int
+	// MaxCost limits the cost of the blocks, states, validators, blobs, committees, fork choice nodes and SSZ
+	// encodings loaded by a query.
+	MaxCost uint64
+}
+
+// Handler is an HTTP handler answering GraphQL queries.
+type Handler struct {
+	schema  *graphql.Schema
+	maxCost uint64
+}
+
+// NewHandler returns a GraphQL handler resolving queries with the given configuration.
+func NewHandler(cfg *Config) *Handler {
+	maxDepth := cfg.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxDepth
+	}
+	maxCost := cfg.MaxCost
+	if maxCost == 0 {
+		maxCost = DefaultMaxCost
+	}
+	return &Handler{
+		schema:  graphql.MustParseSchema(schema, &Resolver{cfg: cfg}, graphql.MaxDepth(maxDepth)),
+		maxCost: maxCost,
+	}
+}
+
+// ServeHTTP executes the query of the request body, of the form {"query", "operationName", "variables"}.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "graphql.ServeHTTP")
+	defer span.End()
+
+	var req struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			httputil.HandleError(w, fmt.Sprintf("Request body exceeds the limit of %d bytes", maxRequestSize), http.StatusRequestEntityTooLarge)
+			return
+		}
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	ctx = context.WithValue(ctx, budgetKey{}, &budget{max: h.maxCost})
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	if len(resp.Errors) > 0 {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.WithError(err).Error("Could not write GraphQL response")
+		}
+		return
+	}
+	httputil.WriteJson(w, resp)
+}
+
+type budgetKey struct{}
+
+// budget is the cost a query may still spend. Fields are resolved concurrently.
+type budget struct {
+	max      uint64
+	spent    atomic.Uint64
+	replayed atomic.Uint64
+}
+
+// charge spends the cost of a load from the budget of the query, failing once the budget is exceeded.
+func charge(ctx context.Context, cost uint64) error {
+	b, ok := ctx.Value(budgetKey{}).(*budget)
+	if !ok {
+		return nil
+	}
+	if b.spent.Add(cost) > b.max {
+		return fmt.Errorf("query exceeds the cost limit of %d", b.max)
+	}
+	return nil
+}
+
+// chargeState spends the cost of loading the state with the given id. States other than the head, genesis, justified
+// and finalized ones may be regenerated by replaying blocks, so they cost more and their number is limited.
+func chargeState(ctx context.Context, id string) error {
+	switch strings.ToLower(id) {
+	case "head", "genesis", "justified", "finalized":
+		return charge(ctx, stateCost)
+	}
+	if b, ok := ctx.Value(budgetKey{}).(*budget); ok && b.replayed.Add(1) > maxReplayedStates {
+		return fmt.Errorf("query exceeds the limit of %d states by slot or root", maxReplayedStates)
+	}
+	return charge(ctx, replayedStateCost)
+}
+
+// chargeSSZ spends the cost of the SSZ encoding of an object, in proportion to its size, before it is encoded.
+func chargeSSZ(ctx context.Context, obj interface{}) error {
+	m, ok := obj.(ssz.Marshaler)
+	if !ok {
+		return errors.New("object has no SSZ encoding")
+	}
+	return charge(ctx, uint64(m.SizeSSZ())/sszBytesPerCost+1)
+}
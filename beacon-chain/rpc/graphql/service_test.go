@@ -0,0 +1,196 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	mockExecution "github.com/prysmaticlabs/prysm/v5/beacon-chain/execution/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func newTestHandler(t *testing.T, maxDepth int, maxCost uint64) *Handler {
+	st, _ := util.DeterministicGenesisState(t, 4)
+	require.NoError(t, st.SetSlot(5))
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 5
+	b.Block.ProposerIndex = 2
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	return NewHandler(&Config{
+		Blocker:  &testutil.MockBlocker{BlockToReturn: blk},
+		Stater:   &testutil.MockStater{BeaconState: st},
+		MaxDepth: maxDepth,
+		MaxCost:  maxCost,
+	})
+}
+
+func query(t *testing.T, h *Handler, q string) (int, map[string]interface{}) {
+	body, err := json.Marshal(map[string]string{"query": q})
+	require.NoError(t, err)
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/graphql", bytes.NewReader(body))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	h.ServeHTTP(writer, request)
+	resp := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &resp))
+	return writer.Code, resp
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	t.Run("block with state and validators", func(t *testing.T) {
+		h := newTestHandler(t, 0, 0)
+		code, resp := query(t, h, `{
+			block(id: "head") {
+				slot
+				proposer { index }
+				state {
+					slot
+					validators(first: 2, skip: 1) { index balance status }
+				}
+			}
+		}`)
+		require.Equal(t, http.StatusOK, code)
+		blk := resp["data"].(map[string]interface{})["block"].(map[string]interface{})
+		assert.Equal(t, "5", blk["slot"])
+		assert.Equal(t, "2", blk["proposer"].(map[string]interface{})["index"])
+		st := blk["state"].(map[string]interface{})
+		assert.Equal(t, "5", st["slot"])
+		vals := st["validators"].([]interface{})
+		require.Equal(t, 2, len(vals))
+		assert.Equal(t, "1", vals[0].(map[string]interface{})["index"])
+		assert.Equal(t, "32000000000", vals[0].(map[string]interface{})["balance"])
+		assert.Equal(t, "active_ongoing", vals[0].(map[string]interface{})["status"])
+		assert.Equal(t, "2", vals[1].(map[string]interface{})["index"])
+	})
+	t.Run("validator by id", func(t *testing.T) {
+		h := newTestHandler(t, 0, 0)
+		code, resp := query(t, h, `{ state { a: validator(id: "3") { index } b: validator(id: "10") { index } } }`)
+		require.Equal(t, http.StatusOK, code)
+		st := resp["data"].(map[string]interface{})["state"].(map[string]interface{})
+		assert.Equal(t, "3", st["a"].(map[string]interface{})["index"])
+		assert.Equal(t, nil, st["b"])
+	})
+	t.Run("depth limit", func(t *testing.T) {
+		h := newTestHandler(t, 3, 0)
+		code, resp := query(t, h, `{ block { parent { parent { parent { slot } } } } }`)
+		require.Equal(t, http.StatusBadRequest, code)
+		errs := resp["errors"].([]interface{})
+		require.Equal(t, true, len(errs) > 0)
+		assert.Equal(t, true, strings.Contains(errs[0].(map[string]interface{})["message"].(string), "depth"))
+	})
+	t.Run("cost limit", func(t *testing.T) {
+		h := newTestHandler(t, 0, 50)
+		code, resp := query(t, h, `{ state { slot } }`)
+		require.Equal(t, http.StatusBadRequest, code)
+		errs := resp["errors"].([]interface{})
+		require.Equal(t, true, len(errs) > 0)
+		assert.Equal(t, true, strings.Contains(errs[0].(map[string]interface{})["message"].(string), "cost limit of 50"))
+	})
+	t.Run("cost of aliased validators", func(t *testing.T) {
+		h := newTestHandler(t, 0, 105)
+		code, _ := query(t, h, `{ state { validators { index } } }`)
+		require.Equal(t, http.StatusOK, code)
+		code, resp := query(t, h, `{ state { a: validators { index } b: validators { index } } }`)
+		require.Equal(t, http.StatusBadRequest, code)
+		errs := resp["errors"].([]interface{})
+		require.Equal(t, true, len(errs) > 0)
+		assert.Equal(t, true, strings.Contains(errs[0].(map[string]interface{})["message"].(string), "cost limit of 105"))
+	})
+	t.Run("cost of ssz", func(t *testing.T) {
+		h := newTestHandler(t, 0, 200)
+		code, _ := query(t, h, `{ state { slot } }`)
+		require.Equal(t, http.StatusOK, code)
+		code, resp := query(t, h, `{ state { ssz } }`)
+		require.Equal(t, http.StatusBadRequest, code)
+		errs := resp["errors"].([]interface{})
+		require.Equal(t, true, len(errs) > 0)
+		assert.Equal(t, true, strings.Contains(errs[0].(map[string]interface{})["message"].(string), "cost limit of 200"))
+	})
+	t.Run("replayed states", func(t *testing.T) {
+		h := newTestHandler(t, 0, 0)
+		code, _ := query(t, h, `{ a: state(id: "1") { slot } b: state(id: "2") { slot } c: state(id: "3") { slot } d: state(id: "4") { slot } }`)
+		require.Equal(t, http.StatusOK, code)
+		code, resp := query(t, h, `{ a: state(id: "1") { slot } b: state(id: "2") { slot } c: state(id: "3") { slot } d: state(id: "4") { slot } e: state(id: "5") { slot } }`)
+		require.Equal(t, http.StatusBadRequest, code)
+		errs := resp["errors"].([]interface{})
+		require.Equal(t, true, len(errs) > 0)
+		assert.Equal(t, true, strings.Contains(errs[0].(map[string]interface{})["message"].(string), "limit of 4 states by slot or root"))
+		// states by slot or root cost more than the head state
+		h = newTestHandler(t, 0, 500)
+		code, _ = query(t, h, `{ state(id: "head") { slot } }`)
+		require.Equal(t, http.StatusOK, code)
+		code, _ = query(t, h, `{ state(id: "5") { slot } }`)
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+	t.Run("invalid body", func(t *testing.T) {
+		h := newTestHandler(t, 0, 0)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/graphql", strings.NewReader("{"))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		h.ServeHTTP(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("body too large", func(t *testing.T) {
+		h := newTestHandler(t, 0, 0)
+		body := `{"query": "` + strings.Repeat(" ", maxRequestSize) + `{ state { slot } }"}`
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/graphql", strings.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		h.ServeHTTP(writer, request)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, writer.Code)
+	})
+}
+
+func TestBlock_SszBlinded(t *testing.T) {
+	blockHash := bytesutil.ToBytes32([]byte("foo"))
+	payload := &enginev1.ExecutionPayload{
+		ParentHash:    make([]byte, fieldparams.RootLength),
+		FeeRecipient:  make([]byte, fieldparams.FeeRecipientLength),
+		StateRoot:     make([]byte, fieldparams.RootLength),
+		ReceiptsRoot:  make([]byte, fieldparams.RootLength),
+		LogsBloom:     make([]byte, fieldparams.LogsBloomLength),
+		PrevRandao:    make([]byte, fieldparams.RootLength),
+		ExtraData:     make([]byte, 0),
+		BlockHash:     blockHash[:],
+		BaseFeePerGas: make([]byte, fieldparams.RootLength),
+		Transactions:  [][]byte{{0x01, 0x02}},
+	}
+	wrapped, err := blocks.WrappedExecutionPayload(payload)
+	require.NoError(t, err)
+	header, err := blocks.PayloadToHeader(wrapped)
+	require.NoError(t, err)
+	b := util.NewBlindedBeaconBlockBellatrix()
+	b.Block.Slot = 5
+	b.Block.Body.ExecutionPayloadHeader = header
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	st, _ := util.DeterministicGenesisState(t, 4)
+	engine := &mockExecution.EngineClient{ExecutionPayloadByBlockHash: map[[32]byte]*enginev1.ExecutionPayload{blockHash: payload}}
+	h := NewHandler(&Config{
+		Blocker:                &testutil.MockBlocker{BlockToReturn: blk},
+		Stater:                 &testutil.MockStater{BeaconState: st},
+		ExecutionReconstructor: engine,
+	})
+
+	code, resp := query(t, h, `{ block { ssz } }`)
+	require.Equal(t, http.StatusOK, code)
+	enc, err := hexutil.Decode(resp["data"].(map[string]interface{})["block"].(map[string]interface{})["ssz"].(string))
+	require.NoError(t, err)
+	full := &ethpb.SignedBeaconBlockBellatrix{}
+	require.NoError(t, full.UnmarshalSSZ(enc))
+	require.DeepEqual(t, payload.Transactions, full.Block.Body.ExecutionPayload.Transactions)
+	require.Equal(t, uint64(1), engine.NumReconstructedPayloads)
+}
@@ -0,0 +1,319 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	rpchelpers "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// State resolves a state. The state is loaded from its id the first time one of its fields is resolved.
+type State struct {
+	cfg  *Config
+	id   string
+	once sync.Once
+	st   state.BeaconState
+	err  error
+}
+
+func newState(cfg *Config, id string) *State {
+	return &State{cfg: cfg, id: id}
+}
+
+func (s *State) load(ctx context.Context) (state.BeaconState, error) {
+	s.once.Do(func() {
+		if s.err = chargeState(ctx, s.id); s.err != nil {
+			return
+		}
+		st, err := s.cfg.Stater.State(ctx, []byte(s.id))
+		if err != nil {
+			var notFoundErr *lookup.StateNotFoundError
+			if errors.As(err, &notFoundErr) {
+				s.err = errors.Wrapf(errNotFound, "state %s", s.id)
+				return
+			}
+			s.err = errors.Wrapf(err, "could not get state %s", s.id)
+			return
+		}
+		s.st = st
+	})
+	return s.st, s.err
+}
+
+func (s *State) Root(ctx context.Context) (string, error) {
+	if isRoot(s.id) {
+		return s.id, nil
+	}
+	st, err := s.load(ctx)
+	if err != nil {
+		return "", err
+	}
+	root, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "could not get state root")
+	}
+	return hexutil.Encode(root[:]), nil
+}
+
+func (s *State) Slot(ctx context.Context) (string, error) {
+	st, err := s.load(ctx)
+	if err != nil {
+		return "", err
+	}
+	return uint64String(uint64(st.Slot())), nil
+}
+
+func (s *State) Version(ctx context.Context) (string, error) {
+	st, err := s.load(ctx)
+	if err != nil {
+		return "", err
+	}
+	return version.String(st.Version()), nil
+}
+
+func (s *State) FinalizedCheckpoint(ctx context.Context) (*Checkpoint, error) {
+	st, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Checkpoint{cp: st.FinalizedCheckpoint()}, nil
+}
+
+func (s *State) CurrentJustifiedCheckpoint(ctx context.Context) (*Checkpoint, error) {
+	st, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Checkpoint{cp: st.CurrentJustifiedCheckpoint()}, nil
+}
+
+func (s *State) PreviousJustifiedCheckpoint(ctx context.Context) (*Checkpoint, error) {
+	st, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Checkpoint{cp: st.PreviousJustifiedCheckpoint()}, nil
+}
+
+func (s *State) Validator(ctx context.Context, args struct{ Id string }) (*Validator, error) {
+	st, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	index, ok, err := validatorIndex(st, args.Id)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return s.validatorAt(ctx, index)
+}
+
+func (s *State) Validators(ctx context.Context, args struct {
+	Ids   *[]string
+	First int32
+	Skip  int32
+}) ([]*Validator, error) {
+	st, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if args.Ids != nil {
+		if len(*args.Ids) > maxListPageSize {
+			return nil, fmt.Errorf("ids exceed the limit of %d validators", maxListPageSize)
+		}
+		indices := make([]uint64, 0, len(*args.Ids))
+		for _, id := range *args.Ids {
+			index, ok, err := validatorIndex(st, id)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				indices = append(indices, uint64(index))
+			}
+		}
+		return s.validators(ctx, indices)
+	}
+	if args.First < 0 || args.First > maxListPageSize || args.Skip < 0 {
+		return nil, fmt.Errorf("first must be between 0 and %d and skip must not be negative", maxListPageSize)
+	}
+	indices := make([]uint64, 0, args.First)
+	for i := uint64(args.Skip); i < uint64(args.Skip)+uint64(args.First) && i < uint64(st.NumValidators()); i++ {
+		indices = append(indices, i)
+	}
+	return s.validators(ctx, indices)
+}
+
+func (s *State) Committees(ctx context.Context, args struct {
+	Slot  *string
+	Index *string
+}) ([]*Committee, error) {
+	st, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slot := st.Slot()
+	if args.Slot != nil {
+		v, err := strconv.ParseUint(*args.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid slot %s", *args.Slot)
+		}
+		slot = primitives.Slot(v)
+	}
+	epoch, currentEpoch := slots.ToEpoch(slot), slots.ToEpoch(st.Slot())
+	if epoch+1 < currentEpoch || epoch > currentEpoch+1 {
+		return nil, fmt.Errorf("slot %d is not in the previous, current or next epoch of the state", slot)
+	}
+	if err := charge(ctx, committeesCost); err != nil {
+		return nil, err
+	}
+	committees, err := helpers.BeaconCommittees(ctx, st, slot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get committees")
+	}
+	resolvers := make([]*Committee, 0, len(committees))
+	for i, committee := range committees {
+		if args.Index != nil && *args.Index != strconv.Itoa(i) {
+			continue
+		}
+		resolvers = append(resolvers, &Committee{state: s, slot: slot, index: primitives.CommitteeIndex(i), members: committee})
+	}
+	return resolvers, nil
+}
+
+func (s *State) Ssz(ctx context.Context) (string, error) {
+	st, err := s.load(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err := chargeSSZ(ctx, st.ToProtoUnsafe()); err != nil {
+		return "", err
+	}
+	ssz, err := st.MarshalSSZ()
+	if err != nil {
+		return "", errors.Wrap(err, "could not marshal state")
+	}
+	return hexutil.Encode(ssz), nil
+}
+
+// validatorAt returns the validator at an index of the loaded state, or nil if the index is out of the registry.
+func (s *State) validatorAt(ctx context.Context, index primitives.ValidatorIndex) (*Validator, error) {
+	if uint64(index) >= uint64(s.st.NumValidators()) {
+		return nil, nil
+	}
+	if err := charge(ctx, validatorCost); err != nil {
+		return nil, err
+	}
+	val, err := s.st.ValidatorAtIndexReadOnly(index)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get validator %d", index)
+	}
+	return &Validator{st: s.st, index: index, val: val}, nil
+}
+
+func (s *State) validators(ctx context.Context, indices []uint64) ([]*Validator, error) {
+	vals := make([]*Validator, 0, len(indices))
+	for _, index := range indices {
+		v, err := s.validatorAt(ctx, primitives.ValidatorIndex(index))
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			vals = append(vals, v)
+		}
+	}
+	return vals, nil
+}
+
+// validatorIndex resolves a validator id, either an index or a public key, in the state.
+func validatorIndex(st state.ReadOnlyBeaconState, id string) (primitives.ValidatorIndex, bool, error) {
+	if pubkey, err := hexutil.Decode(id); err == nil {
+		if len(pubkey) != fieldparams.BLSPubkeyLength {
+			return 0, false, fmt.Errorf("pubkey length is %d instead of %d", len(pubkey), fieldparams.BLSPubkeyLength)
+		}
+		index, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey))
+		return index, ok, nil
+	}
+	index, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid validator id %s", id)
+	}
+	return primitives.ValidatorIndex(index), index < uint64(st.NumValidators()), nil
+}
+
+// Validator resolves a validator of a state.
+type Validator struct {
+	st    state.ReadOnlyBeaconState
+	index primitives.ValidatorIndex
+	val   state.ReadOnlyValidator
+}
+
+func (v *Validator) Index() string { return uint64String(uint64(v.index)) }
+
+func (v *Validator) Pubkey() string {
+	pubkey := v.val.PublicKey()
+	return hexutil.Encode(pubkey[:])
+}
+
+func (v *Validator) WithdrawalCredentials() string {
+	return hexutil.Encode(v.val.GetWithdrawalCredentials())
+}
+
+func (v *Validator) Balance() (string, error) {
+	balance, err := v.st.BalanceAtIndex(v.index)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get balance of validator %d", v.index)
+	}
+	return uint64String(balance), nil
+}
+
+func (v *Validator) EffectiveBalance() string { return uint64String(v.val.EffectiveBalance()) }
+
+func (v *Validator) Status() (string, error) {
+	status, err := rpchelpers.ValidatorSubStatus(v.val, slots.ToEpoch(v.st.Slot()))
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get status of validator %d", v.index)
+	}
+	return status.String(), nil
+}
+
+func (v *Validator) Slashed() bool { return v.val.Slashed() }
+
+func (v *Validator) ActivationEligibilityEpoch() string {
+	return uint64String(uint64(v.val.ActivationEligibilityEpoch()))
+}
+
+func (v *Validator) ActivationEpoch() string { return uint64String(uint64(v.val.ActivationEpoch())) }
+func (v *Validator) ExitEpoch() string       { return uint64String(uint64(v.val.ExitEpoch())) }
+func (v *Validator) WithdrawableEpoch() string {
+	return uint64String(uint64(v.val.WithdrawableEpoch()))
+}
+
+// Committee resolves a beacon committee.
+type Committee struct {
+	state   *State
+	slot    primitives.Slot
+	index   primitives.CommitteeIndex
+	members []primitives.ValidatorIndex
+}
+
+func (c *Committee) Slot() string  { return uint64String(uint64(c.slot)) }
+func (c *Committee) Index() string { return uint64String(uint64(c.index)) }
+
+func (c *Committee) Validators(ctx context.Context) ([]*Validator, error) {
+	indices := make([]uint64, len(c.members))
+	for i, index := range c.members {
+		indices[i] = uint64(index)
+	}
+	return c.state.validators(ctx, indices)
+}
@@ -0,0 +1,232 @@
+package beacon
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+)
+
+// GetAttestationInclusion is a HTTP handler that serves the GET /prysm/v1/beacon/attestations/inclusion endpoint.
+// It returns the imported blocks, canonical or not, which include an attestation along with their inclusion delay.
+// The attestation is identified either by the validator_index and slot query parameters, or by the data_root and
+// hex encoded aggregation_bits query parameters. Electra attestations spanning several committees also need the
+// hex encoded committee_bits query parameter. A block includes the attestation when it includes the votes of all
+// its attesters, possibly across several aggregates.
+//
+// Inclusions are only kept for the number of epochs configured by --attestation-inclusion-epochs.
+//
+// Example usage:
+//
+//	GET /prysm/v1/beacon/attestations/inclusion?validator_index=12&slot=100
+//	GET /prysm/v1/beacon/attestations/inclusion?data_root=0x...&aggregation_bits=0x...
+func (s *Server) GetAttestationInclusion(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetAttestationInclusion")
+	defer span.End()
+
+	if !s.AttInclusionCache.Enabled() {
+		httputil.HandleError(w, "Attestation inclusion tracking is disabled", http.StatusNotFound)
+		return
+	}
+	rawIndex, index, ok := shared.UintFromQuery(w, r, "validator_index", false)
+	if !ok {
+		return
+	}
+	rawSlot, slot, ok := shared.UintFromQuery(w, r, "slot", false)
+	if !ok {
+		return
+	}
+	rawDataRoot, dataRoot, ok := shared.HexFromQuery(w, r, "data_root", fieldparams.RootLength, false)
+	if !ok {
+		return
+	}
+	rawAggBits := r.URL.Query().Get("aggregation_bits")
+	byValidator := rawIndex != "" || rawSlot != ""
+	byData := rawDataRoot != "" || rawAggBits != ""
+	if byValidator == byData {
+		httputil.HandleError(w, "Either validator_index and slot or data_root and aggregation_bits must be provided", http.StatusBadRequest)
+		return
+	}
+
+	st, err := s.HeadFetcher.HeadStateReadOnly(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get head state: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// votes holds the committee positions of the attesters to look for, by committee index.
+	votes := make(map[primitives.CommitteeIndex][]uint64)
+	var incs []*cache.AttestationInclusion
+	if byValidator {
+		if rawIndex == "" || rawSlot == "" {
+			httputil.HandleError(w, "Both validator_index and slot must be provided", http.StatusBadRequest)
+			return
+		}
+		if primitives.Slot(slot) > st.Slot() {
+			httputil.HandleError(w, fmt.Sprintf("Slot %d is after the head slot %d", slot, st.Slot()), http.StatusBadRequest)
+			return
+		}
+		if lowest := s.AttInclusionCache.LowestSlot(); primitives.Slot(slot) < lowest {
+			httputil.HandleError(w, fmt.Sprintf("Slot %d is before the lowest tracked slot %d", slot, lowest), http.StatusBadRequest)
+			return
+		}
+		committees, err := helpers.BeaconCommittees(ctx, st, primitives.Slot(slot))
+		if err != nil {
+			httputil.HandleError(w, "Could not get committees: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	search:
+		for ci, committee := range committees {
+			for pos, v := range committee {
+				if v == primitives.ValidatorIndex(index) {
+					votes[primitives.CommitteeIndex(ci)] = []uint64{uint64(pos)}
+					incs = s.AttInclusionCache.Committee(primitives.Slot(slot), primitives.CommitteeIndex(ci))
+					break search
+				}
+			}
+		}
+		if len(votes) == 0 {
+			httputil.HandleError(w, fmt.Sprintf("Validator %d is not in a committee at slot %d", index, slot), http.StatusNotFound)
+			return
+		}
+	} else {
+		if rawDataRoot == "" || rawAggBits == "" {
+			httputil.HandleError(w, "Both data_root and aggregation_bits must be provided", http.StatusBadRequest)
+			return
+		}
+		aggBits, err := hexutil.Decode(rawAggBits)
+		if err != nil {
+			httputil.HandleError(w, "aggregation_bits is invalid: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		incs = s.AttInclusionCache.DataRoot([32]byte(dataRoot))
+		if len(incs) == 0 {
+			httputil.WriteJson(w, &structs.GetAttestationInclusionResponse{Data: []*structs.AttestationInclusion{}})
+			return
+		}
+		var committeeIndices []primitives.CommitteeIndex
+		if rawCommitteeBits := r.URL.Query().Get("committee_bits"); rawCommitteeBits != "" {
+			committeeBits, ok := shared.ValidateHex(w, "committee_bits", rawCommitteeBits, 8)
+			if !ok {
+				return
+			}
+			for _, ci := range bitfield.Bitvector64(committeeBits).BitIndices() {
+				committeeIndices = append(committeeIndices, primitives.CommitteeIndex(ci))
+			}
+		} else {
+			for _, inc := range incs {
+				if inc.CommitteeIndex != incs[0].CommitteeIndex {
+					httputil.HandleError(w, "committee_bits must be provided for attestations spanning several committees", http.StatusBadRequest)
+					return
+				}
+			}
+			committeeIndices = []primitives.CommitteeIndex{incs[0].CommitteeIndex}
+		}
+		if votes, err = committeeVotes(bitfield.Bitlist(aggBits), incs[0].Slot, committeeIndices, func(ci primitives.CommitteeIndex) (uint64, error) {
+			committee, err := helpers.BeaconCommitteeFromState(ctx, st, incs[0].Slot, ci)
+			return uint64(len(committee)), err
+		}); err != nil {
+			httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	resp := &structs.GetAttestationInclusionResponse{Data: []*structs.AttestationInclusion{}}
+	for _, inc := range includingBlocks(incs, votes) {
+		canonical, err := s.ChainInfoFetcher.IsCanonical(ctx, inc.BlockRoot)
+		if err != nil {
+			httputil.HandleError(w, "Could not determine if block is canonical: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp.Data = append(resp.Data, &structs.AttestationInclusion{
+			Slot:           strconv.FormatUint(uint64(inc.Slot), 10),
+			InclusionSlot:  strconv.FormatUint(uint64(inc.BlockSlot), 10),
+			InclusionDelay: strconv.FormatUint(uint64(inc.BlockSlot-inc.Slot), 10),
+			BlockRoot:      hexutil.Encode(inc.BlockRoot[:]),
+			Canonical:      canonical,
+		})
+	}
+	httputil.WriteJson(w, resp)
+}
+
+// committeeVotes splits the aggregation bits of an attestation into the committee positions of its attesters, by
+// committee index. The aggregation bits span the given committees in order.
+func committeeVotes(
+	aggBits bitfield.Bitlist,
+	slot primitives.Slot,
+	committeeIndices []primitives.CommitteeIndex,
+	committeeSize func(primitives.CommitteeIndex) (uint64, error),
+) (map[primitives.CommitteeIndex][]uint64, error) {
+	if aggBits.Count() == 0 {
+		return nil, errors.New("aggregation_bits has no bit set")
+	}
+	votes := make(map[primitives.CommitteeIndex][]uint64)
+	offset := uint64(0)
+	for _, ci := range committeeIndices {
+		size, err := committeeSize(ci)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get committee %d at slot %d", ci, slot)
+		}
+		for pos := uint64(0); pos < size; pos++ {
+			if aggBits.BitAt(offset + pos) {
+				votes[ci] = append(votes[ci], pos)
+			}
+		}
+		offset += size
+	}
+	if offset != aggBits.Len() {
+		return nil, errors.Errorf("aggregation_bits has length %d instead of %d", aggBits.Len(), offset)
+	}
+	return votes, nil
+}
+
+// includingBlocks returns one inclusion per block which includes all the given votes, ordered by block slot.
+func includingBlocks(incs []*cache.AttestationInclusion, votes map[primitives.CommitteeIndex][]uint64) []*cache.AttestationInclusion {
+	byBlock := make(map[[32]byte][]*cache.AttestationInclusion)
+	for _, inc := range incs {
+		byBlock[inc.BlockRoot] = append(byBlock[inc.BlockRoot], inc)
+	}
+	var including []*cache.AttestationInclusion
+	for _, blockIncs := range byBlock {
+		if includesVotes(blockIncs, votes) {
+			including = append(including, blockIncs[0])
+		}
+	}
+	sort.Slice(including, func(i, j int) bool {
+		if including[i].BlockSlot != including[j].BlockSlot {
+			return including[i].BlockSlot < including[j].BlockSlot
+		}
+		return string(including[i].BlockRoot[:]) < string(including[j].BlockRoot[:])
+	})
+	return including
+}
+
+func includesVotes(incs []*cache.AttestationInclusion, votes map[primitives.CommitteeIndex][]uint64) bool {
+	for ci, positions := range votes {
+		for _, pos := range positions {
+			included := false
+			for _, inc := range incs {
+				if inc.CommitteeIndex == ci && inc.AggregationBits.BitAt(pos) {
+					included = true
+					break
+				}
+			}
+			if !included {
+				return false
+			}
+		}
+	}
+	return true
+}
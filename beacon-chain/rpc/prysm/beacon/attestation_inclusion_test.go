@@ -0,0 +1,147 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestServer_GetAttestationInclusion(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MinimalSpecConfig())
+	st, _ := util.DeterministicGenesisStateElectra(t, 256)
+	require.NoError(t, st.SetSlot(10))
+	committees, err := helpers.BeaconCommittees(context.Background(), st, 3)
+	require.NoError(t, err)
+	require.Equal(t, true, len(committees) > 1)
+	size0, size1 := uint64(len(committees[0])), uint64(len(committees[1]))
+	validator := committees[1][2]
+
+	bits := func(size uint64, positions ...uint64) bitfield.Bitlist {
+		b := bitfield.NewBitlist(size)
+		for _, p := range positions {
+			b.SetBitAt(p, true)
+		}
+		return b
+	}
+	dataRoot := [32]byte{'d'}
+	rootA, rootB, rootC := [32]byte{'a'}, [32]byte{'b'}, [32]byte{'c'}
+	inclusionCache := cache.NewAttestationInclusionCache(2)
+	inclusionCache.Add(rootA, 4, []*cache.AttestationInclusion{
+		{BlockRoot: rootA, BlockSlot: 4, DataRoot: dataRoot, Slot: 3, CommitteeIndex: 0, AggregationBits: bits(size0, 5)},
+		{BlockRoot: rootA, BlockSlot: 4, DataRoot: dataRoot, Slot: 3, CommitteeIndex: 1, AggregationBits: bits(size1, 0, 2)},
+	})
+	inclusionCache.Add(rootB, 5, []*cache.AttestationInclusion{
+		{BlockRoot: rootB, BlockSlot: 5, DataRoot: dataRoot, Slot: 3, CommitteeIndex: 1, AggregationBits: bits(size1, 0)},
+	})
+	inclusionCache.Add(rootC, 6, []*cache.AttestationInclusion{
+		{BlockRoot: rootC, BlockSlot: 6, DataRoot: dataRoot, Slot: 3, CommitteeIndex: 1, AggregationBits: bits(size1, 2)},
+	})
+	chain := &chainMock.ChainService{State: st, CanonicalRoots: map[[32]byte]bool{rootA: true}}
+	s := &Server{
+		HeadFetcher:       chain,
+		ChainInfoFetcher:  chain,
+		AttInclusionCache: inclusionCache,
+	}
+	get := func(query string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/attestations/inclusion"+query, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetAttestationInclusion(writer, request)
+		return writer
+	}
+
+	t.Run("by validator", func(t *testing.T) {
+		writer := get(fmt.Sprintf("?validator_index=%d&slot=3", validator))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetAttestationInclusionResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		require.DeepEqual(t, &structs.AttestationInclusion{
+			Slot:           "3",
+			InclusionSlot:  "4",
+			InclusionDelay: "1",
+			BlockRoot:      hexutil.Encode(rootA[:]),
+			Canonical:      true,
+		}, resp.Data[0])
+		require.DeepEqual(t, &structs.AttestationInclusion{
+			Slot:           "3",
+			InclusionSlot:  "6",
+			InclusionDelay: "3",
+			BlockRoot:      hexutil.Encode(rootC[:]),
+			Canonical:      false,
+		}, resp.Data[1])
+	})
+	t.Run("by validator not included", func(t *testing.T) {
+		writer := get(fmt.Sprintf("?validator_index=%d&slot=3", committees[0][0]))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetAttestationInclusionResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 0, len(resp.Data))
+	})
+	t.Run("by aggregation bits", func(t *testing.T) {
+		committeeBits := bitfield.NewBitvector64()
+		committeeBits.SetBitAt(0, true)
+		committeeBits.SetBitAt(1, true)
+		aggBits := bits(size0+size1, 5, size0)
+		writer := get(fmt.Sprintf("?data_root=%#x&aggregation_bits=%#x&committee_bits=%#x", dataRoot, []byte(aggBits), []byte(committeeBits)))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetAttestationInclusionResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		require.Equal(t, hexutil.Encode(rootA[:]), resp.Data[0].BlockRoot)
+	})
+	t.Run("unknown data root", func(t *testing.T) {
+		writer := get(fmt.Sprintf("?data_root=%#x&aggregation_bits=0x03", [32]byte{'e'}))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetAttestationInclusionResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 0, len(resp.Data))
+	})
+	t.Run("several committees without committee bits", func(t *testing.T) {
+		writer := get(fmt.Sprintf("?data_root=%#x&aggregation_bits=%#x", dataRoot, []byte(bits(size1, 0))))
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "committee_bits must be provided", writer.Body.String())
+	})
+	t.Run("invalid aggregation bits length", func(t *testing.T) {
+		committeeBits := bitfield.NewBitvector64()
+		committeeBits.SetBitAt(1, true)
+		writer := get(fmt.Sprintf("?data_root=%#x&aggregation_bits=%#x&committee_bits=%#x", dataRoot, []byte(bits(size1+1, 0)), []byte(committeeBits)))
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "aggregation_bits has length", writer.Body.String())
+	})
+	t.Run("missing parameters", func(t *testing.T) {
+		writer := get("")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		writer = get("?validator_index=1")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		writer = get(fmt.Sprintf("?validator_index=1&slot=3&data_root=%#x", dataRoot))
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("future slot", func(t *testing.T) {
+		writer := get("?validator_index=1&slot=11")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		s := &Server{AttInclusionCache: cache.NewAttestationInclusionCache(0)}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/attestations/inclusion?validator_index=1&slot=3", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetAttestationInclusion(writer, request)
+		require.Equal(t, http.StatusNotFound, writer.Code)
+	})
+}
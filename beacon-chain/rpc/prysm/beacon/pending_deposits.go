@@ -0,0 +1,139 @@
+package beacon
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/electra"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// GetPendingDepositEpochs is a HTTP handler that serves the GET /prysm/v1/beacon/states/{state_id}/pending_deposit_epochs
+// endpoint. It estimates the epoch at which each deposit in the pending deposits queue of the state is applied,
+// given the churn limits and assuming the chain keeps finalizing.
+//
+// Deposits can be filtered with the validator_id query parameter, a validator index or public key, and with the
+// deposit_index query parameter, the position of the deposit in the queue. Both can be repeated.
+func (s *Server) GetPendingDepositEpochs(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPendingDepositEpochs")
+	defer span.End()
+
+	stateId := r.PathValue("state_id")
+	if stateId == "" {
+		httputil.HandleError(w, "state_id is required in URL params", http.StatusBadRequest)
+		return
+	}
+	st, err := s.Stater.State(ctx, []byte(stateId))
+	if err != nil {
+		shared.WriteStateFetchError(w, err)
+		return
+	}
+	if st.Version() < version.Electra {
+		httputil.HandleError(w, "State is from before "+version.String(version.Electra), http.StatusBadRequest)
+		return
+	}
+
+	var pubkeys [][]byte
+	for _, id := range r.URL.Query()["validator_id"] {
+		pubkey, err := hexutil.Decode(id)
+		if err == nil {
+			if len(pubkey) != fieldparams.BLSPubkeyLength {
+				httputil.HandleError(w, fmt.Sprintf("Pubkey length is %d instead of %d", len(pubkey), fieldparams.BLSPubkeyLength), http.StatusBadRequest)
+				return
+			}
+			pubkeys = append(pubkeys, pubkey)
+			continue
+		}
+		index, valid := shared.ValidateUint(w, "validator_id", id)
+		if !valid {
+			return
+		}
+		val, err := st.ValidatorAtIndexReadOnly(primitives.ValidatorIndex(index))
+		if err != nil {
+			httputil.HandleError(w, fmt.Sprintf("Invalid validator index %d", index), http.StatusBadRequest)
+			return
+		}
+		pk := val.PublicKey()
+		pubkeys = append(pubkeys, pk[:])
+	}
+	depositIndices := make(map[uint64]bool)
+	for _, rawIndex := range r.URL.Query()["deposit_index"] {
+		index, valid := shared.ValidateUint(w, "deposit_index", rawIndex)
+		if !valid {
+			return
+		}
+		depositIndices[index] = true
+	}
+
+	deposits, err := st.PendingDeposits()
+	if err != nil {
+		httputil.HandleError(w, "Could not get pending deposits: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	epochs, err := electra.EstimatePendingDepositEpochs(st)
+	if err != nil {
+		httputil.HandleError(w, "Could not estimate pending deposit epochs: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := make([]*structs.PendingDepositEpoch, 0)
+	for i, d := range deposits {
+		if !matchesPendingDeposit(d, uint64(i), pubkeys, depositIndices) {
+			continue
+		}
+		estimate := &structs.PendingDepositEpoch{
+			QueueIndex:      strconv.Itoa(i),
+			Deposit:         structs.PendingDepositsFromConsensus([]*eth.PendingDeposit{d})[0],
+			ProcessingEpoch: fmt.Sprintf("%d", epochs[i]),
+		}
+		if index, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(d.PublicKey)); ok {
+			estimate.ValidatorIndex = fmt.Sprintf("%d", index)
+		}
+		data = append(data, estimate)
+	}
+
+	isOptimistic, err := helpers.IsOptimistic(ctx, []byte(stateId), s.OptimisticModeFetcher, s.Stater, s.ChainInfoFetcher, s.BeaconDB)
+	if err != nil {
+		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		httputil.HandleError(w, "Could not calculate root of latest block header: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &structs.GetPendingDepositEpochsResponse{
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           s.FinalizationFetcher.IsFinalized(ctx, blockRoot),
+		Data:                data,
+	})
+}
+
+// matchesPendingDeposit reports whether the deposit at the given queue position passes the filters. Empty filters match
+// every deposit.
+func matchesPendingDeposit(d *eth.PendingDeposit, index uint64, pubkeys [][]byte, indices map[uint64]bool) bool {
+	if len(pubkeys) == 0 && len(indices) == 0 {
+		return true
+	}
+	if indices[index] {
+		return true
+	}
+	for _, pk := range pubkeys {
+		if bytes.Equal(pk, d.PublicKey) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package beacon
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestGetPendingDepositEpochs(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateElectra(t, 64)
+	require.NoError(t, st.SetSlot(10*params.BeaconConfig().SlotsPerEpoch))
+	val, err := st.ValidatorAtIndexReadOnly(3)
+	require.NoError(t, err)
+	existing := val.PublicKey()
+	newKey := bytesutil.PadTo([]byte("new"), 48)
+	amount := params.BeaconConfig().MinActivationBalance
+	deposits := []*eth.PendingDeposit{
+		{PublicKey: existing[:], WithdrawalCredentials: make([]byte, 32), Amount: amount, Signature: make([]byte, 96)},
+		{PublicKey: newKey, WithdrawalCredentials: make([]byte, 32), Amount: amount, Signature: make([]byte, 96)},
+	}
+	require.NoError(t, st.SetPendingDeposits(deposits))
+
+	chainService := &chainMock.ChainService{}
+	s := &Server{
+		Stater:                &testutil.MockStater{BeaconState: st},
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+	}
+	get := func(query string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/states/{state_id}/pending_deposit_epochs"+query, nil)
+		request.SetPathValue("state_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetPendingDepositEpochs(writer, request)
+		return writer
+	}
+
+	t.Run("all", func(t *testing.T) {
+		writer := get("")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPendingDepositEpochsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		require.Equal(t, "0", resp.Data[0].QueueIndex)
+		require.Equal(t, "3", resp.Data[0].ValidatorIndex)
+		require.Equal(t, "11", resp.Data[0].ProcessingEpoch)
+		require.Equal(t, "1", resp.Data[1].QueueIndex)
+		require.Equal(t, "", resp.Data[1].ValidatorIndex)
+		require.Equal(t, hexutil.Encode(newKey), resp.Data[1].Deposit.Pubkey)
+	})
+	t.Run("filters", func(t *testing.T) {
+		for _, query := range []string{"?validator_id=3", "?validator_id=" + hexutil.Encode(existing[:]), "?deposit_index=0"} {
+			writer := get(query)
+			require.Equal(t, http.StatusOK, writer.Code)
+			resp := &structs.GetPendingDepositEpochsResponse{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+			require.Equal(t, 1, len(resp.Data))
+			require.Equal(t, "0", resp.Data[0].QueueIndex)
+		}
+		writer := get("?validator_id=" + hexutil.Encode(newKey) + "&deposit_index=0")
+		resp := &structs.GetPendingDepositEpochsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+	})
+	t.Run("invalid validator id", func(t *testing.T) {
+		writer := get("?validator_id=foo")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		writer = get("?validator_id=1000")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "Invalid validator index 1000", writer.Body.String())
+	})
+}
@@ -0,0 +1,169 @@
+package beacon
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/proof"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// GetStateProof is a HTTP handler that serves the GET /prysm/v1/beacon/states/{state_id}/proof endpoint. It returns a
+// Merkle proof of the node of the state at the path query parameter, such as validators[3].withdrawal_credentials,
+// verifiable against the state root.
+func (s *Server) GetStateProof(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetStateProof")
+	defer span.End()
+
+	stateId := r.PathValue("state_id")
+	if stateId == "" {
+		httputil.HandleError(w, "state_id is required in URL params", http.StatusBadRequest)
+		return
+	}
+	path, ok := proofPath(w, r)
+	if !ok {
+		return
+	}
+	st, err := s.Stater.State(ctx, []byte(stateId))
+	if err != nil {
+		shared.WriteStateFetchError(w, err)
+		return
+	}
+	root, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not compute state root: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// The nodes of the state's tree are taken from its merkle layers and field tries
+	// rather than hashing the whole state again.
+	p, err := proof.ProveTree(ctx, st.ToProtoUnsafe(), st, path)
+	if !checkProof(w, p, err, root) {
+		return
+	}
+
+	isOptimistic, err := helpers.IsOptimistic(ctx, []byte(stateId), s.OptimisticModeFetcher, s.Stater, s.ChainInfoFetcher, s.BeaconDB)
+	if err != nil {
+		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		httputil.HandleError(w, "Could not calculate root of latest block header: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &structs.GetSszProofResponse{
+		Version:             version.String(st.Version()),
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           s.FinalizationFetcher.IsFinalized(ctx, blockRoot),
+		Data:                sszProofFromProof(path, p),
+	})
+}
+
+// GetBlockProof is a HTTP handler that serves the GET /prysm/v1/beacon/blocks/{block_id}/proof endpoint. It returns a
+// Merkle proof of the node of the block at the path query parameter, such as body.execution_payload.state_root,
+// verifiable against the block root.
+//
+// The execution payload of blinded blocks is proven from its header, which has the same root. Paths into the
+// transactions or withdrawals of the payload can't be resolved for them.
+func (s *Server) GetBlockProof(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetBlockProof")
+	defer span.End()
+
+	blockId := r.PathValue("block_id")
+	if blockId == "" {
+		httputil.HandleError(w, "block_id is required in URL params", http.StatusBadRequest)
+		return
+	}
+	path, ok := proofPath(w, r)
+	if !ok {
+		return
+	}
+	blk, err := s.Blocker.Block(ctx, []byte(blockId))
+	if !shared.WriteBlockFetchError(w, blk, err) {
+		return
+	}
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		httputil.HandleError(w, "Could not get block root: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	obj, err := blk.Block().Proto()
+	if err != nil {
+		httputil.HandleError(w, "Could not get block: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	provenPath := path
+	if blk.IsBlinded() {
+		provenPath = make(proof.Path, len(path))
+		copy(provenPath, path)
+		for i, e := range provenPath {
+			if e.Name == "execution_payload" {
+				provenPath[i].Name = "execution_payload_header"
+			}
+		}
+	}
+	p, err := proof.Prove(obj, provenPath)
+	if !checkProof(w, p, err, root) {
+		return
+	}
+
+	isOptimistic, err := s.OptimisticModeFetcher.IsOptimisticForRoot(ctx, root)
+	if err != nil {
+		httputil.HandleError(w, "Could not check if block is optimistic: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &structs.GetSszProofResponse{
+		Version:             version.String(blk.Version()),
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           s.FinalizationFetcher.IsFinalized(ctx, root),
+		Data:                sszProofFromProof(path, p),
+	})
+}
+
+func proofPath(w http.ResponseWriter, r *http.Request) (proof.Path, bool) {
+	rawPath := r.URL.Query().Get("path")
+	if rawPath == "" {
+		httputil.HandleError(w, "path is required in query params", http.StatusBadRequest)
+		return nil, false
+	}
+	path, err := proof.ParsePath(rawPath)
+	if err != nil {
+		httputil.HandleError(w, "Invalid path: "+err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return path, true
+}
+
+// checkProof writes an error when the path could not be proven or when the root of the proof differs from the
+// expected one, which happens when the SSZ schema of the object does not match the configuration.
+func checkProof(w http.ResponseWriter, p *proof.Proof, err error, root [32]byte) bool {
+	if err != nil {
+		httputil.HandleError(w, "Could not prove path: "+err.Error(), http.StatusBadRequest)
+		return false
+	}
+	if p.Root != root {
+		httputil.HandleError(w, fmt.Sprintf("Proof root %#x does not match root %#x", p.Root, root), http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
+func sszProofFromProof(path proof.Path, p *proof.Proof) *structs.SszProof {
+	branch := make([]string, len(p.Branch))
+	for i, node := range p.Branch {
+		branch[i] = hexutil.Encode(node[:])
+	}
+	return &structs.SszProof{
+		Path:             path.String(),
+		Root:             hexutil.Encode(p.Root[:]),
+		GeneralizedIndex: fmt.Sprintf("%d", p.GeneralizedIndex),
+		Leaf:             hexutil.Encode(p.Leaf[:]),
+		Branch:           branch,
+	}
+}
@@ -0,0 +1,126 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/proof"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func proofFromResponse(t *testing.T, writer *httptest.ResponseRecorder) ([32]byte, *proof.Proof) {
+	require.Equal(t, http.StatusOK, writer.Code, writer.Body.String())
+	resp := &structs.GetSszProofResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	root, err := hexutil.Decode(resp.Data.Root)
+	require.NoError(t, err)
+	leaf, err := hexutil.Decode(resp.Data.Leaf)
+	require.NoError(t, err)
+	gindex, err := strconv.ParseUint(resp.Data.GeneralizedIndex, 10, 64)
+	require.NoError(t, err)
+	p := &proof.Proof{Leaf: bytesutil.ToBytes32(leaf), GeneralizedIndex: gindex}
+	for _, node := range resp.Data.Branch {
+		b, err := hexutil.Decode(node)
+		require.NoError(t, err)
+		p.Branch = append(p.Branch, bytesutil.ToBytes32(b))
+	}
+	return bytesutil.ToBytes32(root), p
+}
+
+func TestGetStateProof(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateElectra(t, 16)
+	root, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	chainService := &chainMock.ChainService{}
+	s := &Server{
+		Stater:                &testutil.MockStater{BeaconState: st},
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+	}
+	get := func(path string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/states/{state_id}/proof?path="+path, nil)
+		request.SetPathValue("state_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetStateProof(writer, request)
+		return writer
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		writer := get("validators.3.withdrawal_credentials")
+		stateRoot, p := proofFromResponse(t, writer)
+		require.Equal(t, root, stateRoot)
+		require.Equal(t, true, proof.Verify(root, p))
+		val, err := st.ValidatorAtIndexReadOnly(3)
+		require.NoError(t, err)
+		require.DeepEqual(t, val.GetWithdrawalCredentials(), p.Leaf[:])
+		require.StringContains(t, `"path":"validators[3].withdrawal_credentials"`, writer.Body.String())
+		require.StringContains(t, `"version":"electra"`, writer.Body.String())
+	})
+	t.Run("no path", func(t *testing.T) {
+		writer := get("")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "path is required", writer.Body.String())
+	})
+	t.Run("unknown field", func(t *testing.T) {
+		writer := get("validators.3.foo")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "unknown field", writer.Body.String())
+	})
+}
+
+func TestGetBlockProof(t *testing.T) {
+	chainService := &chainMock.ChainService{}
+	get := func(blocker *testutil.MockBlocker, path string) *httptest.ResponseRecorder {
+		s := &Server{
+			Blocker:               blocker,
+			OptimisticModeFetcher: chainService,
+			FinalizationFetcher:   chainService,
+		}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/blocks/{block_id}/proof?path="+path, nil)
+		request.SetPathValue("block_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetBlockProof(writer, request)
+		return writer
+	}
+
+	b := util.NewBeaconBlockElectra()
+	b.Block.Body.ExecutionPayload.StateRoot = bytesutil.PadTo([]byte{'s'}, 32)
+	signed, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	root, err := signed.Block().HashTreeRoot()
+	require.NoError(t, err)
+	blinded, err := signed.ToBlinded()
+	require.NoError(t, err)
+
+	for name, blk := range map[string]*testutil.MockBlocker{
+		"full":    {BlockToReturn: signed},
+		"blinded": {BlockToReturn: blinded},
+	} {
+		t.Run(name, func(t *testing.T) {
+			writer := get(blk, "body.execution_payload.state_root")
+			blockRoot, p := proofFromResponse(t, writer)
+			require.Equal(t, root, blockRoot)
+			require.Equal(t, true, proof.Verify(root, p))
+			require.DeepEqual(t, b.Block.Body.ExecutionPayload.StateRoot, p.Leaf[:])
+			require.StringContains(t, `"path":"body.execution_payload.state_root"`, writer.Body.String())
+		})
+	}
+	t.Run("not found", func(t *testing.T) {
+		writer := get(&testutil.MockBlocker{}, "slot")
+		require.Equal(t, http.StatusNotFound, writer.Code)
+	})
+}
@@ -0,0 +1,58 @@
+package beacon
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+)
+
+// GetReorgs is a HTTP handler that serves the GET /prysm/v1/beacon/reorgs endpoint.
+// It returns the chain reorgs and the late block reorg attempts and refusals recorded by the node
+// between the from_slot and to_slot query parameters, both inclusive. to_slot defaults to the
+// current slot and from_slot defaults to one epoch before to_slot.
+//
+// Events are only kept for the number of epochs configured by --reorg-history-window.
+//
+// Example usage:
+//
+//	GET /prysm/v1/beacon/reorgs?from_slot=100&to_slot=200
+func (s *Server) GetReorgs(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetReorgs")
+	defer span.End()
+
+	rawTo, to, ok := shared.UintFromQuery(w, r, "to_slot", false)
+	if !ok {
+		return
+	}
+	if rawTo == "" {
+		to = uint64(s.TimeFetcher.CurrentSlot())
+	}
+	rawFrom, from, ok := shared.UintFromQuery(w, r, "from_slot", false)
+	if !ok {
+		return
+	}
+	if rawFrom == "" && to > uint64(params.BeaconConfig().SlotsPerEpoch) {
+		from = to - uint64(params.BeaconConfig().SlotsPerEpoch)
+	}
+	if from > to {
+		httputil.HandleError(w, fmt.Sprintf("from_slot %d is higher than to_slot %d", from, to), http.StatusBadRequest)
+		return
+	}
+
+	events, err := s.BeaconDB.ReorgEvents(ctx, primitives.Slot(from), primitives.Slot(to))
+	if err != nil {
+		httputil.HandleError(w, "Could not get reorg events: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	resp := &structs.GetReorgsResponse{Data: make([]*structs.ReorgEvent, len(events))}
+	for i, e := range events {
+		resp.Data[i] = structs.ReorgEventFromConsensus(e)
+	}
+	httputil.WriteJson(w, resp)
+}
@@ -0,0 +1,87 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestServer_GetReorgs(t *testing.T) {
+	ctx := context.Background()
+	db := dbTest.SetupDB(t)
+	for _, e := range []*forkchoice.ReorgEvent{
+		{Kind: forkchoice.ChainReorg, Slot: 10, OldHeadRoot: [32]byte{'a'}},
+		{
+			Kind:        forkchoice.LateBlockReorgAttempt,
+			Slot:        40,
+			OldHeadRoot: [32]byte{'b'},
+			OldBranch:   []*forkchoice.ReorgBlock{{Root: [32]byte{'b'}, Slot: 40, ProposerIndex: 9, ArrivalMillis: 6000}},
+			OrphanedAttestations: []*forkchoice.OrphanedAttestation{
+				{BlockRoot: [32]byte{'b'}, Slot: 39, CommitteeIndex: 1, Attesters: 12},
+			},
+		},
+		{Kind: forkchoice.LateBlockReorgRefused, Slot: 45, Reason: "attestations at 10 seconds"},
+	} {
+		require.NoError(t, db.SaveReorgEvent(ctx, e))
+	}
+	currentSlot := primitives.Slot(50)
+	s := &Server{
+		BeaconDB:    db,
+		TimeFetcher: &chainMock.ChainService{Slot: &currentSlot},
+	}
+	get := func(query string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/reorgs"+query, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetReorgs(writer, request)
+		return writer
+	}
+
+	t.Run("defaults to the last epoch", func(t *testing.T) {
+		writer := get("")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetReorgsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		attempt := resp.Data[0]
+		require.Equal(t, "late_block_reorg_attempt", attempt.Kind)
+		require.Equal(t, "40", attempt.Slot)
+		require.Equal(t, hexutil.Encode([]byte{'b', 31: 0}), attempt.OldHeadRoot)
+		require.Equal(t, 1, len(attempt.OldBranch))
+		require.Equal(t, "9", attempt.OldBranch[0].ProposerIndex)
+		require.Equal(t, "6000", attempt.OldBranch[0].ArrivalMillis)
+		require.Equal(t, 1, len(attempt.OrphanedAttestations))
+		require.Equal(t, "12", attempt.OrphanedAttestations[0].Attesters)
+		require.Equal(t, "late_block_reorg_refused", resp.Data[1].Kind)
+		require.Equal(t, "attestations at 10 seconds", resp.Data[1].Reason)
+	})
+	t.Run("range", func(t *testing.T) {
+		writer := get("?from_slot=0&to_slot=40")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetReorgsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		require.Equal(t, "reorg", resp.Data[0].Kind)
+		require.Equal(t, "10", resp.Data[0].Slot)
+	})
+	t.Run("invalid range", func(t *testing.T) {
+		writer := get("?from_slot=20&to_slot=10")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "from_slot 20 is higher than to_slot 10", writer.Body.String())
+	})
+	t.Run("invalid slot", func(t *testing.T) {
+		writer := get("?from_slot=foo")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
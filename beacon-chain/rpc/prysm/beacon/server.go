@@ -2,6 +2,7 @@ package beacon
 
 import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	beacondb "github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
@@ -17,10 +18,12 @@ type Server struct {
 	OptimisticModeFetcher blockchain.OptimisticModeFetcher
 	CanonicalHistory      *stategen.CanonicalHistory
 	BeaconDB              beacondb.ReadOnlyDatabase
+	Blocker               lookup.Blocker
 	Stater                lookup.Stater
 	ChainInfoFetcher      blockchain.ChainInfoFetcher
 	FinalizationFetcher   blockchain.FinalizationFetcher
 	CoreService           *core.Service
 	Broadcaster           p2p.Broadcaster
 	BlobReceiver          blockchain.BlobReceiver
+	AttInclusionCache     *cache.AttestationInclusionCache
 }
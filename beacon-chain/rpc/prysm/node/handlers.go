@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	corenet "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -129,6 +131,37 @@ func (s *Server) RemoveTrustedPeer(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// ListExecutionClients retrieves the status of the primary and secondary execution clients of the node.
+func (s *Server) ListExecutionClients(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.ListExecutionClients")
+	defer span.End()
+
+	statuses := s.ExecutionClientsFetcher.ExecutionClientStatuses()
+	clients := make([]*structs.ExecutionClient, len(statuses))
+	for i, st := range statuses {
+		clients[i] = &structs.ExecutionClient{
+			Endpoint:       st.Endpoint,
+			Primary:        st.Primary,
+			Healthy:        st.Healthy,
+			Syncing:        st.Syncing,
+			LastResponse:   unixString(st.LastResponse),
+			UnhealthySince: unixString(st.UnhealthySince),
+		}
+		if st.LastError != nil {
+			clients[i].LastError = st.LastError.Error()
+		}
+	}
+	httputil.WriteJson(w, &structs.ExecutionClientsResponse{Data: clients})
+}
+
+// unixString formats a time as unix seconds, with "0" for the zero time.
+func unixString(t time.Time) string {
+	if t.IsZero() {
+		return "0"
+	}
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
 // httpPeerInfo does the same thing as peerInfo function in node.go but returns the
 // http peer response.
 func httpPeerInfo(peerStatus *peers.Status, id peer.ID) (*structs.Peer, error) {
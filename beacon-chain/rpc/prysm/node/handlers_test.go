@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
@@ -14,7 +15,9 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	libp2ptest "github.com/libp2p/go-libp2p/p2p/host/peerstore/test"
 	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
 	mockp2p "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
@@ -249,3 +252,38 @@ func TestRemoveTrustedPeer_EmptyParameter(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, writer.Code)
 	assert.Equal(t, "Could not decode peer id: failed to parse peer ID: invalid cid: cid too short", e.Message)
 }
+
+type mockExecutionClients []*execution.ExecutionClientStatus
+
+func (m mockExecutionClients) ExecutionClientStatuses() []*execution.ExecutionClientStatus {
+	return m
+}
+
+func TestListExecutionClients(t *testing.T) {
+	s := Server{ExecutionClientsFetcher: mockExecutionClients{
+		{Endpoint: "http://primary:8551", Primary: true, Syncing: true, LastResponse: time.Unix(100, 0), UnhealthySince: time.Unix(50, 0)},
+		{Endpoint: "http://secondary:8551", LastError: errors.New("connection refused")},
+	}}
+
+	request := httptest.NewRequest("GET", "http://foo.example/prysm/v1/node/execution_clients", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.ListExecutionClients(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.ExecutionClientsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 2, len(resp.Data))
+	assert.DeepEqual(t, &structs.ExecutionClient{
+		Endpoint:       "http://primary:8551",
+		Primary:        true,
+		Syncing:        true,
+		LastResponse:   "100",
+		UnhealthySince: "50",
+	}, resp.Data[0])
+	assert.DeepEqual(t, &structs.ExecutionClient{
+		Endpoint:       "http://secondary:8551",
+		LastResponse:   "0",
+		UnhealthySince: "0",
+		LastError:      "connection refused",
+	}, resp.Data[1])
+}
@@ -0,0 +1,74 @@
+package slasher
+
+import (
+	"net/http"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/slashing"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+)
+
+// GetSlashings is a HTTP handler that serves the GET /prysm/v1/slasher/slashings endpoint.
+// It returns the attester and proposer slashings recorded by the node, whether they were detected
+// by its slasher, received over gossip or the API, or seen included in a canonical block, with the
+// conflicting messages, the time they were first seen and their inclusion slot.
+//
+// The optional validator_index query parameter, which can be repeated, only keeps the slashings of
+// the given validators. The optional from_epoch query parameter only keeps the slashings of offenses
+// at or after the given epoch.
+//
+// Example usage:
+//
+//	GET /prysm/v1/slasher/slashings?validator_index=12&validator_index=40&from_epoch=1000
+func (s *Server) GetSlashings(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.GetSlashings")
+	defer span.End()
+
+	_, fromEpoch, ok := shared.UintFromQuery(w, r, "from_epoch", false)
+	if !ok {
+		return
+	}
+	rawIndices := r.URL.Query()["validator_index"]
+	indices := make([]primitives.ValidatorIndex, len(rawIndices))
+	for i, raw := range rawIndices {
+		index, ok := shared.ValidateUint(w, "validator_index", raw)
+		if !ok {
+			return
+		}
+		indices[i] = primitives.ValidatorIndex(index)
+	}
+
+	evidence, err := s.BeaconDB.SlashingEvidenceSince(ctx, primitives.Epoch(fromEpoch))
+	if err != nil {
+		httputil.HandleError(w, "Could not get slashing evidence: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	resp := &structs.GetSlashingEvidenceResponse{Data: make([]*structs.SlashingEvidence, 0)}
+	for _, e := range evidence {
+		if !involvesAny(e, indices) {
+			continue
+		}
+		data, err := structs.SlashingEvidenceFromConsensus(e)
+		if err != nil {
+			httputil.HandleError(w, "Could not convert slashing evidence: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp.Data = append(resp.Data, data)
+	}
+	httputil.WriteJson(w, resp)
+}
+
+func involvesAny(e *slashing.Evidence, indices []primitives.ValidatorIndex) bool {
+	if len(indices) == 0 {
+		return true
+	}
+	for _, index := range indices {
+		if e.Involves(index) {
+			return true
+		}
+	}
+	return false
+}
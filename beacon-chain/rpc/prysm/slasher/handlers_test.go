@@ -0,0 +1,98 @@
+package slasher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/slashing"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestServer_GetSlashings(t *testing.T) {
+	ctx := context.Background()
+	db := dbTest.SetupDB(t)
+
+	att1 := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{3, 4}})
+	att1.Data.Target.Epoch = 2
+	att2 := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{3, 4}})
+	att2.Data.Target.Epoch = 2
+	att2.Data.BeaconBlockRoot = bytesutil.PadTo([]byte{'b'}, 32)
+	attesterSlashing, err := slashing.NewAttesterSlashingEvidence(
+		&ethpb.AttesterSlashing{Attestation_1: att1, Attestation_2: att2}, slashing.SourceSlasher, 100,
+	)
+	require.NoError(t, err)
+	attesterSlashing.Included = true
+	attesterSlashing.InclusionSlot = 90
+	proposerSlashing, err := slashing.NewProposerSlashingEvidence(&ethpb.ProposerSlashing{
+		Header_1: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: 200, ProposerIndex: 7}}),
+		Header_2: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: 200, ProposerIndex: 7, BodyRoot: bytesutil.PadTo([]byte{'b'}, 32)}}),
+	}, slashing.SourceGossip, 200)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveSlashingEvidence(ctx, attesterSlashing))
+	require.NoError(t, db.SaveSlashingEvidence(ctx, proposerSlashing))
+
+	s := &Server{BeaconDB: db}
+	get := func(query string) *structs.GetSlashingEvidenceResponse {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/slashings"+query, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetSlashings(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetSlashingEvidenceResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		return resp
+	}
+
+	t.Run("all", func(t *testing.T) {
+		resp := get("")
+		require.Equal(t, 2, len(resp.Data))
+		e := resp.Data[0]
+		require.Equal(t, "2", e.Epoch)
+		require.DeepEqual(t, []string{"3", "4"}, e.ValidatorIndices)
+		require.Equal(t, "slasher", e.Source)
+		require.Equal(t, "100", e.Timestamp)
+		require.Equal(t, true, e.Included)
+		require.Equal(t, "90", e.InclusionSlot)
+		require.Equal(t, "phase0", e.Version)
+		as := &structs.AttesterSlashing{}
+		require.NoError(t, json.Unmarshal(e.AttesterSlashing, as))
+		require.DeepEqual(t, []string{"3", "4"}, as.Attestation1.AttestingIndices)
+		e = resp.Data[1]
+		require.Equal(t, "gossip", e.Source)
+		require.Equal(t, false, e.Included)
+		require.Equal(t, "", e.InclusionSlot)
+		require.Equal(t, "7", e.ProposerSlashing.SignedHeader1.Message.ProposerIndex)
+	})
+	t.Run("validator index", func(t *testing.T) {
+		resp := get("?validator_index=4")
+		require.Equal(t, 1, len(resp.Data))
+		require.Equal(t, "2", resp.Data[0].Epoch)
+		resp = get("?validator_index=5&validator_index=7")
+		require.Equal(t, 1, len(resp.Data))
+		require.Equal(t, "6", resp.Data[0].Epoch)
+	})
+	t.Run("from epoch", func(t *testing.T) {
+		resp := get("?from_epoch=3")
+		require.Equal(t, 1, len(resp.Data))
+		require.Equal(t, "6", resp.Data[0].Epoch)
+		resp = get("?from_epoch=7")
+		require.Equal(t, 0, len(resp.Data))
+	})
+	t.Run("invalid validator index", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/slashings?validator_index=foo", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetSlashings(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "validator_index", writer.Body.String())
+	})
+}
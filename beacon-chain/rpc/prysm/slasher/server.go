@@ -0,0 +1,9 @@
+package slasher
+
+import (
+	beacondb "github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+)
+
+type Server struct {
+	BeaconDB beacondb.ReadOnlyDatabase
+}
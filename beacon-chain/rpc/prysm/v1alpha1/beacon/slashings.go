@@ -5,6 +5,7 @@ import (
 
 	"github.com/prysmaticlabs/prysm/v5/config/features"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	slashingtypes "github.com/prysmaticlabs/prysm/v5/consensus-types/slashing"
 	"github.com/prysmaticlabs/prysm/v5/container/slice"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"google.golang.org/grpc/codes"
@@ -25,6 +26,9 @@ func (bs *Server) SubmitProposerSlashing(
 	if err := bs.SlashingsPool.InsertProposerSlashing(ctx, beaconState, req); err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not insert proposer slashing into pool: %v", err)
 	}
+	if bs.SlashingArchive != nil {
+		bs.SlashingArchive.RecordProposerSlashing(ctx, req, slashingtypes.SourceAPI)
+	}
 	if !features.Get().DisableBroadcastSlashings {
 		if err := bs.Broadcaster.Broadcast(ctx, req); err != nil {
 			return nil, status.Errorf(codes.Internal, "Could not broadcast slashing object: %v", err)
@@ -55,6 +59,9 @@ func (bs *Server) submitAttesterSlashing(ctx context.Context, slashing ethpb.Att
 	if err := bs.SlashingsPool.InsertAttesterSlashing(ctx, beaconState, slashing); err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not insert attester slashing into pool: %v", err)
 	}
+	if bs.SlashingArchive != nil {
+		bs.SlashingArchive.RecordAttesterSlashing(ctx, slashing, slashingtypes.SourceAPI)
+	}
 	if !features.Get().DisableBroadcastSlashings {
 		if err := bs.Broadcaster.Broadcast(ctx, slashing); err != nil {
 			return nil, status.Errorf(codes.Internal, "Could not broadcast slashing object: %v", err)
@@ -36,6 +36,9 @@ func (vs *Server) SubmitAggregateSelectionProof(ctx context.Context, req *ethpb.
 		}
 	}
 	best := bestAggregate(atts, req.CommitteeIndex, indexInCommittee)
+	if err := vs.disputedStatus(ctx, best.GetData().BeaconBlockRoot); err != nil {
+		return nil, err
+	}
 	attAndProof := &ethpb.AggregateAttestationAndProof{
 		Aggregate:       best,
 		SelectionProof:  req.SlotSignature,
@@ -67,6 +70,9 @@ func (vs *Server) SubmitAggregateSelectionProofElectra(
 		}
 	}
 	best := bestAggregate(atts, req.CommitteeIndex, indexInCommittee)
+	if err := vs.disputedStatus(ctx, best.GetData().BeaconBlockRoot); err != nil {
+		return nil, err
+	}
 	attAndProof := &ethpb.AggregateAttestationAndProofElectra{
 		Aggregate:       best,
 		SelectionProof:  req.SlotSignature,
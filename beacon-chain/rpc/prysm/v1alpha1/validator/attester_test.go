@@ -341,6 +341,29 @@ func TestGetAttestationData_Optimistic(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGetAttestationData_DisputedBranch(t *testing.T) {
+	beaconState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	as := &Server{
+		SyncChecker:           &mockSync.Sync{},
+		OptimisticModeFetcher: &mock.ChainService{Optimistic: false},
+		TimeFetcher:           &mock.ChainService{Genesis: time.Now()},
+		CoreService: &core.Service{
+			AttestationCache:      cache.NewAttestationCache(),
+			GenesisTimeFetcher:    &mock.ChainService{Genesis: time.Now()},
+			HeadFetcher:           &mock.ChainService{State: beaconState, Root: make([]byte, 32)},
+			FinalizedFetcher:      &mock.ChainService{CurrentJustifiedCheckPoint: &ethpb.Checkpoint{}},
+			OptimisticModeFetcher: &mock.ChainService{Optimistic: false},
+			PayloadDisputeFetcher: &mock.ChainService{DisputedBranch: true},
+		},
+	}
+	_, err = as.GetAttestationData(context.Background(), &ethpb.AttestationDataRequest{})
+	s, ok := status.FromError(err)
+	require.Equal(t, true, ok)
+	require.DeepEqual(t, codes.Unavailable, s.Code())
+	require.ErrorContains(t, "disputed by the verification execution client", err)
+}
+
 func TestServer_GetAttestationData_InvalidRequestSlot(t *testing.T) {
 	ctx := context.Background()
 
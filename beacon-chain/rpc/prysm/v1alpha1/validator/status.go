@@ -7,6 +7,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -272,6 +273,18 @@ func (vs *Server) optimisticStatus(ctx context.Context) error {
 	return status.Errorf(codes.Unavailable, errOptimisticMode.Error())
 }
 
+// disputedStatus returns an error if the block descends from an execution payload disputed by the
+// verification execution client.
+func (vs *Server) disputedStatus(ctx context.Context, root []byte) error {
+	if vs.CoreService == nil {
+		return nil
+	}
+	if rpcErr := vs.CoreService.CheckDisputedBranch(ctx, root); rpcErr != nil {
+		return status.Errorf(core.ErrorReasonToGRPC(rpcErr.Reason), rpcErr.Err.Error())
+	}
+	return nil
+}
+
 // validatorStatus searches for the requested validator's state and deposit to retrieve its inclusion estimate. Also returns the validators index.
 func (vs *Server) validatorStatus(
 	ctx context.Context,
@@ -26,6 +26,9 @@ func (vs *Server) GetSyncMessageBlockRoot(
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not retrieve head root: %v", err)
 	}
+	if err := vs.disputedStatus(ctx, r); err != nil {
+		return nil, err
+	}
 
 	return &ethpb.SyncMessageBlockRootResponse{
 		Root: r,
@@ -76,6 +79,9 @@ func (vs *Server) GetSyncCommitteeContribution(
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not get head root: %v", err)
 	}
+	if err := vs.disputedStatus(ctx, headRoot); err != nil {
+		return nil, err
+	}
 	sig, aggregatedBits, err := vs.CoreService.AggregatedSigAndAggregationBits(
 		ctx,
 		&ethpb.AggregatedSigAndAggregationBitsRequest{
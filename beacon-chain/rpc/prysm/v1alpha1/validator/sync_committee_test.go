@@ -62,6 +62,21 @@ func TestGetSyncMessageBlockRoot_Optimistic(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGetSyncMessageBlockRoot_DisputedBranch(t *testing.T) {
+	server := &Server{
+		HeadFetcher: &mock.ChainService{Root: make([]byte, 32)},
+		TimeFetcher: &mock.ChainService{Genesis: time.Now()},
+		CoreService: &core.Service{
+			PayloadDisputeFetcher: &mock.ChainService{DisputedBranch: true},
+		},
+	}
+	_, err := server.GetSyncMessageBlockRoot(context.Background(), &emptypb.Empty{})
+	s, ok := status.FromError(err)
+	require.Equal(t, true, ok)
+	require.DeepEqual(t, codes.Unavailable, s.Code())
+	require.ErrorContains(t, "disputed by the verification execution client", err)
+}
+
 func TestSubmitSyncMessage_OK(t *testing.T) {
 	st, _ := util.DeterministicGenesisStateAltair(t, 10)
 	server := &Server{
@@ -0,0 +1,272 @@
+package validator
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/validator"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultMaxHistoryEpochs is the default limit of the number of epochs served by one GetHistory or GetRewards request.
+const DefaultMaxHistoryEpochs = primitives.Epoch(7200) // About one month.
+
+// historyRecordSize is the size of the SSZ encoding of a validator record of GetHistory.
+const historyRecordSize = 33
+
+// GetHistory serves the status, balance and effective balance of the requested validators at the start of every
+// epoch of an inclusive epoch range. The states are computed by walking the canonical chain once from the start of
+// the range, rather than regenerating each of them.
+//
+// The response is streamed one epoch at a time, either as JSON lines of structs.ValidatorEpochHistory or, when SSZ is
+// requested, as an SSZ list of fixed size records (epoch uint64, index uint64, balance uint64, effective_balance
+// uint64, status uint8), status being the ordinal of validator.Status. Validators that are not in the registry at an
+// epoch are left out of that epoch. An error occurring after the response started ends the stream early, with a
+// last JSON line describing the error in the JSON encoding. SSZ responses are aborted instead, so that clients can't
+// mistake a truncated stream for a complete one.
+func (s *Server) GetHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetHistory")
+	defer span.End()
+
+	var req structs.GetValidatorHistoryRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	switch {
+	case errors.Is(err, io.EOF):
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	case err != nil:
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(req.Ids) == 0 {
+		httputil.HandleError(w, "ids is required", http.StatusBadRequest)
+		return
+	}
+	startEpoch, endEpoch, ok := s.epochRange(w, req.StartEpoch, req.EndEpoch)
+	if !ok {
+		return
+	}
+	if currentEpoch := slots.ToEpoch(s.TimeFetcher.CurrentSlot()); endEpoch > currentEpoch {
+		httputil.HandleError(w, fmt.Sprintf("end_epoch %d is after the current epoch %d", endEpoch, currentEpoch), http.StatusBadRequest)
+		return
+	}
+	ids, ok := parseHistoryIds(w, req.Ids)
+	if !ok {
+		return
+	}
+
+	startSlot, err := slots.EpochStart(startEpoch)
+	if err != nil {
+		httputil.HandleError(w, "Could not get start slot: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	st, err := s.Stater.StateBySlot(ctx, startSlot)
+	if err != nil {
+		httputil.HandleError(w, fmt.Sprintf("Could not get state at slot %d: %v", startSlot, err), http.StatusInternalServerError)
+		return
+	}
+	walker := stategen.NewWalker(s.BeaconDB, s.CanonicalFetcher, st.Copy())
+
+	ssz := httputil.RespondWithSsz(r)
+	if ssz {
+		w.Header().Set("Content-Type", api.OctetStreamMediaType)
+	} else {
+		w.Header().Set("Content-Type", api.JsonLinesMediaType)
+	}
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		if epoch > startEpoch {
+			slot, err := slots.EpochStart(epoch)
+			if err == nil {
+				st, err = walker.AdvanceTo(ctx, slot)
+			}
+			if err != nil {
+				writeHistoryError(enc, ssz, fmt.Sprintf("Could not compute state of epoch %d: %v", epoch, err))
+				return
+			}
+		}
+		records, err := historyRecords(st, epoch, ids)
+		if err != nil {
+			writeHistoryError(enc, ssz, fmt.Sprintf("Could not get validators of epoch %d: %v", epoch, err))
+			return
+		}
+		if ssz {
+			err = writeHistorySSZ(w, epoch, records)
+		} else {
+			err = enc.Encode(historyJSON(epoch, records))
+		}
+		if err != nil {
+			log.WithError(err).Debug("Could not write validator history")
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+// epochRange parses an inclusive epoch range and checks it against the limit of the server.
+func (s *Server) epochRange(w http.ResponseWriter, rawStart, rawEnd string) (primitives.Epoch, primitives.Epoch, bool) {
+	start, ok := shared.ValidateUint(w, "start_epoch", rawStart)
+	if !ok {
+		return 0, 0, false
+	}
+	end, ok := shared.ValidateUint(w, "end_epoch", rawEnd)
+	if !ok {
+		return 0, 0, false
+	}
+	startEpoch, endEpoch := primitives.Epoch(start), primitives.Epoch(end)
+	if endEpoch < startEpoch {
+		httputil.HandleError(w, "end_epoch is lower than start_epoch", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	maxEpochs := s.MaxHistoryEpochs
+	if maxEpochs == 0 {
+		maxEpochs = DefaultMaxHistoryEpochs
+	}
+	if endEpoch-startEpoch >= maxEpochs {
+		httputil.HandleError(w, fmt.Sprintf("Epoch range exceeds the limit of %d epochs", maxEpochs), http.StatusBadRequest)
+		return 0, 0, false
+	}
+	return startEpoch, endEpoch, true
+}
+
+// historyId is a requested validator, given either by index or by public key.
+type historyId struct {
+	index  primitives.ValidatorIndex
+	pubkey []byte
+}
+
+func parseHistoryIds(w http.ResponseWriter, rawIds []string) ([]historyId, bool) {
+	ids := make([]historyId, len(rawIds))
+	for i, rawId := range rawIds {
+		pubkey, err := hexutil.Decode(rawId)
+		if err == nil {
+			if len(pubkey) != fieldparams.BLSPubkeyLength {
+				httputil.HandleError(w, fmt.Sprintf("Pubkey length is %d instead of %d", len(pubkey), fieldparams.BLSPubkeyLength), http.StatusBadRequest)
+				return nil, false
+			}
+			ids[i] = historyId{pubkey: pubkey}
+			continue
+		}
+		index, err := strconv.ParseUint(rawId, 10, 64)
+		if err != nil {
+			httputil.HandleError(w, fmt.Sprintf("Invalid validator index %s", rawId), http.StatusBadRequest)
+			return nil, false
+		}
+		ids[i] = historyId{index: primitives.ValidatorIndex(index)}
+	}
+	return ids, true
+}
+
+// historyRecord is the state of a validator at the start of an epoch.
+type historyRecord struct {
+	index            primitives.ValidatorIndex
+	status           validator.Status
+	balance          uint64
+	effectiveBalance uint64
+}
+
+// resolveHistoryIds returns the indices of the requested validators that are in the registry of the state.
+func resolveHistoryIds(st state.ReadOnlyBeaconState, ids []historyId) []primitives.ValidatorIndex {
+	indices := make([]primitives.ValidatorIndex, 0, len(ids))
+	for _, id := range ids {
+		index := id.index
+		if id.pubkey != nil {
+			var ok bool
+			index, ok = st.ValidatorIndexByPubkey(bytesutil.ToBytes48(id.pubkey))
+			if !ok {
+				continue
+			}
+		}
+		if uint64(index) >= uint64(st.NumValidators()) {
+			continue
+		}
+		indices = append(indices, index)
+	}
+	return indices
+}
+
+func historyRecords(st state.ReadOnlyBeaconState, epoch primitives.Epoch, ids []historyId) ([]historyRecord, error) {
+	indices := resolveHistoryIds(st, ids)
+	records := make([]historyRecord, 0, len(indices))
+	for _, index := range indices {
+		val, err := st.ValidatorAtIndexReadOnly(index)
+		if err != nil {
+			return nil, err
+		}
+		balance, err := st.BalanceAtIndex(index)
+		if err != nil {
+			return nil, err
+		}
+		status, err := helpers.ValidatorSubStatus(val, epoch)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, historyRecord{
+			index:            index,
+			status:           status,
+			balance:          balance,
+			effectiveBalance: val.EffectiveBalance(),
+		})
+	}
+	return records, nil
+}
+
+func historyJSON(epoch primitives.Epoch, records []historyRecord) *structs.ValidatorEpochHistory {
+	validators := make([]*structs.ValidatorEpochRecord, len(records))
+	for i, r := range records {
+		validators[i] = &structs.ValidatorEpochRecord{
+			Index:            fmt.Sprintf("%d", r.index),
+			Status:           r.status.String(),
+			Balance:          fmt.Sprintf("%d", r.balance),
+			EffectiveBalance: fmt.Sprintf("%d", r.effectiveBalance),
+		}
+	}
+	return &structs.ValidatorEpochHistory{Epoch: fmt.Sprintf("%d", epoch), Validators: validators}
+}
+
+func writeHistorySSZ(w io.Writer, epoch primitives.Epoch, records []historyRecord) error {
+	buf := make([]byte, 0, len(records)*historyRecordSize)
+	for _, r := range records {
+		buf = binary.LittleEndian.AppendUint64(buf, uint64(epoch))
+		buf = binary.LittleEndian.AppendUint64(buf, uint64(r.index))
+		buf = binary.LittleEndian.AppendUint64(buf, r.balance)
+		buf = binary.LittleEndian.AppendUint64(buf, r.effectiveBalance)
+		buf = append(buf, uint8(r.status))
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// writeHistoryError ends a JSON lines response with the error. SSZ responses have no way to carry the error, so the
+// connection is aborted.
+func writeHistoryError(enc *json.Encoder, ssz bool, msg string) {
+	log.Debug(msg)
+	if ssz {
+		panic(http.ErrAbortHandler)
+	}
+	if err := enc.Encode(&httputil.DefaultJsonError{Message: msg, Code: http.StatusInternalServerError}); err != nil {
+		log.WithError(err).Debug("Could not write validator history error")
+	}
+}
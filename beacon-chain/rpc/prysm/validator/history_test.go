@@ -0,0 +1,115 @@
+package validator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/validator"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestServer_GetHistory(t *testing.T) {
+	cfg := params.BeaconConfig()
+	st, _ := util.DeterministicGenesisState(t, 8)
+	startSlot := 2 * cfg.SlotsPerEpoch
+	require.NoError(t, st.SetSlot(startSlot))
+	pubkey := st.PubkeyAtIndex(5)
+
+	currentSlot := 4 * cfg.SlotsPerEpoch
+	s := &Server{
+		BeaconDB:         dbtest.SetupDB(t),
+		Stater:           &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{startSlot: st}},
+		CanonicalFetcher: &mock.ChainService{},
+		TimeFetcher:      &mock.ChainService{Slot: &currentSlot},
+		MaxHistoryEpochs: 4,
+	}
+	postWithContext := func(ctx context.Context, body, accept string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validators/history", bytes.NewBufferString(body)).WithContext(ctx)
+		request.Header.Set("Accept", accept)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetHistory(writer, request)
+		return writer
+	}
+	post := func(body, accept string) *httptest.ResponseRecorder {
+		return postWithContext(context.Background(), body, accept)
+	}
+	body := fmt.Sprintf(`{"ids":["1","%s","100"],"start_epoch":"2","end_epoch":"4"}`, hexutil.Encode(pubkey[:]))
+
+	t.Run("json lines", func(t *testing.T) {
+		writer := post(body, api.JsonLinesMediaType)
+		require.Equal(t, http.StatusOK, writer.Code)
+		require.Equal(t, api.JsonLinesMediaType, writer.Header().Get("Content-Type"))
+		scanner := bufio.NewScanner(writer.Body)
+		epoch := primitives.Epoch(2)
+		for ; scanner.Scan(); epoch++ {
+			h := &structs.ValidatorEpochHistory{}
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), h))
+			require.Equal(t, fmt.Sprintf("%d", epoch), h.Epoch)
+			require.Equal(t, 2, len(h.Validators))
+			require.Equal(t, "1", h.Validators[0].Index)
+			require.Equal(t, "5", h.Validators[1].Index)
+			require.Equal(t, validator.ActiveOngoing.String(), h.Validators[1].Status)
+			require.Equal(t, fmt.Sprintf("%d", cfg.MaxEffectiveBalance), h.Validators[1].EffectiveBalance)
+		}
+		require.Equal(t, primitives.Epoch(5), epoch)
+		// The start state is not modified by the walk.
+		require.Equal(t, startSlot, st.Slot())
+	})
+	t.Run("ssz", func(t *testing.T) {
+		writer := post(body, api.OctetStreamMediaType)
+		require.Equal(t, http.StatusOK, writer.Code)
+		b := writer.Body.Bytes()
+		require.Equal(t, 3*2*historyRecordSize, len(b))
+		require.Equal(t, uint64(3), binary.LittleEndian.Uint64(b[2*historyRecordSize:]))
+		require.Equal(t, uint64(1), binary.LittleEndian.Uint64(b[2*historyRecordSize+8:]))
+		require.Equal(t, uint8(validator.ActiveOngoing), b[historyRecordSize-1])
+	})
+	t.Run("error after streaming started", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		writer := postWithContext(ctx, body, api.JsonLinesMediaType)
+		require.Equal(t, http.StatusOK, writer.Code)
+		lines := bytes.Split(bytes.TrimSpace(writer.Body.Bytes()), []byte("\n"))
+		require.Equal(t, 2, len(lines))
+		require.StringContains(t, "Could not compute state of epoch 3", string(lines[1]))
+
+		defer func() {
+			require.Equal(t, http.ErrAbortHandler, recover())
+		}()
+		postWithContext(ctx, body, api.OctetStreamMediaType)
+		t.Fatal("SSZ response was not aborted")
+	})
+	t.Run("range limit", func(t *testing.T) {
+		writer := post(`{"ids":["1"],"start_epoch":"0","end_epoch":"4"}`, api.JsonMediaType)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "exceeds the limit of 4 epochs", writer.Body.String())
+	})
+	t.Run("future epoch", func(t *testing.T) {
+		writer := post(`{"ids":["1"],"start_epoch":"3","end_epoch":"5"}`, api.JsonMediaType)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "after the current epoch", writer.Body.String())
+	})
+	t.Run("invalid id", func(t *testing.T) {
+		writer := post(`{"ids":["foo"],"start_epoch":"2","end_epoch":"2"}`, api.JsonMediaType)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "Invalid validator index foo", writer.Body.String())
+	})
+}
@@ -0,0 +1,224 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/electra"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/validators"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+const pendingDepositStatus = "pending_deposit"
+
+// GetLifecycles projects, from the head state, the activation eligibility, activation, exit and withdrawable epochs
+// of the validators given by the repeated id query parameter, a validator index or public key, along with their
+// positions in the activation and exit queues and the slot of their next withdrawal by the withdrawal sweep.
+//
+// Validators that did not initiate their exit are projected to exit as if they did in the current epoch. A public
+// key that is not in the registry yet is projected from its pending deposits. The number of ids is limited to the
+// maximum page size of the RPC.
+func (s *Server) GetLifecycles(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetLifecycles")
+	defer span.End()
+
+	rawIds := r.URL.Query()["id"]
+	if len(rawIds) == 0 {
+		httputil.HandleError(w, "id is required", http.StatusBadRequest)
+		return
+	}
+	if len(rawIds) > cmd.Get().MaxRPCPageSize {
+		httputil.HandleError(w, fmt.Sprintf("Number of ids exceeds the limit of %d", cmd.Get().MaxRPCPageSize), http.StatusBadRequest)
+		return
+	}
+	st, err := s.ChainInfoFetcher.HeadState(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get head state: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	projector, err := validators.NewLifecycleProjector(ctx, st)
+	if err != nil {
+		httputil.HandleError(w, "Could not project validator lifecycles: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var sweep *blocks.WithdrawalSweep
+	if st.Version() >= version.Capella {
+		sweep, err = blocks.NewWithdrawalSweep(st)
+		if err != nil {
+			httputil.HandleError(w, "Could not project withdrawal sweep: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	pending := &pendingDeposits{st: st}
+	data := make([]*structs.ValidatorLifecycle, 0, len(rawIds))
+	for _, rawId := range rawIds {
+		pubkey, err := hexutil.Decode(rawId)
+		if err == nil {
+			if len(pubkey) != fieldparams.BLSPubkeyLength {
+				httputil.HandleError(w, fmt.Sprintf("Pubkey length is %d instead of %d", len(pubkey), fieldparams.BLSPubkeyLength), http.StatusBadRequest)
+				return
+			}
+			index, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey))
+			if !ok {
+				lifecycle, found, err := pendingDepositLifecycle(pending, projector, pubkey)
+				if err != nil {
+					httputil.HandleError(w, "Could not project pending deposit lifecycle: "+err.Error(), http.StatusInternalServerError)
+					return
+				}
+				if !found {
+					httputil.HandleError(w, fmt.Sprintf("Unknown validator: %s", rawId), http.StatusNotFound)
+					return
+				}
+				data = append(data, lifecycle)
+				continue
+			}
+			rawId = strconv.FormatUint(uint64(index), 10)
+		}
+		index, err := strconv.ParseUint(rawId, 10, 64)
+		if err != nil || index >= uint64(st.NumValidators()) {
+			httputil.HandleError(w, fmt.Sprintf("Invalid validator index %s", rawId), http.StatusBadRequest)
+			return
+		}
+		lifecycle, err := validatorLifecycle(r, st, projector, sweep, primitives.ValidatorIndex(index))
+		if err != nil {
+			httputil.HandleError(w, fmt.Sprintf("Could not project lifecycle of validator %d: %v", index, err), http.StatusInternalServerError)
+			return
+		}
+		data = append(data, lifecycle)
+	}
+
+	httputil.WriteJson(w, &structs.GetValidatorLifecyclesResponse{
+		Slot: fmt.Sprintf("%d", st.Slot()),
+		Data: data,
+	})
+}
+
+func validatorLifecycle(
+	r *http.Request,
+	st state.BeaconState,
+	projector *validators.LifecycleProjector,
+	sweep *blocks.WithdrawalSweep,
+	index primitives.ValidatorIndex,
+) (*structs.ValidatorLifecycle, error) {
+	val, err := st.ValidatorAtIndexReadOnly(index)
+	if err != nil {
+		return nil, err
+	}
+	status, err := helpers.ValidatorSubStatus(val, slots.ToEpoch(st.Slot()))
+	if err != nil {
+		return nil, err
+	}
+	l, err := projector.Project(r.Context(), index)
+	if err != nil {
+		return nil, err
+	}
+	pubkey := val.PublicKey()
+	lifecycle := lifecycleFromProjection(l)
+	lifecycle.Index = fmt.Sprintf("%d", index)
+	lifecycle.Pubkey = hexutil.Encode(pubkey[:])
+	lifecycle.Status = status.String()
+	if sweep != nil {
+		slot, position, ok, err := sweep.NextWithdrawalSlot(index)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			lifecycle.NextWithdrawalSlot = fmt.Sprintf("%d", slot)
+			lifecycle.WithdrawalSweepPosition = fmt.Sprintf("%d", position)
+		}
+	}
+	return lifecycle, nil
+}
+
+// pendingDeposits holds the pending deposits of a state along with the estimated epochs at which they are applied.
+// They are loaded once, on first use, for all the ids of a request.
+type pendingDeposits struct {
+	st       state.BeaconState
+	loaded   bool
+	deposits []*ethpb.PendingDeposit
+	epochs   []primitives.Epoch
+}
+
+func (p *pendingDeposits) load() ([]*ethpb.PendingDeposit, []primitives.Epoch, error) {
+	if p.loaded || p.st.Version() < version.Electra {
+		return p.deposits, p.epochs, nil
+	}
+	deposits, err := p.st.PendingDeposits()
+	if err != nil {
+		return nil, nil, err
+	}
+	epochs, err := electra.EstimatePendingDepositEpochs(p.st)
+	if err != nil {
+		return nil, nil, err
+	}
+	p.deposits, p.epochs, p.loaded = deposits, epochs, true
+	return deposits, epochs, nil
+}
+
+// pendingDepositLifecycle projects the lifecycle of a validator that is not in the registry from its pending
+// deposits. The validator becomes eligible for activation once its deposits reach the activation balance.
+func pendingDepositLifecycle(pending *pendingDeposits, projector *validators.LifecycleProjector, pubkey []byte) (*structs.ValidatorLifecycle, bool, error) {
+	deposits, epochs, err := pending.load()
+	if err != nil {
+		return nil, false, err
+	}
+	found := false
+	position := 0
+	fundedEpoch := params.BeaconConfig().FarFutureEpoch
+	amount := uint64(0)
+	for i, d := range deposits {
+		if !bytes.Equal(d.PublicKey, pubkey) {
+			continue
+		}
+		if !found {
+			found, position = true, i+1
+		}
+		amount += d.Amount
+		if amount >= params.BeaconConfig().MinActivationBalance && fundedEpoch == params.BeaconConfig().FarFutureEpoch {
+			fundedEpoch = epochs[i]
+		}
+	}
+	if !found {
+		return nil, false, nil
+	}
+	lifecycle := lifecycleFromProjection(projector.ProjectDeposit(fundedEpoch))
+	lifecycle.Pubkey = hexutil.Encode(pubkey)
+	lifecycle.Status = pendingDepositStatus
+	lifecycle.PendingDepositQueuePosition = fmt.Sprintf("%d", position)
+	return lifecycle, true, nil
+}
+
+func lifecycleFromProjection(l *validators.Lifecycle) *structs.ValidatorLifecycle {
+	lifecycle := &structs.ValidatorLifecycle{
+		ActivationEligibilityEpoch: fmt.Sprintf("%d", l.ActivationEligibilityEpoch),
+		ActivationEpoch:            fmt.Sprintf("%d", l.ActivationEpoch),
+		ExitEpoch:                  fmt.Sprintf("%d", l.ExitEpoch),
+		WithdrawableEpoch:          fmt.Sprintf("%d", l.WithdrawableEpoch),
+		ExitInitiated:              l.ExitInitiated,
+	}
+	if l.ActivationQueuePosition != 0 {
+		lifecycle.ActivationQueuePosition = fmt.Sprintf("%d", l.ActivationQueuePosition)
+	}
+	if l.ExitQueuePosition != 0 {
+		lifecycle.ExitQueuePosition = fmt.Sprintf("%d", l.ExitQueuePosition)
+	}
+	return lifecycle
+}
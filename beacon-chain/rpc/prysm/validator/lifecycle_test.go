@@ -0,0 +1,129 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/electra"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/validators"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestServer_GetLifecycles(t *testing.T) {
+	ctx := context.Background()
+	cfg := params.BeaconConfig()
+	vals := make([]*ethpb.Validator, 8)
+	balances := make([]uint64, len(vals))
+	for i := range vals {
+		creds := make([]byte, 32)
+		creds[0] = cfg.ETH1AddressWithdrawalPrefixByte
+		vals[i] = &ethpb.Validator{
+			PublicKey:                  bytesutil.PadTo([]byte{byte(i + 1)}, 48),
+			WithdrawalCredentials:      creds,
+			EffectiveBalance:           cfg.MinActivationBalance,
+			ActivationEligibilityEpoch: 0,
+			ExitEpoch:                  cfg.FarFutureEpoch,
+			WithdrawableEpoch:          cfg.FarFutureEpoch,
+		}
+		balances[i] = cfg.MinActivationBalance
+	}
+	// Validator 3 has an excess balance to withdraw.
+	balances[3] += cfg.EffectiveBalanceIncrement
+	unknown := bytesutil.PadTo([]byte{0xff}, 48)
+	st, err := util.NewBeaconStateElectra()
+	require.NoError(t, err)
+	require.NoError(t, st.SetValidators(vals))
+	require.NoError(t, st.SetBalances(balances))
+	require.NoError(t, st.SetSlot(10*cfg.SlotsPerEpoch))
+	require.NoError(t, st.SetPendingDeposits([]*ethpb.PendingDeposit{
+		{PublicKey: vals[0].PublicKey, WithdrawalCredentials: vals[0].WithdrawalCredentials, Amount: cfg.EffectiveBalanceIncrement, Signature: make([]byte, 96)},
+		{PublicKey: unknown, WithdrawalCredentials: make([]byte, 32), Amount: cfg.MinActivationBalance, Signature: make([]byte, 96)},
+	}))
+
+	s := &Server{ChainInfoFetcher: &mock.ChainService{State: st}}
+	get := func(ids ...string) *httptest.ResponseRecorder {
+		url := "http://example.com/prysm/v1/validators/lifecycle?"
+		for _, id := range ids {
+			url += "id=" + id + "&"
+		}
+		request := httptest.NewRequest(http.MethodGet, url, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetLifecycles(writer, request)
+		return writer
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		writer := get("3", hexutil.Encode(vals[5].PublicKey), hexutil.Encode(unknown))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetValidatorLifecyclesResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, fmt.Sprintf("%d", st.Slot()), resp.Slot)
+		require.Equal(t, 3, len(resp.Data))
+
+		p, err := validators.NewLifecycleProjector(ctx, st)
+		require.NoError(t, err)
+		l, err := p.Project(ctx, 3)
+		require.NoError(t, err)
+		d := resp.Data[0]
+		require.Equal(t, "3", d.Index)
+		require.Equal(t, hexutil.Encode(vals[3].PublicKey), d.Pubkey)
+		require.Equal(t, "active_ongoing", d.Status)
+		require.Equal(t, false, d.ExitInitiated)
+		require.Equal(t, fmt.Sprintf("%d", l.ExitEpoch), d.ExitEpoch)
+		require.Equal(t, fmt.Sprintf("%d", l.WithdrawableEpoch), d.WithdrawableEpoch)
+		require.Equal(t, "", d.ActivationQueuePosition)
+		// The sweep starts at validator 0 and validator 3 is the only one with a withdrawal.
+		require.Equal(t, fmt.Sprintf("%d", st.Slot()+1), d.NextWithdrawalSlot)
+		require.Equal(t, "3", d.WithdrawalSweepPosition)
+
+		d = resp.Data[1]
+		require.Equal(t, "5", d.Index)
+		require.Equal(t, "", d.NextWithdrawalSlot)
+
+		epochs, err := electra.EstimatePendingDepositEpochs(st)
+		require.NoError(t, err)
+		d = resp.Data[2]
+		require.Equal(t, "", d.Index)
+		require.Equal(t, pendingDepositStatus, d.Status)
+		require.Equal(t, "2", d.PendingDepositQueuePosition)
+		require.Equal(t, fmt.Sprintf("%d", p.ProjectDeposit(epochs[1]).ActivationEpoch), d.ActivationEpoch)
+	})
+	t.Run("no id", func(t *testing.T) {
+		writer := get()
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "id is required", writer.Body.String())
+	})
+	t.Run("too many ids", func(t *testing.T) {
+		ids := make([]string, cmd.Get().MaxRPCPageSize+1)
+		for i := range ids {
+			ids[i] = "0"
+		}
+		writer := get(ids...)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "Number of ids exceeds the limit", writer.Body.String())
+	})
+	t.Run("unknown pubkey", func(t *testing.T) {
+		writer := get(hexutil.Encode(bytesutil.PadTo([]byte{0xfe}, 48)))
+		require.Equal(t, http.StatusNotFound, writer.Code)
+		require.StringContains(t, "Unknown validator", writer.Body.String())
+	})
+	t.Run("invalid index", func(t *testing.T) {
+		writer := get("8")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "Invalid validator index 8", writer.Body.String())
+	})
+}
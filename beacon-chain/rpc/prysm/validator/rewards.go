@@ -0,0 +1,402 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetRewards serves the rewards and penalties of the requested validators for every epoch of an inclusive epoch
+// range. Consensus rewards are in Gwei and split into the attestation source, target and head rewards, the inactivity
+// penalty, the proposer rewards for including attestations, sync aggregates and slashings, and the sync committee
+// rewards and penalties. Execution payments are in Wei and sum the payments of the payloads proposed by the validator,
+// either from a builder or the priority fees of the payload. They are null when the execution client can't provide
+// the payloads or their receipts.
+//
+// The states are computed by walking the canonical chain once, from the end of the epoch preceding the range to the
+// end of the epoch following it, as attestations of an epoch are rewarded at the end of the next epoch.
+func (s *Server) GetRewards(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetRewards")
+	defer span.End()
+
+	var req structs.GetValidatorRewardsRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	switch {
+	case errors.Is(err, io.EOF):
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	case err != nil:
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(req.Ids) == 0 {
+		httputil.HandleError(w, "ids is required", http.StatusBadRequest)
+		return
+	}
+	startEpoch, endEpoch, ok := s.epochRange(w, req.StartEpoch, req.EndEpoch)
+	if !ok {
+		return
+	}
+	if startEpoch < params.BeaconConfig().AltairForkEpoch {
+		httputil.HandleError(w, "Rewards are not supported for Phase 0", http.StatusBadRequest)
+		return
+	}
+	if currentEpoch := slots.ToEpoch(s.TimeFetcher.CurrentSlot()); endEpoch+1 >= currentEpoch {
+		httputil.HandleError(w,
+			"Rewards are available after two epoch transitions to ensure all attestations have a chance of inclusion",
+			http.StatusBadRequest)
+		return
+	}
+	ids, ok := parseHistoryIds(w, req.Ids)
+	if !ok {
+		return
+	}
+
+	startSlot, err := slots.EpochStart(startEpoch)
+	if err != nil {
+		httputil.HandleError(w, "Could not get start slot: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if startSlot > 0 {
+		startSlot--
+	}
+	st, err := s.Stater.StateBySlot(ctx, startSlot)
+	if err != nil {
+		httputil.HandleError(w, fmt.Sprintf("Could not get state at slot %d: %v", startSlot, err), http.StatusInternalServerError)
+		return
+	}
+	walker := stategen.NewWalker(s.BeaconDB, s.CanonicalFetcher, st.Copy())
+
+	data := make([]*structs.ValidatorEpochRewards, 0, endEpoch-startEpoch+1)
+	var prev, cur epochRewards
+	for epoch := startEpoch; epoch <= endEpoch+1; epoch++ {
+		end, err := slots.EpochEnd(epoch)
+		if err != nil {
+			httputil.HandleError(w, "Could not get end slot: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		st, err = walker.AdvanceTo(ctx, end)
+		if err != nil {
+			httputil.HandleError(w, fmt.Sprintf("Could not compute state of epoch %d: %v", epoch, err), http.StatusInternalServerError)
+			return
+		}
+		// The state at the end of an epoch holds the sync committee of the epoch and the participation of the
+		// previous epoch.
+		if epoch <= endEpoch {
+			cur = newEpochRewards(resolveHistoryIds(st, ids))
+			if err = cur.addSyncCommitteeRewards(ctx, st, walker.Applied()); err != nil {
+				httputil.HandleError(w, fmt.Sprintf("Could not get sync committee rewards of epoch %d: %v", epoch, err), http.StatusInternalServerError)
+				return
+			}
+			if httpErr := s.addProposerRewards(ctx, cur, walker.Applied()); httpErr != nil {
+				httputil.WriteError(w, httpErr)
+				return
+			}
+		}
+		if epoch > startEpoch {
+			if err = prev.addAttestationRewards(ctx, st); err != nil {
+				httputil.HandleError(w, fmt.Sprintf("Could not get attestation rewards of epoch %d: %v", epoch-1, err), http.StatusInternalServerError)
+				return
+			}
+			data = append(data, prev.toStruct(epoch-1))
+		}
+		prev = cur
+	}
+
+	optimistic, err := s.OptimisticModeFetcher.IsOptimistic(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get optimistic mode info: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &structs.GetValidatorRewardsResponse{
+		ExecutionOptimistic: optimistic,
+		Finalized:           s.FinalizationFetcher.FinalizedCheckpt().Epoch > endEpoch+1,
+		Data:                data,
+	})
+}
+
+// validatorRewards accumulates the rewards of a validator over an epoch. Penalties are negative.
+type validatorRewards struct {
+	source, target, head, inactivity                               int64
+	proposerAttestations, proposerSyncAggregate, proposerSlashings uint64
+	syncCommitteeRewards, syncCommitteePenalties                   uint64
+	executionPayments                                              *big.Int
+}
+
+type epochRewards map[primitives.ValidatorIndex]*validatorRewards
+
+func newEpochRewards(indices []primitives.ValidatorIndex) epochRewards {
+	e := make(epochRewards, len(indices))
+	for _, index := range indices {
+		e[index] = &validatorRewards{executionPayments: new(big.Int)}
+	}
+	return e
+}
+
+// addAttestationRewards adds the attestation deltas of the previous epoch of the state.
+func (e epochRewards) addAttestationRewards(ctx context.Context, st state.BeaconState) error {
+	vals, bal, err := altair.InitializePrecomputeValidators(ctx, st)
+	if err != nil {
+		return errors.Wrap(err, "could not initialize precompute validators")
+	}
+	vals, bal, err = altair.ProcessEpochParticipation(ctx, st, bal, vals)
+	if err != nil {
+		return errors.Wrap(err, "could not process epoch participation")
+	}
+	indices := e.indices()
+	filtered := make([]*precompute.Validator, len(indices))
+	for i, index := range indices {
+		filtered[i] = vals[index]
+	}
+	deltas, err := altair.AttestationsDelta(st, bal, filtered)
+	if err != nil {
+		return errors.Wrap(err, "could not get attestations delta")
+	}
+	for i, d := range deltas {
+		v := e[indices[i]]
+		v.source = signed(d.SourceReward, d.SourcePenalty)
+		v.target = signed(d.TargetReward, d.TargetPenalty)
+		v.head = signed(d.HeadReward, 0)
+		v.inactivity = signed(0, d.InactivityPenalty)
+	}
+	return nil
+}
+
+// addSyncCommitteeRewards adds the rewards and penalties of the sync committee members for the sync aggregates of the
+// blocks, the state being in the epoch of the blocks.
+func (e epochRewards) addSyncCommitteeRewards(ctx context.Context, st state.BeaconState, blks []interfaces.ReadOnlySignedBeaconBlock) error {
+	if st.Version() < version.Altair || len(blks) == 0 {
+		return nil
+	}
+	sc, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return errors.Wrap(err, "could not get current sync committee")
+	}
+	members := make([]primitives.ValidatorIndex, len(sc.Pubkeys))
+	for i, pk := range sc.Pubkeys {
+		index, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pk))
+		if !ok {
+			return fmt.Errorf("no validator index found for pubkey %#x", pk)
+		}
+		members[i] = index
+	}
+	activeBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return errors.Wrap(err, "could not get total active balance")
+	}
+	_, participantReward, err := altair.SyncRewards(activeBalance)
+	if err != nil {
+		return errors.Wrap(err, "could not get sync rewards")
+	}
+	for _, blk := range blks {
+		if blk.Version() < version.Altair {
+			continue
+		}
+		sa, err := blk.Block().Body().SyncAggregate()
+		if err != nil {
+			return errors.Wrap(err, "could not get sync aggregate")
+		}
+		for i, index := range members {
+			v, ok := e[index]
+			if !ok {
+				continue
+			}
+			if sa.SyncCommitteeBits.BitAt(uint64(i)) {
+				v.syncCommitteeRewards += participantReward
+			} else {
+				v.syncCommitteePenalties += participantReward
+			}
+		}
+	}
+	return nil
+}
+
+// addProposerRewards adds the rewards of the blocks proposed by the validators, using the block reward fetcher.
+func (s *Server) addProposerRewards(ctx context.Context, e epochRewards, blks []interfaces.ReadOnlySignedBeaconBlock) *httputil.DefaultJsonError {
+	for _, blk := range blks {
+		v, ok := e[blk.Block().ProposerIndex()]
+		if !ok || blk.Version() < version.Altair {
+			continue
+		}
+		rewards, httpErr := s.BlockRewardFetcher.GetBlockRewardsData(ctx, blk.Block())
+		if httpErr != nil {
+			return httpErr
+		}
+		amounts := make([]uint64, 4)
+		for i, a := range []string{rewards.Attestations, rewards.SyncAggregate, rewards.ProposerSlashings, rewards.AttesterSlashings} {
+			amount, err := strconv.ParseUint(a, 10, 64)
+			if err != nil {
+				return &httputil.DefaultJsonError{
+					Message: "Could not parse block rewards: " + err.Error(),
+					Code:    http.StatusInternalServerError,
+				}
+			}
+			amounts[i] = amount
+		}
+		v.proposerAttestations += amounts[0]
+		v.proposerSyncAggregate += amounts[1]
+		v.proposerSlashings += amounts[2] + amounts[3]
+		// Once the payment of one of the blocks is unknown, so is the sum.
+		if v.executionPayments == nil {
+			continue
+		}
+		payment, err := s.executionPayment(ctx, blk)
+		if err != nil {
+			log.WithError(err).WithField("slot", blk.Block().Slot()).Debug("Could not compute execution payment")
+			v.executionPayments = nil
+			continue
+		}
+		v.executionPayments.Add(v.executionPayments, payment)
+	}
+	return nil
+}
+
+// executionPayment returns the payment in Wei of the proposer of the block. When the last transaction of the
+// execution payload is a transfer from the fee recipient of the payload to another address, which is how builders pay
+// proposers, the payment is its value. Otherwise the payload was built for the proposer, who is paid the priority
+// fees of its transactions. Blinded payloads are reconstructed from the execution client.
+func (s *Server) executionPayment(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock) (*big.Int, error) {
+	if blk.Version() < version.Bellatrix {
+		return new(big.Int), nil
+	}
+	if blk.IsBlinded() {
+		if s.ExecutionReconstructor == nil {
+			return nil, errors.New("no execution client to reconstruct the payload")
+		}
+		full, err := s.ExecutionReconstructor.ReconstructFullBlock(ctx, blk)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not reconstruct payload")
+		}
+		blk = full
+	}
+	payload, err := blk.Block().Body().Execution()
+	if err != nil {
+		return nil, err
+	}
+	// Payloads before the merge are empty.
+	if bytesutil.ZeroRoot(payload.BlockHash()) {
+		return new(big.Int), nil
+	}
+	rawTxs, err := payload.Transactions()
+	if err != nil {
+		return nil, err
+	}
+	if len(rawTxs) == 0 {
+		return new(big.Int), nil
+	}
+	txs := make([]*gethtypes.Transaction, len(rawTxs))
+	for i, raw := range rawTxs {
+		txs[i] = &gethtypes.Transaction{}
+		if err := txs[i].UnmarshalBinary(raw); err != nil {
+			return nil, errors.Wrapf(err, "could not decode transaction %d", i)
+		}
+	}
+	feeRecipient := common.BytesToAddress(payload.FeeRecipient())
+	last := txs[len(txs)-1]
+	if last.To() != nil && *last.To() != feeRecipient {
+		sender, err := gethtypes.Sender(gethtypes.LatestSignerForChainID(last.ChainId()), last)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not recover sender of the last transaction")
+		}
+		if sender == feeRecipient {
+			return last.Value(), nil
+		}
+	}
+	return s.priorityFees(ctx, payload, txs)
+}
+
+// priorityFees returns the sum of the priority fees paid by the transactions of the payload, using their receipts for
+// the gas they used.
+func (s *Server) priorityFees(ctx context.Context, payload interfaces.ExecutionData, txs []*gethtypes.Transaction) (*big.Int, error) {
+	if s.ReceiptsFetcher == nil {
+		return nil, errors.New("no execution client to fetch receipts")
+	}
+	receipts, err := s.ReceiptsFetcher.BlockReceipts(ctx, common.BytesToHash(payload.BlockHash()))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get receipts")
+	}
+	if len(receipts) != len(txs) {
+		return nil, fmt.Errorf("got %d receipts for %d transactions", len(receipts), len(txs))
+	}
+	baseFee := bytesutil.LittleEndianBytesToBigInt(payload.BaseFeePerGas())
+	fees := new(big.Int)
+	for i, r := range receipts {
+		if r.EffectiveGasPrice == nil {
+			return nil, fmt.Errorf("no effective gas price in receipt %d", i)
+		}
+		tip := new(big.Int).Sub(r.EffectiveGasPrice, baseFee)
+		fees.Add(fees, tip.Mul(tip, new(big.Int).SetUint64(r.GasUsed)))
+	}
+	return fees, nil
+}
+
+func (e epochRewards) indices() []primitives.ValidatorIndex {
+	indices := make([]primitives.ValidatorIndex, 0, len(e))
+	for index := range e {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices
+}
+
+func (e epochRewards) toStruct(epoch primitives.Epoch) *structs.ValidatorEpochRewards {
+	indices := e.indices()
+	vals := make([]*structs.ValidatorReward, len(indices))
+	for i, index := range indices {
+		v := e[index]
+		// lint:ignore uintcast -- Rewards of a validator over an epoch are far below the int64 limit.
+		total := v.source + v.target + v.head + v.inactivity + int64(v.proposerAttestations+v.proposerSyncAggregate+v.proposerSlashings+v.syncCommitteeRewards) - int64(v.syncCommitteePenalties)
+		var executionPayments *string
+		if v.executionPayments != nil {
+			p := v.executionPayments.String()
+			executionPayments = &p
+		}
+		vals[i] = &structs.ValidatorReward{
+			ValidatorIndex:         strconv.FormatUint(uint64(index), 10),
+			Total:                  strconv.FormatInt(total, 10),
+			Source:                 strconv.FormatInt(v.source, 10),
+			Target:                 strconv.FormatInt(v.target, 10),
+			Head:                   strconv.FormatInt(v.head, 10),
+			Inactivity:             strconv.FormatInt(v.inactivity, 10),
+			ProposerAttestations:   strconv.FormatUint(v.proposerAttestations, 10),
+			ProposerSyncAggregate:  strconv.FormatUint(v.proposerSyncAggregate, 10),
+			ProposerSlashings:      strconv.FormatUint(v.proposerSlashings, 10),
+			SyncCommitteeRewards:   strconv.FormatUint(v.syncCommitteeRewards, 10),
+			SyncCommitteePenalties: strconv.FormatUint(v.syncCommitteePenalties, 10),
+			ExecutionPayments:      executionPayments,
+		}
+	}
+	return &structs.ValidatorEpochRewards{Epoch: strconv.FormatUint(uint64(epoch), 10), Validators: vals}
+}
+
+// signed returns the reward, or the negated penalty when there is one.
+func signed(reward, penalty uint64) int64 {
+	if penalty > 0 {
+		return -int64(penalty) // lint:ignore uintcast -- Penalties of a validator are far below the int64 limit.
+	}
+	return int64(reward) // lint:ignore uintcast -- Rewards of a validator are far below the int64 limit.
+}
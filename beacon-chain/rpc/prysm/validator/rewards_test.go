@@ -0,0 +1,246 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	mockExecution "github.com/prysmaticlabs/prysm/v5/beacon-chain/execution/testing"
+	rewardtesting "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/rewards/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestServer_GetRewards(t *testing.T) {
+	helpers.ClearCache()
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+	ctx := context.Background()
+
+	// Build a chain over epochs 0 to 2, with empty sync aggregates.
+	beaconDB := dbtest.SetupDB(t)
+	st, keys := util.DeterministicGenesisStateAltair(t, 64)
+	syncCommittee, err := altair.NextSyncCommittee(ctx, st)
+	require.NoError(t, err)
+	require.NoError(t, st.SetCurrentSyncCommittee(syncCommittee))
+	require.NoError(t, st.SetNextSyncCommittee(syncCommittee))
+	var startState, epochOneState state.BeaconState
+	proposed := make(map[primitives.ValidatorIndex]int)
+	for slot := primitives.Slot(1); slot < 3*cfg.SlotsPerEpoch; slot++ {
+		b, err := util.GenerateFullBlockAltair(st, keys, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		wsb, err := consensusblocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, beaconDB, b)
+		if slot == cfg.SlotsPerEpoch-1 {
+			startState = st.Copy()
+		}
+		if slot/cfg.SlotsPerEpoch == 1 {
+			proposed[b.Block.ProposerIndex]++
+			epochOneState = st
+		}
+	}
+
+	currentSlot := 3 * cfg.SlotsPerEpoch
+	s := &Server{
+		BeaconDB:              beaconDB,
+		Stater:                &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{cfg.SlotsPerEpoch - 1: startState}},
+		CanonicalFetcher:      &mock.ChainService{},
+		FinalizationFetcher:   &mock.ChainService{FinalizedCheckPoint: &ethpb.Checkpoint{}},
+		OptimisticModeFetcher: &mock.ChainService{},
+		TimeFetcher:           &mock.ChainService{Slot: &currentSlot},
+		BlockRewardFetcher: &rewardtesting.MockBlockRewardFetcher{Rewards: &structs.BlockRewards{
+			Attestations:      "10",
+			SyncAggregate:     "2",
+			ProposerSlashings: "1",
+			AttesterSlashings: "0",
+		}},
+	}
+	post := func(body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validators/rewards", bytes.NewBufferString(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetRewards(writer, request)
+		return writer
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		sc, err := epochOneState.CurrentSyncCommittee()
+		require.NoError(t, err)
+		member, ok := epochOneState.ValidatorIndexByPubkey(bytesutil.ToBytes48(sc.Pubkeys[0]))
+		require.Equal(t, true, ok)
+		seats := 0
+		for _, pk := range sc.Pubkeys {
+			if bytes.Equal(pk, sc.Pubkeys[0]) {
+				seats++
+			}
+		}
+		var proposer primitives.ValidatorIndex
+		for index := range proposed {
+			proposer = index
+			break
+		}
+
+		writer := post(fmt.Sprintf(`{"ids":["%d","%d","1000"],"start_epoch":"1","end_epoch":"1"}`, member, proposer))
+		require.Equal(t, http.StatusOK, writer.Code, writer.Body.String())
+		resp := &structs.GetValidatorRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, false, resp.Finalized)
+		require.Equal(t, 1, len(resp.Data))
+		require.Equal(t, "1", resp.Data[0].Epoch)
+		rewards := make(map[string]*structs.ValidatorReward)
+		for _, v := range resp.Data[0].Validators {
+			rewards[v.ValidatorIndex] = v
+		}
+
+		activeBalance, err := helpers.TotalActiveBalance(epochOneState)
+		require.NoError(t, err)
+		_, participantReward, err := altair.SyncRewards(activeBalance)
+		require.NoError(t, err)
+		m := rewards[strconv.FormatUint(uint64(member), 10)]
+		require.NotNil(t, m)
+		require.Equal(t, "0", m.SyncCommitteeRewards)
+		require.Equal(t, strconv.FormatUint(uint64(seats)*uint64(cfg.SlotsPerEpoch)*participantReward, 10), m.SyncCommitteePenalties)
+
+		p := rewards[strconv.FormatUint(uint64(proposer), 10)]
+		require.NotNil(t, p)
+		require.Equal(t, strconv.Itoa(10*proposed[proposer]), p.ProposerAttestations)
+		require.Equal(t, strconv.Itoa(2*proposed[proposer]), p.ProposerSyncAggregate)
+		require.Equal(t, strconv.Itoa(proposed[proposer]), p.ProposerSlashings)
+		require.NotNil(t, p.ExecutionPayments)
+		require.Equal(t, "0", *p.ExecutionPayments)
+
+		for _, v := range resp.Data[0].Validators {
+			var sum int64
+			for _, a := range []string{v.Source, v.Target, v.Head, v.Inactivity, v.ProposerAttestations, v.ProposerSyncAggregate, v.ProposerSlashings, v.SyncCommitteeRewards} {
+				n, err := strconv.ParseInt(a, 10, 64)
+				require.NoError(t, err)
+				sum += n
+			}
+			penalties, err := strconv.ParseInt(v.SyncCommitteePenalties, 10, 64)
+			require.NoError(t, err)
+			require.Equal(t, strconv.FormatInt(sum-penalties, 10), v.Total)
+		}
+	})
+	t.Run("too recent", func(t *testing.T) {
+		writer := post(`{"ids":["1"],"start_epoch":"1","end_epoch":"2"}`)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "available after two epoch transitions", writer.Body.String())
+	})
+	t.Run("phase 0", func(t *testing.T) {
+		cfg := params.BeaconConfig().Copy()
+		cfg.AltairForkEpoch = 1
+		params.OverrideBeaconConfig(cfg)
+		writer := post(`{"ids":["1"],"start_epoch":"0","end_epoch":"0"}`)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "not supported for Phase 0", writer.Body.String())
+	})
+}
+
+func TestServer_ExecutionPayment(t *testing.T) {
+	ctx := context.Background()
+	builderKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	userKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	builder := crypto.PubkeyToAddress(builderKey.PublicKey)
+	proposer := common.Address{'p'}
+	signer := gethtypes.LatestSignerForChainID(big.NewInt(1))
+	tx := func(key *ecdsa.PrivateKey, nonce uint64, to common.Address, value int64) []byte {
+		signed, err := gethtypes.SignNewTx(key, signer, &gethtypes.DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(2),
+			GasFeeCap: big.NewInt(20),
+			Gas:       21000,
+			To:        &to,
+			Value:     big.NewInt(value),
+		})
+		require.NoError(t, err)
+		raw, err := signed.MarshalBinary()
+		require.NoError(t, err)
+		return raw
+	}
+	block := func(feeRecipient common.Address, txs ...[]byte) interfaces.ReadOnlySignedBeaconBlock {
+		b := util.NewBeaconBlockBellatrix()
+		b.Block.Body.ExecutionPayload.FeeRecipient = feeRecipient.Bytes()
+		b.Block.Body.ExecutionPayload.BlockHash = bytesutil.PadTo([]byte{byte(len(txs)), feeRecipient[0]}, 32)
+		b.Block.Body.ExecutionPayload.BaseFeePerGas = bytesutil.PadTo([]byte{10}, 32)
+		b.Block.Body.ExecutionPayload.Transactions = txs
+		wsb, err := consensusblocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		return wsb
+	}
+
+	builderBlock := block(builder, tx(userKey, 0, proposer, 7), tx(builderKey, 0, proposer, 1000))
+	localBlock := block(proposer, tx(userKey, 0, builder, 7), tx(userKey, 1, builder, 8))
+	localPayload, err := localBlock.Block().Body().Execution()
+	require.NoError(t, err)
+	engine := &mockExecution.EngineClient{
+		ExecutionPayloadByBlockHash: map[[32]byte]*enginev1.ExecutionPayload{},
+		ReceiptsByBlockHash: map[[32]byte][]*gethtypes.Receipt{
+			bytesutil.ToBytes32(localPayload.BlockHash()): {
+				{GasUsed: 21000, EffectiveGasPrice: big.NewInt(12)},
+				{GasUsed: 30000, EffectiveGasPrice: big.NewInt(11)},
+			},
+		},
+	}
+	s := &Server{ExecutionReconstructor: engine, ReceiptsFetcher: engine}
+
+	t.Run("builder payment", func(t *testing.T) {
+		payment, err := s.executionPayment(ctx, builderBlock)
+		require.NoError(t, err)
+		require.Equal(t, "1000", payment.String())
+	})
+	t.Run("priority fees", func(t *testing.T) {
+		payment, err := s.executionPayment(ctx, localBlock)
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("%d", 2*21000+1*30000), payment.String())
+	})
+	t.Run("blinded", func(t *testing.T) {
+		blinded, err := builderBlock.(interfaces.SignedBeaconBlock).ToBlinded()
+		require.NoError(t, err)
+		_, err = s.executionPayment(ctx, blinded)
+		require.ErrorContains(t, "could not reconstruct payload", err)
+
+		payload, err := builderBlock.Block().Body().Execution()
+		require.NoError(t, err)
+		engine.ExecutionPayloadByBlockHash[bytesutil.ToBytes32(payload.BlockHash())] = payload.Proto().(*enginev1.ExecutionPayload)
+		payment, err := s.executionPayment(ctx, blinded)
+		require.NoError(t, err)
+		require.Equal(t, "1000", payment.String())
+	})
+	t.Run("no receipts", func(t *testing.T) {
+		_, err := s.executionPayment(ctx, block(proposer, tx(userKey, 0, builder, 7)))
+		require.ErrorContains(t, "could not get receipts", err)
+	})
+}
@@ -3,15 +3,24 @@ package validator
 import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/rewards"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 )
 
 type Server struct {
-	BeaconDB            db.ReadOnlyDatabase
-	Stater              lookup.Stater
-	CanonicalFetcher    blockchain.CanonicalFetcher
-	FinalizationFetcher blockchain.FinalizationFetcher
-	ChainInfoFetcher    blockchain.ChainInfoFetcher
-	CoreService         *core.Service
+	BeaconDB               db.ReadOnlyDatabase
+	Stater                 lookup.Stater
+	CanonicalFetcher       blockchain.CanonicalFetcher
+	FinalizationFetcher    blockchain.FinalizationFetcher
+	ChainInfoFetcher       blockchain.ChainInfoFetcher
+	CoreService            *core.Service
+	TimeFetcher            blockchain.TimeFetcher
+	OptimisticModeFetcher  blockchain.OptimisticModeFetcher
+	BlockRewardFetcher     rewards.BlockRewardsFetcher
+	ExecutionReconstructor execution.Reconstructor
+	ReceiptsFetcher        execution.ReceiptsFetcher
+	MaxHistoryEpochs       primitives.Epoch
 }
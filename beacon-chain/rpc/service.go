@@ -31,6 +31,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/events"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/rewards"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
 	beaconv1alpha1 "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/v1alpha1/beacon"
@@ -42,6 +43,7 @@ import (
 	chainSync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/io/logs"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing"
 	ethpbv1alpha1 "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
@@ -72,6 +74,7 @@ type Service struct {
 // Config options for the beacon node RPC server.
 type Config struct {
 	ExecutionReconstructor    execution.Reconstructor
+	ExecutionReceiptsFetcher  execution.ReceiptsFetcher
 	Host                      string
 	Port                      string
 	CertFlag                  string
@@ -91,6 +94,7 @@ type Config struct {
 	ExecutionChainService     execution.Chain
 	ChainStartFetcher         execution.ChainStartFetcher
 	ExecutionChainInfoFetcher execution.ChainInfoFetcher
+	ExecutionClientsFetcher   execution.ExecutionClientsFetcher
 	GenesisTimeFetcher        blockchain.TimeFetcher
 	GenesisFetcher            blockchain.GenesisFetcher
 	MockEth1Votes             bool
@@ -98,6 +102,7 @@ type Config struct {
 	AttestationsPool          attestations.Pool
 	ExitPool                  voluntaryexits.PoolManager
 	SlashingsPool             slashings.PoolManager
+	SlashingArchive           *slashings.Archive
 	SyncCommitteeObjectPool   synccommittee.Pool
 	BLSChangesPool            blstoexec.PoolManager
 	SyncService               chainSync.Checker
@@ -114,12 +119,19 @@ type Config struct {
 	MaxMsgSize                int
 	ExecutionEngineCaller     execution.EngineCaller
 	OptimisticModeFetcher     blockchain.OptimisticModeFetcher
+	PayloadDisputeFetcher     blockchain.PayloadDisputeFetcher
 	BlockBuilder              builder.BlockBuilder
 	Router                    *http.ServeMux
 	ClockWaiter               startup.ClockWaiter
 	BlobStorage               *filesystem.BlobStorage
 	TrackedValidatorsCache    *cache.TrackedValidatorsCache
+	AttestationInclusionCache *cache.AttestationInclusionCache
 	PayloadIDCache            *cache.PayloadIDCache
+	EventLog                  *events.EventLog
+	MaxValidatorHistoryEpochs primitives.Epoch
+	EnableGraphQL             bool
+	GraphQLMaxDepth           int
+	GraphQLMaxCost            uint64
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -209,6 +221,7 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		FinalizedFetcher:      s.cfg.FinalizationFetcher,
 		ReplayerBuilder:       ch,
 		OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
+		PayloadDisputeFetcher: s.cfg.PayloadDisputeFetcher,
 	}
 	validatorServer := &validatorv1alpha1.Server{
 		Ctx:                    s.ctx,
@@ -268,6 +281,7 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		BeaconDB:                    s.cfg.BeaconDB,
 		AttestationsPool:            s.cfg.AttestationsPool,
 		SlashingsPool:               s.cfg.SlashingsPool,
+		SlashingArchive:             s.cfg.SlashingArchive,
 		OptimisticModeFetcher:       s.cfg.OptimisticModeFetcher,
 		HeadFetcher:                 s.cfg.HeadFetcher,
 		FinalizationFetcher:         s.cfg.FinalizationFetcher,
@@ -6,6 +6,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	slashingtypes "github.com/prysmaticlabs/prysm/v5/consensus-types/slashing"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 )
@@ -52,6 +53,9 @@ func (s *Service) processAttesterSlashings(
 		if err := s.serviceCfg.SlashingPoolInserter.InsertAttesterSlashing(ctx, beaconState, slashing); err != nil {
 			log.WithError(err).Error("Could not insert attester slashing into operations pool")
 		}
+		if s.serviceCfg.SlashingArchive != nil {
+			s.serviceCfg.SlashingArchive.RecordAttesterSlashing(ctx, slashing, slashingtypes.SourceSlasher)
+		}
 
 		processedSlashings[root] = slashing
 	}
@@ -97,6 +101,9 @@ func (s *Service) processProposerSlashings(ctx context.Context, slashings []*eth
 		if err := s.serviceCfg.SlashingPoolInserter.InsertProposerSlashing(ctx, beaconState, slashing); err != nil {
 			log.WithError(err).Error("Could not insert proposer slashing into operations pool")
 		}
+		if s.serviceCfg.SlashingArchive != nil {
+			s.serviceCfg.SlashingArchive.RecordProposerSlashing(ctx, slashing, slashingtypes.SourceSlasher)
+		}
 	}
 
 	return nil
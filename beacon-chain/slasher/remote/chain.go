@@ -0,0 +1,236 @@
+package remote
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// maxCachedStates bounds the number of states downloaded to verify slashings that are kept in memory.
+const maxCachedStates = 4
+
+var errResyncNotSupported = errors.New("cannot resync a remote beacon node")
+
+// Chain gives the slasher access to the chain of a remote beacon node through the beacon API. It
+// implements the clock, sync and state dependencies of the slasher service.
+type Chain struct {
+	client        *beacon.Client
+	retryInterval time.Duration
+
+	lock        sync.Mutex
+	initialized bool
+	headSlot    primitives.Slot
+	statusErr   error
+	headState   state.BeaconState
+	states      map[[32]byte]state.BeaconState
+}
+
+// NewChain returns a Chain backed by the given beacon API client. retryInterval is the time waited
+// between attempts to get the genesis of the chain.
+func NewChain(client *beacon.Client, retryInterval time.Duration) *Chain {
+	return &Chain{
+		client:        client,
+		retryInterval: retryInterval,
+		states:        make(map[[32]byte]state.BeaconState),
+	}
+}
+
+// WaitForClock waits until the remote beacon node serves the genesis of the chain, and returns the
+// clock of the chain.
+func (c *Chain) WaitForClock(ctx context.Context) (*startup.Clock, error) {
+	for {
+		genesis, err := c.client.GetGenesis(ctx)
+		if err == nil {
+			clock, err := clockFromGenesis(genesis)
+			if err != nil {
+				return nil, err
+			}
+			c.lock.Lock()
+			c.initialized = true
+			c.lock.Unlock()
+			return clock, nil
+		}
+		log.WithError(err).Warn("Could not get genesis from beacon node, retrying")
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.retryInterval):
+		}
+	}
+}
+
+func clockFromGenesis(genesis *structs.Genesis) (*startup.Clock, error) {
+	t, err := strconv.ParseUint(genesis.GenesisTime, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid genesis time %s", genesis.GenesisTime)
+	}
+	root, err := bytesutil.DecodeHexWithLength(genesis.GenesisValidatorsRoot, 32)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid genesis validators root %s", genesis.GenesisValidatorsRoot)
+	}
+	return startup.NewClock(time.Unix(int64(t), 0), bytesutil.ToBytes32(root)), nil // lint:ignore uintcast -- Genesis time will not exceed int64 in your lifetime.
+}
+
+// Initialized returns true once the genesis of the chain was received from the beacon node.
+func (c *Chain) Initialized() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.initialized
+}
+
+// Syncing returns true if the beacon node is syncing, or if it could not be reached.
+func (c *Chain) Syncing() bool {
+	syncing, err := c.syncStatus()
+	if err != nil {
+		return true
+	}
+	return syncing
+}
+
+// Synced returns true if the beacon node is reachable and not syncing.
+func (c *Chain) Synced() bool {
+	return !c.Syncing()
+}
+
+// Status returns the error of the last failed request for the sync status of the beacon node.
+func (c *Chain) Status() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.statusErr
+}
+
+// Resync is not supported, the beacon node manages its own sync.
+func (*Chain) Resync() error {
+	return errResyncNotSupported
+}
+
+// HeadSlot returns the head slot of the beacon node. The last known head slot is returned when the
+// beacon node cannot be reached.
+func (c *Chain) HeadSlot() primitives.Slot {
+	if _, err := c.syncStatus(); err != nil {
+		log.WithError(err).Debug("Could not get head slot from beacon node")
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.headSlot
+}
+
+// syncStatus requests the sync status of the beacon node, records its head slot and returns whether
+// it is syncing.
+func (c *Chain) syncStatus() (bool, error) {
+	status, err := c.client.GetSyncStatus(context.Background())
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.statusErr = err
+	if err != nil {
+		return false, err
+	}
+	headSlot, err := strconv.ParseUint(status.HeadSlot, 10, 64)
+	if err != nil {
+		c.statusErr = errors.Wrapf(err, "invalid head slot %s", status.HeadSlot)
+		return false, c.statusErr
+	}
+	c.headSlot = primitives.Slot(headSlot)
+	return status.IsSyncing, nil
+}
+
+// HeadState downloads the head state of the beacon node. The state is downloaded at most once per
+// epoch, as the slasher only uses it to read the validator registry.
+func (c *Chain) HeadState(ctx context.Context) (state.BeaconState, error) {
+	headSlot := c.HeadSlot()
+	c.lock.Lock()
+	cached := c.headState
+	c.lock.Unlock()
+	if cached != nil && slots.ToEpoch(cached.Slot()) == slots.ToEpoch(headSlot) {
+		return cached, nil
+	}
+	st, err := c.state(ctx, beacon.IdHead)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head state")
+	}
+	c.lock.Lock()
+	c.headState = st
+	c.lock.Unlock()
+	return st, nil
+}
+
+// StateByRoot downloads the post state of the block with the given root.
+func (c *Chain) StateByRoot(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
+	c.lock.Lock()
+	cached, ok := c.states[blockRoot]
+	c.lock.Unlock()
+	if ok {
+		return cached, nil
+	}
+	stateRoot, err := c.stateRoot(ctx, blockRoot)
+	if err != nil {
+		return nil, err
+	}
+	st, err := c.state(ctx, beacon.IdFromRoot(stateRoot))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get state of block %#x", blockRoot)
+	}
+	c.lock.Lock()
+	if len(c.states) >= maxCachedStates {
+		c.states = make(map[[32]byte]state.BeaconState)
+	}
+	c.states[blockRoot] = st
+	c.lock.Unlock()
+	return st, nil
+}
+
+// AttestationTargetState returns the state of the target checkpoint of an attestation, advanced to
+// the start of the target epoch.
+func (c *Chain) AttestationTargetState(ctx context.Context, target *ethpb.Checkpoint) (state.ReadOnlyBeaconState, error) {
+	st, err := c.StateByRoot(ctx, bytesutil.ToBytes32(target.Root))
+	if err != nil {
+		return nil, err
+	}
+	epochStart, err := slots.EpochStart(target.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	if st.Slot() >= epochStart {
+		return st, nil
+	}
+	return transition.ProcessSlots(ctx, st.Copy(), epochStart)
+}
+
+// stateRoot returns the state root of the block with the given root.
+func (c *Chain) stateRoot(ctx context.Context, blockRoot [32]byte) ([32]byte, error) {
+	header, err := c.client.GetBlockHeader(ctx, beacon.IdFromRoot(blockRoot))
+	if err != nil {
+		return [32]byte{}, err
+	}
+	stateRoot, err := hexutil.Decode(header.Header.Message.StateRoot)
+	if err != nil {
+		return [32]byte{}, errors.Wrapf(err, "invalid state root %s", header.Header.Message.StateRoot)
+	}
+	return bytesutil.ToBytes32(stateRoot), nil
+}
+
+func (c *Chain) state(ctx context.Context, id beacon.StateOrBlockId) (state.BeaconState, error) {
+	b, err := c.client.GetState(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	vu, err := detect.FromState(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not detect the fork of the state")
+	}
+	return vu.UnmarshalBeaconState(b)
+}
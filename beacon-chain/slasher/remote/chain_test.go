@@ -0,0 +1,119 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+// testBeaconNode serves the beacon API endpoints used by the remote slasher.
+type testBeaconNode struct {
+	syncing        bool
+	headSlot       string
+	stateRoot      [32]byte
+	committees     []*structs.Committee
+	committeeCalls int
+	posted         map[string][]byte
+	blocks         map[string][]byte
+	blockRequests  []string
+	poolAtts       []*structs.Attestation
+}
+
+func (n *testBeaconNode) server(t *testing.T) *httptest.Server {
+	n.posted = make(map[string][]byte)
+	mux := http.NewServeMux()
+	writeJson := func(w http.ResponseWriter, v interface{}) {
+		require.NoError(t, json.NewEncoder(w).Encode(v))
+	}
+	mux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, &structs.GetGenesisResponse{Data: &structs.Genesis{
+			GenesisTime:           "1606824023",
+			GenesisValidatorsRoot: hexutil.Encode([]byte{'r', 31: 0}),
+		}})
+	})
+	mux.HandleFunc("/eth/v1/node/syncing", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, &structs.SyncStatusResponse{Data: &structs.SyncStatusResponseData{
+			HeadSlot:  n.headSlot,
+			IsSyncing: n.syncing,
+		}})
+	})
+	mux.HandleFunc("/eth/v1/beacon/headers/", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, &structs.GetBlockHeaderResponse{Data: &structs.SignedBeaconBlockHeaderContainer{
+			Header: &structs.SignedBeaconBlockHeader{Message: &structs.BeaconBlockHeader{
+				StateRoot: hexutil.Encode(n.stateRoot[:]),
+			}},
+		}})
+	})
+	mux.HandleFunc("/eth/v1/beacon/states/"+hexutil.Encode(n.stateRoot[:])+"/committees", func(w http.ResponseWriter, r *http.Request) {
+		n.committeeCalls++
+		require.Equal(t, "1", r.URL.Query().Get("epoch"))
+		writeJson(w, &structs.GetCommitteesResponse{Data: n.committees})
+	})
+	mux.HandleFunc("/eth/v2/beacon/blocks/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/eth/v2/beacon/blocks/")
+		n.blockRequests = append(n.blockRequests, id)
+		b, ok := n.blocks[id]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", api.OctetStreamMediaType)
+		_, err := w.Write(b)
+		require.NoError(t, err)
+	})
+	mux.HandleFunc("/eth/v2/beacon/pool/attestations", func(w http.ResponseWriter, r *http.Request) {
+		data, err := json.Marshal(n.poolAtts)
+		require.NoError(t, err)
+		writeJson(w, &structs.ListAttestationsResponse{Version: "phase0", Data: data})
+	})
+	mux.HandleFunc("/eth/v1/beacon/pool/", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		var body json.RawMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		n.posted[r.URL.Path] = body
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestChain(t *testing.T) {
+	ctx := context.Background()
+	node := &testBeaconNode{headSlot: "40", syncing: true}
+	srv := node.server(t)
+	c, err := beacon.NewClient(srv.URL)
+	require.NoError(t, err)
+	chain := NewChain(c, time.Millisecond)
+
+	require.Equal(t, false, chain.Initialized())
+	clock, err := chain.WaitForClock(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1606824023), clock.GenesisTime().Unix())
+	require.Equal(t, [32]byte{'r'}, clock.GenesisValidatorsRoot())
+	require.Equal(t, true, chain.Initialized())
+
+	require.Equal(t, true, chain.Syncing())
+	node.syncing = false
+	require.Equal(t, false, chain.Syncing())
+	require.Equal(t, true, chain.Synced())
+	require.Equal(t, primitives.Slot(40), chain.HeadSlot())
+	require.NoError(t, chain.Status())
+	require.ErrorIs(t, chain.Resync(), errResyncNotSupported)
+
+	// The last known head slot is kept when the beacon node cannot be reached.
+	srv.Close()
+	require.Equal(t, primitives.Slot(40), chain.HeadSlot())
+	require.Equal(t, true, chain.Syncing())
+	require.NotNil(t, chain.Status())
+}
@@ -0,0 +1,35 @@
+package remote
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+var _ slashings.PoolInserter = (*PoolInserter)(nil)
+
+// PoolInserter submits the slashings detected by the slasher to the operations pool of a remote
+// beacon node, which verifies them again and broadcasts them to the network.
+type PoolInserter struct {
+	client *beacon.Client
+}
+
+// NewPoolInserter returns a PoolInserter backed by the given beacon API client.
+func NewPoolInserter(client *beacon.Client) *PoolInserter {
+	return &PoolInserter{client: client}
+}
+
+// InsertAttesterSlashing submits an attester slashing to the beacon node. The state is not used, the
+// beacon node verifies the slashing against its own head state.
+func (p *PoolInserter) InsertAttesterSlashing(ctx context.Context, _ state.ReadOnlyBeaconState, slashing ethpb.AttSlashing) error {
+	return p.client.SubmitAttesterSlashing(ctx, slashing)
+}
+
+// InsertProposerSlashing submits a proposer slashing to the beacon node. The state is not used, the
+// beacon node verifies the slashing against its own head state.
+func (p *PoolInserter) InsertProposerSlashing(ctx context.Context, _ state.ReadOnlyBeaconState, slashing *ethpb.ProposerSlashing) error {
+	return p.client.SubmitProposerSlashing(ctx, slashing)
+}
@@ -0,0 +1,41 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestPoolInserter(t *testing.T) {
+	ctx := context.Background()
+	node := &testBeaconNode{}
+	srv := node.server(t)
+	c, err := beacon.NewClient(srv.URL)
+	require.NoError(t, err)
+	p := NewPoolInserter(c)
+
+	proposerSlashing := &ethpb.ProposerSlashing{
+		Header_1: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+		Header_2: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+	}
+	proposerSlashing.Header_1.Header.ProposerIndex = 7
+	require.NoError(t, p.InsertProposerSlashing(ctx, nil, proposerSlashing))
+	gotProposerSlashing := &structs.ProposerSlashing{}
+	require.NoError(t, json.Unmarshal(node.posted["/eth/v1/beacon/pool/proposer_slashings"], gotProposerSlashing))
+	require.Equal(t, "7", gotProposerSlashing.SignedHeader1.Message.ProposerIndex)
+
+	attesterSlashing := &ethpb.AttesterSlashing{
+		Attestation_1: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1, 2}}),
+		Attestation_2: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{2}}),
+	}
+	require.NoError(t, p.InsertAttesterSlashing(ctx, nil, attesterSlashing))
+	gotAttesterSlashing := &structs.AttesterSlashing{}
+	require.NoError(t, json.Unmarshal(node.posted["/eth/v1/beacon/pool/attester_slashings"], gotAttesterSlashing))
+	require.DeepEqual(t, []string{"1", "2"}, gotAttesterSlashing.Attestation1.AttestingIndices)
+}
@@ -0,0 +1,395 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/api/client/event"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	asyncevent "github.com/prysmaticlabs/prysm/v5/async/event"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+// SourceConfig for the Source service.
+type SourceConfig struct {
+	Client                  *beacon.Client
+	Chain                   *Chain
+	IndexedAttestationsFeed *asyncevent.Feed
+	BeaconBlockHeadersFeed  *asyncevent.Feed
+	ReconnectInterval       time.Duration
+}
+
+// targetKey identifies the target checkpoint of an attestation.
+type targetKey struct {
+	epoch primitives.Epoch
+	root  [32]byte
+}
+
+// maxBackfillEpochs bounds the number of epochs of blocks requested after the event stream was interrupted.
+const maxBackfillEpochs = 2
+
+// committeeKey identifies a beacon committee.
+type committeeKey struct {
+	slot  primitives.Slot
+	index primitives.CommitteeIndex
+}
+
+// Source follows the event stream of a remote beacon node and feeds the slasher with the headers of
+// the blocks it imports, and with the attestations it receives over gossip or includes in blocks. When
+// the stream is interrupted, the blocks imported in the meantime and the attestations of the pool of the
+// beacon node are requested once it is subscribed to again.
+type Source struct {
+	cfg    *SourceConfig
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// lastSlot is the slot of the latest block processed, only accessed by the event loop.
+	lastSlot    primitives.Slot
+	hasLastSlot bool
+
+	committeesLock sync.Mutex
+	committees     map[targetKey]map[committeeKey][]primitives.ValidatorIndex
+}
+
+// NewSource returns a Source from configuration values.
+func NewSource(ctx context.Context, cfg *SourceConfig) *Source {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Source{
+		cfg:        cfg,
+		ctx:        ctx,
+		cancel:     cancel,
+		committees: make(map[targetKey]map[committeeKey][]primitives.ValidatorIndex),
+	}
+}
+
+// Start following the event stream of the beacon node.
+func (s *Source) Start() {
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Stop following the event stream of the beacon node.
+func (s *Source) Stop() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+// Status of the source.
+func (*Source) Status() error {
+	return nil
+}
+
+// run subscribes to the block and attestation events of the beacon node, and subscribes again when
+// the stream is interrupted.
+func (s *Source) run() {
+	defer s.wg.Done()
+	host := strings.TrimSuffix(s.cfg.Client.NodeURL(), "/")
+	topics := []string{event.EventBlock, event.EventAttestation}
+	hc := eventStreamClient(s.cfg.Client.HTTPClient())
+	for reconnect := false; ; reconnect = true {
+		stream, err := event.NewEventStream(s.ctx, hc, host, topics)
+		if err != nil {
+			log.WithError(err).Error("Could not create event stream")
+			return
+		}
+		events := make(chan *event.Event, 16)
+		done := make(chan struct{})
+		go func() {
+			stream.Subscribe(events)
+			close(done)
+		}()
+		// Events received while backfilling wait in the stream, blocks processed twice are harmless to the slasher.
+		if reconnect {
+			s.backfill()
+		}
+		if !s.receiveEvents(events, done) {
+			return
+		}
+		log.WithField("reconnectInterval", s.cfg.ReconnectInterval).Warn("Event stream interrupted, reconnecting")
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(s.cfg.ReconnectInterval):
+		}
+	}
+}
+
+// eventStreamClient returns the HTTP client of the event stream. It uses the transport of the beacon API client,
+// so that its TLS configuration applies, and its timeout bounds the wait for the stream to be established rather
+// than the whole stream.
+func eventStreamClient(hc *http.Client) *http.Client {
+	rt := hc.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	t, ok := rt.(*http.Transport)
+	if !ok {
+		return &http.Client{Transport: rt}
+	}
+	t = t.Clone()
+	t.ResponseHeaderTimeout = hc.Timeout
+	return &http.Client{Transport: t}
+}
+
+// backfill processes the blocks imported by the beacon node since the last processed block, at most
+// maxBackfillEpochs of them, and the attestations of its pool, which were missed while the event stream
+// was interrupted.
+func (s *Source) backfill() {
+	if !s.hasLastSlot {
+		return
+	}
+	headSlot := s.cfg.Chain.HeadSlot()
+	start := s.lastSlot + 1
+	if limit := params.BeaconConfig().SlotsPerEpoch.Mul(maxBackfillEpochs); headSlot > limit && start < headSlot-limit {
+		log.WithFields(logrus.Fields{
+			"lastSlot": s.lastSlot,
+			"headSlot": headSlot,
+		}).Warn("Event stream interrupted for too long, not all missed blocks are backfilled")
+		start = headSlot - limit
+	}
+	for slot := start; slot <= headSlot; slot++ {
+		if s.ctx.Err() != nil {
+			return
+		}
+		b, err := s.cfg.Client.GetBlock(s.ctx, beacon.IdFromSlot(slot))
+		if errors.Is(err, client.ErrNotFound) {
+			continue
+		}
+		if err == nil {
+			err = s.processBlock(b)
+		}
+		if err != nil {
+			log.WithError(err).WithField("slot", slot).Error("Could not backfill block")
+		}
+	}
+	atts, err := s.cfg.Client.GetPoolAttestations(s.ctx, nil, nil)
+	if err != nil {
+		log.WithError(err).Error("Could not backfill pool attestations")
+		return
+	}
+	for _, att := range atts {
+		if err := s.sendAttestation(att); err != nil {
+			log.WithError(err).Debug("Could not backfill pool attestation")
+		}
+	}
+}
+
+// receiveEvents processes events until the stream ends. It returns false if the source is stopped.
+func (s *Source) receiveEvents(events chan *event.Event, done chan struct{}) bool {
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return false
+			}
+			s.processEvent(e)
+		case <-done:
+			for len(events) > 0 {
+				s.processEvent(<-events)
+			}
+			return true
+		case <-s.ctx.Done():
+			return false
+		}
+	}
+}
+
+func (s *Source) processEvent(e *event.Event) {
+	var err error
+	switch e.EventType {
+	case event.EventBlock:
+		err = s.receiveBlock(e.Data)
+	case event.EventAttestation:
+		err = s.receiveAttestation(e.Data)
+	case event.EventError, event.EventConnectionError:
+		err = errors.New(string(e.Data))
+	default:
+		return
+	}
+	if err != nil {
+		log.WithError(err).WithField("type", e.EventType).Error("Could not process event")
+	}
+}
+
+// receiveBlock downloads the block of a block event, and sends its header and the attestations it
+// includes to the slasher.
+func (s *Source) receiveBlock(data []byte) error {
+	blockEvent := &structs.BlockEvent{}
+	if err := json.Unmarshal(data, blockEvent); err != nil {
+		return errors.Wrap(err, "could not decode block event")
+	}
+	root, err := bytesutil.DecodeHexWithLength(blockEvent.Block, 32)
+	if err != nil {
+		return errors.Wrapf(err, "invalid block root %s", blockEvent.Block)
+	}
+	b, err := s.cfg.Client.GetBlock(s.ctx, beacon.IdFromRoot(bytesutil.ToBytes32(root)))
+	if err != nil {
+		return err
+	}
+	return s.processBlock(b)
+}
+
+// processBlock sends the header of an ssz encoded block and the attestations it includes to the slasher.
+func (s *Source) processBlock(b []byte) error {
+	vu, err := detect.FromBlock(b)
+	if err != nil {
+		return errors.Wrap(err, "could not detect the fork of the block")
+	}
+	blk, err := vu.UnmarshalBeaconBlock(b)
+	if err != nil {
+		return errors.Wrap(err, "could not decode block")
+	}
+	header, err := interfaces.SignedBeaconBlockHeaderFromBlockInterface(blk)
+	if err != nil {
+		return errors.Wrap(err, "could not get block header")
+	}
+	s.cfg.BeaconBlockHeadersFeed.Send(header)
+	if slot := blk.Block().Slot(); !s.hasLastSlot || slot > s.lastSlot {
+		s.lastSlot, s.hasLastSlot = slot, true
+	}
+	for _, att := range blk.Block().Body().Attestations() {
+		if err := s.sendAttestation(att); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// receiveAttestation sends the attestation of an attestation event to the slasher.
+func (s *Source) receiveAttestation(data []byte) error {
+	att, err := attestationFromEvent(data)
+	if err != nil {
+		return err
+	}
+	return s.sendAttestation(att)
+}
+
+func attestationFromEvent(data []byte) (ethpb.Att, error) {
+	electra := &structs.AttestationElectra{}
+	if err := json.Unmarshal(data, electra); err != nil {
+		return nil, errors.Wrap(err, "could not decode attestation event")
+	}
+	if electra.CommitteeBits != "" {
+		return electra.ToConsensus()
+	}
+	phase0 := &structs.Attestation{
+		AggregationBits: electra.AggregationBits,
+		Data:            electra.Data,
+		Signature:       electra.Signature,
+	}
+	return phase0.ToConsensus()
+}
+
+// sendAttestation converts an attestation to an indexed attestation and sends it to the slasher.
+func (s *Source) sendAttestation(att ethpb.Att) error {
+	committees, err := s.attestationCommittees(att)
+	if err != nil {
+		return err
+	}
+	indexedAtt, err := attestation.ConvertToIndexed(s.ctx, att, committees...)
+	if err != nil {
+		return errors.Wrap(err, "could not convert to indexed attestation")
+	}
+	s.cfg.IndexedAttestationsFeed.Send(&slashertypes.WrappedIndexedAtt{IndexedAtt: indexedAtt})
+	return nil
+}
+
+// attestationCommittees returns the committees of the validators that may have signed an attestation.
+func (s *Source) attestationCommittees(att ethpb.Att) ([][]primitives.ValidatorIndex, error) {
+	committees, err := s.targetCommittees(att.GetData().Target)
+	if err != nil {
+		return nil, err
+	}
+	indices := []primitives.CommitteeIndex{att.GetData().CommitteeIndex}
+	if att.Version() >= version.Electra {
+		bits := att.CommitteeBitsVal().BitIndices()
+		indices = make([]primitives.CommitteeIndex, len(bits))
+		for i, b := range bits {
+			indices[i] = primitives.CommitteeIndex(b)
+		}
+	}
+	result := make([][]primitives.ValidatorIndex, len(indices))
+	for i, index := range indices {
+		committee, ok := committees[committeeKey{slot: att.GetData().Slot, index: index}]
+		if !ok {
+			return nil, errors.Errorf("no committee %d at slot %d", index, att.GetData().Slot)
+		}
+		result[i] = committee
+	}
+	return result, nil
+}
+
+// targetCommittees returns the committees of the target epoch of an attestation. They are computed by
+// the beacon node from the state of the target block, so that attestations on any fork are indexed
+// with the right shuffling.
+func (s *Source) targetCommittees(target *ethpb.Checkpoint) (map[committeeKey][]primitives.ValidatorIndex, error) {
+	key := targetKey{epoch: target.Epoch, root: bytesutil.ToBytes32(target.Root)}
+	s.committeesLock.Lock()
+	committees, ok := s.committees[key]
+	s.committeesLock.Unlock()
+	if ok {
+		return committees, nil
+	}
+
+	// The committees are requested without holding the lock, concurrent requests for the same target
+	// store the same committees.
+
+	stateRoot, err := s.cfg.Chain.stateRoot(s.ctx, key.root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get state root of target %#x", key.root)
+	}
+	resp, err := s.cfg.Client.GetCommittees(s.ctx, beacon.IdFromRoot(stateRoot), key.epoch)
+	if err != nil {
+		return nil, err
+	}
+	committees = make(map[committeeKey][]primitives.ValidatorIndex, len(resp))
+	for _, c := range resp {
+		slot, err := strconv.ParseUint(c.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid committee slot %s", c.Slot)
+		}
+		index, err := strconv.ParseUint(c.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid committee index %s", c.Index)
+		}
+		validators := make([]primitives.ValidatorIndex, len(c.Validators))
+		for i, v := range c.Validators {
+			validator, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid validator index %s", v)
+			}
+			validators[i] = primitives.ValidatorIndex(validator)
+		}
+		committees[committeeKey{slot: primitives.Slot(slot), index: primitives.CommitteeIndex(index)}] = validators
+	}
+
+	// Attestations are only accepted for the current and previous epochs.
+	s.committeesLock.Lock()
+	defer s.committeesLock.Unlock()
+	for k := range s.committees {
+		if k.epoch+1 < key.epoch {
+			delete(s.committees, k)
+		}
+	}
+	s.committees[key] = committees
+	return committees, nil
+}
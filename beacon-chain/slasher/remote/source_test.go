@@ -0,0 +1,166 @@
+package remote
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/api/client/event"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	asyncevent "github.com/prysmaticlabs/prysm/v5/async/event"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestSource_receiveAttestation(t *testing.T) {
+	node := &testBeaconNode{
+		stateRoot: [32]byte{'s'},
+		committees: []*structs.Committee{
+			{Slot: "33", Index: "0", Validators: []string{"5", "9", "12"}},
+			{Slot: "33", Index: "1", Validators: []string{"3", "7"}},
+		},
+	}
+	srv := node.server(t)
+	c, err := beacon.NewClient(srv.URL)
+	require.NoError(t, err)
+	attsFeed := new(asyncevent.Feed)
+	s := NewSource(context.Background(), &SourceConfig{
+		Client:                  c,
+		Chain:                   NewChain(c, time.Millisecond),
+		IndexedAttestationsFeed: attsFeed,
+		BeaconBlockHeadersFeed:  new(asyncevent.Feed),
+	})
+	atts := make(chan *slashertypes.WrappedIndexedAtt, 1)
+	sub := attsFeed.Subscribe(atts)
+	defer sub.Unsubscribe()
+
+	receive := func(committeeIndex primitives.CommitteeIndex, bits bitfield.Bitlist) []uint64 {
+		att := util.HydrateAttestation(&ethpb.Attestation{
+			AggregationBits: bits,
+			Data: &ethpb.AttestationData{
+				Slot:           33,
+				CommitteeIndex: committeeIndex,
+				Target:         &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+			},
+		})
+		data, err := json.Marshal(structs.AttFromConsensus(att))
+		require.NoError(t, err)
+		require.NoError(t, s.receiveAttestation(data))
+		return (<-atts).GetAttestingIndices()
+	}
+
+	bits := bitfield.NewBitlist(3)
+	bits.SetBitAt(1, true)
+	bits.SetBitAt(2, true)
+	require.DeepEqual(t, []uint64{9, 12}, receive(0, bits))
+	bits = bitfield.NewBitlist(2)
+	bits.SetBitAt(0, true)
+	require.DeepEqual(t, []uint64{3}, receive(1, bits))
+	// The committees of a target are only requested once.
+	require.Equal(t, 1, node.committeeCalls)
+
+	att := util.HydrateAttestation(&ethpb.Attestation{
+		Data: &ethpb.AttestationData{Slot: 33, CommitteeIndex: 2, Target: &ethpb.Checkpoint{Epoch: 1}},
+	})
+	data, err := json.Marshal(structs.AttFromConsensus(att))
+	require.NoError(t, err)
+	require.ErrorContains(t, "no committee 2 at slot 33", s.receiveAttestation(data))
+}
+
+func TestSource_backfill(t *testing.T) {
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = 35
+	b, err := blk.MarshalSSZ()
+	require.NoError(t, err)
+	bits := bitfield.NewBitlist(2)
+	bits.SetBitAt(1, true)
+	att := util.HydrateAttestation(&ethpb.Attestation{
+		AggregationBits: bits,
+		Data:            &ethpb.AttestationData{Slot: 33, Target: &ethpb.Checkpoint{Epoch: 1}},
+	})
+	node := &testBeaconNode{
+		headSlot:   "36",
+		stateRoot:  [32]byte{'s'},
+		committees: []*structs.Committee{{Slot: "33", Index: "0", Validators: []string{"4", "8"}}},
+		blocks:     map[string][]byte{"35": b},
+		poolAtts:   []*structs.Attestation{structs.AttFromConsensus(att)},
+	}
+	srv := node.server(t)
+	c, err := beacon.NewClient(srv.URL)
+	require.NoError(t, err)
+	attsFeed := new(asyncevent.Feed)
+	headersFeed := new(asyncevent.Feed)
+	s := NewSource(context.Background(), &SourceConfig{
+		Client:                  c,
+		Chain:                   NewChain(c, time.Millisecond),
+		IndexedAttestationsFeed: attsFeed,
+		BeaconBlockHeadersFeed:  headersFeed,
+	})
+	atts := make(chan *slashertypes.WrappedIndexedAtt, 1)
+	attsSub := attsFeed.Subscribe(atts)
+	defer attsSub.Unsubscribe()
+	headers := make(chan *ethpb.SignedBeaconBlockHeader, 1)
+	headersSub := headersFeed.Subscribe(headers)
+	defer headersSub.Unsubscribe()
+
+	// Nothing is backfilled before a block was processed.
+	s.backfill()
+	require.Equal(t, 0, len(node.blockRequests))
+
+	// The blocks after the last processed one are requested up to the head, skipping missed slots.
+	s.lastSlot, s.hasLastSlot = 33, true
+	s.backfill()
+	require.DeepEqual(t, []string{"34", "35", "36"}, node.blockRequests)
+	require.Equal(t, primitives.Slot(35), (<-headers).Header.Slot)
+	require.Equal(t, primitives.Slot(35), s.lastSlot)
+	require.DeepEqual(t, []uint64{8}, (<-atts).GetAttestingIndices())
+}
+
+func TestEventStreamClient(t *testing.T) {
+	hc := &http.Client{Timeout: time.Second}
+	stream := eventStreamClient(hc)
+	require.Equal(t, time.Duration(0), stream.Timeout)
+	transport, ok := stream.Transport.(*http.Transport)
+	require.Equal(t, true, ok)
+	require.Equal(t, time.Second, transport.ResponseHeaderTimeout)
+
+	tlsTransport := &http.Transport{TLSClientConfig: &tls.Config{ServerName: "beacon", MinVersion: tls.VersionTLS12}}
+	stream = eventStreamClient(&http.Client{Transport: tlsTransport})
+	transport, ok = stream.Transport.(*http.Transport)
+	require.Equal(t, true, ok)
+	require.Equal(t, "beacon", transport.TLSClientConfig.ServerName)
+}
+
+func TestAttestationFromEvent(t *testing.T) {
+	phase0 := util.HydrateAttestation(&ethpb.Attestation{})
+	data, err := json.Marshal(structs.AttFromConsensus(phase0))
+	require.NoError(t, err)
+	att, err := attestationFromEvent(data)
+	require.NoError(t, err)
+	require.DeepEqual(t, phase0, att)
+
+	electra := util.HydrateAttestationElectra(&ethpb.AttestationElectra{})
+	electra.CommitteeBits.SetBitAt(3, true)
+	data, err = json.Marshal(structs.AttElectraFromConsensus(electra))
+	require.NoError(t, err)
+	att, err = attestationFromEvent(data)
+	require.NoError(t, err)
+	require.DeepEqual(t, electra, att)
+
+	_, err = attestationFromEvent([]byte("{"))
+	require.ErrorContains(t, "could not decode attestation event", err)
+}
+
+func TestSource_processEvent(t *testing.T) {
+	s := NewSource(context.Background(), &SourceConfig{})
+	// Events of other topics are ignored.
+	s.processEvent(&event.Event{EventType: event.EventHead, Data: []byte("{}")})
+}
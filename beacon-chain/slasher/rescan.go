@@ -0,0 +1,262 @@
+package slasher
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// RescanResult summarizes a historical rescan.
+type RescanResult struct {
+	Blocks            int
+	Attestations      int
+	AttesterSlashings []ethpb.AttSlashing
+	ProposerSlashings []*ethpb.ProposerSlashing
+}
+
+// targetKey identifies the target checkpoint of an attestation.
+type targetKey struct {
+	epoch primitives.Epoch
+	root  [32]byte
+}
+
+// Rescan walks the blocks stored in beaconDB from startEpoch to endEpoch, both included, and feeds
+// the proposer headers and the attestations they contain through slashing detection, so that the
+// offenses that happened while the slasher was not running are caught. currentEpoch is the epoch up to
+// which min and max spans are updated. The slashings found are returned and, when the service has a
+// slashing pool, verified and submitted to it.
+func (s *Service) Rescan(
+	ctx context.Context, beaconDB db.ReadOnlyDatabase, startEpoch, endEpoch, currentEpoch primitives.Epoch,
+) (*RescanResult, error) {
+	if endEpoch < startEpoch {
+		return nil, errors.Errorf("end epoch %d is lower than start epoch %d", endEpoch, startEpoch)
+	}
+	if endEpoch > currentEpoch {
+		return nil, errors.Errorf("end epoch %d is higher than current epoch %d", endEpoch, currentEpoch)
+	}
+	if currentEpoch >= s.params.historyLength && startEpoch <= currentEpoch-s.params.historyLength {
+		// Attestations older than the history length are dropped by detection anyway.
+		startEpoch = currentEpoch - s.params.historyLength + 1
+	}
+
+	log.WithFields(logrus.Fields{
+		"startEpoch":   startEpoch,
+		"endEpoch":     endEpoch,
+		"currentEpoch": currentEpoch,
+	}).Info("Rescanning stored blocks for slashable offenses")
+	start := time.Now()
+
+	result := &RescanResult{}
+	targetStates := make(map[targetKey]state.ReadOnlyBeaconState)
+	loaded := make(map[primitives.ValidatorIndex]bool)
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err := s.rescanEpoch(ctx, beaconDB, epoch, currentEpoch, targetStates, loaded, result); err != nil {
+			return nil, errors.Wrapf(err, "could not rescan epoch %d", epoch)
+		}
+		// Attestations included in the blocks of an epoch target that epoch or the previous one.
+		for k := range targetStates {
+			if k.epoch < epoch {
+				delete(targetStates, k)
+			}
+		}
+	}
+
+	if err := s.serviceCfg.Database.SaveLastEpochWrittenForValidators(ctx, s.latestEpochUpdatedForValidator); err != nil {
+		return nil, errors.Wrap(err, "could not save last epoch written for validators")
+	}
+
+	log.WithFields(logrus.Fields{
+		"numBlocks":            result.Blocks,
+		"numAtts":              result.Attestations,
+		"numAttesterSlashings": len(result.AttesterSlashings),
+		"numProposerSlashings": len(result.ProposerSlashings),
+		"elapsed":              time.Since(start),
+	}).Info("Done rescanning stored blocks")
+	return result, nil
+}
+
+// rescanRecentBlocks rescans the blocks of the last RescanEpochs epochs up to the head epoch.
+func (s *Service) rescanRecentBlocks(headEpoch primitives.Epoch) {
+	startEpoch := primitives.Epoch(0)
+	if headEpoch >= s.serviceCfg.RescanEpochs {
+		startEpoch = headEpoch - s.serviceCfg.RescanEpochs + 1
+	}
+	currentEpoch := slots.ToEpoch(slots.CurrentSlot(uint64(s.genesisTime.Unix())))
+	if _, err := s.Rescan(s.ctx, s.serviceCfg.BeaconDatabase, startEpoch, headEpoch, currentEpoch); err != nil {
+		log.WithError(err).Error("Could not rescan stored blocks")
+	}
+}
+
+// rescanEpoch runs slashing detection on the blocks of a single epoch.
+func (s *Service) rescanEpoch(
+	ctx context.Context,
+	beaconDB db.ReadOnlyDatabase,
+	epoch, currentEpoch primitives.Epoch,
+	targetStates map[targetKey]state.ReadOnlyBeaconState,
+	loaded map[primitives.ValidatorIndex]bool,
+	result *RescanResult,
+) error {
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return err
+	}
+	endSlot, err := slots.EpochEnd(epoch)
+	if err != nil {
+		return err
+	}
+	blks, _, err := beaconDB.Blocks(ctx, filters.NewFilter().SetStartSlot(startSlot).SetEndSlot(endSlot))
+	if err != nil {
+		return errors.Wrap(err, "could not get blocks")
+	}
+
+	headers := make([]*slashertypes.SignedBlockHeaderWrapper, 0, len(blks))
+	atts := make([]*slashertypes.IndexedAttestationWrapper, 0)
+	for _, blk := range blks {
+		if blk.Block().Slot() == 0 {
+			continue
+		}
+		header, err := interfaces.SignedBeaconBlockHeaderFromBlockInterface(blk)
+		if err != nil {
+			return errors.Wrap(err, "could not get block header")
+		}
+		headerRoot, err := header.Header.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not get hash tree root of block header")
+		}
+		headers = append(headers, &slashertypes.SignedBlockHeaderWrapper{
+			SignedBeaconBlockHeader: header,
+			HeaderRoot:              headerRoot,
+		})
+
+		for _, att := range blk.Block().Body().Attestations() {
+			st, err := s.rescanTargetState(ctx, att.GetData().Target, targetStates)
+			if err != nil {
+				return err
+			}
+			committees, err := helpers.AttestationCommittees(ctx, st, att)
+			if err != nil {
+				return errors.Wrap(err, "could not get attestation committees")
+			}
+			indexedAtt, err := attestation.ConvertToIndexed(ctx, att, committees...)
+			if err != nil {
+				return errors.Wrap(err, "could not convert to indexed attestation")
+			}
+			if !validateAttestationIntegrity(indexedAtt) {
+				continue
+			}
+			dataRoot, err := indexedAtt.GetData().HashTreeRoot()
+			if err != nil {
+				return errors.Wrap(err, "could not get hash tree root of attestation")
+			}
+			atts = append(atts, &slashertypes.IndexedAttestationWrapper{
+				IndexedAttestation: indexedAtt,
+				DataRoot:           dataRoot,
+			})
+		}
+	}
+	result.Blocks += len(headers)
+
+	proposerSlashings, err := s.detectProposerSlashings(ctx, headers)
+	if err != nil {
+		return errors.Wrap(err, "could not detect proposer slashings")
+	}
+	result.ProposerSlashings = append(result.ProposerSlashings, proposerSlashings...)
+
+	validAtts, _, _ := s.filterAttestations(atts, currentEpoch)
+	result.Attestations += len(validAtts)
+	if err := s.loadLastEpochsWritten(ctx, validAtts, loaded); err != nil {
+		return err
+	}
+	attesterSlashings, err := s.checkSlashableAttestations(ctx, currentEpoch, validAtts)
+	if err != nil {
+		return errors.Wrap(err, couldNotCheckSlashableAtt)
+	}
+	for _, slashing := range attesterSlashings {
+		result.AttesterSlashings = append(result.AttesterSlashings, slashing)
+	}
+
+	if s.serviceCfg.SlashingPoolInserter == nil {
+		return nil
+	}
+	if _, err := s.processAttesterSlashings(ctx, attesterSlashings); err != nil {
+		return errors.Wrap(err, couldNotProcessAttesterSlashings)
+	}
+	if err := s.processProposerSlashings(ctx, proposerSlashings); err != nil {
+		return errors.Wrap(err, "could not process proposer slashings")
+	}
+	return nil
+}
+
+// rescanTargetState returns the state at the start of the target epoch of an attestation, which
+// is used to compute its committees.
+func (s *Service) rescanTargetState(
+	ctx context.Context, target *ethpb.Checkpoint, targetStates map[targetKey]state.ReadOnlyBeaconState,
+) (state.ReadOnlyBeaconState, error) {
+	key := targetKey{epoch: target.Epoch, root: bytesutil.ToBytes32(target.Root)}
+	if st, ok := targetStates[key]; ok {
+		return st, nil
+	}
+	st, err := s.serviceCfg.StateGen.StateByRoot(ctx, key.root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get state for target root %#x", key.root)
+	}
+	epochStart, err := slots.EpochStart(key.epoch)
+	if err != nil {
+		return nil, err
+	}
+	if st.Slot() < epochStart {
+		st, err = transition.ProcessSlots(ctx, st, epochStart)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not process slots up to %d", epochStart)
+		}
+	}
+	targetStates[key] = st
+	return st, nil
+}
+
+// loadLastEpochsWritten reads from the database the last epoch written for the attesting validators
+// that the service does not know about yet, and marks them as loaded. The service only loads them for
+// every validator when it starts, so this is needed when rescanning without a running service.
+func (s *Service) loadLastEpochsWritten(
+	ctx context.Context, atts []*slashertypes.IndexedAttestationWrapper, loaded map[primitives.ValidatorIndex]bool,
+) error {
+	missing := make([]primitives.ValidatorIndex, 0)
+	for _, att := range atts {
+		for _, i := range att.IndexedAttestation.GetAttestingIndices() {
+			index := primitives.ValidatorIndex(i)
+			if _, ok := s.latestEpochUpdatedForValidator[index]; ok || loaded[index] {
+				continue
+			}
+			loaded[index] = true
+			missing = append(missing, index)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	epochsByValidator, err := s.serviceCfg.Database.LastEpochWrittenForValidators(ctx, missing)
+	if err != nil {
+		return errors.Wrap(err, "could not get last epoch written for validators")
+	}
+	for _, item := range epochsByValidator {
+		s.latestEpochUpdatedForValidator[item.ValidatorIndex] = item.Epoch
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package slasher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestService_Rescan(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	beaconDB := dbtest.SetupDB(t)
+
+	genesisState, _ := util.DeterministicGenesisState(t, 64)
+	genesis := util.NewBeaconBlock()
+	stateRoot, err := genesisState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis.Block.StateRoot = stateRoot[:]
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, genesisState, genesisRoot))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	// Two blocks include conflicting attestations from the same committee, and a third block is a
+	// double proposal of the second one.
+	attestation := func(blockRoot byte) *ethpb.Attestation {
+		bits := bitfield.NewBitlist(2)
+		bits.SetBitAt(0, true)
+		return util.HydrateAttestation(&ethpb.Attestation{
+			AggregationBits: bits,
+			Data: &ethpb.AttestationData{
+				BeaconBlockRoot: bytesutil.PadTo([]byte{blockRoot}, 32),
+				Target:          &ethpb.Checkpoint{Root: genesisRoot[:]},
+			},
+		})
+	}
+	first := util.NewBeaconBlock()
+	first.Block.Slot = 1
+	first.Block.ParentRoot = genesisRoot[:]
+	first.Block.Body.Attestations = []*ethpb.Attestation{attestation('a')}
+	second := util.NewBeaconBlock()
+	second.Block.Slot = 2
+	second.Block.ProposerIndex = 7
+	second.Block.ParentRoot = genesisRoot[:]
+	second.Block.Body.Attestations = []*ethpb.Attestation{attestation('b')}
+	double := util.NewBeaconBlock()
+	double.Block.Slot = 2
+	double.Block.ProposerIndex = 7
+	double.Block.ParentRoot = genesisRoot[:]
+	double.Block.Body.Graffiti = bytesutil.PadTo([]byte("double"), 32)
+	for _, b := range []*ethpb.SignedBeaconBlock{first, second, double} {
+		util.SaveBlock(t, ctx, beaconDB, b)
+	}
+
+	s, err := New(ctx, &ServiceConfig{
+		Database: slasherDB,
+		StateGen: stategen.New(beaconDB, doublylinkedtree.New()),
+	})
+	require.NoError(t, err)
+
+	_, err = s.Rescan(ctx, beaconDB, 1, 0, 1)
+	require.ErrorContains(t, "end epoch 0 is lower than start epoch 1", err)
+	_, err = s.Rescan(ctx, beaconDB, 0, 2, 1)
+	require.ErrorContains(t, "end epoch 2 is higher than current epoch 1", err)
+
+	result, err := s.Rescan(ctx, beaconDB, 0, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Blocks)
+	require.Equal(t, 2, result.Attestations)
+	require.Equal(t, 1, len(result.AttesterSlashings))
+	require.Equal(t, 1, len(result.ProposerSlashings))
+	require.Equal(t, primitives.ValidatorIndex(7), result.ProposerSlashings[0].Header_1.Header.ProposerIndex)
+	require.Equal(t, primitives.ValidatorIndex(7), result.ProposerSlashings[0].Header_2.Header.ProposerIndex)
+
+	// The attestations and proposals were saved, so a second rescan finds the offenses against the database.
+	result, err = s.Rescan(ctx, beaconDB, 0, 1, 1)
+	require.NoError(t, err)
+	require.NotEqual(t, 0, len(result.AttesterSlashings))
+	require.Equal(t, 2, len(result.ProposerSlashings))
+}
@@ -16,7 +16,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
-	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	beaconChainSync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
@@ -37,11 +37,27 @@ type ServiceConfig struct {
 	Database                db.SlasherDatabase
 	StateNotifier           statefeed.Notifier
 	AttestationStateFetcher blockchain.AttestationStateFetcher
-	StateGen                stategen.StateManager
+	StateGen                StateByRooter
 	SlashingPoolInserter    slashings.PoolInserter
-	HeadStateFetcher        blockchain.HeadFetcher
+	SlashingArchive         *slashings.Archive
+	HeadStateFetcher        HeadFetcher
 	SyncChecker             beaconChainSync.Checker
 	ClockWaiter             startup.ClockWaiter
+	BeaconDatabase          db.ReadOnlyDatabase
+	RescanEpochs            primitives.Epoch
+}
+
+// HeadFetcher retrieves the head of the chain. It is satisfied by the blockchain service when the
+// slasher runs inside the beacon node, and by a beacon API client when it runs on its own.
+type HeadFetcher interface {
+	HeadSlot() primitives.Slot
+	HeadState(ctx context.Context) (state.BeaconState, error)
+}
+
+// StateByRooter retrieves the post state of a block, which is used to verify the signatures of
+// detected slashings.
+type StateByRooter interface {
+	StateByRoot(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
 }
 
 // Service defining a slasher implementation as part of
@@ -86,7 +102,10 @@ func (s *Service) Start() {
 }
 
 func (s *Service) run() {
-	s.waitForChainInitialization()
+	if err := s.waitForChainInitialization(); err != nil {
+		log.WithError(err).Error("Could not receive chain start notification")
+		return
+	}
 	s.waitForSync(s.genesisTime)
 
 	log.Info("Completed chain sync, starting slashing detection")
@@ -147,6 +166,11 @@ func (s *Service) run() {
 	s.wg.Add(1)
 	go s.receiveBlocks(s.ctx, beaconBlockHeadersChan)
 
+	// Live attestations and blocks are queued while the stored blocks are rescanned.
+	if s.serviceCfg.RescanEpochs > 0 {
+		s.rescanRecentBlocks(headEpoch)
+	}
+
 	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
 	s.attsSlotTicker = slots.NewSlotTicker(s.genesisTime, secondsPerSlot)
 	s.blocksSlotTicker = slots.NewSlotTicker(s.genesisTime, secondsPerSlot)
@@ -198,15 +222,16 @@ func (*Service) Status() error {
 	return nil
 }
 
-func (s *Service) waitForChainInitialization() {
+func (s *Service) waitForChainInitialization() error {
 	clock, err := s.serviceCfg.ClockWaiter.WaitForClock(s.ctx)
 	if err != nil {
-		log.WithError(err).Error("Could not receive chain start notification")
+		return err
 	}
 	s.genesisTime = clock.GenesisTime()
 	log.WithField("genesisTime", s.genesisTime).Info(
 		"Slasher received chain initialization event",
 	)
+	return nil
 }
 
 func (s *Service) waitForSync(genesisTime time.Time) {
@@ -0,0 +1,431 @@
+// Package diff computes the field level difference between two beacon states.
+package diff
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	state_native "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"google.golang.org/protobuf/proto"
+)
+
+// StateDiff is the difference between two beacon states. Only the fields listed in ChangedFields differ, and the
+// other members detail the changes of the fields most useful to debug state transitions.
+type StateDiff struct {
+	FromSlot          primitives.Slot
+	ToSlot            primitives.Slot
+	FromVersion       int
+	ToVersion         int
+	ChangedFields     []string
+	Fork              *ForkChange
+	JustificationBits *JustificationBitsChange
+	Checkpoints       []*CheckpointChange
+	Validators        []*ValidatorChange
+	Balances          []*Uint64Change
+	InactivityScores  []*Uint64Change
+	Participation     []*ParticipationChange
+	Queues            []*QueueChange
+	// Truncated is true when the changes of a per validator field were not all listed because of the limit given
+	// to Compute.
+	Truncated bool
+}
+
+// ForkChange is a change of the fork of the state.
+type ForkChange struct {
+	Old *ethpb.Fork
+	New *ethpb.Fork
+}
+
+// JustificationBitsChange is a change of the justification bits of the state.
+type JustificationBitsChange struct {
+	Old bitfield.Bitvector4
+	New bitfield.Bitvector4
+}
+
+// CheckpointChange is a change of one of the checkpoints of the state.
+type CheckpointChange struct {
+	Name string
+	Old  *ethpb.Checkpoint
+	New  *ethpb.Checkpoint
+}
+
+// ValidatorChange is a change of a validator record. Old is nil for validators added to the registry.
+type ValidatorChange struct {
+	Index primitives.ValidatorIndex
+	Old   *ethpb.Validator
+	New   *ethpb.Validator
+}
+
+// Uint64Change is a change of a per validator value, such as a balance. Old is zero for validators added to the
+// registry.
+type Uint64Change struct {
+	Index primitives.ValidatorIndex
+	Old   uint64
+	New   uint64
+}
+
+// ParticipationChange is a change of the participation flags of a validator in the previous or current epoch.
+type ParticipationChange struct {
+	Index primitives.ValidatorIndex
+	Epoch string
+	Old   byte
+	New   byte
+}
+
+// QueueChange is a change of a list used as a queue, which is processed from its head and appended to at its tail.
+type QueueChange struct {
+	Name      string
+	OldLength int
+	NewLength int
+	// Removed is the number of items removed from the head of the queue.
+	Removed int
+	// Added is the number of items appended to the tail of the queue.
+	Added int
+}
+
+const (
+	previousEpoch = "previous"
+	currentEpoch  = "current"
+	// balancesPerChunk is the number of balances packed in a chunk of the balances field trie.
+	balancesPerChunk = 4
+)
+
+// Compute returns the difference between the two states, which must be native states. The fields which did not
+// change are found from the field roots of the states and skipped, and the changed validators and balances are
+// found from the field tries of the states without reading the unchanged ones. A positive limit bounds the number
+// of changes listed for each per validator field, such as validators, balances and participation flags.
+func Compute(ctx context.Context, from, to state.ReadOnlyBeaconState, limit int) (*StateDiff, error) {
+	fromNative, ok := from.(*state_native.BeaconState)
+	if !ok {
+		return nil, errors.New("from state is not a native beacon state")
+	}
+	toNative, ok := to.(*state_native.BeaconState)
+	if !ok {
+		return nil, errors.New("to state is not a native beacon state")
+	}
+	changed, err := state_native.ChangedFields(ctx, fromNative, toNative)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compare field roots")
+	}
+
+	d := &StateDiff{
+		FromSlot:      from.Slot(),
+		ToSlot:        to.Slot(),
+		FromVersion:   from.Version(),
+		ToVersion:     to.Version(),
+		ChangedFields: make([]string, len(changed)),
+	}
+	for i, f := range changed {
+		d.ChangedFields[i] = f.String()
+		if err := d.addField(ctx, f, fromNative, toNative, limit); err != nil {
+			return nil, errors.Wrapf(err, "could not compare %s", f)
+		}
+	}
+	return d, nil
+}
+
+// addField adds the details of the changes of the given field to the diff.
+func (d *StateDiff) addField(ctx context.Context, f types.FieldIndex, from, to *state_native.BeaconState, limit int) error {
+	switch f {
+	case types.Fork:
+		d.Fork = &ForkChange{Old: from.Fork(), New: to.Fork()}
+	case types.JustificationBits:
+		d.JustificationBits = &JustificationBitsChange{Old: from.JustificationBits(), New: to.JustificationBits()}
+	case types.PreviousJustifiedCheckpoint:
+		d.addCheckpoint("previous_justified", from.PreviousJustifiedCheckpoint(), to.PreviousJustifiedCheckpoint())
+	case types.CurrentJustifiedCheckpoint:
+		d.addCheckpoint("current_justified", from.CurrentJustifiedCheckpoint(), to.CurrentJustifiedCheckpoint())
+	case types.FinalizedCheckpoint:
+		d.addCheckpoint("finalized", from.FinalizedCheckpoint(), to.FinalizedCheckpoint())
+	case types.Validators:
+		changes, err := validatorChanges(ctx, from, to, limit)
+		if err != nil {
+			return err
+		}
+		d.Validators = capChanges(d, changes, limit)
+	case types.Balances:
+		changes, err := balanceChanges(ctx, from, to, limit)
+		if err != nil {
+			return err
+		}
+		d.Balances = capChanges(d, changes, limit)
+	case types.InactivityScores:
+		old, err := inactivityScores(from)
+		if err != nil {
+			return err
+		}
+		current, err := inactivityScores(to)
+		if err != nil {
+			return err
+		}
+		d.InactivityScores = capChanges(d, uint64Changes(old, current, limit), limit)
+	case types.PreviousEpochParticipationBits:
+		old, err := participation(from, previousEpoch)
+		if err != nil {
+			return err
+		}
+		current, err := participation(to, previousEpoch)
+		if err != nil {
+			return err
+		}
+		d.Participation = capChanges(d, append(d.Participation, participationChanges(previousEpoch, old, current, limit)...), limit)
+	case types.CurrentEpochParticipationBits:
+		old, err := participation(from, currentEpoch)
+		if err != nil {
+			return err
+		}
+		current, err := participation(to, currentEpoch)
+		if err != nil {
+			return err
+		}
+		d.Participation = capChanges(d, append(d.Participation, participationChanges(currentEpoch, old, current, limit)...), limit)
+	case types.Eth1DataVotes:
+		d.Queues = append(d.Queues, queueChange("eth1_data_votes", from.Eth1DataVotes(), to.Eth1DataVotes()))
+	case types.PendingDeposits:
+		return addQueue(d, "pending_deposits", from, to, state.ReadOnlyBeaconState.PendingDeposits)
+	case types.PendingPartialWithdrawals:
+		return addQueue(d, "pending_partial_withdrawals", from, to, state.ReadOnlyBeaconState.PendingPartialWithdrawals)
+	case types.PendingConsolidations:
+		return addQueue(d, "pending_consolidations", from, to, state.ReadOnlyBeaconState.PendingConsolidations)
+	}
+	return nil
+}
+
+func (d *StateDiff) addCheckpoint(name string, old, current *ethpb.Checkpoint) {
+	d.Checkpoints = append(d.Checkpoints, &CheckpointChange{Name: name, Old: old, New: current})
+}
+
+// capChanges truncates the changes to the limit, if any, and records the truncation in the diff.
+func capChanges[T any](d *StateDiff, changes []T, limit int) []T {
+	if limit > 0 && len(changes) > limit {
+		d.Truncated = true
+		return changes[:limit]
+	}
+	return changes
+}
+
+// full returns true when more changes than the limit were found, so that the search can stop.
+func full[T any](changes []T, limit int) bool {
+	return limit > 0 && len(changes) > limit
+}
+
+// validatorChanges returns the changed validator records, found from the validators field tries of the states.
+// The registry is scanned when a field trie was modified concurrently.
+func validatorChanges(ctx context.Context, from, to *state_native.BeaconState, limit int) ([]*ValidatorChange, error) {
+	chunks, ok, err := state_native.ChangedChunks(ctx, from, to, types.Validators)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return scanValidatorChanges(ctx, from, to, limit)
+	}
+	numOld, numNew := uint64(from.NumValidators()), uint64(to.NumValidators())
+	var changes []*ValidatorChange
+	for _, c := range chunks {
+		if c >= numNew || full(changes, limit) {
+			break
+		}
+		idx := primitives.ValidatorIndex(c)
+		val, err := to.ValidatorAtIndexReadOnly(idx)
+		if err != nil {
+			return nil, err
+		}
+		change := &ValidatorChange{Index: idx, New: val.Copy()}
+		if c < numOld {
+			old, err := from.ValidatorAtIndexReadOnly(idx)
+			if err != nil {
+				return nil, err
+			}
+			change.Old = old.Copy()
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// scanValidatorChanges compares every validator record of the states. Validators are read in place and only the
+// changed ones are copied.
+func scanValidatorChanges(ctx context.Context, from, to state.ReadOnlyBeaconState, limit int) ([]*ValidatorChange, error) {
+	numOld := from.NumValidators()
+	var changes []*ValidatorChange
+	err := to.ReadFromEveryValidator(func(idx int, val state.ReadOnlyValidator) error {
+		if idx%1024 == 0 && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if full(changes, limit) {
+			return nil
+		}
+		if idx >= numOld {
+			changes = append(changes, &ValidatorChange{Index: primitives.ValidatorIndex(idx), New: val.Copy()})
+			return nil
+		}
+		old, err := from.ValidatorAtIndexReadOnly(primitives.ValidatorIndex(idx))
+		if err != nil {
+			return err
+		}
+		if !validatorsEqual(old, val) {
+			changes = append(changes, &ValidatorChange{Index: primitives.ValidatorIndex(idx), Old: old.Copy(), New: val.Copy()})
+		}
+		return nil
+	})
+	return changes, err
+}
+
+// balanceChanges returns the changed balances, found from the balances field tries of the states. Each changed
+// chunk of the trie holds up to four balances, which are compared one by one. The balances are compared in full
+// when a field trie was modified concurrently.
+func balanceChanges(ctx context.Context, from, to *state_native.BeaconState, limit int) ([]*Uint64Change, error) {
+	chunks, ok, err := state_native.ChangedChunks(ctx, from, to, types.Balances)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return uint64Changes(from.Balances(), to.Balances(), limit), nil
+	}
+	numOld, numNew := uint64(from.BalancesLength()), uint64(to.BalancesLength())
+	var changes []*Uint64Change
+	for _, c := range chunks {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if full(changes, limit) {
+			break
+		}
+		for i := c * balancesPerChunk; i < (c+1)*balancesPerChunk && i < numNew; i++ {
+			idx := primitives.ValidatorIndex(i)
+			v, err := to.BalanceAtIndex(idx)
+			if err != nil {
+				return nil, err
+			}
+			change := &Uint64Change{Index: idx, New: v}
+			if i < numOld {
+				if change.Old, err = from.BalanceAtIndex(idx); err != nil {
+					return nil, err
+				}
+				if change.Old == v {
+					continue
+				}
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+func validatorsEqual(a, b state.ReadOnlyValidator) bool {
+	return a.EffectiveBalance() == b.EffectiveBalance() &&
+		a.Slashed() == b.Slashed() &&
+		a.ActivationEligibilityEpoch() == b.ActivationEligibilityEpoch() &&
+		a.ActivationEpoch() == b.ActivationEpoch() &&
+		a.ExitEpoch() == b.ExitEpoch() &&
+		a.WithdrawableEpoch() == b.WithdrawableEpoch() &&
+		a.PublicKey() == b.PublicKey() &&
+		bytes.Equal(a.GetWithdrawalCredentials(), b.GetWithdrawalCredentials())
+}
+
+func uint64Changes(old, current []uint64, limit int) []*Uint64Change {
+	var changes []*Uint64Change
+	for i, v := range current {
+		if full(changes, limit) {
+			break
+		}
+		if i >= len(old) || old[i] != v {
+			c := &Uint64Change{Index: primitives.ValidatorIndex(i), New: v}
+			if i < len(old) {
+				c.Old = old[i]
+			}
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+func participationChanges(epoch string, old, current []byte, limit int) []*ParticipationChange {
+	var changes []*ParticipationChange
+	for i, v := range current {
+		if full(changes, limit) {
+			break
+		}
+		if i >= len(old) || old[i] != v {
+			c := &ParticipationChange{Index: primitives.ValidatorIndex(i), Epoch: epoch, New: v}
+			if i < len(old) {
+				c.Old = old[i]
+			}
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// inactivityScores returns the inactivity scores of the state, which are empty before Altair.
+func inactivityScores(st state.ReadOnlyBeaconState) ([]uint64, error) {
+	if st.Version() < version.Altair {
+		return nil, nil
+	}
+	return st.InactivityScores()
+}
+
+// participation returns the participation flags of the state in the given epoch, which are empty before Altair.
+func participation(st state.ReadOnlyBeaconState, epoch string) ([]byte, error) {
+	if st.Version() < version.Altair {
+		return nil, nil
+	}
+	if epoch == previousEpoch {
+		return st.PreviousEpochParticipation()
+	}
+	return st.CurrentEpochParticipation()
+}
+
+// addQueue adds the change of an Electra queue to the diff. The queue is empty in states before Electra.
+func addQueue[T proto.Message](d *StateDiff, name string, from, to state.ReadOnlyBeaconState, get func(state.ReadOnlyBeaconState) ([]T, error)) error {
+	var old, current []T
+	var err error
+	if from.Version() >= version.Electra {
+		if old, err = get(from); err != nil {
+			return err
+		}
+	}
+	if to.Version() >= version.Electra {
+		if current, err = get(to); err != nil {
+			return err
+		}
+	}
+	d.Queues = append(d.Queues, queueChange(name, old, current))
+	return nil
+}
+
+// queueChange finds how many items were removed from the head of the old queue and appended to its tail to obtain
+// the new queue, by looking for the first item of the old queue from which the remainder of the old queue is a
+// prefix of the new queue.
+func queueChange[T proto.Message](name string, old, current []T) *QueueChange {
+	removed := len(old)
+	for i := range old {
+		if len(old)-i <= len(current) && isPrefix(old[i:], current) {
+			removed = i
+			break
+		}
+	}
+	return &QueueChange{
+		Name:      name,
+		OldLength: len(old),
+		NewLength: len(current),
+		Removed:   removed,
+		Added:     len(current) - (len(old) - removed),
+	}
+}
+
+func isPrefix[T proto.Message](prefix, list []T) bool {
+	for i := range prefix {
+		if !proto.Equal(prefix[i], list[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package diff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestCompute(t *testing.T) {
+	ctx := context.Background()
+	from, _ := util.DeterministicGenesisStateElectra(t, 64)
+	deposit := func(amount uint64) *ethpb.PendingDeposit {
+		return &ethpb.PendingDeposit{
+			PublicKey:             make([]byte, 48),
+			WithdrawalCredentials: make([]byte, 32),
+			Amount:                amount,
+			Signature:             make([]byte, 96),
+		}
+	}
+	require.NoError(t, from.SetPendingDeposits([]*ethpb.PendingDeposit{deposit(1), deposit(2), deposit(3)}))
+	to := from.Copy()
+
+	require.NoError(t, to.SetSlot(from.Slot()+1))
+	val, err := to.ValidatorAtIndex(3)
+	require.NoError(t, err)
+	oldVal := ethpb.CopyValidator(val)
+	val.ExitEpoch = 10
+	require.NoError(t, to.UpdateValidatorAtIndex(3, val))
+	newVal := &ethpb.Validator{
+		PublicKey:             make([]byte, 48),
+		WithdrawalCredentials: make([]byte, 32),
+		EffectiveBalance:      params.BeaconConfig().MinActivationBalance,
+	}
+	require.NoError(t, to.AppendValidator(newVal))
+	require.NoError(t, to.AppendBalance(5))
+	require.NoError(t, to.AppendCurrentParticipationBits(0))
+	oldBalance, err := from.BalanceAtIndex(7)
+	require.NoError(t, err)
+	require.NoError(t, to.UpdateBalancesAtIndex(7, oldBalance+9))
+	require.NoError(t, to.ModifyCurrentParticipationBits(func(val []byte) ([]byte, error) {
+		val[2] = 0b111
+		return val, nil
+	}))
+	finalized := &ethpb.Checkpoint{Epoch: 2, Root: make([]byte, 32)}
+	require.NoError(t, to.SetFinalizedCheckpoint(finalized))
+	require.NoError(t, to.SetPendingDeposits([]*ethpb.PendingDeposit{deposit(3), deposit(4), deposit(5)}))
+
+	d, err := Compute(ctx, from, to, 0)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []string{
+		"slot", "validators", "balances", "currentEpochParticipationBits", "finalizedCheckpoint", "pendingDeposits",
+	}, d.ChangedFields)
+	assert.Equal(t, from.Slot()+1, d.ToSlot)
+
+	require.Equal(t, 2, len(d.Validators))
+	assert.Equal(t, 3, int(d.Validators[0].Index))
+	assert.DeepEqual(t, oldVal, d.Validators[0].Old)
+	assert.DeepEqual(t, val, d.Validators[0].New)
+	assert.Equal(t, 64, int(d.Validators[1].Index))
+	assert.Equal(t, true, d.Validators[1].Old == nil)
+	assert.DeepEqual(t, newVal, d.Validators[1].New)
+
+	assert.DeepEqual(t, []*Uint64Change{{Index: 7, Old: oldBalance, New: oldBalance + 9}, {Index: 64, New: 5}}, d.Balances)
+	require.Equal(t, 2, len(d.Participation))
+	assert.DeepEqual(t, &ParticipationChange{Index: 2, Epoch: currentEpoch, New: 0b111}, d.Participation[0])
+	assert.DeepEqual(t, &ParticipationChange{Index: 64, Epoch: currentEpoch}, d.Participation[1])
+	assert.DeepEqual(t, []*CheckpointChange{{Name: "finalized", Old: from.FinalizedCheckpoint(), New: finalized}}, d.Checkpoints)
+	assert.DeepEqual(t, []*QueueChange{{Name: "pending_deposits", OldLength: 3, NewLength: 3, Removed: 2, Added: 2}}, d.Queues)
+	assert.Equal(t, true, d.Fork == nil)
+}
+
+func TestCompute_Limit(t *testing.T) {
+	ctx := context.Background()
+	from, _ := util.DeterministicGenesisStateElectra(t, 64)
+	to := from.Copy()
+	for i := primitives.ValidatorIndex(0); i < 10; i++ {
+		require.NoError(t, to.UpdateBalancesAtIndex(i, uint64(i)))
+	}
+
+	d, err := Compute(ctx, from, to, 4)
+	require.NoError(t, err)
+	assert.Equal(t, true, d.Truncated)
+	require.Equal(t, 4, len(d.Balances))
+	assert.Equal(t, primitives.ValidatorIndex(3), d.Balances[3].Index)
+
+	d, err = Compute(ctx, from, to, 10)
+	require.NoError(t, err)
+	assert.Equal(t, false, d.Truncated)
+	require.Equal(t, 10, len(d.Balances))
+	assert.Equal(t, uint64(9), d.Balances[9].New)
+}
+
+func TestQueueChange(t *testing.T) {
+	item := func(i uint64) *ethpb.Eth1Data {
+		return &ethpb.Eth1Data{DepositCount: i}
+	}
+	tests := []struct {
+		name              string
+		old, current      []*ethpb.Eth1Data
+		removed, appended int
+	}{
+		{name: "unchanged", old: []*ethpb.Eth1Data{item(1), item(2)}, current: []*ethpb.Eth1Data{item(1), item(2)}},
+		{name: "appended", old: []*ethpb.Eth1Data{item(1)}, current: []*ethpb.Eth1Data{item(1), item(2)}, appended: 1},
+		{name: "processed", old: []*ethpb.Eth1Data{item(1), item(2)}, current: []*ethpb.Eth1Data{item(2)}, removed: 1},
+		{name: "emptied", old: []*ethpb.Eth1Data{item(1), item(2)}, removed: 2},
+		{name: "replaced", old: []*ethpb.Eth1Data{item(1)}, current: []*ethpb.Eth1Data{item(2), item(3)}, removed: 1, appended: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := queueChange("queue", tt.old, tt.current)
+			assert.Equal(t, tt.removed, c.Removed)
+			assert.Equal(t, tt.appended, c.Added)
+			assert.Equal(t, len(tt.old), c.OldLength)
+			assert.Equal(t, len(tt.current), c.NewLength)
+		})
+	}
+}
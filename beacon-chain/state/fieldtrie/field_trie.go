@@ -1,6 +1,7 @@
 package fieldtrie
 
 import (
+	"encoding/binary"
 	"reflect"
 	"sync"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stateutil"
 	multi_value_slice "github.com/prysmaticlabs/prysm/v5/container/multi-value-slice"
+	"github.com/prysmaticlabs/prysm/v5/container/trie"
 	pmath "github.com/prysmaticlabs/prysm/v5/math"
 )
 
@@ -255,6 +257,91 @@ func (f *FieldTrie) TrieRoot() ([32]byte, error) {
 	}
 }
 
+// Proof returns the chunk at the index of the bottom layer of the trie along
+// with the branch linking it to the root of the field. For lists, the branch
+// ends with the length mixin.
+func (f *FieldTrie) Proof(index uint64) ([32]byte, [][32]byte, error) {
+	f.RLock()
+	defer f.RUnlock()
+	if f.Empty() {
+		return [32]byte{}, nil, ErrEmptyFieldTrie
+	}
+	depth := len(f.fieldLayers) - 1
+	if depth < 64 && index >= uint64(1)<<depth {
+		return [32]byte{}, nil, errors.Errorf("index %d out of range for trie of depth %d", index, depth)
+	}
+	leaf := nodeAt(f.fieldLayers[0], index, 0)
+	branch := make([][32]byte, 0, depth+1)
+	for i := 0; i < depth; i++ {
+		branch = append(branch, nodeAt(f.fieldLayers[i], index^1, i))
+		index /= 2
+	}
+	switch f.dataType {
+	case types.BasicArray:
+	case types.CompositeArray:
+		branch = append(branch, lengthChunk(uint64(len(f.fieldLayers[0]))))
+	case types.CompressedArray:
+		branch = append(branch, lengthChunk(uint64(f.numOfElems)))
+	default:
+		return [32]byte{}, nil, errors.Errorf("unrecognized data type in field map: %v", reflect.TypeOf(f.dataType).Name())
+	}
+	return leaf, branch, nil
+}
+
+// ChangedChunks returns the indices of the chunks of the bottom layer of the trie which differ from the chunks of
+// the other trie of the same field, in increasing order. Only the subtrees whose roots differ are visited, so the
+// cost depends on the number of changed chunks rather than on the length of the field.
+func (f *FieldTrie) ChangedChunks(other *FieldTrie) ([]uint64, error) {
+	if f == other {
+		return nil, nil
+	}
+	f.RLock()
+	defer f.RUnlock()
+	other.RLock()
+	defer other.RUnlock()
+	if f.Empty() || other.Empty() {
+		return nil, ErrEmptyFieldTrie
+	}
+	if f.field != other.field || len(f.fieldLayers) != len(other.fieldLayers) {
+		return nil, errors.Errorf("cannot compare trie of %s with depth %d to trie of %s with depth %d",
+			f.field, len(f.fieldLayers)-1, other.field, len(other.fieldLayers)-1)
+	}
+	var changed []uint64
+	var walk func(depth int, index uint64)
+	walk = func(depth int, index uint64) {
+		a, b := f.fieldLayers[depth], other.fieldLayers[depth]
+		if index < uint64(len(a)) && index < uint64(len(b)) && a[index] == b[index] {
+			return
+		}
+		if nodeAt(a, index, depth) == nodeAt(b, index, depth) {
+			return
+		}
+		if depth == 0 {
+			changed = append(changed, index)
+			return
+		}
+		walk(depth-1, 2*index)
+		walk(depth-1, 2*index+1)
+	}
+	walk(len(f.fieldLayers)-1, 0)
+	return changed, nil
+}
+
+// nodeAt returns the node at the index of a layer of the trie, which is the
+// zero hash of the layer's depth when it is past the populated nodes.
+func nodeAt(layer []*[32]byte, index uint64, depth int) [32]byte {
+	if index < uint64(len(layer)) && layer[index] != nil {
+		return *layer[index]
+	}
+	return trie.ZeroHashes[depth]
+}
+
+func lengthChunk(length uint64) [32]byte {
+	var chunk [32]byte
+	binary.LittleEndian.PutUint64(chunk[:8], length)
+	return chunk
+}
+
 // FieldReference returns the underlying field reference
 // object for the trie.
 func (f *FieldTrie) FieldReference() *stateutil.Reference {
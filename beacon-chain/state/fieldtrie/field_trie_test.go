@@ -11,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	mvslice "github.com/prysmaticlabs/prysm/v5/container/multi-value-slice"
+	"github.com/prysmaticlabs/prysm/v5/crypto/hash"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
@@ -227,6 +228,38 @@ func TestFieldTrie_TransferTrie(t *testing.T) {
 	require.DeepEqual(t, oldRoot, newRoot)
 }
 
+func TestFieldTrie_Proof(t *testing.T) {
+	newState, _ := util.DeterministicGenesisState(t, 40)
+	validators := newState.Validators()
+	trie, err := NewFieldTrie(types.Validators, types.CompositeArray, validators, params.BeaconConfig().ValidatorRegistryLimit)
+	require.NoError(t, err)
+	root, err := trie.TrieRoot()
+	require.NoError(t, err)
+	valRoot, err := validators[7].HashTreeRoot()
+	require.NoError(t, err)
+
+	leaf, branch, err := trie.Proof(7)
+	require.NoError(t, err)
+	assert.Equal(t, valRoot, leaf)
+	require.Equal(t, 41, len(branch))
+	index := uint64(7)
+	for _, sibling := range branch {
+		if index%2 == 0 {
+			leaf = hash.Hash(append(leaf[:], sibling[:]...))
+		} else {
+			leaf = hash.Hash(append(sibling[:], leaf[:]...))
+		}
+		index /= 2
+	}
+	assert.Equal(t, root, leaf)
+
+	_, _, err = trie.Proof(1 << 40)
+	require.ErrorContains(t, "out of range", err)
+	trie.TransferTrie()
+	_, _, err = trie.Proof(7)
+	require.ErrorIs(t, err, ErrEmptyFieldTrie)
+}
+
 func FuzzFieldTrie(f *testing.F) {
 	newState, _ := util.DeterministicGenesisState(f, 40)
 	var data []byte
@@ -265,3 +298,32 @@ type mockIdentifier struct{}
 func (_ mockIdentifier) Id() mvslice.Id {
 	return 0
 }
+
+func TestFieldTrie_ChangedChunks(t *testing.T) {
+	newState, _ := util.DeterministicGenesisState(t, 40)
+	validators := newState.Validators()
+	limit := params.BeaconConfig().ValidatorRegistryLimit
+	trie, err := NewFieldTrie(types.Validators, types.CompositeArray, validators, limit)
+	require.NoError(t, err)
+
+	changed, err := trie.ChangedChunks(trie.CopyTrie())
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(changed))
+
+	validators[5].ExitEpoch = 10
+	validators[33].Slashed = true
+	validators = append(validators, ethpb.CopyValidator(validators[0]))
+	other, err := NewFieldTrie(types.Validators, types.CompositeArray, validators, limit)
+	require.NoError(t, err)
+	changed, err = trie.ChangedChunks(other)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []uint64{5, 33, 40}, changed)
+
+	balances, err := NewFieldTrie(types.Balances, types.CompressedArray, newState.Balances(), stateutil.ValidatorLimitForBalancesChunks())
+	require.NoError(t, err)
+	_, err = trie.ChangedChunks(balances)
+	require.ErrorContains(t, "cannot compare", err)
+	other.TransferTrie()
+	_, err = trie.ChangedChunks(other)
+	require.ErrorIs(t, err, ErrEmptyFieldTrie)
+}
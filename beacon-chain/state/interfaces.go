@@ -41,6 +41,8 @@ type Prover interface {
 	FinalizedRootProof(ctx context.Context) ([][]byte, error)
 	CurrentSyncCommitteeProof(ctx context.Context) ([][]byte, error)
 	NextSyncCommitteeProof(ctx context.Context) ([][]byte, error)
+	FieldProof(ctx context.Context, position uint64) ([32]byte, [][32]byte, error)
+	FieldChunkProof(ctx context.Context, position, chunk uint64) ([32]byte, [][32]byte, bool, error)
 }
 
 // ReadOnlyBeaconState defines a struct which only has read access to beacon state methods.
@@ -0,0 +1,122 @@
+package state_native
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/fieldtrie"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native/types"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// ChangedFields returns the fields whose hash tree root differs between the two states, followed by the fields
+// only present in the from state. Field roots are read from the Merkle layers of the states, which are only
+// rehashed for the fields modified since the states were last hashed, so the content of unchanged fields is
+// never traversed.
+func ChangedFields(ctx context.Context, from, to *BeaconState) ([]types.FieldIndex, error) {
+	if _, err := from.HashTreeRoot(ctx); err != nil {
+		return nil, errors.Wrap(err, "could not hash from state")
+	}
+	if _, err := to.HashTreeRoot(ctx); err != nil {
+		return nil, errors.Wrap(err, "could not hash to state")
+	}
+	fromRoots := from.fieldRoots()
+	toRoots := to.fieldRoots()
+
+	var changed []types.FieldIndex
+	for _, f := range versionFields(to.version) {
+		// Fields of different versions may share a position, such as the execution payload header.
+		if root, ok := fromRoots[f]; !ok || !bytes.Equal(root, toRoots[f]) {
+			changed = append(changed, f)
+		}
+	}
+	for _, f := range versionFields(from.version) {
+		if _, ok := toRoots[f]; !ok {
+			changed = append(changed, f)
+		}
+	}
+	return changed, nil
+}
+
+// fieldRoots returns the roots of the fields of the state from its Merkle layers, which must be initialized.
+func (b *BeaconState) fieldRoots() map[types.FieldIndex][]byte {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	fields := versionFields(b.version)
+	roots := make(map[types.FieldIndex][]byte, len(fields))
+	for _, f := range fields {
+		roots[f] = bytes.Clone(b.merkleLayers[0][f.RealPosition()])
+	}
+	return roots
+}
+
+// ChangedChunks returns the indices of the chunks of the field which differ between the field tries of the two
+// states, such as the indices of the changed validators or of the groups of four changed balances. The field tries
+// are built first if the states do not hold them yet. It returns false when a field trie was modified concurrently,
+// in which case the caller has to compare the content of the field.
+func ChangedChunks(ctx context.Context, from, to *BeaconState, field types.FieldIndex) ([]uint64, bool, error) {
+	if from == to {
+		return nil, true, nil
+	}
+	if err := from.buildFieldTrie(ctx, field); err != nil {
+		return nil, false, errors.Wrap(err, "could not build field trie of from state")
+	}
+	if err := to.buildFieldTrie(ctx, field); err != nil {
+		return nil, false, errors.Wrap(err, "could not build field trie of to state")
+	}
+
+	from.lock.RLock()
+	defer from.lock.RUnlock()
+	to.lock.RLock()
+	defer to.lock.RUnlock()
+	if from.staleFieldTrie(field) || to.staleFieldTrie(field) {
+		return nil, false, nil
+	}
+	changed, err := from.stateFieldLeaves[field].ChangedChunks(to.stateFieldLeaves[field])
+	if errors.Is(err, fieldtrie.ErrEmptyFieldTrie) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return changed, true, nil
+}
+
+// buildFieldTrie brings the field trie of the field up to date. Field tries are otherwise only built when a field
+// is modified after the state was first hashed.
+func (b *BeaconState) buildFieldTrie(ctx context.Context, field types.FieldIndex) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if !b.staleFieldTrie(field) {
+		return nil
+	}
+	_, err := b.rootSelector(ctx, field)
+	return err
+}
+
+// staleFieldTrie returns true when the state has no field trie for the field or when the field was modified since
+// its trie was last recomputed.
+//
+// WARNING: Caller must acquire the mutex before using.
+func (b *BeaconState) staleFieldTrie(field types.FieldIndex) bool {
+	return b.stateFieldLeaves[field] == nil || b.rebuildTrie[field] || len(b.dirtyIndices[field]) > 0
+}
+
+func versionFields(v int) []types.FieldIndex {
+	switch v {
+	case version.Phase0:
+		return phase0Fields
+	case version.Altair:
+		return altairFields
+	case version.Bellatrix:
+		return bellatrixFields
+	case version.Capella:
+		return capellaFields
+	case version.Deneb:
+		return denebFields
+	default:
+		return electraFields
+	}
+}
@@ -0,0 +1,69 @@
+package state_native_test
+
+import (
+	"context"
+	"testing"
+
+	statenative "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native/types"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestChangedFields(t *testing.T) {
+	ctx := context.Background()
+	from, _ := util.DeterministicGenesisStateElectra(t, 64)
+	to := from.Copy()
+
+	changed, err := statenative.ChangedFields(ctx, from.(*statenative.BeaconState), to.(*statenative.BeaconState))
+	require.NoError(t, err)
+	require.Equal(t, 0, len(changed))
+
+	require.NoError(t, to.SetSlot(5))
+	require.NoError(t, to.UpdateBalancesAtIndex(3, 1))
+	changed, err = statenative.ChangedFields(ctx, from.(*statenative.BeaconState), to.(*statenative.BeaconState))
+	require.NoError(t, err)
+	require.DeepEqual(t, []types.FieldIndex{types.Slot, types.Balances}, changed)
+}
+
+func TestChangedFields_DifferentVersions(t *testing.T) {
+	ctx := context.Background()
+	from, _ := util.DeterministicGenesisStateDeneb(t, 64)
+	to, _ := util.DeterministicGenesisStateElectra(t, 64)
+
+	changed, err := statenative.ChangedFields(ctx, from.(*statenative.BeaconState), to.(*statenative.BeaconState))
+	require.NoError(t, err)
+	fields := make(map[types.FieldIndex]bool)
+	for _, f := range changed {
+		fields[f] = true
+	}
+	require.Equal(t, true, fields[types.PendingDeposits])
+	require.Equal(t, false, fields[types.Slot])
+}
+
+func TestChangedChunks(t *testing.T) {
+	ctx := context.Background()
+	from, _ := util.DeterministicGenesisStateElectra(t, 64)
+	to := from.Copy()
+	require.NoError(t, to.UpdateBalancesAtIndex(9, 1))
+
+	chunks, ok, err := statenative.ChangedChunks(ctx, from.(*statenative.BeaconState), to.(*statenative.BeaconState), types.Balances)
+	require.NoError(t, err)
+	require.Equal(t, true, ok)
+	require.DeepEqual(t, []uint64{2}, chunks)
+	chunks, ok, err = statenative.ChangedChunks(ctx, from.(*statenative.BeaconState), to.(*statenative.BeaconState), types.Validators)
+	require.NoError(t, err)
+	require.Equal(t, true, ok)
+	require.Equal(t, 0, len(chunks))
+
+	// The field tries built for the comparison are kept up to date by the later changes of the state.
+	require.NoError(t, to.UpdateBalancesAtIndex(10, 1))
+	root, err := to.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	fresh, err := statenative.InitializeFromProtoElectra(to.ToProto().(*ethpb.BeaconStateElectra))
+	require.NoError(t, err)
+	expected, err := fresh.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+}
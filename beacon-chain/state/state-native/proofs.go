@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/binary"
 
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/fieldtrie"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native/types"
 	"github.com/prysmaticlabs/prysm/v5/container/trie"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
@@ -106,3 +108,72 @@ func (b *BeaconState) FinalizedRootProof(ctx context.Context) ([][]byte, error)
 	proof = append(proof, branch...)
 	return proof, nil
 }
+
+// FieldProof returns the root of the field at the position in the SSZ container of
+// the state along with the branch linking it to the state root.
+func (b *BeaconState) FieldProof(ctx context.Context, position uint64) ([32]byte, [][32]byte, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if err := b.initializeMerkleLayers(ctx); err != nil {
+		return [32]byte{}, nil, err
+	}
+	if err := b.recomputeDirtyFields(ctx); err != nil {
+		return [32]byte{}, nil, err
+	}
+	if position >= uint64(len(b.merkleLayers[0])) {
+		return [32]byte{}, nil, errors.Errorf("field position %d out of range", position)
+	}
+	branch := trie.ProofFromMerkleLayers(b.merkleLayers, int(position))
+	proof := make([][32]byte, len(branch))
+	for i, node := range branch {
+		proof[i] = bytesutil.ToBytes32(node)
+	}
+	return bytesutil.ToBytes32(b.merkleLayers[0][position]), proof, nil
+}
+
+// FieldChunkProof returns the chunk at the index of the tree of the field at the
+// position in the SSZ container of the state, taken from the trie of the field,
+// along with the branch linking it to the root of the field. It returns false
+// when the state keeps no trie for the field.
+func (b *BeaconState) FieldChunkProof(ctx context.Context, position, chunk uint64) ([32]byte, [][32]byte, bool, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	field, ok := b.trieField(position)
+	if !ok {
+		return [32]byte{}, nil, false, nil
+	}
+	if err := b.initializeMerkleLayers(ctx); err != nil {
+		return [32]byte{}, nil, false, err
+	}
+	if err := b.recomputeDirtyFields(ctx); err != nil {
+		return [32]byte{}, nil, false, err
+	}
+	leaf, branch, err := b.stateFieldLeaves[field].Proof(chunk)
+	if errors.Is(err, fieldtrie.ErrEmptyFieldTrie) {
+		// The trie of the field is only built once one of its elements
+		// changes, or it may have been transferred to another state.
+		if _, err := b.rootSelector(ctx, field); err != nil {
+			return [32]byte{}, nil, false, err
+		}
+		leaf, branch, err = b.stateFieldLeaves[field].Proof(chunk)
+	}
+	if err != nil {
+		return [32]byte{}, nil, false, err
+	}
+	return leaf, branch, true, nil
+}
+
+// trieField returns the index of the field at the position in the SSZ container
+// of the state when it is one of the fields with a trie.
+//
+// WARNING: Caller must acquire the mutex before using.
+func (b *BeaconState) trieField(position uint64) (types.FieldIndex, bool) {
+	for field := range fieldMap {
+		if _, ok := b.stateFieldLeaves[field]; ok && uint64(field.RealPosition()) == position {
+			return field, true
+		}
+	}
+	return 0, false
+}
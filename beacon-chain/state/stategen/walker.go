@@ -0,0 +1,93 @@
+package stategen
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+)
+
+// BlocksFetcher retrieves the blocks matching a filter, along with their roots.
+type BlocksFetcher interface {
+	Blocks(ctx context.Context, f *filters.QueryFilter) ([]interfaces.ReadOnlySignedBeaconBlock, [][32]byte, error)
+}
+
+// Walker advances a state along the canonical chain, so that the states at successive slots are regenerated by
+// replaying the blocks between them once, rather than from the closest saved state each.
+type Walker struct {
+	blocks  BlocksFetcher
+	cc      CanonicalChecker
+	st      state.BeaconState
+	applied []interfaces.ReadOnlySignedBeaconBlock
+}
+
+// NewWalker returns a Walker starting from the given state, which it advances in place.
+func NewWalker(blocks BlocksFetcher, cc CanonicalChecker, st state.BeaconState) *Walker {
+	return &Walker{blocks: blocks, cc: cc, st: st}
+}
+
+// State returns the current state of the walker.
+func (w *Walker) State() state.BeaconState {
+	return w.st
+}
+
+// Applied returns the canonical blocks applied by the last call to AdvanceTo, in slot order.
+func (w *Walker) Applied() []interfaces.ReadOnlySignedBeaconBlock {
+	return w.applied
+}
+
+// AdvanceTo applies the canonical blocks after the slot of the current state and up to and including the target
+// slot, then processes the remaining slots up to the target slot.
+func (w *Walker) AdvanceTo(ctx context.Context, target primitives.Slot) (state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "stategen.Walker.AdvanceTo")
+	defer span.End()
+
+	if target < w.st.Slot() {
+		return nil, errors.Wrapf(ErrReplayTargetSlotExceeded, "slot desired=%d, state.slot=%d", target, w.st.Slot())
+	}
+	if target == w.st.Slot() {
+		w.applied = nil
+		return w.st, nil
+	}
+	f := filters.NewFilter().SetStartSlot(w.st.Slot() + 1).SetEndSlot(target)
+	blks, roots, err := w.blocks.Blocks(ctx, f)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get blocks")
+	}
+	if len(blks) != len(roots) {
+		return nil, errors.New("length of blocks and roots don't match")
+	}
+	canonical := make([]interfaces.ReadOnlySignedBeaconBlock, 0, len(blks))
+	for i, b := range blks {
+		ok, err := w.cc.IsCanonical(ctx, roots[i])
+		if err != nil {
+			return nil, errors.Wrap(err, "could not check if block is canonical")
+		}
+		if ok {
+			canonical = append(canonical, b)
+		}
+	}
+	sort.Slice(canonical, func(i, j int) bool {
+		return canonical[i].Block().Slot() < canonical[j].Block().Slot()
+	})
+
+	st := w.st
+	for _, b := range canonical {
+		st, err = executeStateTransitionStateGen(ctx, st, b)
+		if err != nil {
+			return nil, err
+		}
+	}
+	st, err = ReplayProcessSlots(ctx, st, target)
+	if err != nil {
+		return nil, err
+	}
+	w.st = st
+	w.applied = canonical
+	return st, nil
+}
@@ -0,0 +1,77 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestWalker_AdvanceTo(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	beaconState, pks := util.DeterministicGenesisState(t, 32)
+	genesisStateRoot, err := beaconState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, blocks.NewGenesisBlock(genesisStateRoot[:]))
+	start := beaconState.Copy()
+
+	// Blocks 1 and 4 are canonical, block 3 is on a fork.
+	expected := beaconState
+	canonical := make(map[[32]byte]bool)
+	var forkRoot [32]byte
+	for _, slot := range []primitives.Slot{1, 3, 4} {
+		gen := expected
+		if slot == 3 {
+			gen = expected.Copy()
+		}
+		b, err := util.GenerateFullBlock(gen, pks, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		wb, err := consensusblocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, beaconDB, b)
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		if slot == 3 {
+			forkRoot = root
+			continue
+		}
+		expected, err = executeStateTransitionStateGen(ctx, expected, wb)
+		require.NoError(t, err)
+		canonical[root] = true
+	}
+	expected, err = ReplayProcessSlots(ctx, expected, 6)
+	require.NoError(t, err)
+
+	cc := &mockCanonicalChecker{isCanon: func(root [32]byte) (bool, error) {
+		return canonical[root], nil
+	}}
+	w := NewWalker(beaconDB, cc, start)
+
+	st, err := w.AdvanceTo(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(2), st.Slot())
+	require.Equal(t, primitives.Slot(1), st.LatestBlockHeader().Slot)
+
+	st, err = w.AdvanceTo(ctx, 6)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(6), st.Slot())
+	require.Equal(t, primitives.Slot(4), st.LatestBlockHeader().Slot)
+	require.Equal(t, 1, len(w.Applied()))
+	require.Equal(t, primitives.Slot(4), w.Applied()[0].Block().Slot())
+	require.NotEqual(t, forkRoot, [32]byte(st.LatestBlockHeader().ParentRoot))
+	expectedRoot, err := expected.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	actualRoot, err := w.State().HashTreeRoot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, actualRoot)
+
+	_, err = w.AdvanceTo(ctx, 5)
+	require.Equal(t, true, errors.Is(err, ErrReplayTargetSlotExceeded))
+}
@@ -0,0 +1,161 @@
+package backfill
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+var (
+	errArchiveRequiresArchiveStorage = errors.New("blob archive backfill requires blob storage in archive mode")
+	errArchiveRequiresSource         = errors.New("blob archive backfill requires a BlobSidecarSource for blobs peers are not required to serve")
+)
+
+// BlobSidecarSource is used by backfill in blob archive mode to download the BlobSidecars for a given block root,
+// for blocks older than the window where peers are required to serve blobs.
+type BlobSidecarSource interface {
+	BlobSidecars(ctx context.Context, root [32]byte) ([]blocks.ROBlob, error)
+}
+
+type apiBlobSidecarSource struct {
+	c *beacon.Client
+}
+
+var _ BlobSidecarSource = &apiBlobSidecarSource{}
+
+// NewAPIBlobSidecarSource creates a BlobSidecarSource that downloads BlobSidecars from the beacon API
+// of the beacon node at the given host.
+func NewAPIBlobSidecarSource(host string) (BlobSidecarSource, error) {
+	c, err := beacon.NewClient(host)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to create beacon API client for blob archive source %s", host)
+	}
+	return &apiBlobSidecarSource{c: c}, nil
+}
+
+// BlobSidecars implements BlobSidecarSource.
+func (s *apiBlobSidecarSource) BlobSidecars(ctx context.Context, root [32]byte) ([]blocks.ROBlob, error) {
+	scs, err := s.c.GetBlobSidecars(ctx, beacon.IdFromRoot(root))
+	if err != nil {
+		return nil, err
+	}
+	robs := make([]blocks.ROBlob, len(scs))
+	for i := range scs {
+		robs[i], err = blocks.NewROBlob(scs[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid BlobSidecar at index %d for block root %#x", i, root)
+		}
+	}
+	return robs, nil
+}
+
+// blobArchive holds the configuration of the blob archive mode, where backfill downloads the blobs
+// for the full available history, not just the ones within the data availability window.
+type blobArchive struct {
+	src BlobSidecarSource
+}
+
+// useSource determines if the blobs for the given batch should be downloaded from the BlobSidecarSource,
+// rather than from peers, which are not required to serve blobs older than the minimum blob request slot.
+func (a *blobArchive) useSource(b batch, peerMinimum primitives.Slot) bool {
+	return a != nil && b.begin < peerMinimum
+}
+
+// archiveAvailabilityStore wraps the LazilyPersistentStore used by backfill, and additionally holds onto
+// BlobSidecars outside the data availability window, which the LazilyPersistentStore would ignore. These
+// BlobSidecars are verified against the block and saved when the batch is imported, like the ones within the window.
+type archiveAvailabilityStore struct {
+	das.AvailabilityStore
+	store    *filesystem.BlobStorage
+	verifier das.BlobBatchVerifier
+	sidecars map[[32]byte][]blocks.ROBlob
+}
+
+var _ das.AvailabilityStore = &archiveAvailabilityStore{}
+
+func newArchiveAvailabilityStore(store *filesystem.BlobStorage, verifier das.BlobBatchVerifier) *archiveAvailabilityStore {
+	return &archiveAvailabilityStore{
+		AvailabilityStore: das.NewLazilyPersistentStore(store, verifier),
+		store:             store,
+		verifier:          verifier,
+		sidecars:          make(map[[32]byte][]blocks.ROBlob),
+	}
+}
+
+// Persist implements das.AvailabilityStore.
+func (s *archiveAvailabilityStore) Persist(current primitives.Slot, sc ...blocks.ROBlob) error {
+	// A batch can straddle the edge of the DA window, so each sidecar is classified on its own slot.
+	within := make([]blocks.ROBlob, 0, len(sc))
+	for i := range sc {
+		if params.WithinDAPeriod(slots.ToEpoch(sc[i].Slot()), slots.ToEpoch(current)) {
+			within = append(within, sc[i])
+			continue
+		}
+		root := sc[i].BlockRoot()
+		s.sidecars[root] = append(s.sidecars[root], sc[i])
+	}
+	if len(within) == 0 {
+		return nil
+	}
+	return s.AvailabilityStore.Persist(current, within...)
+}
+
+// IsDataAvailable implements das.AvailabilityStore.
+func (s *archiveAvailabilityStore) IsDataAvailable(ctx context.Context, current primitives.Slot, b blocks.ROBlock) error {
+	if b.Version() < version.Deneb || params.WithinDAPeriod(slots.ToEpoch(b.Block().Slot()), slots.ToEpoch(current)) {
+		return s.AvailabilityStore.IsDataAvailable(ctx, current, b)
+	}
+	c, err := b.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return errors.Wrapf(err, "could not read commitments from block %#x", b.Root())
+	}
+	if len(c) == 0 {
+		return nil
+	}
+	root := b.Root()
+	defer delete(s.sidecars, root)
+	vscs, err := s.verifier.VerifiedROBlobs(ctx, b, s.sidecars[root])
+	if err != nil {
+		return errors.Wrapf(err, "invalid archived BlobSidecars for block %#x", root)
+	}
+	for i := range vscs {
+		if err := s.store.Save(vscs[i]); err != nil {
+			return errors.Wrapf(err, "failed to save archived BlobSidecar index %d for block %#x", vscs[i].Index, root)
+		}
+	}
+	return nil
+}
+
+// blobRoots returns the distinct block roots of the expected blobs, in the order they are expected.
+func (bs *blobSync) blobRoots() [][32]byte {
+	roots := make([][32]byte, 0)
+	for i := bs.next; i < len(bs.expected); i++ {
+		r := bs.expected[i].blockRoot
+		if len(roots) == 0 || roots[len(roots)-1] != r {
+			roots = append(roots, r)
+		}
+	}
+	return roots
+}
+
+func (s *Service) checkBlobArchive() error {
+	if s.blobArchive == nil {
+		return nil
+	}
+	if !s.blobStore.ArchiveMode() {
+		return errArchiveRequiresArchiveStorage
+	}
+	if s.blobArchive.src == nil {
+		return errArchiveRequiresSource
+	}
+	log.Info("Backfill will download blobs beyond the retention period")
+	return nil
+}
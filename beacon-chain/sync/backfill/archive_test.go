@@ -0,0 +1,160 @@
+package backfill
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+type mockBlobSidecarSource struct {
+	blobs map[[32]byte][]blocks.ROBlob
+	err   error
+}
+
+func (m *mockBlobSidecarSource) BlobSidecars(_ context.Context, root [32]byte) ([]blocks.ROBlob, error) {
+	return m.blobs[root], m.err
+}
+
+func verifiedBlobVerifier() verification.NewBlobVerifier {
+	return func(b blocks.ROBlob, _ []verification.Requirement) verification.BlobVerifier {
+		return &verification.MockBlobVerifier{CbVerifiedROBlob: func() (blocks.VerifiedROBlob, error) {
+			return blocks.NewVerifiedROBlob(b), nil
+		}}
+	}
+}
+
+// archiveTestCurrentSlot returns a slot for which blocks generated at low slots are outside the DA window.
+func archiveTestCurrentSlot() primitives.Slot {
+	return primitives.Slot(params.BeaconConfig().MinEpochsForBlobsSidecarsRequest+10) * params.BeaconConfig().SlotsPerEpoch
+}
+
+func TestArchiveAvailabilityStore(t *testing.T) {
+	ctx := context.Background()
+	current := archiveTestCurrentSlot()
+	blks, blobs := testBlobGen(t, 63, 3)
+
+	t.Run("archive", func(t *testing.T) {
+		store := filesystem.NewEphemeralBlobStorage(t)
+		bsync, err := newBlobSync(current, blks, &blobSyncConfig{nbv: verifiedBlobVerifier(), store: store, archive: true})
+		require.NoError(t, err)
+		for i := range blobs {
+			for j := range blobs[i] {
+				require.NoError(t, bsync.validateNext(blobs[i][j]))
+			}
+		}
+		for i := range blks {
+			require.NoError(t, bsync.store.IsDataAvailable(ctx, current, blks[i]))
+			idx, err := store.Indices(blks[i].Root(), blks[i].Block().Slot())
+			require.NoError(t, err)
+			for j := range blobs[i] {
+				require.Equal(t, true, idx[j])
+			}
+		}
+	})
+	t.Run("missing blobs", func(t *testing.T) {
+		store := filesystem.NewEphemeralBlobStorage(t)
+		bsync, err := newBlobSync(current, blks, &blobSyncConfig{nbv: verifiedBlobVerifier(), store: store, archive: true})
+		require.NoError(t, err)
+		require.NoError(t, bsync.validateNext(blobs[0][0]))
+		require.ErrorIs(t, bsync.store.IsDataAvailable(ctx, current, blks[0]), errBatchVerifierMismatch)
+	})
+	t.Run("not archive", func(t *testing.T) {
+		store := filesystem.NewEphemeralBlobStorage(t)
+		bsync, err := newBlobSync(current, blks, &blobSyncConfig{nbv: testNewBlobVerifier(), store: store})
+		require.NoError(t, err)
+		for i := range blobs[0] {
+			require.NoError(t, bsync.validateNext(blobs[0][i]))
+		}
+		// Blobs outside the DA window are ignored by the LazilyPersistentStore.
+		require.NoError(t, bsync.store.IsDataAvailable(ctx, current, blks[0]))
+		idx, err := store.Indices(blks[0].Root(), blks[0].Block().Slot())
+		require.NoError(t, err)
+		require.Equal(t, false, idx[0])
+	})
+}
+
+func TestBlobSync_blobRoots(t *testing.T) {
+	blks, blobs := testBlobGen(t, 63, 3)
+	bsync, err := newBlobSync(128, blks, &blobSyncConfig{nbv: testNewBlobVerifier(), store: filesystem.NewEphemeralBlobStorage(t)})
+	require.NoError(t, err)
+	require.DeepEqual(t, [][32]byte{blks[0].Root(), blks[1].Root(), blks[2].Root()}, bsync.blobRoots())
+	for i := range blobs[0] {
+		require.NoError(t, bsync.validateNext(blobs[0][i]))
+	}
+	require.DeepEqual(t, [][32]byte{blks[1].Root(), blks[2].Root()}, bsync.blobRoots())
+}
+
+func TestHandleArchiveBlobs(t *testing.T) {
+	ctx := context.Background()
+	current := archiveTestCurrentSlot()
+	blks, blobs := testBlobGen(t, 63, 2)
+	src := &mockBlobSidecarSource{blobs: make(map[[32]byte][]blocks.ROBlob)}
+	for i := range blks {
+		src.blobs[blks[i].Root()] = blobs[i]
+	}
+	genesis := time.Now().Add(-time.Duration(uint64(current)*params.BeaconConfig().SecondsPerSlot) * time.Second)
+	w := &p2pWorker{
+		c:       startup.NewClock(genesis, [32]byte{}),
+		archive: &blobArchive{src: src},
+	}
+	newBatch := func() batch {
+		bsync, err := newBlobSync(current, blks, &blobSyncConfig{nbv: verifiedBlobVerifier(), store: filesystem.NewEphemeralBlobStorage(t), archive: true})
+		require.NoError(t, err)
+		return batch{begin: 63, end: 65, state: batchBlobSync, bs: bsync}
+	}
+
+	b := w.handleBlobs(ctx, newBatch())
+	require.Equal(t, batchImportable, b.state)
+	require.Equal(t, 0, b.blobsNeeded())
+
+	src.err = errors.New("unavailable")
+	b = w.handleBlobs(ctx, newBatch())
+	require.Equal(t, batchErrRetryable, b.state)
+
+	// Batches within the window where peers serve blobs are not downloaded from the archive source.
+	require.Equal(t, false, w.archive.useSource(newBatch(), 63))
+}
+
+func TestArchiveAvailabilityStore_PersistStraddlesWindow(t *testing.T) {
+	// Slot 63 is the last slot of epoch 1, which is just outside the DA window, while slot 64 is just inside it.
+	current := primitives.Slot(params.BeaconConfig().MinEpochsForBlobsSidecarsRequest+2) * params.BeaconConfig().SlotsPerEpoch
+	blks, blobs := testBlobGen(t, 63, 2)
+	var persisted []blocks.ROBlob
+	as := &archiveAvailabilityStore{
+		AvailabilityStore: &das.MockAvailabilityStore{PersistBlobsCallback: func(_ primitives.Slot, sc ...blocks.ROBlob) error {
+			persisted = append(persisted, sc...)
+			return nil
+		}},
+		sidecars: make(map[[32]byte][]blocks.ROBlob),
+	}
+	sc := append(append([]blocks.ROBlob{}, blobs[0]...), blobs[1]...)
+	require.NoError(t, as.Persist(current, sc...))
+	require.Equal(t, len(blobs[0]), len(as.sidecars[blks[0].Root()]))
+	_, ok := as.sidecars[blks[1].Root()]
+	require.Equal(t, false, ok)
+	require.Equal(t, len(blobs[1]), len(persisted))
+	for i := range persisted {
+		require.Equal(t, blks[1].Root(), persisted[i].BlockRoot())
+	}
+}
+
+func TestCheckBlobArchive(t *testing.T) {
+	archive, err := filesystem.NewBlobStorage(filesystem.WithBasePath(t.TempDir()), filesystem.WithArchiveMode(true))
+	require.NoError(t, err)
+	s := &Service{blobStore: filesystem.NewEphemeralBlobStorage(t), blobArchive: &blobArchive{src: &mockBlobSidecarSource{}}}
+	require.ErrorIs(t, s.checkBlobArchive(), errArchiveRequiresArchiveStorage)
+	s = &Service{blobStore: archive, blobArchive: &blobArchive{}}
+	require.ErrorIs(t, s.checkBlobArchive(), errArchiveRequiresSource)
+	s = &Service{blobStore: archive, blobArchive: &blobArchive{src: &mockBlobSidecarSource{}}}
+	require.NoError(t, s.checkBlobArchive())
+}
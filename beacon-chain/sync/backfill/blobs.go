@@ -33,6 +33,7 @@ type blobSyncConfig struct {
 	retentionStart primitives.Slot
 	nbv            verification.NewBlobVerifier
 	store          *filesystem.BlobStorage
+	archive        bool
 }
 
 func newBlobSync(current primitives.Slot, vbs verifiedROBlocks, cfg *blobSyncConfig) (*blobSync, error) {
@@ -41,7 +42,10 @@ func newBlobSync(current primitives.Slot, vbs verifiedROBlocks, cfg *blobSyncCon
 		return nil, err
 	}
 	bbv := newBlobBatchVerifier(cfg.nbv)
-	as := das.NewLazilyPersistentStore(cfg.store, bbv)
+	var as das.AvailabilityStore = das.NewLazilyPersistentStore(cfg.store, bbv)
+	if cfg.archive {
+		as = newArchiveAvailabilityStore(cfg.store, bbv)
+	}
 	return &blobSync{current: current, expected: expected, bbv: bbv, store: as}, nil
 }
 
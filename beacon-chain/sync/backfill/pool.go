@@ -28,9 +28,11 @@ type worker interface {
 
 type newWorker func(id workerId, in, out chan batch, c *startup.Clock, v *verifier, cm sync.ContextByteVersions, nbv verification.NewBlobVerifier, bfs *filesystem.BlobStorage) worker
 
-func defaultNewWorker(p p2p.P2P) newWorker {
+func defaultNewWorker(p p2p.P2P, archive *blobArchive) newWorker {
 	return func(id workerId, in, out chan batch, c *startup.Clock, v *verifier, cm sync.ContextByteVersions, nbv verification.NewBlobVerifier, bfs *filesystem.BlobStorage) worker {
-		return newP2pWorker(id, p, in, out, c, v, cm, nbv, bfs)
+		w := newP2pWorker(id, p, in, out, c, v, cm, nbv, bfs)
+		w.archive = archive
+		return w
 	}
 }
 
@@ -49,8 +51,8 @@ type p2pBatchWorkerPool struct {
 
 var _ batchWorkerPool = &p2pBatchWorkerPool{}
 
-func newP2PBatchWorkerPool(p p2p.P2P, maxBatches int) *p2pBatchWorkerPool {
-	nw := defaultNewWorker(p)
+func newP2PBatchWorkerPool(p p2p.P2P, maxBatches int, archive *blobArchive) *p2pBatchWorkerPool {
+	nw := defaultNewWorker(p, archive)
 	return &p2pBatchWorkerPool{
 		newWorker:   nw,
 		toRouter:    make(chan batch, maxBatches),
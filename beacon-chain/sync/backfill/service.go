@@ -38,6 +38,7 @@ type Service struct {
 	pa              PeerAssigner
 	batchImporter   batchImporter
 	blobStore       *filesystem.BlobStorage
+	blobArchive     *blobArchive
 	initSyncWaiter  func() error
 }
 
@@ -136,6 +137,18 @@ func WithMinimumSlot(s primitives.Slot) ServiceOption {
 	}
 }
 
+// WithBlobArchive enables the blob archive mode, where backfill downloads blobs for all backfilled blocks since the
+// deneb fork, rather than only within the data availability window, so that archive nodes can serve the full history.
+// Blob storage must be in archive mode, otherwise the blobs outside of the retention period would be pruned.
+// Peers are not required to serve blobs older than the retention period, so the given BlobSidecarSource is used
+// to download blobs for batches that are older than what peers are required to serve, and must not be nil.
+func WithBlobArchive(src BlobSidecarSource) ServiceOption {
+	return func(s *Service) error {
+		s.blobArchive = &blobArchive{src: src}
+		return nil
+	}
+}
+
 // NewService initializes the backfill Service. Like all implementations of the Service interface,
 // the service won't begin its runloop until Start() is called.
 func NewService(ctx context.Context, su *Store, bStore *filesystem.BlobStorage, cw startup.ClockWaiter, p p2p.P2P, pa PeerAssigner, opts ...ServiceOption) (*Service, error) {
@@ -154,7 +167,10 @@ func NewService(ctx context.Context, su *Store, bStore *filesystem.BlobStorage,
 			return nil, err
 		}
 	}
-	s.pool = newP2PBatchWorkerPool(p, s.nWorkers)
+	if err := s.checkBlobArchive(); err != nil {
+		return nil, err
+	}
+	s.pool = newP2PBatchWorkerPool(p, s.nWorkers, s.blobArchive)
 
 	return s, nil
 }
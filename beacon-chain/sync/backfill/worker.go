@@ -24,6 +24,8 @@ type p2pWorker struct {
 	cm   sync.ContextByteVersions
 	nbv  verification.NewBlobVerifier
 	bfs  *filesystem.BlobStorage
+
+	archive *blobArchive
 }
 
 func (w *p2pWorker) run(ctx context.Context) {
@@ -49,6 +51,10 @@ func (w *p2pWorker) handleBlocks(ctx context.Context, b batch) batch {
 	if err != nil {
 		return b.withRetryableError(errors.Wrap(err, "configuration issue, could not compute minimum blob retention slot"))
 	}
+	if w.archive != nil {
+		// In archive mode, blobs are downloaded for every block since the deneb fork.
+		blobRetentionStart = 0
+	}
 	b.blockPid = b.busy
 	start := time.Now()
 	results, err := sync.SendBeaconBlocksByRangeRequest(ctx, w.c, w.p2p, b.blockPid, b.blockRequest(), blockValidationMetrics)
@@ -73,7 +79,7 @@ func (w *p2pWorker) handleBlocks(ctx context.Context, b batch) batch {
 	}
 	backfillBlocksApproximateBytes.Add(float64(bdl))
 	log.WithFields(b.logFields()).WithField("dlbytes", bdl).Debug("Backfill batch block bytes downloaded")
-	bs, err := newBlobSync(cs, vb, &blobSyncConfig{retentionStart: blobRetentionStart, nbv: w.nbv, store: w.bfs})
+	bs, err := newBlobSync(cs, vb, &blobSyncConfig{retentionStart: blobRetentionStart, nbv: w.nbv, store: w.bfs, archive: w.archive != nil})
 	if err != nil {
 		return b.withRetryableError(err)
 	}
@@ -81,6 +87,13 @@ func (w *p2pWorker) handleBlocks(ctx context.Context, b batch) batch {
 }
 
 func (w *p2pWorker) handleBlobs(ctx context.Context, b batch) batch {
+	peerMinimum, err := sync.BlobRPCMinValidSlot(w.c.CurrentSlot())
+	if err != nil {
+		return b.withRetryableError(errors.Wrap(err, "configuration issue, could not compute minimum blob retention slot"))
+	}
+	if w.archive.useSource(b, peerMinimum) {
+		return w.handleArchiveBlobs(ctx, b)
+	}
 	b.blobPid = b.busy
 	start := time.Now()
 	// we don't need to use the response for anything other than metrics, because blobResponseValidation
@@ -101,6 +114,33 @@ func (w *p2pWorker) handleBlobs(ctx context.Context, b batch) batch {
 	return b.postBlobSync()
 }
 
+// handleArchiveBlobs downloads the blobs for a batch from the blob archive source, rather than from a peer.
+// The blobs undergo the same validation as blobs received from peers.
+func (w *p2pWorker) handleArchiveBlobs(ctx context.Context, b batch) batch {
+	start := time.Now()
+	sz := 0
+	for _, root := range b.bs.blobRoots() {
+		blobs, err := w.archive.src.BlobSidecars(ctx, root)
+		if err != nil {
+			log.WithError(err).WithFields(b.logFields()).Debug("Batch requesting blobs from archive source failed")
+			b.bs = nil
+			return b.withRetryableError(err)
+		}
+		for i := range blobs {
+			if err := b.bs.validateNext(blobs[i]); err != nil {
+				log.WithError(err).WithFields(b.logFields()).Debug("Archive source blob validation failed")
+				b.bs = nil
+				return b.withRetryableError(err)
+			}
+			sz += blobs[i].SizeSSZ()
+		}
+	}
+	backfillBatchTimeDownloadingBlobs.Observe(float64(time.Since(start).Milliseconds()))
+	backfillBlobsApproximateBytes.Add(float64(sz))
+	log.WithFields(b.logFields()).WithField("dlbytes", sz).Debug("Backfill batch blob bytes downloaded from archive source")
+	return b.postBlobSync()
+}
+
 func newP2pWorker(id workerId, p p2p.P2P, todo, done chan batch, c *startup.Clock, v *verifier, cm sync.ContextByteVersions, nbv verification.NewBlobVerifier, bfs *filesystem.BlobStorage) *p2pWorker {
 	return &p2pWorker{
 		id:   id,
@@ -0,0 +1,105 @@
+// Package capture records raw gossip messages along with the outcome of their validation, so that they can be
+// replayed through the gossip validators later on, to reproduce validation issues seen on a live network.
+package capture
+
+import (
+	"strings"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+)
+
+var errUnknownResult = errors.New("unknown validation result")
+
+const (
+	resultAccept = "accept"
+	resultReject = "reject"
+	resultIgnore = "ignore"
+)
+
+// Record is a single captured gossip message. Data holds the message exactly as received on the wire,
+// i.e. snappy compressed SSZ.
+type Record struct {
+	Topic     string    `json:"topic"`
+	Peer      string    `json:"peer"`
+	Timestamp time.Time `json:"timestamp"`
+	Data      []byte    `json:"data"`
+	Result    string    `json:"result"`
+}
+
+// ValidationResult returns the captured validation outcome as a pubsub.ValidationResult.
+func (r *Record) ValidationResult() (pubsub.ValidationResult, error) {
+	return ParseResult(r.Result)
+}
+
+// ResultString returns the name used in capture files for a validation result.
+func ResultString(r pubsub.ValidationResult) string {
+	switch r {
+	case pubsub.ValidationAccept:
+		return resultAccept
+	case pubsub.ValidationReject:
+		return resultReject
+	default:
+		return resultIgnore
+	}
+}
+
+// ParseResult is the inverse of ResultString.
+func ParseResult(s string) (pubsub.ValidationResult, error) {
+	switch s {
+	case resultAccept:
+		return pubsub.ValidationAccept, nil
+	case resultReject:
+		return pubsub.ValidationReject, nil
+	case resultIgnore:
+		return pubsub.ValidationIgnore, nil
+	default:
+		return pubsub.ValidationIgnore, errors.Wrapf(errUnknownResult, "result=%s", s)
+	}
+}
+
+// TopicName returns the name of a gossip topic, without the fork digest, encoding suffix or subnet index.
+// For example, /eth2/6a95a1a9/beacon_attestation_3/ssz_snappy is named beacon_attestation.
+func TopicName(topic string) string {
+	parts := strings.Split(strings.TrimPrefix(topic, "/"), "/")
+	if len(parts) < 3 {
+		return topic
+	}
+	name := parts[2]
+	if i := strings.LastIndex(name, "_"); i > 0 && isDigits(name[i+1:]) {
+		name = name[:i]
+	}
+	return name
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// TopicFilter selects gossip topics by name. An empty filter selects every topic.
+type TopicFilter map[string]bool
+
+// NewTopicFilter creates a TopicFilter selecting the given topic names.
+func NewTopicFilter(names []string) TopicFilter {
+	f := make(TopicFilter, len(names))
+	for _, n := range names {
+		if n = strings.TrimSpace(n); n != "" {
+			f[n] = true
+		}
+	}
+	return f
+}
+
+// Selects determines if messages on the given topic are selected by the filter.
+func (f TopicFilter) Selects(topic string) bool {
+	return len(f) == 0 || f[TopicName(topic)]
+}
@@ -0,0 +1,112 @@
+package capture
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestTopicName(t *testing.T) {
+	cases := map[string]string{
+		"/eth2/6a95a1a9/beacon_block/ssz_snappy":                          "beacon_block",
+		"/eth2/6a95a1a9/beacon_attestation_3/ssz_snappy":                  "beacon_attestation",
+		"/eth2/6a95a1a9/sync_committee_0/ssz_snappy":                      "sync_committee",
+		"/eth2/6a95a1a9/sync_committee_contribution_and_proof/ssz_snappy": "sync_committee_contribution_and_proof",
+		"/eth2/6a95a1a9/blob_sidecar_5/ssz_snappy":                        "blob_sidecar",
+		"invalid": "invalid",
+	}
+	for topic, name := range cases {
+		require.Equal(t, name, TopicName(topic))
+	}
+}
+
+func TestTopicFilter(t *testing.T) {
+	require.Equal(t, true, NewTopicFilter(nil).Selects("/eth2/6a95a1a9/beacon_block/ssz_snappy"))
+	f := NewTopicFilter([]string{"beacon_block", " blob_sidecar", ""})
+	require.Equal(t, true, f.Selects("/eth2/6a95a1a9/beacon_block/ssz_snappy"))
+	require.Equal(t, true, f.Selects("/eth2/6a95a1a9/blob_sidecar_1/ssz_snappy"))
+	require.Equal(t, false, f.Selects("/eth2/6a95a1a9/beacon_attestation_1/ssz_snappy"))
+}
+
+func TestResult(t *testing.T) {
+	for _, r := range []pubsub.ValidationResult{pubsub.ValidationAccept, pubsub.ValidationReject, pubsub.ValidationIgnore} {
+		parsed, err := ParseResult(ResultString(r))
+		require.NoError(t, err)
+		require.Equal(t, r, parsed)
+	}
+	_, err := ParseResult("maybe")
+	require.ErrorIs(t, err, errUnknownResult)
+}
+
+func TestWriter(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWriter(dir, WithTopics([]string{"beacon_block"}), WithMaxFileSize(1), WithMaxFiles(2))
+	require.NoError(t, err)
+	block := "/eth2/6a95a1a9/beacon_block/ssz_snappy"
+	w.Capture("/eth2/6a95a1a9/voluntary_exit/ssz_snappy", "peer", []byte{1}, pubsub.ValidationAccept)
+	for i := byte(0); i < 3; i++ {
+		w.Capture(block, "peer", []byte{i}, pubsub.ValidationReject)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, w.Close())
+
+	// Each record exceeds the maximum file size, so each is written to its own file, and only the last two are kept.
+	files, err := Files(dir)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(files))
+	recs, err := Load(dir)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(recs))
+	for i, rec := range recs {
+		require.Equal(t, block, rec.Topic)
+		require.Equal(t, "peer", rec.Peer)
+		require.DeepEqual(t, []byte{byte(i + 1)}, rec.Data)
+		res, err := rec.ValidationResult()
+		require.NoError(t, err)
+		require.Equal(t, pubsub.ValidationReject, res)
+	}
+
+	recs, err = Load(files[1])
+	require.NoError(t, err)
+	require.Equal(t, 1, len(recs))
+}
+
+func TestWriter_CaptureDuringClose(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWriter(dir)
+	require.NoError(t, err)
+	block := "/eth2/6a95a1a9/beacon_block/ssz_snappy"
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				w.Capture(block, "peer", []byte{1}, pubsub.ValidationAccept)
+			}
+		}()
+	}
+	require.NoError(t, w.Close())
+	wg.Wait()
+	// Messages captured after Close are dropped.
+	w.Capture(block, "peer", []byte{1}, pubsub.ValidationAccept)
+	_, err = Load(dir)
+	require.NoError(t, err)
+}
+
+func TestLoad_Truncated(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "gossip-1.jsonl")
+	require.NoError(t, os.WriteFile(p, []byte(`{"topic":"a","result":"accept"}`+"\n"+`{"topic":"b","res`), 0600))
+	recs, err := Load(p)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(recs))
+	require.Equal(t, "a", recs[0].Topic)
+
+	require.NoError(t, os.WriteFile(p, []byte(`{"topic":}`), 0600))
+	_, err = Load(p)
+	require.NotNil(t, err)
+}
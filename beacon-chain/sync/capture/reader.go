@@ -0,0 +1,63 @@
+package capture
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// Load reads the captured gossip messages from a capture file, or from every capture file in a directory,
+// in the order in which they were captured.
+func Load(path string) ([]*Record, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{path}
+	if fi.IsDir() {
+		paths, err = Files(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	records := make([]*Record, 0)
+	for _, p := range paths {
+		recs, err := loadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, recs...)
+	}
+	return records, nil
+}
+
+func loadFile(path string) ([]*Record, error) {
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close gossip capture file")
+		}
+	}()
+	records := make([]*Record, 0)
+	dec := json.NewDecoder(f)
+	for {
+		rec := &Record{}
+		if err := dec.Decode(rec); err != nil {
+			if errors.Is(err, io.EOF) {
+				return records, nil
+			}
+			// The last record may be incomplete if the node stopped while writing it.
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				log.WithField("path", path).Warn("Ignoring truncated record at the end of gossip capture file")
+				return records, nil
+			}
+			return nil, errors.Wrapf(err, "could not decode record %d of gossip capture file %s", len(records), path)
+		}
+		records = append(records, rec)
+	}
+}
@@ -0,0 +1,255 @@
+package capture
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "gossip-capture")
+
+var (
+	capturedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gossip_capture_messages_total",
+		Help: "Number of gossip messages written to the capture file, by topic name.",
+	}, []string{"topic"})
+	droppedMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "gossip_capture_dropped_total",
+		Help: "Number of gossip messages which were not captured because the capture writer could not keep up.",
+	})
+)
+
+const (
+	filePrefix = "gossip-"
+	fileSuffix = ".jsonl"
+	// DefaultMaxFileSize is the size in bytes after which the capture file is rotated.
+	DefaultMaxFileSize = 256 << 20
+	// DefaultMaxFiles is the number of capture files kept, including the one being written.
+	DefaultMaxFiles = 8
+	// bufferSize is the number of captured messages that can be waiting to be written.
+	bufferSize = 4096
+)
+
+// WriterOption is a functional option for configuring a Writer.
+type WriterOption func(*Writer)
+
+// WithTopics restricts the capture to the gossip topics with the given names, e.g. beacon_block.
+func WithTopics(names []string) WriterOption {
+	return func(w *Writer) {
+		w.filter = NewTopicFilter(names)
+	}
+}
+
+// WithMaxFileSize sets the size in bytes after which the capture file is rotated.
+func WithMaxFileSize(size int64) WriterOption {
+	return func(w *Writer) {
+		w.maxSize = size
+	}
+}
+
+// WithMaxFiles sets the number of capture files to keep. The oldest files are deleted on rotation.
+func WithMaxFiles(n int) WriterOption {
+	return func(w *Writer) {
+		w.maxFiles = n
+	}
+}
+
+// Writer captures gossip messages to a rotating set of files, in the background.
+type Writer struct {
+	dir      string
+	filter   TopicFilter
+	maxSize  int64
+	maxFiles int
+	records  chan *Record
+	quit     chan struct{}
+	done     chan struct{}
+	once     sync.Once
+	f        *os.File
+	bw       *bufio.Writer
+	size     int64
+
+	// lock guards closing, so that no message is queued once the write loop is told to quit.
+	lock    sync.RWMutex
+	closing bool
+}
+
+// NewWriter creates a Writer capturing to files in the given directory, and starts its write loop.
+func NewWriter(dir string, opts ...WriterOption) (*Writer, error) {
+	w := &Writer{
+		dir:      dir,
+		maxSize:  DefaultMaxFileSize,
+		maxFiles: DefaultMaxFiles,
+		records:  make(chan *Record, bufferSize),
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	for _, o := range opts {
+		o(w)
+	}
+	if w.maxFiles < 1 {
+		return nil, errors.Errorf("at least one capture file must be kept, got %d", w.maxFiles)
+	}
+	if err := file.MkdirAll(dir); err != nil {
+		return nil, errors.Wrapf(err, "could not create gossip capture directory %s", dir)
+	}
+	if err := w.rotate(); err != nil {
+		return nil, err
+	}
+	go w.run()
+	return w, nil
+}
+
+// Selects determines if messages on the given topic are captured.
+func (w *Writer) Selects(topic string) bool {
+	return w != nil && w.filter.Selects(topic)
+}
+
+// Capture queues a gossip message to be written. Messages are dropped rather than slowing down gossip validation
+// when the writer can't keep up.
+func (w *Writer) Capture(topic, pid string, data []byte, res pubsub.ValidationResult) {
+	if !w.Selects(topic) {
+		return
+	}
+	rec := &Record{Topic: topic, Peer: pid, Timestamp: time.Now(), Data: data, Result: ResultString(res)}
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	if w.closing {
+		return
+	}
+	select {
+	case w.records <- rec:
+	default:
+		droppedMessages.Inc()
+	}
+}
+
+// Close stops accepting messages, writes the queued ones and closes the capture file. Messages captured
+// concurrently with or after Close are dropped.
+func (w *Writer) Close() error {
+	var err error
+	w.once.Do(func() {
+		w.lock.Lock()
+		w.closing = true
+		w.lock.Unlock()
+		close(w.quit)
+		<-w.done
+		err = w.closeFile()
+	})
+	return err
+}
+
+func (w *Writer) run() {
+	defer close(w.done)
+	for {
+		select {
+		case rec := <-w.records:
+			w.writeOrLog(rec)
+		case <-w.quit:
+			// No message is queued once closing is set, so the queue can be drained without blocking.
+			for {
+				select {
+				case rec := <-w.records:
+					w.writeOrLog(rec)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (w *Writer) writeOrLog(rec *Record) {
+	if err := w.write(rec); err != nil {
+		log.WithError(err).Error("Could not write captured gossip message")
+	}
+}
+
+func (w *Writer) write(rec *Record) error {
+	enc, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	enc = append(enc, '\n')
+	if w.size > 0 && w.size+int64(len(enc)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := w.bw.Write(enc)
+	w.size += int64(n)
+	if err != nil {
+		return err
+	}
+	capturedMessages.WithLabelValues(TopicName(rec.Topic)).Inc()
+	// Flush once the queue is drained, so that a capture file is usable while the node is running.
+	if len(w.records) == 0 {
+		return w.bw.Flush()
+	}
+	return nil
+}
+
+// rotate closes the current capture file, opens a new one, and removes the oldest files above the limit.
+func (w *Writer) rotate() error {
+	if err := w.closeFile(); err != nil {
+		return err
+	}
+	p := filepath.Join(w.dir, fmt.Sprintf("%s%020d%s", filePrefix, time.Now().UnixNano(), fileSuffix))
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600) // #nosec G304
+	if err != nil {
+		return errors.Wrapf(err, "could not create gossip capture file %s", p)
+	}
+	w.f, w.bw, w.size = f, bufio.NewWriter(f), 0
+	files, err := Files(w.dir)
+	if err != nil {
+		return err
+	}
+	for len(files) > w.maxFiles {
+		if err := os.Remove(files[0]); err != nil {
+			return errors.Wrapf(err, "could not remove old gossip capture file %s", files[0])
+		}
+		files = files[1:]
+	}
+	return nil
+}
+
+func (w *Writer) closeFile() error {
+	if w.f == nil {
+		return nil
+	}
+	if err := w.bw.Flush(); err != nil {
+		return err
+	}
+	err := w.f.Close()
+	w.f, w.bw = nil, nil
+	return err
+}
+
+// Files returns the paths of the capture files in the given directory, from oldest to newest.
+func Files(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not list gossip capture directory %s", dir)
+	}
+	paths := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), filePrefix) || !strings.HasSuffix(e.Name(), fileSuffix) {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, e.Name()))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
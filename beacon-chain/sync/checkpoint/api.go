@@ -11,19 +11,37 @@ import (
 )
 
 // APIInitializer manages initializing the beacon node using checkpoint sync, retrieving the checkpoint state and root
-// from the remote beacon node api.
+// from one or more remote beacon node apis.
 type APIInitializer struct {
-	c *beacon.Client
+	clients []*beacon.Client
+	quorum  int
 }
 
 // NewAPIInitializer creates an APIInitializer, handling the set up of a beacon node api client
 // using the provided host string.
 func NewAPIInitializer(beaconNodeHost string) (*APIInitializer, error) {
-	c, err := beacon.NewClient(beaconNodeHost, client.WithMaxBodySize(client.MaxBodySizeState))
-	if err != nil {
-		return nil, errors.Wrapf(err, "unable to parse beacon node url or hostname - %s", beaconNodeHost)
+	return NewQuorumAPIInitializer([]string{beaconNodeHost}, 1)
+}
+
+// NewQuorumAPIInitializer creates an APIInitializer that requires at least quorum of the given beacon node hosts to
+// agree on the finalized block root and state root, before downloading the checkpoint state and block from one of them.
+// A quorum value of 0 requires all hosts to agree.
+func NewQuorumAPIInitializer(beaconNodeHosts []string, quorum int) (*APIInitializer, error) {
+	if len(beaconNodeHosts) == 0 {
+		return nil, errors.New("at least one beacon node url is required for checkpoint sync")
+	}
+	if quorum < 0 || quorum > len(beaconNodeHosts) {
+		return nil, errors.Errorf("checkpoint sync quorum=%d must be between 1 and the number of beacon node urls (%d)", quorum, len(beaconNodeHosts))
 	}
-	return &APIInitializer{c: c}, nil
+	clients := make([]*beacon.Client, len(beaconNodeHosts))
+	for i, h := range beaconNodeHosts {
+		c, err := beacon.NewClient(h, client.WithMaxBodySize(client.MaxBodySizeState))
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse beacon node url or hostname - %s", h)
+		}
+		clients[i] = c
+	}
+	return &APIInitializer{clients: clients, quorum: quorum}, nil
 }
 
 // Initialize downloads origin state and block for checkpoint sync and initializes database records to
@@ -37,9 +55,18 @@ func (dl *APIInitializer) Initialize(ctx context.Context, d db.Database) error {
 	if err != nil && !errors.Is(err, db.ErrNotFound) {
 		return errors.Wrap(err, "error while checking database for origin root")
 	}
-	od, err := beacon.DownloadFinalizedData(ctx, dl.c)
+	od, err := dl.download(ctx)
 	if err != nil {
 		return errors.Wrap(err, "Error retrieving checkpoint origin state and block")
 	}
 	return d.SaveOrigin(ctx, od.StateBytes(), od.BlockBytes())
 }
+
+func (dl *APIInitializer) download(ctx context.Context) (*beacon.OriginData, error) {
+	if len(dl.clients) == 1 {
+		return beacon.DownloadFinalizedData(ctx, dl.clients[0])
+	}
+	// the quorum report is embedded in the error, so the operator sees every provider's response on failure
+	od, _, err := beacon.DownloadFinalizedDataWithQuorum(ctx, dl.clients, dl.quorum)
+	return od, err
+}
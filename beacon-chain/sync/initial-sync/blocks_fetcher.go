@@ -78,11 +78,12 @@ type blocksFetcherConfig struct {
 	peerFilterCapacityWeight float64
 	mode                     syncMode
 	bs                       filesystem.BlobStorageSummarizer
+	stats                    *peerStatsTracker
 }
 
 // blocksFetcher is a service to fetch chain data from peers.
-// On an incoming requests, requested block range is evenly divided
-// among available peers (for fair network load distribution).
+// On an incoming requests, requested block range is divided among available peers,
+// proportionally to their observed throughput (see peerStatsTracker).
 type blocksFetcher struct {
 	sync.Mutex
 	ctx             context.Context
@@ -99,9 +100,10 @@ type blocksFetcher struct {
 	peerLocks       map[peer.ID]*peerLock
 	fetchRequests   chan *fetchRequestParams
 	fetchResponses  chan *fetchRequestResponse
-	capacityWeight  float64       // how remaining capacity affects peer selection
-	mode            syncMode      // allows to use fetcher in different sync scenarios
-	quit            chan struct{} // termination notifier
+	capacityWeight  float64           // how remaining capacity affects peer selection
+	mode            syncMode          // allows to use fetcher in different sync scenarios
+	stats           *peerStatsTracker // per-peer throughput and latency, used for adaptive batch sizing
+	quit            chan struct{}     // termination notifier
 }
 
 // peerLock restricts fetcher actions on per peer basis. Currently, used for rate limiting.
@@ -125,6 +127,8 @@ type fetchRequestResponse struct {
 	count uint64
 	bwb   []blocks2.BlockWithROBlobs
 	err   error
+	// sources holds the peer that served each part of the range, when it was split between several peers.
+	sources []rangeChunk
 }
 
 // newBlocksFetcher creates ready to use fetcher.
@@ -142,6 +146,11 @@ func newBlocksFetcher(ctx context.Context, cfg *blocksFetcherConfig) *blocksFetc
 		capacityWeight = peerFilterCapacityWeight
 	}
 
+	stats := cfg.stats
+	if stats == nil {
+		stats = newPeerStatsTracker(uint64(blockBatchLimit))
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	return &blocksFetcher{
 		ctx:             ctx,
@@ -160,6 +169,7 @@ func newBlocksFetcher(ctx context.Context, cfg *blocksFetcherConfig) *blocksFetc
 		fetchResponses:  make(chan *fetchRequestResponse, maxPendingRequests),
 		capacityWeight:  capacityWeight,
 		mode:            cfg.mode,
+		stats:           stats,
 		quit:            make(chan struct{}),
 	}
 }
@@ -314,6 +324,13 @@ func (f *blocksFetcher) handleRequest(ctx context.Context, start primitives.Slot
 		}
 	}
 
+	// Finalized blocks are the same on all peers, so the range can be safely split between several of them.
+	// Non-finalized ranges are fetched from a single peer, to avoid mixing blocks from competing forks.
+	if f.mode == modeStopOnFinalizedEpoch {
+		response.bwb, response.sources, response.err = f.fetchBlocksAdaptive(ctx, start, count, peers)
+		return response
+	}
+
 	response.bwb, response.pid, response.err = f.fetchBlocksFromPeer(ctx, start, count, peers)
 	if response.err == nil {
 		bwb, err := f.fetchBlobsFromPeer(ctx, response.bwb, response.pid, peers)
@@ -598,7 +615,17 @@ func (f *blocksFetcher) requestBlocks(
 	}
 	f.rateLimiter.Add(pid.String(), int64(req.Count))
 	l.Unlock()
-	return prysmsync.SendBeaconBlocksByRangeRequest(ctx, f.chain, f.p2p, pid, req, nil)
+	requestStart := time.Now()
+	blks, err := prysmsync.SendBeaconBlocksByRangeRequest(ctx, f.chain, f.p2p, pid, req, nil)
+	if err != nil {
+		// Requests cancelled by us (e.g. when work is stolen by a faster peer) do not count against the peer.
+		if ctx.Err() == nil {
+			f.stats.recordFailure(pid)
+		}
+		return nil, err
+	}
+	f.stats.recordSuccess(pid, req.Count, len(blks), time.Since(requestStart))
+	return blks, nil
 }
 
 func (f *blocksFetcher) requestBlobs(ctx context.Context, req *p2ppb.BlobSidecarsByRangeRequest, pid peer.ID) ([]blocks.ROBlob, error) {
@@ -0,0 +1,213 @@
+package initialsync
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	beaconsync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	p2ppb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+// rangeChunk is a contiguous part of a fetch request, assigned to a single peer.
+type rangeChunk struct {
+	start primitives.Slot
+	count uint64
+	pid   peer.ID
+}
+
+// chunkResult holds the outcome of fetching a single chunk.
+type chunkResult struct {
+	pid peer.ID
+	bwb []blocks.BlockWithROBlobs
+	err error
+}
+
+// planChunks splits the [start, start+count) range into chunks, sized according to each peer's observed
+// throughput and capped by peer's remaining rate limiter capacity. Peers are assigned chunks in the order they
+// are provided, cycling through the list if the range is larger than what all peers can serve in a single round.
+// Peers without enough capacity for a minimal chunk are skipped, unless no peer has any capacity left.
+func (f *blocksFetcher) planChunks(start primitives.Slot, count uint64, peers []peer.ID) []rangeChunk {
+	if len(peers) == 0 || count == 0 {
+		return nil
+	}
+	capacity := make(map[peer.ID]uint64, len(peers))
+	for _, pid := range peers {
+		if remaining := f.rateLimiter.Remaining(pid.String()); remaining > 0 {
+			capacity[pid] = uint64(remaining)
+		}
+	}
+	chunks := make([]rangeChunk, 0, len(peers))
+	remaining := count
+	for remaining > 0 {
+		assigned := false
+		for _, pid := range peers {
+			if remaining == 0 {
+				break
+			}
+			size := min(f.stats.batchSize(pid), capacity[pid], remaining)
+			if size < min(minAdaptiveBatchSize, remaining) {
+				continue
+			}
+			capacity[pid] -= size
+			chunks = append(chunks, rangeChunk{start: start, count: size, pid: pid})
+			start = start.Add(size)
+			remaining -= size
+			assigned = true
+		}
+		if !assigned {
+			// All peers are throttled, the rest of the range is assigned to the fastest peer,
+			// which is going to wait for its capacity to be restored.
+			pid, _ := f.stats.fastest(peers, nil)
+			chunks = append(chunks, rangeChunk{start: start, count: remaining, pid: pid})
+			break
+		}
+	}
+	return chunks
+}
+
+// fetchBlocksAdaptive fetches blocks (and blobs) for a given range, splitting it between peers according to
+// their throughput. Chunks are requested concurrently, and if a peer takes too long to serve its chunk, the chunk
+// is re-requested from the fastest available peer (work stealing), with the first complete response winning.
+// The returned chunks cover the range, each with the peer that actually served it.
+func (f *blocksFetcher) fetchBlocksAdaptive(
+	ctx context.Context,
+	start primitives.Slot, count uint64,
+	peers []peer.ID,
+) ([]blocks.BlockWithROBlobs, []rangeChunk, error) {
+	ctx, span := trace.StartSpan(ctx, "initialsync.fetchBlocksAdaptive")
+	defer span.End()
+
+	peers = f.filterPeers(ctx, peers, peersPercentagePerRequest)
+	bestPeers := f.hasSufficientBandwidth(peers, minAdaptiveBatchSize)
+	peers = dedupPeers(append(bestPeers, peers...))
+	chunks := f.planChunks(start, count, peers)
+	if len(chunks) == 0 {
+		return nil, nil, errNoPeersAvailable
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make([]chan chunkResult, len(chunks))
+	for i := range chunks {
+		results[i] = make(chan chunkResult, 1)
+		go func(i int) {
+			bwb, pid, err := f.fetchChunk(ctx, chunks[i], peers)
+			results[i] <- chunkResult{pid: pid, bwb: bwb, err: err}
+		}(i)
+	}
+
+	bwb := make([]blocks.BlockWithROBlobs, 0, count)
+	for i := range results {
+		res := <-results[i]
+		if res.err != nil {
+			// Remaining chunks are cancelled by the deferred cancel, the whole range is going to be re-requested.
+			return nil, nil, res.err
+		}
+		chunks[i].pid = res.pid
+		bwb = append(bwb, res.bwb...)
+	}
+	sort.Sort(blocks.BlockWithROBlobsSlice(bwb))
+	log.WithFields(logrus.Fields{
+		"start":  start,
+		"count":  count,
+		"chunks": len(chunks),
+		"blocks": len(bwb),
+	}).Trace("Fetched range using adaptive batches")
+	return bwb, chunks, nil
+}
+
+// fetchChunk fetches a single chunk from its assigned peer, falling back to other peers on failure, and stealing
+// the chunk for a faster peer if the assigned peer is too slow.
+func (f *blocksFetcher) fetchChunk(ctx context.Context, c rangeChunk, peers []peer.ID) ([]blocks.BlockWithROBlobs, peer.ID, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan chunkResult, len(peers))
+	tried := make(map[peer.ID]bool)
+	inflight := 0
+	launch := func(pid peer.ID) {
+		tried[pid] = true
+		inflight++
+		go func() {
+			bwb, err := f.fetchRangeFromPeer(ctx, c.start, c.count, pid)
+			results <- chunkResult{pid: pid, bwb: bwb, err: err}
+		}()
+	}
+	launch(c.pid)
+
+	timer := time.NewTimer(f.stats.stealTimeout(c.pid, c.count))
+	defer timer.Stop()
+	stolen := false
+	var lastErr error = errNoPeersAvailable
+	for inflight > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, "", ctx.Err()
+		case res := <-results:
+			inflight--
+			if res.err == nil {
+				bwb, err := f.fetchBlobsFromPeer(ctx, res.bwb, res.pid, peers)
+				if err == nil {
+					return bwb, res.pid, nil
+				}
+				res.err = err
+			}
+			lastErr = res.err
+			if errors.Is(res.err, beaconsync.ErrInvalidFetchedData) {
+				// The chunk is retried from other peers, so the peer is penalized here rather than by the queue.
+				f.p2p.Peers().Scorers().BadResponsesScorer().Increment(res.pid)
+			}
+			log.WithError(res.err).WithField("peer", res.pid).Debug("Could not fetch chunk from peer")
+			if next, ok := f.nextPeer(peers, tried, c.count); ok {
+				launch(next)
+			}
+		case <-timer.C:
+			if stolen {
+				continue
+			}
+			stolen = true
+			if next, ok := f.nextPeer(peers, tried, c.count); ok {
+				f.stats.recordStolen(c.pid)
+				log.WithFields(logrus.Fields{
+					"start":    c.start,
+					"count":    c.count,
+					"slowPeer": c.pid,
+					"peer":     next,
+				}).Debug("Peer is too slow, stealing its work")
+				launch(next)
+			}
+		}
+	}
+	return nil, "", lastErr
+}
+
+// nextPeer picks the fastest peer that was not tried yet, preferring peers with enough rate limiter
+// capacity to serve count slots without waiting.
+func (f *blocksFetcher) nextPeer(peers []peer.ID, tried map[peer.ID]bool, count uint64) (peer.ID, bool) {
+	if pid, ok := f.stats.fastest(f.hasSufficientBandwidth(peers, count), tried); ok {
+		return pid, true
+	}
+	return f.stats.fastest(peers, tried)
+}
+
+// fetchRangeFromPeer requests blocks for a given range from a single peer.
+func (f *blocksFetcher) fetchRangeFromPeer(ctx context.Context, start primitives.Slot, count uint64, pid peer.ID) ([]blocks.BlockWithROBlobs, error) {
+	req := &p2ppb.BeaconBlocksByRangeRequest{
+		StartSlot: start,
+		Count:     count,
+		Step:      1,
+	}
+	blks, err := f.requestBlocks(ctx, req, pid)
+	if err != nil {
+		return nil, err
+	}
+	f.p2p.Peers().Scorers().BlockProviderScorer().Touch(pid)
+	return sortedBlockWithVerifiedBlobSlice(blks)
+}
@@ -0,0 +1,166 @@
+package initialsync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestBlocksFetcher_planChunks(t *testing.T) {
+	fetcher := newBlocksFetcher(context.Background(), &blocksFetcherConfig{})
+	fast, slow, unknown := peer.ID("fast"), peer.ID("slow"), peer.ID("unknown")
+	fetcher.stats.recordSuccess(fast, 64, 64, 100*time.Millisecond)
+	fetcher.stats.recordSuccess(slow, 64, 64, 16*time.Second)
+
+	t.Run("no peers", func(t *testing.T) {
+		assert.Equal(t, 0, len(fetcher.planChunks(1, 64, nil)))
+	})
+	t.Run("slow peer gets a smaller chunk", func(t *testing.T) {
+		chunks := fetcher.planChunks(1, 64, []peer.ID{slow, fast})
+		require.Equal(t, 2, len(chunks))
+		assert.DeepEqual(t, rangeChunk{start: 1, count: minAdaptiveBatchSize, pid: slow}, chunks[0])
+		assert.DeepEqual(t, rangeChunk{start: 1 + minAdaptiveBatchSize, count: 64 - minAdaptiveBatchSize, pid: fast}, chunks[1])
+	})
+	t.Run("fast peer covers the whole range", func(t *testing.T) {
+		chunks := fetcher.planChunks(1, 64, []peer.ID{fast, slow})
+		require.Equal(t, 1, len(chunks))
+		assert.DeepEqual(t, rangeChunk{start: 1, count: 64, pid: fast}, chunks[0])
+	})
+	t.Run("peers are cycled", func(t *testing.T) {
+		chunks := fetcher.planChunks(1, 20, []peer.ID{slow})
+		require.Equal(t, 3, len(chunks))
+		assert.Equal(t, uint64(8), chunks[0].count)
+		assert.Equal(t, uint64(8), chunks[1].count)
+		assert.Equal(t, primitives.Slot(17), chunks[2].start)
+		assert.Equal(t, uint64(4), chunks[2].count)
+	})
+	t.Run("unknown peers are probed with a full batch", func(t *testing.T) {
+		chunks := fetcher.planChunks(1, 64, []peer.ID{unknown, slow})
+		require.Equal(t, 1, len(chunks))
+		assert.Equal(t, unknown, chunks[0].pid)
+	})
+}
+
+func TestBlocksFetcher_fetchBlocksAdaptive(t *testing.T) {
+	blockBatchLimit := uint64(flags.Get().BlockBatchLimit)
+	chainSlots := makeSequence(1, primitives.Slot(4*blockBatchLimit))
+	mc, p2p, _ := initializeTestServices(t, chainSlots, []*peerData{})
+	fast := connectPeer(t, p2p, &peerData{blocks: chainSlots, finalizedEpoch: 8, headSlot: 320}, p2p.Peers())
+	slow := connectPeer(t, p2p, &peerData{blocks: chainSlots, finalizedEpoch: 8, headSlot: 320, perBlockDelay: 50 * time.Millisecond}, p2p.Peers())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fetcher := newBlocksFetcher(ctx, &blocksFetcherConfig{
+		chain: mc,
+		p2p:   p2p,
+		clock: startup.NewClock(mc.Genesis, mc.ValidatorsRoot),
+	})
+
+	t.Run("split between peers", func(t *testing.T) {
+		fetcher.stats.recordSuccess(fast, 64, 64, 100*time.Millisecond)
+		// Slow peer is expected to serve its minimal chunk in 1s.
+		fetcher.stats.recordSuccess(slow, minAdaptiveBatchSize, minAdaptiveBatchSize, time.Second)
+		// The range is larger than the batch of the fast peer, so that it is split between peers.
+		count := 2 * blockBatchLimit
+		bwb, sources, err := fetcher.fetchBlocksAdaptive(ctx, 1, count, []peer.ID{slow, fast})
+		require.NoError(t, err)
+		require.Equal(t, int(count), len(bwb))
+		for i, b := range bwb {
+			require.Equal(t, primitives.Slot(i+1), b.Block.Block().Slot())
+		}
+		// Each chunk of the range is attributed to the peer that served it.
+		require.Equal(t, true, len(sources) > 1)
+		next := primitives.Slot(1)
+		served := make(map[peer.ID]bool)
+		for _, src := range sources {
+			assert.Equal(t, next, src.start)
+			next = src.start.Add(src.count)
+			served[src.pid] = true
+		}
+		assert.Equal(t, primitives.Slot(1).Add(count), next)
+		assert.Equal(t, true, served[slow])
+		assert.Equal(t, true, served[fast])
+	})
+
+	t.Run("work is stolen from slow peer", func(t *testing.T) {
+		// Pretend the slow peer is fast, so that it is assigned the whole range, with a short steal timeout.
+		fetcher.stats = newPeerStatsTracker(blockBatchLimit)
+		fetcher.stats.recordSuccess(slow, blockBatchLimit, int(blockBatchLimit), 10*time.Millisecond)
+		fetcher.stats.recordSuccess(fast, blockBatchLimit, int(blockBatchLimit), 20*time.Millisecond)
+		chunks := fetcher.planChunks(1, blockBatchLimit, []peer.ID{slow})
+		require.Equal(t, 1, len(chunks))
+
+		start := time.Now()
+		bwb, pid, err := fetcher.fetchChunk(ctx, chunks[0], []peer.ID{slow, fast})
+		require.NoError(t, err)
+		assert.Equal(t, fast, pid)
+		assert.Equal(t, int(blockBatchLimit), len(bwb))
+		// Slow peer would need 64 * 50ms = 3.2s to serve the range.
+		assert.Equal(t, true, time.Since(start) < 3*time.Second)
+
+		var slowStats PeerSyncStats
+		for _, s := range fetcher.stats.snapshot() {
+			if s.Peer == slow {
+				slowStats = s
+			}
+		}
+		assert.Equal(t, uint64(1), slowStats.Stolen)
+	})
+}
+
+// BenchmarkBlocksFetcher_simulatedNetwork compares fetching a finalized range using a single peer per
+// request (the fixed batch, non-finalized code path) against adaptive batches split between peers, on a
+// simulated network where half of the peers are much slower than the others.
+func BenchmarkBlocksFetcher_simulatedNetwork(b *testing.B) {
+	blockBatchLimit := uint64(flags.Get().BlockBatchLimit)
+	const batches = 8
+	chainSlots := makeSequence(1, primitives.Slot(batches*blockBatchLimit))
+	mc, p2p, _ := initializeTestServices(b, chainSlots, []*peerData{})
+	headSlot := primitives.Slot(batches * blockBatchLimit)
+	for i := 0; i < 4; i++ {
+		delay := time.Duration(0)
+		if i%2 == 1 {
+			delay = 5 * time.Millisecond
+		}
+		connectPeer(b, p2p, &peerData{blocks: chainSlots, finalizedEpoch: 16, headSlot: headSlot, perBlockDelay: delay}, p2p.Peers())
+	}
+
+	run := func(b *testing.B, mode syncMode) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stats := newPeerStatsTracker(blockBatchLimit)
+		// Scale the target request duration down to the simulated network.
+		stats.targetDuration = 20 * time.Millisecond
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			// Peer stats are retained between iterations, but each iteration gets a fresh rate limiter, so
+			// that the benchmark measures the network rather than the local rate limits.
+			b.StopTimer()
+			fetcher := newBlocksFetcher(ctx, &blocksFetcherConfig{
+				chain: mc,
+				p2p:   p2p,
+				clock: startup.NewClock(mc.Genesis, mc.ValidatorsRoot),
+				mode:  mode,
+				stats: stats,
+			})
+			b.StartTimer()
+			for start := primitives.Slot(1); start < headSlot; start = start.Add(blockBatchLimit) {
+				resp := fetcher.handleRequest(ctx, start, blockBatchLimit)
+				require.NoError(b, resp.err)
+			}
+		}
+	}
+	b.Run("fixed", func(b *testing.B) {
+		run(b, modeNonConstrained)
+	})
+	b.Run("adaptive", func(b *testing.B) {
+		run(b, modeStopOnFinalizedEpoch)
+	})
+}
@@ -0,0 +1,208 @@
+package initialsync
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// statsSmoothingFactor is the weight given to the most recent sample when updating a peer's
+	// exponentially weighted moving averages (throughput and latency).
+	statsSmoothingFactor = 0.3
+	// adaptiveBatchTargetDuration is how long a single BeaconBlocksByRange request to a peer should
+	// take. Batch size for a peer is derived from its observed throughput, so that fast peers are asked
+	// for more slots and slow peers for fewer.
+	adaptiveBatchTargetDuration = 2 * time.Second
+	// minAdaptiveBatchSize is a lower bound on the number of slots requested from any single peer.
+	minAdaptiveBatchSize = 8
+	// stealTimeoutFactor defines how many times longer than expected a request may take before
+	// the same range is re-requested from another peer (work stealing).
+	stealTimeoutFactor = 3
+	// minStealTimeout is a lower bound on time given to a peer before its work can be stolen.
+	minStealTimeout = time.Second
+	// unknownPeerStealTimeout is the time given to peers for which there are no stats yet.
+	unknownPeerStealTimeout = 10 * time.Second
+	// peerStatsExpiry is how long stats of a peer which was not sent any request are retained.
+	peerStatsExpiry = 10 * time.Minute
+)
+
+// PeerSyncStats is a snapshot of the statistics initial-sync has gathered about a single peer.
+type PeerSyncStats struct {
+	Peer       peer.ID
+	Requests   uint64
+	Failures   uint64
+	Stolen     uint64
+	Blocks     uint64
+	Throughput float64       // slots served per second (exponentially weighted moving average)
+	Latency    time.Duration // duration of a single batch request (exponentially weighted moving average)
+	BatchSize  uint64        // number of slots the next request to this peer will cover
+	LastSeen   time.Time
+}
+
+// peerStatsTracker keeps track of per-peer request throughput and latency, and uses them to size
+// requests adaptively. It is safe for concurrent use, and is shared across block fetchers of the same service,
+// so that knowledge about peers is retained between sync phases.
+type peerStatsTracker struct {
+	sync.RWMutex
+	stats          map[peer.ID]*PeerSyncStats
+	maxBatch       uint64
+	targetDuration time.Duration
+}
+
+func newPeerStatsTracker(maxBatch uint64) *peerStatsTracker {
+	return &peerStatsTracker{
+		stats:          make(map[peer.ID]*PeerSyncStats),
+		maxBatch:       maxBatch,
+		targetDuration: adaptiveBatchTargetDuration,
+	}
+}
+
+func (t *peerStatsTracker) peerStats(pid peer.ID) *PeerSyncStats {
+	s, ok := t.stats[pid]
+	if !ok {
+		s = &PeerSyncStats{Peer: pid}
+		t.stats[pid] = s
+	}
+	return s
+}
+
+// recordSuccess updates peer's stats with a successfully served request for the given number of slots.
+func (t *peerStatsTracker) recordSuccess(pid peer.ID, slots uint64, blocks int, elapsed time.Duration) {
+	if elapsed <= 0 {
+		elapsed = time.Millisecond
+	}
+	t.Lock()
+	defer t.Unlock()
+	s := t.peerStats(pid)
+	throughput := float64(slots) / elapsed.Seconds()
+	if s.Requests == s.Failures {
+		// First successful sample, nothing to smooth against.
+		s.Throughput = throughput
+		s.Latency = elapsed
+	} else {
+		s.Throughput = statsSmoothingFactor*throughput + (1-statsSmoothingFactor)*s.Throughput
+		s.Latency = time.Duration(statsSmoothingFactor*float64(elapsed) + (1-statsSmoothingFactor)*float64(s.Latency))
+	}
+	s.Requests++
+	s.Blocks += uint64(blocks)
+	s.LastSeen = time.Now()
+	syncBatchRequests.Inc()
+	syncBatchBlocks.Add(float64(blocks))
+	syncBatchLatency.Observe(elapsed.Seconds())
+}
+
+// recordFailure marks a failed request, halving peer's estimated throughput.
+func (t *peerStatsTracker) recordFailure(pid peer.ID) {
+	t.Lock()
+	defer t.Unlock()
+	s := t.peerStats(pid)
+	s.Requests++
+	s.Failures++
+	s.Throughput /= 2
+	s.LastSeen = time.Now()
+	syncBatchRequests.Inc()
+	syncBatchFailures.Inc()
+}
+
+// recordStolen marks that a request to a peer took too long, and its work was handed to another peer.
+func (t *peerStatsTracker) recordStolen(pid peer.ID) {
+	t.Lock()
+	defer t.Unlock()
+	s := t.peerStats(pid)
+	s.Stolen++
+	s.Throughput /= 2
+	s.LastSeen = time.Now()
+	syncBatchStolen.Inc()
+}
+
+// batchSize returns the number of slots that should be requested from a given peer in a single request.
+// Peers with no stats yet are asked for the maximum batch, which allows their throughput to be measured.
+func (t *peerStatsTracker) batchSize(pid peer.ID) uint64 {
+	t.RLock()
+	defer t.RUnlock()
+	return t.batchSizeLocked(pid)
+}
+
+func (t *peerStatsTracker) batchSizeLocked(pid peer.ID) uint64 {
+	s, ok := t.stats[pid]
+	if !ok || s.Requests == 0 && s.Stolen == 0 {
+		return t.maxBatch
+	}
+	size := uint64(s.Throughput * t.targetDuration.Seconds())
+	if size < minAdaptiveBatchSize {
+		size = minAdaptiveBatchSize
+	}
+	if size > t.maxBatch {
+		size = t.maxBatch
+	}
+	return size
+}
+
+// stealTimeout returns how long a request of the given size may be outstanding with a peer, before
+// it is re-requested from another peer.
+func (t *peerStatsTracker) stealTimeout(pid peer.ID, slots uint64) time.Duration {
+	t.RLock()
+	defer t.RUnlock()
+	s, ok := t.stats[pid]
+	if !ok || s.Throughput <= 0 {
+		return unknownPeerStealTimeout
+	}
+	expected := time.Duration(float64(slots) / s.Throughput * float64(time.Second))
+	timeout := expected * stealTimeoutFactor
+	if timeout < minStealTimeout {
+		timeout = minStealTimeout
+	}
+	return timeout
+}
+
+// fastest returns the peer with the highest throughput among the candidates, skipping excluded peers.
+// Peers without stats are considered only if no measured peer is available.
+func (t *peerStatsTracker) fastest(candidates []peer.ID, excluded map[peer.ID]bool) (peer.ID, bool) {
+	t.RLock()
+	defer t.RUnlock()
+	var best peer.ID
+	bestThroughput := -1.0
+	for _, pid := range candidates {
+		if excluded[pid] {
+			continue
+		}
+		throughput := 0.0
+		if s, ok := t.stats[pid]; ok {
+			throughput = s.Throughput
+		}
+		if throughput > bestThroughput {
+			best, bestThroughput = pid, throughput
+		}
+	}
+	return best, bestThroughput >= 0
+}
+
+// snapshot returns a copy of stats for all tracked peers, sorted by descending throughput.
+func (t *peerStatsTracker) snapshot() []PeerSyncStats {
+	t.RLock()
+	defer t.RUnlock()
+	stats := make([]PeerSyncStats, 0, len(t.stats))
+	for pid, s := range t.stats {
+		cp := *s
+		cp.BatchSize = t.batchSizeLocked(pid)
+		stats = append(stats, cp)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Throughput > stats[j].Throughput
+	})
+	return stats
+}
+
+// prune drops the stats of peers which were not sent any request since the given time.
+func (t *peerStatsTracker) prune(before time.Time) {
+	t.Lock()
+	defer t.Unlock()
+	for pid, s := range t.stats {
+		if s.LastSeen.Before(before) {
+			delete(t.stats, pid)
+		}
+	}
+}
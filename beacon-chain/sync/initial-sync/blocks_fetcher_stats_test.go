@@ -0,0 +1,98 @@
+package initialsync
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestPeerStatsTracker_batchSize(t *testing.T) {
+	tracker := newPeerStatsTracker(64)
+	fast, slow, failing := peer.ID("fast"), peer.ID("slow"), peer.ID("failing")
+
+	// Unknown peers are asked for the full batch.
+	assert.Equal(t, uint64(64), tracker.batchSize(fast))
+
+	// 64 slots in 0.5s = 128 slots/sec, which over the target duration exceeds the max batch.
+	tracker.recordSuccess(fast, 64, 64, 500*time.Millisecond)
+	assert.Equal(t, uint64(64), tracker.batchSize(fast))
+
+	// 64 slots in 8s = 8 slots/sec, i.e. 16 slots over the target duration.
+	tracker.recordSuccess(slow, 64, 60, 8*time.Second)
+	assert.Equal(t, uint64(16), tracker.batchSize(slow))
+
+	tracker.recordFailure(failing)
+	assert.Equal(t, uint64(minAdaptiveBatchSize), tracker.batchSize(failing))
+
+	// Stealing work halves the throughput estimate.
+	tracker.recordStolen(slow)
+	assert.Equal(t, uint64(minAdaptiveBatchSize), tracker.batchSize(slow))
+}
+
+func TestPeerStatsTracker_recordSuccess(t *testing.T) {
+	tracker := newPeerStatsTracker(64)
+	pid := peer.ID("a")
+	tracker.recordSuccess(pid, 10, 10, time.Second)
+	tracker.recordSuccess(pid, 20, 18, time.Second)
+
+	stats := tracker.snapshot()
+	require.Equal(t, 1, len(stats))
+	assert.Equal(t, pid, stats[0].Peer)
+	assert.Equal(t, uint64(2), stats[0].Requests)
+	assert.Equal(t, uint64(28), stats[0].Blocks)
+	// Throughput is smoothed towards the latest sample: 0.3 * 20 + 0.7 * 10.
+	assert.Equal(t, true, math.Abs(stats[0].Throughput-13) < 1e-9)
+	assert.Equal(t, time.Second, stats[0].Latency)
+}
+
+func TestPeerStatsTracker_stealTimeout(t *testing.T) {
+	tracker := newPeerStatsTracker(64)
+	pid := peer.ID("a")
+	assert.Equal(t, unknownPeerStealTimeout, tracker.stealTimeout(pid, 64))
+
+	tracker.recordSuccess(pid, 32, 32, 2*time.Second)
+	assert.Equal(t, 12*time.Second, tracker.stealTimeout(pid, 64))
+	assert.Equal(t, minStealTimeout, tracker.stealTimeout(pid, 1))
+}
+
+func TestPeerStatsTracker_fastest(t *testing.T) {
+	tracker := newPeerStatsTracker(64)
+	a, b, c := peer.ID("a"), peer.ID("b"), peer.ID("c")
+	tracker.recordSuccess(a, 10, 10, time.Second)
+	tracker.recordSuccess(b, 50, 50, time.Second)
+
+	pid, ok := tracker.fastest([]peer.ID{a, b, c}, map[peer.ID]bool{})
+	require.Equal(t, true, ok)
+	assert.Equal(t, b, pid)
+
+	pid, ok = tracker.fastest([]peer.ID{a, b, c}, map[peer.ID]bool{b: true})
+	require.Equal(t, true, ok)
+	assert.Equal(t, a, pid)
+
+	pid, ok = tracker.fastest([]peer.ID{a, b, c}, map[peer.ID]bool{a: true, b: true})
+	require.Equal(t, true, ok)
+	assert.Equal(t, c, pid)
+
+	_, ok = tracker.fastest([]peer.ID{a, b, c}, map[peer.ID]bool{a: true, b: true, c: true})
+	assert.Equal(t, false, ok)
+}
+
+func TestPeerStatsTracker_prune(t *testing.T) {
+	tracker := newPeerStatsTracker(64)
+	active, inactive := peer.ID("active"), peer.ID("inactive")
+	requests := testutil.ToFloat64(syncBatchRequests)
+	tracker.recordSuccess(inactive, 64, 64, time.Second)
+	cutoff := time.Now()
+	tracker.recordSuccess(active, 64, 64, time.Second)
+	assert.Equal(t, requests+2, testutil.ToFloat64(syncBatchRequests))
+
+	tracker.prune(cutoff)
+	stats := tracker.snapshot()
+	require.Equal(t, 1, len(stats))
+	assert.Equal(t, active, stats[0].Peer)
+}
@@ -71,6 +71,7 @@ type blocksQueueConfig struct {
 	db                  db.ReadOnlyDatabase
 	mode                syncMode
 	bs                  filesystem.BlobStorageSummarizer
+	stats               *peerStatsTracker
 }
 
 // blocksQueue is a priority queue that serves as a intermediary between block fetchers (producers)
@@ -94,7 +95,9 @@ type blocksQueue struct {
 // blocksQueueFetchedData is a data container that is returned from a queue on each step.
 type blocksQueueFetchedData struct {
 	pid peer.ID
-	bwb []blocks.BlockWithROBlobs
+	// sources holds the peer that served each part of the blocks, when they were fetched from several peers.
+	sources []rangeChunk
+	bwb     []blocks.BlockWithROBlobs
 }
 
 // newBlocksQueue creates initialized priority queue.
@@ -113,6 +116,7 @@ func newBlocksQueue(ctx context.Context, cfg *blocksQueueConfig) *blocksQueue {
 			db:     cfg.db,
 			clock:  cfg.clock,
 			bs:     cfg.bs,
+			stats:  cfg.stats,
 		})
 	}
 	highestExpectedSlot := cfg.highestExpectedSlot
@@ -134,9 +138,11 @@ func newBlocksQueue(ctx context.Context, cfg *blocksQueueConfig) *blocksQueue {
 		blocksFetcher:       blocksFetcher,
 		chain:               cfg.chain,
 		mode:                cfg.mode,
-		fetchedData:         make(chan *blocksQueueFetchedData, 1),
 		quit:                make(chan struct{}),
 		staleEpochs:         make(map[primitives.Epoch]uint8),
+		// Buffer up to lookaheadSteps batches, so that the queue keeps downloading subsequent batches
+		// while the consumer is busy running the state transition over the current one (see extendWindow).
+		fetchedData: make(chan *blocksQueueFetchedData, lookaheadSteps),
 	}
 
 	// Configure state machines.
@@ -249,6 +255,8 @@ func (q *blocksQueue) loop() {
 					}
 				}
 			}
+			q.extendWindow(blocksPerRequest)
+			q.blocksFetcher.stats.prune(time.Now().Add(-peerStatsExpiry))
 		case response, ok := <-q.blocksFetcher.requestResponses():
 			if !ok {
 				log.Debug("Fetcher closed output channel")
@@ -274,6 +282,34 @@ func (q *blocksQueue) loop() {
 	}
 }
 
+// extendWindow adds state machines past the end of the window, so that lookaheadSteps batches are requested or
+// awaiting delivery while the consumer processes the batches already handed to it. This overlaps requests for the
+// next batches with the processing of the current one, while bounding the number of batches held in memory to the
+// lookahead plus the capacity of the output channel.
+func (q *blocksQueue) extendWindow(blocksPerRequest uint64) {
+	for len(q.smm.machines) < lookaheadSteps+cap(q.fetchedData) {
+		undelivered := 0
+		for _, fsm := range q.smm.machines {
+			switch fsm.state {
+			case stateNew, stateScheduled, stateDataParsed:
+				undelivered++
+			}
+		}
+		if undelivered >= lookaheadSteps {
+			return
+		}
+		highestStartSlot, err := q.smm.highestStartSlot()
+		if err != nil {
+			return
+		}
+		next := highestStartSlot.Add(blocksPerRequest)
+		if next > q.highestExpectedSlot {
+			return
+		}
+		q.smm.addStateMachine(next)
+	}
+}
+
 func waitHighestExpectedSlot(q *blocksQueue) bool {
 	// Check highest expected slot when we approach chain's head slot.
 	if q.chain.HeadSlot() >= q.highestExpectedSlot {
@@ -343,6 +379,7 @@ func (q *blocksQueue) onDataReceivedEvent(ctx context.Context) eventHandlerFn {
 			return m.state, response.err
 		}
 		m.pid = response.pid
+		m.sources = response.sources
 		m.bwb = response.bwb
 		return stateDataParsed, nil
 	}
@@ -364,8 +401,9 @@ func (q *blocksQueue) onReadyToSendEvent(ctx context.Context) eventHandlerFn {
 
 		send := func() (stateID, error) {
 			data := &blocksQueueFetchedData{
-				pid: m.pid,
-				bwb: m.bwb,
+				pid:     m.pid,
+				sources: m.sources,
+				bwb:     m.bwb,
 			}
 			select {
 			case <-ctx.Done():
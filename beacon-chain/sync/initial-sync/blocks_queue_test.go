@@ -1348,3 +1348,49 @@ func TestBlocksQueue_stuckWhenHeadIsSetToOrphanedBlock(t *testing.T) {
 		require.Equal(t, true, beaconDB.HasBlock(ctx, blkRoot) || mc.HasBlock(ctx, blkRoot), "slot %d", blk.Block.Slot)
 	}
 }
+
+func TestBlocksQueue_extendWindow(t *testing.T) {
+	const blocksPerRequest = 64
+	newQueue := func(highestExpectedSlot primitives.Slot) *blocksQueue {
+		q := &blocksQueue{
+			smm:                 newStateMachineManager(),
+			fetchedData:         make(chan *blocksQueueFetchedData, lookaheadSteps),
+			highestExpectedSlot: highestExpectedSlot,
+		}
+		for i := primitives.Slot(0); i < blocksPerRequest*lookaheadSteps; i += blocksPerRequest {
+			q.smm.addStateMachine(i).setState(stateScheduled)
+		}
+		return q
+	}
+
+	t.Run("window full", func(t *testing.T) {
+		q := newQueue(1000)
+		q.extendWindow(blocksPerRequest)
+		assert.Equal(t, lookaheadSteps, len(q.smm.machines))
+	})
+	t.Run("delivered batches are replaced", func(t *testing.T) {
+		q := newQueue(1000)
+		q.smm.machines[0].setState(stateSent)
+		q.smm.machines[blocksPerRequest].setState(stateSent)
+		q.extendWindow(blocksPerRequest)
+		require.Equal(t, lookaheadSteps+2, len(q.smm.machines))
+		highest, err := q.smm.highestStartSlot()
+		require.NoError(t, err)
+		assert.Equal(t, primitives.Slot(blocksPerRequest*(lookaheadSteps+1)), highest)
+	})
+	t.Run("bounded by output channel capacity", func(t *testing.T) {
+		q := newQueue(10000)
+		for _, fsm := range q.smm.machines {
+			fsm.setState(stateSent)
+		}
+		q.extendWindow(blocksPerRequest)
+		require.Equal(t, lookaheadSteps+cap(q.fetchedData), len(q.smm.machines))
+	})
+	t.Run("bounded by highest expected slot", func(t *testing.T) {
+		q := newQueue(blocksPerRequest * lookaheadSteps)
+		q.smm.machines[0].setState(stateSent)
+		q.smm.machines[blocksPerRequest].setState(stateSent)
+		q.extendWindow(blocksPerRequest)
+		assert.Equal(t, lookaheadSteps+1, len(q.smm.machines))
+	})
+}
@@ -46,6 +46,7 @@ type stateMachine struct {
 	start   primitives.Slot
 	state   stateID
 	pid     peer.ID
+	sources []rangeChunk
 	bwb     []blocks.BlockWithROBlobs
 	updated time.Time
 }
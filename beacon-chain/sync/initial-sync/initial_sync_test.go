@@ -50,6 +50,7 @@ type peerData struct {
 	headSlot       primitives.Slot
 	failureSlots   []primitives.Slot // slots at which the peer will return an error
 	forkedPeer     bool
+	perBlockDelay  time.Duration // simulated time it takes the peer to serve a single block
 }
 
 func TestMain(m *testing.M) {
@@ -73,7 +74,7 @@ func TestMain(m *testing.M) {
 	m.Run()
 }
 
-func initializeTestServices(t *testing.T, slots []primitives.Slot, peers []*peerData) (*mock.ChainService, *p2pt.TestP2P, db.Database) {
+func initializeTestServices(t testing.TB, slots []primitives.Slot, peers []*peerData) (*mock.ChainService, *p2pt.TestP2P, db.Database) {
 	cache.initializeRootCache(slots, t)
 	beaconDB := dbtest.SetupDB(t)
 
@@ -124,7 +125,7 @@ func makeSequence(start, end primitives.Slot) []primitives.Slot {
 	return seq
 }
 
-func (c *testCache) initializeRootCache(reqSlots []primitives.Slot, t *testing.T) {
+func (c *testCache) initializeRootCache(reqSlots []primitives.Slot, t testing.TB) {
 	c.Lock()
 	defer c.Unlock()
 
@@ -158,14 +159,14 @@ func TestMakeSequence(t *testing.T) {
 
 // Connect peers with local host. This method sets up peer statuses and the appropriate handlers
 // for each test peer.
-func connectPeers(t *testing.T, host *p2pt.TestP2P, data []*peerData, peerStatus *peers.Status) {
+func connectPeers(t testing.TB, host *p2pt.TestP2P, data []*peerData, peerStatus *peers.Status) {
 	for _, d := range data {
 		connectPeer(t, host, d, peerStatus)
 	}
 }
 
 // connectPeer connects a peer to a local host.
-func connectPeer(t *testing.T, host *p2pt.TestP2P, datum *peerData, peerStatus *peers.Status) peer.ID {
+func connectPeer(t testing.TB, host *p2pt.TestP2P, datum *peerData, peerStatus *peers.Status) peer.ID {
 	const topic = "/eth2/beacon_chain/req/beacon_blocks_by_range/1/ssz_snappy"
 	p := p2pt.NewTestP2P(t)
 	p.SetStreamHandler(topic, func(stream network.Stream) {
@@ -218,6 +219,7 @@ func connectPeer(t *testing.T, host *p2pt.TestP2P, datum *peerData, peerStatus *
 		}
 
 		for i := 0; i < len(ret); i++ {
+			time.Sleep(datum.perBlockDelay)
 			wsb, err := blocks.NewSignedBeaconBlock(ret[i])
 			require.NoError(t, err)
 			assert.NoError(t, beaconsync.WriteBlockChunk(stream, startup.NewClock(time.Now(), [32]byte{}), p.Encoding(), wsb))
@@ -0,0 +1,31 @@
+package initialsync
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics are aggregated over all peers, the stats of individual peers are available from Service.PeerSyncStats.
+var (
+	syncBatchRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "initial_sync_batch_requests_total",
+		Help: "Number of block batch requests sent to peers during initial sync.",
+	})
+	syncBatchFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "initial_sync_batch_request_failures_total",
+		Help: "Number of failed block batch requests during initial sync.",
+	})
+	syncBatchStolen = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "initial_sync_batch_requests_stolen_total",
+		Help: "Number of block batch requests re-requested from a faster peer during initial sync.",
+	})
+	syncBatchBlocks = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "initial_sync_batch_blocks_total",
+		Help: "Number of blocks served by peers in block batch requests during initial sync.",
+	})
+	syncBatchLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "initial_sync_batch_request_duration_seconds",
+		Help:    "Duration of successful block batch requests during initial sync.",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2, 4, 8, 16},
+	})
+)
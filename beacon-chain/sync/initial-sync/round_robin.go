@@ -87,6 +87,7 @@ func (s *Service) startBlocksQueue(ctx context.Context, highestSlot primitives.S
 		highestExpectedSlot: highestSlot,
 		mode:                mode,
 		bs:                  summarizer,
+		stats:               s.peerStats,
 	}
 	queue := newBlocksQueue(ctx, cfg)
 	if err := queue.start(); err != nil {
@@ -120,6 +121,7 @@ func (s *Service) syncToFinalizedEpoch(ctx context.Context, genesis time.Time) e
 		"syncedSlot":  s.cfg.Chain.HeadSlot(),
 		"currentSlot": slots.Since(genesis),
 	}).Info("Synced to finalized epoch - now syncing blocks up to current head")
+	s.logPeerSyncStats()
 	if err := queue.stop(); err != nil {
 		log.WithError(err).Debug("Error stopping queue")
 	}
@@ -151,7 +153,7 @@ func (s *Service) syncToNonFinalizedEpoch(ctx context.Context, genesis time.Time
 // processFetchedData processes data received from queue.
 func (s *Service) processFetchedData(
 	ctx context.Context, genesis time.Time, startSlot primitives.Slot, data *blocksQueueFetchedData) {
-	defer s.updatePeerScorerStats(data.pid, startSlot)
+	defer s.updateFetchedDataScorerStats(data, startSlot)
 
 	// Use Batch Block Verify to process and verify batches directly.
 	if err := s.processBatchedBlocks(ctx, genesis, data.bwb, s.cfg.Chain.ReceiveBlockBatch); err != nil {
@@ -162,7 +164,7 @@ func (s *Service) processFetchedData(
 // processFetchedDataRegSync processes data received from queue.
 func (s *Service) processFetchedDataRegSync(
 	ctx context.Context, genesis time.Time, startSlot primitives.Slot, data *blocksQueueFetchedData) {
-	defer s.updatePeerScorerStats(data.pid, startSlot)
+	defer s.updateFetchedDataScorerStats(data, startSlot)
 
 	bwb, err := validUnprocessed(ctx, data.bwb, s.cfg.Chain.HeadSlot(), s.isProcessedBlock)
 	if err != nil {
@@ -204,6 +206,22 @@ func syncFields(b blocks.ROBlock) logrus.Fields {
 	}
 }
 
+// logPeerSyncStats logs per-peer throughput and batch sizing statistics gathered so far.
+func (s *Service) logPeerSyncStats() {
+	for _, st := range s.PeerSyncStats() {
+		log.WithFields(logrus.Fields{
+			"peer":           st.Peer,
+			"requests":       st.Requests,
+			"failures":       st.Failures,
+			"stolen":         st.Stolen,
+			"blocks":         st.Blocks,
+			"slotsPerSecond": fmt.Sprintf("%.1f", st.Throughput),
+			"latency":        st.Latency,
+			"batchSize":      st.BatchSize,
+		}).Debug("Peer sync stats")
+	}
+}
+
 // highestFinalizedEpoch returns the absolute highest finalized epoch of all connected peers.
 // Note this can be lower than our finalized epoch if we have no peers or peers that are all behind us.
 func (s *Service) highestFinalizedEpoch() primitives.Epoch {
@@ -347,6 +365,25 @@ func (s *Service) processBatchedBlocks(ctx context.Context, genesis time.Time,
 }
 
 // updatePeerScorerStats adjusts monitored metrics for a peer.
+// updateFetchedDataScorerStats credits the peers which served the fetched data for the blocks processed since startSlot.
+// Blocks fetched from several peers are credited to the peer that served each part of the range.
+func (s *Service) updateFetchedDataScorerStats(data *blocksQueueFetchedData, startSlot primitives.Slot) {
+	if len(data.sources) == 0 {
+		s.updatePeerScorerStats(data.pid, startSlot)
+		return
+	}
+	headSlot := s.cfg.Chain.HeadSlot()
+	scorer := s.cfg.P2P.Peers().Scorers().BlockProviderScorer()
+	for _, src := range data.sources {
+		// Slots in (startSlot, headSlot] were processed, credit the part of them within the source's range.
+		lo, hi := max(startSlot+1, src.start), min(headSlot+1, src.start.Add(src.count))
+		if src.pid == "" || lo >= hi {
+			continue
+		}
+		scorer.IncrementProcessedBlocks(src.pid, uint64(hi-lo))
+	}
+}
+
 func (s *Service) updatePeerScorerStats(pid peer.ID, startSlot primitives.Slot) {
 	if pid == "" {
 		return
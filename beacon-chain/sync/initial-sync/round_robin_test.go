@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/paulbellamy/ratecounter"
 	"github.com/prysmaticlabs/prysm/v5/async/abool"
 	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
@@ -703,3 +704,27 @@ func TestService_ValidUnprocessed(t *testing.T) {
 	// Ensure that the unprocessed batch is returned correctly.
 	assert.Equal(t, len(retBlocks), len(batch)-2)
 }
+
+func TestService_updateFetchedDataScorerStats(t *testing.T) {
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(40))
+	s := NewService(context.Background(), &Config{
+		P2P:           p2pt.NewTestP2P(t),
+		Chain:         &mock.ChainService{State: st},
+		StateNotifier: &mock.MockStateNotifier{},
+	})
+	scorer := s.cfg.P2P.Peers().Scorers().BlockProviderScorer()
+	a, b, c := peer.ID("a"), peer.ID("b"), peer.ID("c")
+	data := &blocksQueueFetchedData{sources: []rangeChunk{
+		{start: 1, count: 32, pid: a},
+		{start: 33, count: 32, pid: b},
+		{start: 65, count: 32, pid: c},
+	}}
+
+	// Slots 11 to 40 were processed, 22 of them served by a, and 8 by b.
+	s.updateFetchedDataScorerStats(data, 10)
+	assert.Equal(t, uint64(22), scorer.ProcessedBlocks(a))
+	assert.Equal(t, uint64(8), scorer.ProcessedBlocks(b))
+	assert.Equal(t, uint64(0), scorer.ProcessedBlocks(c))
+}
@@ -68,6 +68,7 @@ type Service struct {
 	verifierWaiter  *verification.InitializerWaiter
 	newBlobVerifier verification.NewBlobVerifier
 	ctxMap          sync.ContextByteVersions
+	peerStats       *peerStatsTracker
 }
 
 // Option is a functional option for the initial-sync Service.
@@ -117,6 +118,7 @@ func NewService(ctx context.Context, cfg *Config, opts ...Option) *Service {
 		counter:      ratecounter.NewRateCounter(counterSeconds * time.Second),
 		genesisChan:  make(chan time.Time),
 		clock:        startup.NewClock(time.Unix(0, 0), [32]byte{}), // default clock to prevent panic
+		peerStats:    newPeerStatsTracker(uint64(maxBatchLimit())),
 	}
 	for _, o := range opts {
 		o(s)
@@ -217,6 +219,15 @@ func (s *Service) Syncing() bool {
 	return s.synced.IsNotSet()
 }
 
+// PeerSyncStats returns per-peer statistics (throughput, latency, adaptive batch size) gathered
+// while syncing, sorted by descending throughput.
+func (s *Service) PeerSyncStats() []PeerSyncStats {
+	if s.peerStats == nil {
+		return nil
+	}
+	return s.peerStats.snapshot()
+}
+
 // Initialized returns true if initial sync has been started.
 func (s *Service) Initialized() bool {
 	return s.chainStarted.IsSet()
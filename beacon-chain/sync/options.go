@@ -17,6 +17,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/backfill/coverage"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/capture"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 )
 
@@ -64,6 +65,14 @@ func WithSlashingPool(slashingPool slashings.PoolManager) Option {
 	}
 }
 
+// WithSlashingArchive records the slashings received over gossip.
+func WithSlashingArchive(archive *slashings.Archive) Option {
+	return func(s *Service) error {
+		s.cfg.slashingArchive = archive
+		return nil
+	}
+}
+
 func WithSyncCommsPool(syncCommsPool synccommittee.Pool) Option {
 	return func(s *Service) error {
 		s.cfg.syncCommsPool = syncCommsPool
@@ -180,3 +189,12 @@ func WithAvailableBlocker(avb coverage.AvailableBlocker) Option {
 		return nil
 	}
 }
+
+// WithGossipCapture captures the gossip messages received on the topics selected by the capture writer,
+// along with their validation result.
+func WithGossipCapture(w *capture.Writer) Option {
+	return func(s *Service) error {
+		s.cfg.gossipCapture = w
+		return nil
+	}
+}
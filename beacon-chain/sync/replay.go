@@ -0,0 +1,160 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/capture"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"google.golang.org/protobuf/proto"
+)
+
+var errReplayUnknownTopic = errors.New("no gossip validator for captured topic")
+
+type gossipHandler struct {
+	validate wrappedVal
+	handle   subHandler
+}
+
+// gossipHandlers maps gossip topic names to the validators and subscribers registered by registerSubscribers.
+func (s *Service) gossipHandlers() map[string]gossipHandler {
+	return map[string]gossipHandler{
+		p2p.GossipBlockMessage:                {s.validateBeaconBlockPubSub, s.beaconBlockSubscriber},
+		p2p.GossipAggregateAndProofMessage:    {s.validateAggregateAndProof, s.beaconAggregateProofSubscriber},
+		p2p.GossipExitMessage:                 {s.validateVoluntaryExit, s.voluntaryExitSubscriber},
+		p2p.GossipProposerSlashingMessage:     {s.validateProposerSlashing, s.proposerSlashingSubscriber},
+		p2p.GossipAttesterSlashingMessage:     {s.validateAttesterSlashing, s.attesterSlashingSubscriber},
+		p2p.GossipAttestationMessage:          {s.validateCommitteeIndexBeaconAttestation, s.committeeIndexBeaconAttestationSubscriber},
+		p2p.GossipContributionAndProofMessage: {s.validateSyncContributionAndProof, s.syncContributionAndProofSubscriber},
+		p2p.GossipSyncCommitteeMessage:        {s.validateSyncCommitteeMessage, s.syncCommitteeMessageSubscriber},
+		p2p.GossipBlsToExecutionChangeMessage: {s.validateBlsToExecutionChange, s.blsToExecutionChangeSubscriber},
+		p2p.GossipBlobSidecarMessage:          {s.validateBlob, s.blobSubscriber},
+	}
+}
+
+// ReplayResult is the outcome of replaying a captured gossip message.
+type ReplayResult struct {
+	Record   *capture.Record
+	Captured pubsub.ValidationResult
+	Result   pubsub.ValidationResult
+	// Err is the error returned by the validator, if any.
+	Err error
+	// HandlerErr is the error returned by the subscriber, for accepted messages.
+	HandlerErr error
+}
+
+// Differs reports whether the replayed validation outcome differs from the captured one.
+func (r *ReplayResult) Differs() bool {
+	return r.Captured != r.Result
+}
+
+// Replayer feeds captured gossip messages through the topic validators and subscribers of a Service. The clock of
+// the Service is set to the time each message was captured at, so that validators checking the message timing
+// see the same current slot as when the message was received.
+type Replayer struct {
+	s       *Service
+	handler map[string]gossipHandler
+	nowLock sync.RWMutex
+	now     time.Time
+}
+
+// NewReplayer creates a Replayer for a chain with the given genesis time and validators root. The options must
+// provide the dependencies of the gossip validators and subscribers, like for NewService; the fork choice and
+// state getter are used to initialize blob verification.
+func NewReplayer(ctx context.Context, genesis time.Time, vr [32]byte, fc verification.Forkchoicer, sr verification.StateByRooter, opts ...Option) (*Replayer, error) {
+	r := &Replayer{}
+	s := NewService(ctx, append(opts, WithInitialSync(replaySynced{}))...)
+	if s == nil {
+		return nil, errors.New("could not initialize sync service for replay")
+	}
+	s.cfg.clock = startup.NewClock(genesis, vr, startup.WithNower(r.Now))
+	s.chainStarted.Set()
+	cs := startup.NewClockSynchronizer()
+	if err := cs.SetClock(s.cfg.clock); err != nil {
+		return nil, err
+	}
+	ini, err := verification.NewInitializerWaiter(cs, fc, sr).WaitForInitializer(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize blob verification")
+	}
+	s.newBlobVerifier = newBlobVerifierFromInitializer(ini)
+	go s.verifierRoutine()
+	r.s = s
+	r.handler = s.gossipHandlers()
+	return r, nil
+}
+
+// Now returns the time at which the message being replayed was captured.
+func (r *Replayer) Now() time.Time {
+	r.nowLock.RLock()
+	defer r.nowLock.RUnlock()
+	return r.now
+}
+
+func (r *Replayer) setNow(t time.Time) {
+	r.nowLock.Lock()
+	defer r.nowLock.Unlock()
+	r.now = t
+}
+
+// Replay validates the captured message and, if it is accepted, passes it to the topic subscriber.
+func (r *Replayer) Replay(ctx context.Context, rec *capture.Record) (*ReplayResult, error) {
+	h, ok := r.handler[capture.TopicName(rec.Topic)]
+	if !ok {
+		return nil, errors.Wrapf(errReplayUnknownTopic, "topic=%s", rec.Topic)
+	}
+	captured, err := rec.ValidationResult()
+	if err != nil {
+		return nil, err
+	}
+	pid, err := peer.Decode(rec.Peer)
+	if err != nil {
+		pid = peer.ID(rec.Peer)
+	}
+	r.setNow(rec.Timestamp)
+
+	topic := rec.Topic
+	msg := &pubsub.Message{
+		Message:      &pubsubpb.Message{Data: rec.Data, Topic: &topic},
+		ReceivedFrom: pid,
+	}
+	vctx, cancel := context.WithTimeout(ctx, pubsubMessageTimeout)
+	defer cancel()
+	res, verr := h.validate(vctx, pid, msg)
+	// As in live validation, timeouts are not counted against the message.
+	if res == pubsub.ValidationReject && vctx.Err() != nil {
+		res = pubsub.ValidationIgnore
+	}
+	result := &ReplayResult{Record: rec, Captured: captured, Result: res, Err: verr}
+	if res == pubsub.ValidationAccept && msg.ValidatorData != nil {
+		result.HandlerErr = h.handle(vctx, msg.ValidatorData.(proto.Message))
+	}
+	return result, nil
+}
+
+// Stop stops the background routines of the replayed sync service.
+func (r *Replayer) Stop() {
+	r.s.cancel()
+}
+
+// replaySynced reports the node as synced, since captured messages are only validated by synced nodes.
+type replaySynced struct{}
+
+var _ Checker = replaySynced{}
+
+func (replaySynced) Initialized() bool { return true }
+
+func (replaySynced) Syncing() bool { return false }
+
+func (replaySynced) Synced() bool { return true }
+
+func (replaySynced) Status() error { return nil }
+
+func (replaySynced) Resync() error { return nil }
@@ -0,0 +1,208 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/capture"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestReplayer_Replay(t *testing.T) {
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = math.MaxUint64
+	params.OverrideBeaconConfig(cfg)
+	params.SetupTestConfigCleanup(t)
+
+	ctx := context.Background()
+	p := p2ptest.NewTestP2P(t)
+	exit, st := setupValidExit(t)
+	genesis := time.Now().Add(-time.Hour)
+	chain := &mock.ChainService{State: st, Genesis: genesis}
+	pool := voluntaryexits.NewPool()
+	r, err := NewReplayer(ctx, genesis, [32]byte{}, nil, nil,
+		WithP2P(p),
+		WithChainService(chain),
+		WithOperationNotifier(chain.OperationNotifier()),
+		WithExitPool(pool),
+	)
+	require.NoError(t, err)
+	defer r.Stop()
+
+	buf := new(bytes.Buffer)
+	_, err = p.Encoding().EncodeGossip(buf, exit)
+	require.NoError(t, err)
+	d, err := r.s.currentForkDigest()
+	require.NoError(t, err)
+	topic := r.s.addDigestToTopic(p2p.GossipTypeMapping[reflect.TypeOf(exit)], d) + p.Encoding().ProtocolSuffix()
+	captured := genesis.Add(10 * time.Minute)
+	rec := &capture.Record{
+		Topic:     topic,
+		Peer:      "16Uiu2HAm7yD5fhhw1Kihg5pffaGbvKpJVxKxdmKBPpw4hAXUrYTv",
+		Timestamp: captured,
+		Data:      buf.Bytes(),
+		Result:    "accept",
+	}
+
+	res, err := r.Replay(ctx, rec)
+	require.NoError(t, err)
+	require.NoError(t, res.Err)
+	require.NoError(t, res.HandlerErr)
+	require.Equal(t, pubsub.ValidationAccept, res.Result)
+	require.Equal(t, false, res.Differs())
+	require.Equal(t, captured, r.Now())
+	pending, err := pool.PendingExits()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(pending))
+
+	// The exit has been seen by the time it is replayed again.
+	res, err = r.Replay(ctx, rec)
+	require.NoError(t, err)
+	require.Equal(t, pubsub.ValidationIgnore, res.Result)
+	require.Equal(t, true, res.Differs())
+
+	rec.Topic = "/eth2/00000000/unknown/ssz_snappy"
+	_, err = r.Replay(ctx, rec)
+	require.ErrorIs(t, err, errReplayUnknownTopic)
+}
+
+func TestReplayer_ReplayAttestations(t *testing.T) {
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = math.MaxUint64
+	params.OverrideBeaconConfig(cfg)
+	params.SetupTestConfigCleanup(t)
+	helpers.ClearCache()
+
+	ctx := context.Background()
+	db := dbtest.SetupDB(t)
+	p := p2ptest.NewTestP2P(t)
+	validators := uint64(256)
+	st, keys := util.DeterministicGenesisState(t, validators)
+	b := util.NewBeaconBlock()
+	util.SaveBlock(t, ctx, db, b)
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, st, root))
+
+	// The messages were captured during the second slot of a chain started a day before the replay, which is far
+	// outside of the attestation propagation range of the local clock.
+	genesis := time.Now().Add(-24 * time.Hour)
+	captured := genesis.Add(time.Duration(params.BeaconConfig().SecondsPerSlot+1) * time.Second)
+	chain := &mock.ChainService{
+		Genesis:             genesis,
+		DB:                  db,
+		State:               st,
+		ValidAttestation:    true,
+		FinalizedCheckPoint: &ethpb.Checkpoint{Root: root[:]},
+	}
+	pool := attestations.NewPool()
+	r, err := NewReplayer(ctx, genesis, [32]byte{}, nil, nil,
+		WithP2P(p),
+		WithDatabase(db),
+		WithChainService(chain),
+		WithAttestationPool(pool),
+		WithAttestationNotifier(chain.OperationNotifier()),
+		WithOperationNotifier(chain.OperationNotifier()),
+	)
+	require.NoError(t, err)
+	defer r.Stop()
+
+	committee, err := helpers.BeaconCommitteeFromState(ctx, st, 1, 0)
+	require.NoError(t, err)
+	domain, err := signing.Domain(st.Fork(), 0, params.BeaconConfig().DomainBeaconAttester, st.GenesisValidatorsRoot())
+	require.NoError(t, err)
+	newAttestation := func(bit uint64) *ethpb.Attestation {
+		bits := bitfield.NewBitlist(uint64(len(committee)))
+		bits.SetBitAt(bit, true)
+		att := &ethpb.Attestation{
+			AggregationBits: bits,
+			Data: &ethpb.AttestationData{
+				Slot:            1,
+				BeaconBlockRoot: root[:],
+				Source:          &ethpb.Checkpoint{Root: bytesutil.PadTo([]byte("source"), 32)},
+				Target:          &ethpb.Checkpoint{Root: root[:]},
+			},
+		}
+		signingRoot, err := signing.ComputeSigningRoot(att.Data, domain)
+		require.NoError(t, err)
+		att.Signature = keys[committee[bit]].Sign(signingRoot[:]).Marshal()
+		return att
+	}
+	d, err := r.s.currentForkDigest()
+	require.NoError(t, err)
+	record := func(topic string, msg ssz.Marshaler) *capture.Record {
+		buf := new(bytes.Buffer)
+		_, err := p.Encoding().EncodeGossip(buf, msg)
+		require.NoError(t, err)
+		return &capture.Record{
+			Topic:     topic + p.Encoding().ProtocolSuffix(),
+			Peer:      "16Uiu2HAm7yD5fhhw1Kihg5pffaGbvKpJVxKxdmKBPpw4hAXUrYTv",
+			Timestamp: captured,
+			Data:      buf.Bytes(),
+			Result:    "accept",
+		}
+	}
+
+	t.Run("aggregate", func(t *testing.T) {
+		ai := committee[0]
+		slot := primitives.SSZUint64(1)
+		proof, err := signing.ComputeDomainAndSign(st, 0, &slot, params.BeaconConfig().DomainSelectionProof, keys[ai])
+		require.NoError(t, err)
+		agg := &ethpb.SignedAggregateAttestationAndProof{
+			Message: &ethpb.AggregateAttestationAndProof{
+				AggregatorIndex: ai,
+				Aggregate:       newAttestation(0),
+				SelectionProof:  proof,
+			},
+		}
+		agg.Signature, err = signing.ComputeDomainAndSign(st, 0, agg.Message, params.BeaconConfig().DomainAggregateAndProof, keys[ai])
+		require.NoError(t, err)
+		rec := record(r.s.addDigestToTopic(p2p.GossipTypeMapping[reflect.TypeOf(agg)], d), agg)
+
+		res, err := r.Replay(ctx, rec)
+		require.NoError(t, err)
+		require.NoError(t, res.Err)
+		require.NoError(t, res.HandlerErr)
+		require.Equal(t, pubsub.ValidationAccept, res.Result)
+		require.Equal(t, false, res.Differs())
+		// An aggregate with a single attester is saved as an unaggregated attestation.
+		unaggregated, err := pool.UnaggregatedAttestations()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(unaggregated))
+	})
+	t.Run("attestation", func(t *testing.T) {
+		att := newAttestation(1)
+		rec := record(fmt.Sprintf(p2p.AttestationSubnetTopicFormat, d, 1), att)
+
+		res, err := r.Replay(ctx, rec)
+		require.NoError(t, err)
+		require.NoError(t, res.Err)
+		require.NoError(t, res.HandlerErr)
+		require.Equal(t, pubsub.ValidationAccept, res.Result)
+		require.Equal(t, false, res.Differs())
+		unaggregated, err := pool.UnaggregatedAttestations()
+		require.NoError(t, err)
+		require.Equal(t, 2, len(unaggregated))
+	})
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/backfill/coverage"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/capture"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 	lruwrpr "github.com/prysmaticlabs/prysm/v5/cache/lru"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -87,6 +88,7 @@ type config struct {
 	attPool                 attestations.Pool
 	exitPool                voluntaryexits.PoolManager
 	slashingPool            slashings.PoolManager
+	slashingArchive         *slashings.Archive
 	syncCommsPool           synccommittee.Pool
 	blsToExecPool           blstoexec.PoolManager
 	chain                   blockchainService
@@ -100,6 +102,7 @@ type config struct {
 	clock                   *startup.Clock
 	stateNotifier           statefeed.Notifier
 	blobStorage             *filesystem.BlobStorage
+	gossipCapture           *capture.Writer
 }
 
 // This defines the interface for interacting with block chain service
@@ -261,6 +264,9 @@ func (s *Service) Stop() error {
 		s.unSubscribeFromTopic(t)
 	}
 	defer s.cancel()
+	if s.cfg.gossipCapture != nil {
+		return s.cfg.gossipCapture.Close()
+	}
 	return nil
 }
 
@@ -330,6 +330,7 @@ func (s *Service) wrapAndReportValidation(topic string, v wrappedVal) (string, p
 			}
 			messageIgnoredValidationCounter.WithLabelValues(topic).Inc()
 		}
+		s.cfg.gossipCapture.Capture(topic, pid.String(), msg.Data, b)
 		return b
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/slashing"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"google.golang.org/protobuf/proto"
 )
@@ -41,6 +42,9 @@ func (s *Service) attesterSlashingSubscriber(ctx context.Context, msg proto.Mess
 			return errors.Wrap(err, "could not insert attester slashing into pool")
 		}
 		s.setAttesterSlashingIndicesSeen(aSlashing.FirstAttestation().GetAttestingIndices(), aSlashing.SecondAttestation().GetAttestingIndices())
+		if s.cfg.slashingArchive != nil {
+			s.cfg.slashingArchive.RecordAttesterSlashing(ctx, aSlashing, slashing.SourceGossip)
+		}
 	}
 	return nil
 }
@@ -62,6 +66,9 @@ func (s *Service) proposerSlashingSubscriber(ctx context.Context, msg proto.Mess
 			return errors.Wrap(err, "could not insert proposer slashing into pool")
 		}
 		s.setProposerSlashingIndexSeen(pSlashing.Header_1.Header.ProposerIndex)
+		if s.cfg.slashingArchive != nil {
+			s.cfg.slashingArchive.RecordProposerSlashing(ctx, pSlashing, slashing.SourceGossip)
+		}
 	}
 	return nil
 }
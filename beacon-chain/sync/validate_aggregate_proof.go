@@ -87,9 +87,10 @@ func (s *Service) validateAggregateAndProof(ctx context.Context, pid peer.ID, ms
 
 	// Attestation's slot is within ATTESTATION_PROPAGATION_SLOT_RANGE and early attestation
 	// processing tolerance.
-	if err := helpers.ValidateAttestationTime(
+	if err := helpers.ValidateAttestationTimeAt(
 		data.Slot,
 		s.cfg.clock.GenesisTime(),
+		s.cfg.clock.Now(),
 		earlyAttestationProcessingTolerance,
 	); err != nil {
 		tracing.AnnotateError(span, err)
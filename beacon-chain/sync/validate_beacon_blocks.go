@@ -207,6 +207,7 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 		return pubsub.ValidationIgnore, err
 	}
 	msg.ValidatorData = blkPb // Used in downstream subscriber
+	s.cfg.chain.RecordBlockArrival(blockRoot, receivedTime)
 
 	// Log the arrival time of the accepted block
 	graffiti := blk.Block().Body().Graffiti()
@@ -70,9 +70,10 @@ func (s *Service) validateSyncCommitteeMessage(
 
 	// Validate sync message times before proceeding.
 	// The message's `slot` is for the current slot (with a MAXIMUM_GOSSIP_CLOCK_DISPARITY allowance).
-	if err := altair.ValidateSyncMessageTime(
+	if err := altair.ValidateSyncMessageTimeAt(
 		m.Slot,
 		s.cfg.clock.GenesisTime(),
+		s.cfg.clock.Now(),
 		params.BeaconConfig().MaximumGossipClockDisparityDuration(),
 	); err != nil {
 		tracing.AnnotateError(span, err)
@@ -31,5 +31,22 @@ var Commands = &cli.Command{
 				return nil
 			},
 		},
+		{
+			Name: "import-era",
+			Description: `imports the finalized blocks from a directory of era files, to fill the block history ` +
+				`of a checkpoint synced database. The beacon node must be stopped while this command runs`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				cmd.EraDirFlag,
+				cmd.ChainConfigFileFlag,
+			}),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := importEra(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not import era files")
+				}
+				return nil
+			},
+		},
 	},
 }
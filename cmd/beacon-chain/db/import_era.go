@@ -0,0 +1,40 @@
+package db
+
+import (
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/era"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/urfave/cli/v2"
+)
+
+func importEra(cliCtx *cli.Context) error {
+	eraDir := cliCtx.String(cmd.EraDirFlag.Name)
+	if eraDir == "" {
+		return errors.Errorf("--%s is required", cmd.EraDirFlag.Name)
+	}
+	if cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		if err := params.LoadChainConfigFile(cliCtx.String(cmd.ChainConfigFileFlag.Name), nil); err != nil {
+			return err
+		}
+	}
+	dbPath := path.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.BeaconNodeDbDirName)
+	db, err := kv.NewKVStore(cliCtx.Context, dbPath)
+	if err != nil {
+		return errors.Wrapf(err, "could not open beacon node database at %s", dbPath)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close beacon node database")
+		}
+	}()
+	n, err := era.Import(cliCtx.Context, db, eraDir)
+	if err != nil {
+		return err
+	}
+	log.WithField("blocks", n).Info("Finished importing era files")
+	return nil
+}
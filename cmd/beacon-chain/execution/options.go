@@ -3,10 +3,12 @@ package execution
 import (
 	"encoding/hex"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v5/io/file"
 	"github.com/urfave/cli/v2"
@@ -31,6 +33,67 @@ func FlagOptions(c *cli.Context) ([]execution.Option, error) {
 	if len(jwtSecret) > 0 {
 		opts = append(opts, execution.WithHttpEndpointAndJWTSecret(endpoint, jwtSecret))
 	}
+	secondaryOpts, err := parseSecondaryExecutionEndpoints(c)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, secondaryOpts...)
+	verificationOpts, err := parseVerificationExecutionEndpoint(c)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, verificationOpts...)
+	return opts, nil
+}
+
+// Parses the verification execution client endpoint, which cross-checks the payloads validated by the primary.
+func parseVerificationExecutionEndpoint(c *cli.Context) ([]execution.Option, error) {
+	endpoint := c.String(flags.VerificationExecutionEngineEndpoint.Name)
+	if endpoint == "" {
+		return nil, nil
+	}
+	var secret []byte
+	if secretFile := c.String(flags.VerificationExecutionJWTSecretFlag.Name); secretFile != "" {
+		var err error
+		secret, err = readJWTSecret(secretFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read JWT secret file for verification execution endpoint")
+		}
+	}
+	dir := c.String(flags.ExecutionDivergenceDir.Name)
+	if dir == "" {
+		dir = filepath.Join(c.String(cmd.DataDirFlag.Name), "execution-divergences")
+	}
+	return []execution.Option{
+		execution.WithVerificationHttpEndpoint(endpoint, secret),
+		execution.WithPayloadDivergenceDir(dir),
+	}, nil
+}
+
+// Parses the secondary execution client endpoints, each with the JWT secret at the same position
+// in the list of secondary JWT secret files.
+func parseSecondaryExecutionEndpoints(c *cli.Context) ([]execution.Option, error) {
+	endpoints := c.StringSlice(flags.SecondaryExecutionEngineEndpoints.Name)
+	secretFiles := c.StringSlice(flags.SecondaryExecutionJWTSecretFlag.Name)
+	if len(secretFiles) > 0 && len(secretFiles) != len(endpoints) {
+		return nil, fmt.Errorf("%s must be set once for each %s, got %d secrets for %d endpoints",
+			flags.SecondaryExecutionJWTSecretFlag.Name, flags.SecondaryExecutionEngineEndpoints.Name, len(secretFiles), len(endpoints))
+	}
+	if len(endpoints) == 0 {
+		return nil, nil
+	}
+	opts := []execution.Option{execution.WithFailoverTimeout(c.Duration(flags.ExecutionFailoverTimeout.Name))}
+	for i, endpoint := range endpoints {
+		var secret []byte
+		if len(secretFiles) > 0 {
+			var err error
+			secret, err = readJWTSecret(secretFiles[i])
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not read JWT secret file for secondary execution endpoint %d", i)
+			}
+		}
+		opts = append(opts, execution.WithSecondaryHttpEndpoint(endpoint, secret))
+	}
 	return opts, nil
 }
 
@@ -47,6 +110,10 @@ func parseJWTSecretFromFile(c *cli.Context) ([]byte, error) {
 	if jwtSecretFile == "" {
 		return nil, nil
 	}
+	return readJWTSecret(jwtSecretFile)
+}
+
+func readJWTSecret(jwtSecretFile string) ([]byte, error) {
 	enc, err := file.ReadFileAsBytes(jwtSecretFile)
 	if err != nil {
 		return nil, err
@@ -99,3 +99,50 @@ func TestPowchainPreregistration_EmptyWeb3Provider(t *testing.T) {
 	_, err := parseExecutionChainEndpoint(ctx)
 	assert.ErrorContains(t, "you need to specify", err)
 }
+
+func Test_parseSecondaryExecutionEndpoints(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	secret := bytesutil.ToBytes32([]byte("foo"))
+	require.NoError(t, file.WriteFile(secretFile, []byte(fmt.Sprintf("%#x", secret))))
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	endpoints := cli.NewStringSlice("http://secondary:8551", "http://tertiary:8551")
+	set.Var(endpoints, flags.SecondaryExecutionEngineEndpoints.Name, "")
+	secrets := cli.NewStringSlice()
+	require.NoError(t, secrets.Set(secretFile))
+	set.Var(secrets, flags.SecondaryExecutionJWTSecretFlag.Name, "")
+	ctx := cli.NewContext(&app, set, nil)
+	_, err := parseSecondaryExecutionEndpoints(ctx)
+	require.ErrorContains(t, "must be set once for each", err)
+
+	require.NoError(t, secrets.Set(secretFile))
+	opts, err := parseSecondaryExecutionEndpoints(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(opts))
+}
+
+func Test_parseVerificationExecutionEndpoint(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(flags.VerificationExecutionEngineEndpoint.Name, "", "")
+	set.String(flags.VerificationExecutionJWTSecretFlag.Name, "", "")
+	set.String(flags.ExecutionDivergenceDir.Name, "", "")
+	ctx := cli.NewContext(&app, set, nil)
+	opts, err := parseVerificationExecutionEndpoint(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(opts))
+
+	require.NoError(t, set.Set(flags.VerificationExecutionEngineEndpoint.Name, "http://verifier:8551"))
+	require.NoError(t, set.Set(flags.VerificationExecutionJWTSecretFlag.Name, filepath.Join(t.TempDir(), "missing")))
+	_, err = parseVerificationExecutionEndpoint(ctx)
+	require.ErrorContains(t, "could not read JWT secret file for verification execution endpoint", err)
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	secret := bytesutil.ToBytes32([]byte("foo"))
+	require.NoError(t, file.WriteFile(secretFile, []byte(fmt.Sprintf("%#x", secret))))
+	require.NoError(t, set.Set(flags.VerificationExecutionJWTSecretFlag.Name, secretFile))
+	opts, err = parseVerificationExecutionEndpoint(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(opts))
+}
@@ -4,6 +4,7 @@ package flags
 
 import (
 	"strings"
+	"time"
 
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -86,6 +87,41 @@ var (
 			"This is not required if using an IPC connection.",
 		Value: "",
 	}
+	// SecondaryExecutionEngineEndpoints provides additional execution clients which receive the engine API calls sent to the primary.
+	SecondaryExecutionEngineEndpoints = &cli.StringSliceFlag{
+		Name: "execution-endpoint-secondary",
+		Usage: "Secondary execution client http endpoints. Engine API calls are also sent to them, and a healthy " +
+			"secondary is promoted when the primary --execution-endpoint is unresponsive or syncing for longer than " +
+			"--execution-failover-timeout.",
+	}
+	// SecondaryExecutionJWTSecretFlag provides the JWT secrets of the secondary execution clients.
+	SecondaryExecutionJWTSecretFlag = &cli.StringSliceFlag{
+		Name:  "jwt-secret-secondary",
+		Usage: "Paths to the JWT secret files of the secondary execution clients, in the order of --execution-endpoint-secondary.",
+	}
+	// ExecutionFailoverTimeout is the time after which an unhealthy primary execution client is replaced by a secondary.
+	ExecutionFailoverTimeout = &cli.DurationFlag{
+		Name:  "execution-failover-timeout",
+		Usage: "Time the primary execution client may be unresponsive or syncing before a healthy secondary execution client is promoted.",
+		Value: time.Minute,
+	}
+	// VerificationExecutionEngineEndpoint provides an execution client which cross-checks the payloads validated by the primary.
+	VerificationExecutionEngineEndpoint = &cli.StringFlag{
+		Name: "execution-endpoint-verification",
+		Usage: "Verification execution client http endpoint. Every payload sent to the primary --execution-endpoint " +
+			"is also verified by this execution client. If they disagree on the validity of a payload, the node stops " +
+			"attesting to its branch, emits an execution_payload_divergence event and persists the payload.",
+	}
+	// VerificationExecutionJWTSecretFlag provides the JWT secret of the verification execution client.
+	VerificationExecutionJWTSecretFlag = &cli.StringFlag{
+		Name:  "jwt-secret-verification",
+		Usage: "Path to the JWT secret file of the verification execution client.",
+	}
+	// ExecutionDivergenceDir is the directory where payloads disputed by the verification execution client are persisted.
+	ExecutionDivergenceDir = &cli.StringFlag{
+		Name:  "execution-divergence-dir",
+		Usage: "Directory where payloads disputed by the verification execution client are persisted. Defaults to <datadir>/execution-divergences.",
+	}
 	// JwtId is the id field of the JWT claims. The consensus layer client MAY use this to communicate a unique identifier for the individual consensus layer client
 	JwtId = &cli.StringFlag{
 		Name:  "jwt-id",
@@ -153,6 +189,18 @@ var (
 		Value:   strings.Join(DefaultHTTPCorsDomains, ", "),
 		Aliases: []string{"grpc-gateway-corsdomain"},
 	}
+	// HTTPAccessConfig specifies the file of the bearer tokens and per-client limits of the HTTP API.
+	HTTPAccessConfig = &cli.StringFlag{
+		Name: "http-access-config",
+		Usage: "Path to a YAML file of the bearer tokens accepted by the HTTP API, with the route groups " +
+			"(read, validator, debug, admin), rate limit and concurrent requests allowed to each of them. " +
+			"Changes to the file are applied without restarting.",
+	}
+	// HTTPAuditLogFile specifies the file where the requests of the HTTP API are recorded.
+	HTTPAuditLogFile = &cli.StringFlag{
+		Name:  "http-audit-log-file",
+		Usage: "Path to a file where a JSON record of each request to the HTTP API, with its client and status, is appended.",
+	}
 
 	// MinSyncPeers specifies the required number of successful peer handshakes in order
 	// to start syncing with external peers.
@@ -180,10 +228,85 @@ var (
 		Usage: "The slot durations of when an archived state gets saved in the beaconDB.",
 		Value: 2048,
 	}
+	// ForkchoiceSnapshotInterval specifies how often fork choice is saved to the database.
+	ForkchoiceSnapshotInterval = &cli.DurationFlag{
+		Name: "forkchoice-snapshot-interval",
+		Usage: "Interval at which fork choice is saved to the database, so that it can be restored at startup " +
+			"instead of being rebuilt from the finalized state. A snapshot is also saved on shutdown. Set to 0 to disable.",
+		Value: 5 * time.Minute,
+	}
+	// ForkchoiceRecordFile specifies the file where the inputs of fork choice are recorded.
+	ForkchoiceRecordFile = &cli.StringFlag{
+		Name: "forkchoice-record-file",
+		Usage: "Appends every input of fork choice (blocks, attestations, ticks, checkpoints, proposer boost and late " +
+			"block reorg decisions) to this file, so that its decisions can be replayed with `prysmctl forkchoice replay`. " +
+			"Disabled by default.",
+	}
+	// ReorgHistoryWindow specifies for how many epochs reorg analytics are kept in the database.
+	ReorgHistoryWindow = &cli.Uint64Flag{
+		Name: "reorg-history-window",
+		Usage: "Number of epochs for which chain reorgs and late block reorg decisions are kept in the database and " +
+			"served by /prysm/v1/beacon/reorgs. Set to 0 to disable reorg analytics.",
+		Value: 1575, // About one week.
+	}
+	// SlashingEvidenceRetention specifies for how many epochs slashing evidence is kept in the database.
+	SlashingEvidenceRetention = &cli.Uint64Flag{
+		Name: "slashing-evidence-retention-epochs",
+		Usage: "Number of epochs after the offense epoch for which slashing evidence is kept in the database and " +
+			"served by /prysm/v1/slasher/slashings. Set to 0 to keep slashing evidence forever.",
+		Value: 82125, // About one year.
+	}
+	// AttestationInclusionEpochs specifies for how many epochs attestation inclusions are tracked.
+	AttestationInclusionEpochs = &cli.Uint64Flag{
+		Name: "attestation-inclusion-epochs",
+		Usage: "Number of epochs for which the attestations included in imported blocks are indexed in memory and " +
+			"served by /prysm/v1/beacon/attestations/inclusion. Set to 0 to disable attestation inclusion tracking.",
+		Value: 8,
+	}
+	// EventReplayDepth specifies how many events of each replayed topic are kept for the event stream.
+	EventReplayDepth = &cli.IntFlag{
+		Name: "event-replay-depth",
+		Usage: "Number of head, block, finalized_checkpoint and chain_reorg events kept to be replayed to event stream " +
+			"clients reconnecting with the Last-Event-ID header. Set to 0 to disable event IDs and replay.",
+		Value: 256,
+	}
+	// EventReplayFile specifies the file where the replayed events are saved on shutdown.
+	EventReplayFile = &cli.StringFlag{
+		Name: "event-replay-file",
+		Usage: "Saves the events kept for replay to this file on shutdown and loads them at startup, so that event " +
+			"stream clients can resume across restarts. Disabled by default.",
+	}
+	// ValidatorHistoryMaxEpochs specifies the largest epoch range served by one validator history or rewards request.
+	ValidatorHistoryMaxEpochs = &cli.Uint64Flag{
+		Name:  "validator-history-max-epochs",
+		Usage: "Maximum number of epochs served by one request to /prysm/v1/validators/history or /prysm/v1/validators/rewards.",
+		Value: 7200, // About one month.
+	}
+	// GraphQL enables the GraphQL query endpoint of the beacon API.
+	GraphQL = &cli.BoolFlag{
+		Name:  "graphql",
+		Usage: "Enables the /graphql query endpoint over blocks, states, validators and fork choice on the beacon API.",
+	}
+	// GraphQLMaxDepth specifies the maximum nesting depth of a GraphQL query.
+	GraphQLMaxDepth = &cli.IntFlag{
+		Name:  "graphql-max-depth",
+		Usage: "Maximum nesting depth of a query to the /graphql endpoint.",
+		Value: 10,
+	}
+	// GraphQLMaxCost specifies the maximum cost of the data loaded by a GraphQL query.
+	GraphQLMaxCost = &cli.Uint64Flag{
+		Name: "graphql-max-cost",
+		Usage: "Maximum cost of a query to the /graphql endpoint. Each returned validator costs 1, a block 10, blobs 20, " +
+			"committees, fork choice or a reconstructed execution payload 50, the head, genesis, justified or finalized state 100, " +
+			"a state by slot or root 1000 (at most 4 per query) and an SSZ encoding 1 per 16 KiB.",
+		Value: 10000,
+	}
 	// BlockBatchLimit specifies the requested block batch size.
 	BlockBatchLimit = &cli.IntFlag{
-		Name:  "block-batch-limit",
-		Usage: "The amount of blocks the local peer is bounded to request and respond to in a batch. Maximum 128",
+		Name: "block-batch-limit",
+		Usage: "The amount of blocks the local peer is bounded to request and respond to in a batch. Maximum 128. " +
+			"During initial sync, this is the upper bound for batches requested from a single peer, " +
+			"which are sized adaptively based on the peer's observed throughput.",
 		Value: 64,
 	}
 	// BlockBatchLimitBurstFactor specifies the factor by which block batch size may increase.
@@ -290,10 +413,39 @@ var (
 			"WARNING: This flag should be used only if you have a clear understanding that community has decided to override the terminal block hash activation epoch. " +
 			"Incorrect usage will result in your node experience consensus failure.",
 	}
+	// GossipCaptureDir enables the capture of gossip messages to rotating files in the given directory.
+	GossipCaptureDir = &cli.StringFlag{
+		Name: "gossip-capture-dir",
+		Usage: "Enables the capture of raw gossip messages and their validation result to rotating files in the given directory. " +
+			"Captured messages can be replayed with `prysmctl p2p replay`.",
+	}
+	// GossipCaptureTopics specifies the gossip topics to capture.
+	GossipCaptureTopics = &cli.StringSliceFlag{
+		Name:  "gossip-capture-topics",
+		Usage: "Names of the gossip topics to capture, e.g. beacon_block or beacon_attestation. All topics are captured when not set.",
+	}
+	// GossipCaptureMaxFileSize specifies the size after which the gossip capture file is rotated.
+	GossipCaptureMaxFileSize = &cli.Uint64Flag{
+		Name:  "gossip-capture-max-file-size-mb",
+		Usage: "Size in megabytes after which the gossip capture file is rotated.",
+		Value: 256,
+	}
+	// GossipCaptureMaxFiles specifies the number of gossip capture files to keep.
+	GossipCaptureMaxFiles = &cli.IntFlag{
+		Name:  "gossip-capture-max-files",
+		Usage: "Number of gossip capture files to keep. The oldest file is deleted when the limit is exceeded.",
+		Value: 8,
+	}
 	// SlasherDirFlag defines a path on disk where the slasher database is stored.
 	SlasherDirFlag = &cli.StringFlag{
 		Name:  "slasher-datadir",
 		Usage: "Directory for the slasher database",
 		Value: cmd.DefaultDataDir(),
 	}
+	// SlasherRescanEpochs defines how many epochs of stored blocks the slasher rescans at startup.
+	SlasherRescanEpochs = &cli.Uint64Flag{
+		Name: "slasher-rescan-epochs",
+		Usage: "Number of epochs of blocks stored in the beacon database that the slasher rescans for slashable " +
+			"offenses when it starts, to catch the offenses it missed while it was not running. Disabled by default.",
+	}
 )
@@ -44,6 +44,12 @@ var appFlags = []cli.Flag{
 	flags.ExecutionEngineEndpoint,
 	flags.ExecutionEngineHeaders,
 	flags.ExecutionJWTSecretFlag,
+	flags.SecondaryExecutionEngineEndpoints,
+	flags.SecondaryExecutionJWTSecretFlag,
+	flags.ExecutionFailoverTimeout,
+	flags.VerificationExecutionEngineEndpoint,
+	flags.VerificationExecutionJWTSecretFlag,
+	flags.ExecutionDivergenceDir,
 	flags.RPCHost,
 	flags.RPCPort,
 	flags.CertFlag,
@@ -52,6 +58,8 @@ var appFlags = []cli.Flag{
 	flags.HTTPServerHost,
 	flags.HTTPServerPort,
 	flags.HTTPServerCorsDomain,
+	flags.HTTPAccessConfig,
+	flags.HTTPAuditLogFile,
 	flags.MinSyncPeers,
 	flags.ContractDeploymentBlock,
 	flags.SetGCPercent,
@@ -61,7 +69,22 @@ var appFlags = []cli.Flag{
 	flags.BlobBatchLimitBurstFactor,
 	flags.InteropMockEth1DataVotesFlag,
 	flags.SlotsPerArchivedPoint,
+	flags.ForkchoiceSnapshotInterval,
+	flags.ForkchoiceRecordFile,
+	flags.ReorgHistoryWindow,
+	flags.SlashingEvidenceRetention,
+	flags.AttestationInclusionEpochs,
+	flags.EventReplayDepth,
+	flags.EventReplayFile,
+	flags.ValidatorHistoryMaxEpochs,
+	flags.GraphQL,
+	flags.GraphQLMaxDepth,
+	flags.GraphQLMaxCost,
 	flags.DisableDebugRPCEndpoints,
+	flags.GossipCaptureDir,
+	flags.GossipCaptureTopics,
+	flags.GossipCaptureMaxFileSize,
+	flags.GossipCaptureMaxFiles,
 	flags.SubscribeToAllSubnets,
 	flags.HistoricalSlasherNode,
 	flags.ChainID,
@@ -136,16 +159,21 @@ var appFlags = []cli.Flag{
 	checkpoint.BlockPath,
 	checkpoint.StatePath,
 	checkpoint.RemoteURL,
+	checkpoint.Quorum,
 	genesis.StatePath,
 	genesis.BeaconAPIURL,
 	flags.SlasherDirFlag,
+	flags.SlasherRescanEpochs,
 	flags.JwtId,
 	storage.BlobStoragePathFlag,
 	storage.BlobRetentionEpochFlag,
+	storage.BlobArchiveFlag,
 	bflags.EnableExperimentalBackfill,
 	bflags.BackfillBatchSize,
 	bflags.BackfillWorkerCount,
 	bflags.BackfillOldestSlot,
+	bflags.BackfillBlobArchive,
+	bflags.BackfillBlobArchiveURL,
 }
 
 func init() {
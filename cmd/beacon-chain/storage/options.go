@@ -24,6 +24,12 @@ var (
 		Value:   uint64(params.BeaconConfig().MinEpochsForBlobsSidecarsRequest),
 		Aliases: []string{"extend-blob-retention-epoch"},
 	}
+	// BlobArchiveFlag exempts all blobs from the retention period, so that archive nodes can keep the full blob history.
+	BlobArchiveFlag = &cli.BoolFlag{
+		Name: "blob-archive",
+		Usage: "Keep all blobs indefinitely, disabling the pruning of blobs older than the retention period. " +
+			"Required to backfill blobs beyond the retention period with --backfill-blob-archive.",
+	}
 )
 
 // BeaconNodeOptions sets configuration values on the node.BeaconNode value at node startup.
@@ -37,6 +43,7 @@ func BeaconNodeOptions(c *cli.Context) ([]node.Option, error) {
 	}
 	opts := []node.Option{node.WithBlobStorageOptions(
 		filesystem.WithBlobRetentionEpochs(e), filesystem.WithBasePath(blobStoragePath(c)),
+		filesystem.WithArchiveMode(c.Bool(BlobArchiveFlag.Name)),
 	)}
 	return opts, nil
 }
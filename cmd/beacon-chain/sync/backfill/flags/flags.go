@@ -40,4 +40,16 @@ var (
 		Usage: "Specifies the oldest slot that backfill should download. " +
 			"If this value is greater than current_slot - MIN_EPOCHS_FOR_BLOCK_REQUESTS, it will be ignored with a warning log.",
 	}
+	// BackfillBlobArchive enables backfill of blobs for the full available history, for archive nodes.
+	BackfillBlobArchive = &cli.BoolFlag{
+		Name: "backfill-blob-archive",
+		Usage: "Backfill blobs for all blocks since the deneb fork, rather than only within the blob retention period. " +
+			"Requires --blob-archive and --backfill-blob-archive-url. Unless --backfill-oldest-slot is specified, blocks will be backfilled up to the deneb fork.",
+	}
+	// BackfillBlobArchiveURL is the beacon API endpoint used to download blobs older than what peers are required to serve.
+	BackfillBlobArchiveURL = &cli.StringFlag{
+		Name: "backfill-blob-archive-url",
+		Usage: "URL of a beacon node API endpoint with the full blob history. Required by --backfill-blob-archive " +
+			"to download the blobs older than the retention period, which peers are not required to serve.",
+	}
 )
@@ -1,10 +1,14 @@
 package backfill
 
 import (
+	"fmt"
+
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/node"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/backfill"
 	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/sync/backfill/flags"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	"github.com/urfave/cli/v2"
 )
 
@@ -22,8 +26,38 @@ func BeaconNodeOptions(c *cli.Context) ([]node.Option, error) {
 			uv := c.Uint64(flags.BackfillOldestSlot.Name)
 			bno = append(bno, backfill.WithMinimumSlot(primitives.Slot(uv)))
 		}
+		if c.Bool(flags.BackfillBlobArchive.Name) {
+			archiveOpts, err := blobArchiveOptions(c)
+			if err != nil {
+				return err
+			}
+			bno = append(bno, archiveOpts...)
+		}
 		node.BackfillOpts = bno
 		return nil
 	}
 	return []node.Option{opt}, nil
 }
+
+// blobArchiveOptions configures backfill to download blobs for the full history since the deneb fork.
+// Blocks need to be backfilled to the deneb fork as well, unless the user has specified a different oldest slot.
+func blobArchiveOptions(c *cli.Context) ([]backfill.ServiceOption, error) {
+	if !c.IsSet(flags.BackfillBlobArchiveURL.Name) {
+		return nil, fmt.Errorf("--%s requires --%s, since peers are not required to serve blobs older than the retention period",
+			flags.BackfillBlobArchive.Name, flags.BackfillBlobArchiveURL.Name)
+	}
+	src, err := backfill.NewAPIBlobSidecarSource(c.String(flags.BackfillBlobArchiveURL.Name))
+	if err != nil {
+		return nil, err
+	}
+	opts := []backfill.ServiceOption{backfill.WithBlobArchive(src)}
+	if c.IsSet(flags.BackfillOldestSlot.Name) {
+		return opts, nil
+	}
+	denebStart, err := slots.EpochStart(params.BeaconConfig().DenebForkEpoch)
+	if err != nil {
+		// The deneb fork is not scheduled, so there are no blobs to archive.
+		return opts, nil
+	}
+	return append(opts, backfill.WithMinimumSlot(denebStart)), nil
+}
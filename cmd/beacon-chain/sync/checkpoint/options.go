@@ -22,12 +22,21 @@ var (
 		Usage: "Rather than syncing from genesis, you can start processing from a ssz-serialized BeaconState+Block." +
 			" This flag allows you to specify a local file containing the checkpoint Block to load.",
 	}
-	RemoteURL = &cli.StringFlag{
+	// RemoteURL specifies one or more beacon node api urls to obtain checkpoint sync data from.
+	RemoteURL = &cli.StringSliceFlag{
 		Name: "checkpoint-sync-url",
 		Usage: "URL of a synced beacon node to trust in obtaining checkpoint sync data. " +
+			"May be repeated (or comma-separated) to download checkpoint sync data from several providers, " +
+			"in which case the node will only start if --checkpoint-sync-quorum of them agree on the finalized checkpoint. " +
 			"As an additional safety measure, it is strongly recommended to only use this option in conjunction with " +
 			"--weak-subjectivity-checkpoint flag",
 	}
+	// Quorum specifies how many of the --checkpoint-sync-url providers must agree on the finalized checkpoint.
+	Quorum = &cli.IntFlag{
+		Name: "checkpoint-sync-quorum",
+		Usage: "Minimum number of --checkpoint-sync-url providers that must agree on the finalized block root and state root " +
+			"before the node will start from the downloaded checkpoint. Defaults to all providers.",
+	}
 )
 
 // BeaconNodeOptions is responsible for determining if the checkpoint sync options have been used, and if so,
@@ -36,11 +45,15 @@ var (
 func BeaconNodeOptions(c *cli.Context) ([]node.Option, error) {
 	blockPath := c.Path(BlockPath.Name)
 	statePath := c.Path(StatePath.Name)
-	remoteURL := c.String(RemoteURL.Name)
-	if remoteURL != "" {
+	remoteURLs := c.StringSlice(RemoteURL.Name)
+	if len(remoteURLs) > 0 {
+		quorum := c.Int(Quorum.Name)
+		if quorum < 0 || quorum > len(remoteURLs) {
+			return nil, fmt.Errorf("--%s=%d must be between 1 and the number of --%s values (%d)", Quorum.Name, quorum, RemoteURL.Name, len(remoteURLs))
+		}
 		opt := func(node *node.BeaconNode) error {
 			var err error
-			node.CheckpointInitializer, err = checkpoint.NewAPIInitializer(remoteURL)
+			node.CheckpointInitializer, err = checkpoint.NewQuorumAPIInitializer(remoteURLs, quorum)
 			if err != nil {
 				return errors.Wrap(err, "error while constructing beacon node api client for checkpoint sync")
 			}
@@ -106,16 +106,39 @@ var appHelpFlagGroups = []flagGroup{
 			flags.HTTPServerHost,
 			flags.HTTPServerPort,
 			flags.HTTPServerCorsDomain,
+			flags.HTTPAccessConfig,
+			flags.HTTPAuditLogFile,
 			flags.ExecutionEngineEndpoint,
 			flags.ExecutionEngineHeaders,
 			flags.ExecutionJWTSecretFlag,
+			flags.SecondaryExecutionEngineEndpoints,
+			flags.SecondaryExecutionJWTSecretFlag,
+			flags.ExecutionFailoverTimeout,
+			flags.VerificationExecutionEngineEndpoint,
+			flags.VerificationExecutionJWTSecretFlag,
+			flags.ExecutionDivergenceDir,
 			flags.SetGCPercent,
 			flags.SlotsPerArchivedPoint,
+			flags.ForkchoiceSnapshotInterval,
+			flags.ForkchoiceRecordFile,
+			flags.ReorgHistoryWindow,
+			flags.SlashingEvidenceRetention,
+			flags.AttestationInclusionEpochs,
+			flags.EventReplayDepth,
+			flags.EventReplayFile,
+			flags.ValidatorHistoryMaxEpochs,
+			flags.GraphQL,
+			flags.GraphQLMaxDepth,
+			flags.GraphQLMaxCost,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
 			flags.BlobBatchLimit,
 			flags.BlobBatchLimitBurstFactor,
 			flags.DisableDebugRPCEndpoints,
+			flags.GossipCaptureDir,
+			flags.GossipCaptureTopics,
+			flags.GossipCaptureMaxFileSize,
+			flags.GossipCaptureMaxFiles,
 			flags.SubscribeToAllSubnets,
 			flags.HistoricalSlasherNode,
 			flags.ChainID,
@@ -129,6 +152,7 @@ var appHelpFlagGroups = []flagGroup{
 			flags.MaxBuilderConsecutiveMissedSlots,
 			flags.EngineEndpointTimeoutSeconds,
 			flags.SlasherDirFlag,
+			flags.SlasherRescanEpochs,
 			flags.LocalBlockValueBoost,
 			flags.MinBuilderBid,
 			flags.MinBuilderDiff,
@@ -136,14 +160,18 @@ var appHelpFlagGroups = []flagGroup{
 			checkpoint.BlockPath,
 			checkpoint.StatePath,
 			checkpoint.RemoteURL,
+			checkpoint.Quorum,
 			genesis.StatePath,
 			genesis.BeaconAPIURL,
 			storage.BlobStoragePathFlag,
 			storage.BlobRetentionEpochFlag,
+			storage.BlobArchiveFlag,
 			backfill.EnableExperimentalBackfill,
 			backfill.BackfillWorkerCount,
 			backfill.BackfillBatchSize,
 			backfill.BackfillOldestSlot,
+			backfill.BackfillBlobArchive,
+			backfill.BackfillBlobArchiveURL,
 		},
 	},
 	{
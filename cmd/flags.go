@@ -267,6 +267,11 @@ var (
 		Usage: "Target directory of the restored database",
 		Value: DefaultDataDir(),
 	}
+	// EraDirFlag specifies the directory containing the era files to import into the database.
+	EraDirFlag = &cli.StringFlag{
+		Name:  "era-dir",
+		Usage: "Directory containing the era files to import into the database",
+	}
 	// ApiTimeoutFlag specifies the timeout value for API requests in seconds. A timeout of zero means no timeout.
 	ApiTimeoutFlag = &cli.DurationFlag{
 		Name:  "api-timeout",
@@ -284,13 +289,43 @@ var (
 // LoadFlagsFromConfig sets flags values from config file if ConfigFileFlag is set.
 func LoadFlagsFromConfig(cliCtx *cli.Context, flags []cli.Flag) error {
 	if cliCtx.IsSet(ConfigFileFlag.Name) {
-		if err := altsrc.InitInputSourceWithContext(flags, altsrc.NewYamlSourceFromFlagFunc(ConfigFileFlag.Name))(cliCtx); err != nil {
+		if err := altsrc.InitInputSourceWithContext(flags, yamlSourceFromFlag(ConfigFileFlag.Name))(cliCtx); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// yamlSourceFromFlag creates a yaml input source from the file given by the flag, which accepts a single string
+// for string slice flags so that flags turned from a string into a string slice keep working with existing config
+// files.
+func yamlSourceFromFlag(flagName string) func(cliCtx *cli.Context) (altsrc.InputSourceContext, error) {
+	return func(cliCtx *cli.Context) (altsrc.InputSourceContext, error) {
+		isc, err := altsrc.NewYamlSourceFromFlagFunc(flagName)(cliCtx)
+		if err != nil {
+			return nil, err
+		}
+		return &scalarSliceInputSource{InputSourceContext: isc}, nil
+	}
+}
+
+type scalarSliceInputSource struct {
+	altsrc.InputSourceContext
+}
+
+// StringSlice returns the string slice value of the flag, or a slice of the single string value of the flag.
+func (s *scalarSliceInputSource) StringSlice(name string) ([]string, error) {
+	values, err := s.InputSourceContext.StringSlice(name)
+	if err != nil {
+		value, strErr := s.InputSourceContext.String(name)
+		if strErr != nil {
+			return nil, err
+		}
+		return []string{value}, nil
+	}
+	return values, nil
+}
+
 // ValidateNoArgs insures that the application is not run with erroneous arguments or flags.
 // This function should be used in the app.Before, whenever the application supports a default command.
 func ValidateNoArgs(ctx *cli.Context) error {
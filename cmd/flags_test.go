@@ -3,6 +3,7 @@ package cmd
 import (
 	"flag"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
@@ -41,6 +42,48 @@ func TestLoadFlagsFromConfig(t *testing.T) {
 	require.NoError(t, os.Remove("flags_test.yaml"))
 }
 
+func TestLoadFlagsFromConfig_StringSlice(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   []string
+	}{
+		{name: "scalar", config: "testflag: http://a", want: []string{"http://a"}},
+		{name: "list", config: "testflag:\n  - http://a\n  - http://b", want: []string{"http://a", "http://b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := cli.App{}
+			set := flag.NewFlagSet("test", 0)
+			context := cli.NewContext(&app, set, nil)
+
+			configPath := filepath.Join(t.TempDir(), "flags_test.yaml")
+			require.NoError(t, os.WriteFile(configPath, []byte(tt.config), 0666))
+			require.NoError(t, set.Parse([]string{"test-command", "--" + ConfigFileFlag.Name, configPath}))
+			comFlags := WrapFlags([]cli.Flag{
+				&cli.StringFlag{
+					Name: ConfigFileFlag.Name,
+				},
+				&cli.StringSliceFlag{
+					Name: "testflag",
+				},
+			})
+			command := &cli.Command{
+				Name:  "test-command",
+				Flags: comFlags,
+				Before: func(cliCtx *cli.Context) error {
+					return LoadFlagsFromConfig(cliCtx, comFlags)
+				},
+				Action: func(cliCtx *cli.Context) error {
+					require.DeepEqual(t, tt.want, cliCtx.StringSlice("testflag"))
+					return nil
+				},
+			}
+			require.NoError(t, command.Run(context, context.Args().Slice()...))
+		})
+	}
+}
+
 func TestValidateNoArgs(t *testing.T) {
 	app := &cli.App{
 		Before: ValidateNoArgs,
@@ -0,0 +1,309 @@
+package blobs
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	errUnexpectedSidecarCount = errors.New("number of blob sidecars does not match the block commitments")
+	errSidecarMismatch        = errors.New("blob sidecar does not match the stored block")
+)
+
+// backfillBatchSize is the number of slots read from the database at once.
+const backfillBatchSize = 32
+
+var backfillFlags = struct {
+	DataDir         string
+	BlobPath        string
+	BeaconNodeHost  string
+	Timeout         time.Duration
+	StartSlot       uint64
+	EndSlot         uint64
+	ChainConfigFile string
+}{}
+
+var backfillCmd = &cli.Command{
+	Name: "backfill",
+	Usage: "Download the blob sidecars for blocks in the beacon node database from a beacon API endpoint, in reverse slot order. " +
+		"Blobs are verified against the stored blocks and saved to blob storage without being subject to the retention period. " +
+		"The beacon node must be stopped while this command runs, and should be started with --blob-archive to retain the blobs.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := cliActionBackfill(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not backfill blobs")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "datadir",
+			Usage:       "data directory of the beacon node",
+			Destination: &backfillFlags.DataDir,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "blob-path",
+			Usage:       "location of the beacon node blob storage. default: 'blobs' directory in datadir",
+			Destination: &backfillFlags.BlobPath,
+		},
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port of a beacon node API with the blob history",
+			Destination: &backfillFlags.BeaconNodeHost,
+			Value:       "localhost:3500",
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to beacon-node-host (uses duration format, ex: 2m31s). default: 1m",
+			Destination: &backfillFlags.Timeout,
+			Value:       time.Minute,
+		},
+		&cli.Uint64Flag{
+			Name:        "start-slot",
+			Usage:       "lowest slot to backfill blobs for. default: deneb fork slot",
+			Destination: &backfillFlags.StartSlot,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-slot",
+			Usage:       "highest slot to backfill blobs for, where the backfill begins. default: finalized slot in the database",
+			Destination: &backfillFlags.EndSlot,
+		},
+		&cli.StringFlag{
+			Name:        cmd.ChainConfigFileFlag.Name,
+			Usage:       cmd.ChainConfigFileFlag.Usage,
+			Destination: &backfillFlags.ChainConfigFile,
+		},
+	},
+}
+
+func cliActionBackfill(cliCtx *cli.Context) error {
+	ctx := context.Background()
+	f := backfillFlags
+	if f.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(f.ChainConfigFile, nil); err != nil {
+			return err
+		}
+	}
+	if err := kzg.Start(); err != nil {
+		return errors.Wrap(err, "could not initialize kzg trusted setup")
+	}
+
+	db, err := kv.NewKVStore(ctx, path.Join(f.DataDir, kv.BeaconNodeDbDirName))
+	if err != nil {
+		return errors.Wrap(err, "could not open beacon node database")
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close beacon node database")
+		}
+	}()
+	blobPath := f.BlobPath
+	if blobPath == "" {
+		blobPath = path.Join(f.DataDir, "blobs")
+	}
+	store, err := filesystem.NewBlobStorage(filesystem.WithBasePath(blobPath), filesystem.WithArchiveMode(true))
+	if err != nil {
+		return err
+	}
+	c, err := beacon.NewClient(f.BeaconNodeHost, client.WithTimeout(f.Timeout))
+	if err != nil {
+		return err
+	}
+
+	start := primitives.Slot(f.StartSlot)
+	if !cliCtx.IsSet("start-slot") {
+		start, err = slots.EpochStart(params.BeaconConfig().DenebForkEpoch)
+		if err != nil {
+			return errors.Wrap(err, "could not determine deneb fork slot")
+		}
+	}
+	end := primitives.Slot(f.EndSlot)
+	if !cliCtx.IsSet("end-slot") {
+		cp, err := db.FinalizedCheckpoint(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not read finalized checkpoint from database")
+		}
+		end, err = slots.EpochStart(cp.Epoch)
+		if err != nil {
+			return err
+		}
+	}
+
+	bf := &blobBackfiller{db: db, store: store, client: c, verifyKZG: kzg.Verify}
+	sum, err := bf.run(ctx, start, end)
+	log.WithFields(log.Fields{
+		"blocks":   sum.blocks,
+		"skipped":  sum.skipped,
+		"sidecars": sum.sidecars,
+		"lowest":   sum.lowest,
+	}).Info("Blob backfill finished")
+	return err
+}
+
+// sidecarClient is the subset of the beacon API client used to download blob sidecars.
+type sidecarClient interface {
+	GetBlobSidecars(ctx context.Context, blockId beacon.StateOrBlockId) ([]*ethpb.BlobSidecar, error)
+}
+
+// blockReader is the subset of the beacon node database used to find the blocks to backfill blobs for.
+type blockReader interface {
+	Blocks(ctx context.Context, f *filters.QueryFilter) ([]interfaces.ReadOnlySignedBeaconBlock, [][32]byte, error)
+	IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
+}
+
+type blobBackfiller struct {
+	db        blockReader
+	store     *filesystem.BlobStorage
+	client    sidecarClient
+	verifyKZG func(...blocks.ROBlob) error
+}
+
+type backfillSummary struct {
+	blocks   int
+	skipped  int
+	sidecars int
+	lowest   primitives.Slot
+}
+
+// run backfills blobs for the finalized blocks in the [start, end] slot range, starting from end and going backwards,
+// so that an interrupted run leaves a contiguous range of blobs behind the head.
+func (b *blobBackfiller) run(ctx context.Context, start, end primitives.Slot) (*backfillSummary, error) {
+	sum := &backfillSummary{lowest: end}
+	if end < start {
+		return sum, nil
+	}
+	for high := end; ; {
+		low := start
+		if high-start >= backfillBatchSize {
+			low = high - backfillBatchSize + 1
+		}
+		blks, roots, err := b.db.Blocks(ctx, filters.NewFilter().SetStartSlot(low).SetEndSlot(high))
+		if err != nil {
+			return sum, errors.Wrapf(err, "could not read blocks for slots [%d, %d]", low, high)
+		}
+		for i := len(blks) - 1; i >= 0; i-- {
+			if ctx.Err() != nil {
+				return sum, ctx.Err()
+			}
+			if !b.db.IsFinalizedBlock(ctx, roots[i]) {
+				continue
+			}
+			blk, err := blocks.NewROBlockWithRoot(blks[i], roots[i])
+			if err != nil {
+				return sum, err
+			}
+			n, err := b.backfillBlock(ctx, blk)
+			if err != nil {
+				return sum, err
+			}
+			if n == 0 {
+				sum.skipped++
+			} else {
+				sum.blocks++
+				sum.sidecars += n
+			}
+		}
+		sum.lowest = low
+		log.WithFields(log.Fields{"slot": low, "blocks": sum.blocks, "sidecars": sum.sidecars}).Info("Backfilled blobs")
+		if low == start {
+			return sum, nil
+		}
+		high = low - 1
+	}
+}
+
+// backfillBlock downloads, verifies and saves the blob sidecars for a single block, if they are not already stored.
+// It returns the number of sidecars saved.
+func (b *blobBackfiller) backfillBlock(ctx context.Context, blk blocks.ROBlock) (int, error) {
+	if blk.Version() < version.Deneb {
+		return 0, nil
+	}
+	commitments, err := blk.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not read commitments from block %#x", blk.Root())
+	}
+	if len(commitments) == 0 {
+		return 0, nil
+	}
+	stored, err := b.store.Indices(blk.Root(), blk.Block().Slot())
+	if err != nil {
+		return 0, err
+	}
+	complete := true
+	for i := range commitments {
+		complete = complete && stored[i]
+	}
+	if complete {
+		return 0, nil
+	}
+	scs, err := b.client.GetBlobSidecars(ctx, beacon.IdFromRoot(blk.Root()))
+	if err != nil {
+		return 0, err
+	}
+	vscs, err := b.verify(blk, commitments, scs)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not verify blob sidecars for block %#x at slot %d", blk.Root(), blk.Block().Slot())
+	}
+	for i := range vscs {
+		if err := b.store.Save(vscs[i]); err != nil {
+			return 0, err
+		}
+	}
+	return len(vscs), nil
+}
+
+// verify checks that the blob sidecars correspond, in order, to the commitments of the stored block, and verifies
+// their inclusion and kzg proofs. The stored block is trusted, so the proposer signature does not need to be checked.
+func (b *blobBackfiller) verify(blk blocks.ROBlock, commitments [][]byte, scs []*ethpb.BlobSidecar) ([]blocks.VerifiedROBlob, error) {
+	if len(scs) != len(commitments) {
+		return nil, errors.Wrapf(errUnexpectedSidecarCount, "commitments=%d, sidecars=%d", len(commitments), len(scs))
+	}
+	robs := make([]blocks.ROBlob, len(scs))
+	for i := range scs {
+		rob, err := blocks.NewROBlob(scs[i])
+		if err != nil {
+			return nil, err
+		}
+		if rob.BlockRoot() != blk.Root() {
+			return nil, errors.Wrapf(errSidecarMismatch, "sidecar %d block root=%#x", i, rob.BlockRoot())
+		}
+		if rob.Index != uint64(i) {
+			return nil, errors.Wrapf(errSidecarMismatch, "sidecar %d has index %d", i, rob.Index)
+		}
+		if !bytes.Equal(rob.KzgCommitment, commitments[i]) {
+			return nil, errors.Wrapf(errSidecarMismatch, "sidecar %d commitment=%#x, block commitment=%#x", i, rob.KzgCommitment, commitments[i])
+		}
+		if err := blocks.VerifyKZGInclusionProof(rob); err != nil {
+			return nil, errors.Wrapf(err, "sidecar %d", i)
+		}
+		robs[i] = rob
+	}
+	if err := b.verifyKZG(robs...); err != nil {
+		return nil, errors.Wrap(err, "kzg proof verification failed")
+	}
+	vscs := make([]blocks.VerifiedROBlob, len(robs))
+	for i := range robs {
+		vscs[i] = blocks.NewVerifiedROBlob(robs[i])
+	}
+	return vscs, nil
+}
@@ -0,0 +1,135 @@
+package blobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"google.golang.org/protobuf/proto"
+)
+
+type mockBlockReader struct {
+	blocks    []blocks.ROBlock
+	finalized map[[32]byte]bool
+}
+
+func (m *mockBlockReader) Blocks(_ context.Context, f *filters.QueryFilter) ([]interfaces.ReadOnlySignedBeaconBlock, [][32]byte, error) {
+	start, end := f.Filters()[filters.StartSlot].(primitives.Slot), f.Filters()[filters.EndSlot].(primitives.Slot)
+	var blks []interfaces.ReadOnlySignedBeaconBlock
+	var roots [][32]byte
+	for _, b := range m.blocks {
+		if b.Block().Slot() >= start && b.Block().Slot() <= end {
+			blks = append(blks, b.ReadOnlySignedBeaconBlock)
+			roots = append(roots, b.Root())
+		}
+	}
+	return blks, roots, nil
+}
+
+func (m *mockBlockReader) IsFinalizedBlock(_ context.Context, root [32]byte) bool {
+	return m.finalized[root]
+}
+
+type mockSidecarClient struct {
+	sidecars map[beacon.StateOrBlockId][]*ethpb.BlobSidecar
+	requests int
+}
+
+func (m *mockSidecarClient) GetBlobSidecars(_ context.Context, id beacon.StateOrBlockId) ([]*ethpb.BlobSidecar, error) {
+	m.requests++
+	scs, ok := m.sidecars[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return scs, nil
+}
+
+func noopKZG(...blocks.ROBlob) error {
+	return nil
+}
+
+func TestBlobBackfiller_run(t *testing.T) {
+	ctx := context.Background()
+	reader := &mockBlockReader{finalized: make(map[[32]byte]bool)}
+	c := &mockSidecarClient{sidecars: make(map[beacon.StateOrBlockId][]*ethpb.BlobSidecar)}
+	for slot := primitives.Slot(1); slot <= 40; slot++ {
+		blk, scs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, slot, 2)
+		reader.blocks = append(reader.blocks, blk)
+		// The block at slot 10 is not finalized, and its blobs are not available.
+		if slot == 10 {
+			continue
+		}
+		reader.finalized[blk.Root()] = true
+		for i := range scs {
+			c.sidecars[beacon.IdFromRoot(blk.Root())] = append(c.sidecars[beacon.IdFromRoot(blk.Root())], scs[i].BlobSidecar)
+		}
+	}
+	store := filesystem.NewEphemeralBlobStorage(t)
+	bf := &blobBackfiller{db: reader, store: store, client: c, verifyKZG: noopKZG}
+
+	sum, err := bf.run(ctx, 5, 40)
+	require.NoError(t, err)
+	require.Equal(t, 35, sum.blocks)
+	require.Equal(t, 70, sum.sidecars)
+	require.Equal(t, primitives.Slot(5), sum.lowest)
+	for _, blk := range reader.blocks {
+		idx, err := store.Indices(blk.Root(), blk.Block().Slot())
+		require.NoError(t, err)
+		saved := blk.Block().Slot() >= 5 && blk.Block().Slot() != 10
+		require.Equal(t, saved, idx[0])
+		require.Equal(t, saved, idx[1])
+	}
+
+	// Blocks with all blobs already stored are skipped without requesting them.
+	requests := c.requests
+	sum, err = bf.run(ctx, 1, 40)
+	require.NoError(t, err)
+	require.Equal(t, 4, sum.blocks)
+	require.Equal(t, 35, sum.skipped)
+	require.Equal(t, requests+4, c.requests)
+}
+
+func TestBlobBackfiller_verify(t *testing.T) {
+	blk, scs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 2)
+	commitments, err := blk.Block().Body().BlobKzgCommitments()
+	require.NoError(t, err)
+	sidecars := func() []*ethpb.BlobSidecar {
+		s := make([]*ethpb.BlobSidecar, len(scs))
+		for i := range scs {
+			s[i] = proto.Clone(scs[i].BlobSidecar).(*ethpb.BlobSidecar)
+		}
+		return s
+	}
+	bf := &blobBackfiller{verifyKZG: noopKZG}
+
+	vscs, err := bf.verify(blk, commitments, sidecars())
+	require.NoError(t, err)
+	require.Equal(t, 2, len(vscs))
+
+	_, err = bf.verify(blk, commitments, sidecars()[:1])
+	require.ErrorIs(t, err, errUnexpectedSidecarCount)
+
+	swapped := sidecars()
+	swapped[0], swapped[1] = swapped[1], swapped[0]
+	_, err = bf.verify(blk, commitments, swapped)
+	require.ErrorIs(t, err, errSidecarMismatch)
+
+	other, otherScs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 2, 2)
+	require.NotEqual(t, blk.Root(), other.Root())
+	_, err = bf.verify(blk, commitments, []*ethpb.BlobSidecar{otherScs[0].BlobSidecar, otherScs[1].BlobSidecar})
+	require.ErrorIs(t, err, errSidecarMismatch)
+
+	badKZG := errors.New("bad proof")
+	bf.verifyKZG = func(...blocks.ROBlob) error { return badKZG }
+	_, err = bf.verify(blk, commitments, sidecars())
+	require.ErrorIs(t, err, badKZG)
+}
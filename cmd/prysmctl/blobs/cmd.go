@@ -0,0 +1,13 @@
+package blobs
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "blobs",
+		Usage: "commands for managing the blob storage of a beacon node",
+		Subcommands: []*cli.Command{
+			backfillCmd,
+		},
+	},
+}
@@ -12,8 +12,9 @@ import (
 )
 
 var downloadFlags = struct {
-	BeaconNodeHost string
-	Timeout        time.Duration
+	BeaconNodeHosts cli.StringSlice
+	Quorum          int
+	Timeout         time.Duration
 }{}
 
 var downloadCmd = &cli.Command{
@@ -27,11 +28,16 @@ var downloadCmd = &cli.Command{
 		return nil
 	},
 	Flags: []cli.Flag{
-		&cli.StringFlag{
+		&cli.StringSliceFlag{
 			Name:        "beacon-node-host",
-			Usage:       "host:port for beacon node connection",
-			Destination: &downloadFlags.BeaconNodeHost,
-			Value:       "localhost:3500",
+			Usage:       "host:port for beacon node connection. May be repeated to download from several providers and compare their checkpoints",
+			Destination: &downloadFlags.BeaconNodeHosts,
+			Value:       cli.NewStringSlice("localhost:3500"),
+		},
+		&cli.IntFlag{
+			Name:        "quorum",
+			Usage:       "minimum number of beacon-node-host providers that must agree on the finalized block root and state root. default: all providers",
+			Destination: &downloadFlags.Quorum,
 		},
 		&cli.DurationFlag{
 			Name:        "http-timeout",
@@ -47,9 +53,14 @@ func cliActionDownload(_ *cli.Context) error {
 	f := downloadFlags
 
 	opts := []client.ClientOpt{client.WithTimeout(f.Timeout), client.WithMaxBodySize(client.MaxBodySizeState)}
-	client, err := beacon.NewClient(downloadFlags.BeaconNodeHost, opts...)
-	if err != nil {
-		return err
+	hosts := f.BeaconNodeHosts.Value()
+	clients := make([]*beacon.Client, len(hosts))
+	for i, h := range hosts {
+		c, err := beacon.NewClient(h, opts...)
+		if err != nil {
+			return err
+		}
+		clients[i] = c
 	}
 
 	cwd, err := os.Getwd()
@@ -57,10 +68,11 @@ func cliActionDownload(_ *cli.Context) error {
 		return err
 	}
 
-	od, err := beacon.DownloadFinalizedData(ctx, client)
+	od, report, err := beacon.DownloadFinalizedDataWithQuorum(ctx, clients, f.Quorum)
 	if err != nil {
 		return err
 	}
+	log.Printf("checkpoint confirmed by providers:\n%s", report)
 
 	blockPath, err := od.SaveBlock(cwd)
 	if err != nil {
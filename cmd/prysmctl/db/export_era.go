@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/era"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var exportEraFlags = struct {
+	DataDir         string
+	OutputDir       string
+	StartEra        uint64
+	EndEra          uint64
+	ChainConfigFile string
+}{}
+
+var exportEraCmd = &cli.Command{
+	Name: "export-era",
+	Usage: "Export finalized blocks and states from the beacon node database to era files. " +
+		"The beacon node must be stopped while this command runs. Eras after Bellatrix can only be exported from a " +
+		"database created with --save-full-execution-payloads, since era files hold full blocks.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := exportEraAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not export era files")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "datadir",
+			Usage:       "data directory of the beacon node",
+			Destination: &exportEraFlags.DataDir,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "output-dir",
+			Usage:       "directory where the era files are written",
+			Destination: &exportEraFlags.OutputDir,
+			Required:    true,
+		},
+		&cli.Uint64Flag{
+			Name:        "start-era",
+			Usage:       "first era to export. default: 0, the genesis era",
+			Destination: &exportEraFlags.StartEra,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-era",
+			Usage:       "last era to export. default: the last finalized era",
+			Destination: &exportEraFlags.EndEra,
+		},
+		&cli.StringFlag{
+			Name:        cmd.ChainConfigFileFlag.Name,
+			Usage:       cmd.ChainConfigFileFlag.Usage,
+			Destination: &exportEraFlags.ChainConfigFile,
+		},
+	},
+}
+
+func exportEraAction(cliCtx *cli.Context) error {
+	ctx := context.Background()
+	f := exportEraFlags
+	if f.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(f.ChainConfigFile, nil); err != nil {
+			return err
+		}
+	}
+	db, err := kv.NewKVStore(ctx, path.Join(f.DataDir, kv.BeaconNodeDbDirName))
+	if err != nil {
+		return errors.Wrap(err, "could not open beacon node database")
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close beacon node database")
+		}
+	}()
+	end := era.Era(f.EndEra)
+	if !cliCtx.IsSet("end-era") {
+		end, err = era.LastFinalizedEra(ctx, db)
+		if err != nil {
+			return err
+		}
+	}
+	start := era.Era(f.StartEra)
+	if start > end {
+		return errors.Errorf("start-era %d is after end-era %d", start, end)
+	}
+	paths, err := era.Export(ctx, db, f.OutputDir, start, end)
+	if err != nil {
+		return err
+	}
+	log.WithField("files", len(paths)).WithField("outputDir", f.OutputDir).Info("Finished exporting era files")
+	return nil
+}
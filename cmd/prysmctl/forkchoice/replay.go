@@ -0,0 +1,144 @@
+package forkchoice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var replayFlags = struct {
+	File            string
+	ChainConfigFile string
+}{}
+
+var replayCmd = &cli.Command{
+	Name: "replay",
+	Usage: "Replay the fork choice inputs recorded with --forkchoice-record-file into a new fork choice store, and " +
+		"print the head at each slot, the weights of the branches involved in every head change, the late block " +
+		"reorg decisions, and the decisions that differ from the recorded ones.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := replayAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not replay fork choice recording")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "file",
+			Usage:       "fork choice recording written by the beacon node",
+			Destination: &replayFlags.File,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        cmd.ChainConfigFileFlag.Name,
+			Usage:       cmd.ChainConfigFileFlag.Usage,
+			Destination: &replayFlags.ChainConfigFile,
+		},
+	},
+}
+
+func replayAction(cliCtx *cli.Context) error {
+	if replayFlags.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(replayFlags.ChainConfigFile, nil); err != nil {
+			return err
+		}
+	}
+	f, err := os.Open(replayFlags.File) // #nosec G304
+	if err != nil {
+		return errors.Wrap(err, "could not open fork choice recording")
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close fork choice recording")
+		}
+	}()
+	return replay(cliCtx.Context, f, cliCtx.App.Writer)
+}
+
+// replay replays a fork choice recording and writes the report to w.
+func replay(ctx context.Context, r io.Reader, w io.Writer) error {
+	rep := &replayReport{w: w}
+	if err := doublylinkedtree.Replay(ctx, r, rep.decision); err != nil {
+		return err
+	}
+	rep.finish()
+	return nil
+}
+
+// replayReport prints the decisions of a replayed fork choice.
+type replayReport struct {
+	w        io.Writer
+	seen     bool
+	slot     primitives.Slot
+	head     [32]byte
+	heads    int
+	diverged int
+}
+
+func (r *replayReport) decision(d *doublylinkedtree.ReplayedDecision) error {
+	if d.Diverged() {
+		r.diverged++
+	}
+	if d.Kind != doublylinkedtree.DecisionHead {
+		r.lateBlockDecision(d)
+		return nil
+	}
+	if r.seen && d.Slot != r.slot {
+		r.printSlot()
+	}
+	r.seen, r.slot = true, d.Slot
+	r.heads++
+	if d.Err != nil {
+		r.printf("slot %d: could not compute head: %v\n", d.Slot, d.Err)
+		return nil
+	}
+	r.head = d.Root
+	if d.Root != d.RecordedRoot {
+		r.printf("slot %d: replayed head %#x differs from recorded head %#x\n", d.Slot, d.Root, d.RecordedRoot)
+	}
+	if d.Root == d.Head || len(d.WeightChanges) == 0 {
+		return nil
+	}
+	r.printf("slot %d: head changed from %#x to %#x at %s\n", d.Slot, d.Head, d.Root, d.Time.UTC().Format("15:04:05.000"))
+	for _, c := range d.WeightChanges {
+		r.printf("  %#x slot %d weight %d -> %d (%+d)\n", c.Root, c.Slot, c.Before, c.After, int64(c.After)-int64(c.Before))
+	}
+	return nil
+}
+
+func (r *replayReport) lateBlockDecision(d *doublylinkedtree.ReplayedDecision) {
+	switch {
+	case d.Err != nil:
+		r.printf("slot %d: %s for head %#x: %v\n", d.Slot, d.Kind, d.Head, d.Err)
+	case d.Kind == doublylinkedtree.DecisionOverrideFCU && (d.Override || d.RecordedOverride):
+		r.printf("slot %d: %s for head %#x: replayed %t, recorded %t\n", d.Slot, d.Kind, d.Head, d.Override, d.RecordedOverride)
+	case d.Kind == doublylinkedtree.DecisionProposerHead && (d.Root != d.Head || d.Diverged()):
+		r.printf("slot %d: %s for head %#x: replayed %#x, recorded %#x\n", d.Slot, d.Kind, d.Head, d.Root, d.RecordedRoot)
+	}
+}
+
+func (r *replayReport) printSlot() {
+	r.printf("slot %d: head %#x\n", r.slot, r.head)
+}
+
+func (r *replayReport) finish() {
+	if r.seen {
+		r.printSlot()
+	}
+	r.printf("replayed %d head computations, %d decisions differ from the recording\n", r.heads, r.diverged)
+}
+
+func (r *replayReport) printf(format string, args ...interface{}) {
+	if _, err := fmt.Fprintf(r.w, format, args...); err != nil {
+		log.WithError(err).Error("Could not write replay report")
+	}
+}
@@ -3,9 +3,13 @@ package main
 import (
 	"os"
 
+	"github.com/prysmaticlabs/prysm/v5/cmd/prysmctl/blobs"
 	"github.com/prysmaticlabs/prysm/v5/cmd/prysmctl/checkpointsync"
 	"github.com/prysmaticlabs/prysm/v5/cmd/prysmctl/db"
+	"github.com/prysmaticlabs/prysm/v5/cmd/prysmctl/forkchoice"
 	"github.com/prysmaticlabs/prysm/v5/cmd/prysmctl/p2p"
+	"github.com/prysmaticlabs/prysm/v5/cmd/prysmctl/slasher"
+	"github.com/prysmaticlabs/prysm/v5/cmd/prysmctl/state"
 	"github.com/prysmaticlabs/prysm/v5/cmd/prysmctl/testnet"
 	"github.com/prysmaticlabs/prysm/v5/cmd/prysmctl/validator"
 	"github.com/prysmaticlabs/prysm/v5/cmd/prysmctl/weaksubjectivity"
@@ -26,9 +30,13 @@ func main() {
 }
 
 func init() {
+	prysmctlCommands = append(prysmctlCommands, blobs.Commands...)
 	prysmctlCommands = append(prysmctlCommands, checkpointsync.Commands...)
 	prysmctlCommands = append(prysmctlCommands, db.Commands...)
+	prysmctlCommands = append(prysmctlCommands, forkchoice.Commands...)
 	prysmctlCommands = append(prysmctlCommands, p2p.Commands...)
+	prysmctlCommands = append(prysmctlCommands, slasher.Commands...)
+	prysmctlCommands = append(prysmctlCommands, state.Commands...)
 	prysmctlCommands = append(prysmctlCommands, testnet.Commands...)
 	prysmctlCommands = append(prysmctlCommands, weaksubjectivity.Commands...)
 	prysmctlCommands = append(prysmctlCommands, validator.Commands...)
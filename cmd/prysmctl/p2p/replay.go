@@ -0,0 +1,452 @@
+package p2p
+
+import (
+	"context"
+	"path"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache/depositsnapshot"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/blstoexec"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/synccommittee"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/encoder"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/capture"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	payloadattribute "github.com/prysmaticlabs/prysm/v5/consensus-types/payload-attribute"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/metadata"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+var replayFlags = struct {
+	Capture         string
+	DataDir         string
+	BlobPath        string
+	Topics          *cli.StringSlice
+	ChainConfigFile string
+}{
+	Topics: cli.NewStringSlice(),
+}
+
+var replayCmd = &cli.Command{
+	Name: "replay",
+	Usage: "Replay gossip messages captured with --gossip-capture-dir through the gossip validators and report " +
+		"the messages whose validation result differs from the captured one. The database is modified by the replay, " +
+		"so --datadir must point to a copy of the beacon node data directory, taken around the start of the capture. " +
+		"Validators use the capture time of each message as the current time, except for the checks based on the " +
+		"local clock, like the gossip clock disparity of blocks, which use the time of the replay.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := replayAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not replay captured gossip messages")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "capture",
+			Usage:       "gossip capture file, or directory containing the capture files",
+			Destination: &replayFlags.Capture,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "datadir",
+			Usage:       "copy of the beacon node data directory to replay the messages against",
+			Destination: &replayFlags.DataDir,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "blob-path",
+			Usage:       "location of the blob storage. default: the blobs directory of --datadir",
+			Destination: &replayFlags.BlobPath,
+		},
+		&cli.StringSliceFlag{
+			Name:        "topics",
+			Usage:       "names of the gossip topics to replay, e.g. beacon_block. default: all topics",
+			Destination: replayFlags.Topics,
+		},
+		&cli.StringFlag{
+			Name:        cmd.ChainConfigFileFlag.Name,
+			Usage:       cmd.ChainConfigFileFlag.Usage,
+			Destination: &replayFlags.ChainConfigFile,
+		},
+	},
+}
+
+type replayTopicSummary struct {
+	replayed int
+	differs  int
+}
+
+func replayAction(_ *cli.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f := replayFlags
+	if f.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(f.ChainConfigFile, nil); err != nil {
+			return err
+		}
+	}
+	records, err := capture.Load(f.Capture)
+	if err != nil {
+		return errors.Wrap(err, "could not load captured gossip messages")
+	}
+
+	db, err := kv.NewKVStore(ctx, path.Join(f.DataDir, kv.BeaconNodeDbDirName))
+	if err != nil {
+		return errors.Wrap(err, "could not open beacon node database")
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close beacon node database")
+		}
+	}()
+	blobPath := f.BlobPath
+	if blobPath == "" {
+		blobPath = path.Join(f.DataDir, "blobs")
+	}
+	bs, err := filesystem.NewBlobStorage(filesystem.WithBasePath(blobPath))
+	if err != nil {
+		return errors.Wrap(err, "could not open blob storage")
+	}
+
+	fc := doublylinkedtree.New()
+	sg := stategen.New(db, fc)
+	cp, err := db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	r := bytesutil.ToBytes32(cp.Root)
+	if r == params.BeaconConfig().ZeroHash {
+		r, err = db.GenesisBlockRoot(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not get genesis block root")
+		}
+	}
+	finalized, err := sg.StateByRoot(ctx, r)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized state")
+	}
+
+	attPool := attestations.NewPool()
+	exitPool := voluntaryexits.NewPool()
+	slashingsPool := slashings.NewPool()
+	blsToExecPool := blstoexec.NewPool()
+	attService, err := attestations.NewService(ctx, &attestations.Config{Pool: attPool})
+	if err != nil {
+		return err
+	}
+	depositCache, err := depositsnapshot.New()
+	if err != nil {
+		return errors.Wrap(err, "could not create deposit cache")
+	}
+	p := newReplayP2P(ctx)
+	feeds := &replayFeeds{}
+	slasherAttestationsFeed := new(event.Feed)
+	chain, err := blockchain.NewService(ctx,
+		blockchain.WithForkChoiceStore(fc),
+		blockchain.WithDatabase(db),
+		blockchain.WithDepositCache(depositCache),
+		blockchain.WithExecutionEngineCaller(replayEngine{}),
+		blockchain.WithAttestationPool(attPool),
+		blockchain.WithExitPool(exitPool),
+		blockchain.WithSlashingPool(slashingsPool),
+		blockchain.WithBLSToExecPool(blsToExecPool),
+		blockchain.WithP2PBroadcaster(p),
+		blockchain.WithStateNotifier(feeds),
+		blockchain.WithAttestationService(attService),
+		blockchain.WithStateGen(sg),
+		blockchain.WithSlasherAttestationsFeed(slasherAttestationsFeed),
+		blockchain.WithFinalizedStateAtStartUp(finalized),
+		blockchain.WithClockSynchronizer(startup.NewClockSynchronizer()),
+		blockchain.WithBlobStorage(bs),
+		blockchain.WithTrackedValidatorsCache(cache.NewTrackedValidatorsCache()),
+		blockchain.WithPayloadIDCache(cache.NewPayloadIDCache()),
+		blockchain.WithSyncChecker(replaySynced{}),
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not create blockchain service")
+	}
+	if err := chain.StartFromSavedState(finalized); err != nil {
+		return errors.Wrap(err, "could not initialize blockchain service")
+	}
+
+	replayer, err := sync.NewReplayer(ctx, chain.GenesisTime(), bytesutil.ToBytes32(finalized.GenesisValidatorsRoot()), fc, sg,
+		sync.WithDatabase(db),
+		sync.WithP2P(p),
+		sync.WithChainService(chain),
+		sync.WithAttestationPool(attPool),
+		sync.WithExitPool(exitPool),
+		sync.WithSlashingPool(slashingsPool),
+		sync.WithSyncCommsPool(synccommittee.NewPool()),
+		sync.WithBlsToExecPool(blsToExecPool),
+		sync.WithStateGen(sg),
+		sync.WithStateNotifier(feeds),
+		sync.WithBlockNotifier(feeds),
+		sync.WithAttestationNotifier(feeds),
+		sync.WithOperationNotifier(feeds),
+		sync.WithSlasherAttestationsFeed(slasherAttestationsFeed),
+		sync.WithSlasherBlockHeadersFeed(new(event.Feed)),
+		sync.WithBlobStorage(bs),
+	)
+	if err != nil {
+		return err
+	}
+	defer replayer.Stop()
+
+	filter := capture.NewTopicFilter(f.Topics.Value())
+	summary := make(map[string]*replayTopicSummary)
+	for i, rec := range records {
+		if !filter.Selects(rec.Topic) {
+			continue
+		}
+		res, err := replayer.Replay(ctx, rec)
+		if err != nil {
+			log.WithError(err).WithField("index", i).Warn("Could not replay captured gossip message")
+			continue
+		}
+		name := capture.TopicName(rec.Topic)
+		s, ok := summary[name]
+		if !ok {
+			s = &replayTopicSummary{}
+			summary[name] = s
+		}
+		s.replayed++
+		if res.HandlerErr != nil {
+			log.WithError(res.HandlerErr).WithField("index", i).WithField("topic", rec.Topic).Warn("Could not handle replayed gossip message")
+		}
+		if !res.Differs() {
+			continue
+		}
+		s.differs++
+		l := log.WithField("index", i).
+			WithField("topic", rec.Topic).
+			WithField("peer", rec.Peer).
+			WithField("capturedAt", rec.Timestamp).
+			WithField("captured", capture.ResultString(res.Captured)).
+			WithField("replayed", capture.ResultString(res.Result))
+		if res.Err != nil {
+			l = l.WithError(res.Err)
+		}
+		l.Warn("Replayed gossip message validation differs from capture")
+	}
+
+	names := make([]string, 0, len(summary))
+	for name := range summary {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		log.WithField("topic", name).
+			WithField("replayed", summary[name].replayed).
+			WithField("differs", summary[name].differs).
+			Info("Finished replaying captured gossip messages")
+	}
+	return nil
+}
+
+// replayFeeds provides the event feeds of the replayed services. Nothing subscribes to them.
+type replayFeeds struct {
+	stateFeed     event.Feed
+	blockFeed     event.Feed
+	operationFeed event.Feed
+}
+
+func (f *replayFeeds) StateFeed() event.SubscriberSender {
+	return &f.stateFeed
+}
+
+func (f *replayFeeds) BlockFeed() *event.Feed {
+	return &f.blockFeed
+}
+
+func (f *replayFeeds) OperationFeed() event.SubscriberSender {
+	return &f.operationFeed
+}
+
+// replayP2P stands in for the p2p service, which is not started during a replay. It implements the whole p2p
+// interface without a network: messages which would be broadcast are dropped, and requests to peers fail.
+type replayP2P struct {
+	peers *peers.Status
+}
+
+var _ p2p.P2P = &replayP2P{}
+
+var errReplayNoNetwork = errors.New("no p2p network during gossip replay")
+
+func newReplayP2P(ctx context.Context) *replayP2P {
+	return &replayP2P{peers: peers.NewStatus(ctx, &peers.StatusConfig{ScorerParams: &scorers.Config{}})}
+}
+
+func (*replayP2P) Encoding() encoder.NetworkEncoding {
+	return &encoder.SszNetworkEncoder{}
+}
+
+func (*replayP2P) PeerID() peer.ID {
+	return "replay"
+}
+
+func (p *replayP2P) Peers() *peers.Status {
+	return p.peers
+}
+
+func (*replayP2P) Broadcast(context.Context, proto.Message) error {
+	return nil
+}
+
+func (*replayP2P) BroadcastAttestation(context.Context, uint64, ethpb.Att) error {
+	return nil
+}
+
+func (*replayP2P) BroadcastSyncCommitteeMessage(context.Context, uint64, *ethpb.SyncCommitteeMessage) error {
+	return nil
+}
+
+func (*replayP2P) BroadcastBlob(context.Context, uint64, *ethpb.BlobSidecar) error {
+	return nil
+}
+
+func (*replayP2P) SetStreamHandler(string, network.StreamHandler) {}
+
+func (*replayP2P) PubSub() *pubsub.PubSub {
+	return nil
+}
+
+func (*replayP2P) JoinTopic(string, ...pubsub.TopicOpt) (*pubsub.Topic, error) {
+	return nil, errReplayNoNetwork
+}
+
+func (*replayP2P) LeaveTopic(string) error {
+	return nil
+}
+
+func (*replayP2P) PublishToTopic(context.Context, string, []byte, ...pubsub.PubOpt) error {
+	return nil
+}
+
+func (*replayP2P) SubscribeToTopic(string, ...pubsub.SubOpt) (*pubsub.Subscription, error) {
+	return nil, errReplayNoNetwork
+}
+
+func (*replayP2P) Send(context.Context, interface{}, string, peer.ID) (network.Stream, error) {
+	return nil, errReplayNoNetwork
+}
+
+func (*replayP2P) Disconnect(peer.ID) error {
+	return nil
+}
+
+func (*replayP2P) Host() host.Host {
+	return nil
+}
+
+func (*replayP2P) ENR() *enr.Record {
+	return nil
+}
+
+func (*replayP2P) DiscoveryAddresses() ([]multiaddr.Multiaddr, error) {
+	return nil, nil
+}
+
+func (*replayP2P) RefreshPersistentSubnets() {}
+
+func (*replayP2P) FindPeersWithSubnet(context.Context, string, uint64, int) (bool, error) {
+	return false, nil
+}
+
+func (*replayP2P) AddPingMethod(func(ctx context.Context, id peer.ID) error) {}
+
+func (*replayP2P) AddConnectionHandler(_, _ func(ctx context.Context, id peer.ID) error) {}
+
+func (*replayP2P) AddDisconnectionHandler(func(ctx context.Context, id peer.ID) error) {}
+
+func (*replayP2P) InterceptPeerDial(peer.ID) bool {
+	return false
+}
+
+func (*replayP2P) InterceptAddrDial(peer.ID, multiaddr.Multiaddr) bool {
+	return false
+}
+
+func (*replayP2P) InterceptAccept(network.ConnMultiaddrs) bool {
+	return false
+}
+
+func (*replayP2P) InterceptSecured(network.Direction, peer.ID, network.ConnMultiaddrs) bool {
+	return false
+}
+
+func (*replayP2P) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return false, 0
+}
+
+func (*replayP2P) Metadata() metadata.Metadata {
+	return nil
+}
+
+func (*replayP2P) MetadataSeq() uint64 {
+	return 0
+}
+
+// replayEngine stands in for the execution client, which is not available during a replay. Payloads are
+// reported as syncing, so that replayed blocks are imported optimistically.
+type replayEngine struct{}
+
+var _ execution.EngineCaller = replayEngine{}
+
+func (replayEngine) NewPayload(context.Context, interfaces.ExecutionData, []common.Hash, *common.Hash, *pb.ExecutionRequests) ([]byte, error) {
+	return nil, execution.ErrAcceptedSyncingPayloadStatus
+}
+
+func (replayEngine) ForkchoiceUpdated(context.Context, *pb.ForkchoiceState, payloadattribute.Attributer) (*pb.PayloadIDBytes, []byte, error) {
+	return nil, nil, execution.ErrAcceptedSyncingPayloadStatus
+}
+
+func (replayEngine) GetPayload(context.Context, [8]byte, primitives.Slot) (*blocks.GetPayloadResponse, error) {
+	return nil, errReplayNoExecutionClient
+}
+
+func (replayEngine) ExecutionBlockByHash(context.Context, common.Hash, bool) (*pb.ExecutionBlock, error) {
+	return nil, errReplayNoExecutionClient
+}
+
+func (replayEngine) GetTerminalBlockHash(context.Context, uint64) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+var errReplayNoExecutionClient = errors.New("no execution client during gossip replay")
+
+// replaySynced reports the node as synced, since captured messages are only validated by synced nodes.
+type replaySynced struct{}
+
+func (replaySynced) Synced() bool { return true }
@@ -0,0 +1,153 @@
+package slasher
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/slasherkv"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/container/slice"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var rescanFlags = struct {
+	DataDir         string
+	SlasherDataDir  string
+	StartEpoch      uint64
+	EndEpoch        uint64
+	ChainConfigFile string
+}{}
+
+var rescanCmd = &cli.Command{
+	Name: "rescan",
+	Usage: "Run slashing detection over the blocks stored in the beacon node database, and the attestations " +
+		"they include, for a range of epochs. The detected slashings are printed and the attestation and " +
+		"proposal history is written to the slasher database. The beacon node must be stopped while this " +
+		"command runs.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := rescanAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not rescan stored blocks")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "datadir",
+			Usage:       "data directory of the beacon node",
+			Destination: &rescanFlags.DataDir,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "slasher-datadir",
+			Usage:       "data directory of the slasher database. default: the data directory of the beacon node",
+			Destination: &rescanFlags.SlasherDataDir,
+		},
+		&cli.Uint64Flag{
+			Name:        "start-epoch",
+			Usage:       "first epoch to rescan. default: 0, capped to the slasher history length",
+			Destination: &rescanFlags.StartEpoch,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-epoch",
+			Usage:       "last epoch to rescan. default: the current epoch",
+			Destination: &rescanFlags.EndEpoch,
+		},
+		&cli.StringFlag{
+			Name:        cmd.ChainConfigFileFlag.Name,
+			Usage:       cmd.ChainConfigFileFlag.Usage,
+			Destination: &rescanFlags.ChainConfigFile,
+		},
+	},
+}
+
+func rescanAction(cliCtx *cli.Context) error {
+	ctx := context.Background()
+	f := rescanFlags
+	if f.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(f.ChainConfigFile, nil); err != nil {
+			return err
+		}
+	}
+	beaconDB, err := kv.NewKVStore(ctx, path.Join(f.DataDir, kv.BeaconNodeDbDirName))
+	if err != nil {
+		return errors.Wrap(err, "could not open beacon node database")
+	}
+	defer func() {
+		if err := beaconDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close beacon node database")
+		}
+	}()
+	slasherDir := f.SlasherDataDir
+	if slasherDir == "" {
+		slasherDir = f.DataDir
+	}
+	slasherDB, err := slasherkv.NewKVStore(ctx, path.Join(slasherDir, kv.BeaconNodeDbDirName))
+	if err != nil {
+		return errors.Wrap(err, "could not open slasher database")
+	}
+	defer func() {
+		if err := slasherDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close slasher database")
+		}
+	}()
+
+	genesisState, err := beaconDB.GenesisState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis state")
+	}
+	if genesisState == nil || genesisState.IsNil() {
+		return errors.New("no genesis state in the beacon node database")
+	}
+	currentEpoch := slots.ToEpoch(slots.CurrentSlot(genesisState.GenesisTime()))
+	end := currentEpoch
+	if cliCtx.IsSet("end-epoch") {
+		end = primitives.Epoch(f.EndEpoch)
+	}
+
+	s, err := slasher.New(ctx, &slasher.ServiceConfig{
+		Database: slasherDB,
+		StateGen: stategen.New(beaconDB, doublylinkedtree.New()),
+	})
+	if err != nil {
+		return err
+	}
+	result, err := s.Rescan(ctx, beaconDB, primitives.Epoch(f.StartEpoch), end, currentEpoch)
+	if err != nil {
+		return err
+	}
+
+	w := cliCtx.App.Writer
+	for _, slashing := range result.ProposerSlashings {
+		h := slashing.Header_1.Header
+		fmt.Fprintf(w, "proposer slashing: validator %d proposed two blocks at slot %d\n", h.ProposerIndex, h.Slot)
+	}
+	for _, slashing := range result.AttesterSlashings {
+		att1, att2 := slashing.FirstAttestation(), slashing.SecondAttestation()
+		fmt.Fprintf(
+			w,
+			"attester slashing: attestations with source %d and target %d, and source %d and target %d, by validators %v\n",
+			att1.GetData().Source.Epoch,
+			att1.GetData().Target.Epoch,
+			att2.GetData().Source.Epoch,
+			att2.GetData().Target.Epoch,
+			slice.IntersectionUint64(att1.GetAttestingIndices(), att2.GetAttestingIndices()),
+		)
+	}
+	log.WithFields(log.Fields{
+		"blocks":            result.Blocks,
+		"attestations":      result.Attestations,
+		"attesterSlashings": len(result.AttesterSlashings),
+		"proposerSlashings": len(result.ProposerSlashings),
+	}).Info("Finished rescanning stored blocks")
+	return nil
+}
@@ -0,0 +1,13 @@
+package state
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "state",
+		Usage: "commands for analyzing beacon states",
+		Subcommands: []*cli.Command{
+			diffCmd,
+		},
+	},
+}
@@ -0,0 +1,100 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/diff"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var diffFlags = struct {
+	From            string
+	To              string
+	ChainConfigFile string
+}{}
+
+var diffCmd = &cli.Command{
+	Name: "diff",
+	Usage: "Print the field level difference between two SSZ encoded beacon states as JSON, in the same format as " +
+		"the /prysm/v1/debug/states/diff endpoint of the beacon node.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := diffAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not diff beacon states")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "from",
+			Usage:       "SSZ encoded beacon state to diff from",
+			Destination: &diffFlags.From,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "to",
+			Usage:       "SSZ encoded beacon state to diff to",
+			Destination: &diffFlags.To,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        cmd.ChainConfigFileFlag.Name,
+			Usage:       cmd.ChainConfigFileFlag.Usage,
+			Destination: &diffFlags.ChainConfigFile,
+		},
+	},
+}
+
+func diffAction(cliCtx *cli.Context) error {
+	if diffFlags.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(diffFlags.ChainConfigFile, nil); err != nil {
+			return err
+		}
+	}
+	from, err := readState(diffFlags.From)
+	if err != nil {
+		return err
+	}
+	to, err := readState(diffFlags.To)
+	if err != nil {
+		return err
+	}
+	return writeDiff(cliCtx.Context, from, to, cliCtx.App.Writer)
+}
+
+// readState reads the SSZ encoded beacon state in the given file, decoded according to its fork version.
+func readState(path string) (state.BeaconState, error) {
+	b, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read state file %s", path)
+	}
+	vu, err := detect.FromState(b)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not detect fork of state file %s", path)
+	}
+	st, err := vu.UnmarshalBeaconState(b)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal state file %s", path)
+	}
+	return st, nil
+}
+
+// writeDiff writes the difference between the states to w as indented JSON.
+func writeDiff(ctx context.Context, from, to state.ReadOnlyBeaconState, w io.Writer) error {
+	d, err := diff.Compute(ctx, from, to, 0)
+	if err != nil {
+		return errors.Wrap(err, "could not compute state diff")
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(structs.StateDiffFromConsensus(d))
+}
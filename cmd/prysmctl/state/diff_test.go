@@ -0,0 +1,67 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestWriteDiff(t *testing.T) {
+	from, _ := util.DeterministicGenesisStateDeneb(t, 64)
+	// The fork version is what the SSZ decoding of the command detects the state version from.
+	cfg := params.BeaconConfig()
+	require.NoError(t, from.SetFork(&ethpb.Fork{
+		PreviousVersion: cfg.CapellaForkVersion,
+		CurrentVersion:  cfg.DenebForkVersion,
+		Epoch:           cfg.DenebForkEpoch,
+	}))
+	to := from.Copy()
+	require.NoError(t, to.SetSlot(3))
+	val, err := to.ValidatorAtIndex(5)
+	require.NoError(t, err)
+	val.Slashed = true
+	require.NoError(t, to.UpdateValidatorAtIndex(5, val))
+
+	dir := t.TempDir()
+	write := func(name string, b []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, b, 0600))
+		return path
+	}
+	fromSsz, err := from.MarshalSSZ()
+	require.NoError(t, err)
+	toSsz, err := to.MarshalSSZ()
+	require.NoError(t, err)
+	fromSt, err := readState(write("from.ssz", fromSsz))
+	require.NoError(t, err)
+	toSt, err := readState(write("to.ssz", toSsz))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeDiff(context.Background(), fromSt, toSt, &buf))
+	d := &structs.StateDiff{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), d))
+	assert.DeepEqual(t, []string{"slot", "validators"}, d.ChangedFields)
+	assert.Equal(t, "3", d.ToSlot)
+	require.Equal(t, 1, len(d.Validators))
+	assert.Equal(t, "5", d.Validators[0].Index)
+	assert.Equal(t, false, d.Validators[0].Old.Slashed)
+	assert.Equal(t, true, d.Validators[0].New.Slashed)
+}
+
+func TestReadState_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.ssz")
+	require.NoError(t, os.WriteFile(path, []byte{1, 2, 3}, 0600))
+	_, err := readState(path)
+	require.ErrorContains(t, "could not detect fork", err)
+}
@@ -0,0 +1,36 @@
+// Package flags contains all configuration runtime flags for
+// the standalone slasher.
+package flags
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// BeaconRESTApiProviderFlag defines the URL of the beacon API of the beacon node the slasher follows.
+	BeaconRESTApiProviderFlag = &cli.StringFlag{
+		Name:  "beacon-rest-api-provider",
+		Usage: "Beacon node REST API provider endpoint. The slasher follows its event stream and submits the detected slashings to it.",
+		Value: "http://127.0.0.1:3500",
+	}
+	// BeaconRESTApiTimeoutFlag defines the timeout of the requests made to the beacon API.
+	BeaconRESTApiTimeoutFlag = &cli.DurationFlag{
+		Name:  "beacon-rest-api-timeout",
+		Usage: "Timeout of the requests made to the beacon node REST API. States are downloaded to verify slashings, so this must leave enough time to download a state.",
+		Value: 2 * time.Minute,
+	}
+	// ReconnectIntervalFlag defines the time waited before reconnecting to the beacon node.
+	ReconnectIntervalFlag = &cli.DurationFlag{
+		Name:  "reconnect-interval",
+		Usage: "Time waited before following the event stream of the beacon node again when it is interrupted.",
+		Value: 5 * time.Second,
+	}
+	// MonitoringPortFlag defines the http port used to serve prometheus metrics.
+	MonitoringPortFlag = &cli.IntFlag{
+		Name:  "monitoring-port",
+		Usage: "Port used to listening and respond metrics for Prometheus.",
+		Value: 8082,
+	}
+)
@@ -0,0 +1,147 @@
+// Package main defines a standalone slasher, which follows a remote beacon node through the beacon API,
+// detects slashable attestations and proposals, and submits the resulting slashings back to the beacon node.
+package main
+
+import (
+	"fmt"
+	"os"
+	runtimeDebug "runtime/debug"
+
+	joonix "github.com/joonix/log"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/cmd/slasher/flags"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/io/logs"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/journald"
+	"github.com/prysmaticlabs/prysm/v5/runtime/debug"
+	prefixed "github.com/prysmaticlabs/prysm/v5/runtime/logging/logrus-prefixed-formatter"
+	_ "github.com/prysmaticlabs/prysm/v5/runtime/maxprocs"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var appFlags = []cli.Flag{
+	flags.BeaconRESTApiProviderFlag,
+	flags.BeaconRESTApiTimeoutFlag,
+	flags.ReconnectIntervalFlag,
+	flags.MonitoringPortFlag,
+	cmd.DisableMonitoringFlag,
+	cmd.MonitoringHostFlag,
+	cmd.VerbosityFlag,
+	cmd.DataDirFlag,
+	cmd.LogFormat,
+	cmd.LogFileName,
+	cmd.ConfigFileFlag,
+	cmd.ChainConfigFileFlag,
+	debug.PProfFlag,
+	debug.PProfAddrFlag,
+	debug.PProfPortFlag,
+	debug.MemProfileRateFlag,
+	debug.CPUProfileFlag,
+	debug.TraceFlag,
+	debug.BlockProfileRateFlag,
+	debug.MutexProfileFractionFlag,
+}
+
+func init() {
+	appFlags = cmd.WrapFlags(append(appFlags, features.NetworkFlags...))
+}
+
+func startNode(ctx *cli.Context) error {
+	n, err := newSlasherNode(ctx)
+	if err != nil {
+		return err
+	}
+	n.Start()
+	return nil
+}
+
+func main() {
+	app := cli.App{
+		Name:    "slasher",
+		Usage:   "Launches a slasher that follows a beacon node through the beacon API and submits the slashings it detects to it.",
+		Version: version.Version(),
+		Action: func(ctx *cli.Context) error {
+			if err := startNode(ctx); err != nil {
+				log.Fatal(err.Error())
+				return err
+			}
+			return nil
+		},
+		Flags: appFlags,
+		Before: func(ctx *cli.Context) error {
+			// Load flags from config file, if specified.
+			if err := cmd.LoadFlagsFromConfig(ctx, appFlags); err != nil {
+				return err
+			}
+
+			verbosity := ctx.String(cmd.VerbosityFlag.Name)
+			level, err := logrus.ParseLevel(verbosity)
+			if err != nil {
+				return err
+			}
+			logrus.SetLevel(level)
+
+			logFileName := ctx.String(cmd.LogFileName.Name)
+
+			format := ctx.String(cmd.LogFormat.Name)
+			switch format {
+			case "text":
+				formatter := new(prefixed.TextFormatter)
+				formatter.TimestampFormat = "2006-01-02 15:04:05"
+				formatter.FullTimestamp = true
+				// If persistent log files are written - we disable the log messages coloring because
+				// the colors are ANSI codes and seen as gibberish in the log files.
+				formatter.DisableColors = logFileName != ""
+				logrus.SetFormatter(formatter)
+			case "fluentd":
+				f := joonix.NewFormatter()
+				if err := joonix.DisableTimestampFormat(f); err != nil {
+					panic(err)
+				}
+				logrus.SetFormatter(f)
+			case "json":
+				logrus.SetFormatter(&logrus.JSONFormatter{})
+			case "journald":
+				if err := journald.Enable(); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("unknown log format %s", format)
+			}
+
+			if logFileName != "" {
+				if err := logs.ConfigurePersistentLogging(logFileName); err != nil {
+					log.WithError(err).Error("Failed to configuring logging to disk.")
+				}
+			}
+
+			if err := debug.Setup(ctx); err != nil {
+				return errors.Wrap(err, "failed to setup debug")
+			}
+
+			if err := features.ValidateNetworkFlags(ctx); err != nil {
+				return errors.Wrap(err, "provided multiple network flags")
+			}
+
+			return cmd.ValidateNoArgs(ctx)
+		},
+		After: func(ctx *cli.Context) error {
+			debug.Exit(ctx)
+			return nil
+		},
+	}
+
+	defer func() {
+		if x := recover(); x != nil {
+			log.Errorf("Runtime panic: %v\n%v", x, string(runtimeDebug.Stack()))
+			panic(x)
+		}
+	}()
+
+	if err := app.Run(os.Args); err != nil {
+		log.Error(err.Error())
+	}
+}
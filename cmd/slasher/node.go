@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/slasherkv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/remote"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/cmd/slasher/flags"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/prometheus"
+	"github.com/prysmaticlabs/prysm/v5/runtime"
+	"github.com/prysmaticlabs/prysm/v5/runtime/debug"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// slasherNode runs the slasher service on its own, fed by the event stream of a remote beacon node.
+type slasherNode struct {
+	cliCtx   *cli.Context
+	ctx      context.Context
+	cancel   context.CancelFunc
+	services *runtime.ServiceRegistry
+	db       *slasherkv.Store
+	lock     sync.Mutex
+	stop     chan struct{}
+}
+
+func newSlasherNode(cliCtx *cli.Context) (*slasherNode, error) {
+	if err := features.ConfigureSlasher(cliCtx); err != nil {
+		return nil, err
+	}
+	if cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		if err := params.LoadChainConfigFile(cliCtx.String(cmd.ChainConfigFileFlag.Name), nil); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(cliCtx.Context)
+	n := &slasherNode{
+		cliCtx:   cliCtx,
+		ctx:      ctx,
+		cancel:   cancel,
+		services: runtime.NewServiceRegistry(),
+		stop:     make(chan struct{}),
+	}
+
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.BeaconNodeDbDirName)
+	log.WithField("databasePath", dbPath).Info("Opening slasher database")
+	d, err := slasherkv.NewKVStore(ctx, dbPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open slasher database")
+	}
+	n.db = d
+
+	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
+		addr := fmt.Sprintf("%s:%d", cliCtx.String(cmd.MonitoringHostFlag.Name), cliCtx.Int(flags.MonitoringPortFlag.Name))
+		if err := n.services.RegisterService(prometheus.NewService(addr, n.services)); err != nil {
+			return nil, err
+		}
+		logrus.AddHook(prometheus.NewLogrusCollector())
+	}
+	if err := n.registerSlasherServices(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// registerSlasherServices registers the slasher service, with the source following the beacon node
+// that feeds it.
+func (n *slasherNode) registerSlasherServices() error {
+	c, err := beacon.NewClient(
+		n.cliCtx.String(flags.BeaconRESTApiProviderFlag.Name),
+		client.WithTimeout(n.cliCtx.Duration(flags.BeaconRESTApiTimeoutFlag.Name)),
+		client.WithMaxBodySize(client.MaxBodySizeState),
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not create beacon API client")
+	}
+	reconnectInterval := n.cliCtx.Duration(flags.ReconnectIntervalFlag.Name)
+	chain := remote.NewChain(c, reconnectInterval)
+	indexedAttsFeed := new(event.Feed)
+	blockHeadersFeed := new(event.Feed)
+
+	slasherSrv, err := slasher.New(n.ctx, &slasher.ServiceConfig{
+		IndexedAttestationsFeed: indexedAttsFeed,
+		BeaconBlockHeadersFeed:  blockHeadersFeed,
+		Database:                n.db,
+		AttestationStateFetcher: chain,
+		StateGen:                chain,
+		SlashingPoolInserter:    remote.NewPoolInserter(c),
+		HeadStateFetcher:        chain,
+		SyncChecker:             chain,
+		ClockWaiter:             chain,
+	})
+	if err != nil {
+		return err
+	}
+	if err := n.services.RegisterService(slasherSrv); err != nil {
+		return err
+	}
+	return n.services.RegisterService(remote.NewSource(n.ctx, &remote.SourceConfig{
+		Client:                  c,
+		Chain:                   chain,
+		IndexedAttestationsFeed: indexedAttsFeed,
+		BeaconBlockHeadersFeed:  blockHeadersFeed,
+		ReconnectInterval:       reconnectInterval,
+	}))
+}
+
+// Start the slasher services and block until the node is closed.
+func (n *slasherNode) Start() {
+	n.lock.Lock()
+
+	log.WithFields(logrus.Fields{
+		"version":  version.Version(),
+		"provider": n.cliCtx.String(flags.BeaconRESTApiProviderFlag.Name),
+	}).Info("Starting standalone slasher")
+
+	n.services.StartAll()
+
+	stop := n.stop
+	n.lock.Unlock()
+
+	go func() {
+		sigc := make(chan os.Signal, 1)
+		signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+		defer signal.Stop(sigc)
+		<-sigc
+		log.Info("Got interrupt, shutting down...")
+		debug.Exit(n.cliCtx) // Ensure trace and CPU profile data are flushed.
+		go n.Close()
+		for i := 10; i > 0; i-- {
+			<-sigc
+			if i > 1 {
+				log.WithField("times", i-1).Info("Already shutting down, interrupt more to panic.")
+			}
+		}
+		panic("Panic closing the slasher")
+	}()
+
+	// Wait for stop channel to be closed.
+	<-stop
+}
+
+// Close handles graceful shutdown of the system.
+func (n *slasherNode) Close() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.services.StopAll()
+	log.Info("Stopping standalone slasher")
+	if err := n.db.Close(); err != nil {
+		log.WithError(err).Error("Failed to close slasher database")
+	}
+	n.cancel()
+	close(n.stop)
+}
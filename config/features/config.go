@@ -310,6 +310,17 @@ func ConfigureValidator(ctx *cli.Context) error {
 	return nil
 }
 
+// ConfigureSlasher sets the global config based
+// on what flags are enabled for the standalone slasher.
+func ConfigureSlasher(ctx *cli.Context) error {
+	complainOnDeprecatedFlags(ctx)
+	if err := configureTestnet(ctx); err != nil {
+		return err
+	}
+	Init(&Flags{EnableSlasher: true})
+	return nil
+}
+
 // enableDevModeFlags switches development mode features on.
 func enableDevModeFlags(ctx *cli.Context) {
 	log.Warn("Enabling development mode flags")
@@ -0,0 +1,77 @@
+package forkchoice
+
+import (
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+type ReorgKind uint8
+
+const (
+	// ChainReorg is a change of head to a block that does not descend from the previous head.
+	ChainReorg ReorgKind = iota
+	// LateBlockReorgAttempt is a decision to build on the parent of a late head block.
+	LateBlockReorgAttempt
+	// LateBlockReorgRefused is a late head block that was kept because it gathered enough attestations.
+	LateBlockReorgRefused
+)
+
+func (k ReorgKind) String() string {
+	switch k {
+	case ChainReorg:
+		return "reorg"
+	case LateBlockReorgAttempt:
+		return "late_block_reorg_attempt"
+	case LateBlockReorgRefused:
+		return "late_block_reorg_refused"
+	default:
+		return "unknown"
+	}
+}
+
+// ReorgEvent records a chain reorg or a late block reorg decision together with
+// the fork choice data that led to it.
+type ReorgEvent struct {
+	Kind               ReorgKind
+	Slot               primitives.Slot
+	Timestamp          uint64
+	Reason             string
+	OldHeadRoot        [32]byte
+	NewHeadRoot        [32]byte
+	CommonAncestorRoot [32]byte
+	CommonAncestorSlot primitives.Slot
+	Depth              uint64
+	// OldBranch and NewBranch hold the blocks of each branch, from the head down to
+	// the common ancestor, excluded.
+	OldBranch            []*ReorgBlock
+	NewBranch            []*ReorgBlock
+	OrphanedAttestations []*OrphanedAttestation
+	// Truncated is set when a branch holds more blocks than were recorded for it.
+	Truncated bool
+}
+
+// ReorgBlock is a block on one of the branches of a ReorgEvent.
+type ReorgBlock struct {
+	Root          [32]byte
+	Slot          primitives.Slot
+	ProposerIndex primitives.ValidatorIndex
+	Weight        uint64
+	// ArrivalMillis is the number of milliseconds between the start of the block's slot
+	// and the moment the block was received over gossip, or inserted in fork choice for
+	// blocks that did not come from gossip.
+	ArrivalMillis uint64
+	Timely        bool
+	ProposerBoost bool
+}
+
+// OrphanedAttestation is an attestation that was included in a block of the
+// orphaned branch.
+type OrphanedAttestation struct {
+	BlockRoot       [32]byte
+	Slot            primitives.Slot
+	CommitteeIndex  primitives.CommitteeIndex
+	BeaconBlockRoot [32]byte
+	Attesters       uint64
+	// AttestingIndices are the validators attesting, read from the post-state of the orphaned
+	// block. They are empty if the state could not be read.
+	AttestingIndices []uint64
+}
@@ -0,0 +1,110 @@
+package slashing
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/container/slice"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// Source is where the node first learned about a slashing.
+type Source uint8
+
+const (
+	// SourceSlasher is a slashing detected by the slasher of the node.
+	SourceSlasher Source = iota
+	// SourceGossip is a slashing received over gossip.
+	SourceGossip
+	// SourceAPI is a slashing submitted to the beacon API of the node.
+	SourceAPI
+	// SourceBlock is a slashing first seen included in a canonical block.
+	SourceBlock
+)
+
+func (s Source) String() string {
+	switch s {
+	case SourceSlasher:
+		return "slasher"
+	case SourceGossip:
+		return "gossip"
+	case SourceAPI:
+		return "api"
+	case SourceBlock:
+		return "block"
+	default:
+		return "unknown"
+	}
+}
+
+// Evidence records an attester or a proposer slashing seen by the node. Exactly one of
+// AttesterSlashing and ProposerSlashing is set.
+type Evidence struct {
+	// Root is the hash tree root of the slashing.
+	Root [32]byte
+	// Epoch is the epoch of the offense: the target epoch of the first attestation of an attester
+	// slashing, or the epoch of the proposals of a proposer slashing.
+	Epoch primitives.Epoch
+	// ValidatorIndices are the indices of the validators slashed by the slashing, in ascending order.
+	ValidatorIndices []primitives.ValidatorIndex
+	Source           Source
+	// Timestamp is the unix time in seconds at which the node first saw the slashing.
+	Timestamp uint64
+	// Included is true once the slashing was seen in a canonical block at InclusionSlot.
+	Included         bool
+	InclusionSlot    primitives.Slot
+	AttesterSlashing ethpb.AttSlashing
+	ProposerSlashing *ethpb.ProposerSlashing
+}
+
+// NewAttesterSlashingEvidence returns the evidence of an attester slashing.
+func NewAttesterSlashingEvidence(s ethpb.AttSlashing, source Source, timestamp uint64) (*Evidence, error) {
+	if s == nil || s.IsNil() {
+		return nil, errors.New("nil attester slashing")
+	}
+	root, err := s.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute attester slashing root")
+	}
+	indices := slice.IntersectionUint64(s.FirstAttestation().GetAttestingIndices(), s.SecondAttestation().GetAttestingIndices())
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	validators := make([]primitives.ValidatorIndex, len(indices))
+	for i, index := range indices {
+		validators[i] = primitives.ValidatorIndex(index)
+	}
+	return &Evidence{
+		Root:             root,
+		Epoch:            s.FirstAttestation().GetData().Target.Epoch,
+		ValidatorIndices: validators,
+		Source:           source,
+		Timestamp:        timestamp,
+		AttesterSlashing: s,
+	}, nil
+}
+
+// NewProposerSlashingEvidence returns the evidence of a proposer slashing.
+func NewProposerSlashingEvidence(s *ethpb.ProposerSlashing, source Source, timestamp uint64) (*Evidence, error) {
+	if s == nil || s.Header_1 == nil || s.Header_1.Header == nil {
+		return nil, errors.New("nil proposer slashing")
+	}
+	root, err := s.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute proposer slashing root")
+	}
+	return &Evidence{
+		Root:             root,
+		Epoch:            slots.ToEpoch(s.Header_1.Header.Slot),
+		ValidatorIndices: []primitives.ValidatorIndex{s.Header_1.Header.ProposerIndex},
+		Source:           source,
+		Timestamp:        timestamp,
+		ProposerSlashing: s,
+	}, nil
+}
+
+// Involves returns true if the validator with the given index is slashed by the slashing.
+func (e *Evidence) Involves(index primitives.ValidatorIndex) bool {
+	i := sort.Search(len(e.ValidatorIndices), func(i int) bool { return e.ValidatorIndices[i] >= index })
+	return i < len(e.ValidatorIndices) && e.ValidatorIndices[i] == index
+}
@@ -0,0 +1,64 @@
+package slashing_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/slashing"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestNewAttesterSlashingEvidence(t *testing.T) {
+	att1 := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{9, 2, 5, 7}})
+	att1.Data.Target.Epoch = 3
+	att2 := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1, 5, 7, 9}})
+	att2.Data.BeaconBlockRoot = make([]byte, 32)
+	att2.Data.BeaconBlockRoot[0] = 'b'
+	s := &ethpb.AttesterSlashing{Attestation_1: att1, Attestation_2: att2}
+
+	e, err := slashing.NewAttesterSlashingEvidence(s, slashing.SourceGossip, 100)
+	require.NoError(t, err)
+	root, err := s.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, root, e.Root)
+	require.Equal(t, primitives.Epoch(3), e.Epoch)
+	require.DeepEqual(t, []primitives.ValidatorIndex{5, 7, 9}, e.ValidatorIndices)
+	require.Equal(t, slashing.SourceGossip, e.Source)
+	require.Equal(t, uint64(100), e.Timestamp)
+	require.Equal(t, true, e.Involves(7))
+	require.Equal(t, false, e.Involves(2))
+	require.Equal(t, false, e.Involves(10))
+
+	_, err = slashing.NewAttesterSlashingEvidence(&ethpb.AttesterSlashing{}, slashing.SourceGossip, 100)
+	require.ErrorContains(t, "nil attester slashing", err)
+}
+
+func TestNewProposerSlashingEvidence(t *testing.T) {
+	s := &ethpb.ProposerSlashing{
+		Header_1: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{
+			Header: &ethpb.BeaconBlockHeader{Slot: 65, ProposerIndex: 4},
+		}),
+		Header_2: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{
+			Header: &ethpb.BeaconBlockHeader{Slot: 65, ProposerIndex: 4, BodyRoot: bytesutil.PadTo([]byte{'b'}, 32)},
+		}),
+	}
+	e, err := slashing.NewProposerSlashingEvidence(s, slashing.SourceSlasher, 100)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Epoch(2), e.Epoch)
+	require.DeepEqual(t, []primitives.ValidatorIndex{4}, e.ValidatorIndices)
+	require.Equal(t, true, e.Involves(4))
+
+	_, err = slashing.NewProposerSlashingEvidence(&ethpb.ProposerSlashing{}, slashing.SourceSlasher, 100)
+	require.ErrorContains(t, "nil proposer slashing", err)
+}
+
+func TestSource_String(t *testing.T) {
+	require.Equal(t, "slasher", slashing.SourceSlasher.String())
+	require.Equal(t, "gossip", slashing.SourceGossip.String())
+	require.Equal(t, "api", slashing.SourceAPI.String())
+	require.Equal(t, "block", slashing.SourceBlock.String())
+	require.Equal(t, "unknown", slashing.Source(9).String())
+}
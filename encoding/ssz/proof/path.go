@@ -0,0 +1,79 @@
+package proof
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// PathElement is a step of a path in an SSZ object, either a container field or an index in a vector or list.
+type PathElement struct {
+	Name    string
+	Index   uint64
+	IsIndex bool
+}
+
+// Path is a sequence of steps from the root of an SSZ object to one of its nodes.
+type Path []PathElement
+
+// ParsePath parses a path of dot separated field names and indices, such as
+// validators[3].withdrawal_credentials or validators.3.withdrawal_credentials.
+// The empty path designates the root of the object.
+func ParsePath(s string) (Path, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), ".")
+	if s == "" {
+		return Path{}, nil
+	}
+	var path Path
+	for _, segment := range strings.Split(s, ".") {
+		name, rest, _ := strings.Cut(segment, "[")
+		if name == "" && rest == "" {
+			return nil, errors.Errorf("empty segment in path %q", s)
+		}
+		if name != "" {
+			if index, err := strconv.ParseUint(name, 10, 64); err == nil {
+				path = append(path, PathElement{Index: index, IsIndex: true})
+			} else {
+				path = append(path, PathElement{Name: name})
+			}
+		}
+		for rest != "" {
+			var rawIndex string
+			var ok bool
+			rawIndex, rest, ok = strings.Cut(rest, "]")
+			if !ok {
+				return nil, errors.Errorf("unclosed bracket in path %q", s)
+			}
+			index, err := strconv.ParseUint(rawIndex, 10, 64)
+			if err != nil {
+				return nil, errors.Errorf("invalid index %q in path %q", rawIndex, s)
+			}
+			path = append(path, PathElement{Index: index, IsIndex: true})
+			if rest == "" {
+				break
+			}
+			if !strings.HasPrefix(rest, "[") {
+				return nil, errors.Errorf("unexpected %q after index in path %q", rest, s)
+			}
+			rest = rest[1:]
+		}
+	}
+	return path, nil
+}
+
+// String returns the path in the bracket notation.
+func (p Path) String() string {
+	var b strings.Builder
+	for _, e := range p {
+		if e.IsIndex {
+			b.WriteString("[" + strconv.FormatUint(e.Index, 10) + "]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(e.Name)
+	}
+	return b.String()
+}
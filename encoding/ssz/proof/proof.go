@@ -0,0 +1,662 @@
+// Package proof generates Merkle proofs of the nodes of SSZ objects designated by a path of field names and indices,
+// such as a validator in a beacon state or the execution state root of a beacon block. The SSZ schema of an object is
+// read from the ssz-size and ssz-max tags of the generated Go types.
+package proof
+
+import (
+	"context"
+	"encoding/binary"
+	"math/bits"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/container/trie"
+	"github.com/prysmaticlabs/prysm/v5/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz"
+)
+
+const bytesPerChunk = 32
+
+// Proof is a Merkle proof of a node of the SSZ tree of an object.
+type Proof struct {
+	// Root is the hash tree root of the object.
+	Root [32]byte
+	// Leaf is the root of the proven node. For an element of a vector or list of basic values, it is the chunk
+	// packing the element.
+	Leaf [32]byte
+	// Branch holds the sibling nodes from the leaf up to the root.
+	Branch [][32]byte
+	// GeneralizedIndex is the generalized index of the leaf in the tree of the object.
+	GeneralizedIndex uint64
+}
+
+// Verify reports whether the proof links its leaf to the given root.
+func Verify(root [32]byte, p *Proof) bool {
+	if p == nil || p.GeneralizedIndex == 0 || bits.Len64(p.GeneralizedIndex)-1 != len(p.Branch) {
+		return false
+	}
+	return rootOf(p.Leaf, p.GeneralizedIndex, p.Branch) == root
+}
+
+// rootOf hashes the leaf at the generalized index with the nodes of the branch up to the root.
+func rootOf(leaf [32]byte, index uint64, branch [][32]byte) [32]byte {
+	node := leaf
+	for _, sibling := range branch {
+		if index%2 == 0 {
+			node = hash.Hash(append(node[:], sibling[:]...))
+		} else {
+			node = hash.Hash(append(sibling[:], node[:]...))
+		}
+		index /= 2
+	}
+	return node
+}
+
+// Prove returns a proof of the node at the path in the object, a pointer to a generated SSZ container.
+func Prove(obj interface{}, path Path) (*Proof, error) {
+	v, t, err := valueOf(obj)
+	if err != nil {
+		return nil, err
+	}
+	n, err := nodeOf(v, t)
+	if err != nil {
+		return nil, err
+	}
+	p, err := proveNode(v, t, n, path, 0)
+	if err != nil {
+		return nil, err
+	}
+	p.Root = n.root()
+	return p, nil
+}
+
+// Tree gives the top of the tree of an SSZ container which keeps its tree hashed, such as the native beacon state.
+type Tree interface {
+	// FieldProof returns the root of the field at the position in the container and the branch linking it to the
+	// root of the container.
+	FieldProof(ctx context.Context, position uint64) ([32]byte, [][32]byte, error)
+	// FieldChunkProof returns the chunk at the index of the tree of the field at the position in the container and
+	// the branch linking it to the root of the field, or false when the tree of the field is not kept.
+	FieldChunkProof(ctx context.Context, position, chunk uint64) ([32]byte, [][32]byte, bool, error)
+}
+
+// ProveTree returns a proof of the node at the path in the object, a pointer to a generated SSZ container whose
+// tree is given by the tree. Only the parts of the object on the path which the tree does not keep are hashed.
+func ProveTree(ctx context.Context, obj interface{}, tree Tree, path Path) (*Proof, error) {
+	v, t, err := valueOf(obj)
+	if err != nil {
+		return nil, err
+	}
+	depth := ssz.Depth(uint64(len(t.fields)))
+	if len(path) == 0 {
+		leaf, branch, err := tree.FieldProof(ctx, 0)
+		if err != nil {
+			return nil, err
+		}
+		root := rootOf(leaf, 1<<depth, branch)
+		return &Proof{Root: root, Leaf: root, GeneralizedIndex: 1}, nil
+	}
+	position, fv, ft, err := descend(v, t, path[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not resolve %s", path[:1])
+	}
+	leaf, branch, err := tree.FieldProof(ctx, position)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not prove %s", path[:1])
+	}
+	if len(branch) != int(depth) {
+		return nil, errors.Errorf("branch of %s has %d nodes instead of %d", path[:1], len(branch), depth)
+	}
+	p := &Proof{Leaf: leaf, Branch: branch, GeneralizedIndex: 1<<depth | position}
+	if len(path) > 1 {
+		if ft == nil {
+			return nil, errors.Errorf("could not resolve %s: %s is a basic value", path, path[:1])
+		}
+		sub, err := proveField(ctx, tree, position, fv, ft, path)
+		if err != nil {
+			return nil, err
+		}
+		if p, err = join(p, sub); err != nil {
+			return nil, errors.Wrapf(err, "could not resolve %s", path)
+		}
+	}
+	p.Root = rootOf(p.Leaf, p.GeneralizedIndex, p.Branch)
+	return p, nil
+}
+
+// proveField returns a proof of the node at the path, from its second element, in the field at the position of a
+// tree, relative to the root of the field. The chunks of vectors and lists are taken from the tree when it keeps
+// them.
+func proveField(ctx context.Context, tree Tree, position uint64, v reflect.Value, t *sszType, path Path) (*Proof, error) {
+	if t.kind == vectorKind || t.kind == listKind {
+		chunk, next, nextType, err := descend(v, t, path[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not resolve %s", path[:2])
+		}
+		leaf, branch, ok, err := tree.FieldChunkProof(ctx, position, chunk)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not prove %s", path[:2])
+		}
+		if ok {
+			index := uint64(1)
+			if t.kind == vectorKind {
+				index = index<<ssz.Depth(chunkCount(t.length, t.elem)) | chunk
+			} else {
+				index = index<<(ssz.Depth(chunkCount(t.limit, t.elem))+1) | chunk
+			}
+			if bits.Len64(index)-1 != len(branch) {
+				return nil, errors.Errorf("branch of %s has %d nodes instead of %d", path[:2], len(branch), bits.Len64(index)-1)
+			}
+			p := &Proof{Leaf: leaf, Branch: branch, GeneralizedIndex: index}
+			if len(path) == 2 {
+				return p, nil
+			}
+			if nextType == nil {
+				return nil, errors.Errorf("could not resolve %s: %s is a basic value", path, path[:2])
+			}
+			n, err := nodeOf(next, nextType)
+			if err != nil {
+				return nil, err
+			}
+			sub, err := proveNode(next, nextType, n, path, 2)
+			if err != nil {
+				return nil, err
+			}
+			if p, err = join(p, sub); err != nil {
+				return nil, errors.Wrapf(err, "could not resolve %s", path)
+			}
+			return p, nil
+		}
+	}
+	n, err := nodeOf(v, t)
+	if err != nil {
+		return nil, err
+	}
+	return proveNode(v, t, n, path, 1)
+}
+
+// join returns the proof of the leaf of the inner proof, whose root is the leaf of the outer proof, up to the root
+// of the outer proof.
+func join(outer, inner *Proof) (*Proof, error) {
+	depth := bits.Len64(inner.GeneralizedIndex) - 1
+	if bits.Len64(outer.GeneralizedIndex)+depth > 64 {
+		return nil, errors.New("generalized index overflows")
+	}
+	branch := make([][32]byte, 0, len(inner.Branch)+len(outer.Branch))
+	branch = append(branch, inner.Branch...)
+	branch = append(branch, outer.Branch...)
+	return &Proof{
+		Leaf:             inner.Leaf,
+		Branch:           branch,
+		GeneralizedIndex: outer.GeneralizedIndex<<depth | (inner.GeneralizedIndex ^ 1<<depth),
+	}, nil
+}
+
+func valueOf(obj interface{}) (reflect.Value, *sszType, error) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return reflect.Value{}, nil, errors.New("object is not a non-nil pointer")
+	}
+	t, err := typeOf(v.Type(), nil)
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+	return v, t, nil
+}
+
+// proveNode returns a proof of the node at the path, from its start element, in the value whose node is given,
+// relative to the root of the value.
+func proveNode(v reflect.Value, t *sszType, n *node, path Path, start int) (*Proof, error) {
+	p := &Proof{GeneralizedIndex: 1}
+	if start == len(path) {
+		p.Leaf = n.root()
+		return p, nil
+	}
+	var steps [][][32]byte
+	for i := start; i < len(path); i++ {
+		e := path[i]
+		if i > start {
+			var err error
+			if n, err = nodeOf(v, t); err != nil {
+				return nil, err
+			}
+		}
+		chunk, next, nextType, err := descend(v, t, e)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not resolve %s", path[:i+1])
+		}
+		if chunk >= uint64(len(n.chunks)) {
+			return nil, errors.Errorf("could not resolve %s: chunk %d out of range", path[:i+1], chunk)
+		}
+		step := n.branch(chunk)
+		if n.isList {
+			step = append(step, lengthChunk(n.length))
+			p.GeneralizedIndex *= 2
+		}
+		if bits.Len64(p.GeneralizedIndex)+int(n.depth) > 64 {
+			return nil, errors.Errorf("generalized index of %s overflows", path[:i+1])
+		}
+		p.GeneralizedIndex = p.GeneralizedIndex<<n.depth | chunk
+		steps = append(steps, step)
+		p.Leaf = n.chunks[chunk]
+		if nextType == nil && i != len(path)-1 {
+			return nil, errors.Errorf("could not resolve %s: %s is a basic value", path, path[:i+1])
+		}
+		v, t = next, nextType
+	}
+	for i := len(steps) - 1; i >= 0; i-- {
+		p.Branch = append(p.Branch, steps[i]...)
+	}
+	return p, nil
+}
+
+type kind int
+
+const (
+	basicKind kind = iota
+	byteVectorKind
+	byteListKind
+	bitlistKind
+	vectorKind
+	listKind
+	containerKind
+)
+
+type field struct {
+	index int
+	name  string
+	alias string
+}
+
+// sszType is the SSZ schema of a Go type.
+type sszType struct {
+	kind kind
+	// size is the serialized size of a basic value or the length of a byte vector.
+	size uint64
+	// length is the length of a vector, limit the limit of a list, in bytes for byte lists and in bits for bitlists.
+	length uint64
+	limit  uint64
+	elem   *sszType
+	fields []field
+}
+
+type dimension struct {
+	n     uint64
+	fixed bool
+}
+
+// dimensions returns the dimensions of a field from its ssz-size and ssz-max tags, outermost first. A ? size
+// takes the next ssz-max value.
+func dimensions(sizeTag, maxTag string) ([]dimension, error) {
+	var sizes, maxes []string
+	if sizeTag != "" {
+		sizes = strings.Split(sizeTag, ",")
+	}
+	if maxTag != "" {
+		maxes = strings.Split(maxTag, ",")
+	}
+	parse := func(s string) (uint64, error) {
+		n, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return 0, errors.Errorf("invalid ssz dimension %q", s)
+		}
+		return n, nil
+	}
+	var dims []dimension
+	if len(sizes) == 0 {
+		for _, m := range maxes {
+			n, err := parse(m)
+			if err != nil {
+				return nil, err
+			}
+			dims = append(dims, dimension{n: n})
+		}
+		return dims, nil
+	}
+	for _, s := range sizes {
+		if strings.TrimSpace(s) != "?" {
+			n, err := parse(s)
+			if err != nil {
+				return nil, err
+			}
+			dims = append(dims, dimension{n: n, fixed: true})
+			continue
+		}
+		if len(maxes) == 0 {
+			return nil, errors.Errorf("no ssz-max for dynamic size in %q", sizeTag)
+		}
+		n, err := parse(maxes[0])
+		if err != nil {
+			return nil, err
+		}
+		maxes = maxes[1:]
+		dims = append(dims, dimension{n: n})
+	}
+	return dims, nil
+}
+
+var bitlistType = reflect.TypeOf(bitfield.Bitlist{})
+
+func typeOf(t reflect.Type, dims []dimension) (*sszType, error) {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Uint8:
+		return &sszType{kind: basicKind, size: 1}, nil
+	case reflect.Uint16:
+		return &sszType{kind: basicKind, size: 2}, nil
+	case reflect.Uint32:
+		return &sszType{kind: basicKind, size: 4}, nil
+	case reflect.Uint64:
+		return &sszType{kind: basicKind, size: 8}, nil
+	case reflect.Ptr:
+		if t.Elem().Kind() != reflect.Struct {
+			return nil, errors.Errorf("unsupported type %s", t)
+		}
+		return containerOf(t.Elem())
+	case reflect.Slice:
+		if len(dims) == 0 {
+			return nil, errors.Errorf("no ssz-size or ssz-max for type %s", t)
+		}
+		d := dims[0]
+		if t.Elem().Kind() == reflect.Uint8 {
+			switch {
+			case t == bitlistType:
+				return &sszType{kind: bitlistKind, limit: d.n}, nil
+			case d.fixed:
+				return &sszType{kind: byteVectorKind, size: d.n}, nil
+			default:
+				return &sszType{kind: byteListKind, limit: d.n}, nil
+			}
+		}
+		elem, err := typeOf(t.Elem(), dims[1:])
+		if err != nil {
+			return nil, err
+		}
+		if d.fixed {
+			return &sszType{kind: vectorKind, length: d.n, elem: elem}, nil
+		}
+		return &sszType{kind: listKind, limit: d.n, elem: elem}, nil
+	default:
+		return nil, errors.Errorf("unsupported type %s", t)
+	}
+}
+
+func containerOf(t reflect.Type) (*sszType, error) {
+	c := &sszType{kind: containerKind}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		protoTag := f.Tag.Get("protobuf")
+		if !f.IsExported() || protoTag == "" {
+			continue
+		}
+		name := f.Name
+		for _, part := range strings.Split(protoTag, ",") {
+			if strings.HasPrefix(part, "name=") {
+				name = strings.TrimPrefix(part, "name=")
+			}
+		}
+		c.fields = append(c.fields, field{index: i, name: name, alias: f.Tag.Get("spec-name")})
+	}
+	if len(c.fields) == 0 {
+		return nil, errors.Errorf("type %s has no SSZ fields", t)
+	}
+	return c, nil
+}
+
+// fieldType returns the schema of a container field.
+func fieldType(container reflect.Type, f field) (*sszType, error) {
+	sf := container.Field(f.index)
+	dims, err := dimensions(sf.Tag.Get("ssz-size"), sf.Tag.Get("ssz-max"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "field %s", f.name)
+	}
+	t, err := typeOf(sf.Type, dims)
+	if err != nil {
+		return nil, errors.Wrapf(err, "field %s", f.name)
+	}
+	return t, nil
+}
+
+// descend returns the chunk of the node holding the path element and the value and schema the element designates,
+// nil for an element packed with other basic values.
+func descend(v reflect.Value, t *sszType, e PathElement) (uint64, reflect.Value, *sszType, error) {
+	switch t.kind {
+	case containerKind:
+		if e.IsIndex {
+			return 0, reflect.Value{}, nil, errors.Errorf("index %d in a container", e.Index)
+		}
+		s := elemOf(v)
+		for i, f := range t.fields {
+			if f.name != e.Name && f.alias != e.Name {
+				continue
+			}
+			ft, err := fieldType(s.Type(), f)
+			if err != nil {
+				return 0, reflect.Value{}, nil, err
+			}
+			if ft.kind == basicKind {
+				return uint64(i), reflect.Value{}, nil, nil
+			}
+			return uint64(i), s.Field(f.index), ft, nil
+		}
+		return 0, reflect.Value{}, nil, errors.Errorf("unknown field %q", e.Name)
+	case vectorKind, listKind:
+		if !e.IsIndex {
+			return 0, reflect.Value{}, nil, errors.Errorf("field %q in a list", e.Name)
+		}
+		if e.Index >= uint64(v.Len()) {
+			return 0, reflect.Value{}, nil, errors.Errorf("index %d out of range for length %d", e.Index, v.Len())
+		}
+		if t.elem.kind == basicKind {
+			return e.Index * t.elem.size / bytesPerChunk, reflect.Value{}, nil, nil
+		}
+		return e.Index, v.Index(int(e.Index)), t.elem, nil
+	case byteVectorKind, byteListKind:
+		if !e.IsIndex {
+			return 0, reflect.Value{}, nil, errors.Errorf("field %q in a byte array", e.Name)
+		}
+		if e.Index >= uint64(v.Len()) {
+			return 0, reflect.Value{}, nil, errors.Errorf("index %d out of range for length %d", e.Index, v.Len())
+		}
+		return e.Index / bytesPerChunk, reflect.Value{}, nil, nil
+	default:
+		return 0, reflect.Value{}, nil, errors.New("cannot descend into a bitlist")
+	}
+}
+
+// elemOf dereferences a pointer to a container, using the zero value for nil like the generated hashing code.
+func elemOf(v reflect.Value) reflect.Value {
+	if v.IsNil() {
+		return reflect.New(v.Type().Elem()).Elem()
+	}
+	return v.Elem()
+}
+
+// node is a composite node of an SSZ tree with its chunks.
+type node struct {
+	chunks [][32]byte
+	depth  uint8
+	isList bool
+	length uint64
+}
+
+func nodeOf(v reflect.Value, t *sszType) (*node, error) {
+	switch t.kind {
+	case containerKind:
+		s := elemOf(v)
+		n := &node{chunks: make([][32]byte, len(t.fields)), depth: ssz.Depth(uint64(len(t.fields)))}
+		for i, f := range t.fields {
+			ft, err := fieldType(s.Type(), f)
+			if err != nil {
+				return nil, err
+			}
+			if n.chunks[i], err = hashTreeRoot(s.Field(f.index), ft); err != nil {
+				return nil, errors.Wrapf(err, "field %s", f.name)
+			}
+		}
+		return n, nil
+	case byteVectorKind:
+		if uint64(v.Len()) != t.size {
+			return nil, errors.Errorf("byte vector has length %d instead of %d", v.Len(), t.size)
+		}
+		return &node{chunks: pack(v.Bytes()), depth: ssz.Depth(byteChunkCount(t.size))}, nil
+	case byteListKind:
+		if uint64(v.Len()) > t.limit {
+			return nil, errors.Errorf("byte list has length %d over its limit %d", v.Len(), t.limit)
+		}
+		return &node{chunks: pack(v.Bytes()), depth: ssz.Depth(byteChunkCount(t.limit)), isList: true, length: uint64(v.Len())}, nil
+	case vectorKind, listKind:
+		length := uint64(v.Len())
+		n := &node{}
+		if t.kind == vectorKind {
+			if length != t.length {
+				return nil, errors.Errorf("vector has length %d instead of %d", length, t.length)
+			}
+			n.depth = ssz.Depth(chunkCount(t.length, t.elem))
+		} else {
+			if length > t.limit {
+				return nil, errors.Errorf("list has length %d over its limit %d", length, t.limit)
+			}
+			n.depth = ssz.Depth(chunkCount(t.limit, t.elem))
+			n.isList, n.length = true, length
+		}
+		if t.elem.kind == basicKind {
+			buf := make([]byte, 0, length*t.elem.size)
+			for i := 0; i < v.Len(); i++ {
+				buf = appendBasic(buf, v.Index(i), t.elem.size)
+			}
+			n.chunks = pack(buf)
+			return n, nil
+		}
+		n.chunks = make([][32]byte, length)
+		for i := 0; i < v.Len(); i++ {
+			root, err := hashTreeRoot(v.Index(i), t.elem)
+			if err != nil {
+				return nil, errors.Wrapf(err, "element %d", i)
+			}
+			n.chunks[i] = root
+		}
+		return n, nil
+	default:
+		return nil, errors.New("not a composite type")
+	}
+}
+
+type hashRoot interface {
+	HashTreeRoot() ([32]byte, error)
+}
+
+func hashTreeRoot(v reflect.Value, t *sszType) ([32]byte, error) {
+	switch t.kind {
+	case basicKind:
+		var chunk [32]byte
+		copy(chunk[:], appendBasic(nil, v, t.size))
+		return chunk, nil
+	case bitlistKind:
+		return ssz.BitlistRoot(bitfield.Bitlist(v.Bytes()), t.limit)
+	case containerKind:
+		// The generated hashing code is much faster than the generic one.
+		if h, ok := v.Interface().(hashRoot); ok && !v.IsNil() {
+			return h.HashTreeRoot()
+		}
+	}
+	n, err := nodeOf(v, t)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return n.root(), nil
+}
+
+// layers returns the layers of the tree of the node, from the chunks up, without the zero padding.
+func (n *node) layers() [][][32]byte {
+	layers := make([][][32]byte, n.depth+1)
+	layers[0] = n.chunks
+	for d := uint8(0); d < n.depth; d++ {
+		current := layers[d]
+		next := make([][32]byte, (len(current)+1)/2)
+		for i := range next {
+			right := trie.ZeroHashes[d]
+			if 2*i+1 < len(current) {
+				right = current[2*i+1]
+			}
+			next[i] = hash.Hash(append(current[2*i][:], right[:]...))
+		}
+		layers[d+1] = next
+	}
+	return layers
+}
+
+func (n *node) root() [32]byte {
+	layers := n.layers()
+	root := trie.ZeroHashes[n.depth]
+	if top := layers[n.depth]; len(top) > 0 {
+		root = top[0]
+	}
+	if n.isList {
+		length := lengthChunk(n.length)
+		root = hash.Hash(append(root[:], length[:]...))
+	}
+	return root
+}
+
+// branch returns the siblings of the chunk from the bottom of the node up to its data root.
+func (n *node) branch(chunk uint64) [][32]byte {
+	layers := n.layers()
+	branch := make([][32]byte, 0, n.depth)
+	for d := uint8(0); d < n.depth; d++ {
+		sibling := trie.ZeroHashes[d]
+		if s := chunk ^ 1; s < uint64(len(layers[d])) {
+			sibling = layers[d][s]
+		}
+		branch = append(branch, sibling)
+		chunk /= 2
+	}
+	return branch
+}
+
+func lengthChunk(length uint64) [32]byte {
+	var chunk [32]byte
+	binary.LittleEndian.PutUint64(chunk[:8], length)
+	return chunk
+}
+
+// chunkCount returns the number of chunks of n elements, packed when they are basic values.
+func chunkCount(n uint64, elem *sszType) uint64 {
+	if elem.kind != basicKind {
+		return n
+	}
+	return byteChunkCount(n * elem.size)
+}
+
+func byteChunkCount(n uint64) uint64 {
+	return (n + bytesPerChunk - 1) / bytesPerChunk
+}
+
+func pack(b []byte) [][32]byte {
+	chunks := make([][32]byte, (len(b)+bytesPerChunk-1)/bytesPerChunk)
+	for i := range chunks {
+		copy(chunks[i][:], b[i*bytesPerChunk:])
+	}
+	return chunks
+}
+
+func appendBasic(buf []byte, v reflect.Value, size uint64) []byte {
+	if v.Kind() == reflect.Bool {
+		if v.Bool() {
+			return append(buf, 1)
+		}
+		return append(buf, 0)
+	}
+	switch size {
+	case 1:
+		return append(buf, uint8(v.Uint()))
+	case 2:
+		return binary.LittleEndian.AppendUint16(buf, uint16(v.Uint()))
+	case 4:
+		return binary.LittleEndian.AppendUint32(buf, uint32(v.Uint()))
+	default:
+		return binary.LittleEndian.AppendUint64(buf, v.Uint())
+	}
+}
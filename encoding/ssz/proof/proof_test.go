@@ -0,0 +1,158 @@
+package proof_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/proof"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestParsePath(t *testing.T) {
+	for _, s := range []string{"validators[3].pubkey", ".validators.3.pubkey", "validators[3]pubkey"} {
+		p, err := proof.ParsePath(s)
+		if s == "validators[3]pubkey" {
+			require.ErrorContains(t, "after index", err)
+			continue
+		}
+		require.NoError(t, err)
+		require.DeepEqual(t, proof.Path{{Name: "validators"}, {Index: 3, IsIndex: true}, {Name: "pubkey"}}, p)
+		require.Equal(t, "validators[3].pubkey", p.String())
+	}
+	p, err := proof.ParsePath("block_roots[1][2]")
+	require.NoError(t, err)
+	require.Equal(t, 3, len(p))
+	p, err = proof.ParsePath("")
+	require.NoError(t, err)
+	require.Equal(t, 0, len(p))
+	_, err = proof.ParsePath("validators[x]")
+	require.ErrorContains(t, "invalid index", err)
+	_, err = proof.ParsePath("validators[1")
+	require.ErrorContains(t, "unclosed bracket", err)
+}
+
+func TestProve_State(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateElectra(t, 64)
+	require.NoError(t, st.UpdateBalancesAtIndex(5, 123))
+	require.NoError(t, st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: 3, Root: bytesutil.PadTo([]byte{'r'}, 32)}))
+	require.NoError(t, st.SetPendingDeposits([]*ethpb.PendingDeposit{{
+		PublicKey:             make([]byte, 48),
+		WithdrawalCredentials: make([]byte, 32),
+		Amount:                params.BeaconConfig().MinActivationBalance,
+		Signature:             make([]byte, 96),
+	}}))
+	root, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	obj := st.ToProtoUnsafe()
+	val, err := st.ValidatorAtIndexReadOnly(7)
+	require.NoError(t, err)
+	valRoot, err := st.ToProtoUnsafe().(*ethpb.BeaconStateElectra).Validators[7].HashTreeRoot()
+	require.NoError(t, err)
+	creds := val.GetWithdrawalCredentials()
+
+	tests := []struct {
+		path   string
+		gindex uint64
+		leaf   []byte
+	}{
+		{path: "", gindex: 1, leaf: root[:]},
+		{path: "slot", gindex: 64 + 2},
+		{path: "finalized_checkpoint.root", gindex: (64+20)<<1 | 1},
+		{path: "validators[7]", gindex: (64+11)*2<<40 | 7, leaf: valRoot[:]},
+		{path: "validators[7].withdrawal_credentials", gindex: ((64+11)*2<<40|7)<<3 | 1, leaf: creds},
+		{path: "validators.7.pubkey", gindex: ((64+11)*2<<40|7)<<3 | 0},
+		{path: "balances[5]", gindex: (64+12)*2<<38 | 1},
+		{path: "block_roots[9]", gindex: (64+5)<<13 | 9},
+		{path: "current_sync_committee.pubkeys[3]", gindex: ((64+22)<<1)<<9 | 3},
+		{path: "justification_bits"},
+		{path: "latest_execution_payload_header.block_hash"},
+		{path: "latest_execution_payload_header.extra_data"},
+		{path: "pending_deposits[0].amount"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := proof.ParsePath(tt.path)
+			require.NoError(t, err)
+			p, err := proof.Prove(obj, path)
+			require.NoError(t, err)
+			require.Equal(t, root, p.Root)
+			require.Equal(t, true, proof.Verify(root, p))
+			if tt.gindex != 0 {
+				require.Equal(t, tt.gindex, p.GeneralizedIndex)
+			}
+			if tt.leaf != nil {
+				require.DeepEqual(t, tt.leaf, p.Leaf[:])
+			}
+			tp, err := proof.ProveTree(context.Background(), obj, st, path)
+			require.NoError(t, err)
+			require.DeepEqual(t, p, tp)
+		})
+	}
+
+	t.Run("matches the native proofs", func(t *testing.T) {
+		p, err := proof.Prove(obj, proof.Path{{Name: "next_sync_committee"}})
+		require.NoError(t, err)
+		branch, err := st.NextSyncCommitteeProof(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, uint64(64+types.NextSyncCommittee.RealPosition()), p.GeneralizedIndex)
+		require.Equal(t, len(branch), len(p.Branch))
+		for i := range branch {
+			require.DeepEqual(t, branch[i], p.Branch[i][:])
+		}
+	})
+	t.Run("packed balance", func(t *testing.T) {
+		p, err := proof.Prove(obj, proof.Path{{Name: "balances"}, {Index: 5, IsIndex: true}})
+		require.NoError(t, err)
+		require.DeepEqual(t, uint64(123), bytesutil.FromBytes8(p.Leaf[8:16]))
+	})
+	t.Run("errors", func(t *testing.T) {
+		for path, msg := range map[string]string{
+			"foo":                                 "unknown field",
+			"validators[64]":                      "out of range",
+			"validators.pubkey":                   "in a list",
+			"slot.foo":                            "basic value",
+			"fork[0]":                             "in a container",
+			"previous_epoch_participation[0].foo": "basic value",
+		} {
+			p, err := proof.ParsePath(path)
+			require.NoError(t, err)
+			_, err = proof.Prove(obj, p)
+			require.ErrorContains(t, msg, err, path)
+			_, err = proof.ProveTree(context.Background(), obj, st, p)
+			require.ErrorContains(t, msg, err, path)
+		}
+	})
+}
+
+func TestProve_Block(t *testing.T) {
+	b := util.NewBeaconBlockElectra()
+	b.Block.Slot = 12
+	b.Block.Body.ExecutionPayload.StateRoot = bytesutil.PadTo([]byte{'s'}, 32)
+	b.Block.Body.ExecutionPayload.Transactions = [][]byte{{1, 2, 3}, {4}}
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	for _, s := range []string{
+		"body.execution_payload.state_root",
+		"body.execution_payload.transactions[1]",
+		"body.sync_aggregate.sync_committee_bits",
+		"body.attestations",
+		"body.execution_requests.deposits",
+	} {
+		path, err := proof.ParsePath(s)
+		require.NoError(t, err)
+		p, err := proof.Prove(b.Block, path)
+		require.NoError(t, err, s)
+		require.Equal(t, root, p.Root, s)
+		require.Equal(t, true, proof.Verify(root, p), s)
+	}
+	p, err := proof.Prove(b.Block, proof.Path{{Name: "body"}, {Name: "execution_payload"}, {Name: "state_root"}})
+	require.NoError(t, err)
+	require.DeepEqual(t, b.Block.Body.ExecutionPayload.StateRoot, p.Leaf[:])
+	p.Leaf[0] = 'x'
+	require.Equal(t, false, proof.Verify(root, p))
+}
@@ -0,0 +1,96 @@
+package httputil
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/prysmaticlabs/prysm/v5/api"
+	log "github.com/sirupsen/logrus"
+)
+
+// streamBufferSize is the amount of encoded items buffered before they are written to the response.
+const streamBufferSize = 64 * 1024
+
+// JsonListWriter writes a JSON response of the form {<fields>, "data": [<items>]} one item at a time,
+// so that large lists are encoded without holding the whole response in memory.
+// Nothing is written until the first item is, so errors found before that can still be reported with a status code.
+type JsonListWriter struct {
+	w       http.ResponseWriter
+	fields  []byte
+	buf     *bufio.Writer
+	count   int
+	started bool
+	err     error
+}
+
+// NewJsonListWriter returns a writer of a list response with the fields of v, which must encode to a JSON object.
+func NewJsonListWriter(w http.ResponseWriter, v any) (*JsonListWriter, error) {
+	fields, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) < 2 || fields[0] != '{' || fields[len(fields)-1] != '}' {
+		return nil, errors.New("response fields must encode to a JSON object")
+	}
+	return &JsonListWriter{w: w, fields: fields}, nil
+}
+
+func (l *JsonListWriter) start() {
+	if l.started {
+		return
+	}
+	l.started = true
+	l.w.Header().Set("Content-Type", api.JsonMediaType)
+	l.w.WriteHeader(http.StatusOK)
+	l.buf = bufio.NewWriterSize(l.w, streamBufferSize)
+	_, l.err = l.buf.Write(l.fields[:len(l.fields)-1])
+	if len(l.fields) > 2 {
+		l.err = l.buf.WriteByte(',')
+	}
+	_, l.err = l.buf.WriteString(`"data":[`)
+}
+
+// Write appends an item to the data list.
+func (l *JsonListWriter) Write(item any) error {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	l.start()
+	if l.err != nil {
+		return l.err
+	}
+	if l.count > 0 {
+		if l.err = l.buf.WriteByte(','); l.err != nil {
+			return l.err
+		}
+	}
+	l.count++
+	_, l.err = l.buf.Write(b)
+	return l.err
+}
+
+// Close ends the data list and the response object and flushes the response.
+func (l *JsonListWriter) Close() error {
+	l.start()
+	if l.err != nil {
+		return l.err
+	}
+	if _, l.err = l.buf.WriteString("]}\n"); l.err != nil {
+		return l.err
+	}
+	l.err = l.buf.Flush()
+	return l.err
+}
+
+// HandleError writes an error response when no item was written yet. Otherwise the status code was already sent,
+// so the error is logged and the response is left truncated, which clients see as invalid JSON.
+func (l *JsonListWriter) HandleError(message string, code int) {
+	if !l.started {
+		HandleError(l.w, message, code)
+		return
+	}
+	log.WithField("items", l.count).Error("Could not finish streaming response: " + message)
+}
@@ -0,0 +1,65 @@
+package httputil
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+type testListFields struct {
+	Finalized bool `json:"finalized"`
+}
+
+type testItem struct {
+	Index string `json:"index"`
+}
+
+func TestJsonListWriter(t *testing.T) {
+	t.Run("items", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		l, err := NewJsonListWriter(w, &testListFields{Finalized: true})
+		require.NoError(t, err)
+		require.NoError(t, l.Write(&testItem{Index: "1"}))
+		require.NoError(t, l.Write(&testItem{Index: "2"}))
+		require.NoError(t, l.Close())
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, api.JsonMediaType, w.Header().Get("Content-Type"))
+		assert.Equal(t, "{\"finalized\":true,\"data\":[{\"index\":\"1\"},{\"index\":\"2\"}]}\n", w.Body.String())
+	})
+	t.Run("no items", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		l, err := NewJsonListWriter(w, struct{}{})
+		require.NoError(t, err)
+		require.NoError(t, l.Close())
+		resp := make(map[string][]testItem)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, 0, len(resp["data"]))
+	})
+	t.Run("error before items", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		l, err := NewJsonListWriter(w, &testListFields{})
+		require.NoError(t, err)
+		l.HandleError("foo", http.StatusInternalServerError)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		e := &DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), e))
+		assert.Equal(t, "foo", e.Message)
+	})
+	t.Run("error after items", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		l, err := NewJsonListWriter(w, &testListFields{})
+		require.NoError(t, err)
+		require.NoError(t, l.Write(&testItem{Index: "1"}))
+		l.HandleError("foo", http.StatusInternalServerError)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+	t.Run("fields not an object", func(t *testing.T) {
+		_, err := NewJsonListWriter(httptest.NewRecorder(), []string{})
+		assert.ErrorContains(t, "JSON object", err)
+	})
+}
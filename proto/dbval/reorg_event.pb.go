@@ -0,0 +1,515 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.25.1
+// source: proto/dbval/reorg_event.proto
+
+package dbval
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReorgEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind                 uint64                 `protobuf:"varint,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Slot                 uint64                 `protobuf:"varint,2,opt,name=slot,proto3" json:"slot,omitempty"`
+	Timestamp            uint64                 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Reason               string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	OldHeadRoot          []byte                 `protobuf:"bytes,5,opt,name=old_head_root,json=oldHeadRoot,proto3" json:"old_head_root,omitempty"`
+	NewHeadRoot          []byte                 `protobuf:"bytes,6,opt,name=new_head_root,json=newHeadRoot,proto3" json:"new_head_root,omitempty"`
+	CommonAncestorRoot   []byte                 `protobuf:"bytes,7,opt,name=common_ancestor_root,json=commonAncestorRoot,proto3" json:"common_ancestor_root,omitempty"`
+	CommonAncestorSlot   uint64                 `protobuf:"varint,8,opt,name=common_ancestor_slot,json=commonAncestorSlot,proto3" json:"common_ancestor_slot,omitempty"`
+	Depth                uint64                 `protobuf:"varint,9,opt,name=depth,proto3" json:"depth,omitempty"`
+	OldBranch            []*ReorgBlock          `protobuf:"bytes,10,rep,name=old_branch,json=oldBranch,proto3" json:"old_branch,omitempty"`
+	NewBranch            []*ReorgBlock          `protobuf:"bytes,11,rep,name=new_branch,json=newBranch,proto3" json:"new_branch,omitempty"`
+	OrphanedAttestations []*OrphanedAttestation `protobuf:"bytes,12,rep,name=orphaned_attestations,json=orphanedAttestations,proto3" json:"orphaned_attestations,omitempty"`
+	Truncated            bool                   `protobuf:"varint,13,opt,name=truncated,proto3" json:"truncated,omitempty"`
+}
+
+func (x *ReorgEvent) Reset() {
+	*x = ReorgEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dbval_reorg_event_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReorgEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorgEvent) ProtoMessage() {}
+
+func (x *ReorgEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dbval_reorg_event_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorgEvent.ProtoReflect.Descriptor instead.
+func (*ReorgEvent) Descriptor() ([]byte, []int) {
+	return file_proto_dbval_reorg_event_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReorgEvent) GetKind() uint64 {
+	if x != nil {
+		return x.Kind
+	}
+	return 0
+}
+
+func (x *ReorgEvent) GetSlot() uint64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *ReorgEvent) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ReorgEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReorgEvent) GetOldHeadRoot() []byte {
+	if x != nil {
+		return x.OldHeadRoot
+	}
+	return nil
+}
+
+func (x *ReorgEvent) GetNewHeadRoot() []byte {
+	if x != nil {
+		return x.NewHeadRoot
+	}
+	return nil
+}
+
+func (x *ReorgEvent) GetCommonAncestorRoot() []byte {
+	if x != nil {
+		return x.CommonAncestorRoot
+	}
+	return nil
+}
+
+func (x *ReorgEvent) GetCommonAncestorSlot() uint64 {
+	if x != nil {
+		return x.CommonAncestorSlot
+	}
+	return 0
+}
+
+func (x *ReorgEvent) GetDepth() uint64 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *ReorgEvent) GetOldBranch() []*ReorgBlock {
+	if x != nil {
+		return x.OldBranch
+	}
+	return nil
+}
+
+func (x *ReorgEvent) GetNewBranch() []*ReorgBlock {
+	if x != nil {
+		return x.NewBranch
+	}
+	return nil
+}
+
+func (x *ReorgEvent) GetOrphanedAttestations() []*OrphanedAttestation {
+	if x != nil {
+		return x.OrphanedAttestations
+	}
+	return nil
+}
+
+func (x *ReorgEvent) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type ReorgBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Root          []byte `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Slot          uint64 `protobuf:"varint,2,opt,name=slot,proto3" json:"slot,omitempty"`
+	ProposerIndex uint64 `protobuf:"varint,3,opt,name=proposer_index,json=proposerIndex,proto3" json:"proposer_index,omitempty"`
+	Weight        uint64 `protobuf:"varint,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	ArrivalMillis uint64 `protobuf:"varint,5,opt,name=arrival_millis,json=arrivalMillis,proto3" json:"arrival_millis,omitempty"`
+	Timely        bool   `protobuf:"varint,6,opt,name=timely,proto3" json:"timely,omitempty"`
+	ProposerBoost bool   `protobuf:"varint,7,opt,name=proposer_boost,json=proposerBoost,proto3" json:"proposer_boost,omitempty"`
+}
+
+func (x *ReorgBlock) Reset() {
+	*x = ReorgBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dbval_reorg_event_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReorgBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorgBlock) ProtoMessage() {}
+
+func (x *ReorgBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dbval_reorg_event_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorgBlock.ProtoReflect.Descriptor instead.
+func (*ReorgBlock) Descriptor() ([]byte, []int) {
+	return file_proto_dbval_reorg_event_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReorgBlock) GetRoot() []byte {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+func (x *ReorgBlock) GetSlot() uint64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *ReorgBlock) GetProposerIndex() uint64 {
+	if x != nil {
+		return x.ProposerIndex
+	}
+	return 0
+}
+
+func (x *ReorgBlock) GetWeight() uint64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *ReorgBlock) GetArrivalMillis() uint64 {
+	if x != nil {
+		return x.ArrivalMillis
+	}
+	return 0
+}
+
+func (x *ReorgBlock) GetTimely() bool {
+	if x != nil {
+		return x.Timely
+	}
+	return false
+}
+
+func (x *ReorgBlock) GetProposerBoost() bool {
+	if x != nil {
+		return x.ProposerBoost
+	}
+	return false
+}
+
+type OrphanedAttestation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockRoot        []byte   `protobuf:"bytes,1,opt,name=block_root,json=blockRoot,proto3" json:"block_root,omitempty"`
+	Slot             uint64   `protobuf:"varint,2,opt,name=slot,proto3" json:"slot,omitempty"`
+	CommitteeIndex   uint64   `protobuf:"varint,3,opt,name=committee_index,json=committeeIndex,proto3" json:"committee_index,omitempty"`
+	BeaconBlockRoot  []byte   `protobuf:"bytes,4,opt,name=beacon_block_root,json=beaconBlockRoot,proto3" json:"beacon_block_root,omitempty"`
+	Attesters        uint64   `protobuf:"varint,5,opt,name=attesters,proto3" json:"attesters,omitempty"`
+	AttestingIndices []uint64 `protobuf:"varint,6,rep,packed,name=attesting_indices,json=attestingIndices,proto3" json:"attesting_indices,omitempty"`
+}
+
+func (x *OrphanedAttestation) Reset() {
+	*x = OrphanedAttestation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dbval_reorg_event_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrphanedAttestation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrphanedAttestation) ProtoMessage() {}
+
+func (x *OrphanedAttestation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dbval_reorg_event_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrphanedAttestation.ProtoReflect.Descriptor instead.
+func (*OrphanedAttestation) Descriptor() ([]byte, []int) {
+	return file_proto_dbval_reorg_event_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OrphanedAttestation) GetBlockRoot() []byte {
+	if x != nil {
+		return x.BlockRoot
+	}
+	return nil
+}
+
+func (x *OrphanedAttestation) GetSlot() uint64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *OrphanedAttestation) GetCommitteeIndex() uint64 {
+	if x != nil {
+		return x.CommitteeIndex
+	}
+	return 0
+}
+
+func (x *OrphanedAttestation) GetBeaconBlockRoot() []byte {
+	if x != nil {
+		return x.BeaconBlockRoot
+	}
+	return nil
+}
+
+func (x *OrphanedAttestation) GetAttesters() uint64 {
+	if x != nil {
+		return x.Attesters
+	}
+	return 0
+}
+
+func (x *OrphanedAttestation) GetAttestingIndices() []uint64 {
+	if x != nil {
+		return x.AttestingIndices
+	}
+	return nil
+}
+
+var File_proto_dbval_reorg_event_proto protoreflect.FileDescriptor
+
+var file_proto_dbval_reorg_event_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x62, 0x76, 0x61, 0x6c, 0x2f, 0x72, 0x65,
+	0x6f, 0x72, 0x67, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x12, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x65, 0x74, 0x68, 0x2e, 0x64, 0x62,
+	0x76, 0x61, 0x6c, 0x22, 0xa6, 0x04, 0x0a, 0x0a, 0x52, 0x65, 0x6f, 0x72, 0x67, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x6c, 0x64, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x5f, 0x72, 0x6f, 0x6f,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x6f, 0x6c, 0x64, 0x48, 0x65, 0x61, 0x64,
+	0x52, 0x6f, 0x6f, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x6e, 0x65, 0x77, 0x5f, 0x68, 0x65, 0x61, 0x64,
+	0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x6e, 0x65, 0x77,
+	0x48, 0x65, 0x61, 0x64, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x5f, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x5f, 0x72, 0x6f, 0x6f, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x12, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x41, 0x6e,
+	0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x5f, 0x73, 0x6c,
+	0x6f, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x41, 0x6e, 0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x64, 0x65, 0x70,
+	0x74, 0x68, 0x12, 0x3d, 0x0a, 0x0a, 0x6f, 0x6c, 0x64, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68,
+	0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75,
+	0x6d, 0x2e, 0x65, 0x74, 0x68, 0x2e, 0x64, 0x62, 0x76, 0x61, 0x6c, 0x2e, 0x52, 0x65, 0x6f, 0x72,
+	0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x09, 0x6f, 0x6c, 0x64, 0x42, 0x72, 0x61, 0x6e, 0x63,
+	0x68, 0x12, 0x3d, 0x0a, 0x0a, 0x6e, 0x65, 0x77, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18,
+	0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d,
+	0x2e, 0x65, 0x74, 0x68, 0x2e, 0x64, 0x62, 0x76, 0x61, 0x6c, 0x2e, 0x52, 0x65, 0x6f, 0x72, 0x67,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x09, 0x6e, 0x65, 0x77, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68,
+	0x12, 0x5c, 0x0a, 0x15, 0x6f, 0x72, 0x70, 0x68, 0x61, 0x6e, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x27, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x65, 0x74, 0x68, 0x2e, 0x64,
+	0x62, 0x76, 0x61, 0x6c, 0x2e, 0x4f, 0x72, 0x70, 0x68, 0x61, 0x6e, 0x65, 0x64, 0x41, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x14, 0x6f, 0x72, 0x70, 0x68, 0x61, 0x6e,
+	0x65, 0x64, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x22, 0xd9, 0x01, 0x0a,
+	0x0a, 0x52, 0x65, 0x6f, 0x72, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x6f, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73,
+	0x6c, 0x6f, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x70, 0x72, 0x6f,
+	0x70, 0x6f, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x72, 0x72, 0x69, 0x76, 0x61, 0x6c, 0x5f, 0x6d, 0x69,
+	0x6c, 0x6c, 0x69, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x61, 0x72, 0x72, 0x69,
+	0x76, 0x61, 0x6c, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x6d,
+	0x65, 0x6c, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x74, 0x69, 0x6d, 0x65, 0x6c,
+	0x79, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x5f, 0x62, 0x6f,
+	0x6f, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x70, 0x6f,
+	0x73, 0x65, 0x72, 0x42, 0x6f, 0x6f, 0x73, 0x74, 0x22, 0xe8, 0x01, 0x0a, 0x13, 0x4f, 0x72, 0x70,
+	0x68, 0x61, 0x6e, 0x65, 0x64, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x6f, 0x6f, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73,
+	0x6c, 0x6f, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x65,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2a, 0x0a, 0x11,
+	0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x72, 0x6f, 0x6f,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x74, 0x74, 0x65,
+	0x73, 0x74, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x61, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x65, 0x72, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74,
+	0x69, 0x6e, 0x67, 0x5f, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x04, 0x52, 0x10, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x64, 0x69,
+	0x63, 0x65, 0x73, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x70, 0x72, 0x79, 0x73, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x6c, 0x61, 0x62, 0x73, 0x2f,
+	0x70, 0x72, 0x79, 0x73, 0x6d, 0x2f, 0x76, 0x35, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64,
+	0x62, 0x76, 0x61, 0x6c, 0x3b, 0x64, 0x62, 0x76, 0x61, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_proto_dbval_reorg_event_proto_rawDescOnce sync.Once
+	file_proto_dbval_reorg_event_proto_rawDescData = file_proto_dbval_reorg_event_proto_rawDesc
+)
+
+func file_proto_dbval_reorg_event_proto_rawDescGZIP() []byte {
+	file_proto_dbval_reorg_event_proto_rawDescOnce.Do(func() {
+		file_proto_dbval_reorg_event_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_dbval_reorg_event_proto_rawDescData)
+	})
+	return file_proto_dbval_reorg_event_proto_rawDescData
+}
+
+var file_proto_dbval_reorg_event_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_dbval_reorg_event_proto_goTypes = []interface{}{
+	(*ReorgEvent)(nil),          // 0: ethereum.eth.dbval.ReorgEvent
+	(*ReorgBlock)(nil),          // 1: ethereum.eth.dbval.ReorgBlock
+	(*OrphanedAttestation)(nil), // 2: ethereum.eth.dbval.OrphanedAttestation
+}
+var file_proto_dbval_reorg_event_proto_depIdxs = []int32{
+	1, // 0: ethereum.eth.dbval.ReorgEvent.old_branch:type_name -> ethereum.eth.dbval.ReorgBlock
+	1, // 1: ethereum.eth.dbval.ReorgEvent.new_branch:type_name -> ethereum.eth.dbval.ReorgBlock
+	2, // 2: ethereum.eth.dbval.ReorgEvent.orphaned_attestations:type_name -> ethereum.eth.dbval.OrphanedAttestation
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_proto_dbval_reorg_event_proto_init() }
+func file_proto_dbval_reorg_event_proto_init() {
+	if File_proto_dbval_reorg_event_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_dbval_reorg_event_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReorgEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dbval_reorg_event_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReorgBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dbval_reorg_event_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrphanedAttestation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_dbval_reorg_event_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_dbval_reorg_event_proto_goTypes,
+		DependencyIndexes: file_proto_dbval_reorg_event_proto_depIdxs,
+		MessageInfos:      file_proto_dbval_reorg_event_proto_msgTypes,
+	}.Build()
+	File_proto_dbval_reorg_event_proto = out.File
+	file_proto_dbval_reorg_event_proto_rawDesc = nil
+	file_proto_dbval_reorg_event_proto_goTypes = nil
+	file_proto_dbval_reorg_event_proto_depIdxs = nil
+}
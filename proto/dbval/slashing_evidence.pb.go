@@ -0,0 +1,220 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.25.1
+// source: proto/dbval/slashing_evidence.proto
+
+package dbval
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SlashingEvidence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValidatorIndices []uint64 `protobuf:"varint,1,rep,packed,name=validator_indices,json=validatorIndices,proto3" json:"validator_indices,omitempty"`
+	Source           uint32   `protobuf:"varint,2,opt,name=source,proto3" json:"source,omitempty"`
+	Timestamp        uint64   `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Included         bool     `protobuf:"varint,4,opt,name=included,proto3" json:"included,omitempty"`
+	InclusionSlot    uint64   `protobuf:"varint,5,opt,name=inclusion_slot,json=inclusionSlot,proto3" json:"inclusion_slot,omitempty"`
+	Version          uint32   `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	AttesterSlashing []byte   `protobuf:"bytes,7,opt,name=attester_slashing,json=attesterSlashing,proto3" json:"attester_slashing,omitempty"`
+	ProposerSlashing []byte   `protobuf:"bytes,8,opt,name=proposer_slashing,json=proposerSlashing,proto3" json:"proposer_slashing,omitempty"`
+}
+
+func (x *SlashingEvidence) Reset() {
+	*x = SlashingEvidence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dbval_slashing_evidence_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SlashingEvidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SlashingEvidence) ProtoMessage() {}
+
+func (x *SlashingEvidence) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dbval_slashing_evidence_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SlashingEvidence.ProtoReflect.Descriptor instead.
+func (*SlashingEvidence) Descriptor() ([]byte, []int) {
+	return file_proto_dbval_slashing_evidence_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SlashingEvidence) GetValidatorIndices() []uint64 {
+	if x != nil {
+		return x.ValidatorIndices
+	}
+	return nil
+}
+
+func (x *SlashingEvidence) GetSource() uint32 {
+	if x != nil {
+		return x.Source
+	}
+	return 0
+}
+
+func (x *SlashingEvidence) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *SlashingEvidence) GetIncluded() bool {
+	if x != nil {
+		return x.Included
+	}
+	return false
+}
+
+func (x *SlashingEvidence) GetInclusionSlot() uint64 {
+	if x != nil {
+		return x.InclusionSlot
+	}
+	return 0
+}
+
+func (x *SlashingEvidence) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SlashingEvidence) GetAttesterSlashing() []byte {
+	if x != nil {
+		return x.AttesterSlashing
+	}
+	return nil
+}
+
+func (x *SlashingEvidence) GetProposerSlashing() []byte {
+	if x != nil {
+		return x.ProposerSlashing
+	}
+	return nil
+}
+
+var File_proto_dbval_slashing_evidence_proto protoreflect.FileDescriptor
+
+var file_proto_dbval_slashing_evidence_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x62, 0x76, 0x61, 0x6c, 0x2f, 0x73, 0x6c,
+	0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x5f, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e,
+	0x65, 0x74, 0x68, 0x2e, 0x64, 0x62, 0x76, 0x61, 0x6c, 0x22, 0xac, 0x02, 0x0a, 0x10, 0x53, 0x6c,
+	0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x2b,
+	0x0a, 0x11, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x6e, 0x64, 0x69,
+	0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x10, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x12, 0x25, 0x0a,
+	0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x6c, 0x6f, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e,
+	0x53, 0x6c, 0x6f, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2b,
+	0x0a, 0x11, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x6c, 0x61, 0x73, 0x68,
+	0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x61, 0x74, 0x74, 0x65, 0x73,
+	0x74, 0x65, 0x72, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x12, 0x2b, 0x0a, 0x11, 0x70,
+	0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x5f, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72,
+	0x53, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x72, 0x79, 0x73, 0x6d, 0x61, 0x74, 0x69, 0x63,
+	0x6c, 0x61, 0x62, 0x73, 0x2f, 0x70, 0x72, 0x79, 0x73, 0x6d, 0x2f, 0x76, 0x35, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x62, 0x76, 0x61, 0x6c, 0x3b, 0x64, 0x62, 0x76, 0x61, 0x6c, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_dbval_slashing_evidence_proto_rawDescOnce sync.Once
+	file_proto_dbval_slashing_evidence_proto_rawDescData = file_proto_dbval_slashing_evidence_proto_rawDesc
+)
+
+func file_proto_dbval_slashing_evidence_proto_rawDescGZIP() []byte {
+	file_proto_dbval_slashing_evidence_proto_rawDescOnce.Do(func() {
+		file_proto_dbval_slashing_evidence_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_dbval_slashing_evidence_proto_rawDescData)
+	})
+	return file_proto_dbval_slashing_evidence_proto_rawDescData
+}
+
+var file_proto_dbval_slashing_evidence_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_proto_dbval_slashing_evidence_proto_goTypes = []interface{}{
+	(*SlashingEvidence)(nil), // 0: ethereum.eth.dbval.SlashingEvidence
+}
+var file_proto_dbval_slashing_evidence_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_proto_dbval_slashing_evidence_proto_init() }
+func file_proto_dbval_slashing_evidence_proto_init() {
+	if File_proto_dbval_slashing_evidence_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_dbval_slashing_evidence_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SlashingEvidence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_dbval_slashing_evidence_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_dbval_slashing_evidence_proto_goTypes,
+		DependencyIndexes: file_proto_dbval_slashing_evidence_proto_depIdxs,
+		MessageInfos:      file_proto_dbval_slashing_evidence_proto_msgTypes,
+	}.Build()
+	File_proto_dbval_slashing_evidence_proto = out.File
+	file_proto_dbval_slashing_evidence_proto_rawDesc = nil
+	file_proto_dbval_slashing_evidence_proto_goTypes = nil
+	file_proto_dbval_slashing_evidence_proto_depIdxs = nil
+}